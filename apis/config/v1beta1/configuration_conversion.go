@@ -59,6 +59,10 @@ func Convert_v1beta1_Integrations_To_v1beta2_Integrations(in *Integrations, out
 	return autoConvert_v1beta1_Integrations_To_v1beta2_Integrations(in, out, s)
 }
 
+func Convert_v1beta2_Integrations_To_v1beta1_Integrations(in *v1beta2.Integrations, out *Integrations, s conversionapi.Scope) error {
+	return autoConvert_v1beta2_Integrations_To_v1beta1_Integrations(in, out, s)
+}
+
 func Convert_v1beta1_FairSharing_To_v1beta2_FairSharing(in *FairSharing, out *v1beta2.FairSharing, s conversionapi.Scope) error {
 	if in != nil && in.Enable && len(in.PreemptionStrategies) == 0 {
 		in.PreemptionStrategies = []PreemptionStrategy{LessThanOrEqualToFinalShare, LessThanInitialShare}
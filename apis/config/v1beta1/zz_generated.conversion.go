@@ -120,11 +120,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.Integrations)(nil), (*Integrations)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_Integrations_To_v1beta1_Integrations(a.(*v1beta2.Integrations), b.(*Integrations), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*InternalCertManagement)(nil), (*v1beta2.InternalCertManagement)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_InternalCertManagement_To_v1beta2_InternalCertManagement(a.(*InternalCertManagement), b.(*v1beta2.InternalCertManagement), scope)
 	}); err != nil {
@@ -255,6 +250,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.Integrations)(nil), (*Integrations)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_Integrations_To_v1beta1_Integrations(a.(*v1beta2.Integrations), b.(*Integrations), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.MultiKueue)(nil), (*MultiKueue)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_MultiKueue_To_v1beta1_MultiKueue(a.(*v1beta2.MultiKueue), b.(*MultiKueue), scope)
 	}); err != nil {
@@ -438,6 +438,9 @@ func autoConvert_v1beta2_Configuration_To_v1beta1_Configuration(in *v1beta2.Conf
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	out.ObjectRetentionPolicies = (*ObjectRetentionPolicies)(unsafe.Pointer(in.ObjectRetentionPolicies))
 	// WARNING: in.VisibilityServer requires manual conversion: does not exist in peer-type
+	// WARNING: in.Scheduler requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultWorkloadPriorityClass requires manual conversion: does not exist in peer-type
+	// WARNING: in.EvictionHooks requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -648,6 +651,7 @@ func autoConvert_v1beta1_FairSharing_To_v1beta2_FairSharing(in *FairSharing, out
 
 func autoConvert_v1beta2_FairSharing_To_v1beta1_FairSharing(in *v1beta2.FairSharing, out *FairSharing, s conversion.Scope) error {
 	out.PreemptionStrategies = *(*[]PreemptionStrategy)(unsafe.Pointer(&in.PreemptionStrategies))
+	// WARNING: in.PreemptionThreshold requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -663,14 +667,10 @@ func autoConvert_v1beta2_Integrations_To_v1beta1_Integrations(in *v1beta2.Integr
 	out.Frameworks = *(*[]string)(unsafe.Pointer(&in.Frameworks))
 	out.ExternalFrameworks = *(*[]string)(unsafe.Pointer(&in.ExternalFrameworks))
 	out.LabelKeysToCopy = *(*[]string)(unsafe.Pointer(&in.LabelKeysToCopy))
+	// WARNING: in.PodLabelPropagation requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_Integrations_To_v1beta1_Integrations is an autogenerated conversion function.
-func Convert_v1beta2_Integrations_To_v1beta1_Integrations(in *v1beta2.Integrations, out *Integrations, s conversion.Scope) error {
-	return autoConvert_v1beta2_Integrations_To_v1beta1_Integrations(in, out, s)
-}
-
 func autoConvert_v1beta1_InternalCertManagement_To_v1beta2_InternalCertManagement(in *InternalCertManagement, out *v1beta2.InternalCertManagement, s conversion.Scope) error {
 	out.Enable = (*bool)(unsafe.Pointer(in.Enable))
 	out.WebhookServiceName = (*string)(unsafe.Pointer(in.WebhookServiceName))
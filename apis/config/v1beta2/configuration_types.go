@@ -112,6 +112,23 @@ type Configuration struct {
 	// VisibilityServer configures the visibility server.
 	// +optional
 	VisibilityServer *VisibilityServerConfiguration `json:"visibilityServer,omitempty"`
+
+	// Scheduler configures the scheduling loop.
+	// +optional
+	Scheduler *SchedulerConfiguration `json:"scheduler,omitempty"`
+
+	// DefaultWorkloadPriorityClass is the name of the WorkloadPriorityClass
+	// assigned to the Workloads of jobs which don't specify a priority, neither
+	// via the kueue.x-k8s.io/priority-class label nor via the priorityClassName
+	// of their pods.
+	// The WorkloadPriorityClass isn't assigned if it doesn't exist.
+	// +optional
+	DefaultWorkloadPriorityClass *string `json:"defaultWorkloadPriorityClass,omitempty"`
+
+	// EvictionHooks configures the eviction hooks of the Workloads.
+	// Only takes effect when the WorkloadEvictionHooks feature gate is enabled.
+	// +optional
+	EvictionHooks *EvictionHooks `json:"evictionHooks,omitempty"`
 }
 
 type ControllerManager struct {
@@ -569,6 +586,25 @@ type Integrations struct {
 	// during the workload creation and are not updated even if the labels of the
 	// underlying job are changed.
 	LabelKeysToCopy []string `json:"labelKeysToCopy,omitempty"`
+
+	// podLabelPropagation configures the labels of the Workload and of its
+	// LocalQueue which are propagated to the pod templates of the job when it
+	// is admitted, for example to attribute the cost of the pods.
+	// +optional
+	PodLabelPropagation *PodLabelPropagation `json:"podLabelPropagation,omitempty"`
+}
+
+type PodLabelPropagation struct {
+	// workloadLabelKeys is a list of label keys copied from the Workload to the
+	// pod templates of the admitted job.
+	// +optional
+	WorkloadLabelKeys []string `json:"workloadLabelKeys,omitempty"`
+
+	// localQueueLabelKeys is a list of label keys copied from the LocalQueue of
+	// the Workload to the pod templates of the admitted job. A label of the
+	// Workload takes precedence over the label of the LocalQueue with the same key.
+	// +optional
+	LocalQueueLabelKeys []string `json:"localQueueLabelKeys,omitempty"`
 }
 
 // QuotaCheckStrategy determines how Kueue checks resources against quota
@@ -722,6 +758,17 @@ type FairSharing struct {
 	//   As a result, the strategy chooses to preempt workloads with the lowest priority and
 	//   newest start time first.
 	PreemptionStrategies []PreemptionStrategy `json:"preemptionStrategies"`
+
+	// preemptionThreshold is the minimum difference between the share of the
+	// target ClusterQueue and the share of the preemptor ClusterQueue, with the
+	// incoming workload, required to preempt a workload based on the
+	// preemptionStrategies. The shares are expressed in the same unit as the
+	// weightedShare of the ClusterQueue status.
+	// Imbalances within the threshold don't cause preemptions.
+	// Defaults to 0, meaning that any imbalance allowed by the
+	// preemptionStrategies causes preemptions.
+	// +optional
+	PreemptionThreshold *int32 `json:"preemptionThreshold,omitempty"`
 }
 
 type AdmissionFairSharing struct {
@@ -781,3 +828,24 @@ type VisibilityServerConfiguration struct {
 	// +optional
 	BindPort *int32 `json:"bindPort,omitempty"`
 }
+
+type SchedulerConfiguration struct {
+	// Workers is the number of cohorts that the scheduler processes concurrently
+	// in a scheduling cycle. Workloads in different cohorts, or in ClusterQueues
+	// that don't belong to a cohort, don't share quota and can be admitted in
+	// parallel; the workloads of a cohort are always processed in order by a
+	// single worker.
+	// Only takes effect when the ConcurrentCohortScheduling feature gate is enabled.
+	// Defaults to 1.
+	// +optional
+	Workers *int32 `json:"workers,omitempty"`
+}
+
+type EvictionHooks struct {
+	// MaxTimeout is the maximum duration Kueue waits for the eviction hooks
+	// of a Workload before stopping its job. The Workloads with a longer
+	// kueue.x-k8s.io/eviction-hooks-timeout annotation are rejected.
+	// Defaults to 1h.
+	// +optional
+	MaxTimeout *metav1.Duration `json:"maxTimeout,omitempty"`
+}
@@ -194,6 +194,21 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(VisibilityServerConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(SchedulerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultWorkloadPriorityClass != nil {
+		in, out := &in.DefaultWorkloadPriorityClass, &out.DefaultWorkloadPriorityClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.EvictionHooks != nil {
+		in, out := &in.EvictionHooks, &out.EvictionHooks
+		*out = new(EvictionHooks)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
@@ -424,6 +439,26 @@ func (in *DeviceClassSourceConfig) DeepCopy() *DeviceClassSourceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionHooks) DeepCopyInto(out *EvictionHooks) {
+	*out = *in
+	if in.MaxTimeout != nil {
+		in, out := &in.MaxTimeout, &out.MaxTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionHooks.
+func (in *EvictionHooks) DeepCopy() *EvictionHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 	*out = *in
@@ -432,6 +467,11 @@ func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 		*out = make([]PreemptionStrategy, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreemptionThreshold != nil {
+		in, out := &in.PreemptionThreshold, &out.PreemptionThreshold
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairSharing.
@@ -482,6 +522,11 @@ func (in *Integrations) DeepCopyInto(out *Integrations) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodLabelPropagation != nil {
+		in, out := &in.PodLabelPropagation, &out.PodLabelPropagation
+		*out = new(PodLabelPropagation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Integrations.
@@ -629,6 +674,31 @@ func (in *ObjectRetentionPolicies) DeepCopy() *ObjectRetentionPolicies {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodLabelPropagation) DeepCopyInto(out *PodLabelPropagation) {
+	*out = *in
+	if in.WorkloadLabelKeys != nil {
+		in, out := &in.WorkloadLabelKeys, &out.WorkloadLabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalQueueLabelKeys != nil {
+		in, out := &in.LocalQueueLabelKeys, &out.LocalQueueLabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLabelPropagation.
+func (in *PodLabelPropagation) DeepCopy() *PodLabelPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(PodLabelPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequeuingStrategy) DeepCopyInto(out *RequeuingStrategy) {
 	*out = *in
@@ -730,6 +800,26 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerConfiguration) DeepCopyInto(out *SchedulerConfiguration) {
+	*out = *in
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerConfiguration.
+func (in *SchedulerConfiguration) DeepCopy() *SchedulerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSOptions) DeepCopyInto(out *TLSOptions) {
 	*out = *in
@@ -73,6 +73,7 @@ type AdmissionScope struct {
 	// in the AdmissionScope. Possible values are:
 	// - UsageBasedAdmissionFairSharing
 	// - NoAdmissionFairSharing
+	// - WeightedRoundRobinAdmissionFairSharing
 	//
 	// +required
 	AdmissionMode AdmissionMode `json:"admissionMode"`
@@ -86,4 +87,8 @@ const (
 
 	// AdmissionFairSharing is disabled for this CQ
 	NoAdmissionFairSharing AdmissionMode = "NoAdmissionFairSharing"
+
+	// Admissions are shared among the LocalQueues of the CQ in proportion to
+	// their fairSharing weights, with QueuingStrategy as defined in CQ.
+	WeightedRoundRobinAdmissionFairSharing AdmissionMode = "WeightedRoundRobinAdmissionFairSharing"
 )
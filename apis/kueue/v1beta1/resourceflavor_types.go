@@ -47,6 +47,7 @@ type TopologyReference string
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.topologyName) || (has(self.nodeLabels) == has(oldSelf.nodeLabels) && (!has(self.nodeLabels) || self.nodeLabels == oldSelf.nodeLabels))", message="nodeLabels are immutable when topologyName is set"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.topologyName) || (has(self.tolerations) == has(oldSelf.tolerations) && (!has(self.tolerations) || self.tolerations == oldSelf.tolerations))", message="tolerations are immutable when topologyName is set"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.topologyName) || (has(self.topologyName) && self.topologyName == oldSelf.topologyName)", message="topologyName is immutable when topologyName is set"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.topologyName) || (has(self.minNodeReadySeconds) == has(oldSelf.minNodeReadySeconds) && (!has(self.minNodeReadySeconds) || self.minNodeReadySeconds == oldSelf.minNodeReadySeconds))", message="minNodeReadySeconds is immutable when topologyName is set"
 type ResourceFlavorSpec struct {
 	// nodeLabels are labels that associate the ResourceFlavor with Nodes that
 	// have the same labels.
@@ -107,6 +108,71 @@ type ResourceFlavorSpec struct {
 	//
 	// +optional
 	TopologyName *TopologyReference `json:"topologyName,omitempty"`
+
+	// minNodeReadySeconds is the minimum number of seconds a Node of the TAS
+	// ResourceFlavor needs to be Ready before it contributes capacity to the
+	// topology domains. Nodes which are not Ready, or are unschedulable, never
+	// contribute capacity. When not set, a Node contributes capacity as soon
+	// as it is Ready.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// TASNodeReadinessGating feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinNodeReadySeconds *int32 `json:"minNodeReadySeconds,omitempty"`
+
+	// namespaceSelector restricts the namespaces whose Workloads can be assigned
+	// this ResourceFlavor, so that the ClusterQueues shared by several tenants
+	// only assign the flavor to the Workloads of the intended namespaces.
+	// Defaults to null, which means that the Workloads of all the namespaces
+	// can be assigned the flavor.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ResourceFlavorNamespaceSelector feature gate.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// resourceScaling maps the names of resources to the number of units of
+	// the resource advertised by the nodes for each unit of quota in this
+	// ResourceFlavor, so that the quota reflects the physical hardware.
+	// For example, when the nodes advertise 4 nvidia.com/gpu replicas per
+	// physical GPU with time-slicing, setting nvidia.com/gpu to 4 makes 4
+	// requested GPUs count as 1 unit of quota. The usage is rounded up.
+	// resourceScaling can't be set when topologyName is set.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ResourceFlavorResourceScaling feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxProperties=16
+	ResourceScaling map[corev1.ResourceName]int32 `json:"resourceScaling,omitempty"`
+
+	// stopPolicy - if set to a value different from None, no new reservation
+	// is made in this ResourceFlavor, while the ClusterQueues using it remain
+	// active and can keep admitting workloads in their other flavors.
+	//
+	// Depending on its value, the workloads assigned to this flavor will:
+	//
+	// - None - Workloads are admitted
+	// - HoldAndDrain - Admitted workloads are evicted and Reserving workloads will cancel the reservation.
+	// - Hold - Admitted workloads will run to completion and Reserving workloads will cancel the reservation.
+	//
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ResourceFlavorStopPolicy feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=None;Hold;HoldAndDrain
+	StopPolicy *StopPolicy `json:"stopPolicy,omitempty"`
+
+	// nodeShape is the allocatable resources of a single node of this
+	// ResourceFlavor. It is used to resolve the requests of the pods which
+	// request a share of a node with the kueue.x-k8s.io/node-share annotation,
+	// for example "100%" to request a whole node.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// NodeShareRequests feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxProperties=16
+	NodeShape corev1.ResourceList `json:"nodeShape,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -119,6 +119,41 @@ type TopologyLevel struct {
 	// +kubebuilder:validation:MaxLength=316
 	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
 	NodeLabel string `json:"nodeLabel"`
+
+	// valueFrom derives the value of the level from a composite node label,
+	// such as "topology=block1-rack3", rather than from the node label named
+	// by nodeLabel. In that case nodeLabel only names the level, and the nodes
+	// don't need to have it. The lowest level of the topology must be
+	// kubernetes.io/hostname when a level is derived.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// TASDerivedTopologyLevels feature gate.
+	//
+	// +optional
+	ValueFrom *TopologyLevelValueSource `json:"valueFrom,omitempty"`
+}
+
+// TopologyLevelValueSource defines how the value of a topology level is
+// derived from a node label.
+type TopologyLevelValueSource struct {
+	// nodeLabel indicates the name of the composite node label.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=316
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
+	NodeLabel string `json:"nodeLabel"`
+
+	// regex is a RE2 regular expression matched against the value of the
+	// node label. The value of the level is the first capture group, or the
+	// whole match if the expression has no capture groups. The nodes whose
+	// label doesn't match the expression are not part of the topology.
+	//
+	// Example: ^(block[0-9]+)-rack[0-9]+$
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Regex string `json:"regex"`
 }
 
 // +genclient
@@ -72,8 +72,9 @@ func Convert_v1beta2_WorkloadSpec_To_v1beta1_WorkloadSpec(in *v1beta2.WorkloadSp
 }
 
 func Convert_v1beta2_PodSetTopologyRequest_To_v1beta1_PodSetTopologyRequest(in *v1beta2.PodSetTopologyRequest, out *PodSetTopologyRequest, s conversionapi.Scope) error {
-	// PodsetSliceRequiredTopologyConstraints is intentionally dropped during
-	// conversion to v1beta1 as it has no equivalent field.
+	// PodsetSliceRequiredTopologyConstraints and MaxPodsPerDomain are
+	// intentionally dropped during conversion to v1beta1 as they have no
+	// equivalent fields.
 	return autoConvert_v1beta2_PodSetTopologyRequest_To_v1beta1_PodSetTopologyRequest(in, out, s)
 }
 
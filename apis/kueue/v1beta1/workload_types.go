@@ -565,6 +565,35 @@ type AdmissionCheckState struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MaxItems=18
 	PodSetUpdates []PodSetUpdate `json:"podSetUpdates,omitempty"`
+
+	// advisory is set by Kueue when the admission check is advisory, so its
+	// state doesn't block the admission of the workload.
+	// +optional
+	Advisory *bool `json:"advisory,omitempty"`
+
+	// artifacts lists the diagnostic artifacts produced by the admission check,
+	// such as reports, which users can consult for details about its state.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	Artifacts []AdmissionCheckArtifact `json:"artifacts,omitempty"`
+}
+
+// AdmissionCheckArtifact is a reference to a diagnostic artifact produced by an
+// admission check.
+type AdmissionCheckArtifact struct {
+	// name of the artifact.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// url of the artifact. It must be an http or https URL.
+	// +required
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
 }
 
 // PodSetUpdate contains a list of pod set modifications suggested by AdmissionChecks.
@@ -711,6 +740,10 @@ const (
 	// because the LocalQueue is Stopped.
 	WorkloadEvictedByLocalQueueStopped = "LocalQueueStopped"
 
+	// WorkloadEvictedByResourceFlavorStopped indicates that the workload was evicted
+	// because a ResourceFlavor assigned to it is Stopped.
+	WorkloadEvictedByResourceFlavorStopped = "ResourceFlavorStopped"
+
 	// WorkloadEvictedDueToNodeFailures indicates that the workload was evicted
 	// due to non-recoverable node failures.
 	WorkloadEvictedDueToNodeFailures = "NodeFailures"
@@ -59,6 +59,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AdmissionCheckArtifact)(nil), (*v1beta2.AdmissionCheckArtifact)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AdmissionCheckArtifact_To_v1beta2_AdmissionCheckArtifact(a.(*AdmissionCheckArtifact), b.(*v1beta2.AdmissionCheckArtifact), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.AdmissionCheckArtifact)(nil), (*AdmissionCheckArtifact)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_AdmissionCheckArtifact_To_v1beta1_AdmissionCheckArtifact(a.(*v1beta2.AdmissionCheckArtifact), b.(*AdmissionCheckArtifact), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AdmissionCheckList)(nil), (*v1beta2.AdmissionCheckList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AdmissionCheckList_To_v1beta2_AdmissionCheckList(a.(*AdmissionCheckList), b.(*v1beta2.AdmissionCheckList), scope)
 	}); err != nil {
@@ -179,11 +189,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.ClusterQueuePreemption)(nil), (*ClusterQueuePreemption)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_ClusterQueuePreemption_To_v1beta1_ClusterQueuePreemption(a.(*v1beta2.ClusterQueuePreemption), b.(*ClusterQueuePreemption), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*v1beta2.ClusterQueueStatus)(nil), (*ClusterQueueStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_ClusterQueueStatus_To_v1beta1_ClusterQueueStatus(a.(*v1beta2.ClusterQueueStatus), b.(*ClusterQueueStatus), scope)
 	}); err != nil {
@@ -329,11 +334,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.LocalQueueSpec)(nil), (*LocalQueueSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_LocalQueueSpec_To_v1beta1_LocalQueueSpec(a.(*v1beta2.LocalQueueSpec), b.(*LocalQueueSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*MultiKueueCluster)(nil), (*v1beta2.MultiKueueCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_MultiKueueCluster_To_v1beta2_MultiKueueCluster(a.(*MultiKueueCluster), b.(*v1beta2.MultiKueueCluster), scope)
 	}); err != nil {
@@ -614,6 +614,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TopologyLevelValueSource)(nil), (*v1beta2.TopologyLevelValueSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TopologyLevelValueSource_To_v1beta2_TopologyLevelValueSource(a.(*TopologyLevelValueSource), b.(*v1beta2.TopologyLevelValueSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta2.TopologyLevelValueSource)(nil), (*TopologyLevelValueSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_TopologyLevelValueSource_To_v1beta1_TopologyLevelValueSource(a.(*v1beta2.TopologyLevelValueSource), b.(*TopologyLevelValueSource), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TopologyList)(nil), (*v1beta2.TopologyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_TopologyList_To_v1beta2_TopologyList(a.(*TopologyList), b.(*v1beta2.TopologyList), scope)
 	}); err != nil {
@@ -739,11 +749,21 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.ClusterQueuePreemption)(nil), (*ClusterQueuePreemption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ClusterQueuePreemption_To_v1beta1_ClusterQueuePreemption(a.(*v1beta2.ClusterQueuePreemption), b.(*ClusterQueuePreemption), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.ClusterQueueSpec)(nil), (*ClusterQueueSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_ClusterQueueSpec_To_v1beta1_ClusterQueueSpec(a.(*v1beta2.ClusterQueueSpec), b.(*ClusterQueueSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.LocalQueueSpec)(nil), (*LocalQueueSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_LocalQueueSpec_To_v1beta1_LocalQueueSpec(a.(*v1beta2.LocalQueueSpec), b.(*LocalQueueSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.LocalQueueStatus)(nil), (*LocalQueueStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_LocalQueueStatus_To_v1beta1_LocalQueueStatus(a.(*v1beta2.LocalQueueStatus), b.(*LocalQueueStatus), scope)
 	}); err != nil {
@@ -856,6 +876,28 @@ func Convert_v1beta2_AdmissionCheck_To_v1beta1_AdmissionCheck(in *v1beta2.Admiss
 	return autoConvert_v1beta2_AdmissionCheck_To_v1beta1_AdmissionCheck(in, out, s)
 }
 
+func autoConvert_v1beta1_AdmissionCheckArtifact_To_v1beta2_AdmissionCheckArtifact(in *AdmissionCheckArtifact, out *v1beta2.AdmissionCheckArtifact, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1beta1_AdmissionCheckArtifact_To_v1beta2_AdmissionCheckArtifact is an autogenerated conversion function.
+func Convert_v1beta1_AdmissionCheckArtifact_To_v1beta2_AdmissionCheckArtifact(in *AdmissionCheckArtifact, out *v1beta2.AdmissionCheckArtifact, s conversion.Scope) error {
+	return autoConvert_v1beta1_AdmissionCheckArtifact_To_v1beta2_AdmissionCheckArtifact(in, out, s)
+}
+
+func autoConvert_v1beta2_AdmissionCheckArtifact_To_v1beta1_AdmissionCheckArtifact(in *v1beta2.AdmissionCheckArtifact, out *AdmissionCheckArtifact, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1beta2_AdmissionCheckArtifact_To_v1beta1_AdmissionCheckArtifact is an autogenerated conversion function.
+func Convert_v1beta2_AdmissionCheckArtifact_To_v1beta1_AdmissionCheckArtifact(in *v1beta2.AdmissionCheckArtifact, out *AdmissionCheckArtifact, s conversion.Scope) error {
+	return autoConvert_v1beta2_AdmissionCheckArtifact_To_v1beta1_AdmissionCheckArtifact(in, out, s)
+}
+
 func autoConvert_v1beta1_AdmissionCheckList_To_v1beta2_AdmissionCheckList(in *AdmissionCheckList, out *v1beta2.AdmissionCheckList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -926,12 +968,18 @@ func autoConvert_v1beta1_AdmissionCheckSpec_To_v1beta2_AdmissionCheckSpec(in *Ad
 	out.ControllerName = in.ControllerName
 	// WARNING: in.RetryDelayMinutes requires manual conversion: does not exist in peer-type
 	out.Parameters = (*v1beta2.AdmissionCheckParametersReference)(unsafe.Pointer(in.Parameters))
+	out.Advisory = (*bool)(unsafe.Pointer(in.Advisory))
+	out.AdmissionCheckTimeoutSeconds = (*int32)(unsafe.Pointer(in.AdmissionCheckTimeoutSeconds))
+	out.TimeoutAction = (*v1beta2.AdmissionCheckTimeoutAction)(unsafe.Pointer(in.TimeoutAction))
 	return nil
 }
 
 func autoConvert_v1beta2_AdmissionCheckSpec_To_v1beta1_AdmissionCheckSpec(in *v1beta2.AdmissionCheckSpec, out *AdmissionCheckSpec, s conversion.Scope) error {
 	out.ControllerName = in.ControllerName
 	out.Parameters = (*AdmissionCheckParametersReference)(unsafe.Pointer(in.Parameters))
+	out.Advisory = (*bool)(unsafe.Pointer(in.Advisory))
+	out.AdmissionCheckTimeoutSeconds = (*int32)(unsafe.Pointer(in.AdmissionCheckTimeoutSeconds))
+	out.TimeoutAction = (*AdmissionCheckTimeoutAction)(unsafe.Pointer(in.TimeoutAction))
 	return nil
 }
 
@@ -948,6 +996,8 @@ func autoConvert_v1beta1_AdmissionCheckState_To_v1beta2_AdmissionCheckState(in *
 	out.RequeueAfterSeconds = (*int32)(unsafe.Pointer(in.RequeueAfterSeconds))
 	out.RetryCount = (*int32)(unsafe.Pointer(in.RetryCount))
 	out.PodSetUpdates = *(*[]v1beta2.PodSetUpdate)(unsafe.Pointer(&in.PodSetUpdates))
+	out.Advisory = (*bool)(unsafe.Pointer(in.Advisory))
+	out.Artifacts = *(*[]v1beta2.AdmissionCheckArtifact)(unsafe.Pointer(&in.Artifacts))
 	return nil
 }
 
@@ -964,6 +1014,8 @@ func autoConvert_v1beta2_AdmissionCheckState_To_v1beta1_AdmissionCheckState(in *
 	out.RequeueAfterSeconds = (*int32)(unsafe.Pointer(in.RequeueAfterSeconds))
 	out.RetryCount = (*int32)(unsafe.Pointer(in.RetryCount))
 	out.PodSetUpdates = *(*[]PodSetUpdate)(unsafe.Pointer(&in.PodSetUpdates))
+	out.Advisory = (*bool)(unsafe.Pointer(in.Advisory))
+	out.Artifacts = *(*[]AdmissionCheckArtifact)(unsafe.Pointer(&in.Artifacts))
 	return nil
 }
 
@@ -1186,21 +1238,27 @@ func autoConvert_v1beta2_ClusterQueuePreemption_To_v1beta1_ClusterQueuePreemptio
 	out.ReclaimWithinCohort = PreemptionPolicy(in.ReclaimWithinCohort)
 	out.BorrowWithinCohort = (*BorrowWithinCohort)(unsafe.Pointer(in.BorrowWithinCohort))
 	out.WithinClusterQueue = PreemptionPolicy(in.WithinClusterQueue)
+	// WARNING: in.CooldownSeconds requires manual conversion: does not exist in peer-type
+	// WARNING: in.Stages requires manual conversion: does not exist in peer-type
+	// WARNING: in.Mode requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_ClusterQueuePreemption_To_v1beta1_ClusterQueuePreemption is an autogenerated conversion function.
-func Convert_v1beta2_ClusterQueuePreemption_To_v1beta1_ClusterQueuePreemption(in *v1beta2.ClusterQueuePreemption, out *ClusterQueuePreemption, s conversion.Scope) error {
-	return autoConvert_v1beta2_ClusterQueuePreemption_To_v1beta1_ClusterQueuePreemption(in, out, s)
-}
-
 func autoConvert_v1beta1_ClusterQueueSpec_To_v1beta2_ClusterQueueSpec(in *ClusterQueueSpec, out *v1beta2.ClusterQueueSpec, s conversion.Scope) error {
 	out.ResourceGroups = *(*[]v1beta2.ResourceGroup)(unsafe.Pointer(&in.ResourceGroups))
 	// WARNING: in.Cohort requires manual conversion: does not exist in peer-type
 	out.QueueingStrategy = v1beta2.QueueingStrategy(in.QueueingStrategy)
 	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
 	out.FlavorFungibility = (*v1beta2.FlavorFungibility)(unsafe.Pointer(in.FlavorFungibility))
-	out.Preemption = (*v1beta2.ClusterQueuePreemption)(unsafe.Pointer(in.Preemption))
+	if in.Preemption != nil {
+		in, out := &in.Preemption, &out.Preemption
+		*out = new(v1beta2.ClusterQueuePreemption)
+		if err := Convert_v1beta1_ClusterQueuePreemption_To_v1beta2_ClusterQueuePreemption(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Preemption = nil
+	}
 	// WARNING: in.AdmissionChecks requires manual conversion: does not exist in peer-type
 	out.AdmissionChecksStrategy = (*v1beta2.AdmissionChecksStrategy)(unsafe.Pointer(in.AdmissionChecksStrategy))
 	out.StopPolicy = (*v1beta2.StopPolicy)(unsafe.Pointer(in.StopPolicy))
@@ -1215,12 +1273,31 @@ func autoConvert_v1beta2_ClusterQueueSpec_To_v1beta1_ClusterQueueSpec(in *v1beta
 	out.QueueingStrategy = QueueingStrategy(in.QueueingStrategy)
 	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
 	out.FlavorFungibility = (*FlavorFungibility)(unsafe.Pointer(in.FlavorFungibility))
-	out.Preemption = (*ClusterQueuePreemption)(unsafe.Pointer(in.Preemption))
+	if in.Preemption != nil {
+		in, out := &in.Preemption, &out.Preemption
+		*out = new(ClusterQueuePreemption)
+		if err := Convert_v1beta2_ClusterQueuePreemption_To_v1beta1_ClusterQueuePreemption(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Preemption = nil
+	}
 	out.AdmissionChecksStrategy = (*AdmissionChecksStrategy)(unsafe.Pointer(in.AdmissionChecksStrategy))
 	out.StopPolicy = (*StopPolicy)(unsafe.Pointer(in.StopPolicy))
 	out.FairSharing = (*FairSharing)(unsafe.Pointer(in.FairSharing))
 	out.AdmissionScope = (*AdmissionScope)(unsafe.Pointer(in.AdmissionScope))
 	// WARNING: in.ConcurrentAdmissionPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResourceBorrowingLimits requires manual conversion: does not exist in peer-type
+	// WARNING: in.OvercommitRatios requires manual conversion: does not exist in peer-type
+	// WARNING: in.PriorityAging requires manual conversion: does not exist in peer-type
+	// WARNING: in.RequeueStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeCapacityFlavors requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultContainerRequests requires manual conversion: does not exist in peer-type
+	// WARNING: in.QuotaReductionPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.BorrowingWindows requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaxAdmittedWorkloads requires manual conversion: does not exist in peer-type
+	// WARNING: in.LimitsAccountedResources requires manual conversion: does not exist in peer-type
+	// WARNING: in.FlavorTieBreak requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1662,14 +1739,10 @@ func autoConvert_v1beta2_LocalQueueSpec_To_v1beta1_LocalQueueSpec(in *v1beta2.Lo
 	out.ClusterQueue = ClusterQueueReference(in.ClusterQueue)
 	out.StopPolicy = (*StopPolicy)(unsafe.Pointer(in.StopPolicy))
 	out.FairSharing = (*FairSharing)(unsafe.Pointer(in.FairSharing))
+	// WARNING: in.GuaranteedQuota requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_LocalQueueSpec_To_v1beta1_LocalQueueSpec is an autogenerated conversion function.
-func Convert_v1beta2_LocalQueueSpec_To_v1beta1_LocalQueueSpec(in *v1beta2.LocalQueueSpec, out *LocalQueueSpec, s conversion.Scope) error {
-	return autoConvert_v1beta2_LocalQueueSpec_To_v1beta1_LocalQueueSpec(in, out, s)
-}
-
 func autoConvert_v1beta1_LocalQueueStatus_To_v1beta2_LocalQueueStatus(in *LocalQueueStatus, out *v1beta2.LocalQueueStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	out.PendingWorkloads = in.PendingWorkloads
@@ -2023,6 +2096,7 @@ func autoConvert_v1beta2_PodSetTopologyRequest_To_v1beta1_PodSetTopologyRequest(
 	out.PodSetSliceRequiredTopology = (*string)(unsafe.Pointer(in.PodSetSliceRequiredTopology))
 	out.PodSetSliceSize = (*int32)(unsafe.Pointer(in.PodSetSliceSize))
 	// WARNING: in.PodsetSliceRequiredTopologyConstraints requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaxPodsPerDomain requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2109,6 +2183,7 @@ func autoConvert_v1beta1_ProvisioningRequestConfigSpec_To_v1beta2_ProvisioningRe
 	out.RetryStrategy = (*v1beta2.ProvisioningRequestRetryStrategy)(unsafe.Pointer(in.RetryStrategy))
 	out.PodSetUpdates = (*v1beta2.ProvisioningRequestPodSetUpdates)(unsafe.Pointer(in.PodSetUpdates))
 	out.PodSetMergePolicy = (*v1beta2.ProvisioningRequestConfigPodSetMergePolicy)(unsafe.Pointer(in.PodSetMergePolicy))
+	out.MaxConcurrentRequests = (*int32)(unsafe.Pointer(in.MaxConcurrentRequests))
 	return nil
 }
 
@@ -2124,6 +2199,7 @@ func autoConvert_v1beta2_ProvisioningRequestConfigSpec_To_v1beta1_ProvisioningRe
 	out.RetryStrategy = (*ProvisioningRequestRetryStrategy)(unsafe.Pointer(in.RetryStrategy))
 	out.PodSetUpdates = (*ProvisioningRequestPodSetUpdates)(unsafe.Pointer(in.PodSetUpdates))
 	out.PodSetMergePolicy = (*ProvisioningRequestConfigPodSetMergePolicy)(unsafe.Pointer(in.PodSetMergePolicy))
+	out.MaxConcurrentRequests = (*int32)(unsafe.Pointer(in.MaxConcurrentRequests))
 	return nil
 }
 
@@ -2295,6 +2371,11 @@ func autoConvert_v1beta1_ResourceFlavorSpec_To_v1beta2_ResourceFlavorSpec(in *Re
 	out.NodeTaints = *(*[]corev1.Taint)(unsafe.Pointer(&in.NodeTaints))
 	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.TopologyName = (*v1beta2.TopologyReference)(unsafe.Pointer(in.TopologyName))
+	out.MinNodeReadySeconds = (*int32)(unsafe.Pointer(in.MinNodeReadySeconds))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.ResourceScaling = *(*map[corev1.ResourceName]int32)(unsafe.Pointer(&in.ResourceScaling))
+	out.StopPolicy = (*v1beta2.StopPolicy)(unsafe.Pointer(in.StopPolicy))
+	out.NodeShape = *(*corev1.ResourceList)(unsafe.Pointer(&in.NodeShape))
 	return nil
 }
 
@@ -2308,6 +2389,11 @@ func autoConvert_v1beta2_ResourceFlavorSpec_To_v1beta1_ResourceFlavorSpec(in *v1
 	out.NodeTaints = *(*[]corev1.Taint)(unsafe.Pointer(&in.NodeTaints))
 	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.TopologyName = (*TopologyReference)(unsafe.Pointer(in.TopologyName))
+	out.MinNodeReadySeconds = (*int32)(unsafe.Pointer(in.MinNodeReadySeconds))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.ResourceScaling = *(*map[corev1.ResourceName]int32)(unsafe.Pointer(&in.ResourceScaling))
+	out.StopPolicy = (*StopPolicy)(unsafe.Pointer(in.StopPolicy))
+	out.NodeShape = *(*corev1.ResourceList)(unsafe.Pointer(&in.NodeShape))
 	return nil
 }
 
@@ -2470,6 +2556,7 @@ func Convert_v1beta2_TopologyInfo_To_v1beta1_TopologyInfo(in *v1beta2.TopologyIn
 
 func autoConvert_v1beta1_TopologyLevel_To_v1beta2_TopologyLevel(in *TopologyLevel, out *v1beta2.TopologyLevel, s conversion.Scope) error {
 	out.NodeLabel = in.NodeLabel
+	out.ValueFrom = (*v1beta2.TopologyLevelValueSource)(unsafe.Pointer(in.ValueFrom))
 	return nil
 }
 
@@ -2480,6 +2567,7 @@ func Convert_v1beta1_TopologyLevel_To_v1beta2_TopologyLevel(in *TopologyLevel, o
 
 func autoConvert_v1beta2_TopologyLevel_To_v1beta1_TopologyLevel(in *v1beta2.TopologyLevel, out *TopologyLevel, s conversion.Scope) error {
 	out.NodeLabel = in.NodeLabel
+	out.ValueFrom = (*TopologyLevelValueSource)(unsafe.Pointer(in.ValueFrom))
 	return nil
 }
 
@@ -2488,6 +2576,28 @@ func Convert_v1beta2_TopologyLevel_To_v1beta1_TopologyLevel(in *v1beta2.Topology
 	return autoConvert_v1beta2_TopologyLevel_To_v1beta1_TopologyLevel(in, out, s)
 }
 
+func autoConvert_v1beta1_TopologyLevelValueSource_To_v1beta2_TopologyLevelValueSource(in *TopologyLevelValueSource, out *v1beta2.TopologyLevelValueSource, s conversion.Scope) error {
+	out.NodeLabel = in.NodeLabel
+	out.Regex = in.Regex
+	return nil
+}
+
+// Convert_v1beta1_TopologyLevelValueSource_To_v1beta2_TopologyLevelValueSource is an autogenerated conversion function.
+func Convert_v1beta1_TopologyLevelValueSource_To_v1beta2_TopologyLevelValueSource(in *TopologyLevelValueSource, out *v1beta2.TopologyLevelValueSource, s conversion.Scope) error {
+	return autoConvert_v1beta1_TopologyLevelValueSource_To_v1beta2_TopologyLevelValueSource(in, out, s)
+}
+
+func autoConvert_v1beta2_TopologyLevelValueSource_To_v1beta1_TopologyLevelValueSource(in *v1beta2.TopologyLevelValueSource, out *TopologyLevelValueSource, s conversion.Scope) error {
+	out.NodeLabel = in.NodeLabel
+	out.Regex = in.Regex
+	return nil
+}
+
+// Convert_v1beta2_TopologyLevelValueSource_To_v1beta1_TopologyLevelValueSource is an autogenerated conversion function.
+func Convert_v1beta2_TopologyLevelValueSource_To_v1beta1_TopologyLevelValueSource(in *v1beta2.TopologyLevelValueSource, out *TopologyLevelValueSource, s conversion.Scope) error {
+	return autoConvert_v1beta2_TopologyLevelValueSource_To_v1beta1_TopologyLevelValueSource(in, out, s)
+}
+
 func autoConvert_v1beta1_TopologyList_To_v1beta2_TopologyList(in *TopologyList, out *v1beta2.TopologyList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]v1beta2.Topology)(unsafe.Pointer(&in.Items))
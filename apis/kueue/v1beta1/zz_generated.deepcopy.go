@@ -75,6 +75,21 @@ func (in *AdmissionCheck) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionCheckArtifact) DeepCopyInto(out *AdmissionCheckArtifact) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckArtifact.
+func (in *AdmissionCheckArtifact) DeepCopy() *AdmissionCheckArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionCheckArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdmissionCheckList) DeepCopyInto(out *AdmissionCheckList) {
 	*out = *in
@@ -135,6 +150,21 @@ func (in *AdmissionCheckSpec) DeepCopyInto(out *AdmissionCheckSpec) {
 		*out = new(AdmissionCheckParametersReference)
 		**out = **in
 	}
+	if in.Advisory != nil {
+		in, out := &in.Advisory, &out.Advisory
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdmissionCheckTimeoutSeconds != nil {
+		in, out := &in.AdmissionCheckTimeoutSeconds, &out.AdmissionCheckTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutAction != nil {
+		in, out := &in.TimeoutAction, &out.TimeoutAction
+		*out = new(AdmissionCheckTimeoutAction)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckSpec.
@@ -168,6 +198,16 @@ func (in *AdmissionCheckState) DeepCopyInto(out *AdmissionCheckState) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Advisory != nil {
+		in, out := &in.Advisory, &out.Advisory
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]AdmissionCheckArtifact, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckState.
@@ -1437,6 +1477,11 @@ func (in *ProvisioningRequestConfigSpec) DeepCopyInto(out *ProvisioningRequestCo
 		*out = new(ProvisioningRequestConfigPodSetMergePolicy)
 		**out = **in
 	}
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningRequestConfigSpec.
@@ -1640,6 +1685,35 @@ func (in *ResourceFlavorSpec) DeepCopyInto(out *ResourceFlavorSpec) {
 		*out = new(TopologyReference)
 		**out = **in
 	}
+	if in.MinNodeReadySeconds != nil {
+		in, out := &in.MinNodeReadySeconds, &out.MinNodeReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceScaling != nil {
+		in, out := &in.ResourceScaling, &out.ResourceScaling
+		*out = make(map[corev1.ResourceName]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StopPolicy != nil {
+		in, out := &in.StopPolicy, &out.StopPolicy
+		*out = new(StopPolicy)
+		**out = **in
+	}
+	if in.NodeShape != nil {
+		in, out := &in.NodeShape, &out.NodeShape
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFlavorSpec.
@@ -1838,6 +1912,11 @@ func (in *TopologyInfo) DeepCopy() *TopologyInfo {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologyLevel) DeepCopyInto(out *TopologyLevel) {
 	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(TopologyLevelValueSource)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyLevel.
@@ -1850,6 +1929,21 @@ func (in *TopologyLevel) DeepCopy() *TopologyLevel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyLevelValueSource) DeepCopyInto(out *TopologyLevelValueSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyLevelValueSource.
+func (in *TopologyLevelValueSource) DeepCopy() *TopologyLevelValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyLevelValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologyList) DeepCopyInto(out *TopologyList) {
 	*out = *in
@@ -1888,7 +1982,9 @@ func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
 	if in.Levels != nil {
 		in, out := &in.Levels, &out.Levels
 		*out = make([]TopologyLevel, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
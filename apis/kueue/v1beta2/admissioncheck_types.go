@@ -58,8 +58,49 @@ type AdmissionCheckSpec struct {
 	// check.
 	// +optional
 	Parameters *AdmissionCheckParametersReference `json:"parameters,omitempty"`
+
+	// advisory indicates that the check records a result without blocking the
+	// admission of the Workloads. A Pending or Retry state doesn't hold the
+	// admission, and a Rejected state is recorded as a warning event instead
+	// of deactivating the Workload.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// AdvisoryAdmissionChecks feature gate.
+	// +optional
+	Advisory *bool `json:"advisory,omitempty"`
+
+	// admissionCheckTimeoutSeconds is the maximum time a Workload can keep its
+	// quota reserved while the check is Pending. When exceeded, the quota
+	// reservation is released and the timeoutAction is applied.
+	// If not set, there is no timeout.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// AdmissionCheckTimeout feature gate.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	AdmissionCheckTimeoutSeconds *int32 `json:"admissionCheckTimeoutSeconds,omitempty"`
+
+	// timeoutAction is the action applied to a Workload once the
+	// admissionCheckTimeoutSeconds is exceeded. Possible values are:
+	//
+	// - Requeue: the Workload is evicted and requeued, following the
+	//   backoff of the evicted Workloads.
+	// - Deactivate: the Workload is evicted and deactivated.
+	//
+	// Defaults to Requeue.
+	// +optional
+	// +kubebuilder:validation:Enum=Requeue;Deactivate
+	TimeoutAction *AdmissionCheckTimeoutAction `json:"timeoutAction,omitempty"`
 }
 
+type AdmissionCheckTimeoutAction string
+
+const (
+	// AdmissionCheckTimeoutActionRequeue evicts and requeues the Workload.
+	AdmissionCheckTimeoutActionRequeue AdmissionCheckTimeoutAction = "Requeue"
+
+	// AdmissionCheckTimeoutActionDeactivate evicts and deactivates the Workload.
+	AdmissionCheckTimeoutActionDeactivate AdmissionCheckTimeoutAction = "Deactivate"
+)
+
 type AdmissionCheckParametersReference struct {
 	// apiGroup is the group for the resource being referenced.
 	// +required
@@ -30,6 +30,16 @@ const (
 	// MultiKueueManagerQuotaAutomation indicates that this ClusterQueue is a
 	// MultiKueue manager queue and its quota is automatically managed.
 	MultiKueueManagerQuotaAutomation string = "MultiKueueManagerQuotaAutomation"
+	// ClusterQueueQuotaExhausted indicates that the quota of some resources of
+	// the ClusterQueue, including the quota it can borrow from its cohort, is
+	// fully used.
+	ClusterQueueQuotaExhausted string = "QuotaExhausted"
+)
+
+// ClusterQueue QuotaExhausted condition reasons.
+const (
+	ClusterQueueQuotaExhaustedReasonExhausted      = "Exhausted"
+	ClusterQueueQuotaExhaustedReasonQuotaAvailable = "QuotaAvailable"
 )
 
 // ClusterQueue Active condition reasons.
@@ -160,6 +170,255 @@ type ClusterQueueSpec struct {
 	//
 	// +optional
 	ConcurrentAdmissionPolicy *ConcurrentAdmissionPolicy `json:"concurrentAdmissionPolicy,omitempty"`
+
+	// resourceBorrowingLimits caps, per resource, the total quantity that this
+	// ClusterQueue is allowed to borrow from the unused quota of other
+	// ClusterQueues in the same cohort, summed across all the flavors that
+	// provide the resource. The borrowingLimit of each [flavor, resource]
+	// combination still applies.
+	// Each name must be one of the coveredResources of the resourceGroups.
+	// resourceBorrowingLimits must be empty if spec.cohortName is empty.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ResourceBorrowingLimits feature gate.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	ResourceBorrowingLimits []ResourceBorrowingLimit `json:"resourceBorrowingLimits,omitempty"`
+
+	// overcommitRatios lets this ClusterQueue admit more than its nominal quota
+	// of compressible resources. For each listed resource, the nominalQuota of
+	// every flavor providing the resource is multiplied by the ratio when
+	// computing the available quota. Workloads are still admitted based on
+	// their requests.
+	// The overcommitted quota is only available to this ClusterQueue: it is not
+	// lent to the cohort, and the nominal quota is reported as configured.
+	// Only cpu can be overcommitted; resources such as memory or GPUs always
+	// use their nominal quota.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ClusterQueueOvercommit feature gate.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	OvercommitRatios []ResourceOvercommitRatio `json:"overcommitRatios,omitempty"`
+
+	// priorityAging gradually increases the effective priority of the pending
+	// Workloads of this ClusterQueue the longer they wait, so that low priority
+	// Workloads are not starved by a steady stream of higher priority ones.
+	// The increase only affects the order in which the Workloads of this
+	// ClusterQueue are considered for admission; the priority of the Workloads
+	// is not modified.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// PriorityAging feature gate.
+	// +optional
+	PriorityAging *PriorityAging `json:"priorityAging,omitempty"`
+
+	// requeueStrategy defines how the Workloads of this ClusterQueue that were
+	// evicted because their Pods didn't become ready within the waitForPodsReady
+	// timeout are requeued.
+	//
+	// Depending on its value, the evicted Workloads are:
+	//
+	// - Backoff - requeued after the backoff configured in waitForPodsReady.requeuingStrategy.
+	// - Immediate - requeued right away, without waiting for a backoff.
+	// - Manual - deactivated; they are requeued once spec.active is set back to true.
+	//
+	// When not set, Backoff is used.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// RequeueStrategy feature gate.
+	// +kubebuilder:validation:Enum=Immediate;Backoff;Manual
+	// +optional
+	RequeueStrategy *RequeueStrategy `json:"requeueStrategy,omitempty"`
+
+	// nodeCapacityFlavors is a list of ResourceFlavors' names whose nominalQuota
+	// is derived from the allocatable capacity of the schedulable and Ready nodes
+	// of the flavor, rather than from the nominalQuota in resourceGroups.
+	// The quota is updated as nodes are added to or removed from the cluster,
+	// for example by an autoscaler. The flavors must be Topology Aware
+	// Scheduling flavors, as their nodes are tracked through the topology.
+	// It must not be set for a ClusterQueue in a cohort, so that the capacity of
+	// the nodes is not accounted once per ClusterQueue of the cohort.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// NodeCapacityQuota feature gate.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	NodeCapacityFlavors []ResourceFlavorReference `json:"nodeCapacityFlavors,omitempty"`
+
+	// defaultContainerRequests are the resource requests accounted for the
+	// containers of the Workloads which neither request nor limit the
+	// resource, after applying the LimitRanges of the namespace.
+	// This prevents the Workloads with request-less containers from being
+	// admitted at no cost. The webhook for Pods sets the same requests on the
+	// Pods of the Workloads, so that they request the accounted resources on
+	// the nodes.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ClusterQueueDefaultRequests feature gate.
+	// +optional
+	DefaultContainerRequests corev1.ResourceList `json:"defaultContainerRequests,omitempty"`
+
+	// quotaReductionPolicy defines how the Workloads of this ClusterQueue are
+	// evicted when its usage exceeds the available quota, for example after
+	// the nominalQuota is reduced. The lowest priority Workloads are evicted
+	// first, gradually, until the usage fits the quota again.
+	// When not set, the admitted Workloads keep running, and only the
+	// admission of new Workloads is blocked.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// QuotaReductionEviction feature gate.
+	// +optional
+	QuotaReductionPolicy *QuotaReductionPolicy `json:"quotaReductionPolicy,omitempty"`
+
+	// borrowingWindows restricts borrowing unused quota from the cohort to
+	// recurring time windows. When set, the Workloads of this ClusterQueue are
+	// admitted beyond its nominalQuota only while one of the windows is open.
+	// Outside of the windows, the admitted Workloads which borrow keep running,
+	// and can be reclaimed by the other ClusterQueues of the cohort.
+	// When not set, borrowing is allowed at any time.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// BorrowingWindows feature gate.
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	BorrowingWindows []BorrowingWindow `json:"borrowingWindows,omitempty"`
+
+	// maxAdmittedWorkloads is the maximum number of Workloads which can have
+	// quota reserved in this ClusterQueue at the same time, regardless of
+	// the available quota. The excess Workloads stay pending until some of
+	// the admitted Workloads finish or are evicted.
+	// The pending Workloads don't preempt the admitted Workloads to get under
+	// the limit, even when they have a higher priority.
+	// When not set, the number of admitted Workloads is not limited.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ClusterQueueMaxAdmittedWorkloads feature gate.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAdmittedWorkloads *int32 `json:"maxAdmittedWorkloads,omitempty"`
+
+	// limitsAccountedResources is the list of resources whose quota usage is
+	// computed from the limits of the containers, rather than from their requests,
+	// when the limits are higher. This prevents the burstable Workloads from being
+	// admitted beyond what the nodes can sustain when they use the resources up to
+	// their limits. The resources not in the list are accounted for their requests.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ClusterQueueLimitsAccounting feature gate.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	LimitsAccountedResources []corev1.ResourceName `json:"limitsAccountedResources,omitempty"`
+
+	// flavorTieBreak determines which flavor is assigned when several flavors
+	// fit the Workload equally well, that is, without preemption and at the same
+	// borrowing level. The possible values are:
+	//
+	// - `None` (default): assign the first such flavor, in the order of the
+	//   flavors in the resource group.
+	// - `MostHeadroom`: assign the flavor with the most headroom, to balance
+	//   the usage of the flavors.
+	// - `LeastHeadroom`: assign the flavor with the least headroom, to
+	//   consolidate the usage of the flavors.
+	//
+	// The headroom of a flavor is the smallest fraction of the quota available
+	// to the ClusterQueue, among the requested resources, which remains unused
+	// after the Workload is admitted.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// FlavorTieBreak feature gate.
+	// +kubebuilder:validation:Enum=None;MostHeadroom;LeastHeadroom
+	// +optional
+	FlavorTieBreak *FlavorTieBreakPolicy `json:"flavorTieBreak,omitempty"`
+}
+
+// BorrowingWindow defines a recurring time window during which a
+// ClusterQueue is allowed to borrow.
+type BorrowingWindow struct {
+	// schedule is a cron expression, in the standard five-field format,
+	// defining when the window opens, for example "0 18 * * 1-5" to open the
+	// window at 18:00 from Monday to Friday. The schedule is evaluated in UTC,
+	// unless it is prefixed with CRON_TZ=<time zone>.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Schedule string `json:"schedule,omitempty"`
+
+	// durationSeconds is how long, in seconds, the window stays open.
+	//
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	DurationSeconds int32 `json:"durationSeconds,omitempty"`
+}
+
+// QuotaReductionPolicy defines how fast the Workloads exceeding the quota of
+// a ClusterQueue are evicted.
+type QuotaReductionPolicy struct {
+	// evictionIntervalSeconds is the minimum time, in seconds, between two
+	// rounds of evictions of the Workloads exceeding the quota.
+	//
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	EvictionIntervalSeconds int32 `json:"evictionIntervalSeconds,omitempty"`
+
+	// maxEvictionsPerInterval is the maximum number of Workloads evicted in a
+	// round of evictions. When not set, a single Workload is evicted per round.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxEvictionsPerInterval *int32 `json:"maxEvictionsPerInterval,omitempty"`
+}
+
+// PriorityAging defines how the effective priority of a pending Workload
+// increases with its waiting time.
+type PriorityAging struct {
+	// intervalSeconds is the waiting time, in seconds, after which the effective
+	// priority of a pending Workload is increased by priorityIncrement.
+	// The waiting time is measured from the queue ordering timestamp of the
+	// Workload.
+	//
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+
+	// priorityIncrement is the increase of the effective priority of a pending
+	// Workload for every intervalSeconds of waiting time.
+	//
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	PriorityIncrement int32 `json:"priorityIncrement,omitempty"`
+
+	// maxPriorityIncrement caps the total increase of the effective priority of
+	// a pending Workload. When not set, the increase is not capped.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxPriorityIncrement *int32 `json:"maxPriorityIncrement,omitempty"`
+}
+
+// ResourceBorrowingLimit defines the maximum quantity of a resource that a
+// ClusterQueue can borrow across all its flavors.
+type ResourceBorrowingLimit struct {
+	// name of the resource.
+	// +required
+	Name corev1.ResourceName `json:"name,omitempty"`
+
+	// borrowingLimit is the maximum amount of the resource that this
+	// ClusterQueue is allowed to borrow from the cohort, across all flavors.
+	// It must be non-negative.
+	// +required
+	BorrowingLimit resource.Quantity `json:"borrowingLimit,omitempty"`
+}
+
+// ResourceOvercommitRatio defines the factor by which the nominal quota of a
+// resource is scaled.
+type ResourceOvercommitRatio struct {
+	// name of the resource.
+	// +required
+	Name corev1.ResourceName `json:"name,omitempty"`
+
+	// ratio is the factor applied to the nominalQuota of the resource.
+	// It must be between 1 and 10. For example, a ratio of 1.5 allows
+	// admitting up to 1.5 times the nominal quota.
+	// +required
+	Ratio resource.Quantity `json:"ratio,omitempty"`
 }
 
 // AdmissionChecksStrategy defines a strategy for a AdmissionCheck.
@@ -187,6 +446,22 @@ type AdmissionCheckStrategyRule struct {
 	OnFlavors []ResourceFlavorReference `json:"onFlavors,omitempty"`
 }
 
+type RequeueStrategy string
+
+const (
+	// RequeueStrategyImmediate means that the evicted workloads are requeued
+	// right away.
+	RequeueStrategyImmediate RequeueStrategy = "Immediate"
+
+	// RequeueStrategyBackoff means that the evicted workloads are requeued
+	// after an exponential backoff.
+	RequeueStrategyBackoff RequeueStrategy = "Backoff"
+
+	// RequeueStrategyManual means that the evicted workloads are deactivated
+	// until they are manually reactivated.
+	RequeueStrategyManual RequeueStrategy = "Manual"
+)
+
 type QueueingStrategy string
 
 const (
@@ -450,6 +725,16 @@ const (
 	PreemptionOverBorrowing FlavorFungibilityPreference = "PreemptionOverBorrowing"
 )
 
+// FlavorTieBreakPolicy determines which flavor is assigned among the flavors
+// which fit a Workload equally well.
+type FlavorTieBreakPolicy string
+
+const (
+	FlavorTieBreakNone          FlavorTieBreakPolicy = "None"
+	FlavorTieBreakMostHeadroom  FlavorTieBreakPolicy = "MostHeadroom"
+	FlavorTieBreakLeastHeadroom FlavorTieBreakPolicy = "LeastHeadroom"
+)
+
 // FlavorFungibility determines whether a workload should try the next flavor
 // before borrowing or preempting in current flavor.
 // +kubebuilder:validation:XValidation:rule="!has(self.preference) || (self.whenCanBorrow == 'TryNextFlavor' && self.whenCanPreempt == 'TryNextFlavor')",message="preference can only be set when both whenCanBorrow and whenCanPreempt are TryNextFlavor"
@@ -558,8 +843,74 @@ type ClusterQueuePreemption struct {
 	// +kubebuilder:validation:Enum=Never;LowerPriority;LowerOrNewerEqualPriority
 	// +optional
 	WithinClusterQueue PreemptionPolicy `json:"withinClusterQueue,omitempty"`
+
+	// cooldownSeconds is the time during which the Workloads of this
+	// ClusterQueue are protected from preemption after their quota is reserved.
+	// Additionally, the Workloads of this ClusterQueue which are preempted are
+	// requeued only after this time, so that they aren't admitted again just to
+	// be preempted again under oscillating load.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// PreemptionCooldown feature gate.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CooldownSeconds *int32 `json:"cooldownSeconds,omitempty"`
+
+	// stages is the ordered list of the preemption stages which are attempted
+	// to accommodate a pending Workload. Kueue stops at the first stage which
+	// frees enough quota. The possible values are:
+	//
+	// - `WithinClusterQueue`: preempt Workloads in the ClusterQueue, according
+	//   to withinClusterQueue.
+	// - `ReclaimWithinCohort`: preempt Workloads in the other ClusterQueues of
+	//   the cohort, according to reclaimWithinCohort and borrowWithinCohort.
+	// - `FlavorDowngrade`: assign the next flavors of the resource group.
+	//
+	// When empty, Kueue preempts Workloads in the cohort and in the ClusterQueue
+	// together, and tries the next flavors according to flavorFungibility.
+	// When set, it takes precedence over flavorFungibility.whenCanPreempt.
+	// May only be configured with Classical Preemption, and __not__ with Fair Sharing.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// PreemptionStages feature gate.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=3
+	// +optional
+	Stages []PreemptionStage `json:"stages,omitempty"`
+
+	// mode defines whether the preemptions are issued. The possible values are:
+	//
+	// - `Enforce` (default): the preemption targets are evicted.
+	// - `DryRun`: the preemption targets are only reported with a
+	//   `PreemptionDryRun` event on the pending Workload, and aren't evicted.
+	//   The pending Workload stays pending, as if it couldn't preempt.
+	//
+	// This allows to preview the effects of the preemption policies before
+	// enforcing them.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// PreemptionDryRun feature gate.
+	// +optional
+	Mode PreemptionMode `json:"mode,omitempty"`
 }
 
+// PreemptionStage is a stage of the preemption configured in the stages
+// of the ClusterQueuePreemption.
+// +kubebuilder:validation:Enum=WithinClusterQueue;ReclaimWithinCohort;FlavorDowngrade
+type PreemptionStage string
+
+const (
+	PreemptionStageWithinClusterQueue  PreemptionStage = "WithinClusterQueue"
+	PreemptionStageReclaimWithinCohort PreemptionStage = "ReclaimWithinCohort"
+	PreemptionStageFlavorDowngrade     PreemptionStage = "FlavorDowngrade"
+)
+
+// PreemptionMode defines whether the preemptions of a ClusterQueue are issued.
+// +kubebuilder:validation:Enum=Enforce;DryRun
+type PreemptionMode string
+
+const (
+	PreemptionModeEnforce PreemptionMode = "Enforce"
+	PreemptionModeDryRun  PreemptionMode = "DryRun"
+)
+
 type BorrowWithinCohortPolicy string
 
 const (
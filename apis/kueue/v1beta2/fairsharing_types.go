@@ -57,8 +57,9 @@ type AdmissionScope struct {
 	// in the AdmissionScope. Possible values are:
 	// - UsageBasedAdmissionFairSharing
 	// - NoAdmissionFairSharing
+	// - WeightedRoundRobinAdmissionFairSharing
 	//
-	// +kubebuilder:validation:Enum=UsageBasedAdmissionFairSharing;NoAdmissionFairSharing
+	// +kubebuilder:validation:Enum=UsageBasedAdmissionFairSharing;NoAdmissionFairSharing;WeightedRoundRobinAdmissionFairSharing
 	// +required
 	AdmissionMode AdmissionMode `json:"admissionMode,omitempty"`
 }
@@ -71,4 +72,8 @@ const (
 
 	// AdmissionFairSharing is disabled for this CQ
 	NoAdmissionFairSharing AdmissionMode = "NoAdmissionFairSharing"
+
+	// Admissions are shared among the LocalQueues of the CQ in proportion to
+	// their fairSharing weights, with QueuingStrategy as defined in CQ.
+	WeightedRoundRobinAdmissionFairSharing AdmissionMode = "WeightedRoundRobinAdmissionFairSharing"
 )
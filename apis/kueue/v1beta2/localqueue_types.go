@@ -55,6 +55,41 @@ type LocalQueueSpec struct {
 	// if AdmissionFairSharing is enabled in the Kueue configuration.
 	// +optional
 	FairSharing *FairSharing `json:"fairSharing,omitempty"`
+
+	// guaranteedQuota is the quota of the ClusterQueue reserved for the
+	// workloads of this LocalQueue. While it is not used by the workloads of
+	// this LocalQueue, the guaranteed quota can't be used by the workloads of
+	// the other LocalQueues of the ClusterQueue.
+	// The field is only relevant if the LocalQueueGuaranteedQuota feature gate is enabled.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	GuaranteedQuota []LocalQueueFlavorQuota `json:"guaranteedQuota,omitempty"`
+}
+
+type LocalQueueFlavorQuota struct {
+	// name of the flavor.
+	// +required
+	Name ResourceFlavorReference `json:"name"`
+
+	// resources lists the guaranteed quota for the resources in this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Resources []LocalQueueResourceQuota `json:"resources"`
+}
+
+type LocalQueueResourceQuota struct {
+	// name of the resource.
+	// +required
+	Name corev1.ResourceName `json:"name"`
+
+	// quota is the quantity of the resource guaranteed to the LocalQueue.
+	// +required
+	Quota resource.Quantity `json:"quota"`
 }
 
 type TopologyInfo struct {
@@ -93,6 +93,21 @@ type ProvisioningRequestConfigSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum=IdenticalPodTemplates;IdenticalWorkloadSchedulingRequirements
 	PodSetMergePolicy *ProvisioningRequestConfigPodSetMergePolicy `json:"podSetMergePolicy,omitempty"`
+
+	// maxConcurrentRequests is the maximum number of ProvisioningRequests,
+	// created for the admission checks using this config, that can be waiting
+	// for provisioning at the same time. A ProvisioningRequest stops counting
+	// against the limit once it is Provisioned or Failed.
+	// When the limit is reached, the admission checks of further workloads
+	// remain Pending, and their ProvisioningRequests are created as soon as
+	// the running ones complete.
+	// If null, the number of ProvisioningRequests is not limited.
+	//
+	// This field requires the ProvisioningRequestMaxConcurrentRequests feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
 }
 
 type ProvisioningRequestPodSetUpdates struct {
@@ -46,6 +46,15 @@ const (
 	// +kubebuilder:validation:Type=boolean
 	PodSetUnconstrainedTopologyAnnotation = "kueue.x-k8s.io/podset-unconstrained-topology"
 
+	// PodSetUnconstrainedTopologyFallbackAnnotation indicates that a PodSet
+	// which requires or prefers a topology can be admitted without any topology
+	// constraint if its topology request cannot be satisfied after waiting for
+	// the duration indicated by the annotation value (e.g. "10m"). In that case
+	// the PodSet is admitted without a TopologyAssignment.
+	//
+	// This annotation is alpha-level for the TASUnconstrainedTopologyFallback feature gate.
+	PodSetUnconstrainedTopologyFallbackAnnotation = "kueue.x-k8s.io/podset-unconstrained-topology-fallback"
+
 	// PodSetSliceRequiredTopologyAnnotation indicates that a PodSet requires
 	// Topology Aware Scheduling, and requires scheduling each PodSet slice on nodes
 	// within the topology domain corresponding to the topology level
@@ -68,6 +77,16 @@ const (
 	// This annotation is beta-level for the TASMultiLayerTopology feature gate.
 	PodSetSliceRequiredTopologyConstraintsAnnotation = "kueue.x-k8s.io/podset-slice-required-topology-constraints"
 
+	// PodSetMaxPodsPerDomainAnnotation indicates the maximum number of pods
+	// of a PodSet that Kueue assigns to a single domain of the lowest topology
+	// level, for example, to a single node when the lowest level is
+	// kubernetes.io/hostname. This allows to limit the pods on a node even if
+	// the node has the capacity for more, for example, to avoid the contention
+	// of the network interfaces.
+	//
+	// This annotation is alpha-level for the TASMaxPodsPerDomain feature gate.
+	PodSetMaxPodsPerDomainAnnotation = "kueue.x-k8s.io/podset-max-pods-per-domain"
+
 	// TopologySchedulingGate is used to delay scheduling of a Pod until the
 	// nodeSelectors corresponding to the assigned topology domain are injected
 	// into the Pod. For the Pod-based integrations the gate is added in webhook
@@ -102,6 +121,22 @@ const (
 	// associated with that Workload.
 	// This annotation is alpha-level for the ElasticJobsViaWorkloadSlices feature gate.
 	WorkloadSliceNameAnnotation = "kueue.x-k8s.io/workload-slice-name"
+
+	// PreviousTopologyAssignmentAnnotation is an annotation set by Kueue on the
+	// Workload admitted with Topology Aware Scheduling. It records, per PodSet,
+	// the lowest level topology domains of its TopologyAssignment, so that the
+	// scheduler can prefer them when the Workload is admitted again after being
+	// requeued. It is not set when the recorded domains would exceed 32KiB.
+	// This annotation is alpha-level for the TASPreferPreviousAssignment feature gate.
+	PreviousTopologyAssignmentAnnotation = "kueue.x-k8s.io/previous-topology-assignment"
+
+	// ColocateWithWorkloadAnnotation is an annotation on the Workload, copied
+	// from the Job, which holds the name of another Workload in the same
+	// namespace. When placing the Workload with Topology Aware Scheduling,
+	// the scheduler prefers the topology domains which contain the nodes
+	// assigned to the referenced Workload.
+	// This annotation is alpha-level for the TASColocateWithWorkload feature gate.
+	ColocateWithWorkloadAnnotation = "kueue.x-k8s.io/colocate-with-workload"
 )
 
 // TopologySpec defines the desired state of Topology
@@ -132,6 +167,41 @@ type TopologyLevel struct {
 	// +kubebuilder:validation:MaxLength=316
 	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
 	NodeLabel string `json:"nodeLabel,omitempty"`
+
+	// valueFrom derives the value of the level from a composite node label,
+	// such as "topology=block1-rack3", rather than from the node label named
+	// by nodeLabel. In that case nodeLabel only names the level, and the nodes
+	// don't need to have it. The lowest level of the topology must be
+	// kubernetes.io/hostname when a level is derived.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// TASDerivedTopologyLevels feature gate.
+	//
+	// +optional
+	ValueFrom *TopologyLevelValueSource `json:"valueFrom,omitempty"`
+}
+
+// TopologyLevelValueSource defines how the value of a topology level is
+// derived from a node label.
+type TopologyLevelValueSource struct {
+	// nodeLabel indicates the name of the composite node label.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=316
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
+	NodeLabel string `json:"nodeLabel"`
+
+	// regex is a RE2 regular expression matched against the value of the
+	// node label. The value of the level is the first capture group, or the
+	// whole match if the expression has no capture groups. The nodes whose
+	// label doesn't match the expression are not part of the topology.
+	//
+	// Example: ^(block[0-9]+)-rack[0-9]+$
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Regex string `json:"regex"`
 }
 
 // +genclient
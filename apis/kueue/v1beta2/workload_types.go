@@ -246,6 +246,18 @@ type PodSetTopologyRequest struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MaxItems=3
 	PodsetSliceRequiredTopologyConstraints []PodsetSliceRequiredTopologyConstraint `json:"podsetSliceRequiredTopologyConstraints,omitempty"`
+
+	// maxPodsPerDomain indicates the maximum number of pods of the PodSet
+	// assigned to a single domain of the lowest topology level, as indicated
+	// by the `kueue.x-k8s.io/podset-max-pods-per-domain` annotation.
+	// When the lowest topology level is kubernetes.io/hostname, this is the
+	// maximum number of pods of the PodSet on a single node.
+	//
+	// This field is alpha-level for the TASMaxPodsPerDomain feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPodsPerDomain *int32 `json:"maxPodsPerDomain,omitempty"`
 }
 
 // PodsetSliceRequiredTopologyConstraint defines a single slice topology constraint layer.
@@ -836,6 +848,35 @@ type AdmissionCheckState struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MaxItems=18
 	PodSetUpdates []PodSetUpdate `json:"podSetUpdates,omitempty"`
+
+	// advisory is set by Kueue when the admission check is advisory, so its
+	// state doesn't block the admission of the workload.
+	// +optional
+	Advisory *bool `json:"advisory,omitempty"`
+
+	// artifacts lists the diagnostic artifacts produced by the admission check,
+	// such as reports, which users can consult for details about its state.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	Artifacts []AdmissionCheckArtifact `json:"artifacts,omitempty"`
+}
+
+// AdmissionCheckArtifact is a reference to a diagnostic artifact produced by an
+// admission check.
+type AdmissionCheckArtifact struct {
+	// name of the artifact.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// url of the artifact. It must be an http or https URL.
+	// +required
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
 }
 
 // PodSetUpdate contains a list of pod set modifications suggested by AdmissionChecks.
@@ -1021,6 +1062,22 @@ const (
 	// WorkloadWaitingForReplacementPods means that Kueue doesn't observe all
 	// the Pods declared for the group.
 	WorkloadWaitingForReplacementPods = "WaitingForReplacementPods"
+
+	// WorkloadPodTemplateMutated means that the pod templates of the job were
+	// changed after the Workload was admitted, so that the running job diverges
+	// from what the Workload was admitted for.
+	WorkloadPodTemplateMutated = "PodTemplateMutated"
+)
+
+// Reasons for the WorkloadPodTemplateMutated condition.
+const (
+	// PodTemplateChangedReason indicates the hash of the pod templates of the job
+	// doesn't match the hash recorded when the job was started.
+	PodTemplateChangedReason string = "PodTemplateChanged"
+
+	// PodTemplateMatchesAdmissionReason indicates the pod templates of the job
+	// match again the ones the job was started with.
+	PodTemplateMatchesAdmissionReason string = "PodTemplateMatchesAdmission"
 )
 
 // Reasons for the WorkloadPreemptionBlocked condition.
@@ -1078,6 +1135,10 @@ const (
 	// due to an AdmissionGatedBy annotation.
 	WorkloadAdmissionGated = "AdmissionGated"
 
+	// WorkloadHeld indicates that the workload is inadmissible
+	// due to the kueue.x-k8s.io/hold annotation.
+	WorkloadHeld = "Held"
+
 	// WorkloadEvictedByPreemption indicates that the workload was evicted
 	// in order to free resources for a workload with a higher priority.
 	WorkloadEvictedByPreemption = "Preempted"
@@ -1103,6 +1164,27 @@ const (
 	// because the LocalQueue is Stopped.
 	WorkloadEvictedByLocalQueueStopped = "LocalQueueStopped"
 
+	// WorkloadEvictedByResourceFlavorStopped indicates that the workload was evicted
+	// because a ResourceFlavor assigned to it is Stopped.
+	WorkloadEvictedByResourceFlavorStopped = "ResourceFlavorStopped"
+
+	// WorkloadEvictedByClusterQueueQuotaReduction indicates that the workload
+	// was evicted because the usage of the ClusterQueue exceeded its quota,
+	// for example after the nominalQuota was reduced.
+	WorkloadEvictedByClusterQueueQuotaReduction = "ClusterQueueQuotaReduction"
+
+	// WorkloadEvictedByPodsCreationTimeout indicates that the workload was evicted
+	// because the pods of its job were not created within the timeout set by the
+	// kueue.x-k8s.io/pods-creation-timeout annotation.
+	WorkloadEvictedByPodsCreationTimeout = "PodsCreationTimeout"
+
+	// WorkloadEvictedByPodTemplateMutation indicates that the workload was evicted
+	// because the pod templates of its job were changed after the admission, and
+	// the job requested to be admitted again with the
+	// kueue.x-k8s.io/pod-template-mutation-policy annotation, or because the
+	// pod sets of its suspended job were changed after the quota reservation.
+	WorkloadEvictedByPodTemplateMutation = "PodTemplateMutated"
+
 	// WorkloadEvictedDueToNodeFailures indicates that the workload was evicted
 	// due to non-recoverable node failures.
 	WorkloadEvictedDueToNodeFailures = "NodeFailures"
@@ -75,6 +75,21 @@ func (in *AdmissionCheck) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionCheckArtifact) DeepCopyInto(out *AdmissionCheckArtifact) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckArtifact.
+func (in *AdmissionCheckArtifact) DeepCopy() *AdmissionCheckArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionCheckArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdmissionCheckList) DeepCopyInto(out *AdmissionCheckList) {
 	*out = *in
@@ -130,6 +145,21 @@ func (in *AdmissionCheckSpec) DeepCopyInto(out *AdmissionCheckSpec) {
 		*out = new(AdmissionCheckParametersReference)
 		**out = **in
 	}
+	if in.Advisory != nil {
+		in, out := &in.Advisory, &out.Advisory
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdmissionCheckTimeoutSeconds != nil {
+		in, out := &in.AdmissionCheckTimeoutSeconds, &out.AdmissionCheckTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutAction != nil {
+		in, out := &in.TimeoutAction, &out.TimeoutAction
+		*out = new(AdmissionCheckTimeoutAction)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckSpec.
@@ -163,6 +193,16 @@ func (in *AdmissionCheckState) DeepCopyInto(out *AdmissionCheckState) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Advisory != nil {
+		in, out := &in.Advisory, &out.Advisory
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]AdmissionCheckArtifact, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckState.
@@ -274,6 +314,21 @@ func (in *BorrowWithinCohort) DeepCopy() *BorrowWithinCohort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BorrowingWindow) DeepCopyInto(out *BorrowingWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BorrowingWindow.
+func (in *BorrowingWindow) DeepCopy() *BorrowingWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BorrowingWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterProfileReference) DeepCopyInto(out *ClusterProfileReference) {
 	*out = *in
@@ -356,6 +411,16 @@ func (in *ClusterQueuePreemption) DeepCopyInto(out *ClusterQueuePreemption) {
 		*out = new(BorrowWithinCohort)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CooldownSeconds != nil {
+		in, out := &in.CooldownSeconds, &out.CooldownSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]PreemptionStage, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueuePreemption.
@@ -418,6 +483,67 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(ConcurrentAdmissionPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceBorrowingLimits != nil {
+		in, out := &in.ResourceBorrowingLimits, &out.ResourceBorrowingLimits
+		*out = make([]ResourceBorrowingLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OvercommitRatios != nil {
+		in, out := &in.OvercommitRatios, &out.OvercommitRatios
+		*out = make([]ResourceOvercommitRatio, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PriorityAging != nil {
+		in, out := &in.PriorityAging, &out.PriorityAging
+		*out = new(PriorityAging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequeueStrategy != nil {
+		in, out := &in.RequeueStrategy, &out.RequeueStrategy
+		*out = new(RequeueStrategy)
+		**out = **in
+	}
+	if in.NodeCapacityFlavors != nil {
+		in, out := &in.NodeCapacityFlavors, &out.NodeCapacityFlavors
+		*out = make([]ResourceFlavorReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultContainerRequests != nil {
+		in, out := &in.DefaultContainerRequests, &out.DefaultContainerRequests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.QuotaReductionPolicy != nil {
+		in, out := &in.QuotaReductionPolicy, &out.QuotaReductionPolicy
+		*out = new(QuotaReductionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BorrowingWindows != nil {
+		in, out := &in.BorrowingWindows, &out.BorrowingWindows
+		*out = make([]BorrowingWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAdmittedWorkloads != nil {
+		in, out := &in.MaxAdmittedWorkloads, &out.MaxAdmittedWorkloads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LimitsAccountedResources != nil {
+		in, out := &in.LimitsAccountedResources, &out.LimitsAccountedResources
+		*out = make([]corev1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.FlavorTieBreak != nil {
+		in, out := &in.FlavorTieBreak, &out.FlavorTieBreak
+		*out = new(FlavorTieBreakPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueSpec.
@@ -842,6 +968,28 @@ func (in *LocalQueueFairSharingStatus) DeepCopy() *LocalQueueFairSharingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueFlavorQuota) DeepCopyInto(out *LocalQueueFlavorQuota) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]LocalQueueResourceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueFlavorQuota.
+func (in *LocalQueueFlavorQuota) DeepCopy() *LocalQueueFlavorQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueFlavorQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueFlavorUsage) DeepCopyInto(out *LocalQueueFlavorUsage) {
 	*out = *in
@@ -896,6 +1044,22 @@ func (in *LocalQueueList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueResourceQuota) DeepCopyInto(out *LocalQueueResourceQuota) {
+	*out = *in
+	out.Quota = in.Quota.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueResourceQuota.
+func (in *LocalQueueResourceQuota) DeepCopy() *LocalQueueResourceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueResourceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueResourceUsage) DeepCopyInto(out *LocalQueueResourceUsage) {
 	*out = *in
@@ -925,6 +1089,13 @@ func (in *LocalQueueSpec) DeepCopyInto(out *LocalQueueSpec) {
 		*out = new(FairSharing)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GuaranteedQuota != nil {
+		in, out := &in.GuaranteedQuota, &out.GuaranteedQuota
+		*out = make([]LocalQueueFlavorQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueSpec.
@@ -1303,6 +1474,11 @@ func (in *PodSetTopologyRequest) DeepCopyInto(out *PodSetTopologyRequest) {
 		*out = make([]PodsetSliceRequiredTopologyConstraint, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaxPodsPerDomain != nil {
+		in, out := &in.MaxPodsPerDomain, &out.MaxPodsPerDomain
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetTopologyRequest.
@@ -1404,6 +1580,26 @@ func (in *PreemptionGateState) DeepCopy() *PreemptionGateState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityAging) DeepCopyInto(out *PriorityAging) {
+	*out = *in
+	if in.MaxPriorityIncrement != nil {
+		in, out := &in.MaxPriorityIncrement, &out.MaxPriorityIncrement
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityAging.
+func (in *PriorityAging) DeepCopy() *PriorityAging {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityAging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PriorityClassRef) DeepCopyInto(out *PriorityClassRef) {
 	*out = *in
@@ -1507,6 +1703,11 @@ func (in *ProvisioningRequestConfigSpec) DeepCopyInto(out *ProvisioningRequestCo
 		*out = new(ProvisioningRequestConfigPodSetMergePolicy)
 		**out = **in
 	}
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningRequestConfigSpec.
@@ -1584,6 +1785,26 @@ func (in *ProvisioningRequestRetryStrategy) DeepCopy() *ProvisioningRequestRetry
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaReductionPolicy) DeepCopyInto(out *QuotaReductionPolicy) {
+	*out = *in
+	if in.MaxEvictionsPerInterval != nil {
+		in, out := &in.MaxEvictionsPerInterval, &out.MaxEvictionsPerInterval
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaReductionPolicy.
+func (in *QuotaReductionPolicy) DeepCopy() *QuotaReductionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaReductionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReclaimablePod) DeepCopyInto(out *ReclaimablePod) {
 	*out = *in
@@ -1623,6 +1844,22 @@ func (in *RequeueState) DeepCopy() *RequeueState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceBorrowingLimit) DeepCopyInto(out *ResourceBorrowingLimit) {
+	*out = *in
+	out.BorrowingLimit = in.BorrowingLimit.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBorrowingLimit.
+func (in *ResourceBorrowingLimit) DeepCopy() *ResourceBorrowingLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceBorrowingLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceFlavor) DeepCopyInto(out *ResourceFlavor) {
 	*out = *in
@@ -1710,6 +1947,35 @@ func (in *ResourceFlavorSpec) DeepCopyInto(out *ResourceFlavorSpec) {
 		*out = new(TopologyReference)
 		**out = **in
 	}
+	if in.MinNodeReadySeconds != nil {
+		in, out := &in.MinNodeReadySeconds, &out.MinNodeReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceScaling != nil {
+		in, out := &in.ResourceScaling, &out.ResourceScaling
+		*out = make(map[corev1.ResourceName]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StopPolicy != nil {
+		in, out := &in.StopPolicy, &out.StopPolicy
+		*out = new(StopPolicy)
+		**out = **in
+	}
+	if in.NodeShape != nil {
+		in, out := &in.NodeShape, &out.NodeShape
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFlavorSpec.
@@ -1749,6 +2015,22 @@ func (in *ResourceGroup) DeepCopy() *ResourceGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOvercommitRatio) DeepCopyInto(out *ResourceOvercommitRatio) {
+	*out = *in
+	out.Ratio = in.Ratio.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOvercommitRatio.
+func (in *ResourceOvercommitRatio) DeepCopy() *ResourceOvercommitRatio {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOvercommitRatio)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceQuota) DeepCopyInto(out *ResourceQuota) {
 	*out = *in
@@ -1991,6 +2273,11 @@ func (in *TopologyInfo) DeepCopy() *TopologyInfo {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologyLevel) DeepCopyInto(out *TopologyLevel) {
 	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(TopologyLevelValueSource)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyLevel.
@@ -2003,6 +2290,21 @@ func (in *TopologyLevel) DeepCopy() *TopologyLevel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyLevelValueSource) DeepCopyInto(out *TopologyLevelValueSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyLevelValueSource.
+func (in *TopologyLevelValueSource) DeepCopy() *TopologyLevelValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyLevelValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologyList) DeepCopyInto(out *TopologyList) {
 	*out = *in
@@ -2041,7 +2343,9 @@ func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
 	if in.Levels != nil {
 		in, out := &in.Levels, &out.Levels
 		*out = make([]TopologyLevel, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
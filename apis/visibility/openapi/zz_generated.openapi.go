@@ -3032,12 +3032,18 @@ func schema_kueue_apis_visibility_v1beta1_PendingWorkload(ref common.ReferenceCa
 							Format:      "int32",
 						},
 					},
+					"estimatedWaitTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimatedWaitTime is the estimated time until the workload is admitted, derived from its position in the ClusterQueue and the number of workloads admitted in the ClusterQueue over the last hour. It is only set when the WorkloadEstimatedWaitTime feature gate is enabled, and workloads were admitted in the ClusterQueue over the last hour.",
+							Ref:         ref(v1.Duration{}.OpenAPIModelName()),
+						},
+					},
 				},
 				Required: []string{"priority", "localQueueName", "positionInClusterQueue", "positionInLocalQueue"},
 			},
 		},
 		Dependencies: []string{
-			v1.ObjectMeta{}.OpenAPIModelName()},
+			v1.Duration{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
 	}
 }
 
@@ -3356,12 +3362,18 @@ func schema_kueue_apis_visibility_v1beta2_PendingWorkload(ref common.ReferenceCa
 							Format:      "int32",
 						},
 					},
+					"estimatedWaitTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimatedWaitTime is the estimated time until the workload is admitted, derived from its position in the ClusterQueue and the number of workloads admitted in the ClusterQueue over the last hour. It is only set when the WorkloadEstimatedWaitTime feature gate is enabled, and workloads were admitted in the ClusterQueue over the last hour.",
+							Ref:         ref(v1.Duration{}.OpenAPIModelName()),
+						},
+					},
 				},
 				Required: []string{"priority", "localQueueName", "positionInClusterQueue", "positionInLocalQueue"},
 			},
 		},
 		Dependencies: []string{
-			v1.ObjectMeta{}.OpenAPIModelName()},
+			v1.Duration{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
 	}
 }
 
@@ -77,6 +77,14 @@ type PendingWorkload struct {
 
 	// PositionInLocalQueue indicates the workload's position in the LocalQueue, starting from 0
 	PositionInLocalQueue int32 `json:"positionInLocalQueue"`
+
+	// EstimatedWaitTime is the estimated time until the workload is admitted,
+	// derived from its position in the ClusterQueue and the number of workloads
+	// admitted in the ClusterQueue over the last hour. It is only set when the
+	// WorkloadEstimatedWaitTime feature gate is enabled, and workloads were
+	// admitted in the ClusterQueue over the last hour.
+	// +optional
+	EstimatedWaitTime *metav1.Duration `json:"estimatedWaitTime,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -25,6 +25,7 @@ import (
 	url "net/url"
 	unsafe "unsafe"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -219,6 +220,7 @@ func autoConvert_v1beta1_PendingWorkload_To_v1beta2_PendingWorkload(in *PendingW
 	out.LocalQueueName = kueuev1beta2.LocalQueueName(in.LocalQueueName)
 	out.PositionInClusterQueue = in.PositionInClusterQueue
 	out.PositionInLocalQueue = in.PositionInLocalQueue
+	out.EstimatedWaitTime = (*v1.Duration)(unsafe.Pointer(in.EstimatedWaitTime))
 	return nil
 }
 
@@ -233,6 +235,7 @@ func autoConvert_v1beta2_PendingWorkload_To_v1beta1_PendingWorkload(in *v1beta2.
 	out.LocalQueueName = kueuev1beta1.LocalQueueName(in.LocalQueueName)
 	out.PositionInClusterQueue = in.PositionInClusterQueue
 	out.PositionInLocalQueue = in.PositionInLocalQueue
+	out.EstimatedWaitTime = (*v1.Duration)(unsafe.Pointer(in.EstimatedWaitTime))
 	return nil
 }
 
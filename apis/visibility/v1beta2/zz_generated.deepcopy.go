@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -144,6 +145,11 @@ func (in *LocalQueueList) DeepCopyObject() runtime.Object {
 func (in *PendingWorkload) DeepCopyInto(out *PendingWorkload) {
 	*out = *in
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.EstimatedWaitTime != nil {
+		in, out := &in.EstimatedWaitTime, &out.EstimatedWaitTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingWorkload.
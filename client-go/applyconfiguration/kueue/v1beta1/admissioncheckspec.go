@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
 // AdmissionCheckSpecApplyConfiguration represents a declarative configuration of the AdmissionCheckSpec type for use
 // with apply.
 //
@@ -35,6 +39,29 @@ type AdmissionCheckSpecApplyConfiguration struct {
 	// parameters identifies a configuration with additional parameters for the
 	// check.
 	Parameters *AdmissionCheckParametersReferenceApplyConfiguration `json:"parameters,omitempty"`
+	// advisory indicates that the check records a result without blocking the
+	// admission of the Workloads. A Pending or Retry state doesn't hold the
+	// admission, and a Rejected state is recorded as a warning event instead
+	// of deactivating the Workload.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// AdvisoryAdmissionChecks feature gate.
+	Advisory *bool `json:"advisory,omitempty"`
+	// admissionCheckTimeoutSeconds is the maximum time a Workload can keep its
+	// quota reserved while the check is Pending. When exceeded, the quota
+	// reservation is released and the timeoutAction is applied.
+	// If not set, there is no timeout.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// AdmissionCheckTimeout feature gate.
+	AdmissionCheckTimeoutSeconds *int32 `json:"admissionCheckTimeoutSeconds,omitempty"`
+	// timeoutAction is the action applied to a Workload once the
+	// admissionCheckTimeoutSeconds is exceeded. Possible values are:
+	//
+	// - Requeue: the Workload is evicted and requeued, following the
+	// backoff of the evicted Workloads.
+	// - Deactivate: the Workload is evicted and deactivated.
+	//
+	// Defaults to Requeue.
+	TimeoutAction *kueuev1beta1.AdmissionCheckTimeoutAction `json:"timeoutAction,omitempty"`
 }
 
 // AdmissionCheckSpecApplyConfiguration constructs a declarative configuration of the AdmissionCheckSpec type for use with
@@ -66,3 +93,27 @@ func (b *AdmissionCheckSpecApplyConfiguration) WithParameters(value *AdmissionCh
 	b.Parameters = value
 	return b
 }
+
+// WithAdvisory sets the Advisory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Advisory field is set to the value of the last call.
+func (b *AdmissionCheckSpecApplyConfiguration) WithAdvisory(value bool) *AdmissionCheckSpecApplyConfiguration {
+	b.Advisory = &value
+	return b
+}
+
+// WithAdmissionCheckTimeoutSeconds sets the AdmissionCheckTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdmissionCheckTimeoutSeconds field is set to the value of the last call.
+func (b *AdmissionCheckSpecApplyConfiguration) WithAdmissionCheckTimeoutSeconds(value int32) *AdmissionCheckSpecApplyConfiguration {
+	b.AdmissionCheckTimeoutSeconds = &value
+	return b
+}
+
+// WithTimeoutAction sets the TimeoutAction field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeoutAction field is set to the value of the last call.
+func (b *AdmissionCheckSpecApplyConfiguration) WithTimeoutAction(value kueuev1beta1.AdmissionCheckTimeoutAction) *AdmissionCheckSpecApplyConfiguration {
+	b.TimeoutAction = &value
+	return b
+}
@@ -30,6 +30,14 @@ type TopologyLevelApplyConfiguration struct {
 	// - cloud.provider.com/topology-block
 	// - cloud.provider.com/topology-rack
 	NodeLabel *string `json:"nodeLabel,omitempty"`
+	// valueFrom derives the value of the level from a composite node label,
+	// such as "topology=block1-rack3", rather than from the node label named
+	// by nodeLabel. In that case nodeLabel only names the level, and the nodes
+	// don't need to have it. The lowest level of the topology must be
+	// kubernetes.io/hostname when a level is derived.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// TASDerivedTopologyLevels feature gate.
+	ValueFrom *TopologyLevelValueSourceApplyConfiguration `json:"valueFrom,omitempty"`
 }
 
 // TopologyLevelApplyConfiguration constructs a declarative configuration of the TopologyLevel type for use with
@@ -45,3 +53,11 @@ func (b *TopologyLevelApplyConfiguration) WithNodeLabel(value string) *TopologyL
 	b.NodeLabel = &value
 	return b
 }
+
+// WithValueFrom sets the ValueFrom field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ValueFrom field is set to the value of the last call.
+func (b *TopologyLevelApplyConfiguration) WithValueFrom(value *TopologyLevelValueSourceApplyConfiguration) *TopologyLevelApplyConfiguration {
+	b.ValueFrom = value
+	return b
+}
@@ -51,6 +51,12 @@ type AdmissionCheckStateApplyConfiguration struct {
 	RetryCount *int32 `json:"retryCount,omitempty"`
 	// podSetUpdates contains a list of pod set modifications suggested by AdmissionChecks.
 	PodSetUpdates []PodSetUpdateApplyConfiguration `json:"podSetUpdates,omitempty"`
+	// advisory is set by Kueue when the admission check is advisory, so its
+	// state doesn't block the admission of the workload.
+	Advisory *bool `json:"advisory,omitempty"`
+	// artifacts lists the diagnostic artifacts produced by the admission check,
+	// such as reports, which users can consult for details about its state.
+	Artifacts []AdmissionCheckArtifactApplyConfiguration `json:"artifacts,omitempty"`
 }
 
 // AdmissionCheckStateApplyConfiguration constructs a declarative configuration of the AdmissionCheckState type for use with
@@ -119,3 +125,24 @@ func (b *AdmissionCheckStateApplyConfiguration) WithPodSetUpdates(values ...*Pod
 	}
 	return b
 }
+
+// WithAdvisory sets the Advisory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Advisory field is set to the value of the last call.
+func (b *AdmissionCheckStateApplyConfiguration) WithAdvisory(value bool) *AdmissionCheckStateApplyConfiguration {
+	b.Advisory = &value
+	return b
+}
+
+// WithArtifacts adds the given value to the Artifacts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Artifacts field.
+func (b *AdmissionCheckStateApplyConfiguration) WithArtifacts(values ...*AdmissionCheckArtifactApplyConfiguration) *AdmissionCheckStateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithArtifacts")
+		}
+		b.Artifacts = append(b.Artifacts, *values[i])
+	}
+	return b
+}
@@ -77,6 +77,43 @@ type ClusterQueuePreemptionApplyConfiguration struct {
 	// either have a lower priority than the pending workload or equal priority
 	// and are newer than the pending workload.
 	WithinClusterQueue *kueuev1beta2.PreemptionPolicy `json:"withinClusterQueue,omitempty"`
+	// cooldownSeconds is the time during which the Workloads of this
+	// ClusterQueue are protected from preemption after their quota is reserved.
+	// Additionally, the Workloads of this ClusterQueue which are preempted are
+	// requeued only after this time, so that they aren't admitted again just to
+	// be preempted again under oscillating load.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// PreemptionCooldown feature gate.
+	CooldownSeconds *int32 `json:"cooldownSeconds,omitempty"`
+	// stages is the ordered list of the preemption stages which are attempted
+	// to accommodate a pending Workload. Kueue stops at the first stage which
+	// frees enough quota. The possible values are:
+	//
+	// - `WithinClusterQueue`: preempt Workloads in the ClusterQueue, according
+	// to withinClusterQueue.
+	// - `ReclaimWithinCohort`: preempt Workloads in the other ClusterQueues of
+	// the cohort, according to reclaimWithinCohort and borrowWithinCohort.
+	// - `FlavorDowngrade`: assign the next flavors of the resource group.
+	//
+	// When empty, Kueue preempts Workloads in the cohort and in the ClusterQueue
+	// together, and tries the next flavors according to flavorFungibility.
+	// When set, it takes precedence over flavorFungibility.whenCanPreempt.
+	// May only be configured with Classical Preemption, and __not__ with Fair Sharing.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// PreemptionStages feature gate.
+	Stages []kueuev1beta2.PreemptionStage `json:"stages,omitempty"`
+	// mode defines whether the preemptions are issued. The possible values are:
+	//
+	// - `Enforce` (default): the preemption targets are evicted.
+	// - `DryRun`: the preemption targets are only reported with a
+	// `PreemptionDryRun` event on the pending Workload, and aren't evicted.
+	// The pending Workload stays pending, as if it couldn't preempt.
+	//
+	// This allows to preview the effects of the preemption policies before
+	// enforcing them.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// PreemptionDryRun feature gate.
+	Mode *kueuev1beta2.PreemptionMode `json:"mode,omitempty"`
 }
 
 // ClusterQueuePreemptionApplyConfiguration constructs a declarative configuration of the ClusterQueuePreemption type for use with
@@ -108,3 +145,29 @@ func (b *ClusterQueuePreemptionApplyConfiguration) WithWithinClusterQueue(value
 	b.WithinClusterQueue = &value
 	return b
 }
+
+// WithCooldownSeconds sets the CooldownSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CooldownSeconds field is set to the value of the last call.
+func (b *ClusterQueuePreemptionApplyConfiguration) WithCooldownSeconds(value int32) *ClusterQueuePreemptionApplyConfiguration {
+	b.CooldownSeconds = &value
+	return b
+}
+
+// WithStages adds the given value to the Stages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Stages field.
+func (b *ClusterQueuePreemptionApplyConfiguration) WithStages(values ...kueuev1beta2.PreemptionStage) *ClusterQueuePreemptionApplyConfiguration {
+	for i := range values {
+		b.Stages = append(b.Stages, values[i])
+	}
+	return b
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *ClusterQueuePreemptionApplyConfiguration) WithMode(value kueuev1beta2.PreemptionMode) *ClusterQueuePreemptionApplyConfiguration {
+	b.Mode = &value
+	return b
+}
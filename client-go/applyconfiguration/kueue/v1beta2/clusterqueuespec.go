@@ -19,6 +19,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	kueuev1beta2 "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 )
@@ -91,6 +92,118 @@ type ClusterQueueSpecApplyConfiguration struct {
 	// Additionally after the admission, Workloads can still try to pursue capacity on the more preferable flavors while running.
 	// It enables them to migrate to more preferable, whenever capacity appears.
 	ConcurrentAdmissionPolicy *ConcurrentAdmissionPolicyApplyConfiguration `json:"concurrentAdmissionPolicy,omitempty"`
+	// resourceBorrowingLimits caps, per resource, the total quantity that this
+	// ClusterQueue is allowed to borrow from the unused quota of other
+	// ClusterQueues in the same cohort, summed across all the flavors that
+	// provide the resource. The borrowingLimit of each [flavor, resource]
+	// combination still applies.
+	// Each name must be one of the coveredResources of the resourceGroups.
+	// resourceBorrowingLimits must be empty if spec.cohortName is empty.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ResourceBorrowingLimits feature gate.
+	ResourceBorrowingLimits []ResourceBorrowingLimitApplyConfiguration `json:"resourceBorrowingLimits,omitempty"`
+	// overcommitRatios lets this ClusterQueue admit more than its nominal quota
+	// of compressible resources. For each listed resource, the nominalQuota of
+	// every flavor providing the resource is multiplied by the ratio when
+	// computing the available quota. Workloads are still admitted based on
+	// their requests.
+	// The overcommitted quota is only available to this ClusterQueue: it is not
+	// lent to the cohort, and the nominal quota is reported as configured.
+	// Only cpu can be overcommitted; resources such as memory or GPUs always
+	// use their nominal quota.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ClusterQueueOvercommit feature gate.
+	OvercommitRatios []ResourceOvercommitRatioApplyConfiguration `json:"overcommitRatios,omitempty"`
+	// priorityAging gradually increases the effective priority of the pending
+	// Workloads of this ClusterQueue the longer they wait, so that low priority
+	// Workloads are not starved by a steady stream of higher priority ones.
+	// The increase only affects the order in which the Workloads of this
+	// ClusterQueue are considered for admission; the priority of the Workloads
+	// is not modified.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// PriorityAging feature gate.
+	PriorityAging *PriorityAgingApplyConfiguration `json:"priorityAging,omitempty"`
+	// requeueStrategy defines how the Workloads of this ClusterQueue that were
+	// evicted because their Pods didn't become ready within the waitForPodsReady
+	// timeout are requeued.
+	// Depending on its value, the evicted Workloads are:
+	// - Backoff - requeued after the backoff configured in waitForPodsReady.requeuingStrategy.
+	// - Immediate - requeued right away, without waiting for a backoff.
+	// - Manual - deactivated; they are requeued once spec.active is set back to true.
+	// When not set, Backoff is used.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// RequeueStrategy feature gate.
+	RequeueStrategy *kueuev1beta2.RequeueStrategy `json:"requeueStrategy,omitempty"`
+	// nodeCapacityFlavors is a list of ResourceFlavors' names whose nominalQuota
+	// is derived from the allocatable capacity of the schedulable and Ready nodes
+	// of the flavor, rather than from the nominalQuota in resourceGroups.
+	// The quota is updated as nodes are added to or removed from the cluster,
+	// for example by an autoscaler. The flavors must be Topology Aware
+	// Scheduling flavors, as their nodes are tracked through the topology.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// NodeCapacityQuota feature gate.
+	NodeCapacityFlavors []kueuev1beta2.ResourceFlavorReference `json:"nodeCapacityFlavors,omitempty"`
+	// defaultContainerRequests are the resource requests accounted for the
+	// containers of the Workloads which neither request nor limit the
+	// resource, after applying the LimitRanges of the namespace.
+	// This prevents the Workloads with request-less containers from being
+	// admitted at no cost. The webhook for Pods sets the same requests on the
+	// Pods of the Workloads, so that they request the accounted resources on
+	// the nodes.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ClusterQueueDefaultRequests feature gate.
+	DefaultContainerRequests *corev1.ResourceList `json:"defaultContainerRequests,omitempty"`
+	// quotaReductionPolicy defines how the Workloads of this ClusterQueue are
+	// evicted when its usage exceeds the available quota, for example after
+	// the nominalQuota is reduced. The lowest priority Workloads are evicted
+	// first, gradually, until the usage fits the quota again.
+	// When not set, the admitted Workloads keep running, and only the
+	// admission of new Workloads is blocked.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// QuotaReductionEviction feature gate.
+	QuotaReductionPolicy *QuotaReductionPolicyApplyConfiguration `json:"quotaReductionPolicy,omitempty"`
+	// borrowingWindows restricts borrowing unused quota from the cohort to
+	// recurring time windows. When set, the Workloads of this ClusterQueue are
+	// admitted beyond its nominalQuota only while one of the windows is open.
+	// Outside of the windows, the admitted Workloads which borrow keep running,
+	// and can be reclaimed by the other ClusterQueues of the cohort.
+	// When not set, borrowing is allowed at any time.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// BorrowingWindows feature gate.
+	BorrowingWindows []BorrowingWindowApplyConfiguration `json:"borrowingWindows,omitempty"`
+	// maxAdmittedWorkloads is the maximum number of Workloads which can have
+	// quota reserved in this ClusterQueue at the same time, regardless of
+	// the available quota. The excess Workloads stay pending until some of
+	// the admitted Workloads finish or are evicted.
+	// When not set, the number of admitted Workloads is not limited.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ClusterQueueMaxAdmittedWorkloads feature gate.
+	MaxAdmittedWorkloads *int32 `json:"maxAdmittedWorkloads,omitempty"`
+	// limitsAccountedResources is the list of resources whose quota usage is
+	// computed from the limits of the containers, rather than from their requests,
+	// when the limits are higher. This prevents the burstable Workloads from being
+	// admitted beyond what the nodes can sustain when they use the resources up to
+	// their limits. The resources not in the list are accounted for their requests.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ClusterQueueLimitsAccounting feature gate.
+	LimitsAccountedResources []corev1.ResourceName `json:"limitsAccountedResources,omitempty"`
+	// flavorTieBreak determines which flavor is assigned when several flavors
+	// fit the Workload equally well, that is, without preemption and at the same
+	// borrowing level. The possible values are:
+	//
+	// - `None` (default): assign the first such flavor, in the order of the
+	// flavors in the resource group.
+	// - `MostHeadroom`: assign the flavor with the most headroom, to balance
+	// the usage of the flavors.
+	// - `LeastHeadroom`: assign the flavor with the least headroom, to
+	// consolidate the usage of the flavors.
+	//
+	// The headroom of a flavor is the smallest fraction of the quota available
+	// to the ClusterQueue, among the requested resources, which remains unused
+	// after the Workload is admitted.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// FlavorTieBreak feature gate.
+	FlavorTieBreak *kueuev1beta2.FlavorTieBreakPolicy `json:"flavorTieBreak,omitempty"`
 }
 
 // ClusterQueueSpecApplyConfiguration constructs a declarative configuration of the ClusterQueueSpec type for use with
@@ -191,3 +304,110 @@ func (b *ClusterQueueSpecApplyConfiguration) WithConcurrentAdmissionPolicy(value
 	b.ConcurrentAdmissionPolicy = value
 	return b
 }
+
+// WithResourceBorrowingLimits adds the given value to the ResourceBorrowingLimits field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceBorrowingLimits field.
+func (b *ClusterQueueSpecApplyConfiguration) WithResourceBorrowingLimits(values ...*ResourceBorrowingLimitApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceBorrowingLimits")
+		}
+		b.ResourceBorrowingLimits = append(b.ResourceBorrowingLimits, *values[i])
+	}
+	return b
+}
+
+// WithOvercommitRatios adds the given value to the OvercommitRatios field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OvercommitRatios field.
+func (b *ClusterQueueSpecApplyConfiguration) WithOvercommitRatios(values ...*ResourceOvercommitRatioApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOvercommitRatios")
+		}
+		b.OvercommitRatios = append(b.OvercommitRatios, *values[i])
+	}
+	return b
+}
+
+// WithPriorityAging sets the PriorityAging field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityAging field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithPriorityAging(value *PriorityAgingApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.PriorityAging = value
+	return b
+}
+
+// WithRequeueStrategy sets the RequeueStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequeueStrategy field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithRequeueStrategy(value kueuev1beta2.RequeueStrategy) *ClusterQueueSpecApplyConfiguration {
+	b.RequeueStrategy = &value
+	return b
+}
+
+// WithNodeCapacityFlavors adds the given value to the NodeCapacityFlavors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodeCapacityFlavors field.
+func (b *ClusterQueueSpecApplyConfiguration) WithNodeCapacityFlavors(values ...kueuev1beta2.ResourceFlavorReference) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		b.NodeCapacityFlavors = append(b.NodeCapacityFlavors, values[i])
+	}
+	return b
+}
+
+// WithDefaultContainerRequests sets the DefaultContainerRequests field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultContainerRequests field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithDefaultContainerRequests(value corev1.ResourceList) *ClusterQueueSpecApplyConfiguration {
+	b.DefaultContainerRequests = &value
+	return b
+}
+
+// WithQuotaReductionPolicy sets the QuotaReductionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QuotaReductionPolicy field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithQuotaReductionPolicy(value *QuotaReductionPolicyApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.QuotaReductionPolicy = value
+	return b
+}
+
+// WithBorrowingWindows adds the given value to the BorrowingWindows field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the BorrowingWindows field.
+func (b *ClusterQueueSpecApplyConfiguration) WithBorrowingWindows(values ...*BorrowingWindowApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithBorrowingWindows")
+		}
+		b.BorrowingWindows = append(b.BorrowingWindows, *values[i])
+	}
+	return b
+}
+
+// WithMaxAdmittedWorkloads sets the MaxAdmittedWorkloads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAdmittedWorkloads field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithMaxAdmittedWorkloads(value int32) *ClusterQueueSpecApplyConfiguration {
+	b.MaxAdmittedWorkloads = &value
+	return b
+}
+
+// WithLimitsAccountedResources adds the given value to the LimitsAccountedResources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the LimitsAccountedResources field.
+func (b *ClusterQueueSpecApplyConfiguration) WithLimitsAccountedResources(values ...corev1.ResourceName) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		b.LimitsAccountedResources = append(b.LimitsAccountedResources, values[i])
+	}
+	return b
+}
+
+// WithFlavorTieBreak sets the FlavorTieBreak field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlavorTieBreak field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithFlavorTieBreak(value kueuev1beta2.FlavorTieBreakPolicy) *ClusterQueueSpecApplyConfiguration {
+	b.FlavorTieBreak = &value
+	return b
+}
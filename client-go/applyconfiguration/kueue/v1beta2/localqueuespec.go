@@ -42,6 +42,12 @@ type LocalQueueSpecApplyConfiguration struct {
 	// participating in AdmissionFairSharing.  The values are only relevant
 	// if AdmissionFairSharing is enabled in the Kueue configuration.
 	FairSharing *FairSharingApplyConfiguration `json:"fairSharing,omitempty"`
+	// guaranteedQuota is the quota of the ClusterQueue reserved for the
+	// workloads of this LocalQueue. While it is not used by the workloads of
+	// this LocalQueue, the guaranteed quota can't be used by the workloads of
+	// the other LocalQueues of the ClusterQueue.
+	// The field is only relevant if the LocalQueueGuaranteedQuota feature gate is enabled.
+	GuaranteedQuota []LocalQueueFlavorQuotaApplyConfiguration `json:"guaranteedQuota,omitempty"`
 }
 
 // LocalQueueSpecApplyConfiguration constructs a declarative configuration of the LocalQueueSpec type for use with
@@ -73,3 +79,16 @@ func (b *LocalQueueSpecApplyConfiguration) WithFairSharing(value *FairSharingApp
 	b.FairSharing = value
 	return b
 }
+
+// WithGuaranteedQuota adds the given value to the GuaranteedQuota field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the GuaranteedQuota field.
+func (b *LocalQueueSpecApplyConfiguration) WithGuaranteedQuota(values ...*LocalQueueFlavorQuotaApplyConfiguration) *LocalQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithGuaranteedQuota")
+		}
+		b.GuaranteedQuota = append(b.GuaranteedQuota, *values[i])
+	}
+	return b
+}
@@ -72,6 +72,14 @@ type PodSetTopologyRequestApplyConfiguration struct {
 	//
 	// This annotation is alpha-level for the TASMultiLayerTopology feature gate.
 	PodsetSliceRequiredTopologyConstraints []PodsetSliceRequiredTopologyConstraintApplyConfiguration `json:"podsetSliceRequiredTopologyConstraints,omitempty"`
+	// maxPodsPerDomain indicates the maximum number of pods of the PodSet
+	// assigned to a single domain of the lowest topology level, as indicated
+	// by the `kueue.x-k8s.io/podset-max-pods-per-domain` annotation.
+	// When the lowest topology level is kubernetes.io/hostname, this is the
+	// maximum number of pods of the PodSet on a single node.
+	//
+	// This field is alpha-level for the TASMaxPodsPerDomain feature gate.
+	MaxPodsPerDomain *int32 `json:"maxPodsPerDomain,omitempty"`
 }
 
 // PodSetTopologyRequestApplyConfiguration constructs a declarative configuration of the PodSetTopologyRequest type for use with
@@ -164,3 +172,11 @@ func (b *PodSetTopologyRequestApplyConfiguration) WithPodsetSliceRequiredTopolog
 	}
 	return b
 }
+
+// WithMaxPodsPerDomain sets the MaxPodsPerDomain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxPodsPerDomain field is set to the value of the last call.
+func (b *PodSetTopologyRequestApplyConfiguration) WithMaxPodsPerDomain(value int32) *PodSetTopologyRequestApplyConfiguration {
+	b.MaxPodsPerDomain = &value
+	return b
+}
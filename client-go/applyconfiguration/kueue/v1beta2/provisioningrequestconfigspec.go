@@ -62,6 +62,17 @@ type ProvisioningRequestConfigSpecApplyConfiguration struct {
 	// - `IdenticalPodTemplates`: Merges only identical PodTemplates.
 	// - `IdenticalWorkloadSchedulingRequirements`: Merges PodTemplates with identical fields that are considered when defining the workload scheduling requirements.
 	PodSetMergePolicy *kueuev1beta2.ProvisioningRequestConfigPodSetMergePolicy `json:"podSetMergePolicy,omitempty"`
+	// maxConcurrentRequests is the maximum number of ProvisioningRequests,
+	// created for the admission checks using this config, that can be waiting
+	// for provisioning at the same time. A ProvisioningRequest stops counting
+	// against the limit once it is Provisioned or Failed.
+	// When the limit is reached, the admission checks of further workloads
+	// remain Pending, and their ProvisioningRequests are created as soon as
+	// the running ones complete.
+	// If null, the number of ProvisioningRequests is not limited.
+	//
+	// This field requires the ProvisioningRequestMaxConcurrentRequests feature gate.
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
 }
 
 // ProvisioningRequestConfigSpecApplyConfiguration constructs a declarative configuration of the ProvisioningRequestConfigSpec type for use with
@@ -125,3 +136,11 @@ func (b *ProvisioningRequestConfigSpecApplyConfiguration) WithPodSetMergePolicy(
 	b.PodSetMergePolicy = &value
 	return b
 }
+
+// WithMaxConcurrentRequests sets the MaxConcurrentRequests field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxConcurrentRequests field is set to the value of the last call.
+func (b *ProvisioningRequestConfigSpecApplyConfiguration) WithMaxConcurrentRequests(value int32) *ProvisioningRequestConfigSpecApplyConfiguration {
+	b.MaxConcurrentRequests = &value
+	return b
+}
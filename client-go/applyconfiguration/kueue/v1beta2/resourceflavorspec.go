@@ -19,7 +19,9 @@ limitations under the License.
 package v1beta2
 
 import (
-	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	kueuev1beta2 "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 )
 
@@ -52,7 +54,7 @@ type ResourceFlavorSpecApplyConfiguration struct {
 	// cloud.provider.com/preemptible="true":NoSchedule
 	//
 	// nodeTaints can be up to 8 elements.
-	NodeTaints []v1.TaintApplyConfiguration `json:"nodeTaints,omitempty"`
+	NodeTaints []corev1.TaintApplyConfiguration `json:"nodeTaints,omitempty"`
 	// tolerations are extra tolerations that will be added to the pods admitted in
 	// the quota associated with this resource flavor.
 	//
@@ -60,11 +62,57 @@ type ResourceFlavorSpecApplyConfiguration struct {
 	// cloud.provider.com/preemptible="true":NoSchedule
 	//
 	// tolerations can be up to 8 elements.
-	Tolerations []v1.TolerationApplyConfiguration `json:"tolerations,omitempty"`
+	Tolerations []corev1.TolerationApplyConfiguration `json:"tolerations,omitempty"`
 	// topologyName indicates topology for the TAS ResourceFlavor.
 	// When specified, it enables scraping of the topology information from the
 	// nodes matching to the Resource Flavor node labels.
 	TopologyName *kueuev1beta2.TopologyReference `json:"topologyName,omitempty"`
+	// minNodeReadySeconds is the minimum number of seconds a Node of the TAS
+	// ResourceFlavor needs to be Ready before it contributes capacity to the
+	// topology domains. Nodes which are not Ready, or are unschedulable, never
+	// contribute capacity. When not set, a Node contributes capacity as soon
+	// as it is Ready.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// TASNodeReadinessGating feature gate.
+	MinNodeReadySeconds *int32 `json:"minNodeReadySeconds,omitempty"`
+	// namespaceSelector restricts the namespaces whose Workloads can be assigned
+	// this ResourceFlavor, so that the ClusterQueues shared by several tenants
+	// only assign the flavor to the Workloads of the intended namespaces.
+	// Defaults to null, which means that the Workloads of all the namespaces
+	// can be assigned the flavor.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ResourceFlavorNamespaceSelector feature gate.
+	NamespaceSelector *metav1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	// resourceScaling maps the names of resources to the number of units of
+	// the resource advertised by the nodes for each unit of quota in this
+	// ResourceFlavor, so that the quota reflects the physical hardware.
+	// For example, when the nodes advertise 4 nvidia.com/gpu replicas per
+	// physical GPU with time-slicing, setting nvidia.com/gpu to 4 makes 4
+	// requested GPUs count as 1 unit of quota. The usage is rounded up.
+	// resourceScaling can't be set when topologyName is set.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ResourceFlavorResourceScaling feature gate.
+	ResourceScaling map[v1.ResourceName]int32 `json:"resourceScaling,omitempty"`
+	// stopPolicy - if set to a value different from None, no new reservation
+	// is made in this ResourceFlavor, while the ClusterQueues using it remain
+	// active and can keep admitting workloads in their other flavors.
+	//
+	// Depending on its value, the workloads assigned to this flavor will:
+	//
+	// - None - Workloads are admitted
+	// - HoldAndDrain - Admitted workloads are evicted and Reserving workloads will cancel the reservation.
+	// - Hold - Admitted workloads will run to completion and Reserving workloads will cancel the reservation.
+	//
+	// This field is in alpha stage. To use this field, you need to enable the
+	// ResourceFlavorStopPolicy feature gate.
+	StopPolicy *kueuev1beta2.StopPolicy `json:"stopPolicy,omitempty"`
+	// nodeShape is the allocatable resources of a single node of this
+	// ResourceFlavor. It is used to resolve the requests of the pods which
+	// request a share of a node with the kueue.x-k8s.io/node-share annotation,
+	// for example "100%" to request a whole node.
+	// This field is in alpha stage. To use this field, you need to enable the
+	// NodeShareRequests feature gate.
+	NodeShape *v1.ResourceList `json:"nodeShape,omitempty"`
 }
 
 // ResourceFlavorSpecApplyConfiguration constructs a declarative configuration of the ResourceFlavorSpec type for use with
@@ -90,7 +138,7 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithNodeLabels(entries map[string
 // WithNodeTaints adds the given value to the NodeTaints field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the NodeTaints field.
-func (b *ResourceFlavorSpecApplyConfiguration) WithNodeTaints(values ...*v1.TaintApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
+func (b *ResourceFlavorSpecApplyConfiguration) WithNodeTaints(values ...*corev1.TaintApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
 	for i := range values {
 		if values[i] == nil {
 			panic("nil value passed to WithNodeTaints")
@@ -103,7 +151,7 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithNodeTaints(values ...*v1.Tain
 // WithTolerations adds the given value to the Tolerations field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Tolerations field.
-func (b *ResourceFlavorSpecApplyConfiguration) WithTolerations(values ...*v1.TolerationApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
+func (b *ResourceFlavorSpecApplyConfiguration) WithTolerations(values ...*corev1.TolerationApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
 	for i := range values {
 		if values[i] == nil {
 			panic("nil value passed to WithTolerations")
@@ -120,3 +168,49 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithTopologyName(value kueuev1bet
 	b.TopologyName = &value
 	return b
 }
+
+// WithMinNodeReadySeconds sets the MinNodeReadySeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinNodeReadySeconds field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithMinNodeReadySeconds(value int32) *ResourceFlavorSpecApplyConfiguration {
+	b.MinNodeReadySeconds = &value
+	return b
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithNamespaceSelector(value *metav1.LabelSelectorApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithResourceScaling puts the entries into the ResourceScaling field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the ResourceScaling field,
+// overwriting an existing map entries in ResourceScaling field with the same key.
+func (b *ResourceFlavorSpecApplyConfiguration) WithResourceScaling(entries map[v1.ResourceName]int32) *ResourceFlavorSpecApplyConfiguration {
+	if b.ResourceScaling == nil && len(entries) > 0 {
+		b.ResourceScaling = make(map[v1.ResourceName]int32, len(entries))
+	}
+	for k, v := range entries {
+		b.ResourceScaling[k] = v
+	}
+	return b
+}
+
+// WithStopPolicy sets the StopPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StopPolicy field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithStopPolicy(value kueuev1beta2.StopPolicy) *ResourceFlavorSpecApplyConfiguration {
+	b.StopPolicy = &value
+	return b
+}
+
+// WithNodeShape sets the NodeShape field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeShape field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithNodeShape(value v1.ResourceList) *ResourceFlavorSpecApplyConfiguration {
+	b.NodeShape = &value
+	return b
+}
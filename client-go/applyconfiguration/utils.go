@@ -42,6 +42,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.AdmissionApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheck"):
 		return &kueuev1beta1.AdmissionCheckApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckArtifact"):
+		return &kueuev1beta1.AdmissionCheckArtifactApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckParametersReference"):
 		return &kueuev1beta1.AdmissionCheckParametersReferenceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckSpec"):
@@ -160,6 +162,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.TopologyInfoApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("TopologyLevel"):
 		return &kueuev1beta1.TopologyLevelApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("TopologyLevelValueSource"):
+		return &kueuev1beta1.TopologyLevelValueSourceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("TopologySpec"):
 		return &kueuev1beta1.TopologySpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("UnhealthyNode"):
@@ -180,6 +184,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta2.AdmissionApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("AdmissionCheck"):
 		return &kueuev1beta2.AdmissionCheckApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("AdmissionCheckArtifact"):
+		return &kueuev1beta2.AdmissionCheckArtifactApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("AdmissionCheckParametersReference"):
 		return &kueuev1beta2.AdmissionCheckParametersReferenceApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("AdmissionCheckSpec"):
@@ -196,6 +202,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta2.AdmissionScopeApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("BorrowWithinCohort"):
 		return &kueuev1beta2.BorrowWithinCohortApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("BorrowingWindow"):
+		return &kueuev1beta2.BorrowingWindowApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ClusterProfileReference"):
 		return &kueuev1beta2.ClusterProfileReferenceApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ClusterQueue"):
@@ -238,8 +246,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta2.LocalQueueAdmissionFairSharingStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("LocalQueueFairSharingStatus"):
 		return &kueuev1beta2.LocalQueueFairSharingStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("LocalQueueFlavorQuota"):
+		return &kueuev1beta2.LocalQueueFlavorQuotaApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("LocalQueueFlavorUsage"):
 		return &kueuev1beta2.LocalQueueFlavorUsageApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("LocalQueueResourceQuota"):
+		return &kueuev1beta2.LocalQueueResourceQuotaApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("LocalQueueResourceUsage"):
 		return &kueuev1beta2.LocalQueueResourceUsageApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("LocalQueueSpec"):
@@ -272,6 +284,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta2.PreemptionGateApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("PreemptionGateState"):
 		return &kueuev1beta2.PreemptionGateStateApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("PriorityAging"):
+		return &kueuev1beta2.PriorityAgingApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("PriorityClassRef"):
 		return &kueuev1beta2.PriorityClassRefApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ProvisioningRequestConfig"):
@@ -284,16 +298,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta2.ProvisioningRequestPodSetUpdatesNodeSelectorApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ProvisioningRequestRetryStrategy"):
 		return &kueuev1beta2.ProvisioningRequestRetryStrategyApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("QuotaReductionPolicy"):
+		return &kueuev1beta2.QuotaReductionPolicyApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ReclaimablePod"):
 		return &kueuev1beta2.ReclaimablePodApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("RequeueState"):
 		return &kueuev1beta2.RequeueStateApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("ResourceBorrowingLimit"):
+		return &kueuev1beta2.ResourceBorrowingLimitApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ResourceFlavor"):
 		return &kueuev1beta2.ResourceFlavorApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ResourceFlavorSpec"):
 		return &kueuev1beta2.ResourceFlavorSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ResourceGroup"):
 		return &kueuev1beta2.ResourceGroupApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("ResourceOvercommitRatio"):
+		return &kueuev1beta2.ResourceOvercommitRatioApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ResourceQuota"):
 		return &kueuev1beta2.ResourceQuotaApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("ResourceUsage"):
@@ -314,6 +334,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta2.TopologyAssignmentSlicePodCountsApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("TopologyLevel"):
 		return &kueuev1beta2.TopologyLevelApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("TopologyLevelValueSource"):
+		return &kueuev1beta2.TopologyLevelValueSourceApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("TopologySpec"):
 		return &kueuev1beta2.TopologySpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("UnhealthyNode"):
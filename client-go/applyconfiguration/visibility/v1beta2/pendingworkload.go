@@ -40,6 +40,12 @@ type PendingWorkloadApplyConfiguration struct {
 	PositionInClusterQueue *int32 `json:"positionInClusterQueue,omitempty"`
 	// PositionInLocalQueue indicates the workload's position in the LocalQueue, starting from 0
 	PositionInLocalQueue *int32 `json:"positionInLocalQueue,omitempty"`
+	// EstimatedWaitTime is the estimated time until the workload is admitted,
+	// derived from its position in the ClusterQueue and the number of workloads
+	// admitted in the ClusterQueue over the last hour. It is only set when the
+	// WorkloadEstimatedWaitTime feature gate is enabled, and workloads were
+	// admitted in the ClusterQueue over the last hour.
+	EstimatedWaitTime *metav1.Duration `json:"estimatedWaitTime,omitempty"`
 }
 
 // PendingWorkloadApplyConfiguration constructs a declarative configuration of the PendingWorkload type for use with
@@ -222,6 +228,14 @@ func (b *PendingWorkloadApplyConfiguration) WithPositionInLocalQueue(value int32
 	return b
 }
 
+// WithEstimatedWaitTime sets the EstimatedWaitTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EstimatedWaitTime field is set to the value of the last call.
+func (b *PendingWorkloadApplyConfiguration) WithEstimatedWaitTime(value metav1.Duration) *PendingWorkloadApplyConfiguration {
+	b.EstimatedWaitTime = &value
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *PendingWorkloadApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
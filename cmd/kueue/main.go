@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	zaplog "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -63,6 +64,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/concurrentadmission"
 	"sigs.k8s.io/kueue/pkg/controller/core"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/controller/cronjob"
 	"sigs.k8s.io/kueue/pkg/controller/elasticjobs"
 	"sigs.k8s.io/kueue/pkg/controller/failurerecovery"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
@@ -87,6 +89,8 @@ import (
 	"sigs.k8s.io/kueue/pkg/version"
 	"sigs.k8s.io/kueue/pkg/visibility"
 	"sigs.k8s.io/kueue/pkg/webhooks"
+	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workloadevents"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -229,6 +233,11 @@ func main() {
 			config.GetCertificate = metricsCertWatcher.GetCertificate
 		})
 	}
+	var workloadEvents *workloadevents.Broadcaster
+	if features.Enabled(features.WorkloadEventsStream) {
+		workloadEvents = workloadevents.NewBroadcaster()
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{workloadevents.Path: workloadEvents}
+	}
 	options.Metrics = metricsServerOptions
 
 	lqMetrics := metrics.NewLocalQueueMetricsConfig(cfg.Metrics.LocalQueueMetrics)
@@ -340,6 +349,20 @@ func main() {
 	}
 	cCache := schdcache.New(mgr.GetClient(), cacheOptions...)
 
+	if features.Enabled(features.CacheConsistencyCheck) {
+		if err := mgr.Add(schdcache.NewConsistencyChecker(cCache, schdcache.ConsistencyCheckInterval)); err != nil {
+			setupLog.Error(err, "Unable to add cache consistency checker to manager")
+			os.Exit(1)
+		}
+	}
+
+	if workloadEvents != nil {
+		if err := workloadEvents.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to add workload events stream to manager")
+			os.Exit(1)
+		}
+	}
+
 	// setup inadmissible workload requeuer
 	requeuer := qcache.NewRequeuer()
 	if err := mgr.Add(requeuer); err != nil {
@@ -398,7 +421,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	if failedWebhook, err := webhooks.Setup(mgr, roleTracker); err != nil {
+	if failedWebhook, err := webhooks.Setup(mgr, roleTracker,
+		webhooks.WithEvictionHooksMaxTimeout(evictionHooksMaxTimeout(&cfg)),
+		webhooks.WithFairSharing(fairsharing.Enabled(cfg.FairSharing)),
+	); err != nil {
 		setupLog.Error(err, "Unable to create webhook", "webhook", failedWebhook)
 		os.Exit(1)
 	}
@@ -551,6 +577,12 @@ func setupControllers(
 		}
 	}
 
+	if features.Enabled(features.CronJobQuotaReservation) {
+		if failedCtrl, err := cronjob.SetupWithManager(mgr, opts.RoleTracker); err != nil {
+			return fmt.Errorf("could not setup %s controller: %w", failedCtrl, err)
+		}
+	}
+
 	if features.Enabled(features.ConcurrentAdmission) {
 		if failedCtrl, err := concurrentadmission.SetupControllers(mgr, queues, cfg, opts.RoleTracker); err != nil {
 			return fmt.Errorf("could not setup ConcurrentAdmission controller %s: %w", failedCtrl, err)
@@ -565,6 +597,9 @@ func setupControllers(
 		jobframework.WithEnabledExternalFrameworks(cfg.Integrations.ExternalFrameworks),
 		jobframework.WithManagerName(constants.KueueName),
 		jobframework.WithLabelKeysToCopy(cfg.Integrations.LabelKeysToCopy),
+		jobframework.WithPodLabelPropagation(cfg.Integrations.PodLabelPropagation),
+		jobframework.WithDefaultWorkloadPriorityClass(ptr.Deref(cfg.DefaultWorkloadPriorityClass, "")),
+		jobframework.WithEvictionHooksMaxTimeout(evictionHooksMaxTimeout(cfg)),
 		jobframework.WithCache(cCache),
 		jobframework.WithQueues(queues),
 		jobframework.WithObjectRetentionPolicies(cfg.ObjectRetentionPolicies),
@@ -638,6 +673,7 @@ func setupScheduler(
 		scheduler.WithRoleTracker(roleTracker),
 		scheduler.WithPreemptionExpectations(preemptionExpectations),
 		scheduler.WithCustomLabels(customLabels),
+		scheduler.WithWorkers(schedulerWorkers(cfg)),
 	)
 	if err := mgr.Add(sched); err != nil {
 		return fmt.Errorf("unable to add scheduler to manager: %w", err)
@@ -697,6 +733,21 @@ func quotaCheckStrategy(cfg *configapi.Configuration) configapi.QuotaCheckStrate
 	return configapi.QuotaCheckBlockUndeclared
 }
 
+func schedulerWorkers(cfg *configapi.Configuration) int {
+	if features.Enabled(features.ConcurrentCohortScheduling) && cfg.Scheduler != nil &&
+		cfg.Scheduler.Workers != nil {
+		return int(*cfg.Scheduler.Workers)
+	}
+	return 1
+}
+
+func evictionHooksMaxTimeout(cfg *configapi.Configuration) time.Duration {
+	if cfg.EvictionHooks != nil && cfg.EvictionHooks.MaxTimeout != nil {
+		return cfg.EvictionHooks.MaxTimeout.Duration
+	}
+	return workload.DefaultEvictionHooksMaxTimeout
+}
+
 func apply(configFile string) (ctrl.Options, configapi.Configuration, error) {
 	options, cfg, err := config.Load(scheme, configFile)
 	if err != nil {
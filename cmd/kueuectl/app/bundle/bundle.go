@@ -0,0 +1,138 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
+)
+
+var errUnsupportedObject = errors.New("unsupported object")
+
+// lastAppliedConfigAnnotation is set by kubectl apply. It is not exported, as
+// it refers to the configuration applied to the source cluster.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// bundle holds the cluster-scoped Kueue configuration. The objects are applied
+// in the order of the fields, so that the referenced objects are created first:
+// the Topologies referenced by the ResourceFlavors, the ResourceFlavors
+// referenced by the Cohorts and ClusterQueues, and the Cohorts referenced by
+// the ClusterQueues.
+type bundle struct {
+	topologies      []kueue.Topology
+	resourceFlavors []kueue.ResourceFlavor
+	cohorts         []kueue.Cohort
+	clusterQueues   []kueue.ClusterQueue
+}
+
+// objects returns the objects of the bundle in the order in which they are applied.
+func (b *bundle) objects() []runtime.Object {
+	objs := make([]runtime.Object, 0, len(b.topologies)+len(b.resourceFlavors)+len(b.cohorts)+len(b.clusterQueues))
+	for i := range b.topologies {
+		objs = append(objs, &b.topologies[i])
+	}
+	for i := range b.resourceFlavors {
+		objs = append(objs, &b.resourceFlavors[i])
+	}
+	for i := range b.cohorts {
+		objs = append(objs, &b.cohorts[i])
+	}
+	for i := range b.clusterQueues {
+		objs = append(objs, &b.clusterQueues[i])
+	}
+	return objs
+}
+
+// sort sorts the objects of each kind by name, so that the bundle is stable.
+func (b *bundle) sort() {
+	slices.SortFunc(b.topologies, func(a, b kueue.Topology) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(b.resourceFlavors, func(a, b kueue.ResourceFlavor) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(b.cohorts, func(a, b kueue.Cohort) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(b.clusterQueues, func(a, b kueue.ClusterQueue) int { return strings.Compare(a.Name, b.Name) })
+}
+
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{APIVersion: kueue.SchemeGroupVersion.String(), Kind: kind}
+}
+
+// portableObjectMeta keeps only the metadata of the object which describes
+// its configuration, dropping the fields populated by the API server and the
+// controllers of the source cluster.
+func portableObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	out := metav1.ObjectMeta{
+		Name:   meta.Name,
+		Labels: meta.Labels,
+	}
+	for k, v := range meta.Annotations {
+		if k == lastAppliedConfigAnnotation {
+			continue
+		}
+		if out.Annotations == nil {
+			out.Annotations = make(map[string]string, len(meta.Annotations))
+		}
+		out.Annotations[k] = v
+	}
+	return out
+}
+
+// readBundle decodes the Kueue objects of a YAML or JSON manifest bundle.
+func readBundle(r io.Reader) (*bundle, error) {
+	b := &bundle{}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	deserializer := scheme.Codecs.UniversalDeserializer()
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+		obj, gvk, err := deserializer.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		switch o := obj.(type) {
+		case *kueue.ResourceFlavor:
+			b.resourceFlavors = append(b.resourceFlavors, *o)
+		case *kueue.Topology:
+			b.topologies = append(b.topologies, *o)
+		case *kueue.Cohort:
+			b.cohorts = append(b.cohorts, *o)
+		case *kueue.ClusterQueue:
+			b.clusterQueues = append(b.clusterQueues, *o)
+		default:
+			return nil, fmt.Errorf("%w: %s", errUnsupportedObject, gvk)
+		}
+	}
+	return b, nil
+}
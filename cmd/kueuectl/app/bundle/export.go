@@ -0,0 +1,164 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	kueuev1beta2 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta2"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/clientgetter"
+)
+
+var (
+	exportLong = templates.LongDesc(`
+		Export the ResourceFlavors, Topologies, Cohorts and ClusterQueues of the
+		cluster as a single YAML manifest bundle.
+
+		Only the configuration of the objects is exported. The status and the
+		metadata populated by the cluster are omitted, so that the bundle can be
+		imported to another cluster with "kueuectl import".
+	`)
+	exportExample = templates.Examples(`
+		# Export the Kueue configuration to a file
+		kueuectl export > kueue-config.yaml
+	`)
+)
+
+type ExportOptions struct {
+	Client kueuev1beta2.KueueV1beta2Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewExportOptions(streams genericiooptions.IOStreams) *ExportOptions {
+	return &ExportOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewExportCmd(clientGetter clientgetter.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewExportOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "export",
+		DisableFlagsInUseLine: true,
+		Short:                 "Export the ClusterQueues, ResourceFlavors, Cohorts and Topologies as a manifest bundle",
+		Long:                  exportLong,
+		Example:               exportExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *ExportOptions) Complete(clientGetter clientgetter.ClientGetter) error {
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta2()
+
+	return nil
+}
+
+// Run prints the manifest bundle.
+func (o *ExportOptions) Run(ctx context.Context) error {
+	b, err := o.exportBundle(ctx)
+	if err != nil {
+		return err
+	}
+
+	printer := &printers.YAMLPrinter{}
+	for _, obj := range b.objects() {
+		if err := printer.PrintObj(obj, o.Out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *ExportOptions) exportBundle(ctx context.Context) (*bundle, error) {
+	b := &bundle{}
+
+	rfs, err := o.Client.ResourceFlavors().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, rf := range rfs.Items {
+		b.resourceFlavors = append(b.resourceFlavors, kueue.ResourceFlavor{
+			TypeMeta:   typeMeta("ResourceFlavor"),
+			ObjectMeta: portableObjectMeta(rf.ObjectMeta),
+			Spec:       rf.Spec,
+		})
+	}
+
+	topologies, err := o.Client.Topologies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, topology := range topologies.Items {
+		b.topologies = append(b.topologies, kueue.Topology{
+			TypeMeta:   typeMeta("Topology"),
+			ObjectMeta: portableObjectMeta(topology.ObjectMeta),
+			Spec:       topology.Spec,
+		})
+	}
+
+	cohorts, err := o.Client.Cohorts().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cohort := range cohorts.Items {
+		b.cohorts = append(b.cohorts, kueue.Cohort{
+			TypeMeta:   typeMeta("Cohort"),
+			ObjectMeta: portableObjectMeta(cohort.ObjectMeta),
+			Spec:       cohort.Spec,
+		})
+	}
+
+	cqs, err := o.Client.ClusterQueues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cq := range cqs.Items {
+		b.clusterQueues = append(b.clusterQueues, kueue.ClusterQueue{
+			TypeMeta:   typeMeta("ClusterQueue"),
+			ObjectMeta: portableObjectMeta(cq.ObjectMeta),
+			Spec:       cq.Spec,
+		})
+	}
+
+	b.sort()
+	return b, nil
+}
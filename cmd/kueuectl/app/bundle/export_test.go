@@ -0,0 +1,194 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+// withClusterMetadata sets the metadata populated by the API server and the
+// controllers, which isn't part of the exported configuration.
+func withClusterMetadata[T metav1.Object](obj T) T {
+	obj.SetUID(types.UID("uid-" + obj.GetName()))
+	obj.SetResourceVersion("7")
+	obj.SetGeneration(3)
+	obj.SetFinalizers([]string{kueue.ResourceInUseFinalizerName})
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[lastAppliedConfigAnnotation] = "{}"
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func withCPUReservation(cq *kueue.ClusterQueue, flavor kueue.ResourceFlavorReference, total string) *kueue.ClusterQueue {
+	cq.Status.FlavorsReservation = append(cq.Status.FlavorsReservation, kueue.FlavorUsage{
+		Name: flavor,
+		Resources: []kueue.ResourceUsage{{
+			Name:  corev1.ResourceCPU,
+			Total: resource.MustParse(total),
+		}},
+	})
+	return cq
+}
+
+func TestExportRun(t *testing.T) {
+	testCases := map[string]struct {
+		objs       []runtime.Object
+		wantOut    string
+		wantOutErr string
+		wantErr    error
+	}{
+		"should export the configuration without the cluster metadata and status": {
+			objs: []runtime.Object{
+				withCPUReservation(withClusterMetadata(utiltestingapi.MakeClusterQueue("cq-b").
+					Cohort("all").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("tas").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj()), "tas", "1"),
+				withClusterMetadata(utiltestingapi.MakeClusterQueue("cq-a").
+					Cohort("all").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj()),
+				withClusterMetadata(utiltestingapi.MakeCohort("all").Label("team", "ml").Obj()),
+				withClusterMetadata(utiltestingapi.MakeTopology("default").Levels(corev1.LabelHostname).Obj()),
+				withClusterMetadata(utiltestingapi.MakeResourceFlavor("tas").TopologyName("default").Obj()),
+				withClusterMetadata(utiltestingapi.MakeResourceFlavor("default").NodeLabel("instance-type", "on-demand").Obj()),
+			},
+			wantOut: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: Topology
+metadata:
+  name: default
+spec:
+  levels:
+  - nodeLabel: kubernetes.io/hostname
+---
+apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: default
+spec:
+  nodeLabels:
+    instance-type: on-demand
+---
+apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: tas
+spec:
+  topologyName: default
+---
+apiVersion: kueue.x-k8s.io/v1beta2
+kind: Cohort
+metadata:
+  labels:
+    team: ml
+  name: all
+spec: {}
+status: {}
+---
+apiVersion: kueue.x-k8s.io/v1beta2
+kind: ClusterQueue
+metadata:
+  name: cq-a
+spec:
+  cohortName: all
+  flavorFungibility:
+    whenCanBorrow: MayStopSearch
+    whenCanPreempt: TryNextFlavor
+  namespaceSelector: {}
+  queueingStrategy: BestEffortFIFO
+  resourceGroups:
+  - coveredResources:
+    - cpu
+    flavors:
+    - name: default
+      resources:
+      - name: cpu
+        nominalQuota: "4"
+status:
+  admittedWorkloads: 0
+  pendingWorkloads: 0
+  reservingWorkloads: 0
+---
+apiVersion: kueue.x-k8s.io/v1beta2
+kind: ClusterQueue
+metadata:
+  name: cq-b
+spec:
+  cohortName: all
+  flavorFungibility:
+    whenCanBorrow: MayStopSearch
+    whenCanPreempt: TryNextFlavor
+  namespaceSelector: {}
+  queueingStrategy: BestEffortFIFO
+  resourceGroups:
+  - coveredResources:
+    - cpu
+    flavors:
+    - name: tas
+      resources:
+      - name: cpu
+        nominalQuota: "2"
+status:
+  admittedWorkloads: 0
+  pendingWorkloads: 0
+  reservingWorkloads: 0
+`,
+		},
+		"should export an empty bundle": {},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(fake.NewSimpleClientset(tc.objs...))
+
+			cmd := NewExportCmd(tcg, streams)
+			cmd.SetArgs(nil)
+
+			gotErr := cmd.Execute()
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			gotOutErr := outErr.String()
+			if diff := cmp.Diff(tc.wantOutErr, gotOutErr); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
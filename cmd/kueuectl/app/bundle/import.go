@@ -0,0 +1,329 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	kueuev1beta2 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta2"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/clientgetter"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/dryrun"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/flags"
+)
+
+var (
+	importLong = templates.LongDesc(`
+		Import the ResourceFlavors, Topologies, Cohorts and ClusterQueues of a
+		manifest bundle created with "kueuectl export".
+
+		Before applying any object, the references of the bundle are validated:
+		the ResourceFlavors referenced by the ClusterQueues and Cohorts, the
+		Topologies referenced by the ResourceFlavors, and the Cohorts referenced
+		by the ClusterQueues, must be part of the bundle or exist in the cluster. The objects are created, or updated if they
+		already exist, with the referenced objects first.
+	`)
+	importExample = templates.Examples(`
+		# Import the Kueue configuration from a file
+		kueuectl import -f kueue-config.yaml
+
+		# Validate the references of the bundle without applying it
+		kueuectl import -f kueue-config.yaml --dry-run client
+	`)
+)
+
+var (
+	errDuplicateObject  = errors.New("duplicate object in the bundle")
+	errFlavorNotFound   = errors.New("resource flavor not found")
+	errTopologyNotFound = errors.New("topology not found")
+	errCohortNotFound   = errors.New("cohort not found")
+)
+
+type ImportOptions struct {
+	Filename       string
+	DryRunStrategy dryrun.Strategy
+
+	Client kueuev1beta2.KueueV1beta2Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewImportOptions(streams genericiooptions.IOStreams) *ImportOptions {
+	return &ImportOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewImportCmd(clientGetter clientgetter.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewImportOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "import -f FILENAME [--dry-run STRATEGY]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Import the ClusterQueues, ResourceFlavors, Cohorts and Topologies of a manifest bundle",
+		Long:                  importLong,
+		Example:               importExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, cmd)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	flags.AddDryRunFlag(cmd)
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", "",
+		`The file that contains the bundle to import, or "-" to read it from the standard input (required).`)
+
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *ImportOptions) Complete(clientGetter clientgetter.ClientGetter, cmd *cobra.Command) error {
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta2()
+
+	o.DryRunStrategy, err = dryrun.GetStrategy(cmd)
+	return err
+}
+
+// Run validates the references of the bundle and applies it.
+func (o *ImportOptions) Run(ctx context.Context) error {
+	b, err := o.readBundle()
+	if err != nil {
+		return err
+	}
+
+	if err := o.validate(ctx, b); err != nil {
+		return err
+	}
+
+	for i := range b.topologies {
+		if err := applyObject(ctx, o, o.Client.Topologies(), &b.topologies[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.resourceFlavors {
+		if err := applyObject(ctx, o, o.Client.ResourceFlavors(), &b.resourceFlavors[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.cohorts {
+		if err := applyObject(ctx, o, o.Client.Cohorts(), &b.cohorts[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.clusterQueues {
+		if err := applyObject(ctx, o, o.Client.ClusterQueues(), &b.clusterQueues[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *ImportOptions) readBundle() (*bundle, error) {
+	if o.Filename == "-" {
+		return readBundle(o.In)
+	}
+	f, err := os.Open(o.Filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readBundle(f)
+}
+
+// validate checks that the names of the objects of the bundle are unique, and
+// that the objects referenced by the bundle are part of it or exist in the cluster.
+func (o *ImportOptions) validate(ctx context.Context, b *bundle) error {
+	var errs []error
+
+	flavors := sets.New[kueue.ResourceFlavorReference]()
+	for _, rf := range b.resourceFlavors {
+		if flavors.Has(kueue.ResourceFlavorReference(rf.Name)) {
+			errs = append(errs, fmt.Errorf("%w: ResourceFlavor %q", errDuplicateObject, rf.Name))
+		}
+		flavors.Insert(kueue.ResourceFlavorReference(rf.Name))
+	}
+	topologies := sets.New[kueue.TopologyReference]()
+	for _, topology := range b.topologies {
+		if topologies.Has(kueue.TopologyReference(topology.Name)) {
+			errs = append(errs, fmt.Errorf("%w: Topology %q", errDuplicateObject, topology.Name))
+		}
+		topologies.Insert(kueue.TopologyReference(topology.Name))
+	}
+	cohorts := sets.New[string]()
+	for _, cohort := range b.cohorts {
+		if cohorts.Has(cohort.Name) {
+			errs = append(errs, fmt.Errorf("%w: Cohort %q", errDuplicateObject, cohort.Name))
+		}
+		cohorts.Insert(cohort.Name)
+	}
+	cqs := sets.New[string]()
+	for _, cq := range b.clusterQueues {
+		if cqs.Has(cq.Name) {
+			errs = append(errs, fmt.Errorf("%w: ClusterQueue %q", errDuplicateObject, cq.Name))
+		}
+		cqs.Insert(cq.Name)
+	}
+
+	flavorExists := func(name kueue.ResourceFlavorReference) (bool, error) {
+		if flavors.Has(name) {
+			return true, nil
+		}
+		_, err := o.Client.ResourceFlavors().Get(ctx, string(name), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		// Check each flavor in the cluster only once.
+		flavors.Insert(name)
+		return true, nil
+	}
+	checkFlavors := func(kind, name string, resourceGroups []kueue.ResourceGroup) error {
+		for _, rg := range resourceGroups {
+			for _, fq := range rg.Flavors {
+				found, err := flavorExists(fq.Name)
+				if err != nil {
+					return err
+				}
+				if !found {
+					errs = append(errs, fmt.Errorf("%w: %q referenced by %s %q", errFlavorNotFound, fq.Name, kind, name))
+				}
+			}
+		}
+		return nil
+	}
+	for _, cohort := range b.cohorts {
+		if err := checkFlavors("Cohort", cohort.Name, cohort.Spec.ResourceGroups); err != nil {
+			return err
+		}
+	}
+	for _, cq := range b.clusterQueues {
+		if err := checkFlavors("ClusterQueue", cq.Name, cq.Spec.ResourceGroups); err != nil {
+			return err
+		}
+	}
+
+	for _, rf := range b.resourceFlavors {
+		if rf.Spec.TopologyName == nil || topologies.Has(*rf.Spec.TopologyName) {
+			continue
+		}
+		_, err := o.Client.Topologies().Get(ctx, string(*rf.Spec.TopologyName), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("%w: %q referenced by ResourceFlavor %q", errTopologyNotFound, *rf.Spec.TopologyName, rf.Name))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		topologies.Insert(*rf.Spec.TopologyName)
+	}
+
+	for _, cq := range b.clusterQueues {
+		if cq.Spec.CohortName == "" || cohorts.Has(string(cq.Spec.CohortName)) {
+			continue
+		}
+		_, err := o.Client.Cohorts().Get(ctx, string(cq.Spec.CohortName), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("%w: %q referenced by ClusterQueue %q", errCohortNotFound, cq.Spec.CohortName, cq.Name))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		cohorts.Insert(string(cq.Spec.CohortName))
+	}
+
+	return errors.Join(errs...)
+}
+
+type bundleObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+type bundleClient[T bundleObject] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+}
+
+// applyObject creates the object, or updates it if it already exists, and
+// prints the name of the object with the operation.
+func applyObject[T bundleObject](ctx context.Context, o *ImportOptions, c bundleClient[T], obj T) error {
+	operation := "created"
+	existing, err := c.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if o.DryRunStrategy != dryrun.Client {
+			createOptions := metav1.CreateOptions{}
+			if o.DryRunStrategy == dryrun.Server {
+				createOptions.DryRun = []string{metav1.DryRunAll}
+			}
+			if _, err := c.Create(ctx, obj, createOptions); err != nil {
+				return err
+			}
+		}
+	case err != nil:
+		return err
+	default:
+		operation = "configured"
+		if o.DryRunStrategy != dryrun.Client {
+			obj.SetResourceVersion(existing.GetResourceVersion())
+			updateOptions := metav1.UpdateOptions{}
+			if o.DryRunStrategy == dryrun.Server {
+				updateOptions.DryRun = []string{metav1.DryRunAll}
+			}
+			if _, err := c.Update(ctx, obj, updateOptions); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch o.DryRunStrategy {
+	case dryrun.Client:
+		operation += " (client dry run)"
+	case dryrun.Server:
+		operation += " (server dry run)"
+	}
+	return (&printers.NamePrinter{Operation: operation}).PrintObj(obj, o.Out)
+}
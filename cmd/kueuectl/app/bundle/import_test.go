@@ -0,0 +1,329 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func TestImportRun(t *testing.T) {
+	testCases := map[string]struct {
+		objs       []runtime.Object
+		args       []string
+		in         string
+		wantOut    string
+		wantOutErr string
+		wantErr    error
+		wantRFs    []string
+		wantCQs    []string
+	}{
+		"should create the objects": {
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ClusterQueue
+metadata:
+  name: cq-a
+spec:
+  resourceGroups:
+  - coveredResources:
+    - cpu
+    flavors:
+    - name: default
+      resources:
+      - name: cpu
+        nominalQuota: "4"
+---
+apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: default
+`,
+			wantOut: `resourceflavor.kueue.x-k8s.io/default created
+clusterqueue.kueue.x-k8s.io/cq-a created
+`,
+			wantRFs: []string{"default"},
+			wantCQs: []string{"cq-a"},
+		},
+		"should update the existing objects": {
+			objs: []runtime.Object{
+				utiltestingapi.MakeResourceFlavor("default").Obj(),
+			},
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: default
+spec:
+  nodeLabels:
+    instance-type: on-demand
+`,
+			wantOut: `resourceflavor.kueue.x-k8s.io/default configured
+`,
+			wantRFs: []string{"default"},
+		},
+		"should not create the objects with client dry run": {
+			args: []string{"-f", "-", "--dry-run", "client"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: default
+`,
+			wantOut: `resourceflavor.kueue.x-k8s.io/default created (client dry run)
+`,
+		},
+		"should accept a flavor which exists in the cluster": {
+			objs: []runtime.Object{
+				utiltestingapi.MakeResourceFlavor("default").Obj(),
+			},
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: Cohort
+metadata:
+  name: all
+spec:
+  resourceGroups:
+  - coveredResources:
+    - cpu
+    flavors:
+    - name: default
+      resources:
+      - name: cpu
+        nominalQuota: "4"
+`,
+			wantOut: `cohort.kueue.x-k8s.io/all created
+`,
+			wantRFs: []string{"default"},
+		},
+		"should fail when the referenced flavor is missing": {
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ClusterQueue
+metadata:
+  name: cq-a
+spec:
+  resourceGroups:
+  - coveredResources:
+    - cpu
+    flavors:
+    - name: default
+      resources:
+      - name: cpu
+        nominalQuota: "4"
+`,
+			wantErr: errFlavorNotFound,
+		},
+		"should fail when the referenced topology is missing": {
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: tas
+spec:
+  topologyName: default
+`,
+			wantErr: errTopologyNotFound,
+		},
+		"should create the topologies before the resource flavors": {
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: tas
+spec:
+  topologyName: default
+---
+apiVersion: kueue.x-k8s.io/v1beta2
+kind: Topology
+metadata:
+  name: default
+spec:
+  levels:
+  - nodeLabel: kubernetes.io/hostname
+`,
+			wantOut: `topology.kueue.x-k8s.io/default created
+resourceflavor.kueue.x-k8s.io/tas created
+`,
+			wantRFs: []string{"tas"},
+		},
+		"should accept a cohort which exists in the cluster": {
+			objs: []runtime.Object{
+				utiltestingapi.MakeCohort("all").Obj(),
+			},
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ClusterQueue
+metadata:
+  name: cq-a
+spec:
+  cohortName: all
+`,
+			wantOut: `clusterqueue.kueue.x-k8s.io/cq-a created
+`,
+			wantCQs: []string{"cq-a"},
+		},
+		"should fail when the referenced cohort is missing": {
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ClusterQueue
+metadata:
+  name: cq-a
+spec:
+  cohortName: all
+`,
+			wantErr: errCohortNotFound,
+		},
+		"should fail on duplicate objects": {
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: default
+---
+apiVersion: kueue.x-k8s.io/v1beta2
+kind: ResourceFlavor
+metadata:
+  name: default
+`,
+			wantErr: errDuplicateObject,
+		},
+		"should fail on unsupported objects": {
+			args: []string{"-f", "-"},
+			in: `apiVersion: kueue.x-k8s.io/v1beta2
+kind: LocalQueue
+metadata:
+  name: lq
+  namespace: default
+spec:
+  clusterQueue: cq-a
+`,
+			wantErr: errUnsupportedObject,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, in, out, outErr := genericiooptions.NewTestIOStreams()
+			in.WriteString(tc.in)
+
+			clientset := fake.NewSimpleClientset(tc.objs...)
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+
+			cmd := NewImportCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+
+			gotErr := cmd.Execute()
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if tc.wantErr == nil {
+				gotOut := out.String()
+				if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+					t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+				}
+			}
+
+			rfs, err := clientset.KueueV1beta2().ResourceFlavors().List(t.Context(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list the resource flavors: %v", err)
+			}
+			var gotRFs []string
+			for _, rf := range rfs.Items {
+				gotRFs = append(gotRFs, rf.Name)
+			}
+			if diff := cmp.Diff(tc.wantRFs, gotRFs); diff != "" {
+				t.Errorf("Unexpected resource flavors (-want/+got)\n%s", diff)
+			}
+
+			cqs, err := clientset.KueueV1beta2().ClusterQueues().List(t.Context(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list the cluster queues: %v", err)
+			}
+			var gotCQs []string
+			for _, cq := range cqs.Items {
+				gotCQs = append(gotCQs, cq.Name)
+			}
+			if diff := cmp.Diff(tc.wantCQs, gotCQs); diff != "" {
+				t.Errorf("Unexpected cluster queues (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	objs := []runtime.Object{
+		withClusterMetadata(utiltestingapi.MakeResourceFlavor("default").NodeLabel("instance-type", "on-demand").Obj()),
+		withClusterMetadata(utiltestingapi.MakeResourceFlavor("tas").TopologyName("default").Obj()),
+		withClusterMetadata(utiltestingapi.MakeTopology("default").Levels("cloud.com/block", corev1.LabelHostname).Obj()),
+		withClusterMetadata(utiltestingapi.MakeCohort("all").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "8").Obj()).
+			Obj()),
+		withCPUReservation(withClusterMetadata(utiltestingapi.MakeClusterQueue("cq-a").
+			Cohort("all").
+			ResourceGroup(
+				*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj(),
+				*utiltestingapi.MakeFlavorQuotas("tas").Resource(corev1.ResourceCPU, "2").Obj(),
+			).
+			Obj()), "default", "1"),
+	}
+
+	source := cmdtesting.NewTestClientGetter().WithKueueClientset(fake.NewSimpleClientset(objs...))
+	exportStreams, _, exported, _ := genericiooptions.NewTestIOStreams()
+	exportCmd := NewExportCmd(source, exportStreams)
+	exportCmd.SetArgs(nil)
+	if err := exportCmd.Execute(); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	target := fake.NewSimpleClientset()
+	importStreams, in, _, _ := genericiooptions.NewTestIOStreams()
+	in.WriteString(exported.String())
+	importCmd := NewImportCmd(cmdtesting.NewTestClientGetter().WithKueueClientset(target), importStreams)
+	importCmd.SetArgs([]string{"-f", "-"})
+	if err := importCmd.Execute(); err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+
+	want, err := (&ExportOptions{Client: fake.NewSimpleClientset(objs...).KueueV1beta2()}).exportBundle(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to export the source configuration: %v", err)
+	}
+	got, err := (&ExportOptions{Client: target.KueueV1beta2()}).exportBundle(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to export the imported configuration: %v", err)
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(bundle{}), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Unexpected configuration after the round-trip (-want/+got)\n%s", diff)
+	}
+	if strings.Contains(exported.String(), lastAppliedConfigAnnotation) {
+		t.Errorf("Unexpected %s annotation in the exported bundle", lastAppliedConfigAnnotation)
+	}
+}
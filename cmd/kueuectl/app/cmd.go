@@ -24,13 +24,17 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/utils/clock"
 
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/bundle"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/clientgetter"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/cohort"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/create"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/explain"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/list"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/passthrough"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/resume"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/stop"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/submit"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/version"
 )
 
@@ -79,6 +83,11 @@ func NewKueuectlCmd(o KueuectlOptions) *cobra.Command {
 	cmd.AddCommand(resume.NewResumeCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(stop.NewStopCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(list.NewListCmd(clientGetter, o.IOStreams, o.Clock))
+	cmd.AddCommand(cohort.NewCohortCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(explain.NewExplainCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(submit.NewSubmitCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(bundle.NewExportCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(bundle.NewImportCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(passthrough.NewCommands(clientGetter, o.IOStreams)...)
 	cmd.AddCommand(version.NewVersionCmd(clientGetter, o.IOStreams))
 
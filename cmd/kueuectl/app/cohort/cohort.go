@@ -0,0 +1,43 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cohort
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/clientgetter"
+)
+
+var cohortExample = templates.Examples(`
+		# Show the quotas, usage and borrowing of the ClusterQueues in the cohort
+		kueuectl cohort status my-cohort
+	`)
+
+func NewCohortCmd(clientGetter clientgetter.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cohort",
+		Short:   "Inspect cohorts",
+		Example: cohortExample,
+		Aliases: []string{"co"},
+	}
+
+	cmd.AddCommand(NewStatusCmd(clientGetter, streams))
+
+	return cmd
+}
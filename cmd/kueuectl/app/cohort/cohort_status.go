@@ -0,0 +1,329 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cohort
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	kueuev1beta2 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta2"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/clientgetter"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+)
+
+const notAvailable = "-"
+
+var (
+	statusLong = templates.LongDesc(`
+		Shows the ClusterQueues of the cohort and of its descendant cohorts.
+		For each resource of each flavor, it displays the nominal quota, the
+		quota reserved by the admitted workloads, the quota borrowed from the
+		cohort and the ClusterQueues lending their unused nominal quota.
+		It also displays the weighted share of each ClusterQueue and its
+		position in fair sharing, where the ClusterQueue with the lowest
+		weighted share comes first.
+	`)
+	statusExample = templates.Examples(`
+		# Show the status of the cohort
+		kueuectl cohort status my-cohort
+	`)
+)
+
+type StatusOptions struct {
+	CohortName kueue.CohortReference
+
+	Client kueuev1beta2.KueueV1beta2Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewStatusOptions(streams genericiooptions.IOStreams) *StatusOptions {
+	return &StatusOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewStatusCmd(clientGetter clientgetter.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewStatusOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "status NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 "Show the quotas, usage and borrowing of the ClusterQueues in the cohort",
+		Long:                  statusLong,
+		Example:               statusExample,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completion.CohortNameFunc(clientGetter),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *StatusOptions) Complete(clientGetter clientgetter.ClientGetter, args []string) error {
+	o.CohortName = kueue.CohortReference(args[0])
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta2()
+
+	return nil
+}
+
+// Run prints the status of the ClusterQueues in the cohort.
+func (o *StatusOptions) Run(ctx context.Context) error {
+	cohorts, err := o.Client.Cohorts().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	cqs, err := o.Client.ClusterQueues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	subtree := cohortSubtree(o.CohortName, cohorts.Items)
+	members := make([]kueue.ClusterQueue, 0, len(cqs.Items))
+	for _, cq := range cqs.Items {
+		if subtree.Has(cq.Spec.CohortName) {
+			members = append(members, cq)
+		}
+	}
+
+	if len(members) == 0 {
+		fmt.Fprintf(o.ErrOut, "No ClusterQueues found in cohort %q\n", o.CohortName)
+		return nil
+	}
+
+	slices.SortFunc(members, func(a, b kueue.ClusterQueue) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return printStatus(statusRows(members), o.Out)
+}
+
+// cohortSubtree returns the names of the given cohort and of all its descendants.
+func cohortSubtree(root kueue.CohortReference, cohorts []kueue.Cohort) sets.Set[kueue.CohortReference] {
+	children := make(map[kueue.CohortReference][]kueue.CohortReference)
+	for _, c := range cohorts {
+		if c.Spec.ParentName != "" {
+			children[c.Spec.ParentName] = append(children[c.Spec.ParentName], kueue.CohortReference(c.Name))
+		}
+	}
+	subtree := sets.New(root)
+	queue := []kueue.CohortReference{root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range children[current] {
+			if !subtree.Has(child) {
+				subtree.Insert(child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return subtree
+}
+
+type statusRow struct {
+	clusterQueue  string
+	cohort        kueue.CohortReference
+	flavor        kueue.ResourceFlavorReference
+	resource      corev1.ResourceName
+	nominal       resource.Quantity
+	usage         resource.Quantity
+	borrowed      resource.Quantity
+	lenders       []string
+	weightedShare string
+	position      string
+}
+
+func statusRows(members []kueue.ClusterQueue) []statusRow {
+	positions := fairSharePositions(members)
+	var rows []statusRow
+	for i := range members {
+		cq := &members[i]
+		weightedShare := notAvailable
+		if cq.Status.FairSharing != nil {
+			weightedShare = fmt.Sprint(cq.Status.FairSharing.WeightedShare)
+		}
+		position := notAvailable
+		if p, ok := positions[cq.Name]; ok {
+			position = fmt.Sprint(p)
+		}
+		for _, rg := range cq.Spec.ResourceGroups {
+			for _, fq := range rg.Flavors {
+				for _, rq := range fq.Resources {
+					row := statusRow{
+						clusterQueue:  cq.Name,
+						cohort:        cq.Spec.CohortName,
+						flavor:        fq.Name,
+						resource:      rq.Name,
+						nominal:       rq.NominalQuota,
+						weightedShare: weightedShare,
+						position:      position,
+					}
+					if usage := findUsage(cq, fq.Name, rq.Name); usage != nil {
+						row.usage = usage.Total
+						row.borrowed = usage.Borrowed
+					}
+					if !row.borrowed.IsZero() {
+						row.lenders = lenders(members, cq.Name, fq.Name, rq.Name)
+					}
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// fairSharePositions ranks the ClusterQueues which report a fair sharing status
+// by ascending weighted share, which is the order in which fair sharing
+// prefers them when distributing the unused quota of the cohort.
+func fairSharePositions(members []kueue.ClusterQueue) map[string]int {
+	ranked := make([]*kueue.ClusterQueue, 0, len(members))
+	for i := range members {
+		if members[i].Status.FairSharing != nil {
+			ranked = append(ranked, &members[i])
+		}
+	}
+	slices.SortStableFunc(ranked, func(a, b *kueue.ClusterQueue) int {
+		return cmp.Compare(a.Status.FairSharing.WeightedShare, b.Status.FairSharing.WeightedShare)
+	})
+	positions := make(map[string]int, len(ranked))
+	for i, cq := range ranked {
+		positions[cq.Name] = i + 1
+	}
+	return positions
+}
+
+func findUsage(cq *kueue.ClusterQueue, flavor kueue.ResourceFlavorReference, resourceName corev1.ResourceName) *kueue.ResourceUsage {
+	for _, fu := range cq.Status.FlavorsReservation {
+		if fu.Name != flavor {
+			continue
+		}
+		for i := range fu.Resources {
+			if fu.Resources[i].Name == resourceName {
+				return &fu.Resources[i]
+			}
+		}
+	}
+	return nil
+}
+
+// lenders returns the ClusterQueues of the cohort, other than the borrowing one,
+// which have unused nominal quota for the resource of the flavor, together with
+// the amount they can lend.
+func lenders(members []kueue.ClusterQueue, borrower string, flavor kueue.ResourceFlavorReference, resourceName corev1.ResourceName) []string {
+	var result []string
+	for i := range members {
+		cq := &members[i]
+		if cq.Name == borrower {
+			continue
+		}
+		for _, rg := range cq.Spec.ResourceGroups {
+			for _, fq := range rg.Flavors {
+				if fq.Name != flavor {
+					continue
+				}
+				for _, rq := range fq.Resources {
+					if rq.Name != resourceName {
+						continue
+					}
+					lendable := rq.NominalQuota.DeepCopy()
+					if usage := findUsage(cq, flavor, resourceName); usage != nil {
+						lendable.Sub(usage.Total)
+					}
+					if rq.LendingLimit != nil && rq.LendingLimit.Cmp(lendable) < 0 {
+						lendable = rq.LendingLimit.DeepCopy()
+					}
+					if lendable.Sign() > 0 {
+						result = append(result, fmt.Sprintf("%s (%s)", cq.Name, lendable.String()))
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+func printStatus(rows []statusRow, out io.Writer) error {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "ClusterQueue", Type: "string", Format: "name"},
+			{Name: "Cohort", Type: "string"},
+			{Name: "Flavor", Type: "string"},
+			{Name: "Resource", Type: "string"},
+			{Name: "Nominal", Type: "string"},
+			{Name: "Usage", Type: "string"},
+			{Name: "Borrowed", Type: "string"},
+			{Name: "Borrowing From", Type: "string"},
+			{Name: "Weighted Share", Type: "string"},
+			{Name: "Fair Share Position", Type: "string"},
+		},
+		Rows: make([]metav1.TableRow, len(rows)),
+	}
+	for i, row := range rows {
+		borrowingFrom := notAvailable
+		if len(row.lenders) > 0 {
+			borrowingFrom = strings.Join(row.lenders, ", ")
+		} else if !row.borrowed.IsZero() {
+			borrowingFrom = string(row.cohort)
+		}
+		table.Rows[i] = metav1.TableRow{
+			Cells: []any{
+				row.clusterQueue,
+				string(row.cohort),
+				string(row.flavor),
+				string(row.resource),
+				row.nominal.String(),
+				row.usage.String(),
+				row.borrowed.String(),
+				borrowingFrom,
+				row.weightedShare,
+				row.position,
+			},
+		}
+	}
+	return printers.NewTablePrinter(printers.PrintOptions{}).PrintObj(table, out)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cohort
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func withCPUReservation(cq *kueue.ClusterQueue, flavor kueue.ResourceFlavorReference, total, borrowed string) *kueue.ClusterQueue {
+	cq.Status.FlavorsReservation = append(cq.Status.FlavorsReservation, kueue.FlavorUsage{
+		Name: flavor,
+		Resources: []kueue.ResourceUsage{{
+			Name:     corev1.ResourceCPU,
+			Total:    resource.MustParse(total),
+			Borrowed: resource.MustParse(borrowed),
+		}},
+	})
+	return cq
+}
+
+func withWeightedShare(cq *kueue.ClusterQueue, share int64) *kueue.ClusterQueue {
+	cq.Status.FairSharing = &kueue.FairSharingStatus{WeightedShare: share}
+	return cq
+}
+
+func TestStatusRun(t *testing.T) {
+	testCases := map[string]struct {
+		objs       []runtime.Object
+		args       []string
+		wantOut    string
+		wantOutErr string
+		wantErr    error
+	}{
+		"should print the borrowing relationships of the cohort tree": {
+			args: []string{"all"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeCohort("all").Obj(),
+				utiltestingapi.MakeCohort("team").Parent("all").Obj(),
+				utiltestingapi.MakeCohort("other").Obj(),
+				withWeightedShare(withCPUReservation(utiltestingapi.MakeClusterQueue("cq-a").
+					Cohort("all").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj(), "default", "8", "3"), 600),
+				withWeightedShare(withCPUReservation(utiltestingapi.MakeClusterQueue("cq-b").
+					Cohort("all").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5", "", "2").Obj()).
+					Obj(), "default", "1", "0"), 0),
+				withWeightedShare(withCPUReservation(utiltestingapi.MakeClusterQueue("cq-c").
+					Cohort("team").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(), "default", "3", "0"), 100),
+				withCPUReservation(utiltestingapi.MakeClusterQueue("cq-d").
+					Cohort("other").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(), "default", "0", "0"),
+			},
+			wantOut: `CLUSTERQUEUE   COHORT   FLAVOR    RESOURCE   NOMINAL   USAGE   BORROWED   BORROWING FROM       WEIGHTED SHARE   FAIR SHARE POSITION
+cq-a           all      default   cpu        5         8       3          cq-b (2), cq-c (1)   600              3
+cq-b           all      default   cpu        5         1       0          -                    0                1
+cq-c           team     default   cpu        4         3       0          -                    100              2
+`,
+		},
+		"should print the cohort when no ClusterQueue has unused quota": {
+			args: []string{"all"},
+			objs: []runtime.Object{
+				withCPUReservation(utiltestingapi.MakeClusterQueue("cq-a").
+					Cohort("all").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj(), "default", "7", "2"),
+				withCPUReservation(utiltestingapi.MakeClusterQueue("cq-b").
+					Cohort("all").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj(), "default", "5", "0"),
+			},
+			wantOut: `CLUSTERQUEUE   COHORT   FLAVOR    RESOURCE   NOMINAL   USAGE   BORROWED   BORROWING FROM   WEIGHTED SHARE   FAIR SHARE POSITION
+cq-a           all      default   cpu        5         7       2          all              -                -
+cq-b           all      default   cpu        5         5       0          -                -                -
+`,
+		},
+		"should print not found error": {
+			args: []string{"all"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeClusterQueue("cq-a").Cohort("other").Obj(),
+			},
+			wantOutErr: "No ClusterQueues found in cohort \"all\"\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(fake.NewSimpleClientset(tc.objs...))
+
+			cmd := NewStatusCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			gotOutErr := outErr.String()
+			if diff := cmp.Diff(tc.wantOutErr, gotOutErr); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
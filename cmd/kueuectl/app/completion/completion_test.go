@@ -165,6 +165,48 @@ func TestClusterQueueNameCompletionFunc(t *testing.T) {
 	}
 }
 
+func TestCohortNameCompletionFunc(t *testing.T) {
+	testCases := map[string]struct {
+		toComplete    string
+		objs          []runtime.Object
+		args          []string
+		wantNames     []string
+		wantDirective cobra.ShellCompDirective
+	}{
+		"should return cohort names": {
+			objs: []runtime.Object{
+				utiltestingapi.MakeCohort("cohort1").Obj(),
+				utiltestingapi.MakeCohort("cohort2").Parent("cohort1").Obj(),
+			},
+			wantNames:     []string{"cohort1", "cohort2"},
+			wantDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+		"shouldn't return cohort names because only one argument can be passed": {
+			objs: []runtime.Object{
+				utiltestingapi.MakeCohort("cohort1").Obj(),
+				utiltestingapi.MakeCohort("cohort2").Parent("cohort1").Obj(),
+			},
+			args:          []string{"cohort1"},
+			wantDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(fake.NewSimpleClientset(tc.objs...))
+
+			complFn := CohortNameFunc(tcg)
+			names, directive := complFn(&cobra.Command{}, tc.args, tc.toComplete)
+			if diff := cmp.Diff(tc.wantNames, names); diff != "" {
+				t.Errorf("Unexpected names (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantDirective, directive); diff != "" {
+				t.Errorf("Unexpected directive (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestLocalQueueNameCompletionFunc(t *testing.T) {
 	testCases := map[string]struct {
 		ns            string
@@ -0,0 +1,176 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	kueuev1beta2 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta2"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/clientgetter"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+)
+
+var (
+	wlLong = templates.LongDesc(`
+		Explains why the Workload is not admitted. It combines the status of the
+		Workload, the state of its LocalQueue and ClusterQueue, the resources
+		requested by the Workload compared with the unused quota of the
+		ClusterQueue, the outcome of the last attempt to assign flavors and
+		topology, and the pending AdmissionChecks. The binding constraint is
+		the constraint which currently prevents the admission of the Workload.
+	`)
+	wlExample = templates.Examples(`
+		# Explain why the Workload is not admitted
+		kueuectl explain workload my-workload
+	`)
+)
+
+type WorkloadOptions struct {
+	Name      string
+	Namespace string
+
+	Client kueuev1beta2.KueueV1beta2Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadOptions(streams genericiooptions.IOStreams) *WorkloadOptions {
+	return &WorkloadOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewWorkloadCmd(clientGetter clientgetter.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewWorkloadOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "workload NAME",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"kwl", "kueueworkload", "kueueworkloads"},
+		Short:                 "Explain why the given Workload is not admitted",
+		Long:                  wlLong,
+		Example:               wlExample,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completion.WorkloadNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadOptions) Complete(clientGetter clientgetter.ClientGetter, args []string) error {
+	o.Name = args[0]
+
+	var err error
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta2()
+
+	return nil
+}
+
+// Run prints the explanation of why the Workload is not admitted.
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	wl, err := o.Client.Workloads(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	lq, err := o.Client.LocalQueues(o.Namespace).Get(ctx, string(wl.Spec.QueueName), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lq = nil
+	} else if err != nil {
+		return err
+	}
+
+	var cqName kueue.ClusterQueueReference
+	switch {
+	case wl.Status.Admission != nil:
+		cqName = wl.Status.Admission.ClusterQueue
+	case lq != nil:
+		cqName = lq.Spec.ClusterQueue
+	}
+
+	var cq *kueue.ClusterQueue
+	if cqName != "" {
+		cq, err = o.Client.ClusterQueues().Get(ctx, string(cqName), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cq = nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return printExplanation(o.Out, wl, cqName, explainWorkload(wl, lq, cq))
+}
+
+func printExplanation(out io.Writer, wl *kueue.Workload, cqName kueue.ClusterQueueReference, e *explanation) error {
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintf(w, "Name:\t%s\n", wl.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", wl.Namespace)
+	fmt.Fprintf(w, "LocalQueue:\t%s\n", wl.Spec.QueueName)
+	fmt.Fprintf(w, "ClusterQueue:\t%s\n", cqName)
+	fmt.Fprintf(w, "Status:\t%s\n", e.status)
+	if e.bindingConstraint != "" {
+		fmt.Fprintf(w, "Binding Constraint:\t%s\n", e.bindingConstraint)
+	}
+	if len(e.details) > 0 {
+		fmt.Fprintln(w, "Details:")
+		for _, detail := range e.details {
+			fmt.Fprintf(w, "  %s\n", detail)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(e.quota) > 0 {
+		fmt.Fprintln(out, "Quota:")
+		w = printers.GetNewTabWriter(out)
+		fmt.Fprintln(w, "  Resource\tFlavor\tRequested\tNominal\tReserved\tAvailable\tShortfall")
+		for _, row := range e.quota {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\n", row.resource, row.flavor,
+				row.requested.String(), row.nominal.String(), row.reserved.String(), row.available.String(), row.shortfall.String())
+		}
+	}
+	return w.Flush()
+}
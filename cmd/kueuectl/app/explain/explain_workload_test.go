@@ -0,0 +1,259 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/component-base/featuregate"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func TestWorkloadRun(t *testing.T) {
+	now := time.Now()
+
+	cpuRequests := kueue.PodSetRequest{
+		Name: kueue.DefaultPodSetName,
+		Resources: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("6"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	pendingCondition := func(msg string) metav1.Condition {
+		return metav1.Condition{
+			Type:    kueue.WorkloadQuotaReserved,
+			Status:  metav1.ConditionFalse,
+			Reason:  kueue.WorkloadPending,
+			Message: msg,
+		}
+	}
+	withCPUReservation := func(cq *kueue.ClusterQueue, total string) *kueue.ClusterQueue {
+		cq.Status.FlavorsReservation = []kueue.FlavorUsage{{
+			Name: "default",
+			Resources: []kueue.ResourceUsage{
+				{Name: corev1.ResourceCPU, Total: resource.MustParse(total)},
+				{Name: corev1.ResourceMemory, Total: resource.MustParse("0")},
+			},
+		}}
+		return cq
+	}
+	cq := func(cpu string) *kueue.ClusterQueue {
+		return utiltestingapi.MakeClusterQueue("cq").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, cpu).
+				Resource(corev1.ResourceMemory, "4Gi").
+				Obj()).
+			Active(metav1.ConditionTrue).
+			Obj()
+	}
+
+	testCases := map[string]struct {
+		objs         []runtime.Object
+		args         []string
+		featureGates map[featuregate.Feature]bool
+		wantOut      string
+		wantErr      error
+	}{
+		"should identify the quota shortfall as the binding constraint": {
+			args: []string{"wl"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					ResourceRequests(cpuRequests).
+					Condition(pendingCondition("couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 4 more needed")).
+					Obj(),
+				utiltestingapi.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+				withCPUReservation(cq("4"), "2"),
+			},
+			wantOut: `Name:                 wl
+Namespace:            default
+LocalQueue:           lq
+ClusterQueue:         cq
+Status:               pending
+Binding Constraint:   insufficient quota for cpu in ClusterQueue "cq"
+Details:
+  insufficient quota for cpu in ClusterQueue "cq"
+  last scheduling attempt: couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 4 more needed
+Quota:
+  Resource   Flavor    Requested   Nominal   Reserved   Available   Shortfall
+  cpu        default   6           4         2          2           4
+  memory     default   1Gi         4Gi       0          4Gi         0
+`,
+		},
+		"should identify the topology as the binding constraint": {
+			args: []string{"wl"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					ResourceRequests(cpuRequests).
+					Condition(pendingCondition(`couldn't assign flavors to pod set main: topology "default" doesn't allow to fit any of 6 pod(s)`)).
+					Obj(),
+				utiltestingapi.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+				withCPUReservation(cq("8"), "0"),
+			},
+			wantOut: `Name:                 wl
+Namespace:            default
+LocalQueue:           lq
+ClusterQueue:         cq
+Status:               pending
+Binding Constraint:   couldn't assign flavors to pod set main: topology "default" doesn't allow to fit any of 6 pod(s)
+Details:
+  last scheduling attempt: couldn't assign flavors to pod set main: topology "default" doesn't allow to fit any of 6 pod(s)
+Quota:
+  Resource   Flavor    Requested   Nominal   Reserved   Available   Shortfall
+  cpu        default   6           8         0          8           0
+  memory     default   1Gi         4Gi       0          4Gi         0
+`,
+		},
+		"should identify the pending admission checks as the binding constraint": {
+			args: []string{"wl"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					ResourceRequests(cpuRequests).
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").Obj(), now).
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "prov", State: kueue.CheckStatePending, Message: "waiting for capacity"}).
+					AdmissionCheck(kueue.AdmissionCheckState{Name: "ready", State: kueue.CheckStateReady}).
+					Obj(),
+				utiltestingapi.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+				withCPUReservation(cq("8"), "6"),
+			},
+			wantOut: `Name:                 wl
+Namespace:            default
+LocalQueue:           lq
+ClusterQueue:         cq
+Status:               quotaReserved
+Binding Constraint:   waiting for AdmissionChecks: prov
+Details:
+  AdmissionCheck "prov" is Pending: waiting for capacity
+`,
+		},
+		"should identify the held workload and the missing LocalQueue": {
+			args: []string{"wl"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					Annotation(constants.HoldAnnotation, "true").
+					Obj(),
+			},
+			featureGates: map[featuregate.Feature]bool{features.WorkloadHold: true},
+			wantOut: `Name:                 wl
+Namespace:            default
+LocalQueue:           lq
+ClusterQueue:         
+Status:               pending
+Binding Constraint:   the workload is held by the kueue.x-k8s.io/hold annotation
+Details:
+  the workload is held by the kueue.x-k8s.io/hold annotation
+  LocalQueue "lq" doesn't exist
+  the workload is waiting to be evaluated by the scheduler
+`,
+		},
+		"should ignore the hold annotation when the WorkloadHold feature gate is disabled": {
+			args: []string{"wl"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					Annotation(constants.HoldAnnotation, "true").
+					Obj(),
+			},
+			featureGates: map[featuregate.Feature]bool{features.WorkloadHold: false},
+			wantOut: `Name:                 wl
+Namespace:            default
+LocalQueue:           lq
+ClusterQueue:         
+Status:               pending
+Binding Constraint:   LocalQueue "lq" doesn't exist
+Details:
+  LocalQueue "lq" doesn't exist
+  the workload is waiting to be evaluated by the scheduler
+`,
+		},
+		"should identify the stopped ClusterQueue": {
+			args: []string{"wl"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					Obj(),
+				utiltestingapi.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+				utiltestingapi.MakeClusterQueue("cq").StopPolicy(kueue.Hold).Obj(),
+			},
+			wantOut: `Name:                 wl
+Namespace:            default
+LocalQueue:           lq
+ClusterQueue:         cq
+Status:               pending
+Binding Constraint:   ClusterQueue "cq" is stopped
+Details:
+  ClusterQueue "cq" is stopped
+  the workload is waiting to be evaluated by the scheduler
+`,
+		},
+		"should print no binding constraint for an admitted workload": {
+			args: []string{"wl"},
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				utiltestingapi.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+				cq("8"),
+			},
+			wantOut: `Name:           wl
+Namespace:      default
+LocalQueue:     lq
+ClusterQueue:   cq
+Status:         admitted
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGatesDuringTest(t, tc.featureGates)
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(fake.NewSimpleClientset(tc.objs...))
+
+			cmd := NewWorkloadCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
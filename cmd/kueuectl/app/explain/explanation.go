@@ -0,0 +1,244 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// explanation describes why a workload is not admitted.
+type explanation struct {
+	// status is the status of the workload, one of pending, quotaReserved,
+	// admitted or finished.
+	status string
+	// bindingConstraint is the constraint which currently prevents the
+	// admission of the workload. It is empty for admitted and finished
+	// workloads.
+	bindingConstraint string
+	// details lists the findings collected from the status of the workload,
+	// its queues and their quotas.
+	details []string
+	// quota lists the requested resources of the workload compared with the
+	// unused quota of the ClusterQueue.
+	quota []quotaRow
+}
+
+type quotaRow struct {
+	resource  corev1.ResourceName
+	flavor    kueue.ResourceFlavorReference
+	requested resource.Quantity
+	nominal   resource.Quantity
+	reserved  resource.Quantity
+	available resource.Quantity
+	shortfall resource.Quantity
+}
+
+func (e *explanation) add(blocking bool, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	e.details = append(e.details, msg)
+	if blocking && e.bindingConstraint == "" {
+		e.bindingConstraint = msg
+	}
+}
+
+// explainWorkload combines the status of the workload with the state of its
+// LocalQueue and ClusterQueue to explain why it is not admitted. The lq and cq
+// are nil when they don't exist.
+func explainWorkload(wl *kueue.Workload, lq *kueue.LocalQueue, cq *kueue.ClusterQueue) *explanation {
+	e := &explanation{status: workload.Status(wl)}
+	if e.status == workload.StatusFinished || e.status == workload.StatusAdmitted {
+		return e
+	}
+
+	if !workload.IsActive(wl) {
+		e.add(true, "the workload is deactivated")
+	}
+	if workload.IsHeld(wl) {
+		e.add(true, "the workload is held by the %s annotation", constants.HoldAnnotation)
+	}
+
+	switch {
+	case lq == nil:
+		e.add(true, "LocalQueue %q doesn't exist", wl.Spec.QueueName)
+	case isStopped(lq.Spec.StopPolicy):
+		e.add(true, "LocalQueue %q is stopped", lq.Name)
+	}
+
+	switch {
+	case cq == nil:
+		if lq != nil {
+			e.add(true, "ClusterQueue %q doesn't exist", lq.Spec.ClusterQueue)
+		}
+	case isStopped(cq.Spec.StopPolicy):
+		e.add(true, "ClusterQueue %q is stopped", cq.Name)
+	case !apimeta.IsStatusConditionTrue(cq.Status.Conditions, kueue.ClusterQueueActive):
+		msg := "ClusterQueue %q is inactive"
+		if cond := apimeta.FindStatusCondition(cq.Status.Conditions, kueue.ClusterQueueActive); cond != nil && cond.Message != "" {
+			msg += ": " + cond.Message
+		}
+		e.add(true, msg, cq.Name)
+	}
+
+	if e.status == workload.StatusQuotaReserved {
+		explainAdmissionChecks(e, wl)
+		return e
+	}
+
+	if cq != nil {
+		explainQuota(e, wl, cq)
+	}
+	explainSchedulingAttempt(e, wl)
+	return e
+}
+
+func isStopped(policy *kueue.StopPolicy) bool {
+	return ptr.Deref(policy, kueue.None) != kueue.None
+}
+
+// explainAdmissionChecks reports the AdmissionChecks which block the admission
+// of a workload holding a quota reservation.
+func explainAdmissionChecks(e *explanation, wl *kueue.Workload) {
+	var pending []string
+	for _, check := range wl.Status.AdmissionChecks {
+		if check.State == kueue.CheckStateReady || ptr.Deref(check.Advisory, false) {
+			continue
+		}
+		pending = append(pending, string(check.Name))
+		msg := fmt.Sprintf("AdmissionCheck %q is %s", check.Name, check.State)
+		if check.Message != "" {
+			msg += ": " + check.Message
+		}
+		e.add(false, "%s", msg)
+	}
+	if len(pending) > 0 {
+		e.bindingConstraint = cmp.Or(e.bindingConstraint, fmt.Sprintf("waiting for AdmissionChecks: %s", strings.Join(pending, ", ")))
+	}
+}
+
+// explainQuota compares the resources requested by the workload with the
+// unused nominal quota of each flavor of the ClusterQueue providing them.
+// A resource which doesn't fit in any flavor is a binding constraint.
+func explainQuota(e *explanation, wl *kueue.Workload, cq *kueue.ClusterQueue) {
+	requests := make(corev1.ResourceList)
+	for _, psr := range wl.Status.ResourceRequests {
+		for name, q := range psr.Resources {
+			total := requests[name]
+			total.Add(q)
+			requests[name] = total
+		}
+	}
+	names := make([]corev1.ResourceName, 0, len(requests))
+	for name := range requests {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		requested := requests[name]
+		fits := false
+		provided := false
+		for _, rg := range cq.Spec.ResourceGroups {
+			if !slices.Contains(rg.CoveredResources, name) {
+				continue
+			}
+			for _, fq := range rg.Flavors {
+				for _, rq := range fq.Resources {
+					if rq.Name != name {
+						continue
+					}
+					provided = true
+					row := quotaRow{
+						resource:  name,
+						flavor:    fq.Name,
+						requested: requested,
+						nominal:   rq.NominalQuota,
+						reserved:  reservedQuota(cq, fq.Name, name),
+					}
+					row.available = row.nominal.DeepCopy()
+					row.available.Sub(row.reserved)
+					if row.available.Sign() < 0 {
+						row.available = resource.Quantity{}
+					}
+					row.shortfall = requested.DeepCopy()
+					row.shortfall.Sub(row.available)
+					if row.shortfall.Sign() <= 0 {
+						row.shortfall = resource.Quantity{}
+						fits = true
+					}
+					e.quota = append(e.quota, row)
+				}
+			}
+		}
+		switch {
+		case !provided:
+			e.add(true, "resource %s is not provided by ClusterQueue %q", name, cq.Name)
+		case !fits && cq.Spec.CohortName != "":
+			e.add(true, "insufficient unused quota for %s in ClusterQueue %q, the workload needs to borrow from cohort %q", name, cq.Name, cq.Spec.CohortName)
+		case !fits:
+			e.add(true, "insufficient quota for %s in ClusterQueue %q", name, cq.Name)
+		}
+	}
+}
+
+func reservedQuota(cq *kueue.ClusterQueue, flavor kueue.ResourceFlavorReference, name corev1.ResourceName) resource.Quantity {
+	for _, fu := range cq.Status.FlavorsReservation {
+		if fu.Name != flavor {
+			continue
+		}
+		for _, ru := range fu.Resources {
+			if ru.Name == name {
+				return ru.Total
+			}
+		}
+	}
+	return resource.Quantity{}
+}
+
+// explainSchedulingAttempt reports the outcome of the last attempt of the
+// scheduler to assign flavors to the workload, recorded in the QuotaReserved
+// condition. When no other constraint is found, the pod set which doesn't fit
+// in the topology is preferred as the binding constraint.
+func explainSchedulingAttempt(e *explanation, wl *kueue.Workload) {
+	cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if cond == nil || cond.Message == "" {
+		e.add(true, "the workload is waiting to be evaluated by the scheduler")
+		return
+	}
+	e.add(false, "last scheduling attempt: %s", cond.Message)
+	if e.bindingConstraint != "" {
+		return
+	}
+	e.bindingConstraint = cond.Message
+	for podSetMsg := range strings.SplitSeq(cond.Message, "; ") {
+		if strings.Contains(podSetMsg, "topology") {
+			e.bindingConstraint = podSetMsg
+			return
+		}
+	}
+}
@@ -76,6 +76,11 @@ func addActiveFilterFlagVar(cmd *cobra.Command, p *[]bool) {
 		"Filter by active status. Valid values: 'true' and 'false'.")
 }
 
+func addWatchFlagVar(cmd *cobra.Command, p *bool) {
+	cmd.Flags().BoolVarP(p, "watch", "w", false,
+		"After listing the requested resources, watch for changes.")
+}
+
 func addForObjectFlagVar(cmd *cobra.Command, p *string) {
 	cmd.Flags().StringVar(p, "for", "",
 		"Filter only those pertaining to the specified resource.")
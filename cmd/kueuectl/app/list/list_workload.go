@@ -20,14 +20,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 
+	"github.com/liggitt/tabwriter"
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/cli-runtime/pkg/printers"
@@ -51,6 +55,9 @@ var (
 	wlExample = templates.Examples(`
 		# List Workload 
   		kueuectl list kueueworkload
+
+		# List Workload and watch for changes
+		kueuectl list kueueworkload --watch
 	`)
 )
 
@@ -74,6 +81,7 @@ type WorkloadOptions struct {
 	ClusterQueueFilter string
 	LocalQueueFilter   string
 	StatusesFilter     sets.Set[int]
+	Watch              bool
 	forGVK             schema.GroupVersionKind
 	forName            string
 	forObject          *unstructured.Unstructured
@@ -97,7 +105,7 @@ func NewWorkloadCmd(clientGetter clientgetter.ClientGetter, streams genericioopt
 	o := NewWorkloadOptions(streams, clock)
 
 	cmd := &cobra.Command{
-		Use: "workload [--clusterqueue CLUSTER_QUEUE_NAME] [--localqueue LOCAL_QUEUE_NAME] [--status STATUS] [--selector key1=value1] [--field-selector key1=value1] [--all-namespaces] [--for TYPE[.API-GROUP]/NAME]",
+		Use: "workload [--clusterqueue CLUSTER_QUEUE_NAME] [--localqueue LOCAL_QUEUE_NAME] [--status STATUS] [--selector key1=value1] [--field-selector key1=value1] [--all-namespaces] [--for TYPE[.API-GROUP]/NAME] [--watch]",
 		// To do not add "[flags]" suffix on the end of usage line
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"kwl", "kueueworkload", "kueueworkloads"},
@@ -122,6 +130,7 @@ func NewWorkloadCmd(clientGetter clientgetter.ClientGetter, streams genericioopt
 	addClusterQueueFilterFlagVar(cmd, &o.ClusterQueueFilter)
 	addLocalQueueFilterFlagVar(cmd, &o.LocalQueueFilter)
 	addForObjectFlagVar(cmd, &o.UserSpecifiedForObject)
+	addWatchFlagVar(cmd, &o.Watch)
 
 	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("clusterqueue", completion.ClusterQueueNameFunc(clientGetter, nil)))
 	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("localqueue", completion.LocalQueueNameFunc(clientGetter, nil)))
@@ -299,29 +308,7 @@ func (o *WorkloadOptions) Run(ctx context.Context) error {
 
 		totalCount += len(list.Items)
 
-		r := newListWorkloadResources()
-
-		r.localQueues, err = o.localQueues(ctx, list)
-		if err != nil {
-			return err
-		}
-
-		r.pendingWorkloads, err = o.pendingWorkloads(ctx, list, r.localQueues)
-		if err != nil {
-			return err
-		}
-
-		r.apiResourceLists, err = o.apiResources(list)
-		if err != nil {
-			return err
-		}
-
-		printer, err := o.ToPrinter(r, headers)
-		if err != nil {
-			return err
-		}
-
-		if err := printer.PrintObj(list, tabWriter); err != nil {
+		if err := o.printList(ctx, list, headers, tabWriter); err != nil {
 			return err
 		}
 
@@ -336,17 +323,91 @@ func (o *WorkloadOptions) Run(ctx context.Context) error {
 			} else {
 				fmt.Fprintln(o.ErrOut, "No resources found")
 			}
-			return nil
+			if !o.Watch {
+				return nil
+			}
+		} else if err := tabWriter.Flush(); err != nil {
+			return err
 		}
 
-		if err := tabWriter.Flush(); err != nil {
-			return err
+		if o.Watch {
+			opts.ResourceVersion = list.ResourceVersion
+			return o.watch(ctx, namespace, opts, enableOwnerReferenceFilter, jobUID, totalCount == 0, tabWriter)
 		}
 
 		return nil
 	}
 }
 
+// watch prints the Workloads matching the criteria each time they change,
+// until the watch is closed or the context is done.
+func (o *WorkloadOptions) watch(ctx context.Context, namespace string, opts metav1.ListOptions, enableOwnerReferenceFilter bool, uid types.UID, headers bool, tabWriter *tabwriter.Writer) error {
+	opts.Continue = ""
+	opts.Limit = 0
+	w, err := o.ClientSet.KueueV1beta2().Workloads(namespace).Watch(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			if event.Type == watch.Error {
+				return apierrors.FromObject(event.Object)
+			}
+			wl, ok := event.Object.(*kueue.Workload)
+			if !ok {
+				continue
+			}
+			list := &kueue.WorkloadList{Items: []kueue.Workload{*wl}}
+			o.filterList(list, enableOwnerReferenceFilter, uid)
+			if len(list.Items) == 0 {
+				continue
+			}
+			if err := o.printList(ctx, list, headers, tabWriter); err != nil {
+				return err
+			}
+			if err := tabWriter.Flush(); err != nil {
+				return err
+			}
+			headers = false
+		}
+	}
+}
+
+func (o *WorkloadOptions) printList(ctx context.Context, list *kueue.WorkloadList, headers bool, out io.Writer) error {
+	var err error
+	r := newListWorkloadResources()
+
+	r.localQueues, err = o.localQueues(ctx, list)
+	if err != nil {
+		return err
+	}
+
+	r.pendingWorkloads, err = o.pendingWorkloads(ctx, list, r.localQueues)
+	if err != nil {
+		return err
+	}
+
+	r.apiResourceLists, err = o.apiResources(list)
+	if err != nil {
+		return err
+	}
+
+	printer, err := o.ToPrinter(r, headers)
+	if err != nil {
+		return err
+	}
+
+	return printer.PrintObj(list, out)
+}
+
 func (o *WorkloadOptions) filterList(list *kueue.WorkloadList, enableOwnerReferenceFilter bool, uid types.UID) {
 	if len(list.Items) == 0 {
 		return
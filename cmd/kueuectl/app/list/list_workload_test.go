@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/cli-runtime/pkg/resource"
 	fakediscovery "k8s.io/client-go/discovery/fake"
@@ -944,3 +945,65 @@ wl2               j2         lq2          cq2            PENDING   22
 		})
 	}
 }
+
+func TestWorkloadCmdWatch(t *testing.T) {
+	testStartTime := time.Now()
+
+	pendingWl := utiltestingapi.MakeWorkload("wl1", metav1.NamespaceDefault).
+		OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "j1", "test-uid").
+		Queue("lq1").
+		Active(true).
+		Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+		Obj()
+	admittedWl := utiltestingapi.MakeWorkload("wl1", metav1.NamespaceDefault).
+		OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "j1", "test-uid").
+		Queue("lq1").
+		Active(true).
+		Admission(utiltestingapi.MakeAdmission("cq1").Obj()).
+		Condition(metav1.Condition{
+			Type:               kueue.WorkloadQuotaReserved,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(testStartTime.Add(-1 * time.Minute).Truncate(time.Second)),
+		}).
+		Condition(metav1.Condition{
+			Type:               kueue.WorkloadAdmitted,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(testStartTime.Add(-1 * time.Minute).Truncate(time.Second)),
+		}).
+		Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+		Obj()
+	otherWl := utiltestingapi.MakeWorkload("wl2", metav1.NamespaceDefault).
+		Queue("lq2").
+		Active(true).
+		Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
+		Obj()
+
+	streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+	clientset := fake.NewSimpleClientset(pendingWl)
+	fakeWatcher := watch.NewFake()
+	clientset.PrependWatchReactor("workloads", kubetesting.DefaultWatchReactor(fakeWatcher, nil))
+	go func() {
+		fakeWatcher.Modify(otherWl)
+		fakeWatcher.Modify(admittedWl)
+		fakeWatcher.Stop()
+	}()
+
+	tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+	cmd := NewWorkloadCmd(tcg, streams, testingclock.NewFakeClock(testStartTime))
+	cmd.SetArgs([]string{"--localqueue", "lq1", "--watch"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	wantOut := `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1                         PENDING                                   60m
+wl1               j1         lq1          cq1            ADMITTED                       60s         60m
+`
+	if diff := cmp.Diff(wantOut, out.String()); diff != "" {
+		t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+	}
+	if diff := cmp.Diff("", outErr.String()); diff != "" {
+		t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+	}
+}
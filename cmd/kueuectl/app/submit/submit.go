@@ -0,0 +1,279 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	kueuev1beta2 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta2"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/clientgetter"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/dryrun"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/flags"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+
+	// Ensure linking of the job controllers.
+	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
+)
+
+var (
+	submitLong = templates.LongDesc(`
+		Submit the jobs defined in the given files to the specified local queue.
+
+		The queue label is set on every object before it is created. The local
+		queue must exist in the namespace of the object. Only kinds supported
+		by a Kueue integration can be submitted.
+	`)
+	submitExample = templates.Examples(`
+		# Submit a Job to the local queue "main"
+		kueuectl submit -f job.yaml --queue main
+
+		# Submit a suspended Job to the local queue "main"
+		kueuectl submit -f job.yaml --queue main --suspend
+	`)
+)
+
+var (
+	errLocalQueueNotFound  = errors.New("local queue not found")
+	errUnsupportedKind     = errors.New("kind is not supported by Kueue")
+	errSuspendNotSupported = errors.New("suspending is not supported for kind")
+)
+
+type SubmitOptions struct {
+	PrintFlags *genericclioptions.PrintFlags
+
+	FilenameOptions  resource.FilenameOptions
+	DryRunStrategy   dryrun.Strategy
+	Namespace        string
+	EnforceNamespace bool
+	LocalQueue       string
+	Suspend          bool
+
+	Infos []*resource.Info
+
+	Client kueuev1beta2.KueueV1beta2Interface
+
+	PrintObj printers.ResourcePrinterFunc
+
+	genericiooptions.IOStreams
+}
+
+func NewSubmitOptions(streams genericiooptions.IOStreams) *SubmitOptions {
+	return &SubmitOptions{
+		PrintFlags: genericclioptions.NewPrintFlags("created").WithTypeSetter(scheme.Scheme),
+		IOStreams:  streams,
+	}
+}
+
+func NewSubmitCmd(clientGetter clientgetter.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewSubmitOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "submit -f FILENAME --queue LOCAL_QUEUE_NAME [--suspend] [--dry-run STRATEGY]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Submit jobs to a local queue",
+		Long:                  submitLong,
+		Example:               submitExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, cmd)
+			if err != nil {
+				return err
+			}
+			err = o.Validate(ctx)
+			if err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+	flags.AddDryRunFlag(cmd)
+
+	cmd.Flags().StringSliceVarP(&o.FilenameOptions.Filenames, "filename", "f", nil,
+		"Files that contain the jobs to submit (required).")
+	cmd.Flags().StringVarP(&o.LocalQueue, "queue", "q", "",
+		"The local queue to submit the jobs to (required).")
+	cmd.Flags().BoolVar(&o.Suspend, "suspend", false,
+		"Suspend the jobs before creating them.")
+
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("queue", completion.LocalQueueNameFunc(clientGetter, nil)))
+
+	_ = cmd.MarkFlagRequired("filename")
+	_ = cmd.MarkFlagRequired("queue")
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *SubmitOptions) Complete(clientGetter clientgetter.ClientGetter, cmd *cobra.Command) error {
+	var err error
+	o.Namespace, o.EnforceNamespace, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta2()
+
+	o.DryRunStrategy, err = dryrun.GetStrategy(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = dryrun.PrintFlagsWithStrategy(o.PrintFlags, o.DryRunStrategy)
+	if err != nil {
+		return err
+	}
+
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+
+	o.PrintObj = printer.PrintObj
+
+	r := clientGetter.NewResourceBuilder().
+		Unstructured().
+		NamespaceParam(o.Namespace).
+		DefaultNamespace().
+		FilenameParam(o.EnforceNamespace, &o.FilenameOptions).
+		Flatten().
+		Do()
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	o.Infos, err = r.Infos()
+	return err
+}
+
+// Validate validates that the local queue exists in the namespace of every
+// job, and that every job is supported by a Kueue integration.
+func (o *SubmitOptions) Validate(ctx context.Context) error {
+	if len(o.LocalQueue) == 0 {
+		return errors.New("queue must be specified")
+	}
+	if len(o.Infos) == 0 {
+		return errors.New("no objects passed to submit")
+	}
+
+	checkedNamespaces := make(map[string]bool, len(o.Infos))
+	for _, info := range o.Infos {
+		gvk := info.Object.GetObjectKind().GroupVersionKind()
+		cbs, ok := jobframework.GetIntegrationByGVK(gvk)
+		if !ok {
+			return fmt.Errorf("%w: %s", errUnsupportedKind, gvk.GroupKind())
+		}
+		if o.Suspend && cbs.NewJob == nil {
+			return fmt.Errorf("%w: %s", errSuspendNotSupported, gvk.GroupKind())
+		}
+
+		if checkedNamespaces[info.Namespace] {
+			continue
+		}
+		if _, err := o.Client.LocalQueues(info.Namespace).Get(ctx, o.LocalQueue, metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("%w: %s/%s", errLocalQueueNotFound, info.Namespace, o.LocalQueue)
+			}
+			return err
+		}
+		checkedNamespaces[info.Namespace] = true
+	}
+	return nil
+}
+
+// Run submits the jobs
+func (o *SubmitOptions) Run() error {
+	for _, info := range o.Infos {
+		obj, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("unexpected type %T", info.Object)
+		}
+		if err := o.prepareObject(obj); err != nil {
+			return err
+		}
+		if o.DryRunStrategy != dryrun.Client {
+			created, err := resource.NewHelper(info.Client, info.Mapping).
+				DryRun(o.DryRunStrategy == dryrun.Server).
+				WithFieldManager("kueuectl").
+				Create(info.Namespace, true, obj)
+			if err != nil {
+				return err
+			}
+			if err := info.Refresh(created, true); err != nil {
+				return err
+			}
+		}
+		if err := o.PrintObj(info.Object, o.Out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepareObject sets the queue label on the object and, if requested,
+// suspends it the way its integration does.
+func (o *SubmitOptions) prepareObject(obj *unstructured.Unstructured) error {
+	if o.Suspend {
+		cbs, ok := jobframework.GetIntegrationByGVK(obj.GroupVersionKind())
+		if !ok || cbs.NewJob == nil {
+			return fmt.Errorf("%w: %s", errSuspendNotSupported, obj.GroupVersionKind().GroupKind())
+		}
+		job := cbs.NewJob()
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), job.Object()); err != nil {
+			return fmt.Errorf("failed to convert unstructured object: %w", err)
+		}
+		job.Suspend()
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(job.Object())
+		if err != nil {
+			return fmt.Errorf("failed to convert object to unstructured: %w", err)
+		}
+		obj.SetUnstructuredContent(content)
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[constants.QueueLabel] = o.LocalQueue
+	obj.SetLabels(labels)
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submit
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	restfake "k8s.io/client-go/rest/fake"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+const testJob = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: test-job
+spec:
+  template:
+    spec:
+      containers:
+      - name: c
+        image: busybox
+      restartPolicy: Never
+`
+
+const testConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-cm
+`
+
+func TestSubmitCmd(t *testing.T) {
+	testCases := map[string]struct {
+		manifest       string
+		objs           []runtime.Object
+		args           []string
+		wantCreated    bool
+		wantQueueLabel string
+		wantSuspend    bool
+		wantOut        string
+		wantErr        error
+	}{
+		"should submit job with the queue label": {
+			manifest:       testJob,
+			objs:           []runtime.Object{utiltestingapi.MakeLocalQueue("main", metav1.NamespaceDefault).Obj()},
+			args:           []string{"--queue", "main"},
+			wantCreated:    true,
+			wantQueueLabel: "main",
+			wantOut:        "job.batch/test-job created\n",
+		},
+		"should submit suspended job": {
+			manifest:       testJob,
+			objs:           []runtime.Object{utiltestingapi.MakeLocalQueue("main", metav1.NamespaceDefault).Obj()},
+			args:           []string{"--queue", "main", "--suspend"},
+			wantCreated:    true,
+			wantQueueLabel: "main",
+			wantSuspend:    true,
+			wantOut:        "job.batch/test-job created\n",
+		},
+		"shouldn't create job on client dry run": {
+			manifest: testJob,
+			objs:     []runtime.Object{utiltestingapi.MakeLocalQueue("main", metav1.NamespaceDefault).Obj()},
+			args:     []string{"--queue", "main", "--dry-run", "client"},
+			wantOut:  "job.batch/test-job created (client dry run)\n",
+		},
+		"should refuse to submit to a non-existent local queue": {
+			manifest: testJob,
+			objs:     []runtime.Object{utiltestingapi.MakeLocalQueue("main", "other").Obj()},
+			args:     []string{"--queue", "main"},
+			wantErr:  errLocalQueueNotFound,
+		},
+		"should refuse to submit kind not supported by Kueue": {
+			manifest: testConfigMap,
+			objs:     []runtime.Object{utiltestingapi.MakeLocalQueue("main", metav1.NamespaceDefault).Obj()},
+			args:     []string{"--queue", "main"},
+			wantErr:  errUnsupportedKind,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+
+			manifestPath := filepath.Join(t.TempDir(), "manifest.yaml")
+			if err := os.WriteFile(manifestPath, []byte(tc.manifest), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			mapper.Add(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}, meta.RESTScopeNamespace)
+			mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+
+			var created *unstructured.Unstructured
+			restClient := &restfake.RESTClient{
+				NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+				Client: restfake.CreateHTTPClient(func(request *http.Request) (*http.Response, error) {
+					if request.Method != http.MethodPost || request.URL.Path != "/namespaces/default/jobs" {
+						t.Errorf("Unexpected request: %s %s", request.Method, request.URL.Path)
+						return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(&bytes.Buffer{})}, nil
+					}
+					body, err := io.ReadAll(request.Body)
+					if err != nil {
+						return nil, err
+					}
+					created = &unstructured.Unstructured{}
+					if err := created.UnmarshalJSON(body); err != nil {
+						return nil, err
+					}
+					header := http.Header{}
+					header.Set("Content-Type", runtime.ContentTypeJSON)
+					return &http.Response{StatusCode: http.StatusCreated, Header: header, Body: io.NopCloser(bytes.NewReader(body))}, nil
+				}),
+			}
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(fake.NewSimpleClientset(tc.objs...)).
+				WithRESTMapper(mapper).
+				WithRESTClient(restClient)
+
+			cmd := NewSubmitCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append([]string{"-f", manifestPath}, tc.args...))
+
+			gotErr := cmd.Execute()
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if !tc.wantCreated {
+				if created != nil {
+					t.Errorf("Unexpected created object: %v", created)
+				}
+				return
+			}
+			if created == nil {
+				t.Fatal("Expected the job to be created")
+			}
+			if diff := cmp.Diff(tc.wantQueueLabel, created.GetLabels()["kueue.x-k8s.io/queue-name"]); diff != "" {
+				t.Errorf("Unexpected queue label (-want/+got)\n%s", diff)
+			}
+			gotSuspend, _, _ := unstructured.NestedBool(created.Object, "spec", "suspend")
+			if gotSuspend != tc.wantSuspend {
+				t.Errorf("Unexpected suspend, want: %v, got: %v", tc.wantSuspend, gotSuspend)
+			}
+		})
+	}
+}
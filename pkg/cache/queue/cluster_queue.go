@@ -65,6 +65,7 @@ const (
 	RequeueReasonPreemptionFailed       RequeueReason = "PreemptionFailed"
 	RequeueReasonNoFit                  RequeueReason = "NoFit"
 	RequeueReasonPreemptionNoCandidates RequeueReason = "PreemptionNoCandidates"
+	RequeueReasonHeld                   RequeueReason = "Held"
 )
 
 // QuotaReservedReason represents the reason for the WorkloadQuotaReserved condition
@@ -167,7 +168,21 @@ type ClusterQueue struct {
 
 	sw *stickyWorkload
 
+	priorityAging *priorityAging
+
+	submissionOrder *submissionOrder
+
+	weightedRoundRobin *weightedRoundRobin
+
 	ConcurrentAdmissionPolicy *kueue.ConcurrentAdmissionPolicy
+
+	// defaultContainerRequests are accounted for the containers of the
+	// workloads which don't request the resources.
+	defaultContainerRequests corev1.ResourceList
+	// limitsAccountedResources are the resources whose usage is accounted from
+	// the limits of the containers.
+	limitsAccountedResources []corev1.ResourceName
+
 	// pendingResourcesTotal is the incremental sum of TotalRequests across workloads
 	// in heap and inadmissibleWorkloads (not inflight). Updated at each mutation site so
 	// pendingResources() is O(1) rather than O(N).
@@ -250,9 +265,12 @@ func newClusterQueueImpl(ctx context.Context, client client.Client, wo workload.
 		opt(options)
 	}
 	sw := stickyWorkload{}
+	pa := priorityAging{clock: clock, ordering: wo}
+	so := submissionOrder{}
+	wrr := newWeightedRoundRobin()
 	log := ctrl.LoggerFrom(ctx)
-	baseCmp := baseCompareFunc(log, wo, &sw)
-	compareFunc := queueOrderingFunc(ctx, client, wo, options.fsResWeights, options.enableAdmissionFs, options.afsEntryPenalties, options.afsConsumedResources, &sw)
+	baseCmp := baseCompareFunc(log, wo, &sw, &pa, &so)
+	compareFunc := queueOrderingFunc(ctx, client, wo, options.fsResWeights, options.enableAdmissionFs, options.afsEntryPenalties, options.afsConsumedResources, &sw, &pa, &so, wrr)
 	// Derive lessFunc from compareFunc for the heap.
 	lessFunc := func(a, b *workload.Info) bool { return compareFunc(a, b) < 0 }
 	snapshotSort := buildSnapshotSort(
@@ -273,6 +291,9 @@ func newClusterQueueImpl(ctx context.Context, client client.Client, wo workload.
 		afsEntryPenalties:         options.afsEntryPenalties,
 		localQueuesInClusterQueue: make(map[utilqueue.LocalQueueReference]bool),
 		sw:                        &sw,
+		priorityAging:             &pa,
+		submissionOrder:           &so,
+		weightedRoundRobin:        wrr,
 		pendingResourcesTotal:     make(map[corev1.ResourceName]int64),
 	}
 }
@@ -292,10 +313,79 @@ func (c *ClusterQueue) Update(apiCQ *kueue.ClusterQueue) error {
 	if features.Enabled(features.ConcurrentAdmission) {
 		c.ConcurrentAdmissionPolicy = apiCQ.Spec.ConcurrentAdmissionPolicy
 	}
+	c.defaultContainerRequests = nil
+	if features.Enabled(features.ClusterQueueDefaultRequests) {
+		c.defaultContainerRequests = apiCQ.Spec.DefaultContainerRequests
+	}
+	c.limitsAccountedResources = nil
+	if features.Enabled(features.ClusterQueueLimitsAccounting) {
+		c.limitsAccountedResources = apiCQ.Spec.LimitsAccountedResources
+	}
+	c.updatePriorityAging(apiCQ)
+	c.updateSubmissionOrder(apiCQ)
+	c.updateWeightedRoundRobin(apiCQ)
 	c.updateConfiguredResources(apiCQ)
 	return nil
 }
 
+// DefaultContainerRequests returns the requests accounted for the containers of
+// the workloads which don't request the resources.
+func (c *ClusterQueue) DefaultContainerRequests() corev1.ResourceList {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.defaultContainerRequests
+}
+
+// LimitsAccountedResources returns the resources whose usage is accounted from
+// the limits of the containers.
+func (c *ClusterQueue) LimitsAccountedResources() []corev1.ResourceName {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.limitsAccountedResources
+}
+
+// updatePriorityAging updates the priority aging policy, and reorders the heap
+// if the policy changed.
+func (c *ClusterQueue) updatePriorityAging(apiCQ *kueue.ClusterQueue) {
+	var policy *kueue.PriorityAging
+	if features.Enabled(features.PriorityAging) {
+		policy = apiCQ.Spec.PriorityAging
+	}
+	if equality.Semantic.DeepEqual(policy, c.priorityAging.policy) {
+		return
+	}
+	c.priorityAging.policy = policy
+	c.rebuildAll()
+}
+
+// updateWeightedRoundRobin updates whether the admissions are shared among the
+// LocalQueues in proportion to their weights, and reorders the heap if it changed.
+func (c *ClusterQueue) updateWeightedRoundRobin(apiCQ *kueue.ClusterQueue) {
+	enabled := features.Enabled(features.AdmissionFairSharingWeightedRoundRobin) &&
+		apiCQ.Spec.AdmissionScope != nil &&
+		apiCQ.Spec.AdmissionScope.AdmissionMode == kueue.WeightedRoundRobinAdmissionFairSharing
+	if c.weightedRoundRobin.setEnabled(enabled) {
+		c.rebuildAll()
+	}
+}
+
+// RecordAdmission accounts for the admission of a workload from the LocalQueue
+// in the ordering of the LocalQueues.
+func (c *ClusterQueue) RecordAdmission(lqKey utilqueue.LocalQueueReference) {
+	c.weightedRoundRobin.recordAdmission(lqKey)
+}
+
+// updateSubmissionOrder updates whether the workloads are ordered regardless
+// of their priority, and reorders the heap if it changed.
+func (c *ClusterQueue) updateSubmissionOrder(apiCQ *kueue.ClusterQueue) {
+	ignorePriority := features.Enabled(features.StrictFIFOIgnoresPriority) && apiCQ.Spec.QueueingStrategy == kueue.StrictFIFO
+	if ignorePriority == c.submissionOrder.ignorePriority {
+		return
+	}
+	c.submissionOrder.ignorePriority = ignorePriority
+	c.rebuildAll()
+}
+
 // updateConfiguredResources seeds pendingResourcesTotal with 0 for newly configured
 // resources so they appear in metrics even when no workloads are pending, and prunes
 // zero entries for resources removed from the spec.
@@ -331,6 +421,7 @@ func (c *ClusterQueue) AddFromLocalQueue(q *LocalQueue, roleTracker *roletracker
 			continue
 		}
 		if c.heap.PushIfNotPresent(info) {
+			c.priorityAging.track(info.Obj)
 			added = true
 			c.addPendingResources(info)
 		}
@@ -381,6 +472,7 @@ func (c *ClusterQueue) PushOrUpdate(wInfo *workload.Info) {
 			equality.Semantic.DeepEqual(apimeta.FindStatusCondition(oldInfo.Obj.Status.Conditions, kueue.WorkloadRequeued),
 				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadRequeued)) &&
 			workload.HasClosedPreemptionGate(oldInfo.Obj) == workload.HasClosedPreemptionGate(wInfo.Obj) &&
+			workload.IsHeld(oldInfo.Obj) == workload.IsHeld(wInfo.Obj) &&
 			!draRequestsChanged(oldInfo, wInfo) {
 			c.inadmissibleWorkloads.insert(key, wInfo)
 			return
@@ -405,6 +497,7 @@ func (c *ClusterQueue) PushOrUpdate(wInfo *workload.Info) {
 		c.subtractPendingResources(oldHeapInfo)
 	}
 	c.heap.PushOrUpdate(wInfo)
+	c.priorityAging.track(wInfo.Obj)
 	c.addPendingResources(wInfo)
 }
 
@@ -568,6 +661,9 @@ func (c *ClusterQueue) requeueIfNotPresent(log logr.Logger, wInfo *workload.Info
 			c.inadmissibleWorkloads.delete(key)
 		}
 		pushed := c.heap.PushIfNotPresent(wInfo)
+		if pushed {
+			c.priorityAging.track(wInfo.Obj)
+		}
 		if pushed && !wasTracked {
 			c.addPendingResources(wInfo)
 		}
@@ -716,7 +812,8 @@ func (c *ClusterQueue) Pop() *workload.Info {
 	c.rwm.Lock()
 	defer c.rwm.Unlock()
 
-	if c.hasPendingPenalties() {
+	wrrChanged := c.weightedRoundRobin.takeChanged()
+	if c.hasPendingPenalties() || c.priorityAging.due() || wrrChanged {
 		c.rebuildAll()
 	}
 
@@ -734,8 +831,10 @@ func (c *ClusterQueue) Pop() *workload.Info {
 
 // rebuildAll rebuilds the entire heap. Must be called with lock held.
 func (c *ClusterQueue) rebuildAll() {
+	c.priorityAging.reset()
 	for _, wl := range c.heap.List() {
 		c.heap.PushOrUpdate(wl)
+		c.priorityAging.track(wl.Obj)
 	}
 }
 
@@ -916,7 +1015,7 @@ func (c *ClusterQueue) RequeueIfNotPresent(ctx context.Context, wInfo *workload.
 
 	var immediate bool
 	if c.queueingStrategy == kueue.StrictFIFO {
-		immediate = reason != RequeueReasonNamespaceMismatch
+		immediate = reason != RequeueReasonNamespaceMismatch && reason != RequeueReasonHeld
 	} else {
 		immediate = reason == RequeueReasonFailedAfterNomination ||
 			reason == RequeueReasonPendingPreemption ||
@@ -926,8 +1025,16 @@ func (c *ClusterQueue) RequeueIfNotPresent(ctx context.Context, wInfo *workload.
 	return c.requeueIfNotPresent(log, wInfo, immediate, reason, quotaReservedReason)
 }
 
+// submissionOrder reports whether the workloads of a ClusterQueue are ordered
+// solely by their queue ordering timestamp, regardless of their priority.
+type submissionOrder struct {
+	ignorePriority bool
+}
+
 // baseCompareFunc orders workloads by sticky status, priority, timestamp, and UID.
-func baseCompareFunc(log logr.Logger, wo workload.Ordering, sw *stickyWorkload) func(a, b *workload.Info) int {
+// The priority includes the increase computed by the priority aging policy, and
+// is skipped when the submission order ignores the priority.
+func baseCompareFunc(log logr.Logger, wo workload.Ordering, sw *stickyWorkload, pa *priorityAging, so *submissionOrder) func(a, b *workload.Info) int {
 	return func(a, b *workload.Info) int {
 		aSticky := sw.matches(workload.Key(a.Obj))
 		bSticky := sw.matches(workload.Key(b.Obj))
@@ -940,11 +1047,13 @@ func baseCompareFunc(log logr.Logger, wo workload.Ordering, sw *stickyWorkload)
 			return 1
 		}
 
-		p1 := utilpriority.EffectivePriority(log, a.Obj)
-		p2 := utilpriority.EffectivePriority(log, b.Obj)
-		// Higher priority comes first (reverse order).
-		if cmpResult := cmp.Compare(p2, p1); cmpResult != 0 {
-			return cmpResult
+		if !so.ignorePriority {
+			p1 := utilpriority.EffectivePriority(log, a.Obj) + pa.boost(a.Obj)
+			p2 := utilpriority.EffectivePriority(log, b.Obj) + pa.boost(b.Obj)
+			// Higher priority comes first (reverse order).
+			if cmpResult := cmp.Compare(p2, p1); cmpResult != 0 {
+				return cmpResult
+			}
 		}
 
 		tA := wo.GetQueueOrderTimestamp(a.Obj)
@@ -959,7 +1068,8 @@ func baseCompareFunc(log logr.Logger, wo workload.Ordering, sw *stickyWorkload)
 	}
 }
 
-// queueOrderingFunc composes fair-sharing usage (when enabled) with baseCompareFunc.
+// queueOrderingFunc composes fair-sharing usage or the weighted round robin
+// among the LocalQueues (when enabled) with baseCompareFunc.
 func queueOrderingFunc(
 	ctx context.Context,
 	cl client.Client,
@@ -969,11 +1079,19 @@ func queueOrderingFunc(
 	afsEntryPenalties *queueafs.AfsEntryPenalties,
 	afsConsumedResources *queueafs.AfsConsumedResources,
 	sw *stickyWorkload,
+	pa *priorityAging,
+	so *submissionOrder,
+	wrr *weightedRoundRobin,
 ) func(a, b *workload.Info) int {
 	log := ctrl.LoggerFrom(ctx)
-	baseCmp := baseCompareFunc(log, wo, sw)
+	baseCmp := baseCompareFunc(log, wo, sw, pa, so)
 	if !enableAdmissionFs {
-		return baseCmp
+		return func(a, b *workload.Info) int {
+			if cmpResult := wrr.compare(a, b); cmpResult != 0 {
+				return cmpResult
+			}
+			return baseCmp(a, b)
+		}
 	}
 	return func(a, b *workload.Info) int {
 		lqAUsage, errA := a.CalcLocalQueueFSUsage(ctx, cl, fsResWeights, afsEntryPenalties, afsConsumedResources)
@@ -994,14 +1112,16 @@ func queueOrderingFunc(
 	}
 }
 
-func (c *ClusterQueue) addLocalQueue(lqKey utilqueue.LocalQueueReference) {
+func (c *ClusterQueue) addLocalQueue(lq *LocalQueue) {
 	c.rwm.Lock()
 	defer c.rwm.Unlock()
-	c.localQueuesInClusterQueue[lqKey] = true
+	c.localQueuesInClusterQueue[lq.Key] = true
+	c.weightedRoundRobin.setWeight(lq.Key, lq.weight)
 }
 
 func (c *ClusterQueue) deleteLocalQueue(lqKey utilqueue.LocalQueueReference) {
 	c.rwm.Lock()
 	defer c.rwm.Unlock()
 	delete(c.localQueuesInClusterQueue, lqKey)
+	c.weightedRoundRobin.deleteLocalQueue(lqKey)
 }
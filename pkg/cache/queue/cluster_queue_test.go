@@ -17,6 +17,7 @@ limitations under the License.
 package queue
 
 import (
+	"fmt"
 	"slices"
 	"testing"
 	"time"
@@ -34,6 +35,7 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta2"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	queueafs "sigs.k8s.io/kueue/pkg/cache/queue/afs"
+	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	utilqueue "sigs.k8s.io/kueue/pkg/util/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -1128,11 +1130,13 @@ func TestStrictFIFO(t *testing.T) {
 	t2 := t1.Add(time.Second)
 	t3 := t2.Add(time.Second)
 	for _, tt := range []struct {
-		name             string
-		w1               *kueue.Workload
-		w2               *kueue.Workload
-		workloadOrdering *workload.Ordering
-		expected         string
+		name                  string
+		w1                    *kueue.Workload
+		w2                    *kueue.Workload
+		workloadOrdering      *workload.Ordering
+		queueingStrategy      kueue.QueueingStrategy
+		enableIgnoresPriority bool
+		expected              string
 	}{
 		{
 			name: "w1.priority is higher than w2.priority",
@@ -1209,8 +1213,43 @@ func TestStrictFIFO(t *testing.T) {
 				Obj(),
 			expected: "w2",
 		},
+		{
+			name: "p1.priority is lower than p2.priority and w1.create time is earlier than w2.create time, priority ignored",
+			w1: utiltestingapi.MakeWorkload("w1", "").
+				Creation(t1).
+				PodPriorityClassRef("lowPriority").
+				Priority(lowPriority).
+				Obj(),
+			w2: utiltestingapi.MakeWorkload("w2", "").
+				Creation(t2).
+				PodPriorityClassRef("highPriority").
+				Priority(highPriority).
+				Obj(),
+			enableIgnoresPriority: true,
+			expected:              "w1",
+		},
+		{
+			name: "p1.priority is lower than p2.priority and w1.create time is earlier than w2.create time, priority not ignored for BestEffortFIFO",
+			w1: utiltestingapi.MakeWorkload("w1", "").
+				Creation(t1).
+				PodPriorityClassRef("lowPriority").
+				Priority(lowPriority).
+				Obj(),
+			w2: utiltestingapi.MakeWorkload("w2", "").
+				Creation(t2).
+				PodPriorityClassRef("highPriority").
+				Priority(highPriority).
+				Obj(),
+			queueingStrategy:      kueue.BestEffortFIFO,
+			enableIgnoresPriority: true,
+			expected:              "w2",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.StrictFIFOIgnoresPriority, tt.enableIgnoresPriority)
+			if tt.queueingStrategy == "" {
+				tt.queueingStrategy = kueue.StrictFIFO
+			}
 			if tt.workloadOrdering == nil {
 				// The default ordering:
 				tt.workloadOrdering = &workload.Ordering{PodsReadyRequeuingTimestamp: config.EvictionTimestamp}
@@ -1219,7 +1258,7 @@ func TestStrictFIFO(t *testing.T) {
 			q, err := newClusterQueue(ctx, nil,
 				&kueue.ClusterQueue{
 					Spec: kueue.ClusterQueueSpec{
-						QueueingStrategy: kueue.StrictFIFO,
+						QueueingStrategy: tt.queueingStrategy,
 					},
 				},
 				*tt.workloadOrdering,
@@ -1255,6 +1294,9 @@ func TestStrictFIFORequeueIfNotPresent(t *testing.T) {
 		RequeueReasonGeneric: {
 			wantInadmissible: false,
 		},
+		RequeueReasonHeld: {
+			wantInadmissible: true,
+		},
 	}
 
 	for reason, test := range tests {
@@ -1285,6 +1327,247 @@ func TestStrictFIFORequeueIfNotPresent(t *testing.T) {
 	}
 }
 
+func TestPriorityAging(t *testing.T) {
+	policy := kueue.PriorityAging{IntervalSeconds: 60, PriorityIncrement: 10}
+	cases := map[string]struct {
+		enablePriorityAging bool
+		policy              kueue.PriorityAging
+		// wantLowPoppedAtMinute is the minute at which the low priority workload
+		// is popped ahead of a new medium priority workload, 0 if never.
+		wantLowPoppedAtMinute int
+	}{
+		"low priority workload jumps ahead of new medium priority workloads": {
+			enablePriorityAging:   true,
+			policy:                policy,
+			wantLowPoppedAtMinute: 5,
+		},
+		"increase is capped by maxPriorityIncrement": {
+			enablePriorityAging: true,
+			policy:              kueue.PriorityAging{IntervalSeconds: 60, PriorityIncrement: 10, MaxPriorityIncrement: ptr.To[int32](40)},
+		},
+		"feature disabled": {
+			policy: policy,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PriorityAging, tc.enablePriorityAging)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			now := time.Now().Truncate(time.Second)
+			fakeClock := testingclock.NewFakeClock(now)
+			cq := newClusterQueueImpl(ctx, nil, defaultOrdering, fakeClock)
+			if err := cq.Update(utiltestingapi.MakeClusterQueue("cq").PriorityAging(tc.policy).Obj()); err != nil {
+				t.Fatalf("Failed updating ClusterQueue: %v", err)
+			}
+			cq.PushOrUpdate(workload.NewInfo(utiltestingapi.MakeWorkload("low", defaultNamespace).
+				Creation(now).
+				Priority(0).
+				Obj()))
+
+			gotLowPoppedAtMinute := 0
+			for minute := 1; minute <= 10 && gotLowPoppedAtMinute == 0; minute++ {
+				fakeClock.Step(time.Minute)
+				cq.PushOrUpdate(workload.NewInfo(utiltestingapi.MakeWorkload(fmt.Sprintf("medium-%d", minute), defaultNamespace).
+					Creation(fakeClock.Now()).
+					Priority(50).
+					Obj()))
+				if head := cq.Pop(); head != nil && head.Obj.Name == "low" {
+					gotLowPoppedAtMinute = minute
+				}
+			}
+			if gotLowPoppedAtMinute != tc.wantLowPoppedAtMinute {
+				t.Errorf("Unexpected minute at which the low priority workload was popped, want: %d, got: %d", tc.wantLowPoppedAtMinute, gotLowPoppedAtMinute)
+			}
+		})
+	}
+}
+
+func TestPriorityAgingReordersWhenIncreaseGrows(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.PriorityAging, true)
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+	cq := newClusterQueueImpl(ctx, nil, defaultOrdering, fakeClock)
+	policy := kueue.PriorityAging{IntervalSeconds: 60, PriorityIncrement: 10, MaxPriorityIncrement: ptr.To[int32](20)}
+	if err := cq.Update(utiltestingapi.MakeClusterQueue("cq").PriorityAging(policy).Obj()); err != nil {
+		t.Fatalf("Failed updating ClusterQueue: %v", err)
+	}
+	for _, name := range []string{"a", "b"} {
+		cq.PushOrUpdate(workload.NewInfo(utiltestingapi.MakeWorkload(name, defaultNamespace).Creation(now).Obj()))
+	}
+
+	steps := []struct {
+		elapsed        time.Duration
+		wantDue        bool
+		wantNextChange time.Time
+	}{
+		{elapsed: 30 * time.Second, wantNextChange: now.Add(time.Minute)},
+		{elapsed: time.Minute, wantDue: true, wantNextChange: now.Add(2 * time.Minute)},
+		{elapsed: 90 * time.Second, wantNextChange: now.Add(2 * time.Minute)},
+		// The increase is capped after two intervals.
+		{elapsed: 2 * time.Minute, wantDue: true},
+	}
+	for _, step := range steps {
+		fakeClock.SetTime(now.Add(step.elapsed))
+		if got := cq.priorityAging.due(); got != step.wantDue {
+			t.Errorf("Unexpected due after %v, want: %v, got: %v", step.elapsed, step.wantDue, got)
+		}
+		popped := cq.Pop()
+		cq.inflight = nil
+		cq.PushOrUpdate(popped)
+		if got := cq.priorityAging.nextChange; !got.Equal(step.wantNextChange) {
+			t.Errorf("Unexpected next change after %v, want: %v, got: %v", step.elapsed, step.wantNextChange, got)
+		}
+	}
+}
+
+func TestHeldWorkload(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.WorkloadHold, true)
+	features.SetFeatureGateDuringTest(t, features.PriorityAging, true)
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+	cq := newClusterQueueImpl(ctx, nil, defaultOrdering, fakeClock)
+	if err := cq.Update(utiltestingapi.MakeClusterQueue("cq").
+		QueueingStrategy(kueue.StrictFIFO).
+		PriorityAging(kueue.PriorityAging{IntervalSeconds: 60, PriorityIncrement: 10}).
+		Obj()); err != nil {
+		t.Fatalf("Failed updating ClusterQueue: %v", err)
+	}
+	held := utiltestingapi.MakeWorkload("held", defaultNamespace).
+		Creation(now).
+		Priority(0).
+		Annotation(constants.HoldAnnotation, "true").
+		Obj()
+	cq.PushOrUpdate(workload.NewInfo(held))
+
+	// The scheduler requeues the held workload as inadmissible, so that it
+	// doesn't block the StrictFIFO queue.
+	head := cq.Pop()
+	if head == nil || head.Obj.Name != "held" {
+		t.Fatalf("Unexpected head, want: held, got: %v", head)
+	}
+	cq.RequeueIfNotPresent(ctx, head, RequeueReasonHeld, QuotaReservedReason(kueue.WorkloadHeld))
+	if !cq.inadmissibleWorkloads.hasKey(workload.Key(held)) {
+		t.Fatal("The held workload isn't inadmissible")
+	}
+
+	fakeClock.Step(5 * time.Minute)
+	cq.PushOrUpdate(workload.NewInfo(utiltestingapi.MakeWorkload("medium-1", defaultNamespace).
+		Creation(fakeClock.Now()).
+		Priority(40).
+		Obj()))
+	if head := cq.Pop(); head == nil || head.Obj.Name != "medium-1" {
+		t.Fatalf("Unexpected head while the workload is held, want: medium-1, got: %v", head)
+	}
+
+	// Once released, the workload regains its position, including the priority
+	// accrued by aging while it was held.
+	released := held.DeepCopy()
+	delete(released.Annotations, constants.HoldAnnotation)
+	cq.PushOrUpdate(workload.NewInfo(released))
+	cq.PushOrUpdate(workload.NewInfo(utiltestingapi.MakeWorkload("medium-2", defaultNamespace).
+		Creation(fakeClock.Now()).
+		Priority(40).
+		Obj()))
+	if head := cq.Pop(); head == nil || head.Obj.Name != "held" {
+		t.Errorf("Unexpected head after the workload was released, want: held, got: %v", head)
+	}
+}
+
+func TestWeightedRoundRobinAdmission(t *testing.T) {
+	cases := map[string]struct {
+		enableWeightedRoundRobin bool
+		admissionMode            kueue.AdmissionMode
+		wantAdmissions           map[kueue.LocalQueueName]int
+	}{
+		"admissions are shared in proportion to the weights": {
+			enableWeightedRoundRobin: true,
+			admissionMode:            kueue.WeightedRoundRobinAdmissionFairSharing,
+			wantAdmissions:           map[kueue.LocalQueueName]int{"lq-a": 6, "lq-b": 2},
+		},
+		"another admission mode": {
+			enableWeightedRoundRobin: true,
+			admissionMode:            kueue.NoAdmissionFairSharing,
+			wantAdmissions:           map[kueue.LocalQueueName]int{"lq-a": 8},
+		},
+		"feature disabled": {
+			admissionMode:  kueue.WeightedRoundRobinAdmissionFairSharing,
+			wantAdmissions: map[kueue.LocalQueueName]int{"lq-a": 8},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.AdmissionFairSharingWeightedRoundRobin, tc.enableWeightedRoundRobin)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			now := time.Now().Truncate(time.Second)
+			cq := newClusterQueueImpl(ctx, nil, defaultOrdering, testingclock.NewFakeClock(now))
+			if err := cq.Update(utiltestingapi.MakeClusterQueue("cq").AdmissionMode(tc.admissionMode).Obj()); err != nil {
+				t.Fatalf("Failed updating ClusterQueue: %v", err)
+			}
+			cq.addLocalQueue(newLocalQueue(utiltestingapi.MakeLocalQueue("lq-a", defaultNamespace).
+				FairSharing(&kueue.FairSharing{Weight: new(resource.MustParse("3"))}).
+				Obj()))
+			cq.addLocalQueue(newLocalQueue(utiltestingapi.MakeLocalQueue("lq-b", defaultNamespace).
+				FairSharing(&kueue.FairSharing{Weight: new(resource.MustParse("1"))}).
+				Obj()))
+
+			// The workloads of lq-a are older, so they all go first without the
+			// weighted round robin.
+			for i := range 10 {
+				cq.PushOrUpdate(workload.NewInfo(utiltestingapi.MakeWorkload(fmt.Sprintf("a-%d", i), defaultNamespace).
+					Queue("lq-a").
+					Creation(now.Add(time.Duration(i) * time.Second)).
+					Obj()))
+				cq.PushOrUpdate(workload.NewInfo(utiltestingapi.MakeWorkload(fmt.Sprintf("b-%d", i), defaultNamespace).
+					Queue("lq-b").
+					Creation(now.Add(time.Duration(10+i) * time.Second)).
+					Obj()))
+			}
+
+			gotAdmissions := make(map[kueue.LocalQueueName]int)
+			for range 8 {
+				head := cq.Pop()
+				if head == nil {
+					t.Fatal("Expected a workload to be popped")
+				}
+				gotAdmissions[head.Obj.Spec.QueueName]++
+				cq.RecordAdmission(utilqueue.KeyFromWorkload(head.Obj))
+			}
+			if diff := cmp.Diff(tc.wantAdmissions, gotAdmissions); diff != "" {
+				t.Errorf("Unexpected admissions per LocalQueue (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWeightedRoundRobinReordersWhenTagsChange(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.AdmissionFairSharingWeightedRoundRobin, true)
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cq := newClusterQueueImpl(ctx, nil, defaultOrdering, testingclock.NewFakeClock(time.Now()))
+	if err := cq.Update(utiltestingapi.MakeClusterQueue("cq").AdmissionMode(kueue.WeightedRoundRobinAdmissionFairSharing).Obj()); err != nil {
+		t.Fatalf("Failed updating ClusterQueue: %v", err)
+	}
+	lq := utiltestingapi.MakeLocalQueue("lq-a", defaultNamespace).
+		FairSharing(&kueue.FairSharing{Weight: new(resource.MustParse("3"))}).
+		Obj()
+	cq.addLocalQueue(newLocalQueue(lq))
+	if !cq.weightedRoundRobin.takeChanged() {
+		t.Error("Expected a change after adding a LocalQueue")
+	}
+	cq.addLocalQueue(newLocalQueue(lq))
+	if cq.weightedRoundRobin.takeChanged() {
+		t.Error("Unexpected change after setting the same weight")
+	}
+	cq.RecordAdmission(utilqueue.Key(lq))
+	if !cq.weightedRoundRobin.takeChanged() {
+		t.Error("Expected a change after recording an admission")
+	}
+	if cq.weightedRoundRobin.takeChanged() {
+		t.Error("Unexpected change after the change was taken")
+	}
+}
+
 func TestFsAdmission(t *testing.T) {
 	wlCmpOpts := []cmp.Option{
 		cmpopts.EquateEmpty(),
@@ -184,6 +184,7 @@ func queueInadmissibleWorkloads(ctx context.Context, c *ClusterQueue, client cli
 		if err != nil || !c.namespaceSelector.Matches(labels.Set(ns.Labels)) || !c.backoffWaitingTimeExpired(wInfo) {
 			newInadmissibleWorkloads.insert(key, wInfo)
 		} else if c.heap.PushIfNotPresent(wInfo) {
+			c.priorityAging.track(wInfo.Obj)
 			moved++
 		}
 	}
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	afs "sigs.k8s.io/kueue/pkg/util/admissionfairsharing"
 	"sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -34,6 +35,8 @@ type LocalQueue struct {
 	finishedWorkloads sets.Set[workload.Reference]
 
 	labels map[string]string
+
+	weight float64
 }
 
 func newLocalQueue(q *kueue.LocalQueue) *LocalQueue {
@@ -50,6 +53,7 @@ func newLocalQueue(q *kueue.LocalQueue) *LocalQueue {
 func (q *LocalQueue) update(apiQueue *kueue.LocalQueue) {
 	q.ClusterQueue = apiQueue.Spec.ClusterQueue
 	q.labels = apiQueue.GetLabels()
+	q.weight = afs.LQWeightAsFloat64(apiQueue)
 }
 
 func (q *LocalQueue) AddOrUpdate(info *workload.Info) {
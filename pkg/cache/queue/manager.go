@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
@@ -159,6 +160,9 @@ type Manager struct {
 	// Tracks unadmitted workload statuses and counts.
 	unadmittedWorkloads *unadmittedWorkloads
 
+	// Tracks the recent admissions per ClusterQueue for the wait time estimation.
+	admissionThroughput *admissionThroughput
+
 	workloadOrdering workload.Ordering
 
 	workloadInfoOptions []workload.InfoOption
@@ -218,6 +222,7 @@ func NewManager(client client.Client, checker StatusChecker, requeuer inadmissib
 	for _, option := range options {
 		option(m)
 	}
+	m.admissionThroughput = newAdmissionThroughput(m.clock.Now())
 	m.requeuer.setManager(m)
 	m.cond.L = &m.RWMutex
 	return m
@@ -344,7 +349,7 @@ func (m *Manager) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) e
 		if qImpl != nil {
 			added := cqImpl.AddFromLocalQueue(qImpl, m.roleTracker, m.customLabels)
 			addedWorkloads = addedWorkloads || added
-			cqImpl.addLocalQueue(queue.Key(&q))
+			cqImpl.addLocalQueue(qImpl)
 			if features.Enabled(features.UnadmittedWorkloadsObservability) {
 				log := ctrl.LoggerFrom(ctx)
 				for _, wInfo := range qImpl.items {
@@ -490,7 +495,7 @@ func (m *Manager) addLocalQueueLocked(ctx context.Context, q *kueue.LocalQueue)
 
 	cq := m.hm.ClusterQueue(qImpl.ClusterQueue)
 	if cq != nil {
-		cq.addLocalQueue(key)
+		cq.addLocalQueue(qImpl)
 	}
 
 	// Iterate through existing workloads, as workloads corresponding to this
@@ -523,7 +528,7 @@ func (m *Manager) addLocalQueueLocked(ctx context.Context, q *kueue.LocalQueue)
 			continue
 		}
 
-		workload.AdjustResources(ctx, m.client, &w)
+		m.adjustResourcesWithoutLock(ctx, &w)
 		wInfo := workload.NewInfo(&w, m.workloadInfoOptions...)
 		wInfo.UpdateSchedulingHash(log)
 		qImpl.AddOrUpdate(wInfo)
@@ -572,11 +577,14 @@ func (m *Manager) UpdateLocalQueue(log logr.Logger, q *kueue.LocalQueue) error {
 		newCQ := m.hm.ClusterQueue(q.Spec.ClusterQueue)
 		if newCQ != nil {
 			newCQ.AddFromLocalQueue(qImpl, m.roleTracker, m.customLabels)
-			newCQ.addLocalQueue(queue.Key(q))
 			m.Broadcast()
 		}
 	}
 	qImpl.update(q)
+	if cq := m.hm.ClusterQueue(qImpl.ClusterQueue); cq != nil {
+		// Refresh the weight of the LocalQueue.
+		cq.addLocalQueue(qImpl)
+	}
 	if cqChanged && features.Enabled(features.UnadmittedWorkloadsObservability) {
 		for _, wInfo := range qImpl.items {
 			m.updateUnadmittedWorkloadWithoutLock(log, wInfo.Obj)
@@ -668,6 +676,64 @@ func (m *Manager) ClusterQueueForWorkloadWithoutLock(wl *kueue.Workload) *Cluste
 	return m.hm.ClusterQueue(q.ClusterQueue)
 }
 
+// AdjustResources adjusts the resource requests of the workload, including the
+// defaults and the limits accounting of its ClusterQueue.
+func (m *Manager) AdjustResources(ctx context.Context, wl *kueue.Workload) {
+	m.RLock()
+	opts := m.adjustResourcesOptionsWithoutLock(wl)
+	m.RUnlock()
+	workload.AdjustResources(ctx, m.client, wl, opts...)
+}
+
+func (m *Manager) adjustResourcesWithoutLock(ctx context.Context, wl *kueue.Workload) {
+	workload.AdjustResources(ctx, m.client, wl, m.adjustResourcesOptionsWithoutLock(wl)...)
+}
+
+// adjustResourcesOptionsWithoutLock returns the options to adjust the resource
+// requests of the workload for the ClusterQueue which admitted it, or the
+// ClusterQueue of its LocalQueue if it's not admitted.
+func (m *Manager) adjustResourcesOptionsWithoutLock(wl *kueue.Workload) []workload.AdjustResourcesOption {
+	var cq *ClusterQueue
+	if workload.HasQuotaReservation(wl) {
+		cq = m.hm.ClusterQueue(wl.Status.Admission.ClusterQueue)
+	} else {
+		cq = m.ClusterQueueForWorkloadWithoutLock(wl)
+	}
+	if cq == nil {
+		return nil
+	}
+	return []workload.AdjustResourcesOption{
+		workload.WithDefaultContainerRequests(cq.DefaultContainerRequests()),
+		workload.WithLimitsAccountedResources(cq.LimitsAccountedResources()),
+	}
+}
+
+// DefaultContainerRequests returns the default container requests of the
+// ClusterQueue of the LocalQueue.
+func (m *Manager) DefaultContainerRequests(lqKey queue.LocalQueueReference) corev1.ResourceList {
+	m.RLock()
+	defer m.RUnlock()
+	lq, ok := m.localQueues[lqKey]
+	if !ok {
+		return nil
+	}
+	cq := m.hm.ClusterQueue(lq.ClusterQueue)
+	if cq == nil {
+		return nil
+	}
+	return cq.DefaultContainerRequests()
+}
+
+// RecordAdmission accounts for the admission of the workload in the ordering
+// of the LocalQueues of its ClusterQueue.
+func (m *Manager) RecordAdmission(wl *kueue.Workload) {
+	m.RLock()
+	defer m.RUnlock()
+	if cq := m.ClusterQueueForWorkloadWithoutLock(wl); cq != nil {
+		cq.RecordAdmission(queue.KeyFromWorkload(wl))
+	}
+}
+
 func (m *Manager) GetNoFitReason(wl *kueue.Workload) (string, bool) {
 	m.RLock()
 	defer m.RUnlock()
@@ -751,7 +817,7 @@ func (m *Manager) RequeueWorkload(ctx context.Context, info *workload.Info, reas
 		return false
 	}
 	log := ctrl.LoggerFrom(ctx)
-	workload.AdjustResources(ctx, m.client, &w)
+	m.adjustResourcesWithoutLock(ctx, &w)
 	if dra.NeedsDRAReconcile(&w, m.draBackedResources) {
 		info.Update(log, &w, workload.WithPreserveTotalRequests())
 	} else {
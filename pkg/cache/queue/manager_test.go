@@ -31,6 +31,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -885,6 +886,79 @@ func TestDeleteLocalQueue(t *testing.T) {
 	}
 }
 
+func TestAdjustResources(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ClusterQueueDefaultRequests, true)
+	features.SetFeatureGateDuringTest(t, features.ClusterQueueLimitsAccounting, true)
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now().Truncate(time.Second)
+	manager := NewManagerForUnitTests(utiltesting.NewFakeClient(), nil)
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltestingapi.MakeClusterQueue("cq-a").
+			DefaultContainerRequests(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}).
+			Obj(),
+		utiltestingapi.MakeClusterQueue("cq-b").
+			LimitsAccountedResources(corev1.ResourceMemory).
+			Obj(),
+	}
+	for _, cq := range clusterQueues {
+		if err := manager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Could not create ClusterQueue: %v", err)
+		}
+	}
+	if err := manager.AddLocalQueue(ctx, utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq-a").Obj()); err != nil {
+		t.Fatalf("Could not create LocalQueue: %v", err)
+	}
+
+	cases := map[string]struct {
+		wl     *kueue.Workload
+		wantWl *kueue.Workload
+	}{
+		"pending workload uses the ClusterQueue of its LocalQueue": {
+			wl: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Limit(corev1.ResourceMemory, "2Gi").
+				Request(corev1.ResourceMemory, "1Gi").
+				Obj(),
+			wantWl: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Limit(corev1.ResourceMemory, "2Gi").
+				Request(corev1.ResourceMemory, "1Gi").
+				Request(corev1.ResourceCPU, "500m").
+				Obj(),
+		},
+		"admitted workload uses the ClusterQueue which admitted it": {
+			wl: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Limit(corev1.ResourceMemory, "2Gi").
+				Request(corev1.ResourceMemory, "1Gi").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq-b").Obj(), now).
+				Obj(),
+			wantWl: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Limit(corev1.ResourceMemory, "2Gi").
+				Request(corev1.ResourceMemory, "2Gi").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq-b").Obj(), now).
+				Obj(),
+		},
+		"workload without a ClusterQueue": {
+			wl: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("missing").
+				Obj(),
+			wantWl: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("missing").
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			manager.AdjustResources(ctx, tc.wl)
+			if diff := cmp.Diff(tc.wantWl, tc.wl); diff != "" {
+				t.Errorf("Unexpected workload after adjusting the resources (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestAddWorkload(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
 	ctx, log := utiltesting.ContextWithLog(t)
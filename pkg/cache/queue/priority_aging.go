@@ -0,0 +1,93 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"time"
+
+	"k8s.io/utils/clock"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// priorityAging computes the increase of the effective priority of the
+// pending workloads of a ClusterQueue based on their waiting time.
+// A nil policy disables the aging.
+type priorityAging struct {
+	clock    clock.Clock
+	ordering workload.Ordering
+	policy   *kueue.PriorityAging
+
+	// nextChange is the earliest time at which the increase of a pending
+	// workload grows, so that the heap needs to be reordered. It is zero
+	// when no increase is expected to grow.
+	nextChange time.Time
+}
+
+func (pa *priorityAging) enabled() bool {
+	return pa.policy != nil
+}
+
+// due returns true if the increase of a pending workload grew since the heap
+// was last ordered.
+func (pa *priorityAging) due() bool {
+	return pa.enabled() && !pa.nextChange.IsZero() && !pa.clock.Now().Before(pa.nextChange)
+}
+
+// reset forgets the tracked workloads, before the heap is reordered.
+func (pa *priorityAging) reset() {
+	pa.nextChange = time.Time{}
+}
+
+// track records when the increase of the workload grows next.
+func (pa *priorityAging) track(wl *kueue.Workload) {
+	if !pa.enabled() || pa.policy.IntervalSeconds <= 0 || pa.policy.PriorityIncrement == 0 {
+		return
+	}
+	interval := time.Duration(pa.policy.IntervalSeconds) * time.Second
+	since := pa.ordering.GetQueueOrderTimestamp(wl).Time
+	intervals := int64(0)
+	if waited := pa.clock.Since(since); waited > 0 {
+		intervals = int64(waited / interval)
+	}
+	if pa.policy.MaxPriorityIncrement != nil && intervals*int64(pa.policy.PriorityIncrement) >= int64(*pa.policy.MaxPriorityIncrement) {
+		return
+	}
+	next := since.Add(time.Duration(intervals+1) * interval)
+	if pa.nextChange.IsZero() || next.Before(pa.nextChange) {
+		pa.nextChange = next
+	}
+}
+
+// boost returns the increase of the effective priority of the workload, based
+// on the time elapsed since its queue ordering timestamp.
+func (pa *priorityAging) boost(wl *kueue.Workload) int64 {
+	if !pa.enabled() || pa.policy.IntervalSeconds <= 0 {
+		return 0
+	}
+	waited := pa.clock.Since(pa.ordering.GetQueueOrderTimestamp(wl).Time)
+	if waited <= 0 {
+		return 0
+	}
+	intervals := int64(waited / (time.Duration(pa.policy.IntervalSeconds) * time.Second))
+	boost := intervals * int64(pa.policy.PriorityIncrement)
+	if pa.policy.MaxPriorityIncrement != nil {
+		boost = min(boost, int64(*pa.policy.MaxPriorityIncrement))
+	}
+	return boost
+}
@@ -0,0 +1,104 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"sync"
+	"time"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+)
+
+// admissionThroughputWindow is the period over which the admissions of a
+// ClusterQueue are counted to compute its admission throughput.
+const admissionThroughputWindow = time.Hour
+
+// admissionThroughput tracks the recent admissions per ClusterQueue.
+type admissionThroughput struct {
+	sync.Mutex
+	// since is the time when the tracking started.
+	since      time.Time
+	admissions map[kueue.ClusterQueueReference][]time.Time
+}
+
+func newAdmissionThroughput(since time.Time) *admissionThroughput {
+	return &admissionThroughput{
+		since:      since,
+		admissions: make(map[kueue.ClusterQueueReference][]time.Time),
+	}
+}
+
+func (a *admissionThroughput) record(cqName kueue.ClusterQueueReference, at time.Time) {
+	a.Lock()
+	defer a.Unlock()
+	a.admissions[cqName] = append(a.prune(cqName, at), at)
+}
+
+// rate returns the number of admissions per second in the ClusterQueue over
+// the window ending at now, or over the time since the tracking started, if
+// shorter.
+func (a *admissionThroughput) rate(cqName kueue.ClusterQueueReference, now time.Time) float64 {
+	a.Lock()
+	defer a.Unlock()
+	admissions := a.prune(cqName, now)
+	elapsed := min(admissionThroughputWindow, now.Sub(a.since))
+	if len(admissions) == 0 || elapsed <= 0 {
+		return 0
+	}
+	return float64(len(admissions)) / elapsed.Seconds()
+}
+
+// prune drops the admissions older than the window ending at now.
+func (a *admissionThroughput) prune(cqName kueue.ClusterQueueReference, now time.Time) []time.Time {
+	admissions := a.admissions[cqName]
+	cutoff := now.Add(-admissionThroughputWindow)
+	i := 0
+	for i < len(admissions) && admissions[i].Before(cutoff) {
+		i++
+	}
+	if i == len(admissions) {
+		delete(a.admissions, cqName)
+		return nil
+	}
+	admissions = admissions[i:]
+	a.admissions[cqName] = admissions
+	return admissions
+}
+
+// RecordAdmissionThroughput records the admission of a workload in the ClusterQueue,
+// to compute the admission throughput used to estimate the wait times.
+func (m *Manager) RecordAdmissionThroughput(cqName kueue.ClusterQueueReference) {
+	m.admissionThroughput.record(cqName, m.clock.Now())
+}
+
+// AdmissionThroughput returns the number of workloads admitted per second in
+// the ClusterQueue over the recent window.
+func (m *Manager) AdmissionThroughput(cqName kueue.ClusterQueueReference) float64 {
+	return m.admissionThroughput.rate(cqName, m.clock.Now())
+}
+
+// EstimatedWaitTime returns the estimated time until the pending workload at
+// the position in its ClusterQueue, starting from 0, is admitted, given the
+// admission throughput of the ClusterQueue. The estimate is rounded to the
+// minute. It returns nil when no workload was admitted recently.
+func EstimatedWaitTime(throughput float64, position int) *time.Duration {
+	if throughput == 0 {
+		return nil
+	}
+	waitTime := time.Duration(float64(position+1) / throughput * float64(time.Second))
+	return new(max(time.Minute, waitTime.Round(time.Minute)))
+}
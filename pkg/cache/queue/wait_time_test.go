@@ -0,0 +1,65 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	testingclock "k8s.io/utils/clock/testing"
+
+	preemptexpectations "sigs.k8s.io/kueue/pkg/scheduler/preemption/expectations"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+// TestEstimatedWaitTime verifies that the estimated wait time of a workload
+// decreases as it nears the front of the queue.
+func TestEstimatedWaitTime(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+	manager := NewManagerForUnitTests(utiltesting.NewFakeClient(), nil, WithClock(fakeClock), WithPreemptionExpectations(preemptexpectations.New()))
+
+	estimates := func() []*time.Duration {
+		throughput := manager.AdmissionThroughput("cq")
+		return []*time.Duration{EstimatedWaitTime(throughput, 0), EstimatedWaitTime(throughput, 1), EstimatedWaitTime(throughput, 2)}
+	}
+
+	if diff := cmp.Diff([]*time.Duration{nil, nil, nil}, estimates()); diff != "" {
+		t.Errorf("Unexpected estimates without admissions (-want,+got):\n%s", diff)
+	}
+
+	// Two admissions in ten minutes, that is one admission every five minutes.
+	fakeClock.Step(5 * time.Minute)
+	manager.RecordAdmissionThroughput("cq")
+	fakeClock.Step(5 * time.Minute)
+	manager.RecordAdmissionThroughput("cq")
+
+	want := []*time.Duration{new(5 * time.Minute), new(10 * time.Minute), new(15 * time.Minute)}
+	if diff := cmp.Diff(want, estimates()); diff != "" {
+		t.Errorf("Unexpected estimates (-want,+got):\n%s", diff)
+	}
+	if got := EstimatedWaitTime(manager.AdmissionThroughput("other-cq"), 0); got != nil {
+		t.Errorf("Unexpected estimate in a ClusterQueue without admissions: %v", *got)
+	}
+
+	// The admissions out of the window are no longer accounted.
+	fakeClock.Step(admissionThroughputWindow + time.Minute)
+	if diff := cmp.Diff([]*time.Duration{nil, nil, nil}, estimates()); diff != "" {
+		t.Errorf("Unexpected estimates without recent admissions (-want,+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"cmp"
+	"math"
+	"sync"
+
+	utilqueue "sigs.k8s.io/kueue/pkg/util/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// weightedRoundRobin orders the pending workloads of a ClusterQueue so that
+// its LocalQueues get a share of the admissions proportional to their weights.
+//
+// It implements start-time fair queueing over the admissions: every admission
+// from a LocalQueue advances its finish tag by 1/weight, and the workloads of
+// the LocalQueue with the lowest start tag go first. The start tag of a
+// LocalQueue that was idle catches up with the virtual time, which is the start
+// tag of the last admission, so that the LocalQueue doesn't get a burst of
+// admissions for the time it was idle.
+// A zero weight implies an infinite start tag, so the LocalQueue only gets
+// admissions when no other LocalQueue has pending workloads.
+type weightedRoundRobin struct {
+	sync.RWMutex
+
+	enabled     bool
+	virtualTime float64
+	finishTags  map[utilqueue.LocalQueueReference]float64
+	weights     map[utilqueue.LocalQueueReference]float64
+	// changed records that the start tags changed since the heap was last
+	// ordered.
+	changed bool
+}
+
+func newWeightedRoundRobin() *weightedRoundRobin {
+	return &weightedRoundRobin{
+		finishTags: make(map[utilqueue.LocalQueueReference]float64),
+		weights:    make(map[utilqueue.LocalQueueReference]float64),
+	}
+}
+
+// setEnabled enables or disables the ordering, and returns whether it changed.
+func (w *weightedRoundRobin) setEnabled(enabled bool) bool {
+	w.Lock()
+	defer w.Unlock()
+	if w.enabled == enabled {
+		return false
+	}
+	w.enabled = enabled
+	return true
+}
+
+// takeChanged returns whether the ordering is enabled and the start tags
+// changed since the last call.
+func (w *weightedRoundRobin) takeChanged() bool {
+	w.Lock()
+	defer w.Unlock()
+	changed := w.enabled && w.changed
+	w.changed = false
+	return changed
+}
+
+func (w *weightedRoundRobin) setWeight(lqKey utilqueue.LocalQueueReference, weight float64) {
+	w.Lock()
+	defer w.Unlock()
+	if oldWeight, found := w.weights[lqKey]; !found || oldWeight != weight {
+		w.weights[lqKey] = weight
+		w.changed = true
+	}
+}
+
+func (w *weightedRoundRobin) deleteLocalQueue(lqKey utilqueue.LocalQueueReference) {
+	w.Lock()
+	defer w.Unlock()
+	delete(w.weights, lqKey)
+	delete(w.finishTags, lqKey)
+	w.changed = true
+}
+
+// weightLocked must be called with the lock held.
+func (w *weightedRoundRobin) weightLocked(lqKey utilqueue.LocalQueueReference) float64 {
+	if weight, found := w.weights[lqKey]; found {
+		return weight
+	}
+	return 1
+}
+
+// startTagLocked must be called with the lock held.
+func (w *weightedRoundRobin) startTagLocked(lqKey utilqueue.LocalQueueReference) float64 {
+	if w.weightLocked(lqKey) == 0 {
+		return math.Inf(1)
+	}
+	return max(w.finishTags[lqKey], w.virtualTime)
+}
+
+// recordAdmission accounts for the admission of a workload from the LocalQueue.
+func (w *weightedRoundRobin) recordAdmission(lqKey utilqueue.LocalQueueReference) {
+	w.Lock()
+	defer w.Unlock()
+	start := w.startTagLocked(lqKey)
+	if !math.IsInf(start, 1) {
+		w.virtualTime = start
+	}
+	w.finishTags[lqKey] = start + 1/w.weightLocked(lqKey)
+	w.changed = true
+}
+
+// compare orders the workloads by the start tag of their LocalQueues. It
+// returns 0 when the ordering is disabled.
+func (w *weightedRoundRobin) compare(a, b *workload.Info) int {
+	w.RLock()
+	defer w.RUnlock()
+	if !w.enabled {
+		return 0
+	}
+	lqA := utilqueue.KeyFromWorkload(a.Obj)
+	lqB := utilqueue.KeyFromWorkload(b.Obj)
+	if lqA == lqB {
+		return 0
+	}
+	return cmp.Compare(w.startTagLocked(lqA), w.startTagLocked(lqB))
+}
@@ -19,4 +19,5 @@ package scheduler
 type AdmissionCheck struct {
 	Active     bool
 	Controller string
+	Advisory   bool
 }
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +32,8 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -125,6 +128,13 @@ func WithLocalQueueMetrics(value *metrics.LocalQueueMetricsConfig) Option {
 	}
 }
 
+// WithClock sets the clock used to evaluate the borrowing windows of the ClusterQueues.
+func WithClock(c clock.Clock) Option {
+	return func(cache *Cache) {
+		cache.clock = c
+	}
+}
+
 // Cache keeps track of the Workloads that got admitted through ClusterQueues.
 type Cache struct {
 	sync.RWMutex
@@ -148,6 +158,12 @@ type Cache struct {
 	roleTracker  *roletracker.RoleTracker
 	customLabels *metrics.CustomLabels
 	lqMetrics    *metrics.LocalQueueMetricsConfig
+
+	clock clock.Clock
+
+	// availableQuotaMu guards the usage and the exhausted resources recorded
+	// when the available quota of the cohort trees is observed.
+	availableQuotaMu sync.Mutex
 }
 
 func New(client client.Client, options ...Option) *Cache {
@@ -158,6 +174,7 @@ func New(client client.Client, options ...Option) *Cache {
 		workloadAssignedQueues: make(map[workload.Reference]kueue.ClusterQueueReference),
 		hm:                     hierarchy.NewManager(newCohort),
 		tasCache:               NewTASCache(client),
+		clock:                  clock.RealClock{},
 	}
 	for _, option := range options {
 		option(cache)
@@ -177,6 +194,7 @@ func (c *Cache) newClusterQueue(log logr.Logger, cq *kueue.ClusterQueue) (*clust
 		AdmittedUsage:       make(resources.FlavorResourceQuantities),
 		resourceNode:        NewResourceNode(),
 		tasCache:            &c.tasCache,
+		clock:               c.clock,
 		AdmissionScope:      cq.Spec.AdmissionScope,
 
 		roleTracker: c.roleTracker,
@@ -269,6 +287,34 @@ func (c *Cache) updateClusterQueues(log logr.Logger) sets.Set[kueue.ClusterQueue
 	return cqs
 }
 
+// UpdateNodeCapacityQuotas recomputes the quotas of the ClusterQueues which
+// derive the nominal quota of the flavor from the capacity of its nodes.
+// It returns the names of the ClusterQueues whose quotas were updated.
+func (c *Cache) UpdateNodeCapacityQuotas(log logr.Logger, flavor kueue.ResourceFlavorReference) sets.Set[kueue.ClusterQueueReference] {
+	cqs := sets.New[kueue.ClusterQueueReference]()
+	if !features.Enabled(features.NodeCapacityQuota) {
+		return cqs
+	}
+	c.Lock()
+	defer c.Unlock()
+	for _, cq := range c.hm.ClusterQueues() {
+		if !cq.updateNodeCapacityQuotas(flavor) {
+			continue
+		}
+		if cq.HasParent() {
+			if err := updateCohortTreeResources(cq.Parent()); err != nil {
+				log.Error(err, "Failed to update the Cohort tree resources", "clusterQueue", cq.Name)
+				continue
+			}
+		} else {
+			updateClusterQueueResourceNode(cq)
+		}
+		log.V(3).Info("Updated the quotas derived from the node capacity", "clusterQueue", cq.Name, "flavor", flavor)
+		cqs.Insert(cq.Name)
+	}
+	return cqs
+}
+
 func (c *Cache) ActiveClusterQueues() sets.Set[kueue.ClusterQueueReference] {
 	c.RLock()
 	defer c.RUnlock()
@@ -332,6 +378,7 @@ func (c *Cache) AddOrUpdateAdmissionCheck(log logr.Logger, ac *kueue.AdmissionCh
 	newAC := AdmissionCheck{
 		Active:     apimeta.IsStatusConditionTrue(ac.Status.Conditions, kueue.AdmissionCheckActive),
 		Controller: ac.Spec.ControllerName,
+		Advisory:   ptr.Deref(ac.Spec.Advisory, false),
 	}
 	c.admissionChecks[kueue.AdmissionCheckReference(ac.Name)] = newAC
 
@@ -361,6 +408,53 @@ func (c *Cache) AdmissionChecksForClusterQueue(cqName kueue.ClusterQueueReferenc
 	return acs
 }
 
+// AdvisoryAdmissionChecks returns the advisory admission checks among the given ones.
+func (c *Cache) AdvisoryAdmissionChecks(checks sets.Set[kueue.AdmissionCheckReference]) sets.Set[kueue.AdmissionCheckReference] {
+	c.RLock()
+	defer c.RUnlock()
+	advisory := sets.New[kueue.AdmissionCheckReference]()
+	for acName := range checks {
+		if ac, ok := c.admissionChecks[acName]; ok && ac.Advisory {
+			advisory.Insert(acName)
+		}
+	}
+	return advisory
+}
+
+// ResourcesExceedingFlavorQuotas returns the resources for which the per-pod
+// requests exceed the quota potentially available to every flavor of the
+// ClusterQueue, that is the nominal quota plus what can be borrowed, assuming
+// no usage. Resources not covered by the ClusterQueue are ignored.
+func (c *Cache) ResourcesExceedingFlavorQuotas(cqName kueue.ClusterQueueReference, requests resources.Requests) []corev1.ResourceName {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.hm.ClusterQueue(cqName)
+	if cq == nil {
+		return nil
+	}
+	var exceeding []corev1.ResourceName
+	for _, rg := range cq.ResourceGroups {
+		for rName, value := range requests {
+			if !rg.CoveredResources.Has(rName) {
+				continue
+			}
+			fits := false
+			for _, fName := range rg.Flavors {
+				fr := resources.FlavorResource{Flavor: fName, Resource: rName}
+				if potentialAvailable(cq, fr).Add(cq.overcommit[fr]).CmpInt64(value) >= 0 {
+					fits = true
+					break
+				}
+			}
+			if !fits {
+				exceeding = append(exceeding, rName)
+			}
+		}
+	}
+	slices.Sort(exceeding)
+	return exceeding
+}
+
 func (c *Cache) ClusterQueueActive(name kueue.ClusterQueueReference) bool {
 	return c.clusterQueueInStatus(name, active)
 }
@@ -444,6 +538,7 @@ func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) err
 			admittedWorkloads:  0,
 			totalReserved:      make(resources.FlavorResourceQuantities),
 			admittedUsage:      make(resources.FlavorResourceQuantities),
+			guaranteedQuota:    guaranteedQuotaFor(&q),
 			labels:             q.GetLabels(),
 		}
 		if features.Enabled(features.CustomMetricLabels) {
@@ -717,6 +812,7 @@ func (c *Cache) ClusterQueueUsesAdmissionFairSharing(cqName kueue.ClusterQueueRe
 func (c *Cache) UpdateLocalQueue(oldQ, newQ *kueue.LocalQueue) error {
 	if oldQ.Spec.ClusterQueue == newQ.Spec.ClusterQueue {
 		c.updateLqMetricLabels(newQ)
+		c.updateLqGuaranteedQuota(newQ)
 		return nil
 	}
 	c.Lock()
@@ -749,6 +845,16 @@ func (c *Cache) updateLqMetricLabels(newLq *kueue.LocalQueue) {
 	}
 }
 
+func (c *Cache) updateLqGuaranteedQuota(newLq *kueue.LocalQueue) {
+	cachedLq, err := c.GetCacheLocalQueue(newLq.Spec.ClusterQueue, queue.Key(newLq))
+	if err != nil {
+		return
+	}
+	cachedLq.Lock()
+	defer cachedLq.Unlock()
+	cachedLq.guaranteedQuota = guaranteedQuotaFor(newLq)
+}
+
 func (c *Cache) concurrentAdmissionEnabledForWithoutLock(wl *kueue.Workload) bool {
 	if !features.Enabled(features.ConcurrentAdmission) {
 		return false
@@ -860,6 +966,9 @@ type ClusterQueueUsageStats struct {
 	AdmittedResources  []kueue.FlavorUsage
 	AdmittedWorkloads  int
 	WeightedShare      float64
+	// ExhaustedResources are the flavor-resources for which no quota is
+	// available to the ClusterQueue, including the quota it can borrow.
+	ExhaustedResources []resources.FlavorResource
 }
 
 // Usage reports the reserved and admitted resources and number of workloads holding them in the ClusterQueue.
@@ -878,6 +987,9 @@ func (c *Cache) Usage(cqObj *kueue.ClusterQueue) (*ClusterQueueUsageStats, error
 		AdmittedResources:  getUsage(cq.AdmittedUsage, cq),
 		AdmittedWorkloads:  cq.admittedWorkloadsCount,
 	}
+	if features.Enabled(features.ClusterQueueQuotaExhaustedCondition) {
+		stats.ExhaustedResources = cq.exhaustedResources()
+	}
 
 	if c.fairSharingEnabled {
 		drs := dominantResourceShare(cq, nil)
@@ -886,6 +998,20 @@ func (c *Cache) Usage(cqObj *kueue.ClusterQueue) (*ClusterQueueUsageStats, error
 	return stats, nil
 }
 
+// OverQuotaWorkloads returns the Workloads of the ClusterQueue to evict so
+// that its usage fits its available quota again, for example after its
+// nominalQuota was reduced. The Workloads are returned in eviction order.
+func (c *Cache) OverQuotaWorkloads(log logr.Logger, cqName kueue.ClusterQueueReference, now time.Time) []*workload.Info {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.hm.ClusterQueue(cqName)
+	if cq == nil {
+		return nil
+	}
+	return cq.overQuotaWorkloads(log, now)
+}
+
 type CohortUsageStats struct {
 	WeightedShare float64
 }
@@ -38,6 +38,8 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/cache/hierarchy"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -1917,6 +1919,58 @@ func TestClusterQueueUsage(t *testing.T) {
 	}
 }
 
+func TestClusterQueueUsageWithWorkloadSlices(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ElasticJobsViaWorkloadSlices, true)
+	now := time.Now().Truncate(time.Second)
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	cq := utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "20").Obj()).
+		Obj()
+	admittedSlice := func(name string, count int32) *utiltestingapi.WorkloadWrapper {
+		return utiltestingapi.MakeWorkload(name, "ns").
+			Annotation(constants.ElasticJobAnnotation, "true").
+			PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, int(count)).Request(corev1.ResourceCPU, "1").Obj()).
+			ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+				PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+					Assignment(corev1.ResourceCPU, "default", fmt.Sprintf("%d", count)).
+					Count(count).
+					Obj()).
+				Obj(), now)
+	}
+
+	ctx, log := utiltesting.ContextWithLog(t)
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed to add ClusterQueue: %v", err)
+	}
+	checkUsage := func(step string, want int64) {
+		t.Helper()
+		if diff := cmp.Diff(resources.NewAmount(want), cache.hm.ClusterQueue("cq").resourceNode.Usage[cpu]); diff != "" {
+			t.Errorf("%s: unexpected usage (-want,+got):\n%s", step, diff)
+		}
+	}
+
+	base := admittedSlice("slice-1", 10).Obj()
+	cache.AddOrUpdateWorkload(log, base)
+	checkUsage("base slice admitted", 10_000)
+
+	// The replacement slice reserves the additional quota, on top of the quota
+	// of the slice it replaces, until the replaced slice finishes.
+	scaledUp := admittedSlice("slice-2", 15).Obj()
+	cache.AddOrUpdateWorkload(log, scaledUp)
+	checkUsage("scaled up slice admitted", 25_000)
+	cache.AddOrUpdateWorkload(log, admittedSlice("slice-1", 10).Finished().Obj())
+	checkUsage("replaced slice finished", 15_000)
+
+	// Scaling down updates the admitted slice in place, freeing the quota of
+	// the removed pods.
+	scaledDown := scaledUp.DeepCopy()
+	workload.ApplyPodSetCounts(scaledDown, workload.PodSetsCounts{kueue.DefaultPodSetName: 12})
+	cache.AddOrUpdateWorkload(log, scaledDown)
+	checkUsage("slice scaled down", 12_000)
+}
+
 func TestLocalQueueUsage(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
 	cq := *utiltestingapi.MakeClusterQueue("foo").
@@ -17,19 +17,23 @@ limitations under the License.
 package scheduler
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"maps"
 	"math"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
@@ -39,11 +43,14 @@ import (
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/util/borrowingwindow"
 	utilmath "sigs.k8s.io/kueue/pkg/util/math"
+	utilpreemption "sigs.k8s.io/kueue/pkg/util/preemption"
 	"sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	stringsutils "sigs.k8s.io/kueue/pkg/util/strings"
 	"sigs.k8s.io/kueue/pkg/workload"
+	workloadevict "sigs.k8s.io/kueue/pkg/workload/evict"
 )
 
 var (
@@ -87,8 +94,24 @@ type clusterQueue struct {
 	resourceNode resourceNode
 	hierarchy.ClusterQueue[*cohort]
 
+	// quotaSpec holds the quota related fields of the ClusterQueue spec,
+	// required to recompute the quotas derived from the node capacity.
+	quotaSpec quotaSpec
+
+	// overcommit is the capacity the overcommit ratios add to the nominal
+	// quota. It is only available to the ClusterQueue itself.
+	overcommit resources.FlavorResourceQuantities
+
+	// observedExhaustedResources are the exhausted resources when the
+	// available quota of the ClusterQueue was last observed.
+	observedExhaustedResources []resources.FlavorResource
+
 	tasCache *tasCache
 
+	// clock is used to evaluate the readiness of the nodes the quotas derived
+	// from the node capacity are computed from.
+	clock clock.Clock
+
 	// isTASSynced determines if the TAS cached is synced, ie: initialized,
 	// and all TAS Workloads are accounted in the cache. The distintion between
 	// initialized and synced is introduced to make sure all pre-existing
@@ -99,6 +122,17 @@ type clusterQueue struct {
 
 	ConcurrentAdmissionPolicy *kueue.ConcurrentAdmissionPolicy
 
+	// borrowingWindows restrict the times at which the ClusterQueue can borrow.
+	borrowingWindows borrowingwindow.Windows
+
+	// maxAdmittedWorkloads limits the number of Workloads with quota
+	// reserved in the ClusterQueue.
+	maxAdmittedWorkloads *int32
+
+	// flavorTieBreak chooses the flavor among the flavors which fit a
+	// Workload equally well.
+	flavorTieBreak *kueue.FlavorTieBreakPolicy
+
 	roleTracker *roletracker.RoleTracker
 
 	// values extracted from K8s labels/annotations, used as custom Prometheus metric labels
@@ -148,7 +182,13 @@ func (c *clusterQueue) updateClusterQueue(
 	admissionChecks map[kueue.AdmissionCheckReference]AdmissionCheck,
 	oldParent *cohort,
 ) error {
-	if c.updateQuotasAndResourceGroups(in.Spec.ResourceGroups) || oldParent != c.Parent() {
+	resourceBorrowingLimitsChanged := c.updateResourceBorrowingLimits(in.Spec.ResourceBorrowingLimits)
+	c.quotaSpec = quotaSpec{
+		resourceGroups:      in.Spec.ResourceGroups,
+		overcommitRatios:    in.Spec.OvercommitRatios,
+		nodeCapacityFlavors: in.Spec.NodeCapacityFlavors,
+	}
+	if c.updateQuotasAndResourceGroups() || resourceBorrowingLimitsChanged || oldParent != c.Parent() {
 		if oldParent != nil && oldParent != c.Parent() {
 			updateCohortTreeResourcesIfNoCycle(oldParent)
 		}
@@ -200,6 +240,22 @@ func (c *clusterQueue) updateClusterQueue(
 	if features.Enabled(features.ConcurrentAdmission) {
 		c.ConcurrentAdmissionPolicy = in.Spec.ConcurrentAdmissionPolicy
 	}
+	c.borrowingWindows = nil
+	if features.Enabled(features.BorrowingWindows) {
+		borrowingWindows, err := borrowingwindow.Parse(in.Spec.BorrowingWindows)
+		if err != nil {
+			return err
+		}
+		c.borrowingWindows = borrowingWindows
+	}
+	c.maxAdmittedWorkloads = nil
+	if features.Enabled(features.ClusterQueueMaxAdmittedWorkloads) {
+		c.maxAdmittedWorkloads = in.Spec.MaxAdmittedWorkloads
+	}
+	c.flavorTieBreak = nil
+	if features.Enabled(features.FlavorTieBreak) {
+		c.flavorTieBreak = in.Spec.FlavorTieBreak
+	}
 	return nil
 }
 
@@ -224,13 +280,29 @@ func createdResourceGroups(kueueRgs []kueue.ResourceGroup) []ResourceGroup {
 	return rgs
 }
 
-// updateQuotasAndResourceGroups updates Quotas and ResourceGroups.
-// It returns true if any changes were made.
-func (c *clusterQueue) updateQuotasAndResourceGroups(in []kueue.ResourceGroup) bool {
+// quotaSpec holds the fields of the ClusterQueue spec the quotas are
+// computed from.
+type quotaSpec struct {
+	resourceGroups      []kueue.ResourceGroup
+	overcommitRatios    []kueue.ResourceOvercommitRatio
+	nodeCapacityFlavors []kueue.ResourceFlavorReference
+}
+
+// updateQuotasAndResourceGroups updates Quotas and ResourceGroups from the
+// quotaSpec. It returns true if any changes were made.
+func (c *clusterQueue) updateQuotasAndResourceGroups() bool {
 	oldRG := c.ResourceGroups
 	oldQuotas := c.resourceNode.Quotas
-	c.ResourceGroups = createdResourceGroups(in)
-	c.resourceNode.Quotas = createResourceQuotas(in)
+	oldOvercommit := c.overcommit
+	c.ResourceGroups = createdResourceGroups(c.quotaSpec.resourceGroups)
+	c.resourceNode.Quotas = createResourceQuotas(c.quotaSpec.resourceGroups)
+	if features.Enabled(features.NodeCapacityQuota) && len(c.quotaSpec.nodeCapacityFlavors) > 0 {
+		applyNodeCapacity(c.resourceNode.Quotas, c.nodeCapacity(c.clock.Now()))
+	}
+	c.overcommit = nil
+	if features.Enabled(features.ClusterQueueOvercommit) {
+		c.overcommit = overcommitCapacity(c.resourceNode.Quotas, c.quotaSpec.overcommitRatios)
+	}
 
 	// Start at 1, for backwards compatibility.
 	// Use maps.EqualFunc with ResourceQuota.Equal for the Quotas map: it holds
@@ -238,7 +310,42 @@ func (c *clusterQueue) updateQuotasAndResourceGroups(in []kueue.ResourceGroup) b
 	// equality.Semantic.DeepEqual (a forked reflect-based DeepEqual) to panic.
 	return c.AllocatableResourceGeneration == 0 ||
 		!equality.Semantic.DeepEqual(oldRG, c.ResourceGroups) ||
-		!maps.EqualFunc(oldQuotas, c.resourceNode.Quotas, ResourceQuota.Equal)
+		!maps.EqualFunc(oldQuotas, c.resourceNode.Quotas, ResourceQuota.Equal) ||
+		!maps.Equal(oldOvercommit, c.overcommit)
+}
+
+// nodeCapacity returns the capacity of the nodes of the flavors whose nominal
+// quota is derived from the node capacity.
+func (c *clusterQueue) nodeCapacity(now time.Time) map[kueue.ResourceFlavorReference]resources.Requests {
+	capacity := make(map[kueue.ResourceFlavorReference]resources.Requests, len(c.quotaSpec.nodeCapacityFlavors))
+	for _, flavor := range c.quotaSpec.nodeCapacityFlavors {
+		capacity[flavor] = c.tasCache.NodeCapacity(flavor, now)
+	}
+	return capacity
+}
+
+// updateNodeCapacityQuotas recomputes the quotas if their nominal quota is
+// derived from the capacity of the nodes of the flavor.
+// It returns true if any changes were made.
+func (c *clusterQueue) updateNodeCapacityQuotas(flavor kueue.ResourceFlavorReference) bool {
+	if !slices.Contains(c.quotaSpec.nodeCapacityFlavors, flavor) {
+		return false
+	}
+	return c.updateQuotasAndResourceGroups()
+}
+
+// updateResourceBorrowingLimits updates the ResourceBorrowingLimits of the
+// resource node. It returns true if any changes were made.
+func (c *clusterQueue) updateResourceBorrowingLimits(in []kueue.ResourceBorrowingLimit) bool {
+	oldLimits := c.resourceNode.ResourceBorrowingLimits
+	c.resourceNode.ResourceBorrowingLimits = nil
+	if features.Enabled(features.ResourceBorrowingLimits) && len(in) > 0 {
+		c.resourceNode.ResourceBorrowingLimits = make(map[corev1.ResourceName]resources.Amount, len(in))
+		for _, limit := range in {
+			c.resourceNode.ResourceBorrowingLimits[limit.Name] = resources.AmountFromQuantity(limit.Name, limit.BorrowingLimit)
+		}
+	}
+	return !maps.EqualFunc(oldLimits, c.resourceNode.ResourceBorrowingLimits, resources.Amount.Equal)
 }
 
 func (c *clusterQueue) updateQueueStatus(log logr.Logger) {
@@ -544,11 +651,56 @@ func (c *clusterQueue) reportResourceMetrics(fairSharingEnabled bool) {
 		metrics.ReportClusterQueueResourceReservations(cohort, cqName, fName, rName, resourceFloat(fr.Resource, c.resourceNode.Usage[fr].Int64()), c.customMetricLabelValues, c.roleTracker)
 		metrics.ReportClusterQueueResourceUsage(cohort, cqName, fName, rName, resourceFloat(fr.Resource, c.AdmittedUsage[fr].Int64()), c.customMetricLabelValues, c.roleTracker)
 	}
+	c.reportAvailableQuota(cohort)
 	if fairSharingEnabled {
 		c.reportWeightedShare(cohort)
 	}
 }
 
+// reportAvailableQuota reports the quota which remains available to the
+// ClusterQueue given the current usage of its cohort tree, and the part of it
+// which exceeds the unused nominal quota, and would be borrowed from the cohort.
+func (c *clusterQueue) reportAvailableQuota(cohort kueue.CohortReference) {
+	if c.HasParent() && hierarchy.HasCycle(c.Parent()) {
+		return
+	}
+	cqName := string(c.Name)
+	zero := resources.NewAmount(0)
+	for fr, quota := range c.resourceNode.Quotas {
+		avail := resources.MaxAmount(zero, available(c, fr).Add(c.overcommit[fr]))
+		unusedNominal := resources.MaxAmount(zero, quota.Nominal.Sub(c.resourceNode.Usage[fr]))
+		borrowable := resources.MaxAmount(zero, avail.Sub(unusedNominal))
+		metrics.ReportClusterQueueAvailableQuota(cohort, cqName, string(fr.Flavor), string(fr.Resource), quotaFloat(fr.Resource, avail), quotaFloat(fr.Resource, borrowable), c.customMetricLabelValues, c.roleTracker)
+	}
+}
+
+// exhaustedResources returns the flavor-resources for which no quota is
+// available to the ClusterQueue, including the quota it can borrow from its
+// cohort, sorted by flavor and resource.
+func (c *clusterQueue) exhaustedResources() []resources.FlavorResource {
+	if c.HasParent() && hierarchy.HasCycle(c.Parent()) {
+		return nil
+	}
+	var exhausted []resources.FlavorResource
+	for fr := range c.resourceNode.Quotas {
+		if available(c, fr).CmpInt64(0) <= 0 {
+			exhausted = append(exhausted, fr)
+		}
+	}
+	slices.SortFunc(exhausted, func(a, b resources.FlavorResource) int {
+		return cmp.Or(cmp.Compare(a.Flavor, b.Flavor), cmp.Compare(a.Resource, b.Resource))
+	})
+	return exhausted
+}
+
+// quotaFloat is like resourceFloat, but reports Unlimited as +Inf.
+func quotaFloat(name corev1.ResourceName, a resources.Amount) float64 {
+	if a == resources.Unlimited {
+		return math.Inf(1)
+	}
+	return resourceFloat(name, a.Int64())
+}
+
 func (c *clusterQueue) reportWeightedShare(cohort kueue.CohortReference) {
 	drs := dominantResourceShare(c, nil)
 	weightedShare := drs.PreciseWeightedShare()
@@ -628,6 +780,7 @@ func (c *clusterQueue) addLocalQueue(q *kueue.LocalQueue, customLabelValues []st
 		key:                     qKey,
 		reservingWorkloads:      0,
 		totalReserved:           make(resources.FlavorResourceQuantities),
+		guaranteedQuota:         guaranteedQuotaFor(q),
 		customMetricLabelValues: customLabelValues,
 		labels:                  q.GetLabels(),
 	}
@@ -662,6 +815,79 @@ func (c *clusterQueue) deleteLocalQueue(q *kueue.LocalQueue) {
 	delete(c.localQueues, qKey)
 }
 
+// overQuotaWorkloads returns the Workloads to evict so that the usage of the
+// ClusterQueue fits its available quota again, in eviction order: lower
+// priority first, and more recently admitted first. The Workloads which are
+// already evicted are expected to release their quota, so they are not
+// returned.
+func (c *clusterQueue) overQuotaWorkloads(log logr.Logger, now time.Time) []*workload.Info {
+	if c.HasParent() && hierarchy.HasCycle(c.Parent()) {
+		return nil
+	}
+	excess := make(resources.FlavorResourceQuantities)
+	for fr, usage := range c.resourceNode.Usage {
+		overNominal := usage.Sub(c.resourceNode.SubtreeQuota[fr])
+		overAvailable := resources.NewAmount(0).Sub(available(c, fr).Add(c.overcommit[fr]))
+		if e := resources.MinAmount(overNominal, overAvailable); e.CmpInt64(0) > 0 {
+			excess[fr] = e
+		}
+	}
+	if len(excess) == 0 {
+		return nil
+	}
+
+	candidates := make([]*workload.Info, 0, len(c.Workloads))
+	for _, wi := range c.Workloads {
+		if workloadevict.IsEvicted(wi.Obj) {
+			subtractFromExcess(excess, wi.FlavorResourceUsage())
+			continue
+		}
+		candidates = append(candidates, wi)
+	}
+	slices.SortFunc(candidates, func(a, b *workload.Info) int {
+		return utilpreemption.CandidatesOrdering(log, false, a, b, c.Name, now)
+	})
+
+	var targets []*workload.Info
+	for _, wi := range candidates {
+		if len(excess) == 0 {
+			break
+		}
+		usage := wi.FlavorResourceUsage()
+		if !reducesExcess(excess, usage) {
+			continue
+		}
+		targets = append(targets, wi)
+		subtractFromExcess(excess, usage)
+	}
+	return targets
+}
+
+// reducesExcess returns whether the usage covers any of the flavor resources
+// in excess.
+func reducesExcess(excess, usage resources.FlavorResourceQuantities) bool {
+	for fr, q := range usage {
+		if _, found := excess[fr]; found && q.CmpInt64(0) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// subtractFromExcess subtracts the usage from the excess, dropping the flavor
+// resources which are no longer in excess.
+func subtractFromExcess(excess, usage resources.FlavorResourceQuantities) {
+	for fr, q := range usage {
+		if e, found := excess[fr]; found {
+			if e = e.Sub(q); e.CmpInt64(0) > 0 {
+				excess[fr] = e
+			} else {
+				delete(excess, fr)
+			}
+		}
+	}
+}
+
 func (c *clusterQueue) flavorInUse(flavor kueue.ResourceFlavorReference) bool {
 	for _, rg := range c.ResourceGroups {
 		if slices.Contains(rg.Flavors, flavor) {
@@ -17,10 +17,15 @@ limitations under the License.
 package scheduler
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/cache/hierarchy"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
 )
@@ -39,6 +44,62 @@ func (c *Cache) RecordClusterQueueResourceMetrics(log logr.Logger, cqName kueue.
 	cq.reportResourceMetrics(c.fairSharingEnabled)
 }
 
+// UpdateAvailableQuota observes the quota available to the ClusterQueues of
+// the cohort tree of the ClusterQueue, after a change of the ClusterQueue.
+// Only the subtree of the highest cohort whose usage changed since the last
+// observation is affected, or the whole tree if the quotas changed.
+// It reports the available quota of the ClusterQueues of the subtree, if the
+// resource metrics are enabled, and returns the other ClusterQueues of the
+// subtree whose exhausted resources changed.
+func (c *Cache) UpdateAvailableQuota(log logr.Logger, cqName kueue.ClusterQueueReference, quotasChanged bool) []kueue.ClusterQueueReference {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.hm.ClusterQueue(cqName)
+	if cq == nil || !cq.HasParent() || hierarchy.HasCycle(cq.Parent()) {
+		return nil
+	}
+
+	c.availableQuotaMu.Lock()
+	defer c.availableQuotaMu.Unlock()
+
+	var subtree *cohort
+	for ancestor := range cq.Parent().PathSelfToRoot() {
+		if quotasChanged || !maps.Equal(ancestor.observedUsage, ancestor.resourceNode.Usage) {
+			subtree = ancestor
+			ancestor.observedUsage = maps.Clone(ancestor.resourceNode.Usage)
+		}
+	}
+	if subtree == nil {
+		return nil
+	}
+	log.V(4).Info("Updating the available quota of the cohort subtree", "cohort", subtree.Name)
+	var changed []kueue.ClusterQueueReference
+	c.updateSubtreeAvailableQuota(subtree, cq, &changed)
+	return changed
+}
+
+func (c *Cache) updateSubtreeAvailableQuota(ch *cohort, skip *clusterQueue, changed *[]kueue.ClusterQueueReference) {
+	for _, child := range ch.ChildCQs() {
+		if c.resourceMetricsEnabled && child != skip {
+			child.reportAvailableQuota(ch.GetName())
+		}
+		if !features.Enabled(features.ClusterQueueQuotaExhaustedCondition) {
+			continue
+		}
+		exhausted := child.exhaustedResources()
+		if !slices.Equal(exhausted, child.observedExhaustedResources) {
+			child.observedExhaustedResources = exhausted
+			if child != skip {
+				*changed = append(*changed, child.Name)
+			}
+		}
+	}
+	for _, child := range ch.ChildCohorts() {
+		c.updateSubtreeAvailableQuota(child, skip, changed)
+	}
+}
+
 func (c *Cache) ClearClusterQueueOldResourceMetrics(log logr.Logger, oldCq *kueue.ClusterQueue) {
 	c.RLock()
 	defer c.RUnlock()
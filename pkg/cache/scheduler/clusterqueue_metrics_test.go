@@ -0,0 +1,196 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingmetrics "sigs.k8s.io/kueue/pkg/util/testing/metrics"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func TestRecordClusterQueueAvailableQuota(t *testing.T) {
+	ctx, log := utiltesting.ContextWithLog(t)
+	defer metrics.InitMetricVectors(nil)
+	now := time.Now()
+
+	cache := New(utiltesting.NewFakeClient(), WithResourceMetrics(true))
+	cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltestingapi.MakeClusterQueue("cq1").
+			Cohort("cohort").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+			Obj(),
+		utiltestingapi.MakeClusterQueue("cq2").
+			Cohort("cohort").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+			Obj(),
+		utiltestingapi.MakeClusterQueue("standalone").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+			Obj(),
+	} {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed to add cluster queue: %v", err)
+		}
+		cache.RecordClusterQueueResourceMetrics(log, kueue.ClusterQueueReference(cq.Name))
+		cache.UpdateAvailableQuota(log, kueue.ClusterQueueReference(cq.Name), true)
+	}
+
+	expectQuota := func(cq string, wantAvailable, wantBorrowable float64) {
+		t.Helper()
+		lbls := map[string]string{"cluster_queue": cq, "flavor": "default", "resource": string(corev1.ResourceCPU)}
+		for _, tc := range []struct {
+			metric string
+			got    []testingmetrics.MetricDataPoint
+			want   float64
+		}{
+			{metric: "available", got: testingmetrics.CollectFilteredGaugeVec(metrics.ClusterQueueResourceAvailableQuota, lbls), want: wantAvailable},
+			{metric: "borrowable", got: testingmetrics.CollectFilteredGaugeVec(metrics.ClusterQueueResourceBorrowableQuota, lbls), want: wantBorrowable},
+		} {
+			if len(tc.got) != 1 {
+				t.Fatalf("Unexpected number of %s quota metrics for %q: got %d, want 1", tc.metric, cq, len(tc.got))
+			}
+			if tc.got[0].Value != tc.want {
+				t.Errorf("Unexpected %s quota for %q: got %v, want %v", tc.metric, cq, tc.got[0].Value, tc.want)
+			}
+		}
+	}
+	admit := func(name string, cq kueue.ClusterQueueReference, cpu string) {
+		t.Helper()
+		wl := utiltestingapi.MakeWorkload(name, "ns").
+			Request(corev1.ResourceCPU, cpu).
+			ReserveQuotaAt(utiltestingapi.MakeAdmission(cq).
+				PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+					Assignment(corev1.ResourceCPU, "default", cpu).
+					Obj()).Obj(), now).
+			Obj()
+		if !cache.AddOrUpdateWorkload(log, wl) {
+			t.Fatalf("Failed to add workload %q", name)
+		}
+		cache.RecordClusterQueueResourceMetrics(log, cq)
+		cache.UpdateAvailableQuota(log, cq, false)
+	}
+
+	expectQuota("cq1", 10, 5)
+	expectQuota("cq2", 10, 5)
+	expectQuota("standalone", 5, 0)
+
+	admit("a", "cq2", "3")
+	expectQuota("cq1", 7, 2)
+	expectQuota("cq2", 7, 5)
+
+	admit("b", "cq2", "4")
+	expectQuota("cq1", 3, 0)
+	expectQuota("cq2", 3, 3)
+
+	admit("c", "cq1", "1")
+	expectQuota("cq1", 2, 0)
+	expectQuota("cq2", 2, 2)
+	expectQuota("standalone", 5, 0)
+}
+
+func TestUpdateAvailableQuota(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ClusterQueueQuotaExhaustedCondition, true)
+	ctx, log := utiltesting.ContextWithLog(t)
+	now := time.Now()
+
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+	for _, cohort := range []*kueue.Cohort{
+		utiltestingapi.MakeCohort("root").Obj(),
+		utiltestingapi.MakeCohort("left").Parent("root").Obj(),
+	} {
+		if err := cache.AddOrUpdateCohort(cohort); err != nil {
+			t.Fatalf("Failed to add cohort: %v", err)
+		}
+	}
+	for _, cq := range []*kueue.ClusterQueue{
+		// The usage of lent-limited stays within the ClusterQueue, up to the
+		// part of its quota which isn't lent.
+		utiltestingapi.MakeClusterQueue("lent-limited").
+			Cohort("left").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4", "", "1").Obj()).
+			Obj(),
+		utiltestingapi.MakeClusterQueue("left-sibling").
+			Cohort("left").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+			Obj(),
+		utiltestingapi.MakeClusterQueue("right").
+			Cohort("root").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+			Obj(),
+	} {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed to add cluster queue: %v", err)
+		}
+		cache.UpdateAvailableQuota(log, kueue.ClusterQueueReference(cq.Name), true)
+	}
+	admit := func(name string, cpu string) {
+		t.Helper()
+		wl := utiltestingapi.MakeWorkload(name, "ns").
+			Request(corev1.ResourceCPU, cpu).
+			ReserveQuotaAt(utiltestingapi.MakeAdmission("lent-limited").
+				PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+					Assignment(corev1.ResourceCPU, "default", cpu).
+					Obj()).Obj(), now).
+			Obj()
+		if !cache.AddOrUpdateWorkload(log, wl) {
+			t.Fatalf("Failed to add workload %q", name)
+		}
+	}
+
+	// The usage doesn't exceed the quota which isn't lent, so the cohorts
+	// are not affected.
+	admit("a", "3")
+	if got := cache.UpdateAvailableQuota(log, "lent-limited", false); len(got) != 0 {
+		t.Errorf("Unexpected ClusterQueues with exhausted resources changes: %v", got)
+	}
+
+	// The usage exhausts the lent quota, which is all the quota available to
+	// the other ClusterQueues.
+	admit("b", "1")
+	got := cache.UpdateAvailableQuota(log, "lent-limited", false)
+	want := []kueue.ClusterQueueReference{"left-sibling", "right"}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b kueue.ClusterQueueReference) bool { return a < b })); diff != "" {
+		t.Errorf("Unexpected ClusterQueues with exhausted resources changes (-want,+got):\n%s", diff)
+	}
+
+	// Nothing changed since the last observation.
+	if got := cache.UpdateAvailableQuota(log, "lent-limited", false); len(got) != 0 {
+		t.Errorf("Unexpected ClusterQueues with exhausted resources changes: %v", got)
+	}
+}
+
+func TestQuotaFloat(t *testing.T) {
+	if got := quotaFloat(corev1.ResourceCPU, resources.Unlimited); !math.IsInf(got, 1) {
+		t.Errorf("Unexpected value for unlimited quota: got %v, want +Inf", got)
+	}
+	if got := quotaFloat(corev1.ResourceCPU, resources.NewAmount(1_500)); got != 1.5 {
+		t.Errorf("Unexpected value for cpu quota: got %v, want 1.5", got)
+	}
+}
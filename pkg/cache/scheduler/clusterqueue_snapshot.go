@@ -24,12 +24,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/cache/hierarchy"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/queue"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -76,6 +78,23 @@ type ClusterQueueSnapshot struct {
 
 	flavorsForProvReqACs sets.Set[kueue.ResourceFlavorReference]
 	hasMultiKueueAC      bool
+
+	// localQueueGuarantees holds the guaranteed quota of the LocalQueues
+	// which have one, and localQueueUsage their reserved quota.
+	localQueueGuarantees map[queue.LocalQueueReference]resources.FlavorResourceQuantities
+	localQueueUsage      map[queue.LocalQueueReference]resources.FlavorResourceQuantities
+
+	// overcommit is the capacity the overcommit ratios add to the nominal
+	// quota of the ClusterQueue.
+	overcommit resources.FlavorResourceQuantities
+
+	// maxAdmittedWorkloads limits the number of Workloads with quota
+	// reserved in the ClusterQueue.
+	maxAdmittedWorkloads *int32
+
+	// flavorTieBreak chooses the flavor among the flavors which fit a
+	// Workload equally well.
+	flavorTieBreak *kueue.FlavorTieBreakPolicy
 }
 
 // RGByResource returns the ResourceGroup which contains capacity
@@ -140,6 +159,9 @@ func (c *ClusterQueueSnapshot) Fits(usage workload.Usage) FitsCheck {
 			return FitsCheckNoQuota
 		}
 	}
+	if !fitsResourceBorrowingLimits(c, usage.Quota) {
+		return FitsCheckNoQuota
+	}
 	for tasFlavor, flvUsage := range usage.TAS {
 		// We assume the `tasFlavor` is already in the snapshot as this was
 		// already checked earlier during flavor assignment, and the set of
@@ -151,6 +173,61 @@ func (c *ClusterQueueSnapshot) Fits(usage workload.Usage) FitsCheck {
 	return FitsCheckOk
 }
 
+// AddLocalQueueUsage adds the quota reserved by a workload of the LocalQueue
+// to the usage of the LocalQueue, if it has a guaranteed quota.
+func (c *ClusterQueueSnapshot) AddLocalQueueUsage(lq queue.LocalQueueReference, quota resources.FlavorResourceQuantities) {
+	if usage, found := c.localQueueUsage[lq]; found {
+		updateFlavorUsage(quota, usage, add)
+	}
+}
+
+// RemoveLocalQueueUsage removes the quota reserved by a workload of the
+// LocalQueue from the usage of the LocalQueue, if it has a guaranteed quota.
+func (c *ClusterQueueSnapshot) RemoveLocalQueueUsage(lq queue.LocalQueueReference, quota resources.FlavorResourceQuantities) {
+	if usage, found := c.localQueueUsage[lq]; found {
+		updateFlavorUsage(quota, usage, subtract)
+	}
+}
+
+// GuaranteedToOtherLocalQueues returns the quota of the resource which is
+// guaranteed to the LocalQueues other than lq, and not used by them yet.
+func (c *ClusterQueueSnapshot) GuaranteedToOtherLocalQueues(lq queue.LocalQueueReference, fr resources.FlavorResource) resources.Amount {
+	reserved := resources.NewAmount(0)
+	for key, guaranteed := range c.localQueueGuarantees {
+		if key == lq {
+			continue
+		}
+		if unused := guaranteed[fr].Sub(c.localQueueUsage[key][fr]); unused.CmpInt64(0) > 0 {
+			reserved = reserved.Add(unused)
+		}
+	}
+	return reserved
+}
+
+// FitsGuaranteedQuota returns whether the quota fits the capacity available
+// to the LocalQueue, without using the quota guaranteed to the other
+// LocalQueues.
+func (c *ClusterQueueSnapshot) FitsGuaranteedQuota(lq queue.LocalQueueReference, quota resources.FlavorResourceQuantities) bool {
+	for fr, q := range quota {
+		if c.Available(fr).Sub(c.GuaranteedToOtherLocalQueues(lq, fr)).Cmp(q) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MaxAdmittedWorkloadsReached returns true if the number of Workloads with
+// quota reserved in the ClusterQueue reached its maxAdmittedWorkloads.
+func (c *ClusterQueueSnapshot) MaxAdmittedWorkloadsReached() bool {
+	return c.maxAdmittedWorkloads != nil && len(c.Workloads) >= int(*c.maxAdmittedWorkloads)
+}
+
+// FlavorTieBreak returns the policy choosing the flavor among the flavors
+// which fit a Workload equally well.
+func (c *ClusterQueueSnapshot) FlavorTieBreak() kueue.FlavorTieBreakPolicy {
+	return ptr.Deref(c.flavorTieBreak, kueue.FlavorTieBreakNone)
+}
+
 func (c *ClusterQueueSnapshot) QuotaFor(fr resources.FlavorResource) ResourceQuota {
 	return c.ResourceNode.Quotas[fr]
 }
@@ -164,18 +241,18 @@ func (c *ClusterQueueSnapshot) BorrowingWith(fr resources.FlavorResource, val re
 }
 
 // Available returns the current capacity available, before preempting
-// any workloads. Includes local capacity and capacity borrowed from
-// Cohort. When the ClusterQueue/Cohort is in debt, Available
-// will return 0.
+// any workloads. Includes local capacity, capacity borrowed from
+// Cohort and the overcommitted capacity. When the ClusterQueue/Cohort
+// is in debt, Available will return 0.
 func (c *ClusterQueueSnapshot) Available(fr resources.FlavorResource) resources.Amount {
-	return resources.MaxAmount(resources.NewAmount(0), available(c, fr))
+	return resources.MaxAmount(resources.NewAmount(0), available(c, fr).Add(c.overcommit[fr]))
 }
 
 // PotentialAvailable returns the largest workload this ClusterQueue could
 // possibly admit, accounting for its capacity and capacity borrowed
 // its from Cohort.
 func (c *ClusterQueueSnapshot) PotentialAvailable(fr resources.FlavorResource) resources.Amount {
-	return potentialAvailable(c, fr)
+	return potentialAvailable(c, fr).Add(c.overcommit[fr])
 }
 
 func (c *ClusterQueueSnapshot) GetName() kueue.ClusterQueueReference {
@@ -202,6 +279,12 @@ func (c *ClusterQueueSnapshot) DominantResourceShare() DRS {
 	return dominantResourceShare(c, nil)
 }
 
+// CohortUsageShare returns the share of the quota of its Cohort tree used by
+// the ClusterQueue. It is not the DRS used for fair sharing.
+func (c *ClusterQueueSnapshot) CohortUsageShare() float64 {
+	return cohortUsageShare(c)
+}
+
 type WorkloadTASRequests map[kueue.ResourceFlavorReference]FlavorTASRequests
 
 func (c *ClusterQueueSnapshot) FindTopologyAssignmentsForWorkload(
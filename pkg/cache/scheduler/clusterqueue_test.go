@@ -18,16 +18,23 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	tasindexer "sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestClusterQueueUpdateWithFlavors(t *testing.T) {
@@ -649,3 +656,289 @@ func TestClusterQueueReadinessWithTAS(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterQueueOvercommitRatios(t *testing.T) {
+	x86CPU := resources.FlavorResource{Flavor: "x86", Resource: corev1.ResourceCPU}
+	x86Memory := resources.FlavorResource{Flavor: "x86", Resource: corev1.ResourceMemory}
+	armCPU := resources.FlavorResource{Flavor: "arm", Resource: corev1.ResourceCPU}
+	cq := utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("x86").Resource(corev1.ResourceCPU, "4").Resource(corev1.ResourceMemory, "4Gi").Obj(),
+			*utiltestingapi.MakeFlavorQuotas("arm").Resource(corev1.ResourceCPU, "2").Resource(corev1.ResourceMemory, "2Gi").Obj(),
+		).
+		OvercommitRatio(corev1.ResourceCPU, "1.5").
+		Cohort("team").
+		Obj()
+	otherCQ := utiltestingapi.MakeClusterQueue("other").
+		ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource(corev1.ResourceCPU, "0").Obj()).
+		Cohort("team").
+		Obj()
+
+	testCases := map[string]struct {
+		enableOvercommit bool
+		usage            resources.FlavorResourceQuantities
+		wantFits         FitsCheck
+		wantAvailable    resources.FlavorResourceQuantities
+	}{
+		"cpu is admitted up to 1.5 times the nominal quota": {
+			enableOvercommit: true,
+			usage:            resources.FlavorResourceQuantities{x86CPU: resources.NewAmount(6_000)},
+			wantFits:         FitsCheckOk,
+			wantAvailable: resources.FlavorResourceQuantities{
+				x86CPU:    resources.NewAmount(6_000),
+				x86Memory: resources.NewAmount(4 * 1024 * 1024 * 1024),
+				armCPU:    resources.NewAmount(3_000),
+			},
+		},
+		"cpu above 1.5 times the nominal quota does not fit": {
+			enableOvercommit: true,
+			usage:            resources.FlavorResourceQuantities{x86CPU: resources.NewAmount(6_001)},
+			wantFits:         FitsCheckNoQuota,
+		},
+		"memory is not overcommitted": {
+			enableOvercommit: true,
+			usage:            resources.FlavorResourceQuantities{x86Memory: resources.NewAmount(4*1024*1024*1024 + 1)},
+			wantFits:         FitsCheckNoQuota,
+		},
+		"cpu uses the nominal quota when the feature is disabled": {
+			usage:    resources.FlavorResourceQuantities{x86CPU: resources.NewAmount(4_001)},
+			wantFits: FitsCheckNoQuota,
+			wantAvailable: resources.FlavorResourceQuantities{
+				x86CPU:    resources.NewAmount(4_000),
+				x86Memory: resources.NewAmount(4 * 1024 * 1024 * 1024),
+				armCPU:    resources.NewAmount(2_000),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ClusterQueueOvercommit, tc.enableOvercommit)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("x86").Obj())
+			cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("arm").Obj())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add ClusterQueue: %v", err)
+			}
+			if err := cache.AddClusterQueue(ctx, otherCQ); err != nil {
+				t.Fatalf("Failed to add ClusterQueue: %v", err)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error while building snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueue("cq")
+
+			if got := cqSnapshot.Fits(workload.Usage{Quota: tc.usage}); got != tc.wantFits {
+				t.Errorf("Unexpected fits check, want: %v, got: %v", tc.wantFits, got)
+			}
+			for fr, want := range tc.wantAvailable {
+				if diff := cmp.Diff(want, cqSnapshot.Available(fr)); diff != "" {
+					t.Errorf("Unexpected available %s (-want,+got):\n%s", fr, diff)
+				}
+			}
+			// The overcommit is not reflected in the nominal quota, nor lent to the cohort.
+			if diff := cmp.Diff(resources.NewAmount(4_000), cqSnapshot.QuotaFor(x86CPU).Nominal); diff != "" {
+				t.Errorf("Unexpected nominal quota (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(resources.NewAmount(4_000), snapshot.ClusterQueue("other").Available(x86CPU)); diff != "" {
+				t.Errorf("Unexpected available quota of the other ClusterQueue (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClusterQueueNodeCapacityQuota(t *testing.T) {
+	tasCPU := resources.FlavorResource{Flavor: "tas-flavor", Resource: corev1.ResourceCPU}
+	tasMemory := resources.FlavorResource{Flavor: "tas-flavor", Resource: corev1.ResourceMemory}
+	cq := utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("tas-flavor").Resource(corev1.ResourceCPU, "1").Resource(corev1.ResourceMemory, "1Gi").Obj(),
+		).
+		NodeCapacityFlavors("tas-flavor").
+		Obj()
+	makeNode := func(name string) *corev1.Node {
+		return testingnode.MakeNode(name).
+			Label("tas-node", "true").
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}).
+			Ready().
+			Obj()
+	}
+
+	testCases := map[string]struct {
+		enableNodeCapacityQuota bool
+		nodes                   []*corev1.Node
+		wantUpdated             bool
+		wantNominal             map[resources.FlavorResource]resources.Amount
+	}{
+		"quota is the capacity of a single node": {
+			enableNodeCapacityQuota: true,
+			nodes:                   []*corev1.Node{makeNode("x1")},
+			wantUpdated:             true,
+			wantNominal: map[resources.FlavorResource]resources.Amount{
+				tasCPU:    resources.NewAmount(4_000),
+				tasMemory: resources.NewAmount(4 * 1024 * 1024 * 1024),
+			},
+		},
+		"quota grows when nodes are added": {
+			enableNodeCapacityQuota: true,
+			nodes:                   []*corev1.Node{makeNode("x1"), makeNode("x2")},
+			wantUpdated:             true,
+			wantNominal: map[resources.FlavorResource]resources.Amount{
+				tasCPU:    resources.NewAmount(8_000),
+				tasMemory: resources.NewAmount(8 * 1024 * 1024 * 1024),
+			},
+		},
+		"nodes which are not ready don't contribute": {
+			enableNodeCapacityQuota: true,
+			nodes: []*corev1.Node{
+				makeNode("x1"),
+				testingnode.MakeNode("x2").
+					Label("tas-node", "true").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+					NotReady().
+					Obj(),
+			},
+			wantUpdated: true,
+			wantNominal: map[resources.FlavorResource]resources.Amount{
+				tasCPU:    resources.NewAmount(4_000),
+				tasMemory: resources.NewAmount(4 * 1024 * 1024 * 1024),
+			},
+		},
+		"nominal quota is used when the feature is disabled": {
+			nodes: []*corev1.Node{makeNode("x1"), makeNode("x2")},
+			wantNominal: map[resources.FlavorResource]resources.Amount{
+				tasCPU:    resources.NewAmount(1_000),
+				tasMemory: resources.NewAmount(1024 * 1024 * 1024),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.NodeCapacityQuota, tc.enableNodeCapacityQuota)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := New(utiltesting.NewFakeClient())
+			topology := utiltestingapi.MakeTopology("default").Levels(corev1.LabelHostname).Obj()
+			cache.AddOrUpdateTopology(log, topology)
+			cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("tas-flavor").
+				NodeLabel("tas-node", "true").
+				TopologyName(topology.Name).
+				Obj())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add ClusterQueue: %v", err)
+			}
+			for _, node := range tc.nodes {
+				cache.TASCache().SyncNode(node)
+			}
+
+			gotUpdated := cache.UpdateNodeCapacityQuotas(log, "tas-flavor").Has("cq")
+			if gotUpdated != tc.wantUpdated {
+				t.Errorf("Unexpected update of the quotas, want: %v, got: %v", tc.wantUpdated, gotUpdated)
+			}
+			cachedCQ := cache.hm.ClusterQueue("cq")
+			for fr, want := range tc.wantNominal {
+				if diff := cmp.Diff(want, cachedCQ.resourceNode.Quotas[fr].Nominal); diff != "" {
+					t.Errorf("Unexpected nominal quota %s (-want,+got):\n%s", fr, diff)
+				}
+				if diff := cmp.Diff(want, cachedCQ.resourceNode.SubtreeQuota[fr]); diff != "" {
+					t.Errorf("Unexpected subtree quota %s (-want,+got):\n%s", fr, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestClusterQueueOverQuotaWorkloads(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	admittedWorkload := func(name string, cq kueue.ClusterQueueReference, priority int32) *kueue.Workload {
+		return utiltestingapi.MakeWorkload(name, "ns").
+			Priority(priority).
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuotaAt(utiltestingapi.MakeAdmission(cq).
+				PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+					Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+				Obj(), now).
+			AdmittedAt(true, now).
+			Obj()
+	}
+
+	testCases := map[string]struct {
+		cohort       kueue.CohortReference
+		nominalQuota string
+		lenderQuota  string
+		evicted      sets.Set[string]
+		want         []string
+	}{
+		"usage within the quota": {
+			nominalQuota: "3",
+		},
+		"lowest priority workloads first": {
+			nominalQuota: "1",
+			want:         []string{"low", "mid"},
+		},
+		"evicted workloads are expected to release their quota": {
+			nominalQuota: "1",
+			evicted:      sets.New("low"),
+			want:         []string{"mid"},
+		},
+		"borrowed quota still available in the cohort": {
+			cohort:       "cohort",
+			nominalQuota: "2",
+			lenderQuota:  "2",
+		},
+		"borrowed quota no longer available in the cohort": {
+			cohort:       "cohort",
+			nominalQuota: "2",
+			lenderQuota:  "1",
+			want:         []string{"low"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+			cq := utiltestingapi.MakeClusterQueue("cq").
+				Cohort(tc.cohort).
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, tc.nominalQuota).Obj()).
+				Obj()
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add ClusterQueue: %v", err)
+			}
+			if tc.lenderQuota != "" {
+				lender := utiltestingapi.MakeClusterQueue("lender").
+					Cohort(tc.cohort).
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, tc.lenderQuota).Obj()).
+					Obj()
+				if err := cache.AddClusterQueue(ctx, lender); err != nil {
+					t.Fatalf("Failed to add ClusterQueue: %v", err)
+				}
+				cache.AddOrUpdateWorkload(log, admittedWorkload("lender-wl", "lender", 0))
+			}
+			for wlName, priority := range map[string]int32{"low": 1, "mid": 2, "high": 3} {
+				wl := admittedWorkload(wlName, "cq", priority)
+				if tc.evicted.Has(wlName) {
+					wl.Status.Conditions = append(wl.Status.Conditions, metav1.Condition{
+						Type:   kueue.WorkloadEvicted,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadEvictedByClusterQueueQuotaReduction,
+					})
+				}
+				cache.AddOrUpdateWorkload(log, wl)
+			}
+
+			var got []string
+			for _, wi := range cache.OverQuotaWorkloads(log, "cq", now) {
+				got = append(got, wi.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected over quota workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
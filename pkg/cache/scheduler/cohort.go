@@ -21,6 +21,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/cache/hierarchy"
+	"sigs.k8s.io/kueue/pkg/resources"
 )
 
 // cohort is a set of ClusterQueues that can borrow resources from each other.
@@ -30,6 +31,10 @@ type cohort struct {
 
 	resourceNode resourceNode
 
+	// observedUsage is the usage of the cohort when the available quota of
+	// its subtree was last observed.
+	observedUsage resources.FlavorResourceQuantities
+
 	FairWeight float64
 
 	admittedWorkloadsCount int
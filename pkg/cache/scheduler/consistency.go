@@ -0,0 +1,248 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// ConsistencyCheckInterval is the interval between two cache consistency checks.
+const ConsistencyCheckInterval = 5 * time.Minute
+
+// ConsistencyChecker periodically verifies that the cache matches the
+// Workloads holding quota reservations, and repairs any drift.
+type ConsistencyChecker struct {
+	cache    *Cache
+	interval time.Duration
+}
+
+func NewConsistencyChecker(cache *Cache, interval time.Duration) *ConsistencyChecker {
+	return &ConsistencyChecker{
+		cache:    cache,
+		interval: interval,
+	}
+}
+
+// Start implements the Runnable interface. The first check runs after one
+// interval, so that the cache is populated by the regular event handlers first.
+func (cc *ConsistencyChecker) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("cache_consistency_checker")
+	ctx = ctrl.LoggerInto(ctx, log)
+	ticker := time.NewTicker(cc.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			drifts, err := cc.cache.CheckConsistency(ctx)
+			if err != nil {
+				log.Error(err, "Checking cache consistency")
+				continue
+			}
+			log.V(3).Info("Cache consistency check finished", "drifts", drifts)
+		}
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, so that
+// the check runs alongside the scheduler.
+func (cc *ConsistencyChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// CheckConsistency compares the workloads tracked by the cache with the
+// Workloads holding quota reservations, and the ClusterQueue usage with the
+// usage of their workloads. Every drift found is logged, counted in the
+// cache_drifts_detected_total metric and repaired. It returns the number of
+// drifts found.
+func (c *Cache) CheckConsistency(ctx context.Context) (int, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// The Workloads are listed without holding the lock, so that the scheduler
+	// is not blocked for the duration of the List. The workloads which are
+	// added to or removed from the cache in the meantime are not compared.
+	c.RLock()
+	trackedBefore := maps.Clone(c.workloadAssignedQueues)
+	c.RUnlock()
+
+	var workloads kueue.WorkloadList
+	if err := c.client.List(ctx, &workloads); err != nil {
+		return 0, fmt.Errorf("listing workloads: %w", err)
+	}
+	listed := make(map[workload.Reference]*kueue.Workload, len(workloads.Items))
+	for i := range workloads.Items {
+		listed[workload.Key(&workloads.Items[i])] = &workloads.Items[i]
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	drifts := 0
+	for wlKey, cqName := range maps.Clone(c.workloadAssignedQueues) {
+		if trackedCqName, tracked := trackedBefore[wlKey]; !tracked || trackedCqName != cqName {
+			continue
+		}
+		wl, found := listed[wlKey]
+		if found && workload.HasActiveQuotaReservation(wl) {
+			continue
+		}
+		if found && c.isAssumedWithoutLock(wlKey, cqName, wl) {
+			// The quota reservation made by the scheduler is not yet observed.
+			continue
+		}
+		log.Info("Removing workload which no longer holds a quota reservation from the cache", "workload", wlKey, "clusterQueue", cqName)
+		c.deleteFromQueueIfPresent(log, wlKey, cqName)
+		delete(c.workloadAssignedQueues, wlKey)
+		c.reportDrift(cqName, metrics.CacheDriftStaleWorkload)
+		drifts++
+	}
+
+	for wlKey, wl := range listed {
+		if !workload.HasActiveQuotaReservation(wl) {
+			continue
+		}
+		cqName := wl.Status.Admission.ClusterQueue
+		if c.hm.ClusterQueue(cqName) == nil {
+			continue
+		}
+		if assignedCqName, assigned := c.workloadAssignedQueues[wlKey]; assigned && assignedCqName == cqName {
+			continue
+		}
+		if _, tracked := trackedBefore[wlKey]; tracked {
+			continue
+		}
+		added, err := c.addOrUpdateWorkloadWithoutLock(log, wl)
+		if err != nil {
+			return drifts, err
+		}
+		if added {
+			log.Info("Adding workload holding a quota reservation to the cache", "workload", wlKey, "clusterQueue", cqName)
+			c.reportDrift(cqName, metrics.CacheDriftMissingWorkload)
+			drifts++
+		}
+	}
+
+	for _, cq := range c.hm.ClusterQueues() {
+		if cq.repairUsage(log) {
+			c.reportDrift(cq.Name, metrics.CacheDriftUsage)
+			drifts++
+		}
+	}
+	return drifts, nil
+}
+
+// isAssumedWithoutLock returns true if the cache holds the same version of the
+// workload as the given one, which happens when the scheduler assumed the
+// workload: the cached copy holds the quota reservation, but the update which
+// records it is not yet observed.
+func (c *Cache) isAssumedWithoutLock(wlKey workload.Reference, cqName kueue.ClusterQueueReference, wl *kueue.Workload) bool {
+	cq := c.hm.ClusterQueue(cqName)
+	if cq == nil {
+		return false
+	}
+	wi, found := cq.Workloads[wlKey]
+	return found && wi.Obj.ResourceVersion == wl.ResourceVersion
+}
+
+func (c *Cache) reportDrift(cqName kueue.ClusterQueueReference, driftType metrics.CacheDriftType) {
+	metrics.ReportCacheDrift(cqName, driftType, c.roleTracker)
+}
+
+// repairUsage recomputes the usage of the ClusterQueue from its workloads.
+// If it does not match the tracked usage, the usage is replaced, propagated
+// to the Cohort tree, and true is returned. The TAS usage of the workloads is
+// repaired too; the TAS usage recorded for workloads which are no longer
+// tracked by any ClusterQueue is not.
+func (c *clusterQueue) repairUsage(log logr.Logger) bool {
+	tasRepaired := c.repairTASUsage(log)
+	usage := make(resources.FlavorResourceQuantities, len(c.resourceNode.Usage))
+	admittedUsage := make(resources.FlavorResourceQuantities, len(c.AdmittedUsage))
+	for _, wi := range c.Workloads {
+		frUsage := wi.FlavorResourceUsage()
+		updateFlavorUsage(frUsage, usage, add)
+		if workload.IsAdmitted(wi.Obj) {
+			updateFlavorUsage(frUsage, admittedUsage, add)
+		}
+	}
+	if usageEqual(usage, c.resourceNode.Usage) && usageEqual(admittedUsage, c.AdmittedUsage) {
+		return tasRepaired
+	}
+
+	log.Info("Repairing ClusterQueue usage which does not match the usage of its workloads",
+		"clusterQueue", c.Name, "usage", c.resourceNode.Usage, "expectedUsage", usage)
+	c.resourceNode.Usage = usage
+	c.AdmittedUsage = admittedUsage
+	c.AllocatableResourceGeneration++
+	if c.HasParent() {
+		updateCohortTreeResourcesIfNoCycle(c.Parent())
+	}
+	return true
+}
+
+// repairTASUsage records the TAS usage of the workloads of the ClusterQueue
+// again in the TAS flavors where it is missing or outdated. It returns true if
+// any usage was repaired.
+func (c *clusterQueue) repairTASUsage(log logr.Logger) bool {
+	if !features.Enabled(features.TopologyAwareScheduling) {
+		return false
+	}
+	repaired := false
+	for key, wi := range c.Workloads {
+		if !wi.IsUsingTAS() {
+			continue
+		}
+		for tasFlavor, tasUsage := range wi.TASUsage() {
+			tasFlvCache := c.tasCache.Get(tasFlavor)
+			if tasFlvCache == nil || tasFlvCache.hasUsage(key, tasUsage) {
+				continue
+			}
+			log.Info("Repairing TAS usage which does not match the usage of the workload",
+				"clusterQueue", c.Name, "workload", key, "tasFlavor", tasFlavor)
+			tasFlvCache.addUsage(log, key, tasUsage)
+			repaired = true
+		}
+	}
+	return repaired
+}
+
+// usageEqual compares two usages, treating missing entries as zero.
+func usageEqual(a, b resources.FlavorResourceQuantities) bool {
+	for fr, q := range a {
+		if !q.Equal(b[fr]) {
+			return false
+		}
+	}
+	for fr, q := range b {
+		if !q.Equal(a[fr]) {
+			return false
+		}
+	}
+	return true
+}
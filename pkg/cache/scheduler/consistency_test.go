@@ -0,0 +1,260 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/roletracker"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestCheckConsistency(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	admission := utiltestingapi.MakeAdmission("cq").
+		PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+			Assignment(corev1.ResourceCPU, "default", "1").
+			Obj()).
+		Obj()
+	reservedWorkload := func(name string) *utiltestingapi.WorkloadWrapper {
+		return utiltestingapi.MakeWorkload(name, "ns").
+			ResourceVersion("1").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuotaAt(admission, now)
+	}
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	testCases := map[string]struct {
+		clientObjects  []client.Object
+		operation      func(log logr.Logger, cache *Cache)
+		duringList     func(log logr.Logger, cache *Cache)
+		wantDrifts     int
+		wantWorkloads  sets.Set[workload.Reference]
+		wantUsage      resources.FlavorResourceQuantities
+		wantDriftTypes map[metrics.CacheDriftType]float64
+	}{
+		"consistent cache": {
+			clientObjects: []client.Object{reservedWorkload("a").Obj()},
+			wantWorkloads: sets.New[workload.Reference]("ns/a"),
+			wantUsage:     resources.FlavorResourceQuantities{cpu: resources.NewAmount(1000)},
+		},
+		"workload deleted from the cluster is removed from the cache": {
+			operation: func(log logr.Logger, cache *Cache) {
+				cache.AddOrUpdateWorkload(log, reservedWorkload("a").Obj())
+			},
+			wantDrifts:     1,
+			wantWorkloads:  sets.New[workload.Reference](),
+			wantUsage:      resources.FlavorResourceQuantities{cpu: resources.NewAmount(0)},
+			wantDriftTypes: map[metrics.CacheDriftType]float64{metrics.CacheDriftStaleWorkload: 1},
+		},
+		"finished workload is removed from the cache": {
+			clientObjects: []client.Object{reservedWorkload("a").ResourceVersion("2").Finished().Obj()},
+			operation: func(log logr.Logger, cache *Cache) {
+				cache.AddOrUpdateWorkload(log, reservedWorkload("a").Obj())
+			},
+			wantDrifts:     1,
+			wantWorkloads:  sets.New[workload.Reference](),
+			wantUsage:      resources.FlavorResourceQuantities{cpu: resources.NewAmount(0)},
+			wantDriftTypes: map[metrics.CacheDriftType]float64{metrics.CacheDriftStaleWorkload: 1},
+		},
+		"assumed workload is kept in the cache": {
+			clientObjects: []client.Object{utiltestingapi.MakeWorkload("a", "ns").ResourceVersion("1").Request(corev1.ResourceCPU, "1").Obj()},
+			operation: func(log logr.Logger, cache *Cache) {
+				cache.AddOrUpdateWorkload(log, reservedWorkload("a").Obj())
+			},
+			wantWorkloads: sets.New[workload.Reference]("ns/a"),
+			wantUsage:     resources.FlavorResourceQuantities{cpu: resources.NewAmount(1000)},
+		},
+		"workload holding a quota reservation is added to the cache": {
+			clientObjects: []client.Object{reservedWorkload("a").Obj()},
+			operation: func(log logr.Logger, cache *Cache) {
+				_ = cache.DeleteWorkload(log, "ns/a")
+			},
+			wantDrifts:     1,
+			wantWorkloads:  sets.New[workload.Reference]("ns/a"),
+			wantUsage:      resources.FlavorResourceQuantities{cpu: resources.NewAmount(1000)},
+			wantDriftTypes: map[metrics.CacheDriftType]float64{metrics.CacheDriftMissingWorkload: 1},
+		},
+		"workload reserving quota during the listing is kept in the cache": {
+			duringList: func(log logr.Logger, cache *Cache) {
+				cache.AddOrUpdateWorkload(log, reservedWorkload("a").Obj())
+			},
+			wantWorkloads: sets.New[workload.Reference]("ns/a"),
+			wantUsage:     resources.FlavorResourceQuantities{cpu: resources.NewAmount(1000)},
+		},
+		"workload removed from the cache during the listing is not added back": {
+			clientObjects: []client.Object{reservedWorkload("a").Obj()},
+			duringList: func(log logr.Logger, cache *Cache) {
+				_ = cache.DeleteWorkload(log, "ns/a")
+			},
+			wantWorkloads: sets.New[workload.Reference](),
+			wantUsage:     resources.FlavorResourceQuantities{cpu: resources.NewAmount(0)},
+		},
+		"phantom usage is repaired": {
+			clientObjects: []client.Object{reservedWorkload("a").Obj()},
+			operation: func(_ logr.Logger, cache *Cache) {
+				cache.hm.ClusterQueue("cq").resourceNode.Usage[cpu] = resources.NewAmount(3000)
+			},
+			wantDrifts:     1,
+			wantWorkloads:  sets.New[workload.Reference]("ns/a"),
+			wantUsage:      resources.FlavorResourceQuantities{cpu: resources.NewAmount(1000)},
+			wantDriftTypes: map[metrics.CacheDriftType]float64{metrics.CacheDriftUsage: 1},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, log := utiltesting.ContextWithLog(t)
+			defer metrics.InitMetricVectors(nil)
+			metrics.InitMetricVectors(nil)
+
+			var cache *Cache
+			checking := false
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(tc.clientObjects...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						if tc.duringList != nil && checking {
+							checking = false
+							tc.duringList(log, cache)
+						}
+						return client.List(ctx, list, opts...)
+					},
+				}).
+				Build()
+			cache = New(cl)
+			cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+			cq := utiltestingapi.MakeClusterQueue("cq").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+				Obj()
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add ClusterQueue: %v", err)
+			}
+			if tc.operation != nil {
+				tc.operation(log, cache)
+			}
+
+			checking = true
+			gotDrifts, err := cache.CheckConsistency(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if gotDrifts != tc.wantDrifts {
+				t.Errorf("Unexpected number of drifts, got %d, want %d", gotDrifts, tc.wantDrifts)
+			}
+
+			cachedCQ := cache.hm.ClusterQueue("cq")
+			if diff := cmp.Diff(tc.wantWorkloads, sets.KeySet(cachedCQ.Workloads)); diff != "" {
+				t.Errorf("Unexpected workloads (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantUsage, cachedCQ.resourceNode.Usage); diff != "" {
+				t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+			}
+			for _, driftType := range []metrics.CacheDriftType{metrics.CacheDriftStaleWorkload, metrics.CacheDriftMissingWorkload, metrics.CacheDriftUsage} {
+				got := testutil.ToFloat64(metrics.CacheDriftsDetectedTotal.WithLabelValues("cq", string(driftType), roletracker.RoleStandalone))
+				if want := tc.wantDriftTypes[driftType]; got != want {
+					t.Errorf("Unexpected %q drifts metric, got %v, want %v", driftType, got, want)
+				}
+			}
+
+			if tc.duringList != nil {
+				// The changes made during the listing are not yet reflected by the client.
+				return
+			}
+			if gotDrifts, err := cache.CheckConsistency(ctx); err != nil || gotDrifts != 0 {
+				t.Errorf("Expected a consistent cache after the repair, got %d drifts, error: %v", gotDrifts, err)
+			}
+		})
+	}
+}
+
+func TestCheckConsistencyRepairsTASUsage(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, true)
+	ctx, log := utiltesting.ContextWithLog(t)
+	defer metrics.InitMetricVectors(nil)
+	metrics.InitMetricVectors(nil)
+
+	wl := utiltestingapi.MakeWorkload("a", "ns").
+		ResourceVersion("1").
+		PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).
+			Request(corev1.ResourceCPU, "1").
+			RequiredTopologyRequest(corev1.LabelHostname).
+			Obj()).
+		ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+			PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+				Assignment(corev1.ResourceCPU, "tas-flavor", "1").
+				TopologyAssignment(utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname}).
+					Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"node1"}, 1).Obj()).
+					Obj()).
+				Obj()).
+			Obj(), time.Now()).
+		Obj()
+	cache := New(utiltesting.NewClientBuilder().WithObjects(wl).Build())
+	topology := utiltestingapi.MakeTopology("default").Levels(corev1.LabelHostname).Obj()
+	cache.AddOrUpdateTopology(log, topology)
+	cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("tas-flavor").
+		NodeLabel("tas-node", "true").
+		TopologyName(topology.Name).
+		Obj())
+	cq := utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(*utiltestingapi.MakeFlavorQuotas("tas-flavor").Resource(corev1.ResourceCPU, "5").Obj()).
+		Obj()
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed to add ClusterQueue: %v", err)
+	}
+	cache.AddOrUpdateWorkload(log, wl)
+
+	// Lose the TAS usage of the workload.
+	tasFlavorCache := cache.TASCache().Get("tas-flavor")
+	tasFlavorCache.removeUsage(log, "ns/a")
+
+	gotDrifts, err := cache.CheckConsistency(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotDrifts != 1 {
+		t.Errorf("Unexpected number of drifts, got %d, want 1", gotDrifts)
+	}
+	wantUsage := map[utiltas.TopologyDomainID]resources.Requests{
+		"node1": {corev1.ResourceCPU: 1000, corev1.ResourcePods: 1},
+	}
+	if diff := cmp.Diff(wantUsage, tasFlavorCache.usage); diff != "" {
+		t.Errorf("Unexpected TAS usage (-want,+got):\n%s", diff)
+	}
+	if got := testutil.ToFloat64(metrics.CacheDriftsDetectedTotal.WithLabelValues("cq", string(metrics.CacheDriftUsage), roletracker.RoleStandalone)); got != 1 {
+		t.Errorf("Unexpected %q drifts metric, got %v, want 1", metrics.CacheDriftUsage, got)
+	}
+	if gotDrifts, err := cache.CheckConsistency(ctx); err != nil || gotDrifts != 0 {
+		t.Errorf("Expected a consistent cache after the repair, got %d drifts, error: %v", gotDrifts, err)
+	}
+}
@@ -172,6 +172,43 @@ func dominantResourceShare(node dominantResourceShareNode, wlReq resources.Flavo
 	return drs
 }
 
+// cohortUsageShare returns the share of the quota of the Cohort tree used by
+// the node: the maximum, among the resources, of the ratios of the usage of
+// the node to the quota of the root of the tree. It ranges from 0 to 1, and
+// is 0 for a node without a parent.
+//
+// Unlike the DRS, which drives the fair sharing decisions, it accounts all the
+// usage of the node rather than the usage above its quota, and ignores the
+// fair sharing weight. It is only reported to the metrics.
+func cohortUsageShare(node dominantResourceShareNode) float64 {
+	if !node.HasParent() {
+		return 0
+	}
+	var root hierarchicalResourceNode = node
+	for root.HasParent() {
+		root = root.parentHRN()
+	}
+
+	usage := make(map[corev1.ResourceName]resources.Amount, len(node.getResourceNode().Usage))
+	for fr, q := range node.getResourceNode().Usage {
+		usage[fr.Resource] = usage[fr.Resource].Add(q)
+	}
+	quota := make(map[corev1.ResourceName]resources.Amount, len(root.getResourceNode().SubtreeQuota))
+	for fr, q := range root.getResourceNode().SubtreeQuota {
+		quota[fr.Resource] = quota[fr.Resource].Add(q)
+	}
+
+	var share float64
+	for rName, u := range usage {
+		q := quota[rName]
+		if q == resources.Unlimited || q.CmpInt64(0) <= 0 {
+			continue
+		}
+		share = max(share, float64(u.Int64())/float64(q.Int64()))
+	}
+	return share
+}
+
 // calculateLendable aggregates capacity for resources across all
 // FlavorResources.
 func calculateLendable(node hierarchicalResourceNode) map[corev1.ResourceName]resources.Amount {
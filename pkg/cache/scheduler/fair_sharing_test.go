@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -982,3 +983,60 @@ func TestIsBorrowingOn(t *testing.T) {
 		})
 	}
 }
+
+func TestCohortUsageShare(t *testing.T) {
+	cases := map[string]struct {
+		usage         map[kueue.ClusterQueueReference]string
+		wantFairShare map[kueue.ClusterQueueReference]float64
+	}{
+		"no usage": {
+			wantFairShare: map[kueue.ClusterQueueReference]float64{"cq-a": 0, "cq-b": 0},
+		},
+		"usage below the nominal quota": {
+			usage:         map[kueue.ClusterQueueReference]string{"cq-a": "2", "cq-b": "1"},
+			wantFairShare: map[kueue.ClusterQueueReference]float64{"cq-a": 0.2, "cq-b": 0.1},
+		},
+		"contended cohort": {
+			usage:         map[kueue.ClusterQueueReference]string{"cq-a": "7", "cq-b": "3"},
+			wantFairShare: map[kueue.ClusterQueueReference]float64{"cq-a": 0.7, "cq-b": 0.3},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now().Truncate(time.Second)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+			for _, cqName := range []string{"cq-a", "cq-b"} {
+				cq := utiltestingapi.MakeClusterQueue(cqName).
+					Cohort("cohort").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+					Obj()
+				if err := cache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Failed to add ClusterQueue: %v", err)
+				}
+			}
+			for cqName, usage := range tc.usage {
+				wl := utiltestingapi.MakeWorkload("wl-"+string(cqName), "default-namespace").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission(cqName).
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", usage).
+							Obj()).
+						Obj(), now).
+					Obj()
+				cache.AddOrUpdateWorkload(log, wl)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("snapshot: %v", err)
+			}
+			got := make(map[kueue.ClusterQueueReference]float64)
+			for cqName, cq := range snapshot.ClusterQueues() {
+				got[cqName] = cq.CohortUsageShare()
+			}
+			if diff := cmp.Diff(tc.wantFairShare, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Unexpected cohort usage shares (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/queue"
@@ -35,6 +36,7 @@ type LocalQueue struct {
 	admittedWorkloads  int
 	totalReserved      resources.FlavorResourceQuantities
 	admittedUsage      resources.FlavorResourceQuantities
+	guaranteedQuota    resources.FlavorResourceQuantities
 	// values extracted from K8s labels/annotations, used as custom Prometheus metric labels
 	customMetricLabelValues []string
 	labels                  map[string]string
@@ -52,6 +54,21 @@ func (q *LocalQueue) GetLabels() map[string]string {
 	return maps.Clone(q.labels)
 }
 
+// guaranteedQuotaFor returns the guaranteed quota from the spec of the
+// LocalQueue, by flavor and resource.
+func guaranteedQuotaFor(q *kueue.LocalQueue) resources.FlavorResourceQuantities {
+	if len(q.Spec.GuaranteedQuota) == 0 {
+		return nil
+	}
+	quota := make(resources.FlavorResourceQuantities)
+	for _, fq := range q.Spec.GuaranteedQuota {
+		for _, rq := range fq.Resources {
+			quota[resources.FlavorResource{Flavor: fq.Name, Resource: rq.Name}] = resources.AmountFromQuantity(rq.Name, rq.Quota)
+		}
+	}
+	return quota
+}
+
 func (q *LocalQueue) resetFlavorsAndResources(cqUsage resources.FlavorResourceQuantities, cqAdmittedUsage resources.FlavorResourceQuantities) {
 	// Clean up removed flavors or resources.
 	q.Lock()
@@ -23,6 +23,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/resources"
+	utilmath "sigs.k8s.io/kueue/pkg/util/math"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 )
 
@@ -84,6 +85,44 @@ func createResourceQuotas(kueueRgs []kueue.ResourceGroup) map[resources.FlavorRe
 	return quotas
 }
 
+// overcommitCapacity returns the capacity the overcommit ratios add to the
+// nominal quota of the resources, in every flavor. The nominal quota itself
+// is not modified, so that the overcommit doesn't change the quota lent to
+// the cohort. The arithmetic saturates, and an Unlimited nominal quota is
+// left without overcommit since it can't grow any further.
+func overcommitCapacity(quotas map[resources.FlavorResource]ResourceQuota, ratios []kueue.ResourceOvercommitRatio) resources.FlavorResourceQuantities {
+	if len(ratios) == 0 {
+		return nil
+	}
+	milliRatios := make(map[corev1.ResourceName]int64, len(ratios))
+	for _, ratio := range ratios {
+		milliRatios[ratio.Name] = utilmath.SafeMilliValue(ratio.Ratio)
+	}
+	overcommit := make(resources.FlavorResourceQuantities)
+	for fr, quota := range quotas {
+		milliRatio, found := milliRatios[fr.Resource]
+		if !found || quota.Nominal.Equal(resources.Unlimited) {
+			continue
+		}
+		overcommit[fr] = quota.Nominal.MulMilli(milliRatio).Sub(quota.Nominal)
+	}
+	return overcommit
+}
+
+// applyNodeCapacity sets the nominal quota of the resources of the flavors
+// with a node capacity to that capacity. The resources which the nodes don't
+// provide get no nominal quota.
+func applyNodeCapacity(quotas map[resources.FlavorResource]ResourceQuota, capacity map[kueue.ResourceFlavorReference]resources.Requests) {
+	for fr, quota := range quotas {
+		flavorCapacity, found := capacity[fr.Flavor]
+		if !found {
+			continue
+		}
+		quota.Nominal = resources.NewAmount(flavorCapacity[fr.Resource])
+		quotas[fr] = quota
+	}
+}
+
 func AllFlavors(rgs []ResourceGroup) sets.Set[kueue.ResourceFlavorReference] {
 	return utilslices.Reduce(
 		rgs,
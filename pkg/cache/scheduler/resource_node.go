@@ -19,6 +19,7 @@ package scheduler
 import (
 	"maps"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/kueue/pkg/cache/hierarchy"
@@ -41,6 +42,14 @@ type resourceNode struct {
 	// usage. For Cohorts, this is the sum of childrens'
 	// usages past childrens' localQuota.
 	Usage resources.FlavorResourceQuantities
+	// ResourceBorrowingLimits cap, per resource, the total quantity
+	// the node can borrow from its parent across all flavors. Only
+	// ClusterQueues set it.
+	ResourceBorrowingLimits map[corev1.ResourceName]resources.Amount
+	// BorrowingBlocked prevents the node from borrowing from its
+	// parent, as if all its BorrowingLimits were zero. Only
+	// ClusterQueues outside of their borrowing windows set it.
+	BorrowingBlocked bool
 }
 
 func NewResourceNode() resourceNode {
@@ -55,9 +64,11 @@ func NewResourceNode() resourceNode {
 // Quota and SubtreeQuota (these are replaced with new maps upon update).
 func (r resourceNode) Clone() resourceNode {
 	return resourceNode{
-		Quotas:       r.Quotas,
-		SubtreeQuota: r.SubtreeQuota,
-		Usage:        maps.Clone(r.Usage),
+		Quotas:                  r.Quotas,
+		SubtreeQuota:            r.SubtreeQuota,
+		Usage:                   maps.Clone(r.Usage),
+		ResourceBorrowingLimits: r.ResourceBorrowingLimits,
+		BorrowingBlocked:        r.BorrowingBlocked,
 	}
 }
 
@@ -71,6 +82,18 @@ func (r resourceNode) localQuota(fr resources.FlavorResource) resources.Amount {
 	return resources.NewAmount(0)
 }
 
+// borrowed returns how much of the usage in the resource exceeds
+// SubtreeQuota, summed across all flavors.
+func (r resourceNode) borrowed(resource corev1.ResourceName) resources.Amount {
+	total := resources.NewAmount(0)
+	for fr, usage := range r.Usage {
+		if fr.Resource == resource {
+			total = total.Add(resources.MaxAmount(resources.NewAmount(0), usage.Sub(r.SubtreeQuota[fr])))
+		}
+	}
+	return total
+}
+
 // hierarchicalResourceNode extends flatResourceNode
 // with the ability to navigate to the parent node.
 type hierarchicalResourceNode interface {
@@ -110,13 +133,28 @@ func available(node hierarchicalResourceNode, fr resources.FlavorResource) resou
 	}
 	parentAvailable := available(node.parentHRN(), fr)
 
-	if borrowingLimit := r.Quotas[fr].BorrowingLimit; borrowingLimit != nil {
+	borrowingLimit := r.Quotas[fr].BorrowingLimit
+	resourceBorrowingLimit, hasResourceBorrowingLimit := r.ResourceBorrowingLimits[fr.Resource]
+	if borrowingLimit != nil || hasResourceBorrowingLimit || r.BorrowingBlocked {
 		// All of these can be Unlimited; Amount methods propagate that.
 		lq := r.localQuota(fr)
 		storedInParent := r.SubtreeQuota[fr].Sub(lq)
 		usedInParent := resources.MaxAmount(resources.NewAmount(0), r.Usage[fr].Sub(lq))
-		withMaxFromParent := storedInParent.Sub(usedInParent).Add(*borrowingLimit)
-		parentAvailable = resources.MinAmount(withMaxFromParent, parentAvailable)
+		if r.BorrowingBlocked {
+			withMaxFromParent := resources.MaxAmount(resources.NewAmount(0), storedInParent.Sub(usedInParent))
+			parentAvailable = resources.MinAmount(withMaxFromParent, parentAvailable)
+		}
+		if borrowingLimit != nil {
+			withMaxFromParent := storedInParent.Sub(usedInParent).Add(*borrowingLimit)
+			parentAvailable = resources.MinAmount(withMaxFromParent, parentAvailable)
+		}
+		if hasResourceBorrowingLimit {
+			// What is left of the resource borrowing limit is shared by
+			// all flavors, on top of the unused quota stored in the parent.
+			remainingBorrowing := resourceBorrowingLimit.Sub(r.borrowed(fr.Resource))
+			withMaxFromParent := resources.MaxAmount(resources.NewAmount(0), storedInParent.Sub(usedInParent)).Add(remainingBorrowing)
+			parentAvailable = resources.MinAmount(withMaxFromParent, parentAvailable)
+		}
 	}
 	return LocalAvailable(node, fr).Add(parentAvailable)
 }
@@ -132,10 +170,17 @@ func potentialAvailable(node hierarchicalResourceNode, fr resources.FlavorResour
 		return r.SubtreeQuota[fr]
 	}
 	avail := r.localQuota(fr).Add(potentialAvailable(node.parentHRN(), fr))
+	if r.BorrowingBlocked {
+		avail = resources.MinAmount(r.SubtreeQuota[fr], avail)
+	}
 	if borrowingLimit := r.Quotas[fr].BorrowingLimit; borrowingLimit != nil {
 		maxWithBorrowing := r.SubtreeQuota[fr].Add(*borrowingLimit)
 		avail = resources.MinAmount(maxWithBorrowing, avail)
 	}
+	if resourceBorrowingLimit, found := r.ResourceBorrowingLimits[fr.Resource]; found {
+		maxWithBorrowing := r.SubtreeQuota[fr].Add(resourceBorrowingLimit)
+		avail = resources.MinAmount(maxWithBorrowing, avail)
+	}
 	return avail
 }
 
@@ -242,6 +287,33 @@ func QuantitiesFitInQuota(node flatResourceNode, requests resources.FlavorResour
 	return fits, remainingRequests
 }
 
+// fitsResourceBorrowingLimits returns whether adding the requests to the node
+// keeps the quantity it borrows, summed across all flavors, within its
+// ResourceBorrowingLimits. The per-flavor checks done by available cannot
+// detect when requests in different flavors of the same resource jointly
+// exceed the limit.
+func fitsResourceBorrowingLimits(node flatResourceNode, requests resources.FlavorResourceQuantities) bool {
+	r := node.getResourceNode()
+	if len(r.ResourceBorrowingLimits) == 0 {
+		return true
+	}
+	extraBorrowing := make(map[corev1.ResourceName]resources.Amount)
+	for fr, v := range requests {
+		if _, found := r.ResourceBorrowingLimits[fr.Resource]; !found {
+			continue
+		}
+		before := resources.MaxAmount(resources.NewAmount(0), r.Usage[fr].Sub(r.SubtreeQuota[fr]))
+		after := resources.MaxAmount(resources.NewAmount(0), r.Usage[fr].Add(v).Sub(r.SubtreeQuota[fr]))
+		extraBorrowing[fr.Resource] = extraBorrowing[fr.Resource].Add(after.Sub(before))
+	}
+	for resource, extra := range extraBorrowing {
+		if extra.CmpInt64(0) > 0 && r.ResourceBorrowingLimits[resource].Cmp(r.borrowed(resource).Add(extra)) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // IsWithinNominalInResources returns whether or not, the node quota usage exceeds its
 // nominal quota in any resource flavor out of a set of resource flavours.
 func IsWithinNominalInResources(node flatResourceNode, frs sets.Set[resources.FlavorResource]) bool {
@@ -18,13 +18,17 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	testingclock "k8s.io/utils/clock/testing"
 
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestCohortLendable(t *testing.T) {
@@ -65,3 +69,144 @@ func TestCohortLendable(t *testing.T) {
 		t.Errorf("Unexpected cohort lendable (-want,+got):\n%s", diff)
 	}
 }
+
+func TestResourceBorrowingLimits(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ResourceBorrowingLimits, true)
+	ctx, log := utiltesting.ContextWithLog(t)
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("x86").Obj())
+	cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("arm").Obj())
+
+	borrower := utiltestingapi.MakeClusterQueue("borrower").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1", "3").Obj(),
+			*utiltestingapi.MakeFlavorQuotas("arm").Resource("cpu", "1", "3").Obj(),
+		).
+		ResourceBorrowingLimit("cpu", "4").
+		Cohort("test-cohort").
+		Obj()
+	lender := utiltestingapi.MakeClusterQueue("lender").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "10").Obj(),
+			*utiltestingapi.MakeFlavorQuotas("arm").Resource("cpu", "10").Obj(),
+		).
+		Cohort("test-cohort").
+		Obj()
+
+	if err := cache.AddClusterQueue(ctx, borrower); err != nil {
+		t.Fatal("Failed to add CQ to cache", err)
+	}
+	if err := cache.AddClusterQueue(ctx, lender); err != nil {
+		t.Fatal("Failed to add CQ to cache", err)
+	}
+
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	cq := snapshot.ClusterQueue("borrower")
+
+	x86CPU := resources.FlavorResource{Flavor: "x86", Resource: corev1.ResourceCPU}
+	armCPU := resources.FlavorResource{Flavor: "arm", Resource: corev1.ResourceCPU}
+
+	// Each flavor can still borrow up to its own borrowingLimit.
+	if diff := cmp.Diff(resources.NewAmount(4_000), cq.Available(x86CPU)); diff != "" {
+		t.Errorf("Unexpected available x86 cpu (-want,+got):\n%s", diff)
+	}
+	// Borrowing 3 cpu in both flavors exceeds the limit of 4 cpu.
+	usage := workload.Usage{Quota: resources.FlavorResourceQuantities{
+		x86CPU: resources.NewAmount(4_000),
+		armCPU: resources.NewAmount(4_000),
+	}}
+	if got := cq.Fits(usage); got != FitsCheckNoQuota {
+		t.Errorf("Unexpected fits check, want: %v, got: %v", FitsCheckNoQuota, got)
+	}
+
+	cq.AddUsage(workload.Usage{Quota: resources.FlavorResourceQuantities{
+		x86CPU: resources.NewAmount(4_000),
+	}})
+	// 3 cpu of the limit are already borrowed for x86, so arm can only borrow 1 cpu.
+	if diff := cmp.Diff(resources.NewAmount(2_000), cq.Available(armCPU)); diff != "" {
+		t.Errorf("Unexpected available arm cpu (-want,+got):\n%s", diff)
+	}
+	if got := cq.Fits(workload.Usage{Quota: resources.FlavorResourceQuantities{armCPU: resources.NewAmount(2_000)}}); got != FitsCheckOk {
+		t.Errorf("Unexpected fits check, want: %v, got: %v", FitsCheckOk, got)
+	}
+}
+
+func TestBorrowingWindows(t *testing.T) {
+	// Monday
+	day := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	cases := map[string]struct {
+		enableFeature          bool
+		now                    time.Time
+		usage                  int64
+		wantAvailable          resources.Amount
+		wantPotentialAvailable resources.Amount
+	}{
+		"within the window": {
+			enableFeature:          true,
+			now:                    day.Add(20 * time.Hour),
+			wantAvailable:          resources.NewAmount(12_000),
+			wantPotentialAvailable: resources.NewAmount(12_000),
+		},
+		"outside of the window": {
+			enableFeature:          true,
+			now:                    day.Add(12 * time.Hour),
+			wantAvailable:          resources.NewAmount(2_000),
+			wantPotentialAvailable: resources.NewAmount(2_000),
+		},
+		"outside of the window, borrowing before the window closed": {
+			enableFeature:          true,
+			now:                    day.Add(12 * time.Hour),
+			usage:                  4_000,
+			wantAvailable:          resources.NewAmount(0),
+			wantPotentialAvailable: resources.NewAmount(2_000),
+		},
+		"outside of the window, feature disabled": {
+			now:                    day.Add(12 * time.Hour),
+			wantAvailable:          resources.NewAmount(12_000),
+			wantPotentialAvailable: resources.NewAmount(12_000),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.BorrowingWindows, tc.enableFeature)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := New(utiltesting.NewFakeClient(), WithClock(testingclock.NewFakeClock(tc.now)))
+			cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+
+			borrower := utiltestingapi.MakeClusterQueue("borrower").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource("cpu", "2").Obj()).
+				BorrowingWindow("0 18 * * 1-5", 14*3600).
+				Cohort("test-cohort").
+				Obj()
+			lender := utiltestingapi.MakeClusterQueue("lender").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource("cpu", "10").Obj()).
+				Cohort("test-cohort").
+				Obj()
+			if err := cache.AddClusterQueue(ctx, borrower); err != nil {
+				t.Fatal("Failed to add CQ to cache", err)
+			}
+			if err := cache.AddClusterQueue(ctx, lender); err != nil {
+				t.Fatal("Failed to add CQ to cache", err)
+			}
+
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			cq := snapshot.ClusterQueue("borrower")
+			cq.AddUsage(workload.Usage{Quota: resources.FlavorResourceQuantities{cpu: resources.NewAmount(tc.usage)}})
+
+			if diff := cmp.Diff(tc.wantAvailable, cq.Available(cpu)); diff != "" {
+				t.Errorf("Unexpected available cpu (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPotentialAvailable, cq.PotentialAvailable(cpu)); diff != "" {
+				t.Errorf("Unexpected potential available cpu (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package scheduler
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -452,3 +455,41 @@ func TestAvailable(t *testing.T) {
 		})
 	}
 }
+
+func TestOvercommitCapacity(t *testing.T) {
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	memory := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceMemory}
+	ratios := []kueue.ResourceOvercommitRatio{{Name: corev1.ResourceCPU, Ratio: resource.MustParse("1.5")}}
+	cases := map[string]struct {
+		quotas map[resources.FlavorResource]ResourceQuota
+		want   resources.FlavorResourceQuantities
+	}{
+		"scales the nominal quota of the overcommitted resources": {
+			quotas: map[resources.FlavorResource]ResourceQuota{
+				cpu:    {Nominal: resources.NewAmount(4_000)},
+				memory: {Nominal: resources.NewAmount(1_000)},
+			},
+			want: resources.FlavorResourceQuantities{cpu: resources.NewAmount(2_000)},
+		},
+		"leaves an Unlimited nominal quota without overcommit": {
+			quotas: map[resources.FlavorResource]ResourceQuota{
+				cpu: {Nominal: resources.Unlimited},
+			},
+			want: resources.FlavorResourceQuantities{},
+		},
+		"saturates a nominal quota close to MaxInt64": {
+			quotas: map[resources.FlavorResource]ResourceQuota{
+				cpu: {Nominal: resources.NewAmount(math.MaxInt64 - 1)},
+			},
+			want: resources.FlavorResourceQuantities{cpu: resources.Unlimited},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := overcommitCapacity(tc.quotas, ratios)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected overcommit capacity (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/resources"
 	afs "sigs.k8s.io/kueue/pkg/util/admissionfairsharing"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
+	"sigs.k8s.io/kueue/pkg/util/queue"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -60,6 +61,7 @@ func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 	cq := s.ClusterQueue(wl.ClusterQueue)
 	delete(cq.Workloads, workload.Key(wl.Obj))
 	cq.RemoveUsage(wl.Usage())
+	cq.RemoveLocalQueueUsage(queue.KeyFromWorkload(wl.Obj), wl.Usage().Quota)
 }
 
 // AddWorkload adds a workload to its corresponding ClusterQueue and
@@ -68,6 +70,7 @@ func (s *Snapshot) AddWorkload(wl *workload.Info) {
 	cq := s.ClusterQueue(wl.ClusterQueue)
 	cq.Workloads[workload.Key(wl.Obj)] = wl
 	cq.AddUsage(wl.Usage())
+	cq.AddLocalQueueUsage(queue.KeyFromWorkload(wl.Obj), wl.Usage().Quota)
 }
 
 // SimulateWorkloadRemoval modifies the snapshot by removing the usage
@@ -196,6 +199,7 @@ func (c *Cache) Snapshot(ctx context.Context, options ...SnapshotOption) (*Snaps
 	if features.Enabled(features.TopologyAwareScheduling) {
 		var aggregatedDomainUsages map[utiltas.TopologyDomainID]resources.Requests
 		flvTASCache := c.tasCache.Clone()
+		now := c.clock.Now()
 
 		if features.Enabled(features.TASHandleOverlappingFlavors) {
 			aggregatedDomainUsages = make(map[utiltas.TopologyDomainID]resources.Requests)
@@ -213,7 +217,7 @@ func (c *Cache) Snapshot(ctx context.Context, options ...SnapshotOption) (*Snaps
 			}
 			tasSnapshots[flavor] = cache.snapshot(
 				log,
-				c.tasCache.nodesCache.find(cache.flavor.NodeLabels, cache.topology.Levels),
+				c.tasCache.nodesCache.find(cache.flavor.NodeLabels, cache.topology.Levels, cache.topology.DerivedLevels, cache.MinNodeReadySeconds(), now),
 				aggregatedDomainUsagesForFlavor,
 			)
 		}
@@ -238,11 +242,46 @@ func (c *Cache) Snapshot(ctx context.Context, options ...SnapshotOption) (*Snaps
 			}
 		}
 	}
+	if features.Enabled(features.TASColocateWithWorkload) {
+		for _, s := range tasSnapshots {
+			s.admittedWorkload = snap.findWorkload
+		}
+	}
+	if features.Enabled(features.TASRespectTopologySpreadConstraints) {
+		for _, s := range tasSnapshots {
+			s.admittedWorkloadsInNamespace = snap.workloadsInNamespace
+		}
+	}
 	// Shallow copy is enough
 	maps.Copy(snap.ResourceFlavors, c.resourceFlavors)
 	return &snap, nil
 }
 
+// findWorkload returns the workload with the given key admitted in any of the
+// active ClusterQueues of the snapshot, or nil if not found.
+func (s *Snapshot) findWorkload(key workload.Reference) *workload.Info {
+	for _, cq := range s.ClusterQueues() {
+		if wl, found := cq.Workloads[key]; found {
+			return wl
+		}
+	}
+	return nil
+}
+
+// workloadsInNamespace returns the workloads of the namespace admitted in the
+// active ClusterQueues of the snapshot.
+func (s *Snapshot) workloadsInNamespace(namespace string) []*workload.Info {
+	var workloads []*workload.Info
+	for _, cq := range s.ClusterQueues() {
+		for _, wl := range cq.Workloads {
+			if wl.Obj.Namespace == namespace {
+				workloads = append(workloads, wl)
+			}
+		}
+	}
+	return workloads
+}
+
 func (c *Cache) snapshotTopologyDomainUsages(
 	tasFlvCache *TASFlavorCache, aggregatedDomainUsages map[utiltas.TopologyDomainID]resources.Requests,
 ) {
@@ -304,10 +343,28 @@ func (c *Cache) snapshotClusterQueue(
 		tasOnly:                       cq.isTASOnly(),
 		flavorsForProvReqACs:          cq.flavorsWithProvReqAdmissionCheck(),
 		hasMultiKueueAC:               cq.hasMultiKueueAdmissionCheck(),
+		overcommit:                    cq.overcommit,
+		maxAdmittedWorkloads:          cq.maxAdmittedWorkloads,
+		flavorTieBreak:                cq.flavorTieBreak,
 	}
 	for i, rg := range cq.ResourceGroups {
 		cc.ResourceGroups[i] = rg.Clone()
 	}
+	cc.ResourceNode.BorrowingBlocked = !cq.borrowingWindows.Open(c.clock.Now())
+	if features.Enabled(features.LocalQueueGuaranteedQuota) {
+		for key, lq := range cq.localQueues {
+			lq.RLock()
+			if len(lq.guaranteedQuota) > 0 {
+				if cc.localQueueGuarantees == nil {
+					cc.localQueueGuarantees = make(map[queue.LocalQueueReference]resources.FlavorResourceQuantities)
+					cc.localQueueUsage = make(map[queue.LocalQueueReference]resources.FlavorResourceQuantities)
+				}
+				cc.localQueueGuarantees[key] = lq.guaranteedQuota.Clone()
+				cc.localQueueUsage[key] = lq.totalReserved.Clone()
+			}
+			lq.RUnlock()
+		}
+	}
 	if afs.Enabled(c.admissionFairSharing) {
 		if cq.AdmissionScope != nil {
 			cc.AdmissionScope = *cq.AdmissionScope.DeepCopy()
@@ -30,7 +30,9 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/cache/hierarchy"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -1709,3 +1711,93 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotLocalQueueGuaranteedQuota(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.LocalQueueGuaranteedQuota, true)
+	now := time.Now().Truncate(time.Second)
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	queues := []kueue.LocalQueue{
+		*utiltestingapi.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq").
+			GuaranteedQuota("default", corev1.ResourceCPU, "4").Obj(),
+		*utiltestingapi.MakeLocalQueue("lq-b", "ns").ClusterQueue("cq").
+			GuaranteedQuota("default", corev1.ResourceCPU, "2").Obj(),
+		*utiltestingapi.MakeLocalQueue("lq-c", "ns").ClusterQueue("cq").Obj(),
+	}
+	workloads := []kueue.Workload{
+		*utiltestingapi.MakeWorkload("a-1", "ns").
+			Queue("lq-a").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+				PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+					Assignment(corev1.ResourceCPU, "default", "1").
+					Obj()).
+				Obj(), now).
+			Obj(),
+		*utiltestingapi.MakeWorkload("c-1", "ns").
+			Queue("lq-c").
+			Request(corev1.ResourceCPU, "3").
+			ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+				PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+					Assignment(corev1.ResourceCPU, "default", "3").
+					Obj()).
+				Obj(), now).
+			Obj(),
+	}
+
+	ctx, log := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: workloads}, &kueue.LocalQueueList{Items: queues}).
+		Build()
+	cqCache := New(cl)
+	cqCache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+	cq := utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	cqSnapshot := snapshot.ClusterQueue("cq")
+
+	guaranteedToOthers := func() map[string]int64 {
+		got := make(map[string]int64, len(queues))
+		for _, lq := range queues {
+			got[lq.Name] = cqSnapshot.GuaranteedToOtherLocalQueues(queue.Key(&lq), cpu).Int64()
+		}
+		return got
+	}
+	// lq-a uses 1 out of its 4 guaranteed cpus, and lq-b none of its 2.
+	wantInitial := map[string]int64{"lq-a": 2_000, "lq-b": 3_000, "lq-c": 5_000}
+	if diff := cmp.Diff(wantInitial, guaranteedToOthers()); diff != "" {
+		t.Errorf("Unexpected quota guaranteed to other LocalQueues (-want,+got):\n%s", diff)
+	}
+	// 6 cpus are available in the ClusterQueue.
+	for lq, want := range map[string]bool{"lq-a": true, "lq-b": false, "lq-c": false} {
+		got := cqSnapshot.FitsGuaranteedQuota(queue.NewLocalQueueReference("ns", kueue.LocalQueueName(lq)), resources.FlavorResourceQuantities{cpu: resources.NewAmount(4_000)})
+		if got != want {
+			t.Errorf("FitsGuaranteedQuota(%s) = %t, want %t", lq, got, want)
+		}
+	}
+
+	wl := workload.NewInfo(utiltestingapi.MakeWorkload("b-1", "ns").
+		Queue("lq-b").
+		Request(corev1.ResourceCPU, "3").
+		ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+			PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+				Assignment(corev1.ResourceCPU, "default", "3").
+				Obj()).
+			Obj(), now).
+		Obj())
+	snapshot.AddWorkload(wl)
+	// lq-b uses more than its guaranteed quota.
+	if diff := cmp.Diff(map[string]int64{"lq-a": 0, "lq-b": 3_000, "lq-c": 3_000}, guaranteedToOthers()); diff != "" {
+		t.Errorf("Unexpected quota guaranteed to other LocalQueues after adding a workload (-want,+got):\n%s", diff)
+	}
+	snapshot.RemoveWorkload(wl)
+	if diff := cmp.Diff(wantInitial, guaranteedToOthers()); diff != "" {
+		t.Errorf("Unexpected quota guaranteed to other LocalQueues after removing the workload (-want,+got):\n%s", diff)
+	}
+}
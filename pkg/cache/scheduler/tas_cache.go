@@ -20,6 +20,7 @@ import (
 	"maps"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 )
@@ -80,6 +82,9 @@ func (t *tasCache) AddFlavor(flavor *kueue.ResourceFlavor) {
 			NodeLabels:   maps.Clone(flavor.Spec.NodeLabels),
 			Tolerations:  slices.Clone(flavor.Spec.Tolerations),
 		}
+		if flavor.Spec.MinNodeReadySeconds != nil {
+			flavorInfo.MinNodeReadySeconds = new(*flavor.Spec.MinNodeReadySeconds)
+		}
 		t.flavors[name] = flavorInfo
 		if tInfo, ok := t.topologies[flavorInfo.TopologyName]; ok {
 			t.flavorCache[name] = t.NewTASFlavorCache(tInfo, flavorInfo)
@@ -95,6 +100,12 @@ func (t *tasCache) AddTopology(topology *kueue.Topology) {
 		tInfo := topologyInformation{
 			Levels: utiltas.Levels(topology),
 		}
+		if features.Enabled(features.TASDerivedTopologyLevels) {
+			// Invalid regexes are rejected by the webhook, so the error is
+			// only possible when the webhook is bypassed. In that case the
+			// derived levels are not set, and the nodes don't match the topology.
+			tInfo.DerivedLevels, _ = utiltas.DerivedLevels(topology)
+		}
 		t.topologies[name] = tInfo
 		for fName, flavorInfo := range t.flavors {
 			if flavorInfo.TopologyName == name {
@@ -139,3 +150,44 @@ func (t *tasCache) SyncNode(node *corev1.Node) {
 func (t *tasCache) DeleteNodeByName(nodeName string) {
 	t.nodesCache.delete(nodeName)
 }
+
+// NextNodeReadyAfter returns the time remaining until the next node of the
+// flavor, which is Ready but not yet for the minNodeReadySeconds of the flavor,
+// starts contributing capacity. It returns 0 if there is no such node.
+func (t *tasCache) NextNodeReadyAfter(name kueue.ResourceFlavorReference, now time.Time) time.Duration {
+	flavorCache := t.Get(name)
+	if flavorCache == nil {
+		return 0
+	}
+	minReadySeconds := flavorCache.MinNodeReadySeconds()
+	if minReadySeconds == nil {
+		return 0
+	}
+	return t.nodesCache.nextReady(flavorCache.NodeLabels(), flavorCache.TopologyLevels(), flavorCache.DerivedLevels(), *minReadySeconds, now)
+}
+
+// NodesMissingLevels returns the topology levels missing in the labels of the
+// schedulable and Ready nodes which match the node labels of the flavor, keyed
+// by the node name.
+func (t *tasCache) NodesMissingLevels(name kueue.ResourceFlavorReference) map[string][]string {
+	flavorCache := t.Get(name)
+	if flavorCache == nil {
+		return nil
+	}
+	return t.nodesCache.missingLevels(flavorCache.NodeLabels(), flavorCache.TopologyLevels(), flavorCache.DerivedLevels())
+}
+
+// NodeCapacity returns the total allocatable capacity of the schedulable and
+// Ready nodes of the flavor. When the flavor sets minNodeReadySeconds, only
+// the nodes which have been Ready for that long are accounted.
+func (t *tasCache) NodeCapacity(name kueue.ResourceFlavorReference, now time.Time) resources.Requests {
+	capacity := resources.Requests{}
+	flavorCache := t.Get(name)
+	if flavorCache == nil {
+		return capacity
+	}
+	for _, node := range t.nodesCache.find(flavorCache.NodeLabels(), flavorCache.TopologyLevels(), flavorCache.DerivedLevels(), flavorCache.MinNodeReadySeconds(), now) {
+		capacity.Add(resources.NewRequests(node.Status.Allocatable))
+	}
+	return capacity
+}
@@ -20,11 +20,13 @@ import (
 	"fmt"
 	"maps"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
@@ -52,6 +54,8 @@ type PodSetTestCase struct {
 	tolerations        []corev1.Toleration
 	nodeSelector       map[string]string
 	nodeAffinity       *corev1.NodeAffinity
+	labels             map[string]string
+	spreadConstraints  []corev1.TopologySpreadConstraint
 	podSetGroupName    *string
 	previousAssignment *kueue.TopologyAssignment
 	wantAssignment     *tas.TopologyAssignment
@@ -65,6 +69,7 @@ func TestFindTopologyAssignments(t *testing.T) {
 		tasBlockLabel      = "cloud.com/topology-block"
 		tasRackLabel       = "cloud.com/topology-rack"
 		tasSubBlockLabel   = "cloud.com/topology-subblock"
+		tasNVLinkLabel     = "cloud.com/topology-nvlink"
 	)
 
 	//      b1                   b2
@@ -386,16 +391,125 @@ func TestFindTopologyAssignments(t *testing.T) {
 			Obj(),
 	}
 
+	//                    b1
+	//          /                   \
+	//         r1                    r2
+	//     /        \            /        \
+	//    n1        n2          n3        n4
+	//   /  \      /  \        /  \      /  \
+	// x1:2 x2:1 x3:2 x4:2    x5:1 x6:1 x7:2 x8:1
+	//
+	// The levels are block, rack, NVLink domain and host, where an NVLink
+	// domain groups the hosts sharing the high-bandwidth GPU interconnect.
+	nvlinkNodes := []corev1.Node{
+		*testingnode.MakeNode("b1-r1-n1-x1").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r1").
+			Label(tasNVLinkLabel, "n1").
+			Label(corev1.LabelHostname, "x1").
+			StatusAllocatable(corev1.ResourceList{
+				"example.com/gpu":   resource.MustParse("2"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+		*testingnode.MakeNode("b1-r1-n1-x2").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r1").
+			Label(tasNVLinkLabel, "n1").
+			Label(corev1.LabelHostname, "x2").
+			StatusAllocatable(corev1.ResourceList{
+				"example.com/gpu":   resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+		*testingnode.MakeNode("b1-r1-n2-x3").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r1").
+			Label(tasNVLinkLabel, "n2").
+			Label(corev1.LabelHostname, "x3").
+			StatusAllocatable(corev1.ResourceList{
+				"example.com/gpu":   resource.MustParse("2"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+		*testingnode.MakeNode("b1-r1-n2-x4").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r1").
+			Label(tasNVLinkLabel, "n2").
+			Label(corev1.LabelHostname, "x4").
+			StatusAllocatable(corev1.ResourceList{
+				"example.com/gpu":   resource.MustParse("2"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+		*testingnode.MakeNode("b1-r2-n3-x5").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r2").
+			Label(tasNVLinkLabel, "n3").
+			Label(corev1.LabelHostname, "x5").
+			StatusAllocatable(corev1.ResourceList{
+				"example.com/gpu":   resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+		*testingnode.MakeNode("b1-r2-n3-x6").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r2").
+			Label(tasNVLinkLabel, "n3").
+			Label(corev1.LabelHostname, "x6").
+			StatusAllocatable(corev1.ResourceList{
+				"example.com/gpu":   resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+		*testingnode.MakeNode("b1-r2-n4-x7").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r2").
+			Label(tasNVLinkLabel, "n4").
+			Label(corev1.LabelHostname, "x7").
+			StatusAllocatable(corev1.ResourceList{
+				"example.com/gpu":   resource.MustParse("2"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+		*testingnode.MakeNode("b1-r2-n4-x8").
+			Label(tasBlockLabel, "b1").
+			Label(tasRackLabel, "r2").
+			Label(tasNVLinkLabel, "n4").
+			Label(corev1.LabelHostname, "x8").
+			StatusAllocatable(corev1.ResourceList{
+				"example.com/gpu":   resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("10"),
+			}).
+			Ready().
+			Obj(),
+	}
+	nvlinkLevels := []string{
+		tasBlockLabel,
+		tasRackLabel,
+		tasNVLinkLabel,
+		corev1.LabelHostname,
+	}
+
 	cases := map[string]struct {
 		featureGates           map[featuregate.Feature]bool
 		nodes                  []corev1.Node
 		pods                   []corev1.Pod
 		levels                 []string
 		nodeLabels             map[string]string
+		minNodeReadySeconds    *int32
 		aggregatedDomainUsages map[tas.TopologyDomainID]resources.Requests
 		priorFlavorUsage       []workload.TopologyDomainRequests
 		priorOwnUsage          []workload.TopologyDomainRequests
 		workload               *kueue.Workload
+		admittedWorkloads      []kueue.Workload
 		podSets                []PodSetTestCase
 	}{
 		"node replacement skipped for single-Pod-owned workload; gate on": {
@@ -590,6 +704,82 @@ func TestFindTopologyAssignments(t *testing.T) {
 				},
 			}},
 		},
+		"4-GPU job packed into a single NVLink domain": {
+			nodes:  nvlinkNodes,
+			levels: nvlinkLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasNVLinkLabel),
+				},
+				requests: resources.Requests{
+					"example.com/gpu": 1,
+				},
+				count: 4,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{Count: 2, Values: []string{"x3"}},
+						{Count: 2, Values: []string{"x4"}},
+					},
+				},
+			}},
+		},
+		"3-GPU job packed into the NVLink domain of the best fit": {
+			nodes:  nvlinkNodes,
+			levels: nvlinkLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasNVLinkLabel),
+				},
+				requests: resources.Requests{
+					"example.com/gpu": 1,
+				},
+				count: 3,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{Count: 2, Values: []string{"x1"}},
+						{Count: 1, Values: []string{"x2"}},
+					},
+				},
+			}},
+		},
+		"5-GPU job doesn't fit into a single NVLink domain": {
+			nodes:  nvlinkNodes,
+			levels: nvlinkLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasNVLinkLabel),
+				},
+				requests: resources.Requests{
+					"example.com/gpu": 1,
+				},
+				count:      5,
+				wantReason: `topology "default" allows to fit only 4 out of 5 pod(s)`,
+			}},
+		},
+		"5-GPU job preferring an NVLink domain packed into a single rack": {
+			nodes:  nvlinkNodes,
+			levels: nvlinkLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Preferred: ptr.To(tasNVLinkLabel),
+				},
+				requests: resources.Requests{
+					"example.com/gpu": 1,
+				},
+				count: 5,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{Count: 1, Values: []string{"x5"}},
+						{Count: 1, Values: []string{"x6"}},
+						{Count: 2, Values: []string{"x7"}},
+						{Count: 1, Values: []string{"x8"}},
+					},
+				},
+			}},
+		},
 		"minimize the number of used racks before optimizing the number of nodes; BestFit": {
 			// Solution by optimizing the number of racks then nodes: [r3]: [x1,x6,x2,x4]
 			// Solution by optimizing the number of nodes: [r1,r2]: [x3,x5]
@@ -995,24 +1185,29 @@ func TestFindTopologyAssignments(t *testing.T) {
 				},
 			}},
 		},
-		"rack required; multiple Pods fit in a rack; BestFit": {
-			nodes:  defaultNodes,
-			levels: defaultTwoLevels,
+		"rack required; single Pod is placed back in its previous rack; TASPreferPreviousAssignment": {
+			featureGates: map[featuregate.Feature]bool{features.TASPreferPreviousAssignment: true},
+			nodes:        defaultNodes,
+			levels:       defaultTwoLevels,
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Annotation(kueue.PreviousTopologyAssignmentAnnotation, `{"main":["b2,r2"]}`).
+				Obj(),
 			podSets: []PodSetTestCase{{
+				podSetName: "main",
 				topologyRequest: &kueue.PodSetTopologyRequest{
 					Required: ptr.To(tasRackLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count: 3,
+				count: 1,
 				wantAssignment: &tas.TopologyAssignment{
 					Levels: defaultTwoLevels,
 					Domains: []tas.TopologyDomainAssignment{
 						{
-							Count: 3,
+							Count: 1,
 							Values: []string{
-								"b1",
+								"b2",
 								"r2",
 							},
 						},
@@ -1020,79 +1215,58 @@ func TestFindTopologyAssignments(t *testing.T) {
 				},
 			}},
 		},
-		"block preferred; Pods fit in 2 blocks; BestFit": {
-			nodes: []corev1.Node{
-				*testingnode.MakeNode("b1").
-					Label(tasBlockLabel, "b1").
-					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("2"),
-						corev1.ResourcePods: resource.MustParse("20"),
-					}).
-					Ready().
-					Obj(),
-				*testingnode.MakeNode("b2").
-					Label(tasBlockLabel, "b2").
-					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("1"),
-						corev1.ResourcePods: resource.MustParse("10"),
-					}).
-					Ready().
-					Obj(),
-				*testingnode.MakeNode("b3").
-					Label(tasBlockLabel, "b3").
-					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("4"),
-						corev1.ResourcePods: resource.MustParse("40"),
-					}).
-					Ready().
-					Obj(),
-			},
-			levels: []string{tasBlockLabel},
+		"rack required; previous rack is ignored when TASPreferPreviousAssignment is disabled": {
+			nodes:  defaultNodes,
+			levels: defaultTwoLevels,
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Annotation(kueue.PreviousTopologyAssignmentAnnotation, `{"main":["b2,r2"]}`).
+				Obj(),
 			podSets: []PodSetTestCase{{
+				podSetName: "main",
 				topologyRequest: &kueue.PodSetTopologyRequest{
-					Preferred: ptr.To(tasBlockLabel),
+					Required: ptr.To(tasRackLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count: 5,
+				count: 1,
 				wantAssignment: &tas.TopologyAssignment{
-					Levels: []string{tasBlockLabel},
+					Levels: defaultTwoLevels,
 					Domains: []tas.TopologyDomainAssignment{
 						{
 							Count: 1,
 							Values: []string{
-								"b2",
-							},
-						},
-						{
-							Count: 4,
-							Values: []string{
-								"b3",
+								"b1",
+								"r1",
 							},
 						},
 					},
 				},
 			}},
 		},
-		"rack required; multiple Pods fit in some racks; BestFit": {
-			nodes:  defaultNodes,
-			levels: defaultTwoLevels,
+		"rack required; previous rack cannot accommodate the Pods; TASPreferPreviousAssignment": {
+			featureGates: map[featuregate.Feature]bool{features.TASPreferPreviousAssignment: true},
+			nodes:        defaultNodes,
+			levels:       defaultTwoLevels,
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Annotation(kueue.PreviousTopologyAssignmentAnnotation, `{"main":["b2,r2"]}`).
+				Obj(),
 			podSets: []PodSetTestCase{{
+				podSetName: "main",
 				topologyRequest: &kueue.PodSetTopologyRequest{
 					Required: ptr.To(tasRackLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count: 2,
+				count: 3,
 				wantAssignment: &tas.TopologyAssignment{
 					Levels: defaultTwoLevels,
 					Domains: []tas.TopologyDomainAssignment{
 						{
-							Count: 2,
+							Count: 3,
 							Values: []string{
-								"b2",
+								"b1",
 								"r2",
 							},
 						},
@@ -1100,96 +1274,370 @@ func TestFindTopologyAssignments(t *testing.T) {
 				},
 			}},
 		},
-		"rack required; too many pods to fit in any rack; BestFit": {
-			nodes:  defaultNodes,
-			levels: defaultTwoLevels,
+		"rack required; single Pod is placed back on its previous host; TASPreferPreviousAssignment": {
+			featureGates: map[featuregate.Feature]bool{features.TASPreferPreviousAssignment: true},
+			nodes:        defaultNodes,
+			levels:       defaultThreeLevels,
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Annotation(kueue.PreviousTopologyAssignmentAnnotation, `{"main":["x4"]}`).
+				Obj(),
 			podSets: []PodSetTestCase{{
+				podSetName: "main",
 				topologyRequest: &kueue.PodSetTopologyRequest{
 					Required: ptr.To(tasRackLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count:      4,
-				wantReason: `topology "default" allows to fit only 3 out of 4 pod(s)`,
-			}},
-		},
-		"block required; single Pod fits in a block and a single rack; BestFit": {
-			nodes:  defaultNodes,
-			levels: defaultTwoLevels,
-			podSets: []PodSetTestCase{{
-				topologyRequest: &kueue.PodSetTopologyRequest{
-					Required: ptr.To(tasBlockLabel),
-				},
-				requests: resources.Requests{
-					corev1.ResourceCPU: 1000,
-				},
 				count: 1,
 				wantAssignment: &tas.TopologyAssignment{
-					Levels: []string{
-						tasBlockLabel,
-						tasRackLabel,
-					},
+					Levels: defaultOneLevel,
 					Domains: []tas.TopologyDomainAssignment{
 						{
 							Count: 1,
 							Values: []string{
-								"b2",
-								"r1",
+								"x4",
 							},
 						},
 					},
 				},
 			}},
 		},
-		"block required; single Pod fits in a block spread across two racks; BestFit": {
-			nodes:  defaultNodes,
-			levels: defaultTwoLevels,
+		"block required; single Pod is placed in the block of the co-located workload; TASColocateWithWorkload": {
+			featureGates: map[featuregate.Feature]bool{features.TASColocateWithWorkload: true},
+			nodes:        defaultNodes,
+			levels:       defaultThreeLevels,
+			workload: utiltestingapi.MakeWorkload("trainer", "ns").
+				Annotation(kueue.ColocateWithWorkloadAnnotation, "data-loader").
+				Obj(),
+			admittedWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("data-loader", "ns").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("cq").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								TopologyAssignment(utiltestingapi.MakeTopologyAssignment(defaultOneLevel).
+									Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"x5"}, 1).Obj()).
+									Obj()).
+								Obj()).
+							Obj(), time.Now()).
+					Obj(),
+			},
 			podSets: []PodSetTestCase{{
+				podSetName: "main",
 				topologyRequest: &kueue.PodSetTopologyRequest{
 					Required: ptr.To(tasBlockLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count: 4,
+				count: 1,
 				wantAssignment: &tas.TopologyAssignment{
-					Levels: []string{
-						tasBlockLabel,
-						tasRackLabel,
-					},
+					Levels: defaultOneLevel,
 					Domains: []tas.TopologyDomainAssignment{
 						{
 							Count: 1,
 							Values: []string{
-								"b1",
-								"r1",
-							},
-						},
-						{
-							Count: 3,
-							Values: []string{
-								"b1",
-								"r2",
+								"x3",
 							},
 						},
 					},
 				},
 			}},
 		},
-		"block required; Pods fit in a block spread across two racks; BestFit": {
+		"block required; co-located workload is ignored when TASColocateWithWorkload is disabled": {
 			nodes:  defaultNodes,
-			levels: defaultTwoLevels,
+			levels: defaultThreeLevels,
+			workload: utiltestingapi.MakeWorkload("trainer", "ns").
+				Annotation(kueue.ColocateWithWorkloadAnnotation, "data-loader").
+				Obj(),
+			admittedWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("data-loader", "ns").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("cq").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								TopologyAssignment(utiltestingapi.MakeTopologyAssignment(defaultOneLevel).
+									Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"x5"}, 1).Obj()).
+									Obj()).
+								Obj()).
+							Obj(), time.Now()).
+					Obj(),
+			},
 			podSets: []PodSetTestCase{{
+				podSetName: "main",
 				topologyRequest: &kueue.PodSetTopologyRequest{
 					Required: ptr.To(tasBlockLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count: 4,
+				count: 1,
 				wantAssignment: &tas.TopologyAssignment{
-					Levels: defaultTwoLevels,
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 1,
+							Values: []string{
+								"x2",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"block required; block of the co-located workload cannot accommodate the Pods; TASColocateWithWorkload": {
+			featureGates: map[featuregate.Feature]bool{features.TASColocateWithWorkload: true},
+			nodes:        defaultNodes,
+			levels:       defaultThreeLevels,
+			workload: utiltestingapi.MakeWorkload("trainer", "ns").
+				Annotation(kueue.ColocateWithWorkloadAnnotation, "data-loader").
+				Obj(),
+			admittedWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("data-loader", "ns").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("cq").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								TopologyAssignment(utiltestingapi.MakeTopologyAssignment(defaultOneLevel).
+									Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"x4"}, 1).Obj()).
+									Obj()).
+								Obj()).
+							Obj(), time.Now()).
+					Obj(),
+			},
+			podSets: []PodSetTestCase{{
+				podSetName: "main",
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasBlockLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 4,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 1,
+							Values: []string{
+								"x3",
+							},
+						},
+						{
+							Count: 1,
+							Values: []string{
+								"x1",
+							},
+						},
+						{
+							Count: 1,
+							Values: []string{
+								"x5",
+							},
+						},
+						{
+							Count: 1,
+							Values: []string{
+								"x6",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"rack required; multiple Pods fit in a rack; BestFit": {
+			nodes:  defaultNodes,
+			levels: defaultTwoLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasRackLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 3,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultTwoLevels,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 3,
+							Values: []string{
+								"b1",
+								"r2",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"block preferred; Pods fit in 2 blocks; BestFit": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1").
+					Label(tasBlockLabel, "b1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("20"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b2").
+					Label(tasBlockLabel, "b2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b3").
+					Label(tasBlockLabel, "b3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("40"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: []string{tasBlockLabel},
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Preferred: ptr.To(tasBlockLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 5,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: []string{tasBlockLabel},
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 1,
+							Values: []string{
+								"b2",
+							},
+						},
+						{
+							Count: 4,
+							Values: []string{
+								"b3",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"rack required; multiple Pods fit in some racks; BestFit": {
+			nodes:  defaultNodes,
+			levels: defaultTwoLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasRackLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 2,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultTwoLevels,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 2,
+							Values: []string{
+								"b2",
+								"r2",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"rack required; too many pods to fit in any rack; BestFit": {
+			nodes:  defaultNodes,
+			levels: defaultTwoLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasRackLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count:      4,
+				wantReason: `topology "default" allows to fit only 3 out of 4 pod(s)`,
+			}},
+		},
+		"block required; single Pod fits in a block and a single rack; BestFit": {
+			nodes:  defaultNodes,
+			levels: defaultTwoLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasBlockLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 1,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: []string{
+						tasBlockLabel,
+						tasRackLabel,
+					},
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 1,
+							Values: []string{
+								"b2",
+								"r1",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"block required; single Pod fits in a block spread across two racks; BestFit": {
+			nodes:  defaultNodes,
+			levels: defaultTwoLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasBlockLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 4,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: []string{
+						tasBlockLabel,
+						tasRackLabel,
+					},
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 1,
+							Values: []string{
+								"b1",
+								"r1",
+							},
+						},
+						{
+							Count: 3,
+							Values: []string{
+								"b1",
+								"r2",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"block required; Pods fit in a block spread across two racks; BestFit": {
+			nodes:  defaultNodes,
+			levels: defaultTwoLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasBlockLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 4,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultTwoLevels,
 					Domains: []tas.TopologyDomainAssignment{
 						{
 							Count: 1,
@@ -1737,13 +2185,59 @@ func TestFindTopologyAssignments(t *testing.T) {
 				"zone": "zone-a",
 			},
 		},
-		"no assignment as node is unschedulable; BestFit": {
+		"no assignment as node is not ready for minNodeReadySeconds; BestFit": {
+			featureGates:        map[featuregate.Feature]bool{features.TASNodeReadinessGating: true},
+			minNodeReadySeconds: ptr.To[int32](60),
 			nodes: []corev1.Node{
-				*testingnode.MakeNode("b1-r1-x3").
+				*testingnode.MakeNode("b1-r1-x1").
 					Label("zone", "zone-a").
-					Label(corev1.LabelHostname, "x3").
+					Label(corev1.LabelHostname, "x1").
 					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					StatusConditions(corev1.NodeCondition{
+						Type:               corev1.NodeReady,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+					}).
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x2").
+					Label("zone", "zone-a").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					StatusConditions(corev1.NodeCondition{
+						Type:               corev1.NodeReady,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(time.Now()),
+					}).
+					Obj(),
+			},
+			levels: defaultOneLevel,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(corev1.LabelHostname),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 2000,
+				},
+				count:      1,
+				wantReason: `topology "default" doesn't allow to fit any of 1 pod(s). Total nodes: 1; excluded: resource "cpu": 1`,
+			}},
+			nodeLabels: map[string]string{
+				"zone": "zone-a",
+			},
+		},
+		"no assignment as node is unschedulable; BestFit": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x3").
+					Label("zone", "zone-a").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
 						corev1.ResourceMemory: resource.MustParse("1Gi"),
 						corev1.ResourcePods:   resource.MustParse("10"),
 					}).
@@ -2485,151 +2979,788 @@ func TestFindTopologyAssignments(t *testing.T) {
 			levels: defaultThreeLevels,
 			podSets: []PodSetTestCase{{
 				topologyRequest: &kueue.PodSetTopologyRequest{
-					Preferred:                   ptr.To(tasBlockLabel),
-					PodSetSliceRequiredTopology: ptr.To(tasRackLabel),
-					PodSetSliceSize:             new(int32(2)),
+					Preferred:                   ptr.To(tasBlockLabel),
+					PodSetSliceRequiredTopology: ptr.To(tasRackLabel),
+					PodSetSliceSize:             new(int32(2)),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 4,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 1,
+							Values: []string{
+								"x1",
+							},
+						},
+						{
+							Count: 1,
+							Values: []string{
+								"x5",
+							},
+						},
+						{
+							Count: 2,
+							Values: []string{
+								"x4",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"block required for podset; host required for slices; optimize last domain; BestFit": {
+			//        b1
+			//         |
+			//        r1
+			//    /    |    \
+			// x3:4, x5:3, x1:2
+			//
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x3").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x5").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x5").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required:                    ptr.To(tasBlockLabel),
+					PodSetSliceRequiredTopology: ptr.To(corev1.LabelHostname),
+					PodSetSliceSize:             new(int32(2)),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 6,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 2,
+							Values: []string{
+								"x1",
+							},
+						},
+						{
+							Count: 4,
+							Values: []string{
+								"x3",
+							},
+						},
+					},
+				},
+			}},
+		},
+		"block preferred for podset; host required for slices; 2 blocks with unbalanced subdomains; BestFit": {
+			//nolint:dupword // suppress duplicate r1 word
+			//        b1                b2
+			//         |                 |
+			//        r1                r1
+			//    /    |    \            |
+			// x3:3, x5:3, x1:3   		x6:6
+			//
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x3").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x5").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x5").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b2-r1-x6").
+					Label(tasBlockLabel, "b2").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x6").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Preferred:                   ptr.To(tasBlockLabel),
+					PodSetSliceRequiredTopology: ptr.To(corev1.LabelHostname),
+					PodSetSliceSize:             new(int32(3)),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 12,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count: 3,
+							Values: []string{
+								"x1",
+							},
+						},
+						{
+							Count: 3,
+							Values: []string{
+								"x3",
+							},
+						},
+						{
+							Count: 6,
+							Values: []string{
+								"x6",
+							},
+						},
+					},
+				},
+			}},
+		},
+		//        b1
+		//        |
+		//        r1
+		//   /    |    \
+		// x1:4  x2:4  x3:4
+		// request: 6, at most 2 pods per node
+		// expected outcome: x1:2, x2:2, x3:2
+		"max pods per domain; pods are spread over the nodes even though a node has capacity for them": {
+			featureGates: map[featuregate.Feature]bool{features.TASMaxPodsPerDomain: true},
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x2").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x3").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required:         ptr.To(tasRackLabel),
+					MaxPodsPerDomain: ptr.To[int32](2),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 6,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count:  2,
+							Values: []string{"x1"},
+						},
+						{
+							Count:  2,
+							Values: []string{"x2"},
+						},
+						{
+							Count:  2,
+							Values: []string{"x3"},
+						},
+					},
+				},
+			}},
+		},
+		//        b1
+		//        |
+		//        r1
+		//   /    |    \
+		// x1:4  x2:4  x3:4
+		// request: 7, at most 2 pods per node
+		// expected outcome: doesn't fit, as at most 6 pods are allowed
+		"max pods per domain; doesn't fit when the limit is lower than the capacity of the nodes": {
+			featureGates: map[featuregate.Feature]bool{features.TASMaxPodsPerDomain: true},
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x2").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x3").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required:         ptr.To(tasRackLabel),
+					MaxPodsPerDomain: ptr.To[int32](2),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count:      7,
+				wantReason: `topology "default" allows to fit only 6 out of 7 pod(s)`,
+			}},
+		},
+		//        b1
+		//        |
+		//        r1
+		//   /    |    \
+		// x1:4  x2:4  x3:4
+		// request: 6, at most 2 pods per node, but the feature gate is disabled
+		// expected outcome: x1:4, x2:2
+		"max pods per domain; ignored when the feature gate is disabled": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x2").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x3").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required:         ptr.To(tasRackLabel),
+					MaxPodsPerDomain: ptr.To[int32](2),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count: 6,
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count:  4,
+							Values: []string{"x1"},
+						},
+						{
+							Count:  2,
+							Values: []string{"x2"},
+						},
+					},
+				},
+			}},
+		},
+		//    b1      b2      b3
+		//    |       |       |
+		//    r1      r1      r1
+		//    |       |       |
+		// x1:6    x2:6    x3:6
+		// request: 6, spread over blocks with maxSkew 1
+		// expected outcome: x1:2, x2:2, x3:2
+		"topology spread constraint; pods are spread across blocks": {
+			featureGates: map[featuregate.Feature]bool{features.TASRespectTopologySpreadConstraints: true},
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b2-r1-x2").
+					Label(tasBlockLabel, "b2").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b3-r1-x3").
+					Label(tasBlockLabel, "b3").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Preferred: ptr.To(tasRackLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count:  6,
+				labels: map[string]string{"app": "spread"},
+				spreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       tasBlockLabel,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "spread"},
+					},
+				}},
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count:  2,
+							Values: []string{"x1"},
+						},
+						{
+							Count:  2,
+							Values: []string{"x2"},
+						},
+						{
+							Count:  2,
+							Values: []string{"x3"},
+						},
+					},
+				},
+			}},
+		},
+		//    b1      b2      b3
+		//    |       |       |
+		//    r1      r1      r1
+		//    |       |       |
+		// x1:1    x2:6    x3:6
+		// request: 7, spread over blocks with maxSkew 2
+		// expected outcome: x1:1, x2:3, x3:3
+		"topology spread constraint; the spread respects the capacity of the blocks": {
+			featureGates: map[featuregate.Feature]bool{features.TASRespectTopologySpreadConstraints: true},
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b2-r1-x2").
+					Label(tasBlockLabel, "b2").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b3-r1-x3").
+					Label(tasBlockLabel, "b3").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Preferred: ptr.To(tasRackLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count:  7,
+				labels: map[string]string{"app": "spread"},
+				spreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           2,
+					TopologyKey:       tasBlockLabel,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "spread"},
+					},
+				}},
+				wantAssignment: &tas.TopologyAssignment{
+					Levels: defaultOneLevel,
+					Domains: []tas.TopologyDomainAssignment{
+						{
+							Count:  1,
+							Values: []string{"x1"},
+						},
+						{
+							Count:  3,
+							Values: []string{"x2"},
+						},
+						{
+							Count:  3,
+							Values: []string{"x3"},
+						},
+					},
+				},
+			}},
+		},
+		//    b1      b2      b3
+		//    |       |       |
+		//    r1      r1      r1
+		//    |       |       |
+		// x1:1    x2:6    x3:6
+		// request: 7, spread over blocks with maxSkew 1
+		// expected outcome: no fit, as x1 fits only a single pod
+		"topology spread constraint; the skew exceeds maxSkew": {
+			featureGates: map[featuregate.Feature]bool{features.TASRespectTopologySpreadConstraints: true},
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b2-r1-x2").
+					Label(tasBlockLabel, "b2").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b3-r1-x3").
+					Label(tasBlockLabel, "b3").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Preferred: ptr.To(tasRackLabel),
+				},
+				requests: resources.Requests{
+					corev1.ResourceCPU: 1000,
+				},
+				count:  7,
+				labels: map[string]string{"app": "spread"},
+				spreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       tasBlockLabel,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "spread"},
+					},
+				}},
+				wantReason: "topology spread constraint on cloud.com/topology-block with maxSkew 1 can't be satisfied",
+			}},
+		},
+		//    b1      b2      b3
+		//    |       |       |
+		//    r1      r1      r1
+		//    |       |       |
+		// x1:6    x2:6    x3:6
+		// request: 6 required in a rack, spread over blocks with maxSkew 1
+		// expected outcome: no fit, as the rack is in a single block
+		"topology spread constraint; conflicts with the required topology": {
+			featureGates: map[featuregate.Feature]bool{features.TASRespectTopologySpreadConstraints: true},
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b2-r1-x2").
+					Label(tasBlockLabel, "b2").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b3-r1-x3").
+					Label(tasBlockLabel, "b3").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("6"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{{
+				topologyRequest: &kueue.PodSetTopologyRequest{
+					Required: ptr.To(tasRackLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count: 4,
-				wantAssignment: &tas.TopologyAssignment{
-					Levels: defaultOneLevel,
-					Domains: []tas.TopologyDomainAssignment{
-						{
-							Count: 1,
-							Values: []string{
-								"x1",
-							},
-						},
-						{
-							Count: 1,
-							Values: []string{
-								"x5",
-							},
-						},
-						{
-							Count: 2,
-							Values: []string{
-								"x4",
-							},
-						},
-					},
-				},
+				count:  6,
+				labels: map[string]string{"app": "spread"},
+				spreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       tasBlockLabel,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "spread"},
+					},
+				}},
+				wantReason: "topology spread constraint on cloud.com/topology-block with maxSkew 1 can't be satisfied by the required topology cloud.com/topology-rack",
 			}},
 		},
-		"block required for podset; host required for slices; optimize last domain; BestFit": {
-			//        b1
-			//         |
-			//        r1
-			//    /    |    \
-			// x3:4, x5:3, x1:2
-			//
+		//    b1      b2      b3
+		//    |       |       |
+		//    r1      r1      r1
+		//    |       |       |
+		// x1:6    x2:6    x3:6
+		// request: 6, spread over blocks with maxSkew 1, with 2 matching pods
+		// of another workload on x1
+		// expected outcome: x2:3, x3:3
+		"topology spread constraint; the existing matching pods are accounted": {
+			featureGates: map[featuregate.Feature]bool{features.TASRespectTopologySpreadConstraints: true},
 			nodes: []corev1.Node{
-				*testingnode.MakeNode("b1-r1-x3").
+				*testingnode.MakeNode("b1-r1-x1").
 					Label(tasBlockLabel, "b1").
 					Label(tasRackLabel, "r1").
-					Label(corev1.LabelHostname, "x3").
+					Label(corev1.LabelHostname, "x1").
 					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourceCPU:  resource.MustParse("6"),
 						corev1.ResourcePods: resource.MustParse("10"),
 					}).
 					Ready().
 					Obj(),
-				*testingnode.MakeNode("b1-r1-x5").
-					Label(tasBlockLabel, "b1").
+				*testingnode.MakeNode("b2-r1-x2").
+					Label(tasBlockLabel, "b2").
 					Label(tasRackLabel, "r1").
-					Label(corev1.LabelHostname, "x5").
+					Label(corev1.LabelHostname, "x2").
 					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourceCPU:  resource.MustParse("6"),
 						corev1.ResourcePods: resource.MustParse("10"),
 					}).
 					Ready().
 					Obj(),
-				*testingnode.MakeNode("b1-r1-x1").
-					Label(tasBlockLabel, "b1").
+				*testingnode.MakeNode("b3-r1-x3").
+					Label(tasBlockLabel, "b3").
 					Label(tasRackLabel, "r1").
-					Label(corev1.LabelHostname, "x1").
+					Label(corev1.LabelHostname, "x3").
 					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("2"),
+						corev1.ResourceCPU:  resource.MustParse("6"),
 						corev1.ResourcePods: resource.MustParse("10"),
 					}).
 					Ready().
 					Obj(),
 			},
-			levels: defaultThreeLevels,
+			levels:   defaultThreeLevels,
+			workload: utiltestingapi.MakeWorkload("wl", "ns").Obj(),
+			admittedWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("other", "ns").
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 2).
+						Labels(map[string]string{"app": "spread"}).
+						Obj()).
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("cq").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								TopologyAssignment(utiltestingapi.MakeTopologyAssignment(defaultOneLevel).
+									Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"x1"}, 2).Obj()).
+									Obj()).
+								Obj()).
+							Obj(), time.Now()).
+					Obj(),
+			},
 			podSets: []PodSetTestCase{{
 				topologyRequest: &kueue.PodSetTopologyRequest{
-					Required:                    ptr.To(tasBlockLabel),
-					PodSetSliceRequiredTopology: ptr.To(corev1.LabelHostname),
-					PodSetSliceSize:             new(int32(2)),
+					Preferred: ptr.To(tasRackLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count: 6,
+				count:  6,
+				labels: map[string]string{"app": "spread"},
+				spreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       tasBlockLabel,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "spread"},
+					},
+				}},
 				wantAssignment: &tas.TopologyAssignment{
 					Levels: defaultOneLevel,
 					Domains: []tas.TopologyDomainAssignment{
 						{
-							Count: 2,
-							Values: []string{
-								"x1",
-							},
+							Count:  3,
+							Values: []string{"x2"},
 						},
 						{
-							Count: 4,
-							Values: []string{
-								"x3",
-							},
+							Count:  3,
+							Values: []string{"x3"},
 						},
 					},
 				},
 			}},
 		},
-		"block preferred for podset; host required for slices; 2 blocks with unbalanced subdomains; BestFit": {
-			//nolint:dupword // suppress duplicate r1 word
-			//        b1                b2
-			//         |                 |
-			//        r1                r1
-			//    /    |    \            |
-			// x3:3, x5:3, x1:3   		x6:6
-			//
+		//    b1      b2      b3
+		//    |       |       |
+		//    r1      r1      r1
+		//    |       |       |
+		// x1:6    x2:6    x3:6
+		// request: 6, spread over blocks with matchLabelKeys
+		// expected outcome: no fit, as matchLabelKeys is not supported
+		"topology spread constraint; matchLabelKeys is not supported": {
+			featureGates: map[featuregate.Feature]bool{features.TASRespectTopologySpreadConstraints: true},
 			nodes: []corev1.Node{
-				*testingnode.MakeNode("b1-r1-x3").
-					Label(tasBlockLabel, "b1").
-					Label(tasRackLabel, "r1").
-					Label(corev1.LabelHostname, "x3").
-					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("3"),
-						corev1.ResourcePods: resource.MustParse("10"),
-					}).
-					Ready().
-					Obj(),
-				*testingnode.MakeNode("b1-r1-x5").
+				*testingnode.MakeNode("b1-r1-x1").
 					Label(tasBlockLabel, "b1").
 					Label(tasRackLabel, "r1").
-					Label(corev1.LabelHostname, "x5").
+					Label(corev1.LabelHostname, "x1").
 					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourceCPU:  resource.MustParse("6"),
 						corev1.ResourcePods: resource.MustParse("10"),
 					}).
 					Ready().
 					Obj(),
-				*testingnode.MakeNode("b1-r1-x1").
-					Label(tasBlockLabel, "b1").
+				*testingnode.MakeNode("b2-r1-x2").
+					Label(tasBlockLabel, "b2").
 					Label(tasRackLabel, "r1").
-					Label(corev1.LabelHostname, "x1").
+					Label(corev1.LabelHostname, "x2").
 					StatusAllocatable(corev1.ResourceList{
-						corev1.ResourceCPU:  resource.MustParse("3"),
+						corev1.ResourceCPU:  resource.MustParse("6"),
 						corev1.ResourcePods: resource.MustParse("10"),
 					}).
 					Ready().
 					Obj(),
-				*testingnode.MakeNode("b2-r1-x6").
-					Label(tasBlockLabel, "b2").
+				*testingnode.MakeNode("b3-r1-x3").
+					Label(tasBlockLabel, "b3").
 					Label(tasRackLabel, "r1").
-					Label(corev1.LabelHostname, "x6").
+					Label(corev1.LabelHostname, "x3").
 					StatusAllocatable(corev1.ResourceList{
 						corev1.ResourceCPU:  resource.MustParse("6"),
 						corev1.ResourcePods: resource.MustParse("10"),
@@ -2640,37 +3771,23 @@ func TestFindTopologyAssignments(t *testing.T) {
 			levels: defaultThreeLevels,
 			podSets: []PodSetTestCase{{
 				topologyRequest: &kueue.PodSetTopologyRequest{
-					Preferred:                   ptr.To(tasBlockLabel),
-					PodSetSliceRequiredTopology: ptr.To(corev1.LabelHostname),
-					PodSetSliceSize:             new(int32(3)),
+					Preferred: ptr.To(tasRackLabel),
 				},
 				requests: resources.Requests{
 					corev1.ResourceCPU: 1000,
 				},
-				count: 12,
-				wantAssignment: &tas.TopologyAssignment{
-					Levels: defaultOneLevel,
-					Domains: []tas.TopologyDomainAssignment{
-						{
-							Count: 3,
-							Values: []string{
-								"x1",
-							},
-						},
-						{
-							Count: 3,
-							Values: []string{
-								"x3",
-							},
-						},
-						{
-							Count: 6,
-							Values: []string{
-								"x6",
-							},
-						},
-					},
-				},
+				count:  6,
+				labels: map[string]string{"app": "spread"},
+				spreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       tasBlockLabel,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "spread"},
+					},
+					MatchLabelKeys: []string{"pod-template-hash"},
+				}},
+				wantReason: "topology spread constraint on cloud.com/topology-block with matchLabelKeys, nodeAffinityPolicy or nodeTaintsPolicy is not supported",
 			}},
 		},
 		//         b1
@@ -7768,6 +8885,104 @@ func TestFindTopologyAssignments(t *testing.T) {
 				},
 			}},
 		},
+		//         b1
+		//      /      \
+		//     r1       r2
+		//   /   \     /   \
+		// x1:1 x2:1 x3:4 x4:1
+		// chief: 3, workers: 2x1
+		// expected outcome: chief on x3, workers on x3 and x4
+		"chief and workers with different requests; required rack; only one rack can host the chief": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("b1-r1-x1").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r1-x2").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r1").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r2-x3").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r2").
+					Label(corev1.LabelHostname, "x3").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("4"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("b1-r2-x4").
+					Label(tasBlockLabel, "b1").
+					Label(tasRackLabel, "r2").
+					Label(corev1.LabelHostname, "x4").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:  resource.MustParse("1"),
+						corev1.ResourcePods: resource.MustParse("10"),
+					}).
+					Ready().
+					Obj(),
+			},
+			levels: defaultThreeLevels,
+			podSets: []PodSetTestCase{
+				{
+					podSetName: "chief",
+					topologyRequest: &kueue.PodSetTopologyRequest{
+						Required: ptr.To(tasRackLabel),
+					},
+					requests: resources.Requests{
+						corev1.ResourceCPU: 3000,
+					},
+					podSetGroupName: new("sameGroup"),
+					count:           1,
+					wantAssignment: &tas.TopologyAssignment{
+						Levels: defaultOneLevel,
+						Domains: []tas.TopologyDomainAssignment{
+							{
+								Count:  1,
+								Values: []string{"x3"},
+							},
+						},
+					},
+				},
+				{
+					podSetName: "workers",
+					topologyRequest: &kueue.PodSetTopologyRequest{
+						Required: ptr.To(tasRackLabel),
+					},
+					requests: resources.Requests{
+						corev1.ResourceCPU: 1000,
+					},
+					podSetGroupName: new("sameGroup"),
+					count:           2,
+					wantAssignment: &tas.TopologyAssignment{
+						Levels: defaultOneLevel,
+						Domains: []tas.TopologyDomainAssignment{
+							{
+								Count:  1,
+								Values: []string{"x3"},
+							},
+							{
+								Count:  1,
+								Values: []string{"x4"},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -7795,8 +9010,9 @@ func TestFindTopologyAssignments(t *testing.T) {
 				Levels: tc.levels,
 			}
 			flavorInformation := flavorInformation{
-				TopologyName: "default",
-				NodeLabels:   tc.nodeLabels,
+				TopologyName:        "default",
+				NodeLabels:          tc.nodeLabels,
+				MinNodeReadySeconds: tc.minNodeReadySeconds,
 			}
 			for _, pod := range tc.pods {
 				tasCache.Update(&pod, log)
@@ -7823,7 +9039,7 @@ func TestFindTopologyAssignments(t *testing.T) {
 			}
 			snapshot := tasFlavorCache.snapshot(
 				log,
-				tasCache.nodesCache.find(tasFlavorCache.flavor.NodeLabels, tasFlavorCache.topology.Levels),
+				tasCache.nodesCache.find(tasFlavorCache.flavor.NodeLabels, tasFlavorCache.topology.Levels, tasFlavorCache.topology.DerivedLevels, tasFlavorCache.MinNodeReadySeconds(), time.Now()),
 				aggregatedDomainUsage,
 			)
 			flavorTASRequests := make([]TASPodSetRequests, 0, len(tc.podSets))
@@ -7840,10 +9056,14 @@ func TestFindTopologyAssignments(t *testing.T) {
 						Name:            kueue.NewPodSetReference(ps.podSetName),
 						TopologyRequest: ps.topologyRequest,
 						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels: ps.labels,
+							},
 							Spec: corev1.PodSpec{
-								Tolerations:  ps.tolerations,
-								NodeSelector: ps.nodeSelector,
-								Affinity:     affinity,
+								Tolerations:               ps.tolerations,
+								NodeSelector:              ps.nodeSelector,
+								Affinity:                  affinity,
+								TopologySpreadConstraints: ps.spreadConstraints,
 							},
 						},
 					},
@@ -7871,6 +9091,25 @@ func TestFindTopologyAssignments(t *testing.T) {
 			if tc.workload != nil {
 				findOpts = append(findOpts, WithWorkload(tc.workload))
 			}
+			if len(tc.admittedWorkloads) > 0 {
+				snapshot.admittedWorkload = func(key workload.Reference) *workload.Info {
+					for i := range tc.admittedWorkloads {
+						if workload.Key(&tc.admittedWorkloads[i]) == key {
+							return workload.NewInfo(&tc.admittedWorkloads[i])
+						}
+					}
+					return nil
+				}
+				snapshot.admittedWorkloadsInNamespace = func(namespace string) []*workload.Info {
+					var infos []*workload.Info
+					for i := range tc.admittedWorkloads {
+						if tc.admittedWorkloads[i].Namespace == namespace {
+							infos = append(infos, workload.NewInfo(&tc.admittedWorkloads[i]))
+						}
+					}
+					return infos
+				}
+			}
 			gotResult := snapshot.FindTopologyAssignmentsForFlavor(flavorTASRequests, findOpts...)
 			if diff := cmp.Diff(wantResult, gotResult); diff != "" {
 				t.Errorf("unexpected topology assignment (-want,+got): %s", diff)
@@ -8295,7 +9534,7 @@ func TestFindTopologyAssignmentsMultiLayerReplacement(t *testing.T) {
 			}
 			snapshot := tasFlavorCache.snapshot(
 				log,
-				tasCache.nodesCache.find(tasFlavorCache.flavor.NodeLabels, tasFlavorCache.topology.Levels),
+				tasCache.nodesCache.find(tasFlavorCache.flavor.NodeLabels, tasFlavorCache.topology.Levels, tasFlavorCache.topology.DerivedLevels, nil, time.Now()),
 				aggregatedDomainUsages,
 			)
 			result := snapshot.FindTopologyAssignmentsForFlavor(flavorTASRequests, WithWorkload(wl))
@@ -17,6 +17,7 @@ limitations under the License.
 package scheduler
 
 import (
+	"maps"
 	"slices"
 	"sync"
 
@@ -58,12 +59,18 @@ type flavorInformation struct {
 	// tolerations represents the list of tolerations specified for the resource
 	// flavor
 	Tolerations []corev1.Toleration
+	// minNodeReadySeconds is the minimum time a node needs to be Ready before it
+	// contributes capacity to the flavor.
+	MinNodeReadySeconds *int32
 }
 
 type topologyInformation struct {
 	// levels is a list of levels defined in the Topology object referenced
 	// by the flavor corresponding to the cache.
 	Levels []string
+	// derivedLevels is a list of levels whose values are derived from
+	// composite node labels.
+	DerivedLevels []utiltas.DerivedLevel
 }
 
 type TASFlavorCache struct {
@@ -107,6 +114,15 @@ func (c *TASFlavorCache) NodeLabels() map[string]string {
 	return c.flavor.NodeLabels
 }
 
+// MinNodeReadySeconds returns the minimum time a node needs to be Ready before
+// it contributes capacity to the flavor, or nil if not configured.
+func (c *TASFlavorCache) MinNodeReadySeconds() *int32 {
+	if !features.Enabled(features.TASNodeReadinessGating) {
+		return nil
+	}
+	return c.flavor.MinNodeReadySeconds
+}
+
 func (c *TASFlavorCache) Topology() kueue.TopologyReference {
 	return c.flavor.TopologyName
 }
@@ -115,6 +131,12 @@ func (c *TASFlavorCache) TopologyLevels() []string {
 	return c.topology.Levels
 }
 
+// DerivedLevels returns the topology levels whose values are derived from
+// composite node labels.
+func (c *TASFlavorCache) DerivedLevels() []utiltas.DerivedLevel {
+	return c.topology.DerivedLevels
+}
+
 func (c *TASFlavorCache) snapshot(
 	log logr.Logger, nodes []*corev1.Node, aggregatedDomainUsages map[utiltas.TopologyDomainID]resources.Requests,
 ) *TASFlavorSnapshot {
@@ -131,7 +153,8 @@ func (c *TASFlavorCache) snapshot(
 	}
 	log.V(3).Info("Constructing TAS snapshot", infoKV...)
 
-	snapshot := newTASFlavorSnapshot(log, c.flavor.TopologyName, c.topology.Levels, withTolerations(c.flavor.Tolerations))
+	snapshot := newTASFlavorSnapshot(log, c.flavor.TopologyName, c.topology.Levels,
+		withTolerations(c.flavor.Tolerations), withDerivedLevels(c.topology.DerivedLevels))
 	nodeToDomain := make(map[string]utiltas.TopologyDomainID)
 	for _, node := range nodes {
 		nodeToDomain[node.Name] = snapshot.addNode(node)
@@ -162,6 +185,15 @@ func (c *TASFlavorCache) addUsage(log logr.Logger, key workload.Reference, topol
 	c.updateUsage(topologyRequests, add)
 }
 
+// hasUsage returns true if the usage recorded for the workload matches the
+// given topology requests.
+func (c *TASFlavorCache) hasUsage(key workload.Reference, topologyRequests []workload.TopologyDomainRequests) bool {
+	recorded, found := c.wlUsage[key]
+	return found && slices.EqualFunc(recorded, topologyRequests, func(a, b workload.TopologyDomainRequests) bool {
+		return a.Count == b.Count && slices.Equal(a.Values, b.Values) && maps.Equal(a.SinglePodRequests, b.SinglePodRequests)
+	})
+}
+
 func (c *TASFlavorCache) removeUsage(log logr.Logger, key workload.Reference) {
 	value, found := c.wlUsage[key]
 	if !found {
@@ -31,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
 	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
 	"k8s.io/utils/ptr"
@@ -92,6 +93,14 @@ type domain struct {
 	// affinityScore is the sum of weights of all preferred affinity terms that match the node.
 	// For non-leaf domains, it is the sum of affinity scores of all children.
 	affinityScore int64
+
+	// previousCount is the number of leaf domains within the domain which were
+	// part of the previous TopologyAssignment of the workload.
+	previousCount int32
+
+	// colocationCount is the number of leaf domains within the domain which
+	// are assigned to the workload referenced for co-location.
+	colocationCount int32
 }
 
 // leafDomain extends the domain with information for the lowest-level domain.
@@ -138,6 +147,10 @@ type TASFlavorSnapshot struct {
 	// tolerations represents the list of tolerations defined for the resource flavor
 	tolerations []corev1.Toleration
 
+	// derivedLevels are the topology levels whose values are derived from
+	// composite node labels.
+	derivedLevels []utiltas.DerivedLevel
+
 	// isLowestLevelNode indicates if kubernetes.io/hostname is the lowest topology level
 	isLowestLevelNode bool
 
@@ -145,6 +158,15 @@ type TASFlavorSnapshot struct {
 	// of a Workload to avoid recalculating selectors/taints during preemption simulations or
 	// multiple worker PodSet placements within the same scheduling cycle snapshot.
 	matchingLeavesCache map[podSetMatchKey]*matchingLeavesCacheEntry
+
+	// admittedWorkload returns the admitted workload with the given key, so
+	// that the workload referenced for co-location can be found.
+	admittedWorkload func(workload.Reference) *workload.Info
+
+	// admittedWorkloadsInNamespace returns the admitted workloads of the
+	// namespace, so that their pods are accounted by the topology spread
+	// constraints.
+	admittedWorkloadsInNamespace func(namespace string) []*workload.Info
 }
 
 // podSetMatchKey uniquely identifies a PodSet within a Workload for caching purposes.
@@ -182,7 +204,8 @@ const (
 )
 
 type tasFlavorSnapshotOptions struct {
-	tolerations []corev1.Toleration
+	tolerations   []corev1.Toleration
+	derivedLevels []utiltas.DerivedLevel
 }
 
 type tasFlavorSnapshotOption func(*tasFlavorSnapshotOptions)
@@ -193,6 +216,12 @@ func withTolerations(tolerations []corev1.Toleration) tasFlavorSnapshotOption {
 	}
 }
 
+func withDerivedLevels(derivedLevels []utiltas.DerivedLevel) tasFlavorSnapshotOption {
+	return func(o *tasFlavorSnapshotOptions) {
+		o.derivedLevels = derivedLevels
+	}
+}
+
 func newTASFlavorSnapshot(log logr.Logger, topologyName kueue.TopologyReference,
 	levels []string, opts ...tasFlavorSnapshotOption) *TASFlavorSnapshot {
 	options := &tasFlavorSnapshotOptions{}
@@ -213,6 +242,7 @@ func newTASFlavorSnapshot(log logr.Logger, topologyName kueue.TopologyReference,
 		levelKeys:         slices.Clone(levels),
 		leaves:            make(leafDomainByID),
 		tolerations:       slices.Clone(options.tolerations),
+		derivedLevels:     options.derivedLevels,
 		domains:           make(domainByID),
 		roots:             make(domainByID),
 		domainsPerLevel:   domainsPerLevel,
@@ -233,11 +263,11 @@ func (s *TASFlavorSnapshot) addNode(node *corev1.Node) utiltas.TopologyDomainID
 		_, leafFound = s.leaves[domainID]
 		// Only compute levelValues when we actually need to create a new leafDomain.
 		if !leafFound {
-			levelValues = utiltas.LevelValues(s.levelKeys, node.Labels)
+			levelValues = utiltas.LevelValues(s.levelKeys, utiltas.NodeLevelLabels(node.Labels, s.derivedLevels))
 		}
 	} else {
 		// Compute full level values and domain ID.
-		levelValues = utiltas.LevelValues(s.levelKeys, node.Labels)
+		levelValues = utiltas.LevelValues(s.levelKeys, utiltas.NodeLevelLabels(node.Labels, s.derivedLevels))
 		domainID = utiltas.DomainID(levelValues)
 		_, leafFound = s.leaves[domainID]
 	}
@@ -479,7 +509,14 @@ func (s *TASFlavorSnapshot) Fits(flavorUsage workload.TASFlavorUsage) bool {
 		}
 		remainingCapacity := leaf.freeCapacity.Clone()
 		remainingCapacity.Sub(leaf.tasUsage)
-		if domainUsage.SinglePodRequests.CountIn(remainingCapacity) < domainUsage.Count {
+		singlePodRequests := domainUsage.SinglePodRequests
+		if features.Enabled(features.TASPlacementWithoutRequests) {
+			// Account for the pods of the PodSets without resource requests,
+			// as done when finding the topology assignment.
+			singlePodRequests = singlePodRequests.Clone()
+			singlePodRequests.Add(resources.Requests{corev1.ResourcePods: 1})
+		}
+		if singlePodRequests.CountIn(remainingCapacity) < domainUsage.Count {
 			return false
 		}
 	}
@@ -513,8 +550,11 @@ type topologyAssignmentPodRequirements struct {
 	affinitySelector          *nodeaffinity.NodeSelector
 	preferredSchedulingTerms  *nodeaffinity.PreferredSchedulingTerms
 	requiredReplacementDomain utiltas.TopologyDomainID
+	previousDomains           sets.Set[utiltas.TopologyDomainID]
+	colocationDomains         sets.Set[utiltas.TopologyDomainID]
 	simulateEmpty             bool
 	matchKey                  *podSetMatchKey
+	maxPodsPerDomain          *int32
 }
 
 // topologyAssignmentParameters stores placement-specific inputs that remain
@@ -804,6 +844,50 @@ func addUsagePerDomain(assumedUsage map[utiltas.TopologyDomainID]resources.Reque
 	}
 }
 
+// previousTopologyDomains returns the lowest level domains of the previous
+// TopologyAssignment of the PodSet, recorded in the workload annotation.
+func previousTopologyDomains(wl *kueue.Workload, psName kueue.PodSetReference) sets.Set[utiltas.TopologyDomainID] {
+	if wl == nil {
+		return nil
+	}
+	value, found := wl.Annotations[kueue.PreviousTopologyAssignmentAnnotation]
+	if !found {
+		return nil
+	}
+	domains, err := utiltas.PreviousAssignmentDomains(value)
+	if err != nil {
+		return nil
+	}
+	return sets.New(domains[psName]...)
+}
+
+// colocationDomains returns the lowest level domains assigned to the workload
+// referenced in the ColocateWithWorkloadAnnotation of the workload. Only the
+// TopologyAssignments using the lowest levels of this topology are considered.
+func (s *TASFlavorSnapshot) colocationDomains(wl *kueue.Workload) sets.Set[utiltas.TopologyDomainID] {
+	if wl == nil || s.admittedWorkload == nil {
+		return nil
+	}
+	name := wl.Annotations[kueue.ColocateWithWorkloadAnnotation]
+	if name == "" || name == wl.Name {
+		return nil
+	}
+	colocated := s.admittedWorkload(workload.NewReference(wl.Namespace, name))
+	if colocated == nil || colocated.Obj.Status.Admission == nil {
+		return nil
+	}
+	domains := sets.New[utiltas.TopologyDomainID]()
+	for _, psa := range colocated.Obj.Status.Admission.PodSetAssignments {
+		ta := psa.TopologyAssignment
+		if ta == nil || len(ta.Levels) == 0 || len(ta.Levels) > len(s.levelKeys) ||
+			!slices.Equal(ta.Levels, s.levelKeys[len(s.levelKeys)-len(ta.Levels):]) {
+			continue
+		}
+		domains.Insert(utiltas.LowestLevelDomainIDs(ta)...)
+	}
+	return domains
+}
+
 func findPSA(wl *kueue.Workload, psName kueue.PodSetReference) *kueue.PodSetAssignment {
 	if wl.Status.Admission == nil {
 		return nil
@@ -1018,6 +1102,10 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 
 	requirements.tolerations = append(info.Tolerations, s.tolerations...)
 
+	if features.Enabled(features.TASMaxPodsPerDomain) && workersTasPodSetRequests.PodSet.TopologyRequest != nil {
+		requirements.maxPodsPerDomain = workersTasPodSetRequests.PodSet.TopologyRequest.MaxPodsPerDomain
+	}
+
 	if s.isLowestLevelNode {
 		sel, err := labels.ValidatedSelectorFromSet(info.NodeSelector)
 		if err != nil {
@@ -1054,6 +1142,13 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 		}
 	}
 
+	if features.Enabled(features.TASPreferPreviousAssignment) && requiredReplacementDomain == "" {
+		requirements.previousDomains = previousTopologyDomains(wl, workersTasPodSetRequests.PodSet.Name)
+	}
+	if features.Enabled(features.TASColocateWithWorkload) && requiredReplacementDomain == "" {
+		requirements.colocationDomains = s.colocationDomains(wl)
+	}
+
 	// phase 1 - determine the number of pods and slices which can fit in each topology domain
 	s.fillInCounts(requirements, state)
 
@@ -1061,8 +1156,21 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 	// the domains which can accommodate all pods/slices
 	var currFitDomain []*domain
 	var fitLevelIdx int
-	var useBalancedPlacement bool
-	if features.Enabled(features.TASBalancedPlacement) && !state.required && !state.unconstrained {
+	var useBalancedPlacement, useSpreadPlacement bool
+	if features.Enabled(features.TASRespectTopologySpreadConstraints) && leaderTasPodSetRequests == nil && !slicesRequested(workersTasPodSetRequests.PodSet.TopologyRequest) {
+		var constraint *topologySpreadConstraint
+		constraint, reason = s.topologySpreadConstraintForPodSet(workersTasPodSetRequests.PodSet, wl)
+		if len(reason) > 0 {
+			return nil, reason
+		}
+		if constraint != nil {
+			currFitDomain, fitLevelIdx, useSpreadPlacement, reason = s.applyTopologySpreadConstraint(&state.topologyAssignmentParameters, constraint)
+			if len(reason) > 0 {
+				return nil, reason
+			}
+		}
+	}
+	if features.Enabled(features.TASBalancedPlacement) && !useSpreadPlacement && !state.required && !state.unconstrained {
 		var bestThreshold int32
 		currFitDomain, bestThreshold = findBestDomainsForBalancedPlacement(s, &state.topologyAssignmentParameters)
 		useBalancedPlacement = bestThreshold > 0
@@ -1075,7 +1183,7 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 		}
 	}
 
-	if !useBalancedPlacement {
+	if !useBalancedPlacement && !useSpreadPlacement {
 		fitLevelIdx, currFitDomain, reason = s.findLevelWithFitDomains(state.requestedLevelIdx, state)
 		if len(reason) > 0 {
 			return nil, reason
@@ -1086,7 +1194,7 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 	// if unconstrained is set, we'll only do it once
 	currFitDomain = s.updateCountsToMinimumGeneric(currFitDomain, state.count, state.leaderCount, state.sliceSize, state.unconstrained, true)
 	currentLevelIdx := fitLevelIdx
-	for ; currentLevelIdx < min(len(s.domainsPerLevel)-1, state.sliceLevelIdx) && !useBalancedPlacement; currentLevelIdx++ {
+	for ; currentLevelIdx < min(len(s.domainsPerLevel)-1, state.sliceLevelIdx) && !useBalancedPlacement && !useSpreadPlacement; currentLevelIdx++ {
 		// If we are "above" the requested slice topology level and we don't run the balanced placement algorithm,
 		// we're greedily assigning pods/slices to all domains without checking what we've assigned to parent domains.
 		sortedLowerDomains := s.sortedDomains(s.lowerLevelDomains(currFitDomain), state.unconstrained)
@@ -1094,7 +1202,7 @@ func (s *TASFlavorSnapshot) findTopologyAssignment(
 	}
 
 	for ; currentLevelIdx < len(s.domainsPerLevel)-1; currentLevelIdx++ {
-		// If we are "at" or "below" the requested slice topology level or we run the balanced placement algorithm
+		// If we are "at" or "below" the requested slice topology level or we run the balanced or spread placement algorithm
 		// we have to carefully assign pods to domains based on what we've assigned to parent domains,
 		// that's why we're iterating through each parent domain and assigning `domain.state` amount of pods
 		// to its child domains.
@@ -1399,6 +1507,11 @@ func (s *TASFlavorSnapshot) findLevelWithFitDomains(
 	}
 
 	if useLeastFreeCapacityAlgorithm(state.unconstrained) {
+		if preferredDomain := preferredFitDomain(sortedDomain, func(d *domain) bool {
+			return d.sliceState >= sliceCount
+		}); preferredDomain != nil {
+			return searchLevelIdx, []*domain{preferredDomain}, ""
+		}
 		for _, candidateDomain := range sortedDomain {
 			if candidateDomain.sliceState >= sliceCount {
 				return searchLevelIdx, []*domain{candidateDomain}, ""
@@ -1467,9 +1580,32 @@ func (s *TASFlavorSnapshot) findLevelWithFitDomains(
 		}
 		return searchLevelIdx, results, ""
 	}
+	if preferredDomain := preferredFitDomain(sortedDomain, func(d *domain) bool {
+		return d.sliceStateWithLeader >= sliceCount && d.leaderState >= state.leaderCount
+	}); preferredDomain != nil {
+		topDomain = preferredDomain
+	}
 	return searchLevelIdx, []*domain{topDomain}, ""
 }
 
+// preferredFitDomain returns the fitting domain which contains the most leaf
+// domains of the previous TopologyAssignment of the workload, and then the
+// most leaf domains of the workload referenced for co-location. It returns nil
+// if no fitting domain contains any of them.
+func preferredFitDomain(domains []*domain, fits func(d *domain) bool) *domain {
+	var result *domain
+	for _, d := range domains {
+		if (d.previousCount == 0 && d.colocationCount == 0) || !fits(d) {
+			continue
+		}
+		if result == nil || d.previousCount > result.previousCount ||
+			(d.previousCount == result.previousCount && d.colocationCount > result.colocationCount) {
+			result = d
+		}
+	}
+	return result
+}
+
 // topAffinityTierDomains truncates the candidate list to include only the domains
 // sharing the highest affinity score present in the slice.
 //
@@ -1757,6 +1893,8 @@ func (s *TASFlavorSnapshot) fillInCounts(requirements *topologyAssignmentPodRequ
 		domain.sliceStateWithLeader = 0
 		domain.leaderState = 0
 		domain.affinityScore = 0
+		domain.previousCount = 0
+		domain.colocationCount = 0
 	}
 
 	if features.Enabled(features.TASCacheNodeMatchResults) {
@@ -1869,6 +2007,13 @@ func (s *TASFlavorSnapshot) fillLeafCounts(leaf *leafDomain, requirements *topol
 		return
 	}
 
+	if requirements.previousDomains.Has(leaf.id) {
+		leaf.previousCount = 1
+	}
+	if requirements.colocationDomains.Has(leaf.id) {
+		leaf.colocationCount = 1
+	}
+
 	remainingCapacity := leaf.freeCapacity.Clone()
 	if !requirements.simulateEmpty {
 		remainingCapacity.Sub(leaf.tasUsage)
@@ -1892,6 +2037,13 @@ func (s *TASFlavorSnapshot) fillLeafCounts(leaf *leafDomain, requirements *topol
 	}
 
 	leaf.stateWithLeader = requirements.requests.CountIn(remainingCapacity)
+
+	// Don't assign more pods to the domain than allowed, even if it has the
+	// capacity for them.
+	if requirements.maxPodsPerDomain != nil {
+		leaf.state = min(leaf.state, *requirements.maxPodsPerDomain)
+		leaf.stateWithLeader = min(leaf.stateWithLeader, *requirements.maxPodsPerDomain)
+	}
 }
 
 func belongsToRequiredDomain(leaf *leafDomain, requiredReplacementDomain utiltas.TopologyDomainID) bool {
@@ -1921,6 +2073,8 @@ func (s *TASFlavorSnapshot) fillInCountsHelper(domain *domain, sliceSize int32,
 	minSliceStateWithLeaderDifference := int32(math.MaxInt32)
 	leaderState := int32(0)
 	affinityScore := int64(0)
+	previousCount := int32(0)
+	colocationCount := int32(0)
 
 	// When multi-layer constraints exist, children at a constrained level
 	// can only contribute pods in multiples of the inner slice size.
@@ -1948,6 +2102,8 @@ func (s *TASFlavorSnapshot) fillInCountsHelper(domain *domain, sliceSize int32,
 		}
 		leaderState = max(child.leaderState, leaderState)
 		affinityScore += child.affinityScore
+		previousCount += child.previousCount
+		colocationCount += child.colocationCount
 	}
 	domain.state = childrenCapacity
 	sliceStateWithLeader := int32(0)
@@ -1959,6 +2115,8 @@ func (s *TASFlavorSnapshot) fillInCountsHelper(domain *domain, sliceSize int32,
 	}
 	domain.leaderState = leaderState
 	domain.affinityScore = affinityScore
+	domain.previousCount = previousCount
+	domain.colocationCount = colocationCount
 	if level == sliceLevelIdx {
 		// initialize the sliceState for the requested slice level.
 		sliceCapacity = domain.state / sliceSize
@@ -19,6 +19,7 @@ package scheduler
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -29,9 +30,10 @@ import (
 )
 
 const (
-	benchBlockLabel = "cloud.provider.com/topology-block"
-	benchRackLabel  = "cloud.provider.com/topology-rack"
-	benchHostLabel  = corev1.LabelHostname
+	benchBlockLabel  = "cloud.provider.com/topology-block"
+	benchRackLabel   = "cloud.provider.com/topology-rack"
+	benchNVLinkLabel = "cloud.provider.com/topology-nvlink"
+	benchHostLabel   = corev1.LabelHostname
 )
 
 type benchTopology struct {
@@ -40,6 +42,16 @@ type benchTopology struct {
 	racksPerBlock  int
 	flavors        int
 	withNonTASPods bool
+	// nodesPerNVLinkDomain adds the NVLink domain level between the rack
+	// and the host levels when set.
+	nodesPerNVLinkDomain int
+}
+
+func (t benchTopology) levels() []string {
+	if t.nodesPerNVLinkDomain > 0 {
+		return []string{benchBlockLabel, benchRackLabel, benchNVLinkLabel, benchHostLabel}
+	}
+	return []string{benchBlockLabel, benchRackLabel, benchHostLabel}
 }
 
 func buildBenchNodes(t benchTopology) []corev1.Node {
@@ -57,9 +69,11 @@ func buildBenchNodes(t benchTopology) []corev1.Node {
 				corev1.ResourceMemory: resource.MustParse("384Gi"),
 				corev1.ResourcePods:   resource.MustParse("110"),
 			}).
-			Ready().
-			Obj()
-		nodes = append(nodes, *node)
+			Ready()
+		if t.nodesPerNVLinkDomain > 0 {
+			node.Label(benchNVLinkLabel, fmt.Sprintf("nvlink-%d", i/t.nodesPerNVLinkDomain))
+		}
+		nodes = append(nodes, *node.Obj())
 	}
 	return nodes
 }
@@ -71,6 +85,7 @@ func BenchmarkTASFlavorSnapshot(b *testing.B) {
 		{nodes: 2500, nodesPerRack: 16, racksPerBlock: 16, withNonTASPods: true},
 		{nodes: 2500, nodesPerRack: 16, racksPerBlock: 16, flavors: 15, withNonTASPods: true},
 		{nodes: 2500, nodesPerRack: 16, racksPerBlock: 16},
+		{nodes: 2500, nodesPerRack: 16, racksPerBlock: 16, nodesPerNVLinkDomain: 4},
 	}
 
 	for _, topo := range topologies {
@@ -79,12 +94,15 @@ func BenchmarkTASFlavorSnapshot(b *testing.B) {
 		if topo.withNonTASPods {
 			name += "/withNonTASPods"
 		}
+		if topo.nodesPerNVLinkDomain > 0 {
+			name += "/withNVLinkDomains"
+		}
 		b.Run(name, func(b *testing.B) {
 			b.ReportAllocs()
 			log := logr.Discard()
 
 			nodes := buildBenchNodes(topo)
-			levels := []string{benchBlockLabel, benchRackLabel, benchHostLabel}
+			levels := topo.levels()
 
 			tasCache := NewTASCache(nil)
 			for i := range nodes {
@@ -113,7 +131,7 @@ func BenchmarkTASFlavorSnapshot(b *testing.B) {
 
 			flavorNodes := make([][]*corev1.Node, len(flavorCaches))
 			for i, flavorCache := range flavorCaches {
-				flavorNodes[i] = tasCache.nodesCache.find(flavorCache.flavor.NodeLabels, flavorCache.topology.Levels)
+				flavorNodes[i] = tasCache.nodesCache.find(flavorCache.flavor.NodeLabels, flavorCache.topology.Levels, nil, nil, time.Now())
 			}
 
 			for b.Loop() {
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/tas"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
 	"sigs.k8s.io/kueue/pkg/util/testingjobs/node"
 )
 
@@ -940,3 +941,51 @@ func TestTruncateAssignment(t *testing.T) {
 		})
 	}
 }
+
+func TestAddNodeWithDerivedLevels(t *testing.T) {
+	const (
+		blockLabel     = "cloud.provider.com/topology-block"
+		rackLabel      = "cloud.provider.com/topology-rack"
+		compositeLabel = "cloud.provider.com/topology"
+	)
+	topology := utiltestingapi.MakeTopology("default").
+		Levels(blockLabel, rackLabel, corev1.LabelHostname).
+		LevelValueFrom(blockLabel, compositeLabel, "^(block[0-9]+)-rack[0-9]+$").
+		LevelValueFrom(rackLabel, compositeLabel, "^block[0-9]+-rack[0-9]+$").
+		Obj()
+	derivedLevels, err := tas.DerivedLevels(topology)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	nodes := []*corev1.Node{
+		node.MakeNode("x").Label(corev1.LabelHostname, "x").Label(compositeLabel, "block1-rack1").Obj(),
+		node.MakeNode("y").Label(corev1.LabelHostname, "y").Label(compositeLabel, "block1-rack2").Obj(),
+		node.MakeNode("z").Label(corev1.LabelHostname, "z").Label(compositeLabel, "block2-rack1").Obj(),
+	}
+
+	_, log := utiltesting.ContextWithLog(t)
+	snapshot := newTASFlavorSnapshot(log, "default", tas.Levels(topology), withDerivedLevels(derivedLevels))
+	for _, n := range nodes {
+		snapshot.addNode(n)
+	}
+	snapshot.initialize()
+
+	gotLevelValues := make(map[tas.TopologyDomainID][]string)
+	for id, leaf := range snapshot.leaves {
+		gotLevelValues[id] = leaf.levelValues
+	}
+	wantLevelValues := map[tas.TopologyDomainID][]string{
+		"x": {"block1", "block1-rack1", "x"},
+		"y": {"block1", "block1-rack2", "y"},
+		"z": {"block2", "block2-rack1", "z"},
+	}
+	if diff := cmp.Diff(wantLevelValues, gotLevelValues); diff != "" {
+		t.Errorf("Unexpected level values (-want,+got):\n%s", diff)
+	}
+	if got := len(snapshot.domainsPerLevel[0]); got != 2 {
+		t.Errorf("Unexpected number of block domains, want 2, got %d", got)
+	}
+	if got := len(snapshot.domainsPerLevel[1]); got != 3 {
+		t.Errorf("Unexpected number of rack domains, want 3, got %d", got)
+	}
+}
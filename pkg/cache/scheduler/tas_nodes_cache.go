@@ -18,6 +18,7 @@ package scheduler
 
 import (
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -60,20 +61,68 @@ func (t *nodesCache) deleteWithoutLock(nodeName string) {
 	delete(t.nodes, nodeName)
 }
 
-func (t *nodesCache) find(nodeLabels map[string]string, levels []string) []*corev1.Node {
+// find returns the nodes matching the flavor node labels and topology levels,
+// including the levels derived from composite node labels. When
+// minReadySeconds is set, only the nodes which have been Ready for at least
+// that long are returned.
+func (t *nodesCache) find(nodeLabels map[string]string, levels []string, derived []utiltas.DerivedLevel, minReadySeconds *int32, now time.Time) []*corev1.Node {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
 	filteredNodes := make([]*corev1.Node, 0, len(t.nodes))
 	for _, node := range t.nodes {
-		if utiltas.NodeMatchesFlavor(node.Labels, nodeLabels, levels) {
-			filteredNodes = append(filteredNodes, node)
+		if !utiltas.NodeMatchesFlavor(utiltas.NodeLevelLabels(node.Labels, derived), nodeLabels, levels) {
+			continue
 		}
+		if minReadySeconds != nil && utiltas.NodeReadyRemaining(node, *minReadySeconds, now) > 0 {
+			continue
+		}
+		filteredNodes = append(filteredNodes, node)
 	}
 	return filteredNodes
 }
 
+// nextReady returns the time remaining until the next node matching the flavor
+// node labels and topology levels, which is Ready but not yet for
+// minReadySeconds, has been Ready long enough. It returns 0 if there is no
+// such node.
+func (t *nodesCache) nextReady(nodeLabels map[string]string, levels []string, derived []utiltas.DerivedLevel, minReadySeconds int32, now time.Time) time.Duration {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	var next time.Duration
+	for _, node := range t.nodes {
+		if !utiltas.NodeMatchesFlavor(utiltas.NodeLevelLabels(node.Labels, derived), nodeLabels, levels) {
+			continue
+		}
+		if remaining := utiltas.NodeReadyRemaining(node, minReadySeconds, now); remaining > 0 && (next == 0 || remaining < next) {
+			next = remaining
+		}
+	}
+	return next
+}
+
+// missingLevels returns the topology levels missing in the labels of the nodes
+// which match the flavor node labels, keyed by the node name. The nodes which
+// have all the topology levels are not returned. Only the schedulable and Ready
+// nodes are considered, as the other nodes are not kept in the cache by sync.
+func (t *nodesCache) missingLevels(nodeLabels map[string]string, levels []string, derived []utiltas.DerivedLevel) map[string][]string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	result := make(map[string][]string)
+	for _, node := range t.nodes {
+		labels := utiltas.NodeLevelLabels(node.Labels, derived)
+		if !utiltas.NodeMatchesFlavor(labels, nodeLabels, nil) {
+			continue
+		}
+		if missing := utiltas.MissingLevels(labels, levels); len(missing) > 0 {
+			result[node.Name] = missing
+		}
+	}
+	return result
+}
+
 // copyAndStripNode creates a minimal copy of the Node object containing only the
-// fields required for TAS scheduling (Name, Labels, Taints, and Allocatable).
+// fields required for TAS scheduling (Name, Labels, Taints, Allocatable, and
+// the NodeReady condition).
 // This reduces the memory footprint and, more importantly, minimizes the number
 // of pointer fields the garbage collector needs to traverse in a large cluster
 // with frequent scheduling activity.
@@ -88,6 +137,19 @@ func copyAndStripNode(node *corev1.Node) *corev1.Node {
 		},
 		Status: corev1.NodeStatus{
 			Allocatable: node.Status.Allocatable,
+			Conditions:  readyCondition(node),
 		},
 	}
 }
+
+func readyCondition(node *corev1.Node) []corev1.NodeCondition {
+	cond := utiltas.GetNodeCondition(node, corev1.NodeReady)
+	if cond == nil {
+		return nil
+	}
+	return []corev1.NodeCondition{{
+		Type:               cond.Type,
+		Status:             cond.Status,
+		LastTransitionTime: cond.LastTransitionTime,
+	}}
+}
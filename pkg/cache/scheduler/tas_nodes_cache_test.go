@@ -18,10 +18,13 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/kueue/pkg/util/testingjobs/node"
 )
@@ -98,6 +101,7 @@ func TestNodesCache(t *testing.T) {
 
 func TestNodesCacheFind(t *testing.T) {
 	nc := newNodesCache()
+	now := time.Now()
 
 	node1 := node.MakeNode("test1").Obj()
 	node2 := node.MakeNode("test2").Label("cloud.provider.com/zone", "us-east-1a").Obj()
@@ -106,17 +110,34 @@ func TestNodesCacheFind(t *testing.T) {
 		Label("cloud.provider.com/topology-block", "b1").
 		Obj()
 	node4 := node.MakeNode("test4").Label("cloud.provider.com/zone", "us-east-1").Obj()
+	node5 := node.MakeNode("test5").
+		Label("cloud.provider.com/zone", "us-west-1").
+		StatusConditions(corev1.NodeCondition{
+			Type:               corev1.NodeReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now.Add(-5 * time.Minute)),
+		}).
+		Obj()
+	node6 := node.MakeNode("test6").
+		Label("cloud.provider.com/zone", "us-west-1").
+		StatusConditions(corev1.NodeCondition{
+			Type:               corev1.NodeReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Second)),
+		}).
+		Obj()
 
-	nodes := []corev1.Node{*node1, *node2, *node3, *node4}
+	nodes := []corev1.Node{*node1, *node2, *node3, *node4, *node5, *node6}
 
 	for i := range nodes {
 		nc.nodes[nodes[i].Name] = copyAndStripNode(&nodes[i])
 	}
 
 	testCases := map[string]struct {
-		nodeLabels map[string]string
-		levels     []string
-		wantNodes  []*corev1.Node
+		nodeLabels      map[string]string
+		levels          []string
+		minReadySeconds *int32
+		wantNodes       []*corev1.Node
 	}{
 		"no nodeLabels and levels": {
 			wantNodes: []*corev1.Node{
@@ -124,6 +145,8 @@ func TestNodesCacheFind(t *testing.T) {
 				copyAndStripNode(node2),
 				copyAndStripNode(node3),
 				copyAndStripNode(node4),
+				copyAndStripNode(node5),
+				copyAndStripNode(node6),
 			},
 		},
 		"match labels": {
@@ -139,10 +162,20 @@ func TestNodesCacheFind(t *testing.T) {
 			levels:     []string{"cloud.provider.com/topology-block"},
 			wantNodes:  []*corev1.Node{copyAndStripNode(node3)},
 		},
+		"match labels; ready for minReadySeconds": {
+			nodeLabels:      map[string]string{"cloud.provider.com/zone": "us-west-1"},
+			minReadySeconds: ptr.To[int32](60),
+			wantNodes:       []*corev1.Node{copyAndStripNode(node5)},
+		},
+		"match labels; zero minReadySeconds": {
+			nodeLabels:      map[string]string{"cloud.provider.com/zone": "us-west-1"},
+			minReadySeconds: ptr.To[int32](0),
+			wantNodes:       []*corev1.Node{copyAndStripNode(node5), copyAndStripNode(node6)},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			gotNodes := nc.find(tc.nodeLabels, tc.levels)
+			gotNodes := nc.find(tc.nodeLabels, tc.levels, nil, tc.minReadySeconds, now)
 			if diff := cmp.Diff(tc.wantNodes, gotNodes, cmpopts.SortSlices(func(a, b *corev1.Node) bool {
 				return a.Name < b.Name
 			})); diff != "" {
@@ -151,3 +184,55 @@ func TestNodesCacheFind(t *testing.T) {
 		})
 	}
 }
+
+func TestNodesCacheNextReady(t *testing.T) {
+	nc := newNodesCache()
+	now := time.Now()
+
+	readySince := func(name string, d time.Duration) *corev1.Node {
+		return node.MakeNode(name).
+			Label("cloud.provider.com/zone", "us-west-1").
+			StatusConditions(corev1.NodeCondition{
+				Type:               corev1.NodeReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(now.Add(-d)),
+			}).
+			Obj()
+	}
+	nodes := []*corev1.Node{
+		readySince("test1", 5*time.Minute),
+		readySince("test2", 10*time.Second),
+		readySince("test3", 40*time.Second),
+	}
+	for _, n := range nodes {
+		nc.nodes[n.Name] = copyAndStripNode(n)
+	}
+
+	testCases := map[string]struct {
+		nodeLabels      map[string]string
+		minReadySeconds int32
+		want            time.Duration
+	}{
+		"the soonest node to be ready long enough": {
+			nodeLabels:      map[string]string{"cloud.provider.com/zone": "us-west-1"},
+			minReadySeconds: 60,
+			want:            20 * time.Second,
+		},
+		"all nodes ready long enough": {
+			nodeLabels:      map[string]string{"cloud.provider.com/zone": "us-west-1"},
+			minReadySeconds: 5,
+		},
+		"no matching nodes": {
+			nodeLabels:      map[string]string{"cloud.provider.com/zone": "us-east-1"},
+			minReadySeconds: 60,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := nc.nextReady(tc.nodeLabels, nil, nil, tc.minReadySeconds, now)
+			if got != tc.want {
+				t.Errorf("Unexpected next ready, want: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
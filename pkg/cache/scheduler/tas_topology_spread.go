@@ -0,0 +1,305 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+)
+
+// topologySpreadConstraint is a topologySpreadConstraint of the pod template
+// resolved to a level of the topology.
+type topologySpreadConstraint struct {
+	levelIdx   int
+	maxSkew    int32
+	minDomains int32
+
+	// existingPods is the number of pods of the other admitted Workloads
+	// selected by the constraint, per domain at the level of the constraint.
+	existingPods map[utiltas.TopologyDomainID]int32
+}
+
+// topologySpreadConstraintForPodSet returns the first topologySpreadConstraint
+// of the PodSet's template which is enforced by kube-scheduler (DoNotSchedule),
+// selects the pods of the PodSet and whose topologyKey is a level of the topology.
+// It returns a reason when the constraint uses matchLabelKeys, or node
+// inclusion policies other than the defaults, which TAS doesn't support.
+func (s *TASFlavorSnapshot) topologySpreadConstraintForPodSet(ps *kueue.PodSet, wl *kueue.Workload) (*topologySpreadConstraint, string) {
+	podLabels := labels.Set(ps.Template.Labels)
+	for _, c := range ps.Template.Spec.TopologySpreadConstraints {
+		if c.WhenUnsatisfiable != corev1.DoNotSchedule || c.LabelSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+		if err != nil || !selector.Matches(podLabels) {
+			continue
+		}
+		levelIdx, found := s.resolveLevelIdx(c.TopologyKey)
+		if !found {
+			continue
+		}
+		if len(c.MatchLabelKeys) > 0 ||
+			ptr.Deref(c.NodeAffinityPolicy, corev1.NodeInclusionPolicyHonor) != corev1.NodeInclusionPolicyHonor ||
+			ptr.Deref(c.NodeTaintsPolicy, corev1.NodeInclusionPolicyIgnore) != corev1.NodeInclusionPolicyIgnore {
+			return nil, fmt.Sprintf("topology spread constraint on %s with matchLabelKeys, nodeAffinityPolicy or nodeTaintsPolicy is not supported",
+				c.TopologyKey)
+		}
+		return &topologySpreadConstraint{
+			levelIdx:     levelIdx,
+			maxSkew:      c.MaxSkew,
+			minDomains:   max(1, ptr.Deref(c.MinDomains, 0)),
+			existingPods: s.existingPodsPerDomain(wl, selector, levelIdx),
+		}, ""
+	}
+	return nil, ""
+}
+
+// existingPodsPerDomain returns the number of pods selected by the selector,
+// per domain at the level, among the pods of the other Workloads of the
+// namespace admitted with a TopologyAssignment on the flavor. The pods which
+// aren't assigned by TAS are unknown to the snapshot, hence not counted.
+func (s *TASFlavorSnapshot) existingPodsPerDomain(wl *kueue.Workload, selector labels.Selector, levelIdx int) map[utiltas.TopologyDomainID]int32 {
+	if wl == nil || s.admittedWorkloadsInNamespace == nil {
+		return nil
+	}
+	existingPods := make(map[utiltas.TopologyDomainID]int32)
+	for _, info := range s.admittedWorkloadsInNamespace(wl.Namespace) {
+		if info.Obj.Name == wl.Name {
+			continue
+		}
+		for _, ps := range info.Obj.Spec.PodSets {
+			if !selector.Matches(labels.Set(ps.Template.Labels)) {
+				continue
+			}
+			psa := findPSA(info.Obj, ps.Name)
+			if psa == nil || psa.TopologyAssignment == nil {
+				continue
+			}
+			ta := psa.TopologyAssignment
+			if len(ta.Levels) == 0 || len(ta.Levels) > len(s.levelKeys) ||
+				!slices.Equal(ta.Levels, s.levelKeys[len(s.levelKeys)-len(ta.Levels):]) {
+				continue
+			}
+			lowestLevelHostname := utiltas.IsLowestLevelHostname(ta.Levels)
+			for assignment := range utiltas.InternalSeqFrom(ta) {
+				leafID := utiltas.DomainID(assignment.Values)
+				if lowestLevelHostname {
+					leafID = utiltas.TopologyDomainID(assignment.Values[len(assignment.Values)-1])
+				}
+				leaf, found := s.leaves[leafID]
+				if !found {
+					continue
+				}
+				d := &leaf.domain
+				for range len(s.levelKeys) - 1 - levelIdx {
+					d = d.parent
+				}
+				existingPods[d.id] += assignment.Count
+			}
+		}
+	}
+	return existingPods
+}
+
+// applyTopologySpreadConstraint distributes the pods among the domains at the
+// level of the topologySpreadConstraint, so that the difference between the
+// number of matching pods in any two eligible domains doesn't exceed maxSkew.
+// Eligible domains are the domains which can fit at least one pod.
+//
+// It returns the domains with the assigned pods, the level of these domains
+// and whether the constraint was applied. When the constraint doesn't need to
+// be applied, as the request is required at the level of the constraint or
+// below, the assignment is left to the regular algorithm, provided the
+// constraint can be satisfied by placing all pods in any single domain.
+func (s *TASFlavorSnapshot) applyTopologySpreadConstraint(params *topologyAssignmentParameters, constraint *topologySpreadConstraint) ([]*domain, int, bool, string) {
+	spreadLevelDomains := slices.Collect(maps.Values(s.domainsPerLevel[constraint.levelIdx]))
+	if params.required && params.requestedLevelIdx >= constraint.levelIdx {
+		// All pods land in a single domain at the level of the constraint.
+		if !fitsSingleDomain(spreadLevelDomains, params.count, constraint) {
+			return nil, 0, false, fmt.Sprintf("topology spread constraint on %s with maxSkew %d can't be satisfied by the required topology %s",
+				s.levelKeys[constraint.levelIdx], constraint.maxSkew, s.levelKeys[params.requestedLevelIdx])
+		}
+		return nil, 0, false, ""
+	}
+
+	var candidateGroups [][]*domain
+	if params.required {
+		requestedLevelDomains := s.sortedDomains(slices.Collect(maps.Values(s.domainsPerLevel[params.requestedLevelIdx])), false)
+		for _, d := range requestedLevelDomains {
+			candidateGroups = append(candidateGroups, descendantsAtLevel(d, params.requestedLevelIdx, constraint.levelIdx))
+		}
+	} else {
+		candidateGroups = [][]*domain{spreadLevelDomains}
+	}
+
+	for _, group := range candidateGroups {
+		if result, ok := spreadPodsOnDomains(group, params.count, constraint); ok {
+			return result, constraint.levelIdx, true, ""
+		}
+	}
+	return nil, 0, true, fmt.Sprintf("topology spread constraint on %s with maxSkew %d can't be satisfied",
+		s.levelKeys[constraint.levelIdx], constraint.maxSkew)
+}
+
+// fitsSingleDomain returns whether the skew is within maxSkew wherever the
+// count pods land, when they all land in a single eligible domain.
+func fitsSingleDomain(domains []*domain, count int32, constraint *topologySpreadConstraint) bool {
+	eligible := eligibleDomains(domains)
+	if len(eligible) == 1 && constraint.minDomains <= 1 {
+		return true
+	}
+	var minExisting, maxExisting int32
+	for i, d := range eligible {
+		existing := constraint.existingPods[d.id]
+		if i == 0 || existing < minExisting {
+			minExisting = existing
+		}
+		maxExisting = max(maxExisting, existing)
+	}
+	// Following kube-scheduler, the global minimum is 0 when there are fewer
+	// eligible domains than minDomains.
+	if int32(len(eligible)) < constraint.minDomains {
+		minExisting = 0
+	}
+	return maxExisting+count-minExisting <= constraint.maxSkew
+}
+
+// spreadPodsOnDomains distributes count pods among the domains, placing every
+// pod in a domain with the fewest matching pods, including the existing ones,
+// which can still fit it. The ties are broken in favor of the domains with the
+// most capacity. It returns the domains with assigned pods and whether the
+// resulting skew is within maxSkew.
+func spreadPodsOnDomains(domains []*domain, count int32, constraint *topologySpreadConstraint) ([]*domain, bool) {
+	eligible := eligibleDomains(domains)
+	if len(eligible) == 0 {
+		return nil, false
+	}
+	slices.SortFunc(eligible, func(a, b *domain) int {
+		if a.state != b.state {
+			return int(a.state - b.state)
+		}
+		return compareDomainLevelValues(a, b)
+	})
+
+	existing := make([]int64, len(eligible))
+	for i, d := range eligible {
+		existing[i] = int64(constraint.existingPods[d.id])
+	}
+	// podsUpTo returns the number of pods placed when every domain is filled
+	// up to level matching pods, within its capacity.
+	podsUpTo := func(level int64) int64 {
+		var pods int64
+		for i, d := range eligible {
+			pods += min(max(level-existing[i], 0), int64(d.state))
+		}
+		return pods
+	}
+	var highest int64
+	for i, d := range eligible {
+		highest = max(highest, existing[i]+int64(d.state))
+	}
+	if podsUpTo(highest) < int64(count) {
+		return nil, false
+	}
+	// Find the lowest level at which all pods are placed.
+	low, high := slices.Min(existing), highest
+	for low < high {
+		mid := low + (high-low)/2
+		if podsUpTo(mid) >= int64(count) {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	assigned := make([]int32, len(eligible))
+	for i, d := range eligible {
+		assigned[i] = int32(min(max(low-existing[i], 0), int64(d.state)))
+	}
+	// Withdraw the pods placed in excess from the domains reaching the level,
+	// starting from the domains with the least capacity.
+	excess := podsUpTo(low) - int64(count)
+	for i := 0; i < len(eligible) && excess > 0; i++ {
+		if assigned[i] > 0 && existing[i]+int64(assigned[i]) == low {
+			assigned[i]--
+			excess--
+		}
+	}
+
+	// Following kube-scheduler, the global minimum is 0 when there are fewer
+	// eligible domains than minDomains.
+	var minTotal, maxTotal int64
+	for i := range eligible {
+		total := existing[i] + int64(assigned[i])
+		if i == 0 || total < minTotal {
+			minTotal = total
+		}
+		if assigned[i] > 0 {
+			maxTotal = max(maxTotal, total)
+		}
+	}
+	if int32(len(eligible)) < constraint.minDomains {
+		minTotal = 0
+	}
+	if maxTotal-minTotal > int64(constraint.maxSkew) {
+		return nil, false
+	}
+
+	result := make([]*domain, 0, len(eligible))
+	for i, d := range eligible {
+		if assigned[i] == 0 {
+			continue
+		}
+		d.state = assigned[i]
+		d.sliceState = assigned[i]
+		d.stateWithLeader = assigned[i]
+		d.sliceStateWithLeader = assigned[i]
+		d.leaderState = 0
+		result = append(result, d)
+	}
+	return result, true
+}
+
+func eligibleDomains(domains []*domain) []*domain {
+	eligible := make([]*domain, 0, len(domains))
+	for _, d := range domains {
+		if d.state > 0 {
+			eligible = append(eligible, d)
+		}
+	}
+	return eligible
+}
+
+func descendantsAtLevel(d *domain, levelIdx, targetLevelIdx int) []*domain {
+	result := []*domain{d}
+	for ; levelIdx < targetLevelIdx; levelIdx++ {
+		var next []*domain
+		for _, r := range result {
+			next = append(next, r.children...)
+		}
+		result = next
+	}
+	return result
+}
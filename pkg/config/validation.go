@@ -37,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/component-base/featuregate"
 	dracel "k8s.io/dynamic-resource-allocation/cel"
 	"k8s.io/utils/ptr"
@@ -55,6 +56,7 @@ var (
 	integrationsPath                      = field.NewPath("integrations")
 	integrationsFrameworksPath            = integrationsPath.Child("frameworks")
 	integrationsExternalFrameworkPath     = integrationsPath.Child("externalFrameworks")
+	integrationsPodLabelPropagationPath   = integrationsPath.Child("podLabelPropagation")
 	managedJobsNamespaceSelectorPath      = field.NewPath("managedJobsNamespaceSelector")
 	waitForPodsReadyPath                  = field.NewPath("waitForPodsReady")
 	requeuingStrategyPath                 = waitForPodsReadyPath.Child("requeuingStrategy")
@@ -62,6 +64,7 @@ var (
 	clusterProfileAccessProvidersPath     = multiKueuePath.Child("clusterProfile").Child("accessProviders")
 	clusterProfileCredentialProvidersPath = multiKueuePath.Child("clusterProfile").Child("credentialsProviders")
 	fsPreemptionStrategiesPath            = field.NewPath("fairSharing", "preemptionStrategies")
+	fsPreemptionThresholdPath             = field.NewPath("fairSharing", "preemptionThreshold")
 	afsResourceWeightsPath                = field.NewPath("admissionFairSharing", "resourceWeights")
 	afsPath                               = field.NewPath("admissionFairSharing")
 	internalCertManagementPath            = field.NewPath("internalCertManagement")
@@ -75,6 +78,10 @@ var (
 	visibilityServerBindPortPath          = field.NewPath("visibilityServer", "bindPort")
 	customLabelsPath                      = field.NewPath("metrics", "customLabels")
 	resourceQuotaCheckStrategyPath        = field.NewPath("resources", "quotaCheckStrategy")
+	schedulerWorkersPath                  = field.NewPath("scheduler", "workers")
+	defaultWorkloadPriorityClassPath      = field.NewPath("defaultWorkloadPriorityClass")
+	evictionHooksMaxTimeoutPath           = field.NewPath("evictionHooks", "maxTimeout")
+	leaderElectionPath                    = field.NewPath("leaderElection")
 	maxCustomLabels                       = 20
 	maxTrackedCustomLabelValues           = 16
 	maxTrackedWlCustomLabelValues         = 12
@@ -103,6 +110,10 @@ func Validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorLis
 	allErrs = append(allErrs, validateVisibilityServer(c)...)
 	allErrs = append(allErrs, validateCustomLabels(c)...)
 	allErrs = append(allErrs, validateQuotaCheckStrategy(c)...)
+	allErrs = append(allErrs, validateScheduler(c)...)
+	allErrs = append(allErrs, validateDefaultWorkloadPriorityClass(c)...)
+	allErrs = append(allErrs, validateEvictionHooks(c)...)
+	allErrs = append(allErrs, validateLeaderElection(c)...)
 	allErrs = append(allErrs, validateDRAFeatureGateDependencies()...)
 	allErrs = append(allErrs, validateFeatureGateDependency(features.UnadmittedWorkloadsExplicitStatus, features.UnadmittedWorkloadsObservability)...)
 	return allErrs
@@ -136,6 +147,69 @@ func validateQuotaCheckStrategy(c *configapi.Configuration) field.ErrorList {
 	return allErrs
 }
 
+func validateScheduler(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.Scheduler == nil {
+		return allErrs
+	}
+	if c.Scheduler.Workers != nil && *c.Scheduler.Workers < 1 {
+		allErrs = append(allErrs, field.Invalid(schedulerWorkersPath, *c.Scheduler.Workers, "must be greater than or equal to 1"))
+	}
+	return allErrs
+}
+
+func validateDefaultWorkloadPriorityClass(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.DefaultWorkloadPriorityClass == nil {
+		return allErrs
+	}
+	if errs := apimachineryutilvalidation.IsDNS1123Subdomain(*c.DefaultWorkloadPriorityClass); len(errs) != 0 {
+		allErrs = append(allErrs, field.Invalid(defaultWorkloadPriorityClassPath, *c.DefaultWorkloadPriorityClass, strings.Join(errs, ",")))
+	}
+	return allErrs
+}
+
+// validateLeaderElection checks the durations used by the leader election,
+// following the constraints of the client-go leader elector.
+func validateLeaderElection(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.LeaderElection == nil || !ptr.Deref(c.LeaderElection.LeaderElect, false) {
+		return allErrs
+	}
+	le := c.LeaderElection
+	if le.LeaseDuration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(leaderElectionPath.Child("leaseDuration"), le.LeaseDuration, "must be greater than zero"))
+	}
+	if le.RenewDeadline.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(leaderElectionPath.Child("renewDeadline"), le.RenewDeadline, "must be greater than zero"))
+	}
+	if le.RetryPeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(leaderElectionPath.Child("retryPeriod"), le.RetryPeriod, "must be greater than zero"))
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	if le.LeaseDuration.Duration <= le.RenewDeadline.Duration {
+		allErrs = append(allErrs, field.Invalid(leaderElectionPath.Child("leaseDuration"), le.LeaseDuration, "must be greater than renewDeadline"))
+	}
+	if float64(le.RenewDeadline.Duration) <= leaderelection.JitterFactor*float64(le.RetryPeriod.Duration) {
+		allErrs = append(allErrs, field.Invalid(leaderElectionPath.Child("renewDeadline"), le.RenewDeadline,
+			fmt.Sprintf("must be greater than retryPeriod*%.1f", leaderelection.JitterFactor)))
+	}
+	return allErrs
+}
+
+func validateEvictionHooks(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.EvictionHooks == nil || c.EvictionHooks.MaxTimeout == nil {
+		return allErrs
+	}
+	if c.EvictionHooks.MaxTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(evictionHooksMaxTimeoutPath, c.EvictionHooks.MaxTimeout, "must be greater than zero"))
+	}
+	return allErrs
+}
+
 func validateInternalCertManagement(c *configapi.Configuration) field.ErrorList {
 	var allErrs field.ErrorList
 	if c.InternalCertManagement == nil || !ptr.Deref(c.InternalCertManagement.Enable, false) {
@@ -343,6 +417,15 @@ func validateIntegrations(c *configapi.Configuration, scheme *runtime.Scheme) fi
 		}
 	}
 
+	if p := c.Integrations.PodLabelPropagation; p != nil {
+		for idx, key := range p.WorkloadLabelKeys {
+			allErrs = append(allErrs, validation.ValidateLabelName(key, integrationsPodLabelPropagationPath.Child("workloadLabelKeys").Index(idx))...)
+		}
+		for idx, key := range p.LocalQueueLabelKeys {
+			allErrs = append(allErrs, validation.ValidateLabelName(key, integrationsPodLabelPropagationPath.Child("localQueueLabelKeys").Index(idx))...)
+		}
+	}
+
 	allErrs = append(allErrs, validatePodIntegrationOptions(c)...)
 	return allErrs
 }
@@ -425,6 +508,9 @@ func validateFairSharing(c *configapi.Configuration) field.ErrorList {
 			allErrs = append(allErrs, field.NotSupported(fsPreemptionStrategiesPath, fs.PreemptionStrategies, validStrategySetsStr))
 		}
 	}
+	if fs.PreemptionThreshold != nil && *fs.PreemptionThreshold < 0 {
+		allErrs = append(allErrs, field.Invalid(fsPreemptionThresholdPath, *fs.PreemptionThreshold, apimachineryvalidation.IsNegativeErrorMsg))
+	}
 	return allErrs
 }
 
@@ -661,6 +747,8 @@ func LoadAndValidateFeatureGates(featureGateCLI string, featureGateMap map[strin
 	allErrs = append(allErrs, validateFeatureGateDependency(features.TASReplaceNodeOnNodeTaints, features.TopologyAwareScheduling)...)
 	allErrs = append(allErrs, validateFeatureGateDependency(features.TASMultiLayerTopology, features.TopologyAwareScheduling)...)
 	allErrs = append(allErrs, validateFeatureGateDependency(features.TASRespectNodeAffinityPreferred, features.TopologyAwareScheduling)...)
+	allErrs = append(allErrs, validateFeatureGateDependency(features.TASRespectTopologySpreadConstraints, features.TopologyAwareScheduling)...)
+	allErrs = append(allErrs, validateFeatureGateDependency(features.TASDerivedTopologyLevels, features.TopologyAwareScheduling)...)
 	allErrs = append(allErrs, validateFeatureGateDependency(features.UnadmittedWorkloadsExplicitStatus, features.UnadmittedWorkloadsObservability)...)
 
 	if features.Enabled(features.ElasticJobsViaWorkloadSlicesWithTAS) {
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
 
@@ -160,6 +161,40 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"valid integrations.podLabelPropagation": {
+			cfg: &configapi.Configuration{
+				Integrations: &configapi.Integrations{
+					Frameworks: []string{"batch/job"},
+					PodLabelPropagation: &configapi.PodLabelPropagation{
+						WorkloadLabelKeys:   []string{"example.com/cost-center"},
+						LocalQueueLabelKeys: []string{"team"},
+					},
+				},
+			},
+		},
+		"invalid integrations.podLabelPropagation label keys": {
+			cfg: &configapi.Configuration{
+				Integrations: &configapi.Integrations{
+					Frameworks: []string{"batch/job"},
+					PodLabelPropagation: &configapi.PodLabelPropagation{
+						WorkloadLabelKeys:   []string{"cost center"},
+						LocalQueueLabelKeys: []string{"-team"},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:   field.ErrorTypeInvalid,
+					Field:  "integrations.podLabelPropagation.workloadLabelKeys[0]",
+					Origin: "format=k8s-label-key",
+				},
+				&field.Error{
+					Type:   field.ErrorTypeInvalid,
+					Field:  "integrations.podLabelPropagation.localQueueLabelKeys[0]",
+					Origin: "format=k8s-label-key",
+				},
+			},
+		},
 		"nil managedJobsNamespaceSelector with pod framework": {
 			cfg: &configapi.Configuration{
 				Integrations: &configapi.Integrations{
@@ -766,6 +801,30 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"negative preemption threshold": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				FairSharing: &configapi.FairSharing{
+					PreemptionStrategies: []configapi.PreemptionStrategy{configapi.LessThanOrEqualToFinalShare, configapi.LessThanInitialShare},
+					PreemptionThreshold:  ptr.To[int32](-1),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "fairSharing.preemptionThreshold",
+				},
+			},
+		},
+		"valid preemption threshold": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				FairSharing: &configapi.FairSharing{
+					PreemptionStrategies: []configapi.PreemptionStrategy{configapi.LessThanOrEqualToFinalShare, configapi.LessThanInitialShare},
+					PreemptionThreshold:  ptr.To[int32](100),
+				},
+			},
+		},
 		"valid admissionFairSharing configuration": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
@@ -1221,6 +1280,132 @@ func TestValidate(t *testing.T) {
 				features.QuotaCheckStrategy: false,
 			},
 		},
+		"invalid .scheduler.workers": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.SchedulerConfiguration{
+					Workers: ptr.To[int32](0),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduler.workers",
+				},
+			},
+		},
+		"valid .scheduler.workers": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.SchedulerConfiguration{
+					Workers: ptr.To[int32](4),
+				},
+			},
+		},
+		"invalid .defaultWorkloadPriorityClass": {
+			cfg: &configapi.Configuration{
+				Integrations:                 defaultIntegrations,
+				DefaultWorkloadPriorityClass: ptr.To("Not_A_Name"),
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "defaultWorkloadPriorityClass",
+				},
+			},
+		},
+		"valid .defaultWorkloadPriorityClass": {
+			cfg: &configapi.Configuration{
+				Integrations:                 defaultIntegrations,
+				DefaultWorkloadPriorityClass: ptr.To("low-priority"),
+			},
+		},
+		"valid .leaderElection": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ControllerManager: configapi.ControllerManager{
+					LeaderElection: &configv1alpha1.LeaderElectionConfiguration{
+						LeaderElect:   ptr.To(true),
+						LeaseDuration: metav1.Duration{Duration: 6 * time.Second},
+						RenewDeadline: metav1.Duration{Duration: 4 * time.Second},
+						RetryPeriod:   metav1.Duration{Duration: time.Second},
+					},
+				},
+			},
+		},
+		"invalid .leaderElection durations": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ControllerManager: configapi.ControllerManager{
+					LeaderElection: &configv1alpha1.LeaderElectionConfiguration{
+						LeaderElect:   ptr.To(true),
+						LeaseDuration: metav1.Duration{Duration: 4 * time.Second},
+						RenewDeadline: metav1.Duration{Duration: 4 * time.Second},
+						RetryPeriod:   metav1.Duration{Duration: 4 * time.Second},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "leaderElection.leaseDuration",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "leaderElection.renewDeadline",
+				},
+			},
+		},
+		"non-positive .leaderElection.retryPeriod": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ControllerManager: configapi.ControllerManager{
+					LeaderElection: &configv1alpha1.LeaderElectionConfiguration{
+						LeaderElect:   ptr.To(true),
+						LeaseDuration: metav1.Duration{Duration: 15 * time.Second},
+						RenewDeadline: metav1.Duration{Duration: 10 * time.Second},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "leaderElection.retryPeriod",
+				},
+			},
+		},
+		"invalid .leaderElection durations are ignored without leader election": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ControllerManager: configapi.ControllerManager{
+					LeaderElection: &configv1alpha1.LeaderElectionConfiguration{
+						LeaderElect: ptr.To(false),
+					},
+				},
+			},
+		},
+		"invalid .evictionHooks.maxTimeout": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				EvictionHooks: &configapi.EvictionHooks{
+					MaxTimeout: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "evictionHooks.maxTimeout",
+				},
+			},
+		},
+		"valid .evictionHooks.maxTimeout": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				EvictionHooks: &configapi.EvictionHooks{
+					MaxTimeout: &metav1.Duration{Duration: 30 * time.Minute},
+				},
+			},
+		},
 		"KueueDRAIntegrationExtendedResource requires KueueDRAIntegration": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
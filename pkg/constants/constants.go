@@ -25,6 +25,7 @@ const (
 	MultiKueueName               = "multikueue"
 	JobControllerName            = KueueName + "-job-controller"
 	WorkloadControllerName       = KueueName + "-workload-controller"
+	ClusterQueueControllerName   = KueueName + "-clusterqueue-controller"
 	PodTerminationControllerName = KueueName + "-pod-termination-controller"
 	AdmissionName                = KueueName + "-admission"
 	ReclaimablePodsMgr           = KueueName + "-reclaimable-pods"
@@ -84,6 +85,22 @@ const (
 	// This annotation is beta-level and requires the AdmissionGatedBy feature gate, enabled by default.
 	AdmissionGatedByAnnotation = "kueue.x-k8s.io/admission-gated-by"
 
+	// HoldAnnotation is the annotation key for holding a Workload. When set to
+	// "true" on a Job, its Workload stays pending in the queue, keeping its
+	// position and priority aging, but Kueue doesn't admit it until the
+	// annotation is removed.
+	//
+	// This annotation is alpha-level and requires the WorkloadHold feature gate.
+	HoldAnnotation = "kueue.x-k8s.io/hold"
+
+	// NodeShareAnnotation is the pod template annotation key for requesting a
+	// share of a node of the assigned ResourceFlavor, as a percentage, for
+	// example "100%" for a whole node. The scheduler resolves the requests of
+	// the pods against the nodeShape of the assigned ResourceFlavor.
+	//
+	// This annotation is alpha-level and requires the NodeShareRequests feature gate.
+	NodeShareAnnotation = "kueue.x-k8s.io/node-share"
+
 	// ElasticJobAnnotation is an annotation set on the Job to indicate that it is an elastic job.
 	ElasticJobAnnotation = "kueue.x-k8s.io/elastic-job"
 )
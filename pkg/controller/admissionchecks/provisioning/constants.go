@@ -20,4 +20,10 @@ const (
 	ConfigKind           = "ProvisioningRequestConfig"
 	CheckInactiveMessage = "the check is not active"
 	NoRequestNeeded      = "the provisioning request is not needed"
+
+	// ConfigLabelKey is the label key of the ProvisioningRequests holding the
+	// name of the ProvisioningRequestConfig they are created for.
+	ConfigLabelKey = "kueue.x-k8s.io/provisioning-request-config"
+
+	WaitingForConcurrentRequestsMessage = "waiting for the number of concurrent provisioning requests to drop below the limit"
 )
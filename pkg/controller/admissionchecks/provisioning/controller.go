@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"sync"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -86,6 +87,8 @@ type Controller struct {
 	helper      *provisioningConfigHelper
 	clock       clock.Clock
 	roleTracker *roletracker.RoleTracker
+
+	concurrentRequests *concurrentRequests
 }
 
 type workloadInfo struct {
@@ -114,6 +117,8 @@ func NewController(client client.Client, record events.EventRecorder, roleTracke
 		helper:      helper,
 		clock:       realClock,
 		roleTracker: roleTracker,
+
+		concurrentRequests: newConcurrentRequests(),
 	}, nil
 }
 
@@ -289,60 +294,24 @@ func (c *Controller) syncOwnedProvisionRequest(
 		}
 		requestName := ProvisioningRequestName(wl.Name, checkName, attempt)
 		if shouldCreatePr {
-			log.V(3).Info("Creating ProvisioningRequest", "requestName", requestName, "attempt", attempt)
-			req = &autoscaling.ProvisioningRequest{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      requestName,
-					Namespace: wl.Namespace,
-					Labels: map[string]string{
-						constants.ManagedByKueueLabelKey: constants.ManagedByKueueLabelValue,
-					},
-				},
-				Spec: autoscaling.ProvisioningRequestSpec{
-					ProvisioningClassName: prc.Spec.ProvisioningClassName,
-					Parameters:            parametersKueueToProvisioning(prc.Spec.Parameters),
-				},
-			}
-			passProvReqParams(wl, req)
-
-			mergedPodSets, err := mergePodSets(wl, &prc.Spec)
+			requestKey := types.NamespacedName{Namespace: wl.Namespace, Name: requestName}
+			reserved, err := c.reserveConcurrentRequest(ctx, prc, requestKey)
 			if err != nil {
 				return err
 			}
-
-			for _, mergedPodSet := range mergedPodSets {
-				ptName := getProvisioningRequestPodTemplateName(requestName, mergedPodSet.Name)
-
-				pt := &corev1.PodTemplate{}
-				err := c.client.Get(ctx, types.NamespacedName{Namespace: wl.Namespace, Name: ptName}, pt)
-				if client.IgnoreNotFound(err) != nil {
+			if !reserved {
+				log.V(3).Info("Waiting for the concurrent ProvisioningRequests limit", "requestName", requestName, "provisioningRequestConfig", klog.KObj(prc))
+				if err := c.setCheckMessage(ctx, wl, ac, WaitingForConcurrentRequestsMessage); err != nil {
 					return err
 				}
-				if err != nil {
-					// it's a not found, so create it
-					_, err := c.createPodTemplate(ctx, wl, ptName, mergedPodSet.PodSet, mergedPodSet.PodSetAssignment)
-					if err != nil {
-						msg := fmt.Sprintf("Error creating PodTemplate %q: %v", ptName, err)
-						return c.handleError(ctx, wl, ac, msg, err)
-					}
-				}
-
-				req.Spec.PodSets = append(req.Spec.PodSets, autoscaling.PodSet{
-					PodTemplateRef: autoscaling.Reference{
-						Name: ptName,
-					},
-					Count: mergedPodSet.Count,
-				})
+				continue
 			}
-
-			if err := ctrl.SetControllerReference(wl, req, c.client.Scheme()); err != nil {
+			log.V(3).Info("Creating ProvisioningRequest", "requestName", requestName, "attempt", attempt)
+			req, err = c.createProvisioningRequest(ctx, wl, ac, prc, requestName)
+			if err != nil {
+				c.concurrentRequests.release(prc.Name, requestKey)
 				return err
 			}
-
-			if err := c.client.Create(ctx, req); err != nil {
-				msg := fmt.Sprintf("Error creating ProvisioningRequest %q: %v", requestName, err)
-				return c.handleError(ctx, wl, ac, msg, err)
-			}
 			c.record.Eventf(wl, nil, corev1.EventTypeNormal, "ProvisioningRequestCreated", "Created", "Created ProvisioningRequest: %q", req.Name)
 			activeOrLastPRForChecks[checkName] = req
 		}
@@ -353,6 +322,130 @@ func (c *Controller) syncOwnedProvisionRequest(
 	return nil
 }
 
+func (c *Controller) createProvisioningRequest(ctx context.Context, wl *kueue.Workload, ac *kueue.AdmissionCheckState, prc *kueue.ProvisioningRequestConfig, requestName string) (*autoscaling.ProvisioningRequest, error) {
+	req := &autoscaling.ProvisioningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      requestName,
+			Namespace: wl.Namespace,
+			Labels: map[string]string{
+				constants.ManagedByKueueLabelKey: constants.ManagedByKueueLabelValue,
+			},
+		},
+		Spec: autoscaling.ProvisioningRequestSpec{
+			ProvisioningClassName: prc.Spec.ProvisioningClassName,
+			Parameters:            parametersKueueToProvisioning(prc.Spec.Parameters),
+		},
+	}
+	if features.Enabled(features.ProvisioningRequestMaxConcurrentRequests) {
+		req.Labels[ConfigLabelKey] = prc.Name
+	}
+	passProvReqParams(wl, req)
+
+	mergedPodSets, err := mergePodSets(wl, &prc.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mergedPodSet := range mergedPodSets {
+		ptName := getProvisioningRequestPodTemplateName(requestName, mergedPodSet.Name)
+
+		pt := &corev1.PodTemplate{}
+		err := c.client.Get(ctx, types.NamespacedName{Namespace: wl.Namespace, Name: ptName}, pt)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		if err != nil {
+			// it's a not found, so create it
+			_, err := c.createPodTemplate(ctx, wl, ptName, mergedPodSet.PodSet, mergedPodSet.PodSetAssignment)
+			if err != nil {
+				msg := fmt.Sprintf("Error creating PodTemplate %q: %v", ptName, err)
+				return nil, c.handleError(ctx, wl, ac, msg, err)
+			}
+		}
+
+		req.Spec.PodSets = append(req.Spec.PodSets, autoscaling.PodSet{
+			PodTemplateRef: autoscaling.Reference{
+				Name: ptName,
+			},
+			Count: mergedPodSet.Count,
+		})
+	}
+
+	if err := ctrl.SetControllerReference(wl, req, c.client.Scheme()); err != nil {
+		return nil, err
+	}
+
+	if err := c.client.Create(ctx, req); err != nil {
+		msg := fmt.Sprintf("Error creating ProvisioningRequest %q: %v", requestName, err)
+		return nil, c.handleError(ctx, wl, ac, msg, err)
+	}
+	return req, nil
+}
+
+// concurrentRequests tracks, per ProvisioningRequestConfig, the ProvisioningRequests
+// created by the controller which are not observed in the cache yet, so that they
+// count against the maxConcurrentRequests of the config in the meantime.
+type concurrentRequests struct {
+	sync.Mutex
+	created map[string]sets.Set[types.NamespacedName]
+}
+
+func newConcurrentRequests() *concurrentRequests {
+	return &concurrentRequests{
+		created: make(map[string]sets.Set[types.NamespacedName]),
+	}
+}
+
+// release stops counting the request against the limit of the config.
+func (r *concurrentRequests) release(config string, key types.NamespacedName) {
+	r.Lock()
+	defer r.Unlock()
+	if created, found := r.created[config]; found {
+		created.Delete(key)
+		if created.Len() == 0 {
+			delete(r.created, config)
+		}
+	}
+}
+
+// reserveConcurrentRequest returns whether a ProvisioningRequest can be created
+// for the config, without exceeding its maxConcurrentRequests. The reserved
+// request counts against the limit until it's observed in the cache.
+func (c *Controller) reserveConcurrentRequest(ctx context.Context, prc *kueue.ProvisioningRequestConfig, key types.NamespacedName) (bool, error) {
+	if !features.Enabled(features.ProvisioningRequestMaxConcurrentRequests) || prc.Spec.MaxConcurrentRequests == nil {
+		return true, nil
+	}
+	c.concurrentRequests.Lock()
+	defer c.concurrentRequests.Unlock()
+	waiting := &autoscaling.ProvisioningRequestList{}
+	if err := c.client.List(ctx, waiting, client.MatchingFields{RequestsWaitingForConfigKey: prc.Name}); err != nil {
+		return false, err
+	}
+	created := c.concurrentRequests.created[prc.Name]
+	for i := range waiting.Items {
+		created.Delete(client.ObjectKeyFromObject(&waiting.Items[i]))
+	}
+	if int32(len(waiting.Items)+created.Len()) >= *prc.Spec.MaxConcurrentRequests {
+		return false, nil
+	}
+	if created == nil {
+		created = sets.New[types.NamespacedName]()
+		c.concurrentRequests.created[prc.Name] = created
+	}
+	created.Insert(key)
+	return true, nil
+}
+
+func (c *Controller) setCheckMessage(ctx context.Context, wl *kueue.Workload, ac *kueue.AdmissionCheckState, msg string) error {
+	if ac == nil || ac.Message == msg {
+		return nil
+	}
+	return workloadpatching.PatchStatus(ctx, c.client, wl, kueue.ProvisioningRequestControllerName, func(wl *kueue.Workload) (bool, error) {
+		ac.Message = msg
+		return workloadpatching.SetAdmissionCheckState(&wl.Status.AdmissionChecks, *ac, c.clock), nil
+	})
+}
+
 func (c *Controller) handleError(ctx context.Context, wl *kueue.Workload, ac *kueue.AdmissionCheckState, msg string, err error) error {
 	c.record.Eventf(wl, nil, corev1.EventTypeWarning, "FailedCreate", "FailedCreate", api.TruncateEventMessage(msg))
 	patchErr := workloadpatching.PatchStatus(ctx, c.client, wl, kueue.ProvisioningRequestControllerName, func(wl *kueue.Workload) (bool, error) {
@@ -490,6 +583,16 @@ func containerUses(cont *corev1.Container, resourceSet sets.Set[corev1.ResourceN
 	return false
 }
 
+// retryLimitReachedMessage builds the message of a check rejected because the
+// last ProvisioningRequest attempt allowed by the retry strategy didn't succeed.
+func retryLimitReachedMessage(failure string, attempt, backoffLimitCount int32, message string) string {
+	msg := fmt.Sprintf("%s on attempt %d of %d, no retries left", failure, attempt, backoffLimitCount+1)
+	if message != "" {
+		msg += ": " + message
+	}
+	return msg
+}
+
 func updateCheckMessage(checkState *kueue.AdmissionCheckState, message string) bool {
 	if message == "" || checkState.Message == message {
 		return false
@@ -578,7 +681,7 @@ func (c *Controller) syncCheckStates(
 					} else {
 						updated = true
 						checkState.State = kueue.CheckStateRejected
-						checkState.Message = apimeta.FindStatusCondition(pr.Status.Conditions, autoscaling.Failed).Message
+						checkState.Message = retryLimitReachedMessage("Failed", attempt, backoffLimitCount, apimeta.FindStatusCondition(pr.Status.Conditions, autoscaling.Failed).Message)
 					}
 				case isCapacityRevoked(pr):
 					if workload.IsActive(wl) && !workloadfinish.IsFinished(wl) {
@@ -602,7 +705,7 @@ func (c *Controller) syncCheckStates(
 						} else {
 							updated = true
 							checkState.State = kueue.CheckStateRejected
-							checkState.Message = apimeta.FindStatusCondition(pr.Status.Conditions, autoscaling.BookingExpired).Message
+							checkState.Message = retryLimitReachedMessage("Booking expired", attempt, backoffLimitCount, apimeta.FindStatusCondition(pr.Status.Conditions, autoscaling.BookingExpired).Message)
 						}
 					}
 				case isProvisioned(pr):
@@ -776,7 +879,8 @@ func (p *prcHandler) Update(ctx context.Context, event event.UpdateEvent, q work
 	}
 
 	if oldPRC.Spec.ProvisioningClassName != newPRC.Spec.ProvisioningClassName || !maps.Equal(oldPRC.Spec.Parameters, newPRC.Spec.Parameters) ||
-		!slices.CmpNoOrder(oldPRC.Spec.ManagedResources, newPRC.Spec.ManagedResources) {
+		!slices.CmpNoOrder(oldPRC.Spec.ManagedResources, newPRC.Spec.ManagedResources) ||
+		!ptr.Equal(oldPRC.Spec.MaxConcurrentRequests, newPRC.Spec.MaxConcurrentRequests) {
 		err := p.reconcileWorkloadsUsing(ctx, oldPRC.Name, q)
 		if err != nil {
 			ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on update event", "provisioningRequestConfig", klog.KObj(oldPRC))
@@ -822,6 +926,66 @@ func (p *prcHandler) reconcileWorkloadsUsing(ctx context.Context, config string,
 	return nil
 }
 
+// prHandler reconciles the workloads that may wait for the concurrent
+// ProvisioningRequests limit of a config when one of its requests is no longer
+// waiting for provisioning.
+type prHandler struct {
+	concurrentRequests *concurrentRequests
+	prcHandler         *prcHandler
+}
+
+var _ handler.EventHandler = (*prHandler)(nil)
+
+func (h *prHandler) Create(_ context.Context, event event.CreateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// a new request doesn't release the limit, but it's now counted from the cache
+	if config, found := event.Object.GetLabels()[ConfigLabelKey]; found {
+		h.concurrentRequests.release(config, client.ObjectKeyFromObject(event.Object))
+	}
+}
+
+func (h *prHandler) Update(ctx context.Context, event event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldPR, isOldPR := event.ObjectOld.(*autoscaling.ProvisioningRequest)
+	newPR, isNewPR := event.ObjectNew.(*autoscaling.ProvisioningRequest)
+	if !isOldPR || !isNewPR {
+		return
+	}
+	if isWaitingForProvisioning(oldPR) && !isWaitingForProvisioning(newPR) {
+		if err := h.reconcileWorkloadsSharingLimit(ctx, newPR, q); err != nil {
+			ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on update event", "provisioningRequest", klog.KObj(newPR))
+		}
+	}
+}
+
+func (h *prHandler) Delete(ctx context.Context, event event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	pr, isPR := event.Object.(*autoscaling.ProvisioningRequest)
+	if !isPR {
+		return
+	}
+	if config, found := pr.Labels[ConfigLabelKey]; found {
+		h.concurrentRequests.release(config, client.ObjectKeyFromObject(pr))
+	}
+	if isWaitingForProvisioning(pr) {
+		if err := h.reconcileWorkloadsSharingLimit(ctx, pr, q); err != nil {
+			ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on delete event", "provisioningRequest", klog.KObj(pr))
+		}
+	}
+}
+
+func (h *prHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do for now
+}
+
+func (h *prHandler) reconcileWorkloadsSharingLimit(ctx context.Context, pr *autoscaling.ProvisioningRequest, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	if !features.Enabled(features.ProvisioningRequestMaxConcurrentRequests) {
+		return nil
+	}
+	config, found := pr.Labels[ConfigLabelKey]
+	if !found {
+		return nil
+	}
+	return h.prcHandler.reconcileWorkloadsUsing(ctx, config, q)
+}
+
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	ach := &acHandler{
 		client: c.client,
@@ -830,10 +994,15 @@ func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 		client:            c.client,
 		acHandlerOverride: ach.reconcileWorkloadsUsing,
 	}
+	prh := &prHandler{
+		concurrentRequests: c.concurrentRequests,
+		prcHandler:         prch,
+	}
 	err := ctrl.NewControllerManagedBy(mgr).
 		Named("provisioning_workload").
 		For(&kueue.Workload{}).
 		Owns(&autoscaling.ProvisioningRequest{}).
+		Watches(&autoscaling.ProvisioningRequest{}, prh).
 		Watches(&kueue.AdmissionCheck{}, ach).
 		Watches(&kueue.ProvisioningRequestConfig{}, prch).
 		WithOptions(controller.Options{
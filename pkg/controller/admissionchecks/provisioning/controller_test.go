@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"testing"
 	"time"
 
@@ -38,6 +39,7 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
@@ -89,6 +91,20 @@ func requestWithConditions(r *autoscaling.ProvisioningRequest, conditions []meta
 	return r
 }
 
+func requestWithOwner(r *autoscaling.ProvisioningRequest, name, owner string) *autoscaling.ProvisioningRequest {
+	r = r.DeepCopy()
+	r.Name = name
+	r.OwnerReferences = []metav1.OwnerReference{{Name: owner}}
+	return r
+}
+
+func requestWithConfigLabel(r *autoscaling.ProvisioningRequest, config string) *autoscaling.ProvisioningRequest {
+	r = r.DeepCopy()
+	r.Labels = maps.Clone(r.Labels)
+	r.Labels[ConfigLabelKey] = config
+	return r
+}
+
 func requestWithCondition(r *autoscaling.ProvisioningRequest, conditionType string, status metav1.ConditionStatus) *autoscaling.ProvisioningRequest {
 	r = r.DeepCopy()
 	apimeta.SetStatusCondition(&r.Status.Conditions, metav1.Condition{
@@ -311,6 +327,7 @@ func TestReconcile(t *testing.T) {
 		configs              []kueue.ProvisioningRequestConfig
 		flavors              []kueue.ResourceFlavor
 		workload             *kueue.Workload
+		otherWorkloads       []kueue.Workload
 		featureGates         map[featuregate.Feature]bool
 		wantReconcileError   error
 		wantWorkloads        map[string]*kueue.Workload
@@ -377,6 +394,86 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		"when the concurrent requests limit is reached; don't create the request": {
+			workload: baseWorkload.DeepCopy(),
+			otherWorkloads: []kueue.Workload{
+				*baseWorkload.Clone().Name("wl2").Obj(),
+			},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithConfigLabel(requestWithOwner(baseRequest, "wl2-check1-1", "wl2"), "config1"),
+			},
+			checks:       []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:      []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs:      []kueue.ProvisioningRequestConfig{*baseConfigWithRetryStrategy.Clone().MaxConcurrentRequests(1).Obj()},
+			featureGates: map[featuregate.Feature]bool{features.ProvisioningRequestMaxConcurrentRequests: true},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.GetName(): baseWorkload.Clone().
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStatePending,
+						Message: WaitingForConcurrentRequestsMessage,
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequestsNotFound: []string{baseRequest.Name},
+		},
+		"when the other request of the config is provisioned; create the request": {
+			workload: baseWorkload.DeepCopy(),
+			otherWorkloads: []kueue.Workload{
+				*baseWorkload.Clone().Name("wl2").Obj(),
+			},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithCondition(requestWithConfigLabel(requestWithOwner(baseRequest, "wl2-check1-1", "wl2"), "config1"), autoscaling.Provisioned, metav1.ConditionTrue),
+			},
+			checks:       []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:      []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs:      []kueue.ProvisioningRequestConfig{*baseConfigWithRetryStrategy.Clone().MaxConcurrentRequests(1).Obj()},
+			featureGates: map[featuregate.Feature]bool{features.ProvisioningRequestMaxConcurrentRequests: true},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.GetName(): baseWorkload.DeepCopy(),
+			},
+			wantRequests: map[string]*autoscaling.ProvisioningRequest{
+				baseRequest.Name: requestWithConfigLabel(baseRequest, "config1"),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       client.ObjectKeyFromObject(baseWorkload),
+					EventType: corev1.EventTypeNormal,
+					Reason:    "ProvisioningRequestCreated",
+					Message:   `Created ProvisioningRequest: "wl-check1-1"`,
+				},
+			},
+		},
+		"when the concurrent requests limit is reached, but the feature gate is disabled; create the request": {
+			workload: baseWorkload.DeepCopy(),
+			otherWorkloads: []kueue.Workload{
+				*baseWorkload.Clone().Name("wl2").Obj(),
+			},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithOwner(baseRequest, "wl2-check1-1", "wl2"),
+			},
+			checks:       []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:      []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs:      []kueue.ProvisioningRequestConfig{*baseConfigWithRetryStrategy.Clone().MaxConcurrentRequests(1).Obj()},
+			featureGates: map[featuregate.Feature]bool{features.ProvisioningRequestMaxConcurrentRequests: false},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.GetName(): baseWorkload.DeepCopy(),
+			},
+			wantRequests: map[string]*autoscaling.ProvisioningRequest{
+				baseRequest.Name: baseRequest.DeepCopy(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       client.ObjectKeyFromObject(baseWorkload),
+					EventType: corev1.EventTypeNormal,
+					Reason:    "ProvisioningRequestCreated",
+					Message:   `Created ProvisioningRequest: "wl-check1-1"`,
+				},
+			},
+		},
 		"workload with provreq annotation": {
 			workload: utiltestingapi.MakeWorkload("wl", TestNamespace).
 				Annotations(map[string]string{
@@ -722,7 +819,7 @@ func TestReconcile(t *testing.T) {
 					AdmissionChecks(kueue.AdmissionCheckState{
 						Name:    "check1",
 						State:   kueue.CheckStateRejected,
-						Message: "By test",
+						Message: "Failed on attempt 1 of 1, no retries left: By test",
 					}, kueue.AdmissionCheckState{
 						Name:  "not-provisioning",
 						State: kueue.CheckStatePending,
@@ -734,7 +831,51 @@ func TestReconcile(t *testing.T) {
 					Key:       client.ObjectKeyFromObject(baseWorkload),
 					EventType: corev1.EventTypeNormal,
 					Reason:    "AdmissionCheckUpdated",
-					Message:   `Admission check check1 updated state from Pending to Rejected with message: By test`,
+					Message:   `Admission check check1 updated state from Pending to Rejected with message: Failed on attempt 1 of 1, no retries left: By test`,
+				},
+			},
+		},
+		"when request fails on the last allowed attempt": {
+			workload: (&utiltestingapi.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:       "check1",
+					State:      kueue.CheckStatePending,
+					RetryCount: ptr.To[int32](2),
+				}, kueue.AdmissionCheckState{
+					Name:  "not-provisioning",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			checks:  []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors: []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs: []kueue.ProvisioningRequestConfig{*baseConfigWithRetryStrategy.Clone().RetryLimit(2).Obj()},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithCondition(func() *autoscaling.ProvisioningRequest {
+					r := baseRequest.DeepCopy()
+					r.Name = "wl-check1-3"
+					return r
+				}(), autoscaling.Failed, metav1.ConditionTrue),
+			},
+			templates: []corev1.PodTemplate{*baseTemplate1.DeepCopy(), *baseTemplate2.DeepCopy()},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.GetName(): (&utiltestingapi.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:       "check1",
+						State:      kueue.CheckStateRejected,
+						Message:    "Failed on attempt 3 of 3, no retries left: By test",
+						RetryCount: ptr.To[int32](2),
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       client.ObjectKeyFromObject(baseWorkload),
+					EventType: corev1.EventTypeNormal,
+					Reason:    "AdmissionCheckUpdated",
+					Message:   `Admission check check1 updated state from Pending to Rejected with message: Failed on attempt 3 of 3, no retries left: By test`,
 				},
 			},
 		},
@@ -1223,8 +1364,9 @@ func TestReconcile(t *testing.T) {
 			wantWorkloads: map[string]*kueue.Workload{
 				baseWorkload.GetName(): (&utiltestingapi.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
 					AdmissionChecks(kueue.AdmissionCheckState{
-						Name:  "check1",
-						State: kueue.CheckStateRejected,
+						Name:    "check1",
+						State:   kueue.CheckStateRejected,
+						Message: "Booking expired on attempt 1 of 1, no retries left",
 					}, kueue.AdmissionCheckState{
 						Name:  "not-provisioning",
 						State: kueue.CheckStatePending,
@@ -1237,7 +1379,7 @@ func TestReconcile(t *testing.T) {
 					Key:       client.ObjectKeyFromObject(baseWorkload),
 					EventType: corev1.EventTypeNormal,
 					Reason:    "AdmissionCheckUpdated",
-					Message:   `Admission check check1 updated state from Pending to Rejected`,
+					Message:   `Admission check check1 updated state from Pending to Rejected with message: Booking expired on attempt 1 of 1, no retries left`,
 				},
 			},
 		},
@@ -1801,6 +1943,7 @@ func TestReconcile(t *testing.T) {
 				builder = builder.WithObjects(tc.workload)
 				builder = builder.WithStatusSubresource(tc.workload)
 				builder = builder.WithLists(
+					&kueue.WorkloadList{Items: tc.otherWorkloads},
 					&autoscaling.ProvisioningRequestList{Items: tc.requests},
 					&corev1.PodTemplateList{Items: tc.templates},
 					&kueue.ProvisioningRequestConfigList{Items: tc.configs},
@@ -1884,6 +2027,52 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReserveConcurrentRequest(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ProvisioningRequestMaxConcurrentRequests, true)
+	ctx, _ := utiltesting.ContextWithLog(t)
+	builder, ctx := getClientBuilder(ctx)
+	k8sclient := builder.Build()
+	controller, err := NewController(k8sclient, &utiltesting.EventRecorder{}, nil)
+	if err != nil {
+		t.Fatalf("Setting up the provisioning request controller: %v", err)
+	}
+	handler := &prHandler{concurrentRequests: controller.concurrentRequests}
+	prc := utiltestingapi.MakeProvisioningRequestConfig("config1").ProvisioningClass("class1").MaxConcurrentRequests(1).Obj()
+	request := &autoscaling.ProvisioningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: TestNamespace,
+			Name:      "wl1-check1-1",
+			Labels:    map[string]string{ConfigLabelKey: prc.Name},
+		},
+	}
+	reserve := func(name string, want bool) {
+		t.Helper()
+		got, err := controller.reserveConcurrentRequest(ctx, prc, types.NamespacedName{Namespace: TestNamespace, Name: name})
+		if err != nil {
+			t.Fatalf("Unexpected error reserving the request %q: %v", name, err)
+		}
+		if got != want {
+			t.Errorf("Unexpected reservation of the request %q, want %v, got %v", name, want, got)
+		}
+	}
+
+	reserve(request.Name, true)
+	// The created request isn't observed in the cache yet.
+	reserve("wl2-check1-1", false)
+
+	if err := k8sclient.Create(ctx, request); err != nil {
+		t.Fatalf("Creating the request: %v", err)
+	}
+	handler.Create(ctx, event.CreateEvent{Object: request}, nil)
+	reserve("wl2-check1-1", false)
+
+	request = requestWithCondition(request, autoscaling.Provisioned, metav1.ConditionTrue)
+	if err := k8sclient.Update(ctx, request); err != nil {
+		t.Fatalf("Updating the request: %v", err)
+	}
+	reserve("wl2-check1-1", true)
+}
+
 func TestActiveOrLastPRForChecks(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
 	baseWorkload := utiltestingapi.MakeWorkload("wl", TestNamespace).
@@ -35,6 +35,7 @@ const (
 	RequestsOwnedByWorkloadKey     = "metadata.ownedByWorkload"
 	WorkloadsWithAdmissionCheckKey = "status.admissionChecks"
 	AdmissionCheckUsingConfigKey   = "spec.provisioningRequestConfig"
+	RequestsWaitingForConfigKey    = "status.waitingForProvisioningRequestConfig"
 )
 
 var (
@@ -49,11 +50,29 @@ func indexRequestsOwner(obj client.Object) []string {
 	return slices.Map(refs, func(r *metav1.OwnerReference) string { return r.Name })
 }
 
+// indexRequestsWaitingForConfig indexes the requests waiting for provisioning
+// by the ProvisioningRequestConfig they are created for.
+func indexRequestsWaitingForConfig(obj client.Object) []string {
+	pr, isPR := obj.(*autoscaling.ProvisioningRequest)
+	if !isPR {
+		return nil
+	}
+	config, found := pr.Labels[ConfigLabelKey]
+	if !found || !isWaitingForProvisioning(pr) {
+		return nil
+	}
+	return []string{config}
+}
+
 func SetupIndexer(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &autoscaling.ProvisioningRequest{}, RequestsOwnedByWorkloadKey, indexRequestsOwner); err != nil {
 		return fmt.Errorf("setting index on provisionRequest owner: %w", err)
 	}
 
+	if err := indexer.IndexField(ctx, &autoscaling.ProvisioningRequest{}, RequestsWaitingForConfigKey, indexRequestsWaitingForConfig); err != nil {
+		return fmt.Errorf("setting index on provisionRequest waiting for config: %w", err)
+	}
+
 	if err := indexer.IndexField(ctx, &kueue.AdmissionCheck{}, AdmissionCheckUsingConfigKey, admissioncheck.IndexerByConfigFunction(kueue.ProvisioningRequestControllerName, configGVK)); err != nil {
 		return fmt.Errorf("setting index on admission checks config: %w", err)
 	}
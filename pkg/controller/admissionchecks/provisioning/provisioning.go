@@ -52,6 +52,11 @@ func isCapacityRevoked(pr *autoscaling.ProvisioningRequest) bool {
 	return apimeta.IsStatusConditionTrue(pr.Status.Conditions, autoscaling.CapacityRevoked)
 }
 
+// isWaitingForProvisioning returns whether the request is neither provisioned nor failed yet.
+func isWaitingForProvisioning(pr *autoscaling.ProvisioningRequest) bool {
+	return !isProvisioned(pr) && !isFailed(pr) && !isBookingExpired(pr) && !isCapacityRevoked(pr)
+}
+
 func ProvisioningRequestName(workloadName string, checkName kueue.AdmissionCheckReference, attempt int32) string {
 	fullName := fmt.Sprintf("%s-%s-%d", workloadName, checkName, int(attempt))
 	return limitObjectName(fullName)
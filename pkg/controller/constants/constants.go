@@ -58,6 +58,54 @@ const (
 	// MaxExecTimeSecondsLabel is the label key in the job that holds the maximum execution time.
 	MaxExecTimeSecondsLabel = `kueue.x-k8s.io/max-exec-time-seconds`
 
+	// FallbackQueueAnnotation is the annotation key in the job that holds the name
+	// of the LocalQueue, in the same namespace, the job is moved to when its workload
+	// stays pending for longer than the FallbackQueueAfterAnnotation duration.
+	FallbackQueueAnnotation = "kueue.x-k8s.io/fallback-queue-name"
+
+	// FallbackQueueAfterAnnotation is the annotation key in the job that holds the
+	// duration (e.g. "30m") after which a pending job is moved to its fallback queue.
+	FallbackQueueAfterAnnotation = "kueue.x-k8s.io/fallback-queue-after"
+
+	// CandidateQueuesAnnotation is the annotation key in the job that holds a
+	// comma-separated, prioritized list of LocalQueues, in the same namespace, the
+	// job can be admitted in. The job is moved to the next LocalQueue of the list
+	// when its workload can't be admitted in the current one.
+	CandidateQueuesAnnotation = "kueue.x-k8s.io/candidate-queue-names"
+
+	// PodsCreationTimeoutAnnotation is the annotation key in the job that holds the
+	// duration (e.g. "10m") after the admission within which the job must have
+	// running pods. Otherwise, the quota reserved for its workload is released.
+	PodsCreationTimeoutAnnotation = "kueue.x-k8s.io/pods-creation-timeout"
+
+	// QuotaReservationLeadTimeAnnotation is the annotation key in the CronJob that holds
+	// the duration (e.g. "5m") before the next scheduled run at which Kueue creates
+	// the Workload of the run, so that its quota is reserved when the Job is created.
+	// The annotation is used with the CronJobQuotaReservation feature.
+	QuotaReservationLeadTimeAnnotation = "kueue.x-k8s.io/quota-reservation-lead-time"
+
+	// PodTemplateMutationPolicyAnnotation is the annotation key in the job that holds
+	// how Kueue handles the changes of its pod templates after the admission. The
+	// supported values are PodTemplateMutationPolicyFlag and PodTemplateMutationPolicyReadmit.
+	PodTemplateMutationPolicyAnnotation = "kueue.x-k8s.io/pod-template-mutation-policy"
+
+	// PodTemplateMutationPolicyFlag sets the PodTemplateMutated condition on the
+	// workload and keeps the job running. This is the default policy.
+	PodTemplateMutationPolicyFlag = "Flag"
+
+	// PodTemplateMutationPolicyReadmit evicts the workload, so that it's admitted
+	// again for the changed pod templates.
+	PodTemplateMutationPolicyReadmit = "Readmit"
+
+	// ManagedAnnotation is the annotation key in the job that, when set to "false",
+	// opts the job out of the management by Kueue, even if it has the queue-name label.
+	// The annotation is used with the JobManagementOptOut feature.
+	ManagedAnnotation = "kueue.x-k8s.io/managed"
+
+	// AdmittedPodTemplateHashAnnotation is the annotation key in the workload that
+	// holds the hash of the pod templates of the job when it was started.
+	AdmittedPodTemplateHashAnnotation = "kueue.x-k8s.io/admitted-pod-template-hash"
+
 	// SafeToForcefullyDeleteAnnotationKey is the annotation key that controls whether a pod opted in to FailureRecoveryPolicy.
 	SafeToForcefullyDeleteAnnotationKey = "kueue.x-k8s.io/safe-to-forcefully-delete"
 	// SafeToForcefullyDeleteAnnotationValue is the value of that annotation that enables FailureRecoveryPolicy for that pod.
@@ -91,8 +139,33 @@ const (
 	// The value is a comma-separated list of resource flavor names (e.g., "reservation,spot").
 	WorkloadAllowedResourceFlavorAnnotation = "kueue.x-k8s.io/workload-allowed-resource-flavors"
 
+	// AdmissionCheckFailedFlavorsAnnotation is an annotation used with the RetryAdmissionCheckOnDifferentFlavor
+	// feature. It's set on a Workload evicted due to an AdmissionCheck in the Retry state, and lists the
+	// ResourceFlavors the AdmissionCheck failed for, which the Kueue scheduler skips when admitting the Workload again.
+	// The value is a comma-separated list of resource flavor names (e.g., "reservation,spot").
+	AdmissionCheckFailedFlavorsAnnotation = "kueue.x-k8s.io/admission-check-failed-flavors"
+
+	// EvictionHooksAnnotation is an annotation used with the WorkloadEvictionHooks feature.
+	// It's set on a Workload by external controllers which need to run, e.g. to checkpoint the job,
+	// before Kueue stops the job of the evicted Workload. Each controller adds its name, and removes
+	// it once its cleanup is complete. The value is a comma-separated list of hook names
+	// (e.g., "example.com/checkpoint").
+	EvictionHooksAnnotation = "kueue.x-k8s.io/eviction-hooks"
+
+	// EvictionHooksTimeoutAnnotation is an annotation used with the WorkloadEvictionHooks feature.
+	// It holds the maximum duration (e.g., "5m") Kueue waits for the eviction hooks of the Workload,
+	// counted from its eviction, before stopping its job. It defaults to 10 minutes, and
+	// can't exceed the evictionHooks.maxTimeout of the Kueue configuration.
+	EvictionHooksTimeoutAnnotation = "kueue.x-k8s.io/eviction-hooks-timeout"
+
 	// ConcurrentAdmissionParentLabelKey is the label key in the Workload that is a Parent of Variants.
 	// The value of this label is boolean, and it is set to "true" if the Workload is a parent of Variants.
 	// The label is used with ConcurrentAdmission feature.
 	ConcurrentAdmissionParentLabelKey = "kueue.x-k8s.io/concurrent-admission-parent"
+
+	// NonPreemptibleAnnotationKey is the annotation key on a Workload that, when set
+	// to "true", excludes the workload from being selected as a preemption victim,
+	// regardless of its priority.
+	// This annotation is alpha-level and requires the NonPreemptibleWorkloads feature gate.
+	NonPreemptibleAnnotationKey = "kueue.x-k8s.io/non-preemptible"
 )
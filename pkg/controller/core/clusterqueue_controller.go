@@ -18,9 +18,13 @@ package core
 
 import (
 	"context"
+	"fmt"
 	"iter"
 	"math"
 	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -29,9 +33,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,8 +56,11 @@ import (
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/borrowingwindow"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	"sigs.k8s.io/kueue/pkg/workload"
+	workloadevict "sigs.k8s.io/kueue/pkg/workload/evict"
 )
 
 type ClusterQueueUpdateWatcher interface {
@@ -71,6 +80,17 @@ type ClusterQueueReconciler struct {
 	clock                 clock.Clock
 	roleTracker           *roletracker.RoleTracker
 	customLabels          *metrics.CustomLabels
+	recorder              events.EventRecorder
+
+	// lastQuotaReductionEvictions tracks, per ClusterQueue, when the last
+	// round of evictions of the Workloads exceeding the quota took place.
+	lastQuotaReductionEvictionsMu sync.Mutex
+	lastQuotaReductionEvictions   map[kueue.ClusterQueueReference]time.Time
+
+	// borrowingWindowsOpen tracks, per ClusterQueue, whether one of its
+	// borrowing windows was open in the last reconciliation.
+	borrowingWindowsOpenMu sync.Mutex
+	borrowingWindowsOpen   map[kueue.ClusterQueueReference]bool
 }
 
 var _ reconcile.Reconciler = (*ClusterQueueReconciler)(nil)
@@ -83,6 +103,7 @@ type ClusterQueueReconcilerOptions struct {
 	clock                 clock.Clock
 	roleTracker           *roletracker.RoleTracker
 	customLabels          *metrics.CustomLabels
+	recorder              events.EventRecorder
 }
 
 // ClusterQueueReconcilerOption configures the reconciler.
@@ -118,6 +139,14 @@ func WithClusterQueueCustomLabels(customLabels *metrics.CustomLabels) ClusterQue
 	}
 }
 
+// WithClusterQueueEventRecorder sets the recorder of the events emitted when
+// evicting the Workloads exceeding the quota of a ClusterQueue.
+func WithClusterQueueEventRecorder(recorder events.EventRecorder) ClusterQueueReconcilerOption {
+	return func(o *ClusterQueueReconcilerOptions) {
+		o.recorder = recorder
+	}
+}
+
 var defaultCQOptions = ClusterQueueReconcilerOptions{
 	clock: realClock,
 }
@@ -144,6 +173,10 @@ func NewClusterQueueReconciler(
 		clock:                 options.clock,
 		roleTracker:           options.roleTracker,
 		customLabels:          options.customLabels,
+		recorder:              options.recorder,
+
+		lastQuotaReductionEvictions: make(map[kueue.ClusterQueueReference]time.Time),
+		borrowingWindowsOpen:        make(map[kueue.ClusterQueueReference]bool),
 	}
 }
 
@@ -208,7 +241,88 @@ func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := r.updateCqStatusIfChanged(ctx, newCQObj, cqCondition, reason, msg); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	return ctrl.Result{}, nil
+	var result ctrl.Result
+	if features.Enabled(features.BorrowingWindows) && len(cqObj.Spec.BorrowingWindows) > 0 {
+		result = r.reconcileBorrowingWindows(ctx, &cqObj)
+	}
+	if features.Enabled(features.QuotaReductionEviction) && cqObj.Spec.QuotaReductionPolicy != nil {
+		evictionResult, err := r.evictOverQuotaWorkloads(ctx, &cqObj)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if result.RequeueAfter == 0 || (evictionResult.RequeueAfter > 0 && evictionResult.RequeueAfter < result.RequeueAfter) {
+			result = evictionResult
+		}
+	}
+	return result, nil
+}
+
+// reconcileBorrowingWindows requeues the inadmissible Workloads of a ClusterQueue
+// when one of its borrowing windows opens, as they might fit by borrowing, and
+// returns when the ClusterQueue needs to be reconciled again to observe the next
+// opening or closing of a window.
+func (r *ClusterQueueReconciler) reconcileBorrowingWindows(ctx context.Context, cq *kueue.ClusterQueue) ctrl.Result {
+	log := ctrl.LoggerFrom(ctx)
+	windows, err := borrowingwindow.Parse(cq.Spec.BorrowingWindows)
+	if err != nil {
+		log.Error(err, "Failed to parse the borrowing windows")
+		return ctrl.Result{}
+	}
+	cqName := kueue.ClusterQueueReference(cq.Name)
+	now := r.clock.Now()
+	open := windows.Open(now)
+
+	r.borrowingWindowsOpenMu.Lock()
+	wasOpen := r.borrowingWindowsOpen[cqName]
+	r.borrowingWindowsOpen[cqName] = open
+	r.borrowingWindowsOpenMu.Unlock()
+
+	if open && !wasOpen {
+		log.V(3).Info("Borrowing window opened, requeueing inadmissible workloads")
+		qcache.NotifyRetryInadmissible(r.qManager, sets.New(cqName))
+	}
+	next := windows.NextTransition(now)
+	if next.IsZero() {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}
+}
+
+// evictOverQuotaWorkloads evicts the lowest priority Workloads of a ClusterQueue
+// whose usage exceeds its quota. At most maxEvictionsPerInterval Workloads are
+// evicted every evictionIntervalSeconds, until the usage fits the quota again.
+func (r *ClusterQueueReconciler) evictOverQuotaWorkloads(ctx context.Context, cq *kueue.ClusterQueue) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	cqName := kueue.ClusterQueueReference(cq.Name)
+	now := r.clock.Now()
+
+	targets := r.cache.OverQuotaWorkloads(log, cqName, now)
+	if len(targets) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	policy := cq.Spec.QuotaReductionPolicy
+	interval := time.Duration(policy.EvictionIntervalSeconds) * time.Second
+	r.lastQuotaReductionEvictionsMu.Lock()
+	last, found := r.lastQuotaReductionEvictions[cqName]
+	if found && now.Before(last.Add(interval)) {
+		r.lastQuotaReductionEvictionsMu.Unlock()
+		return ctrl.Result{RequeueAfter: last.Add(interval).Sub(now)}, nil
+	}
+	r.lastQuotaReductionEvictions[cqName] = now
+	r.lastQuotaReductionEvictionsMu.Unlock()
+
+	maxEvictions := int(ptr.Deref(policy.MaxEvictionsPerInterval, 1))
+	for _, wi := range targets[:min(maxEvictions, len(targets))] {
+		wl := wi.Obj.DeepCopy()
+		log.V(3).Info("Workload is evicted because the usage of the ClusterQueue exceeds its quota", "workload", klog.KObj(wl))
+		exposeLqMetrics := r.cache.ShouldExposeLocalQueueMetricsForWorkload(log, wl)
+		err := workloadevict.Evict(ctx, r.client, r.recorder, wl, kueue.WorkloadEvictedByClusterQueueQuotaReduction, "The usage of the ClusterQueue exceeds its quota", "", r.clock, exposeLqMetrics, r.roleTracker, r.customLabels)
+		if client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
 }
 
 // NotifyTopologyUpdate triggers a topology update event only on creation or deletion,
@@ -343,6 +457,7 @@ func (r *ClusterQueueReconciler) Create(e event.TypedCreateEvent[*kueue.ClusterQ
 	if r.reportResourceMetrics {
 		r.cache.RecordClusterQueueResourceMetrics(log, kueue.ClusterQueueReference(e.Object.Name))
 	}
+	r.updateAvailableQuota(log, kueue.ClusterQueueReference(e.Object.Name), true)
 
 	return true
 }
@@ -356,6 +471,14 @@ func (r *ClusterQueueReconciler) Delete(e event.TypedDeleteEvent[*kueue.ClusterQ
 	r.cache.DeleteClusterQueue(e.Object)
 	r.qManager.DeleteClusterQueue(log, e.Object)
 
+	r.lastQuotaReductionEvictionsMu.Lock()
+	delete(r.lastQuotaReductionEvictions, kueue.ClusterQueueReference(e.Object.Name))
+	r.lastQuotaReductionEvictionsMu.Unlock()
+
+	r.borrowingWindowsOpenMu.Lock()
+	delete(r.borrowingWindowsOpen, kueue.ClusterQueueReference(e.Object.Name))
+	r.borrowingWindowsOpenMu.Unlock()
+
 	metrics.ClearClusterQueueResourceMetrics(e.Object.Name)
 	if features.Enabled(features.CustomMetricLabels) {
 		r.customLabels.CQDelete(kueue.ClusterQueueReference(e.Object.GetName()))
@@ -402,9 +525,24 @@ func (r *ClusterQueueReconciler) Update(e event.TypedUpdateEvent[*kueue.ClusterQ
 	if labelsUpdated {
 		r.resyncClusterQueueGaugeMetrics(e.ObjectNew)
 	}
+	r.updateAvailableQuota(log, kueue.ClusterQueueReference(e.ObjectNew.Name), specUpdated)
 	return true
 }
 
+// updateAvailableQuota observes the quota available to the ClusterQueues of the
+// cohort subtree affected by a change of the ClusterQueue, and reconciles the
+// ClusterQueues whose QuotaExhausted condition is outdated.
+func (r *ClusterQueueReconciler) updateAvailableQuota(log logr.Logger, cqName kueue.ClusterQueueReference, quotasChanged bool) {
+	if !r.reportResourceMetrics && !features.Enabled(features.ClusterQueueQuotaExhaustedCondition) {
+		return
+	}
+	if changed := r.cache.UpdateAvailableQuota(log, cqName, quotasChanged); len(changed) > 0 {
+		r.nonCQObjectUpdateCh <- event.TypedGenericEvent[iter.Seq[kueue.ClusterQueueReference]]{
+			Object: slices.Values(changed),
+		}
+	}
+}
+
 func (r *ClusterQueueReconciler) Generic(e event.TypedGenericEvent[*kueue.ClusterQueue]) bool {
 	r.logger().V(3).Info("Got ClusterQueue generic event", "clusterQueue", klog.KObj(e.Object))
 	return true
@@ -534,6 +672,11 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 		Message:            msg,
 		ObservedGeneration: cq.Generation,
 	})
+	if features.Enabled(features.ClusterQueueQuotaExhaustedCondition) {
+		meta.SetStatusCondition(&cq.Status.Conditions, quotaExhaustedCondition(stats.ExhaustedResources, cq.Generation))
+	} else {
+		meta.RemoveStatusCondition(&cq.Status.Conditions, kueue.ClusterQueueQuotaExhausted)
+	}
 	if r.fairSharingEnabled {
 		if r.reportResourceMetrics {
 			weightedShare := stats.WeightedShare
@@ -554,3 +697,26 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 	}
 	return nil
 }
+
+func quotaExhaustedCondition(exhausted []resources.FlavorResource, generation int64) metav1.Condition {
+	if len(exhausted) == 0 {
+		return metav1.Condition{
+			Type:               kueue.ClusterQueueQuotaExhausted,
+			Status:             metav1.ConditionFalse,
+			Reason:             kueue.ClusterQueueQuotaExhaustedReasonQuotaAvailable,
+			Message:            "Quota is available for all the resources",
+			ObservedGeneration: generation,
+		}
+	}
+	names := make([]string, len(exhausted))
+	for i, fr := range exhausted {
+		names[i] = fmt.Sprintf("%s in flavor %s", fr.Resource, fr.Flavor)
+	}
+	return metav1.Condition{
+		Type:               kueue.ClusterQueueQuotaExhausted,
+		Status:             metav1.ConditionTrue,
+		Reason:             kueue.ClusterQueueQuotaExhaustedReasonExhausted,
+		Message:            fmt.Sprintf("No quota is available for %s", strings.Join(names, ", ")),
+		ObservedGeneration: generation,
+	}
+}
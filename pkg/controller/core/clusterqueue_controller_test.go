@@ -18,6 +18,7 @@ package core
 
 import (
 	"math"
+	"slices"
 	"testing"
 	"time"
 
@@ -25,14 +26,19 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	qcache "sigs.k8s.io/kueue/pkg/cache/queue"
 	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	preemptexpectations "sigs.k8s.io/kueue/pkg/scheduler/preemption/expectations"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
@@ -308,6 +314,233 @@ func TestReconcileRemovesFinalizerWithFinishedWorkloads(t *testing.T) {
 	}
 }
 
+func TestReconcileBorrowingWindows(t *testing.T) {
+	// Monday
+	day := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		enableFeature    bool
+		now              time.Time
+		wantRequeueAfter time.Duration
+		wantOpen         map[kueue.ClusterQueueReference]bool
+	}{
+		"before the window opens": {
+			enableFeature:    true,
+			now:              day.Add(12 * time.Hour),
+			wantRequeueAfter: 6 * time.Hour,
+			wantOpen:         map[kueue.ClusterQueueReference]bool{"cq": false},
+		},
+		"within the window": {
+			enableFeature:    true,
+			now:              day.Add(20 * time.Hour),
+			wantRequeueAfter: 12 * time.Hour,
+			wantOpen:         map[kueue.ClusterQueueReference]bool{"cq": true},
+		},
+		"feature disabled": {
+			now:      day.Add(12 * time.Hour),
+			wantOpen: map[kueue.ClusterQueueReference]bool{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.BorrowingWindows, tc.enableFeature)
+			ctx, log := utiltesting.ContextWithLog(t)
+
+			cq := utiltestingapi.MakeClusterQueue("cq").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+				BorrowingWindow("0 18 * * 1-5", 14*3600).
+				Cohort("cohort").
+				Obj()
+			cq.Finalizers = []string{kueue.ResourceInUseFinalizerName}
+
+			cl := utiltesting.NewClientBuilder().WithObjects(cq).WithStatusSubresource(cq).Build()
+			cqCache := schdcache.New(cl)
+			qManager := qcache.NewManagerForUnitTests(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in cache: %v", err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in manager: %v", err)
+			}
+
+			r := NewClusterQueueReconciler(cl, qManager, cqCache)
+			r.clock = testingclock.NewFakeClock(tc.now)
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "cq"}})
+			if err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			if result.RequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("Unexpected RequeueAfter: got %v, want %v", result.RequeueAfter, tc.wantRequeueAfter)
+			}
+			if diff := cmp.Diff(tc.wantOpen, r.borrowingWindowsOpen); diff != "" {
+				t.Errorf("Unexpected borrowing windows state (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileEvictsOverQuotaWorkloads(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	testCases := map[string]struct {
+		enableFeature       bool
+		nominalQuota        string
+		maxEvictions        *int32
+		reconcileAfter      time.Duration
+		wantEvicted         []string
+		wantRequeueAfter    time.Duration
+		wantSecondEvicted   []string
+		wantSecondRequeueAt time.Duration
+	}{
+		"usage within the quota": {
+			enableFeature: true,
+			nominalQuota:  "3",
+		},
+		"one workload evicted per interval": {
+			enableFeature:       true,
+			nominalQuota:        "1",
+			wantEvicted:         []string{"low"},
+			wantRequeueAfter:    time.Minute,
+			reconcileAfter:      30 * time.Second,
+			wantSecondEvicted:   []string{"low"},
+			wantSecondRequeueAt: 30 * time.Second,
+		},
+		"next workload evicted after the interval": {
+			enableFeature:       true,
+			nominalQuota:        "1",
+			wantEvicted:         []string{"low"},
+			wantRequeueAfter:    time.Minute,
+			reconcileAfter:      time.Minute,
+			wantSecondEvicted:   []string{"low", "mid"},
+			wantSecondRequeueAt: time.Minute,
+		},
+		"evictions stop when the usage fits the quota": {
+			enableFeature:     true,
+			nominalQuota:      "2",
+			maxEvictions:      ptr.To[int32](3),
+			wantEvicted:       []string{"low"},
+			wantRequeueAfter:  time.Minute,
+			reconcileAfter:    time.Minute,
+			wantSecondEvicted: []string{"low"},
+		},
+		"several workloads evicted per interval": {
+			enableFeature:       true,
+			nominalQuota:        "0",
+			maxEvictions:        ptr.To[int32](2),
+			wantEvicted:         []string{"low", "mid"},
+			wantRequeueAfter:    time.Minute,
+			reconcileAfter:      time.Minute,
+			wantSecondEvicted:   []string{"high", "low", "mid"},
+			wantSecondRequeueAt: time.Minute,
+		},
+		"feature disabled": {
+			nominalQuota: "1",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.QuotaReductionEviction, tc.enableFeature)
+			ctx, log := utiltesting.ContextWithLog(t)
+			fakeClock := testingclock.NewFakeClock(now)
+
+			cq := utiltestingapi.MakeClusterQueue("cq").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, tc.nominalQuota).Obj()).
+				QuotaReductionPolicy(kueue.QuotaReductionPolicy{
+					EvictionIntervalSeconds: 60,
+					MaxEvictionsPerInterval: tc.maxEvictions,
+				}).
+				Obj()
+			cq.Finalizers = []string{kueue.ResourceInUseFinalizerName}
+
+			priorities := map[string]int32{"low": 1, "mid": 2, "high": 3}
+			wls := make([]*kueue.Workload, 0, len(priorities))
+			for wlName, p := range priorities {
+				wls = append(wls, utiltestingapi.MakeWorkload(wlName, "ns").
+					Priority(p).
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj())
+			}
+
+			objs := []client.Object{cq}
+			for _, wl := range wls {
+				objs = append(objs, wl)
+			}
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(objs...).Build()
+			cqCache := schdcache.New(cl)
+			qManager := qcache.NewManagerForUnitTests(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in cache: %v", err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in manager: %v", err)
+			}
+			for _, wl := range wls {
+				cqCache.AddOrUpdateWorkload(log, wl)
+			}
+
+			r := NewClusterQueueReconciler(cl, qManager, cqCache, WithClusterQueueEventRecorder(&utiltesting.EventRecorder{}))
+			r.clock = fakeClock
+
+			evicted := func() []string {
+				var got []string
+				for _, wl := range wls {
+					var updated kueue.Workload
+					if err := cl.Get(ctx, client.ObjectKeyFromObject(wl), &updated); err != nil {
+						t.Fatalf("Failed to get workload: %v", err)
+					}
+					if cond := meta.FindStatusCondition(updated.Status.Conditions, kueue.WorkloadEvicted); cond != nil && cond.Status == metav1.ConditionTrue {
+						if cond.Reason != kueue.WorkloadEvictedByClusterQueueQuotaReduction {
+							t.Errorf("Unexpected eviction reason for workload %q: %q", wl.Name, cond.Reason)
+						}
+						got = append(got, wl.Name)
+						// Reflect the eviction in the cache, as the workload controller would.
+						cqCache.AddOrUpdateWorkload(log, &updated)
+					}
+				}
+				slices.Sort(got)
+				return got
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "cq"}}
+			result, err := r.Reconcile(ctx, req)
+			if err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantEvicted, evicted()); diff != "" {
+				t.Errorf("Unexpected evicted workloads (-want,+got):\n%s", diff)
+			}
+			if result.RequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("Unexpected RequeueAfter: got %v, want %v", result.RequeueAfter, tc.wantRequeueAfter)
+			}
+			if tc.reconcileAfter == 0 {
+				return
+			}
+
+			fakeClock.Step(tc.reconcileAfter)
+			result, err = r.Reconcile(ctx, req)
+			if err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantSecondEvicted, evicted()); diff != "" {
+				t.Errorf("Unexpected evicted workloads after %v (-want,+got):\n%s", tc.reconcileAfter, diff)
+			}
+			if result.RequeueAfter != tc.wantSecondRequeueAt {
+				t.Errorf("Unexpected RequeueAfter after %v: got %v, want %v", tc.reconcileAfter, result.RequeueAfter, tc.wantSecondRequeueAt)
+			}
+		})
+	}
+}
+
 type cqMetrics struct {
 	NominalDPs   []testingmetrics.MetricDataPoint
 	BorrowingDPs []testingmetrics.MetricDataPoint
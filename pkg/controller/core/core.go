@@ -92,6 +92,7 @@ func SetupControllers(mgr ctrl.Manager, qManager *qcache.Manager, cc *schdcache.
 		WithWatchers(watchers...),
 		WithClusterQueueRoleTracker(opts.RoleTracker),
 		WithClusterQueueCustomLabels(opts.CustomLabels),
+		WithClusterQueueEventRecorder(mgr.GetEventRecorder(constants.ClusterQueueControllerName)),
 	)
 	rfRec.AddUpdateWatcher(cqRec)
 	acRec.AddUpdateWatchers(cqRec)
@@ -119,6 +120,12 @@ func SetupControllers(mgr ctrl.Manager, qManager *qcache.Manager, cc *schdcache.
 		return "Workload", err
 	}
 
+	if features.Enabled(features.FinishWorkloadsWithDeletedOwner) {
+		owRec := NewOrphanedWorkloadReconciler(mgr.GetClient(), mgr.GetAPIReader(), opts.RoleTracker)
+		if err := owRec.SetupWithManager(mgr); err != nil {
+			return "OrphanedWorkload", err
+		}
+	}
 	if features.Enabled(features.KueueDRAIntegrationPartitionableDevices) {
 		rsRec := NewResourceSliceReconciler(qManager, cfg, opts.RoleTracker)
 		if err := rsRec.SetupWithManager(mgr, cfg); err != nil {
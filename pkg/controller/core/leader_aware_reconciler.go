@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
 )
 
 // WithLeadingManager returns a decorating reconcile.Reconciler that discards reconciliation requests
@@ -54,6 +55,7 @@ func WithLeadingManager(mgr ctrl.Manager, reconciler reconcile.Reconciler, obj c
 		delegate:        reconciler,
 		object:          obj,
 		requeueDuration: cfg.LeaderElection.LeaseDuration.Duration,
+		retryPeriod:     cfg.LeaderElection.RetryPeriod.Duration,
 	}
 }
 
@@ -66,6 +68,10 @@ type leaderAwareReconciler struct {
 	// so no events are missed over the period it takes for
 	// leader election to fail over a new replica.
 	requeueDuration time.Duration
+	// the duration used by non-leading replicas to requeue events
+	// with LeaderElectionFastFailover, which is the period at which
+	// the replicas try to acquire the lease.
+	retryPeriod time.Duration
 }
 
 var _ reconcile.Reconciler = (*leaderAwareReconciler)(nil)
@@ -80,6 +86,14 @@ func (r *leaderAwareReconciler) Reconcile(ctx context.Context, request reconcile
 			// Discard request if not found, to prevent from re-enqueueing indefinitely.
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
+		if features.Enabled(features.LeaderElectionFastFailover) {
+			// Requeue the reconciliation request after the retry period, so that
+			// it's reconciled within a retry period of the replica acquiring the
+			// lease, without holding a worker until then. The watch event queue
+			// deduplicates the pending requests, so it can't grow beyond the
+			// number of watched objects.
+			return ctrl.Result{RequeueAfter: r.retryPeriod}, nil
+		}
 		// The manager hasn't been elected leader yet, requeue the reconciliation request
 		// to prevent against any missed / discarded events over the period it takes
 		// to fail over a new leading replica, which can take as much as the configured
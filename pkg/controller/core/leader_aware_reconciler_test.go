@@ -0,0 +1,105 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+const (
+	leaseDuration = 15 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// TestLeaderAwareReconcilerFailover verifies that a non-leading replica requeues
+// the pending requests, and reconciles them once it's elected leader.
+func TestLeaderAwareReconcilerFailover(t *testing.T) {
+	cases := map[string]struct {
+		fastFailover bool
+		wantResult   ctrl.Result
+	}{
+		"requeue after the lease duration": {
+			wantResult: ctrl.Result{RequeueAfter: leaseDuration},
+		},
+		"requeue after the retry period with LeaderElectionFastFailover": {
+			fastFailover: true,
+			wantResult:   ctrl.Result{RequeueAfter: retryPeriod},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.LeaderElectionFastFailover, tc.fastFailover)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			wl := utiltestingapi.MakeWorkload("wl", "ns").Obj()
+			cl := utiltesting.NewClientBuilder().WithObjects(wl).Build()
+
+			elected := make(chan struct{})
+			reconciled := make(chan reconcile.Request, 1)
+			r := &leaderAwareReconciler{
+				elected: elected,
+				client:  cl,
+				delegate: reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+					reconciled <- req
+					return reconcile.Result{}, nil
+				}),
+				object:          &kueue.Workload{},
+				requeueDuration: leaseDuration,
+				retryPeriod:     retryPeriod,
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "wl"}}
+
+			result, err := r.Reconcile(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantResult, result); diff != "" {
+				t.Errorf("Unexpected result before the election (-want,+got):\n%s", diff)
+			}
+			if len(reconciled) != 0 {
+				t.Errorf("Unexpected reconciliation by the non-leading replica")
+			}
+
+			// Simulate the loss of the previous leader, followed by the election.
+			close(elected)
+			result, err = r.Reconcile(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(ctrl.Result{}, result); diff != "" {
+				t.Errorf("Unexpected result after the election (-want,+got):\n%s", diff)
+			}
+			if len(reconciled) != 1 {
+				t.Fatalf("The request was not reconciled after the election")
+			}
+			if diff := cmp.Diff(req, <-reconciled); diff != "" {
+				t.Errorf("Unexpected reconciled request (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/util/roletracker"
+	"sigs.k8s.io/kueue/pkg/workload"
+	workloadfinish "sigs.k8s.io/kueue/pkg/workload/finish"
+)
+
+// ownerCacheLag is how long after the creation of a Workload an owner missing
+// from the cache is attributed to the lag of the cache. Older owners missing
+// from the cache are looked up in the API server before the Workload is
+// finalized.
+const ownerCacheLag = time.Minute
+
+// OrphanedWorkloadReconciler finishes the Workloads whose controller owner no
+// longer exists, and removes their finalizer, so that they release their quota
+// and can be garbage collected.
+//
+// The job reconcilers finalize the Workloads when their owner is deleted, but
+// they miss the deletions that happen while Kueue is not running. Such Workloads
+// are reconciled when they are listed on startup, or when the garbage collector
+// deletes them.
+type OrphanedWorkloadReconciler struct {
+	logName     string
+	client      client.Client
+	apiReader   client.Reader
+	clock       clock.Clock
+	roleTracker *roletracker.RoleTracker
+}
+
+var _ reconcile.Reconciler = (*OrphanedWorkloadReconciler)(nil)
+var _ predicate.TypedPredicate[*kueue.Workload] = (*OrphanedWorkloadReconciler)(nil)
+
+// NewOrphanedWorkloadReconciler creates an OrphanedWorkloadReconciler. The
+// apiReader is used to confirm that the owners missing from the cache are
+// deleted, so that owners that are not in the cache yet are not considered
+// deleted.
+func NewOrphanedWorkloadReconciler(
+	client client.Client,
+	apiReader client.Reader,
+	roleTracker *roletracker.RoleTracker,
+) *OrphanedWorkloadReconciler {
+	return &OrphanedWorkloadReconciler{
+		logName:     "orphaned-workload-reconciler",
+		client:      client,
+		apiReader:   apiReader,
+		clock:       realClock,
+		roleTracker: roleTracker,
+	}
+}
+
+func (r *OrphanedWorkloadReconciler) logger() logr.Logger {
+	return roletracker.WithReplicaRole(ctrl.Log.WithName(r.logName), r.roleTracker)
+}
+
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+
+func (r *OrphanedWorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var wl kueue.Workload
+	if err := r.client.Get(ctx, req.NamespacedName, &wl); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if workloadfinish.IsFinished(&wl) && !controllerutil.ContainsFinalizer(&wl, kueue.ResourceInUseFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+	owner := metav1.GetControllerOf(&wl)
+	if owner == nil {
+		// The workloads without an owner are handled by the workload reconciler.
+		return ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("owner", klog.KRef(wl.Namespace, owner.Name), "ownerKind", owner.Kind)
+	gone, requeueAfter, err := r.ownerGone(ctx, &wl, owner)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if requeueAfter > 0 {
+		log.V(3).Info("Workload owner is not in the cache yet", "requeueAfter", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	if !gone {
+		log.V(3).Info("Workload owner exists")
+		return ctrl.Result{}, nil
+	}
+	log.V(2).Info("Workload owner no longer exists")
+	return ctrl.Result{}, workload.FinalizeOrphanedWorkload(ctrl.LoggerInto(ctx, log), r.client, r.clock, &wl, true)
+}
+
+// ownerGone returns whether the owner no longer exists, or how long to wait
+// before checking again when the owner is missing from the cache but could be
+// too recent to be in it.
+//
+// The owner is looked up in the cache first. Only the owners missing from the
+// cache that are older than ownerCacheLag, as measured by the creation of the
+// Workload, are looked up in the API server.
+//
+// An owner that was recreated with the same name isn't considered gone: the job
+// reconciler replaces the Workload when it reconciles the new owner. An owner
+// that is being deleted isn't considered gone either, as the job reconciler
+// finalizes its Workloads.
+func (r *OrphanedWorkloadReconciler) ownerGone(ctx context.Context, wl *kueue.Workload, owner *metav1.OwnerReference) (bool, time.Duration, error) {
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		// An invalid owner reference can't be resolved.
+		return false, 0, nil
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gv.WithKind(owner.Kind))
+	key := types.NamespacedName{Namespace: wl.Namespace, Name: owner.Name}
+	found, err := getOwner(ctx, r.client, key, obj)
+	if err != nil {
+		return false, 0, err
+	}
+	if !found {
+		if age := r.clock.Since(wl.CreationTimestamp.Time); age < ownerCacheLag {
+			return false, ownerCacheLag - age, nil
+		}
+		if found, err = getOwner(ctx, r.apiReader, key, obj); err != nil || !found {
+			return !found && err == nil, 0, err
+		}
+	}
+	if obj.UID != "" && obj.UID != owner.UID {
+		ctrl.LoggerFrom(ctx).V(3).Info("Workload owner was recreated", "ownerUID", obj.UID)
+	}
+	return false, 0, nil
+}
+
+// getOwner returns whether the owner was found by the reader. The owners of an
+// unknown kind are reported as found, as Kueue can't tell if they exist.
+func getOwner(ctx context.Context, reader client.Reader, key types.NamespacedName, obj *metav1.PartialObjectMetadata) (bool, error) {
+	err := reader.Get(ctx, key, obj)
+	switch {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case apimeta.IsNoMatchError(err) || apierrors.IsForbidden(err):
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *OrphanedWorkloadReconciler) Create(e event.TypedCreateEvent[*kueue.Workload]) bool {
+	// The workloads are listed on startup, covering the owners deleted while
+	// Kueue was not running.
+	return metav1.GetControllerOf(e.Object) != nil
+}
+
+func (r *OrphanedWorkloadReconciler) Delete(event.TypedDeleteEvent[*kueue.Workload]) bool {
+	return false
+}
+
+func (r *OrphanedWorkloadReconciler) Update(e event.TypedUpdateEvent[*kueue.Workload]) bool {
+	// The garbage collector deletes the workloads whose owner no longer exists,
+	// but their finalizer keeps them around.
+	if e.ObjectOld.DeletionTimestamp.IsZero() && !e.ObjectNew.DeletionTimestamp.IsZero() && metav1.GetControllerOf(e.ObjectNew) != nil {
+		r.logger().V(3).Info("Workload is being deleted", "workload", klog.KObj(e.ObjectNew))
+		return true
+	}
+	return false
+}
+
+func (r *OrphanedWorkloadReconciler) Generic(event.TypedGenericEvent[*kueue.Workload]) bool {
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OrphanedWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return builder.TypedControllerManagedBy[reconcile.Request](mgr).
+		Named("orphaned_workload_controller").
+		WatchesRawSource(source.TypedKind(
+			mgr.GetCache(),
+			&kueue.Workload{},
+			&handler.TypedEnqueueRequestForObject[*kueue.Workload]{},
+			r,
+		)).
+		WithOptions(controller.Options{
+			LogConstructor: roletracker.NewLogConstructor(r.roleTracker, "orphaned-workload-reconciler"),
+		}).
+		Complete(r)
+}
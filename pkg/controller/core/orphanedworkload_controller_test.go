@@ -0,0 +1,149 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+)
+
+func TestOrphanedWorkloadReconcile(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	baseWorkload := utiltestingapi.MakeWorkload("wl", "ns").
+		ControllerReference(jobGVK, "job", "job-uid").
+		Finalizers(kueue.ResourceInUseFinalizerName).
+		ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").Obj(), now).
+		AdmittedAt(true, now)
+
+	cases := map[string]struct {
+		workload *kueue.Workload
+		job      *batchv1.Job
+		// apiJob is only known to the API server, not to the cache.
+		apiJob       *batchv1.Job
+		wantWorkload *kueue.Workload
+		wantResult   reconcile.Result
+	}{
+		"owner exists": {
+			workload:     baseWorkload.Clone().Obj(),
+			job:          testingjob.MakeJob("job", "ns").UID("job-uid").Obj(),
+			wantWorkload: baseWorkload.Clone().Obj(),
+		},
+		"owner was recreated": {
+			workload:     baseWorkload.Clone().Obj(),
+			job:          testingjob.MakeJob("job", "ns").UID("new-job-uid").Obj(),
+			wantWorkload: baseWorkload.Clone().Obj(),
+		},
+		"owner missing from the cache exists in the API server": {
+			workload:     baseWorkload.Clone().Obj(),
+			apiJob:       testingjob.MakeJob("job", "ns").UID("job-uid").Obj(),
+			wantWorkload: baseWorkload.Clone().Obj(),
+		},
+		"owner missing from the cache of a recent workload": {
+			workload:     baseWorkload.Clone().Creation(now.Add(-20 * time.Second)).Obj(),
+			wantWorkload: baseWorkload.Clone().Creation(now.Add(-20 * time.Second)).Obj(),
+			wantResult:   reconcile.Result{RequeueAfter: 40 * time.Second},
+		},
+		"owner is being deleted": {
+			workload:     baseWorkload.Clone().Obj(),
+			job:          testingjob.MakeJob("job", "ns").UID("job-uid").Finalizers("test").DeletionTimestamp(now).Obj(),
+			wantWorkload: baseWorkload.Clone().Obj(),
+		},
+		"owner no longer exists": {
+			workload: baseWorkload.Clone().Obj(),
+			wantWorkload: baseWorkload.Clone().
+				Finalizers().
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadFinished,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadFinishedReasonOwnerNotFound,
+					Message: "The workload's owner no longer exists",
+				}).
+				Obj(),
+		},
+		"owner no longer exists and the workload is being deleted": {
+			workload: baseWorkload.Clone().DeletionTimestamp(now).Obj(),
+		},
+		"workload without owner": {
+			workload:     utiltestingapi.MakeWorkload("wl", "ns").Finalizers(kueue.ResourceInUseFinalizerName).Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").Finalizers(kueue.ResourceInUseFinalizerName).Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			objs := []client.Object{tc.workload}
+			if tc.job != nil {
+				objs = append(objs, tc.job)
+			}
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(objs...).
+				WithStatusSubresource(tc.workload).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				Build()
+			apiObjs := objs
+			if tc.apiJob != nil {
+				apiObjs = append(apiObjs, tc.apiJob)
+			}
+			apiReader := utiltesting.NewClientBuilder().WithObjects(apiObjs...).Build()
+			r := NewOrphanedWorkloadReconciler(cl, apiReader, nil)
+			r.clock = testingclock.NewFakeClock(now)
+
+			gotResult, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.workload)})
+			if err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantResult, gotResult); diff != "" {
+				t.Errorf("Unexpected reconcile result (-want,+got):\n%s", diff)
+			}
+
+			gotWorkload := &kueue.Workload{}
+			err = cl.Get(ctx, client.ObjectKeyFromObject(tc.workload), gotWorkload)
+			if tc.wantWorkload == nil {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("Expected the workload to be deleted, got error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error getting the workload: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWorkload, gotWorkload,
+				cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion", "DeletionTimestamp"),
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "ObservedGeneration"),
+				cmpopts.EquateEmpty(),
+			); diff != "" {
+				t.Errorf("Unexpected workload (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -63,6 +64,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/dra"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	afs "sigs.k8s.io/kueue/pkg/util/admissionfairsharing"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	clientutil "sigs.k8s.io/kueue/pkg/util/client"
@@ -72,6 +74,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 	stringsutils "sigs.k8s.io/kueue/pkg/util/strings"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	"sigs.k8s.io/kueue/pkg/workload"
 	"sigs.k8s.io/kueue/pkg/workload/concurrentadmission"
 	workloadevict "sigs.k8s.io/kueue/pkg/workload/evict"
@@ -218,6 +221,12 @@ type WorkloadReconciler struct {
 	roleTracker            *roletracker.RoleTracker
 	preemptionExpectations *expectations.Store
 	customLabels           *metrics.CustomLabels
+
+	// fallbackRequeuesLock guards fallbackRequeues.
+	fallbackRequeuesLock sync.Mutex
+	// fallbackRequeues records, per workload, the time of the last unconstrained
+	// topology fallback for which the workload was requeued.
+	fallbackRequeues map[workload.Reference]time.Time
 }
 
 var _ reconcile.Reconciler = (*WorkloadReconciler)(nil)
@@ -232,6 +241,7 @@ func NewWorkloadReconciler(client client.Client, queues *qcache.Manager, cache *
 		recorder:            recorder,
 		clock:               realClock,
 		draReconcileChannel: make(chan event.TypedGenericEvent[*kueue.Workload], updateChBuffer),
+		fallbackRequeues:    make(map[workload.Reference]time.Time),
 	}
 	for _, option := range options {
 		option(r)
@@ -341,6 +351,14 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		return ctrl.Result{}, nil
 	}
 
+	if features.Enabled(features.TASPreferPreviousAssignment) && workload.HasQuotaReservation(&wl) {
+		if updated := recordPreviousTopologyAssignment(log, &wl); updated {
+			log.V(3).Info("Recording the topology assignment of the workload")
+			err := r.client.Update(ctx, &wl)
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
 	if requeueAt := workload.NeedsRequeueAtUpdate(&wl, r.clock); requeueAt != nil {
 		err := workloadpatching.PatchAdmissionStatus(ctx, r.client, &wl, r.clock, func(wl *kueue.Workload) (bool, error) {
 			if wl.Status.RequeueState == nil {
@@ -348,6 +366,11 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 			}
 			log.V(2).Info("At least one admission check set a retry time", "requeueAt", requeueAt, "current", wl.Status.RequeueState.RequeueAt)
 			workload.SetRequeueState(wl, *requeueAt, false)
+			if features.Enabled(features.AdmissionCheckRetryStatus) {
+				message := fmt.Sprintf("Waiting for %s; next retry at %s",
+					buildAdmissionChecksMessage(workload.RetryChecks(wl), kueue.CheckStateRetry), requeueAt.UTC().Format(time.RFC3339))
+				workload.SetRequeuedCondition(wl, kueue.WorkloadEvictedByAdmissionCheck, message, false)
+			}
 			return true, nil
 		})
 		return reconcile.Result{}, client.IgnoreNotFound(err)
@@ -374,7 +397,7 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		return ctrl.Result{}, nil
 	}
 	if workload.Status(&wl) == workload.StatusPending && dra.NeedsDRAReconcile(&wl, r.draBackedResources) {
-		workload.AdjustResources(ctx, r.client, &wl)
+		r.queues.AdjustResources(ctx, &wl)
 		if workload.HasResourceClaim(&wl) {
 			log.V(3).Info("Workload is inadmissible because it uses resource claims which is not supported")
 			err := workloadpatching.PatchAdmissionStatus(ctx, r.client, &wl, r.clock, func(wl *kueue.Workload) (bool, error) {
@@ -526,6 +549,10 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		log.V(3).Info("Successfully pre-processed and queued DRA workload in scheduler")
 	}
 
+	if updated, err := r.reconcileFailedFlavors(ctx, &wl); updated || err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
 	if workload.IsActive(&wl) {
 		if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadDeactivationTarget) {
 			wl.Spec.Active = new(false)
@@ -763,7 +790,7 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 	}
 
 	if workload.HasQuotaReservation(&wl) {
-		if evictionTriggered, err := r.reconcileCheckBasedEviction(ctx, &wl); evictionTriggered || err != nil {
+		if evictionTriggered, err := r.reconcileCheckBasedEviction(ctx, &wl, cq); evictionTriggered || err != nil {
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
 
@@ -775,7 +802,16 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
 
-		podsReadyRecheckAfter, err := r.reconcileNotReadyTimeout(ctx, req, &wl)
+		if updated, err := r.reconcileOnResourceFlavorStopped(ctx, &wl); updated || err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+
+		acTimeoutRecheckAfter, updated, err := r.reconcileAdmissionCheckTimeout(ctx, &wl)
+		if updated || err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+
+		podsReadyRecheckAfter, err := r.reconcileNotReadyTimeout(ctx, req, &wl, cq)
 		if err != nil {
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
@@ -785,16 +821,62 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 		}
 
 		// get the minimun non-zero value
-		recheckAfter := min(podsReadyRecheckAfter, maxExecRecheckAfter)
-		if recheckAfter == 0 {
-			recheckAfter = max(podsReadyRecheckAfter, maxExecRecheckAfter)
+		var recheckAfter time.Duration
+		for _, d := range []time.Duration{podsReadyRecheckAfter, maxExecRecheckAfter, acTimeoutRecheckAfter} {
+			if d > 0 && (recheckAfter == 0 || d < recheckAfter) {
+				recheckAfter = d
+			}
 		}
 		return ctrl.Result{RequeueAfter: recheckAfter}, nil
 	}
 
+	if features.Enabled(features.TASUnconstrainedTopologyFallback) && workload.IsActive(&wl) {
+		return r.reconcileUnconstrainedTopologyFallback(ctx, &wl), nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// reconcileUnconstrainedTopologyFallback makes sure a pending workload is
+// retried by the scheduler once one of its PodSets can fall back to being
+// admitted without a topology assignment, even if no other event requeues it.
+// The workload is requeued once per fallback, so that the later events of the
+// workload don't requeue it again.
+func (r *WorkloadReconciler) reconcileUnconstrainedTopologyFallback(ctx context.Context, wl *kueue.Workload) ctrl.Result {
+	log := ctrl.LoggerFrom(ctx)
+	key := workload.Key(wl)
+	if fallbackAt, found := workload.LastUnconstrainedTopologyFallback(wl, r.clock); found && r.recordFallbackRequeue(key, fallbackAt) {
+		log.V(3).Info("Requeuing the workload for the unconstrained topology fallback", "fallbackAt", fallbackAt)
+		r.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, key, nil)
+	}
+	if remaining, found := workload.NextUnconstrainedTopologyFallback(wl, r.clock); found {
+		log.V(3).Info("Waiting for the unconstrained topology fallback", "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}
+	}
+	return ctrl.Result{}
+}
+
+// recordFallbackRequeue records that the workload is requeued for the
+// unconstrained topology fallback at fallbackAt. It returns false if the
+// workload was already requeued for this fallback, or a later one.
+func (r *WorkloadReconciler) recordFallbackRequeue(key workload.Reference, fallbackAt time.Time) bool {
+	r.fallbackRequeuesLock.Lock()
+	defer r.fallbackRequeuesLock.Unlock()
+	if last, found := r.fallbackRequeues[key]; found && !fallbackAt.After(last) {
+		return false
+	}
+	r.fallbackRequeues[key] = fallbackAt
+	return true
+}
+
+// forgetFallbackRequeue forgets the unconstrained topology fallback requeues
+// of the workload.
+func (r *WorkloadReconciler) forgetFallbackRequeue(key workload.Reference) {
+	r.fallbackRequeuesLock.Lock()
+	defer r.fallbackRequeuesLock.Unlock()
+	delete(r.fallbackRequeues, key)
+}
+
 // isOrphanedWorkload determines if a workload is orphaned and should be finalized.
 //
 // A workload is considered orphaned when it meets **both** of the following conditions:
@@ -875,6 +957,78 @@ func (r *WorkloadReconciler) reconcileMaxExecutionTime(ctx context.Context, wl *
 	return 0, nil
 }
 
+// reconcileAdmissionCheckTimeout releases the quota reservation of the workload
+// if one of its admission checks is Pending for longer than the
+// admissionCheckTimeoutSeconds of the AdmissionCheck. Depending on the
+// timeoutAction, the workload is requeued or deactivated.
+// Returns the time after which the timeout should be rechecked and whether the
+// workload was updated.
+func (r *WorkloadReconciler) reconcileAdmissionCheckTimeout(ctx context.Context, wl *kueue.Workload) (time.Duration, bool, error) {
+	if !features.Enabled(features.AdmissionCheckTimeout) || workload.IsAdmitted(wl) || workloadevict.IsEvicted(wl) {
+		return 0, false, nil
+	}
+	quotaReserved := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if quotaReserved == nil || quotaReserved.Status != metav1.ConditionTrue {
+		return 0, false, nil
+	}
+
+	var (
+		recheckAfter  time.Duration
+		timedOut      []kueue.AdmissionCheckState
+		deactivate    bool
+		reservedSince = r.clock.Since(quotaReserved.LastTransitionTime.Time)
+	)
+	for _, acState := range wl.Status.AdmissionChecks {
+		if acState.State != kueue.CheckStatePending {
+			continue
+		}
+		var ac kueue.AdmissionCheck
+		if err := r.client.Get(ctx, types.NamespacedName{Name: string(acState.Name)}, &ac); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return 0, false, err
+		}
+		if ac.Spec.AdmissionCheckTimeoutSeconds == nil {
+			continue
+		}
+		remaining := time.Duration(*ac.Spec.AdmissionCheckTimeoutSeconds)*time.Second - reservedSince
+		if remaining > 0 {
+			if recheckAfter == 0 || remaining < recheckAfter {
+				recheckAfter = remaining
+			}
+			continue
+		}
+		timedOut = append(timedOut, acState)
+		if ptr.Deref(ac.Spec.TimeoutAction, kueue.AdmissionCheckTimeoutActionRequeue) == kueue.AdmissionCheckTimeoutActionDeactivate {
+			deactivate = true
+		}
+	}
+	if len(timedOut) == 0 {
+		return recheckAfter, false, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	message := fmt.Sprintf("Exceeded the timeout of %s", buildAdmissionChecksMessage(timedOut, kueue.CheckStatePending))
+	if deactivate {
+		err := workloadpatching.PatchAdmissionStatus(ctx, r.client, wl, r.clock, func(wl *kueue.Workload) (bool, error) {
+			return workload.SetDeactivationTarget(wl, kueue.WorkloadEvictedByAdmissionCheck, message), nil
+		})
+		if err != nil {
+			return 0, false, err
+		}
+		log.V(3).Info("Workload is deactivated due to admission checks timeout", "timedOutChecks", timedOut)
+		r.recorder.Eventf(wl, nil, corev1.EventTypeWarning, "AdmissionCheckTimeout", "AdmissionCheckTimeout", api.TruncateEventMessage(fmt.Sprintf("Deactivated due to %s", message)))
+		return 0, true, nil
+	}
+	log.V(3).Info("Workload is evicted due to admission checks timeout", "timedOutChecks", timedOut)
+	exposeLqMetrics := r.cache.ShouldExposeLocalQueueMetricsForWorkload(log, wl)
+	if err := workloadevict.Evict(ctx, r.client, r.recorder, wl, kueue.WorkloadEvictedByAdmissionCheck, message, "", r.clock, exposeLqMetrics, r.roleTracker, r.customLabels); err != nil {
+		return 0, false, err
+	}
+	return 0, true, nil
+}
+
 // buildAdmissionChecksMessage formats a human-readable message
 // describing the list of admission checks in the given state.
 func buildAdmissionChecksMessage(checks []kueue.AdmissionCheckState, state kueue.CheckState) string {
@@ -896,9 +1050,24 @@ func buildAdmissionChecksMessage(checks []kueue.AdmissionCheckState, state kueue
 	return fmt.Sprintf("%s in %s state: %s", noun, state, stringsutils.Join(parts, "; "))
 }
 
+// reconcileFailedFlavors clears the flavors recorded as failed by the admission
+// checks once the Workload is admitted, or when it's deactivated, which resets
+// the state of its admission checks.
+// Returns true if the Workload was updated, and false otherwise.
+func (r *WorkloadReconciler) reconcileFailedFlavors(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	if workload.IsActive(wl) && !workload.IsAdmitted(wl) {
+		return false, nil
+	}
+	if !workload.ClearFailedFlavors(wl) {
+		return false, nil
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Clearing the flavors which failed the admission checks")
+	return true, r.client.Update(ctx, wl)
+}
+
 // reconcileCheckBasedEviction evicts or deactivates the given Workload if any admission checks have failed.
-// Returns true if the Workload was rejected or deactivated, and false otherwise.
-func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl *kueue.Workload) (bool, error) {
+// Returns true if the Workload was updated, rejected or deactivated, and false otherwise.
+func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl *kueue.Workload, cq *kueue.ClusterQueue) (bool, error) {
 	if features.Enabled(features.ConcurrentAdmission) && concurrentadmission.IsParent(wl) {
 		// Parent Workloads are not supposed to have admission checks.
 		return false, nil
@@ -922,6 +1091,11 @@ func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl
 		return true, nil
 	}
 	// at this point we know a Workload has at least one Retry AdmissionCheck
+	if features.Enabled(features.RetryAdmissionCheckOnDifferentFlavor) && workload.RecordFailedFlavors(wl, cq) {
+		// The Workload is evicted in the reconcile triggered by the update.
+		log.V(3).Info("Recording the flavors which failed the admission checks", "failedFlavors", wl.Annotations[controllerconsts.AdmissionCheckFailedFlavorsAnnotation])
+		return true, r.client.Update(ctx, wl)
+	}
 	retryChecks := workload.RetryChecks(wl)
 	message := fmt.Sprintf("Evicted due to %s", buildAdmissionChecksMessage(retryChecks, kueue.CheckStateRetry))
 	exposeLqMetrics := r.cache.ShouldExposeLocalQueueMetricsForWorkload(log, wl)
@@ -938,7 +1112,11 @@ func (r *WorkloadReconciler) reconcileSyncAdmissionChecks(ctx context.Context, w
 	}
 	log := ctrl.LoggerFrom(ctx)
 	admissionChecks := workload.AdmissionChecksForWorkload(log, wl, cq)
-	newChecks, shouldUpdate := syncAdmissionCheckConditions(wl.Status.AdmissionChecks, admissionChecks, r.clock)
+	var advisoryChecks sets.Set[kueue.AdmissionCheckReference]
+	if features.Enabled(features.AdvisoryAdmissionChecks) {
+		advisoryChecks = r.cache.AdvisoryAdmissionChecks(admissionChecks)
+	}
+	newChecks, shouldUpdate := syncAdmissionCheckConditions(wl.Status.AdmissionChecks, admissionChecks, advisoryChecks, r.clock)
 	if shouldUpdate {
 		log.V(3).Info("The workload needs admission checks updates", "clusterQueue", klog.KRef("", cq.Name), "admissionChecks", admissionChecks)
 		wl.Status.AdmissionChecks = newChecks
@@ -1065,6 +1243,78 @@ func (r *WorkloadReconciler) reconcileOnClusterQueueActiveState(ctx context.Cont
 	return false, nil
 }
 
+func (r *WorkloadReconciler) reconcileOnResourceFlavorStopped(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	if !features.Enabled(features.ResourceFlavorStopPolicy) {
+		return false, nil
+	}
+	flavorName, stopPolicy, err := r.stoppedResourceFlavor(ctx, wl)
+	if err != nil || stopPolicy == kueue.None {
+		return false, err
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	if workload.IsAdmitted(wl) {
+		if stopPolicy != kueue.HoldAndDrain {
+			return false, nil
+		}
+		if workloadevict.IsEvicted(wl) {
+			log.V(3).Info("Workload is already evicted.")
+			return false, nil
+		}
+		log.V(3).Info("Workload is evicted because the ResourceFlavor is stopped", "resourceFlavor", klog.KRef("", string(flavorName)))
+		message := fmt.Sprintf("The ResourceFlavor %s is stopped", flavorName)
+		exposeLqMetrics := r.cache.ShouldExposeLocalQueueMetricsForWorkload(log, wl)
+		err := workloadevict.Evict(ctx, r.client, r.recorder, wl, kueue.WorkloadEvictedByResourceFlavorStopped, message, "", r.clock, exposeLqMetrics, r.roleTracker, r.customLabels)
+		return true, err
+	}
+
+	log.V(3).Info("Workload reservation is cancelled because the ResourceFlavor is stopped", "resourceFlavor", klog.KRef("", string(flavorName)))
+	return true, workloadpatching.PatchAdmissionStatus(ctx, r.client, wl, r.clock, func(wl *kueue.Workload) (bool, error) {
+		reason := workload.UnadmittedWorkloadReasonWithFallback(
+			kueue.WorkloadQuotaReservedReasonPendingEvaluation,
+			kueue.WorkloadPending, //nolint:staticcheck // SA1019: fallback
+		)
+		return workload.UnsetQuotaReservationWithCondition(wl, reason, fmt.Sprintf("ResourceFlavor %s is stopped", flavorName), r.clock.Now()), nil
+	})
+}
+
+// stoppedResourceFlavor returns a stopped ResourceFlavor assigned to the
+// workload and its stop policy, preferring the flavors to drain.
+func (r *WorkloadReconciler) stoppedResourceFlavor(ctx context.Context, wl *kueue.Workload) (kueue.ResourceFlavorReference, kueue.StopPolicy, error) {
+	var (
+		stoppedFlavor kueue.ResourceFlavorReference
+		stopPolicy    = kueue.None
+	)
+	if wl.Status.Admission == nil {
+		return stoppedFlavor, stopPolicy, nil
+	}
+	checked := sets.New[kueue.ResourceFlavorReference]()
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		for _, flavorName := range psa.Flavors {
+			if checked.Has(flavorName) {
+				continue
+			}
+			checked.Insert(flavorName)
+			var rf kueue.ResourceFlavor
+			if err := r.client.Get(ctx, types.NamespacedName{Name: string(flavorName)}, &rf); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return "", kueue.None, err
+			}
+			switch ptr.Deref(rf.Spec.StopPolicy, kueue.None) {
+			case kueue.HoldAndDrain:
+				return flavorName, kueue.HoldAndDrain, nil
+			case kueue.Hold:
+				if stopPolicy == kueue.None {
+					stoppedFlavor, stopPolicy = flavorName, kueue.Hold
+				}
+			}
+		}
+	}
+	return stoppedFlavor, stopPolicy, nil
+}
+
 // mayUpdateConditionForAdmissionGatedBy updates the Condition of a Workload when it first detects that it is
 // gated by an AdmissionGate or it detects that its AdmissionGate just got removed.
 // Returns whether the function updated the condition or not.
@@ -1123,18 +1373,25 @@ func hasAdmissionGatedCondition(wl *kueue.Workload) bool {
 	return condition != nil && condition.Reason == kueue.WorkloadAdmissionGated
 }
 
-func syncAdmissionCheckConditions(conds []kueue.AdmissionCheckState, admissionChecks sets.Set[kueue.AdmissionCheckReference], c clock.Clock) ([]kueue.AdmissionCheckState, bool) {
+func syncAdmissionCheckConditions(conds []kueue.AdmissionCheckState, admissionChecks, advisoryChecks sets.Set[kueue.AdmissionCheckReference], c clock.Clock) ([]kueue.AdmissionCheckState, bool) {
 	if len(admissionChecks) == 0 {
 		return nil, len(conds) > 0
 	}
 
 	shouldUpdate := false
 	currentChecks := utilslices.ToRefMap(conds, func(c *kueue.AdmissionCheckState) kueue.AdmissionCheckReference { return c.Name })
+	for i := range conds {
+		if ac := &conds[i]; admissionChecks.Has(ac.Name) && ptr.Deref(ac.Advisory, false) != advisoryChecks.Has(ac.Name) {
+			ac.Advisory = advisoryState(advisoryChecks, ac.Name)
+			shouldUpdate = true
+		}
+	}
 	for t := range admissionChecks {
 		if _, found := currentChecks[t]; !found {
 			workloadpatching.SetAdmissionCheckState(&conds, kueue.AdmissionCheckState{
-				Name:  t,
-				State: kueue.CheckStatePending,
+				Name:     t,
+				State:    kueue.CheckStatePending,
+				Advisory: advisoryState(advisoryChecks, t),
 			}, c)
 			shouldUpdate = true
 		}
@@ -1158,7 +1415,42 @@ func syncAdmissionCheckConditions(conds []kueue.AdmissionCheckState, admissionCh
 	return conds, shouldUpdate
 }
 
-func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req ctrl.Request, wl *kueue.Workload) (time.Duration, error) {
+// recordPreviousTopologyAssignment sets the PreviousTopologyAssignmentAnnotation
+// to the topology assignment of the workload, so that the scheduler can prefer
+// the same topology domains when the workload is requeued. When the topology
+// assignment can't be recorded, for example because it is too large, the
+// annotation is removed instead of failing the reconcile, so that a stale
+// assignment is not preferred. It returns true if the annotation was updated.
+func recordPreviousTopologyAssignment(log logr.Logger, wl *kueue.Workload) bool {
+	value, err := utiltas.PreviousAssignmentAnnotationValue(wl.Status.Admission)
+	if err != nil {
+		log.V(2).Info("Skipping the recording of the topology assignment of the workload", "reason", err.Error())
+		if _, found := wl.Annotations[kueue.PreviousTopologyAssignmentAnnotation]; !found {
+			return false
+		}
+		delete(wl.Annotations, kueue.PreviousTopologyAssignmentAnnotation)
+		return true
+	}
+	if value == "" || wl.Annotations[kueue.PreviousTopologyAssignmentAnnotation] == value {
+		return false
+	}
+	if wl.Annotations == nil {
+		wl.Annotations = make(map[string]string, 1)
+	}
+	wl.Annotations[kueue.PreviousTopologyAssignmentAnnotation] = value
+	return true
+}
+
+// advisoryState returns the value of the advisory field of the state of the
+// given admission check.
+func advisoryState(advisoryChecks sets.Set[kueue.AdmissionCheckReference], check kueue.AdmissionCheckReference) *bool {
+	if advisoryChecks.Has(check) {
+		return new(true)
+	}
+	return nil
+}
+
+func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req ctrl.Request, wl *kueue.Workload, cq *kueue.ClusterQueue) (time.Duration, error) {
 	if features.Enabled(features.ConcurrentAdmission) && concurrentadmission.IsVariant(wl) {
 		// Variant Workloads are not supposed to have PodsReady condition, it's Parent Workload responsibility.
 		return 0, nil
@@ -1179,6 +1471,15 @@ func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req c
 		return recheckAfter, nil
 	}
 
+	strategy := requeueStrategy(cq)
+	if strategy == kueue.RequeueStrategyManual {
+		log.V(2).Info("Deactivate the workload due to exceeding the PodsReady timeout, the ClusterQueue requires manual re-activation")
+		err := workloadpatching.PatchAdmissionStatus(ctx, r.client, wl, r.clock, func(wl *kueue.Workload) (bool, error) {
+			return workload.SetDeactivationTarget(wl, kueue.WorkloadEvictedByPodsReadyTimeout, fmt.Sprintf("exceeding the PodsReady timeout %s", req.String())), nil
+		})
+		return 0, err
+	}
+
 	deactivated, err := r.triggerDeactivation(ctx, wl)
 	if err != nil || deactivated {
 		return 0, err
@@ -1201,6 +1502,14 @@ func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req c
 		r.roleTracker,
 		r.customLabels,
 		workloadevict.WithCustomPrepare(func(wl *kueue.Workload) {
+			if strategy == kueue.RequeueStrategyImmediate {
+				// Only count the re-queue, so that the requeuingBackoffLimitCount still applies.
+				if wl.Status.RequeueState == nil {
+					wl.Status.RequeueState = &kueue.RequeueState{}
+				}
+				wl.Status.RequeueState.Count = new(ptr.Deref(wl.Status.RequeueState.Count, 0) + 1)
+				return
+			}
 			workload.UpdateRequeueState(wl, r.waitForPodsReady.requeuingBackoffBaseSeconds, int32(r.waitForPodsReady.requeuingBackoffMaxDuration.Seconds()), r.clock)
 		}),
 	)
@@ -1208,6 +1517,15 @@ func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req c
 	return 0, err
 }
 
+// requeueStrategy returns the strategy used to requeue the workloads of the
+// ClusterQueue evicted by the PodsReady timeout.
+func requeueStrategy(cq *kueue.ClusterQueue) kueue.RequeueStrategy {
+	if cq == nil || !features.Enabled(features.RequeueStrategy) {
+		return kueue.RequeueStrategyBackoff
+	}
+	return ptr.Deref(cq.Spec.RequeueStrategy, kueue.RequeueStrategyBackoff)
+}
+
 // triggerDeactivation trigger deactivation of workload
 // if a re-queued number has already exceeded the limit of re-queuing backoff.
 // It returns true as a first value if a workload triggered deactivation.
@@ -1238,7 +1556,7 @@ func (r *WorkloadReconciler) Create(e event.TypedCreateEvent[*kueue.Workload]) b
 
 	ctx := ctrl.LoggerInto(context.Background(), log)
 	wlCopy := e.Object.DeepCopy()
-	workload.AdjustResources(ctx, r.client, wlCopy)
+	r.queues.AdjustResources(ctx, wlCopy)
 
 	if dra.NeedsDRAReconcile(e.Object, r.draBackedResources) {
 		log.V(2).Info("Skipping DRA workload in Create event - will be handled in Reconcile")
@@ -1271,6 +1589,7 @@ func (r *WorkloadReconciler) Delete(e event.TypedDeleteEvent[*kueue.Workload]) b
 
 	ctx := ctrl.LoggerInto(context.Background(), log)
 	wlKey := workload.Key(e.Object)
+	r.forgetFallbackRequeue(wlKey)
 
 	// Delete from cache unconditionally. Pending workloads may have been "assumed"
 	// by the scheduler, and leaving them blocks ClusterQueue finalizer removal.
@@ -1311,10 +1630,14 @@ func (r *WorkloadReconciler) Update(e event.TypedUpdateEvent[*kueue.Workload]) b
 	}
 	log.V(2).Info("Workload update event")
 
+	if features.Enabled(features.AdvisoryAdmissionChecks) {
+		r.reportRejectedAdvisoryChecks(e.ObjectOld, e.ObjectNew)
+	}
+
 	wlCopy := e.ObjectNew.DeepCopy()
 	wlKey := workload.Key(e.ObjectNew)
 	// We do not handle old workload here as it will be deleted or replaced by new one anyway.
-	workload.AdjustResources(ctrl.LoggerInto(ctx, log), r.client, wlCopy)
+	r.queues.AdjustResources(ctrl.LoggerInto(ctx, log), wlCopy)
 
 	onHold := workload.IsOnHold(wlCopy)
 
@@ -1485,6 +1808,28 @@ func (r *WorkloadReconciler) reportFinishedWorkload(log logr.Logger, wl *kueue.W
 	}
 }
 
+// reportRejectedAdvisoryChecks records a warning event for the advisory
+// admission checks which transitioned to Rejected, as they don't deactivate
+// the workload.
+func (r *WorkloadReconciler) reportRejectedAdvisoryChecks(oldWl, newWl *kueue.Workload) {
+	var rejected []kueue.AdmissionCheckState
+	for i := range newWl.Status.AdmissionChecks {
+		ac := &newWl.Status.AdmissionChecks[i]
+		if ac.State != kueue.CheckStateRejected || !workload.IsAdvisoryCheck(ac) {
+			continue
+		}
+		if oldAC := admissioncheck.FindAdmissionCheck(oldWl.Status.AdmissionChecks, ac.Name); oldAC != nil && oldAC.State == kueue.CheckStateRejected {
+			continue
+		}
+		rejected = append(rejected, *ac)
+	}
+	if len(rejected) == 0 {
+		return
+	}
+	message := buildAdmissionChecksMessage(rejected, kueue.CheckStateRejected)
+	r.recorder.Eventf(newWl, nil, corev1.EventTypeWarning, "AdvisoryAdmissionCheckRejected", "AdvisoryAdmissionCheckRejected", api.TruncateEventMessage(message))
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	ruh := &resourceUpdatesHandler{r: r}
@@ -1508,7 +1853,8 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Conf
 		Watches(&corev1.LimitRange{}, ruh).
 		Watches(&nodev1.RuntimeClass{}, ruh).
 		Watches(&kueue.ClusterQueue{}, wqh).
-		Watches(&kueue.LocalQueue{}, wqh)
+		Watches(&kueue.LocalQueue{}, wqh).
+		Watches(&kueue.ResourceFlavor{}, wqh)
 	if features.Enabled(features.KueueDRAIntegrationExtendedResource) {
 		if _, err := mgr.GetRESTMapper().RESTMapping(resourcev1.SchemeGroupVersion.WithKind("DeviceClass").GroupKind()); err != nil && apimeta.IsNoMatchError(err) {
 			r.logger().V(2).Info("DeviceClass API not available, skipping DeviceClass watcher")
@@ -1609,7 +1955,7 @@ func (h *resourceUpdatesHandler) queueReconcileForPending(ctx context.Context, q
 		wlCopy := w.DeepCopy()
 		log := log.WithValues("workload", klog.KObj(wlCopy))
 		log.V(5).Info("Queue reconcile for")
-		workload.AdjustResources(ctrl.LoggerInto(ctx, log), h.r.client, wlCopy)
+		h.r.queues.AdjustResources(ctrl.LoggerInto(ctx, log), wlCopy)
 
 		if dra.NeedsDRAReconcile(wlCopy, h.r.draBackedResources) {
 			req := reconcile.Request{
@@ -1679,6 +2025,21 @@ func (w *workloadQueueHandler) Update(ctx context.Context, ev event.UpdateEvent,
 		if !newLq.DeletionTimestamp.IsZero() || !ptr.Equal(oldLq.Spec.StopPolicy, newLq.Spec.StopPolicy) {
 			w.queueReconcileForWorkloadsOfLocalQueue(ctx, newLq, wq)
 		}
+		return
+	}
+
+	oldRf, oldIsRf := ev.ObjectOld.(*kueue.ResourceFlavor)
+	newRf, newIsRf := ev.ObjectNew.(*kueue.ResourceFlavor)
+	if oldIsRf && newIsRf && features.Enabled(features.ResourceFlavorStopPolicy) {
+		log := ctrl.LoggerFrom(ctx).WithValues("resourceFlavor", klog.KObj(ev.ObjectNew))
+		ctx = ctrl.LoggerInto(ctx, log)
+		log.V(5).Info("Workload resource flavor update event")
+
+		if !ptr.Equal(oldRf.Spec.StopPolicy, newRf.Spec.StopPolicy) {
+			for _, cqName := range w.r.cache.ClusterQueuesUsingFlavor(kueue.ResourceFlavorReference(newRf.Name)) {
+				w.queueReconcileForWorkloadsOfClusterQueue(ctx, string(cqName), wq)
+			}
+		}
 	}
 }
 
@@ -1831,7 +2192,7 @@ func (h *deviceClassHandler) reconcileWorkloads(ctx context.Context, q workqueue
 			log.V(3).Info("Requeuing workload due to DeviceClass change", "workload", klog.KObj(w), "resource", name)
 			if !dra.NeedsDRAReconcile(w, h.r.draBackedResources) && workload.IsAdmissible(w) {
 				wlCopy := w.DeepCopy()
-				workload.AdjustResources(ctx, h.r.client, wlCopy)
+				h.r.queues.AdjustResources(ctx, wlCopy)
 				if err := h.r.queues.AddOrUpdateWorkload(log, wlCopy); err != nil {
 					log.Error(err, "Failed to re-add workload to queue after DeviceClass change")
 				}
@@ -1901,7 +2262,7 @@ func (r *WorkloadReconciler) resolveGranularUnadmittedQuotaReservedCondition(
 			return kueue.WorkloadQuotaReservedReasonMisconfigured, fmt.Sprintf("invalid namespace selector: %v", err), nil
 		}
 		wlInfo := workload.NewInfo(wl)
-		admissibilityErr = workload.ValidateAdmissibility(ctx, r.client, wlInfo, selector)
+		_, admissibilityErr = workload.ValidateAdmissibility(ctx, r.client, wlInfo, selector)
 		if admissibilityErr != nil && errors.Is(admissibilityErr, workload.ErrInternal) {
 			return "", "", admissibilityErr
 		}
@@ -1911,6 +2272,8 @@ func (r *WorkloadReconciler) resolveGranularUnadmittedQuotaReservedCondition(
 		return kueue.WorkloadQuotaReservedReasonMisconfigured, admissibilityErr.Error(), nil //nolint:nilerr // admissibility validation failure does not require retry
 	case workload.HasAdmissionGate(wl):
 		return kueue.WorkloadAdmissionGated, fmt.Sprintf("Admission is gated by: %s", wl.Annotations[constants.AdmissionGatedByAnnotation]), nil
+	case workload.IsHeld(wl):
+		return kueue.WorkloadHeld, workload.HeldMessage, nil
 	}
 
 	if shouldCheckEquivalenceHash(cond) {
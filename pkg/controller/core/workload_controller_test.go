@@ -286,6 +286,7 @@ func TestSyncCheckStates(t *testing.T) {
 	cases := map[string]struct {
 		states               []kueue.AdmissionCheckState
 		list                 []kueue.AdmissionCheckReference
+		advisory             []kueue.AdmissionCheckReference
 		wantStates           []kueue.AdmissionCheckState
 		wantChange           bool
 		ignoreTransitionTime bool
@@ -370,11 +371,55 @@ func TestSyncCheckStates(t *testing.T) {
 				},
 			},
 		},
+		"add advisory check": {
+			list:       []kueue.AdmissionCheckReference{"ac1", "ac2"},
+			advisory:   []kueue.AdmissionCheckReference{"ac2"},
+			wantChange: true,
+			wantStates: []kueue.AdmissionCheckState{
+				{
+					Name:  "ac1",
+					State: kueue.CheckStatePending,
+				},
+				{
+					Name:     "ac2",
+					State:    kueue.CheckStatePending,
+					Advisory: new(true),
+				},
+			},
+			ignoreTransitionTime: true,
+		},
+		"sync the advisory field of existing checks": {
+			states: []kueue.AdmissionCheckState{
+				{
+					Name:     "ac1",
+					State:    kueue.CheckStateRejected,
+					Advisory: new(true),
+				},
+				{
+					Name:  "ac2",
+					State: kueue.CheckStatePending,
+				},
+			},
+			list:       []kueue.AdmissionCheckReference{"ac1", "ac2"},
+			advisory:   []kueue.AdmissionCheckReference{"ac2"},
+			wantChange: true,
+			wantStates: []kueue.AdmissionCheckState{
+				{
+					Name:  "ac1",
+					State: kueue.CheckStateRejected,
+				},
+				{
+					Name:     "ac2",
+					State:    kueue.CheckStatePending,
+					Advisory: new(true),
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			gotStates, gotShouldChange := syncAdmissionCheckConditions(tc.states, sets.New(tc.list...), fakeClock)
+			gotStates, gotShouldChange := syncAdmissionCheckConditions(tc.states, sets.New(tc.list...), sets.New(tc.advisory...), fakeClock)
 
 			if tc.wantChange != gotShouldChange {
 				t.Errorf("Unexpected should change, want=%v", tc.wantChange)
@@ -550,6 +595,12 @@ func TestReconcile(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
 	fakeClock := testingclock.NewFakeClock(now)
 
+	// A topology assignment too large to be recorded in the annotation.
+	largeTopologyAssignment := utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname})
+	for i := range 4000 {
+		largeTopologyAssignment.Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{fmt.Sprintf("node-%d", i)}, 1).Obj())
+	}
+
 	cases := map[string]reconcileTestCase{
 		"assign Admission Checks from ClusterQueue.spec.AdmissionCheckStrategy": {
 			workload: utiltestingapi.MakeWorkload("wl", "ns").
@@ -666,6 +717,79 @@ func TestReconcile(t *testing.T) {
 				}).
 				Obj(),
 		},
+		"record the previous topology assignment of an admitted workload": {
+			featureGates: map[featuregate.Feature]bool{features.TASPreferPreviousAssignment: true},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("q1").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "tas-flavor", "1").
+						TopologyAssignment(utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname}).
+							Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"node1"}, 1).Obj()).
+							Obj()).
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				Annotation(kueue.PreviousTopologyAssignmentAnnotation, `{"main":["node1"]}`).
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("q1").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "tas-flavor", "1").
+						TopologyAssignment(utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname}).
+							Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"node1"}, 1).Obj()).
+							Obj()).
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+		},
+		"drop the previous topology assignment when the topology assignment is too large": {
+			featureGates: map[featuregate.Feature]bool{features.TASPreferPreviousAssignment: true},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Annotation(kueue.PreviousTopologyAssignmentAnnotation, `{"main":["node1"]}`).
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("q1").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "tas-flavor", "4000").
+						TopologyAssignment(largeTopologyAssignment.Obj()).
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("q1").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "tas-flavor", "4000").
+						TopologyAssignment(largeTopologyAssignment.Obj()).
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+		},
+		"don't record the previous topology assignment when TASPreferPreviousAssignment is disabled": {
+			featureGates: map[featuregate.Feature]bool{features.TASPreferPreviousAssignment: false},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("q1").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "tas-flavor", "1").
+						TopologyAssignment(utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname}).
+							Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"node1"}, 1).Obj()).
+							Obj()).
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("q1").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "tas-flavor", "1").
+						TopologyAssignment(utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname}).
+							Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"node1"}, 1).Obj()).
+							Obj()).
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+		},
 		"remove finalizer for finished workload": {
 			workload: utiltestingapi.MakeWorkload("unit-test", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
 				Condition(metav1.Condition{
@@ -777,6 +901,191 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		"workload with a pending admission check within its timeout": {
+			featureGates: map[featuregate.Feature]bool{
+				features.AdmissionCheckTimeout:            true,
+				features.UnadmittedWorkloadsObservability: false,
+			},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "flavor1", "1").
+						Obj()).
+					Obj(), now.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			additionalObjects: []client.Object{
+				utiltestingapi.MakeAdmissionCheck("check").AdmissionCheckTimeoutSeconds(300).Obj(),
+			},
+			cq: utiltestingapi.MakeClusterQueue("cq").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("flavor1").Obj()).
+				AdmissionChecks("check").
+				Obj(),
+			lq: utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "flavor1", "1").
+						Obj()).
+					Obj(), now.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: 4 * time.Minute},
+		},
+		"workload with a pending admission check exceeding its timeout is evicted": {
+			featureGates: map[featuregate.Feature]bool{
+				features.AdmissionCheckTimeout:            true,
+				features.UnadmittedWorkloadsObservability: false,
+			},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "flavor1", "1").
+						Obj()).
+					Obj(), now.Add(-10*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			additionalObjects: []client.Object{
+				utiltestingapi.MakeAdmissionCheck("check").AdmissionCheckTimeoutSeconds(300).Obj(),
+			},
+			cq: utiltestingapi.MakeClusterQueue("cq").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("flavor1").Obj()).
+				AdmissionChecks("check").
+				Obj(),
+			lq: utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "flavor1", "1").
+						Obj()).
+					Obj(), now.Add(-10*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByAdmissionCheck,
+					Message: `Exceeded the timeout of AdmissionCheck in Pending state: "check"`,
+				}).
+				SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{Reason: kueue.WorkloadEvictedByAdmissionCheck, Count: 1}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "EvictedDueToAdmissionCheck",
+					Message:   `Exceeded the timeout of AdmissionCheck in Pending state: "check"`,
+				},
+			},
+		},
+		"workload with a pending admission check exceeding its timeout is deactivated": {
+			featureGates: map[featuregate.Feature]bool{
+				features.AdmissionCheckTimeout:            true,
+				features.UnadmittedWorkloadsObservability: false,
+			},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "flavor1", "1").
+						Obj()).
+					Obj(), now.Add(-10*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			additionalObjects: []client.Object{
+				utiltestingapi.MakeAdmissionCheck("check").
+					AdmissionCheckTimeoutSeconds(300).
+					TimeoutAction(kueue.AdmissionCheckTimeoutActionDeactivate).
+					Obj(),
+			},
+			cq: utiltestingapi.MakeClusterQueue("cq").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("flavor1").Obj()).
+				AdmissionChecks("check").
+				Obj(),
+			lq: utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "flavor1", "1").
+						Obj()).
+					Obj(), now.Add(-10*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByAdmissionCheck,
+					Message: `Exceeded the timeout of AdmissionCheck in Pending state: "check"`,
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    "AdmissionCheckTimeout",
+					Message:   `Deactivated due to Exceeded the timeout of AdmissionCheck in Pending state: "check"`,
+				},
+			},
+		},
+		"workload with a pending admission check exceeding its timeout is kept when AdmissionCheckTimeout is disabled": {
+			featureGates: map[featuregate.Feature]bool{
+				features.AdmissionCheckTimeout:            false,
+				features.UnadmittedWorkloadsObservability: false,
+			},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "flavor1", "1").
+						Obj()).
+					Obj(), now.Add(-10*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			additionalObjects: []client.Object{
+				utiltestingapi.MakeAdmissionCheck("check").AdmissionCheckTimeoutSeconds(300).Obj(),
+			},
+			cq: utiltestingapi.MakeClusterQueue("cq").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("flavor1").Obj()).
+				AdmissionChecks("check").
+				Obj(),
+			lq: utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "flavor1", "1").
+						Obj()).
+					Obj(), now.Add(-10*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+		},
 		"should handle finished workload logic for orphaned workloads when FinishOrphanedWorkloads enabled": {
 			featureGates: map[featuregate.Feature]bool{features.FinishOrphanedWorkloads: true},
 			workload: utiltestingapi.MakeWorkload("wl", "ns").
@@ -1476,6 +1785,77 @@ func TestReconcile(t *testing.T) {
 				}).
 				Obj(),
 		},
+		"admitted workload using a drained ResourceFlavor is evicted": {
+			featureGates: map[featuregate.Feature]bool{
+				features.ResourceFlavorStopPolicy: true,
+			},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "retiring", "1").
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+			additionalObjects: []client.Object{
+				utiltestingapi.MakeResourceFlavor("retiring").StopPolicy(kueue.HoldAndDrain).Obj(),
+			},
+			cq: utiltestingapi.MakeClusterQueue("cq").Active(metav1.ConditionTrue).Obj(),
+			lq: utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "retiring", "1").
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByResourceFlavorStopped,
+					Message: "The ResourceFlavor retiring is stopped",
+				}).
+				SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{Reason: kueue.WorkloadEvictedByResourceFlavorStopped, Count: 1}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "EvictedDueToResourceFlavorStopped",
+					Message:   "The ResourceFlavor retiring is stopped",
+				},
+			},
+		},
+		"admitted workload using a held ResourceFlavor keeps running": {
+			featureGates: map[featuregate.Feature]bool{
+				features.ResourceFlavorStopPolicy: true,
+			},
+			workload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "retiring", "1").
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+			additionalObjects: []client.Object{
+				utiltestingapi.MakeResourceFlavor("retiring").StopPolicy(kueue.Hold).Obj(),
+			},
+			cq: utiltestingapi.MakeClusterQueue("cq").Active(metav1.ConditionTrue).Obj(),
+			lq: utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			wantWorkload: utiltestingapi.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "retiring", "1").
+						Obj()).
+					Obj(), now).
+				AdmittedAt(true, now).
+				Obj(),
+		},
 		"inadmissible workload due to broken ClusterQueue namespace selector (observability enabled)": {
 			featureGates: map[featuregate.Feature]bool{
 				features.UnadmittedWorkloadsObservability: true,
@@ -2275,3 +2655,56 @@ func TestUpdateAfsConsumedUsage(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileUnconstrainedTopologyFallback(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, true)
+	features.SetFeatureGateDuringTest(t, features.TASUnconstrainedTopologyFallback, true)
+	now := time.Now().Truncate(time.Second)
+	created := now.Add(-5 * time.Minute)
+	fakeClock := testingclock.NewFakeClock(now)
+
+	wl := utiltestingapi.MakeWorkload("wl", "ns").
+		Creation(created).
+		PodSets(
+			*utiltestingapi.MakePodSet("short", 1).
+				Annotations(map[string]string{kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "1m"}).
+				RequiredTopologyRequest(corev1.LabelHostname).
+				Obj(),
+			*utiltestingapi.MakePodSet("long", 1).
+				Annotations(map[string]string{kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "10m"}).
+				RequiredTopologyRequest(corev1.LabelHostname).
+				Obj(),
+		).
+		Obj()
+
+	cl := utiltesting.NewClientBuilder().Build()
+	cqCache := schdcache.New(cl)
+	qManager := qcache.NewManagerForUnitTests(cl, cqCache, qcache.WithClock(fakeClock))
+	reconciler := NewWorkloadReconciler(cl, qManager, cqCache, &utiltesting.EventRecorder{},
+		WithPreemptionExpectations(preemptexpectations.New()))
+	reconciler.clock = fakeClock
+	ctx, _ := utiltesting.ContextWithLog(t)
+
+	if got := reconciler.reconcileUnconstrainedTopologyFallback(ctx, wl); got.RequeueAfter != 5*time.Minute {
+		t.Errorf("Unexpected requeue after the first fallback, got %v, want %v", got.RequeueAfter, 5*time.Minute)
+	}
+	if got, want := reconciler.fallbackRequeues[workload.Key(wl)], created.Add(time.Minute); !got.Equal(want) {
+		t.Errorf("Unexpected recorded fallback, got %v, want %v", got, want)
+	}
+	if reconciler.recordFallbackRequeue(workload.Key(wl), created.Add(time.Minute)) {
+		t.Error("The workload is requeued again for the same fallback")
+	}
+
+	fakeClock.Step(5 * time.Minute)
+	if got := reconciler.reconcileUnconstrainedTopologyFallback(ctx, wl); got.RequeueAfter != 0 {
+		t.Errorf("Unexpected requeue after the last fallback, got %v", got.RequeueAfter)
+	}
+	if got, want := reconciler.fallbackRequeues[workload.Key(wl)], created.Add(10*time.Minute); !got.Equal(want) {
+		t.Errorf("Unexpected recorded fallback, got %v, want %v", got, want)
+	}
+
+	reconciler.Delete(event.TypedDeleteEvent[*kueue.Workload]{Object: wl})
+	if _, found := reconciler.fallbackRequeues[workload.Key(wl)]; found {
+		t.Error("The fallback requeues of the deleted workload are not forgotten")
+	}
+}
@@ -36,6 +36,8 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta2"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 )
 
@@ -66,27 +68,81 @@ func (r *WorkloadPriorityClassReconciler) logger() logr.Logger {
 
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloadpriorityclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;update
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=list;get;watch
 
 func (r *WorkloadPriorityClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var wpc kueue.WorkloadPriorityClass
 	if err := r.client.Get(ctx, req.NamespacedName, &wpc); err != nil {
-		// we'll ignore not-found errors, since there is nothing to do.
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		if !apierrors.IsNotFound(err) || !features.Enabled(features.ResetPriorityOnWorkloadPriorityClassDeletion) {
+			// we'll ignore not-found errors, since there is nothing to do.
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		log := ctrl.LoggerFrom(ctx).WithValues("workloadPriorityClass", klog.KRef("", req.Name))
+		log.V(2).Info("Reconcile deleted WorkloadPriorityClass")
+
+		// The workloads referencing a deleted WorkloadPriorityClass fall back to
+		// the priority they would have without a WorkloadPriorityClass: the one of
+		// the PriorityClass of their pods, or the default priority.
+		return ctrl.Result{}, r.updateWorkloadsPriority(ctx, log, req.Name, r.podPriorityResolver(ctx))
 	}
 
 	log := ctrl.LoggerFrom(ctx).WithValues("workloadPriorityClass", klog.KObj(&wpc))
 	log.V(2).Info("Reconcile WorkloadPriorityClass")
+	return ctrl.Result{}, r.updateWorkloadsPriority(ctx, log, wpc.Name, func(*kueue.Workload) (int32, error) {
+		return wpc.Value, nil
+	})
+}
+
+// podPriorityResolver returns a function which resolves the priority of a
+// workload from the PriorityClass of its pods, or the default priority if the
+// pods don't have one or if it doesn't exist. The priorities are resolved once
+// per PriorityClass name.
+func (r *WorkloadPriorityClassReconciler) podPriorityResolver(ctx context.Context) func(*kueue.Workload) (int32, error) {
+	resolved := make(map[string]int32)
+	var resolve func(string) (int32, error)
+	resolve = func(name string) (int32, error) {
+		if value, found := resolved[name]; found {
+			return value, nil
+		}
+		_, value, err := priority.GetPriorityFromPriorityClass(ctx, r.client, name)
+		if apierrors.IsNotFound(err) && name != "" {
+			value, err = resolve("")
+		}
+		if err != nil {
+			return 0, err
+		}
+		resolved[name] = value
+		return value, nil
+	}
+	return func(wl *kueue.Workload) (int32, error) {
+		return resolve(podSetsPriorityClassName(wl.Spec.PodSets))
+	}
+}
 
+// podSetsPriorityClassName returns the first PriorityClass name set in the
+// pod templates of the podSets.
+func podSetsPriorityClassName(podSets []kueue.PodSet) string {
+	for i := range podSets {
+		if name := podSets[i].Template.Spec.PriorityClassName; name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// updateWorkloadsPriority sets the priority of the workloads referencing the
+// WorkloadPriorityClass to the value returned by priorityFn.
+func (r *WorkloadPriorityClassReconciler) updateWorkloadsPriority(ctx context.Context, log logr.Logger, wpcName string, priorityFn func(*kueue.Workload) (int32, error)) error {
 	// List all workloads using this WorkloadPriorityClass
 	var workloads kueue.WorkloadList
 	if err := r.client.List(ctx, &workloads,
-		client.MatchingFields{indexer.WorkloadPriorityClassKey: wpc.Name}); err != nil {
+		client.MatchingFields{indexer.WorkloadPriorityClassKey: wpcName}); err != nil {
 		log.Error(err, "Failed to list workloads for WorkloadPriorityClass")
-		return ctrl.Result{}, err
+		return err
 	}
 	if len(workloads.Items) == 0 {
 		log.V(2).Info("No workloads using this WorkloadPriorityClass")
-		return ctrl.Result{}, nil
+		return nil
 	}
 
 	var updateErrors []error
@@ -96,13 +152,20 @@ func (r *WorkloadPriorityClassReconciler) Reconcile(ctx context.Context, req ctr
 		wl := &workloads.Items[i]
 		wlLog := log.WithValues("workload", klog.KObj(wl))
 
+		value, err := priorityFn(wl)
+		if err != nil {
+			wlLog.Error(err, "Failed to resolve workload priority")
+			updateErrors = append(updateErrors, err)
+			continue
+		}
+
 		// Skip if priority is already up to date
-		if wl.Spec.Priority != nil && *wl.Spec.Priority == wpc.Value {
+		if wl.Spec.Priority != nil && *wl.Spec.Priority == value {
 			wlLog.V(3).Info("Workload priority already up to date")
 			continue
 		}
 
-		wl.Spec.Priority = new(wpc.Value)
+		wl.Spec.Priority = new(value)
 
 		if err := r.client.Update(ctx, wl); err != nil {
 			if !apierrors.IsNotFound(err) {
@@ -112,9 +175,9 @@ func (r *WorkloadPriorityClassReconciler) Reconcile(ctx context.Context, req ctr
 			continue
 		}
 
-		wlLog.V(2).Info("Updated workload priority", "newPriority", wpc.Value)
+		wlLog.V(2).Info("Updated workload priority", "newPriority", value)
 	}
-	return ctrl.Result{}, errors.Join(updateErrors...)
+	return errors.Join(updateErrors...)
 }
 
 func (r *WorkloadPriorityClassReconciler) Create(e event.TypedCreateEvent[*kueue.WorkloadPriorityClass]) bool {
@@ -127,7 +190,15 @@ func (r *WorkloadPriorityClassReconciler) Create(e event.TypedCreateEvent[*kueue
 }
 
 func (r *WorkloadPriorityClassReconciler) Delete(e event.TypedDeleteEvent[*kueue.WorkloadPriorityClass]) bool {
-	return false
+	if !features.Enabled(features.ResetPriorityOnWorkloadPriorityClassDeletion) {
+		return false
+	}
+	log := r.logger().WithValues("workloadPriorityClass", klog.KObj(e.Object))
+	log.V(2).Info("WorkloadPriorityClass delete event")
+
+	// The workloads still referencing the WorkloadPriorityClass need to be
+	// reset to the default priority.
+	return true
 }
 
 func (r *WorkloadPriorityClassReconciler) Update(e event.TypedUpdateEvent[*kueue.WorkloadPriorityClass]) bool {
@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-base/featuregate"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -31,16 +32,18 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
 )
 
 func TestWorkloadPriorityClassPredicates(t *testing.T) {
 	cases := map[string]struct {
-		eventType string
-		oldWPC    *kueue.WorkloadPriorityClass
-		newWPC    *kueue.WorkloadPriorityClass
-		want      bool
+		eventType    string
+		oldWPC       *kueue.WorkloadPriorityClass
+		newWPC       *kueue.WorkloadPriorityClass
+		featureGates map[featuregate.Feature]bool
+		want         bool
 	}{
 		"create event should trigger reconcile": {
 			eventType: "create",
@@ -52,6 +55,12 @@ func TestWorkloadPriorityClassPredicates(t *testing.T) {
 			oldWPC:    utiltestingapi.MakeWorkloadPriorityClass("test").PriorityValue(100).Obj(),
 			want:      false,
 		},
+		"delete event should trigger reconcile when ResetPriorityOnWorkloadPriorityClassDeletion is enabled": {
+			eventType:    "delete",
+			oldWPC:       utiltestingapi.MakeWorkloadPriorityClass("test").PriorityValue(100).Obj(),
+			featureGates: map[featuregate.Feature]bool{features.ResetPriorityOnWorkloadPriorityClassDeletion: true},
+			want:         true,
+		},
 		"update event with changed priority should trigger reconcile": {
 			eventType: "update",
 			oldWPC:    utiltestingapi.MakeWorkloadPriorityClass("test").PriorityValue(100).Obj(),
@@ -73,6 +82,7 @@ func TestWorkloadPriorityClassPredicates(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGatesDuringTest(t, tc.featureGates)
 			reconciler := NewWorkloadPriorityClassReconciler(nil, nil)
 			var got bool
 
@@ -293,3 +303,136 @@ func TestWorkloadPriorityClassReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkloadPriorityClassReconcileDeleted(t *testing.T) {
+	defaultPriorityClass := utiltesting.MakePriorityClass("default-pc").PriorityValue(50).Obj()
+	defaultPriorityClass.GlobalDefault = true
+
+	cases := map[string]struct {
+		featureGates  map[featuregate.Feature]bool
+		objects       []client.Object
+		workloads     []kueue.Workload
+		wantWorkloads []kueue.Workload
+	}{
+		"reconcile resets workload priority to zero when there is no default priority class": {
+			featureGates: map[featuregate.Feature]bool{features.ResetPriorityOnWorkloadPriorityClassDeletion: true},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(1000).
+					WorkloadPriorityClassRef("high").
+					Obj(),
+				*utiltestingapi.MakeWorkload("wl2", "default").
+					Priority(100).
+					WorkloadPriorityClassRef("low").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(0).
+					WorkloadPriorityClassRef("high").
+					Obj(),
+				*utiltestingapi.MakeWorkload("wl2", "default").
+					Priority(100).
+					WorkloadPriorityClassRef("low").
+					Obj(),
+			},
+		},
+		"reconcile resets workload priority to the default priority class": {
+			featureGates: map[featuregate.Feature]bool{features.ResetPriorityOnWorkloadPriorityClassDeletion: true},
+			objects:      []client.Object{defaultPriorityClass},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(1000).
+					WorkloadPriorityClassRef("high").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(50).
+					WorkloadPriorityClassRef("high").
+					Obj(),
+			},
+		},
+		"reconcile resets workload priority to the priority class of its pods": {
+			featureGates: map[featuregate.Feature]bool{features.ResetPriorityOnWorkloadPriorityClassDeletion: true},
+			objects: []client.Object{
+				defaultPriorityClass,
+				utiltesting.MakePriorityClass("pod-pc").PriorityValue(200).Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(1000).
+					WorkloadPriorityClassRef("high").
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).PriorityClass("pod-pc").Obj()).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(200).
+					WorkloadPriorityClassRef("high").
+					Obj(),
+			},
+		},
+		"reconcile resets workload priority to the default priority class when the priority class of its pods doesn't exist": {
+			featureGates: map[featuregate.Feature]bool{features.ResetPriorityOnWorkloadPriorityClassDeletion: true},
+			objects:      []client.Object{defaultPriorityClass},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(1000).
+					WorkloadPriorityClassRef("high").
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).PriorityClass("missing-pc").Obj()).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(50).
+					WorkloadPriorityClassRef("high").
+					Obj(),
+			},
+		},
+		"reconcile keeps workload priority when ResetPriorityOnWorkloadPriorityClassDeletion is disabled": {
+			featureGates: map[featuregate.Feature]bool{features.ResetPriorityOnWorkloadPriorityClassDeletion: false},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(1000).
+					WorkloadPriorityClassRef("high").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("wl1", "default").
+					Priority(1000).
+					WorkloadPriorityClassRef("high").
+					Obj(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGatesDuringTest(t, tc.featureGates)
+			ctx := t.Context()
+
+			builder := utiltesting.NewClientBuilder().
+				WithObjects(tc.objects...).
+				WithIndex(&kueue.Workload{}, indexer.WorkloadPriorityClassKey, indexer.IndexWorkloadPriorityClass)
+			for i := range tc.workloads {
+				builder = builder.WithObjects(&tc.workloads[i])
+			}
+			k8sClient := builder.Build()
+
+			reconciler := NewWorkloadPriorityClassReconciler(k8sClient, nil)
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "high"}}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			for _, wantWl := range tc.wantWorkloads {
+				gotWl := &kueue.Workload{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: wantWl.Name, Namespace: wantWl.Namespace}, gotWl); err != nil {
+					t.Fatalf("failed to get workload %s: %v", wantWl.Name, err)
+				}
+				if diff := cmp.Diff(wantWl.Spec.Priority, gotWl.Spec.Priority); diff != "" {
+					t.Errorf("workload %s priority mismatch (-want +got):\n%s", wantWl.Name, diff)
+				}
+			}
+		})
+	}
+}
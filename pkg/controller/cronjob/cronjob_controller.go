@@ -0,0 +1,308 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	workloadjob "sigs.k8s.io/kueue/pkg/controller/jobs/job"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	"sigs.k8s.io/kueue/pkg/util/roletracker"
+)
+
+const (
+	ControllerName = "CronJobQuotaReservation"
+
+	// reasonInvalidQuotaReservation is the reason of the event emitted for
+	// CronJobs whose quota reservation can't be set up.
+	reasonInvalidQuotaReservation = "InvalidQuotaReservation"
+
+	// reasonReservedQuota is the reason of the event emitted when the Workload
+	// of the next run of a CronJob is created.
+	reasonReservedQuota = "ReservedQuota"
+
+	// minClaimTimeout is the minimum time after the scheduled time of a run
+	// for its Job to claim the reserved Workload, before the Workload is deleted.
+	minClaimTimeout = time.Minute
+)
+
+// Reconciler creates the Workload of the next run of the CronJobs with the
+// kueue.x-k8s.io/quota-reservation-lead-time annotation ahead of the scheduled
+// time. The Job webhook sets it as the prebuilt Workload of the Job created
+// for the run. This lets Kueue reserve quota for the run before its Job is created.
+type Reconciler struct {
+	client      client.Client
+	record      events.EventRecorder
+	clock       clock.Clock
+	roleTracker *roletracker.RoleTracker
+}
+
+type Option func(*Reconciler)
+
+// WithClock sets the clock of the reconciler.
+func WithClock(c clock.Clock) Option {
+	return func(r *Reconciler) {
+		r.clock = c
+	}
+}
+
+// WithRoleTracker sets the roleTracker of the reconciler.
+func WithRoleTracker(tracker *roletracker.RoleTracker) Option {
+	return func(r *Reconciler) {
+		r.roleTracker = tracker
+	}
+}
+
+func NewReconciler(c client.Client, record events.EventRecorder, opts ...Option) *Reconciler {
+	r := &Reconciler{
+		client: c,
+		record: record,
+		clock:  clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;watch;update;patch
+
+func SetupWithManager(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) (string, error) {
+	r := NewReconciler(mgr.GetClient(), mgr.GetEventRecorder(ControllerName), WithRoleTracker(roleTracker))
+	return ControllerName, ctrl.NewControllerManagedBy(mgr).
+		Named("cronjob_quota_reservation").
+		For(&batchv1.CronJob{}).
+		Watches(&kueue.Workload{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &batchv1.CronJob{})).
+		WithLogConstructor(roletracker.NewLogConstructor(r.roleTracker, ControllerName)).
+		Complete(r)
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	cronJob := &batchv1.CronJob{}
+	if err := r.client.Get(ctx, req.NamespacedName, cronJob); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile CronJob")
+
+	now := r.clock.Now()
+	leadTime, nextRun, err := r.nextReservation(cronJob, now)
+	if err != nil {
+		log.V(2).Info("Unable to reserve quota for the next run", "error", err)
+		r.record.Eventf(cronJob, nil, corev1.EventTypeWarning, reasonInvalidQuotaReservation, "ReserveQuota", "%s", err.Error())
+	}
+
+	reserved, err := r.reservedWorkloads(ctx, cronJob)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	var nextName string
+	if !nextRun.IsZero() {
+		nextName = workloadNameForRun(cronJob, nextRun)
+	}
+	var requeueAfter time.Duration
+	updateRequeue := func(d time.Duration) {
+		if d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+			requeueAfter = d
+		}
+	}
+
+	// Release the quota reserved for the runs whose Job didn't claim the
+	// Workload in time, for example, because the run was skipped.
+	claimTimeout := claimTimeoutFor(cronJob)
+	var nextReserved bool
+	for i := range reserved {
+		wl := &reserved[i]
+		if wl.Name == nextName {
+			nextReserved = true
+			continue
+		}
+		if !nextRun.IsZero() {
+			expiresIn := wl.CreationTimestamp.Add(leadTime + claimTimeout).Sub(now)
+			if expiresIn > 0 {
+				updateRequeue(expiresIn)
+				continue
+			}
+		}
+		log.V(2).Info("Deleting the unclaimed Workload reserved for a run", "workload", klog.KObj(wl))
+		if err := r.client.Delete(ctx, wl); client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if nextRun.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	if reserveIn := nextRun.Add(-leadTime).Sub(now); reserveIn > 0 {
+		updateRequeue(reserveIn)
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+	if !nextReserved {
+		if err := r.createWorkload(ctx, cronJob, nextName); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.V(2).Info("Reserved quota for the next run", "workload", klog.KRef(cronJob.Namespace, nextName), "scheduledTime", nextRun)
+		r.record.Eventf(cronJob, nil, corev1.EventTypeNormal, reasonReservedQuota, "ReserveQuota",
+			"Created Workload %s for the run scheduled at %s", nextName, nextRun.Format(time.RFC3339))
+	}
+	// Reconcile at the scheduled time, to reserve quota for the following run.
+	updateRequeue(nextRun.Sub(now))
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// nextReservation returns the lead time of the quota reservation and the
+// scheduled time of the next run of the CronJob, or the zero time if quota
+// shouldn't be reserved for the next run.
+func (r *Reconciler) nextReservation(cronJob *batchv1.CronJob, now time.Time) (time.Duration, time.Time, error) {
+	value, found := cronJob.Annotations[controllerconstants.QuotaReservationLeadTimeAnnotation]
+	if !found || ptr.Deref(cronJob.Spec.Suspend, false) || !cronJob.DeletionTimestamp.IsZero() {
+		return 0, time.Time{}, nil
+	}
+	leadTime, err := time.ParseDuration(value)
+	if err != nil || leadTime <= 0 {
+		return 0, time.Time{}, fmt.Errorf("invalid %s annotation %q: must be a positive duration", controllerconstants.QuotaReservationLeadTimeAnnotation, value)
+	}
+	if cronJob.Spec.JobTemplate.Labels[controllerconstants.QueueLabel] == "" {
+		return 0, time.Time{}, fmt.Errorf("the job template doesn't have the %s label", controllerconstants.QueueLabel)
+	}
+	spec := cronJob.Spec.Schedule
+	if cronJob.Spec.TimeZone != nil {
+		spec = fmt.Sprintf("CRON_TZ=%s %s", *cronJob.Spec.TimeZone, spec)
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	return leadTime, schedule.Next(now), nil
+}
+
+// reservedWorkloads returns the Workloads created for the runs of the CronJob
+// which are not claimed by a Job yet. It also removes the CronJob from the
+// owners of the claimed Workloads, which are owned by their Jobs instead.
+func (r *Reconciler) reservedWorkloads(ctx context.Context, cronJob *batchv1.CronJob) ([]kueue.Workload, error) {
+	var workloads kueue.WorkloadList
+	if err := r.client.List(ctx, &workloads, client.InNamespace(cronJob.Namespace),
+		client.MatchingFields{indexer.OwnerReferenceUID: string(cronJob.UID)}); err != nil {
+		return nil, err
+	}
+	reserved := make([]kueue.Workload, 0, len(workloads.Items))
+	for i := range workloads.Items {
+		wl := &workloads.Items[i]
+		if metav1.GetControllerOfNoCopy(wl) == nil {
+			reserved = append(reserved, *wl)
+			continue
+		}
+		if err := r.releaseOwnership(ctx, cronJob, wl); err != nil {
+			return nil, err
+		}
+	}
+	return reserved, nil
+}
+
+// releaseOwnership removes the CronJob from the owners of a Workload claimed
+// by the Job of a run.
+func (r *Reconciler) releaseOwnership(ctx context.Context, cronJob *batchv1.CronJob, wl *kueue.Workload) error {
+	ctrl.LoggerFrom(ctx).V(3).Info("Removing the CronJob from the owners of the claimed Workload", "workload", klog.KObj(wl))
+	err := clientutil.Patch(ctx, r.client, wl, func() (bool, error) {
+		wl.OwnerReferences = slices.DeleteFunc(wl.OwnerReferences, func(ref metav1.OwnerReference) bool {
+			return ref.UID == cronJob.UID
+		})
+		return true, nil
+	})
+	return client.IgnoreNotFound(err)
+}
+
+// createWorkload creates the Workload of the next run from the job template
+// of the CronJob, so that it matches the Job created for the run.
+func (r *Reconciler) createWorkload(ctx context.Context, cronJob *batchv1.CronJob, name string) error {
+	job := &batchv1.Job{
+		ObjectMeta: *cronJob.Spec.JobTemplate.ObjectMeta.DeepCopy(),
+		Spec:       *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+	job.Namespace = cronJob.Namespace
+	// Default the counts as the API server does for the Jobs.
+	if job.Spec.Parallelism == nil {
+		job.Spec.Parallelism = ptr.To[int32](1)
+		if job.Spec.Completions == nil {
+			job.Spec.Completions = ptr.To[int32](1)
+		}
+	}
+	podSets, err := (*workloadjob.Job)(job).PodSets(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	wl := &kueue.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cronJob.Namespace,
+			Labels: map[string]string{
+				controllerconstants.JobUIDLabel: string(cronJob.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: batchv1.SchemeGroupVersion.String(),
+				Kind:       "CronJob",
+				Name:       cronJob.Name,
+				UID:        cronJob.UID,
+			}},
+		},
+		Spec: kueue.WorkloadSpec{
+			PodSets:   podSets,
+			QueueName: jobframework.QueueNameForObject(job),
+		},
+	}
+	if err := jobframework.PrepareWorkloadPriority(ctx, r.client, job, wl, nil); err != nil {
+		return err
+	}
+	return client.IgnoreAlreadyExists(r.client.Create(ctx, wl))
+}
+
+// workloadNameForRun returns the name of the Workload reserved for the run
+// scheduled at the given time, based on the name of the Job created for the
+// run, which the CronJob controller names after the scheduled time in minutes.
+func workloadNameForRun(cronJob *batchv1.CronJob, scheduledTime time.Time) string {
+	return workloadjob.ReservedWorkloadNameForCronJobRun(fmt.Sprintf("%s-%d", cronJob.Name, scheduledTime.Unix()/60))
+}
+
+// claimTimeoutFor returns how long after the scheduled time of a run its Job
+// can claim the reserved Workload.
+func claimTimeoutFor(cronJob *batchv1.CronJob) time.Duration {
+	return max(minClaimTimeout, time.Duration(ptr.Deref(cronJob.Spec.StartingDeadlineSeconds, 0))*time.Second)
+}
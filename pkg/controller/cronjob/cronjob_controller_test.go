@@ -0,0 +1,247 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func TestReconcile(t *testing.T) {
+	// The CronJob runs hourly, the next run is scheduled at 12:00.
+	nextRun := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	nextName := fmt.Sprintf("cronjob-sample-%d", nextRun.Unix()/60)
+	prevName := fmt.Sprintf("cronjob-sample-%d", nextRun.Add(-time.Hour).Unix()/60)
+
+	baseCronJob := func() *batchv1.CronJob {
+		return &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample",
+				Namespace: "ns",
+				UID:       "cronjob-uid",
+				Annotations: map[string]string{
+					controllerconstants.QuotaReservationLeadTimeAnnotation: "15m",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "0 * * * *",
+				JobTemplate: batchv1.JobTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{controllerconstants.QueueLabel: "lq"},
+					},
+					Spec: batchv1.JobSpec{
+						Parallelism: ptr.To[int32](2),
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								RestartPolicy: corev1.RestartPolicyNever,
+								Containers:    []corev1.Container{{Name: "c", Image: "pause"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	reservedWorkload := func(name string, created time.Time) *kueue.Workload {
+		return utiltestingapi.MakeWorkload(name, "ns").
+			Label(controllerconstants.JobUIDLabel, "cronjob-uid").
+			OwnerReference(batchv1.SchemeGroupVersion.WithKind("CronJob"), "sample", "cronjob-uid").
+			Creation(created).
+			Obj()
+	}
+
+	type workloadSummary struct {
+		Name      string
+		QueueName kueue.LocalQueueName
+		Count     int32
+		Labels    map[string]string
+		Owners    []string
+	}
+
+	cases := map[string]struct {
+		now           time.Time
+		cronJob       *batchv1.CronJob
+		workloads     []kueue.Workload
+		wantWorkloads []workloadSummary
+		wantResult    reconcile.Result
+		wantEvents    []utiltesting.EventRecord
+	}{
+		"before the lead time of the next run": {
+			now:        nextRun.Add(-20 * time.Minute),
+			cronJob:    baseCronJob(),
+			wantResult: reconcile.Result{RequeueAfter: 5 * time.Minute},
+		},
+		"at the scheduled time minus the lead time": {
+			now:     nextRun.Add(-15 * time.Minute),
+			cronJob: baseCronJob(),
+			wantWorkloads: []workloadSummary{{
+				Name:      nextName,
+				QueueName: "lq",
+				Count:     2,
+				Labels:    map[string]string{controllerconstants.JobUIDLabel: "cronjob-uid"},
+				Owners:    []string{"sample"},
+			}},
+			wantResult: reconcile.Result{RequeueAfter: 15 * time.Minute},
+			wantEvents: []utiltesting.EventRecord{
+				utiltesting.MakeEventRecord("ns", "sample", reasonReservedQuota, corev1.EventTypeNormal).
+					Message("Created Workload " + nextName + " for the run scheduled at 2026-01-01T12:00:00Z").
+					Obj(),
+			},
+		},
+		"the next run is already reserved": {
+			now:       nextRun.Add(-10 * time.Minute),
+			cronJob:   baseCronJob(),
+			workloads: []kueue.Workload{*reservedWorkload(nextName, nextRun.Add(-15*time.Minute))},
+			wantWorkloads: []workloadSummary{{
+				Name:   nextName,
+				Count:  1,
+				Labels: map[string]string{controllerconstants.JobUIDLabel: "cronjob-uid"},
+				Owners: []string{"sample"},
+			}},
+			wantResult: reconcile.Result{RequeueAfter: 10 * time.Minute},
+		},
+		"the CronJob is removed from the owners of the claimed Workload": {
+			now:     nextRun.Add(-50 * time.Minute),
+			cronJob: baseCronJob(),
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload(prevName, "ns").
+					Label(controllerconstants.JobUIDLabel, "job-uid").
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("CronJob"), "sample", "cronjob-uid").
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "sample-29456940", "job-uid").
+					Creation(nextRun.Add(-75 * time.Minute)).
+					Obj(),
+			},
+			wantWorkloads: []workloadSummary{{
+				Name:   prevName,
+				Count:  1,
+				Labels: map[string]string{controllerconstants.JobUIDLabel: "job-uid"},
+				Owners: []string{"sample-29456940"},
+			}},
+			wantResult: reconcile.Result{RequeueAfter: 35 * time.Minute},
+		},
+		"the unclaimed reservation of a previous run expires": {
+			now:        nextRun.Add(-50 * time.Minute),
+			cronJob:    baseCronJob(),
+			workloads:  []kueue.Workload{*reservedWorkload(prevName, nextRun.Add(-75*time.Minute))},
+			wantResult: reconcile.Result{RequeueAfter: 35 * time.Minute},
+		},
+		"the unclaimed reservation of a previous run is kept until the claim timeout": {
+			now:       nextRun.Add(-59*time.Minute - 30*time.Second),
+			cronJob:   baseCronJob(),
+			workloads: []kueue.Workload{*reservedWorkload(prevName, nextRun.Add(-75*time.Minute))},
+			wantWorkloads: []workloadSummary{{
+				Name:   prevName,
+				Count:  1,
+				Labels: map[string]string{controllerconstants.JobUIDLabel: "cronjob-uid"},
+				Owners: []string{"sample"},
+			}},
+			wantResult: reconcile.Result{RequeueAfter: 30 * time.Second},
+		},
+		"the reservation is released when the CronJob is suspended": {
+			now: nextRun.Add(-10 * time.Minute),
+			cronJob: func() *batchv1.CronJob {
+				cj := baseCronJob()
+				cj.Spec.Suspend = ptr.To(true)
+				return cj
+			}(),
+			workloads: []kueue.Workload{*reservedWorkload(nextName, nextRun.Add(-15*time.Minute))},
+		},
+		"invalid lead time": {
+			now: nextRun.Add(-10 * time.Minute),
+			cronJob: func() *batchv1.CronJob {
+				cj := baseCronJob()
+				cj.Annotations[controllerconstants.QuotaReservationLeadTimeAnnotation] = "soon"
+				return cj
+			}(),
+			wantEvents: []utiltesting.EventRecord{
+				utiltesting.MakeEventRecord("ns", "sample", reasonInvalidQuotaReservation, corev1.EventTypeWarning).
+					Message(`invalid kueue.x-k8s.io/quota-reservation-lead-time annotation "soon": must be a positive duration`).
+					Obj(),
+			},
+		},
+		"without the annotation": {
+			now: nextRun.Add(-10 * time.Minute),
+			cronJob: func() *batchv1.CronJob {
+				cj := baseCronJob()
+				cj.Annotations = nil
+				return cj
+			}(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			kClient := utiltesting.NewClientBuilder().
+				WithObjects(tc.cronJob).
+				WithLists(&kueue.WorkloadList{Items: tc.workloads}).
+				Build()
+			recorder := &utiltesting.EventRecorder{}
+			reconciler := NewReconciler(kClient, recorder, WithClock(testingclock.NewFakeClock(tc.now)))
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.cronJob)})
+			if err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantResult, result); diff != "" {
+				t.Errorf("Unexpected result (-want,+got):\n%s", diff)
+			}
+
+			var gotWorkloads kueue.WorkloadList
+			if err := kClient.List(ctx, &gotWorkloads); err != nil {
+				t.Fatalf("Could not list Workloads: %v", err)
+			}
+			gotSummaries := make([]workloadSummary, 0, len(gotWorkloads.Items))
+			for _, wl := range gotWorkloads.Items {
+				summary := workloadSummary{
+					Name:      wl.Name,
+					QueueName: wl.Spec.QueueName,
+					Labels:    wl.Labels,
+				}
+				for _, ps := range wl.Spec.PodSets {
+					summary.Count += ps.Count
+				}
+				for _, ref := range wl.OwnerReferences {
+					summary.Owners = append(summary.Owners, ref.Name)
+				}
+				gotSummaries = append(gotSummaries, summary)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, gotSummaries, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected Workloads (-want,+got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
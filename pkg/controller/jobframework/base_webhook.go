@@ -18,6 +18,7 @@ package jobframework
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/labels"
@@ -29,6 +30,8 @@ import (
 
 	qcache "sigs.k8s.io/kueue/pkg/cache/queue"
 	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utilqueue "sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	"sigs.k8s.io/kueue/pkg/util/webhook"
 )
@@ -92,7 +95,10 @@ func (w *BaseWebhook[T]) ValidateCreate(ctx context.Context, obj T) (admission.W
 		}
 		allErrs = append(allErrs, validationErrs...)
 	}
-	return nil, allErrs.ToAggregate()
+	if len(allErrs) > 0 {
+		return nil, allErrs.ToAggregate()
+	}
+	return QuotaWarnings(ctx, job, w.Client, w.Queues, w.Cache), nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -117,6 +123,34 @@ func (w *BaseWebhook[T]) ValidateDelete(context.Context, T) (admission.Warnings,
 	return nil, nil
 }
 
+// QuotaWarnings returns admission warnings for the PodSets of the job whose
+// per-pod requests don't fit in any flavor of the ClusterQueue targeted by
+// the job's LocalQueue, even when borrowing all the quota allowed.
+// Such jobs are not rejected, as the quotas of the ClusterQueue can change.
+func QuotaWarnings(ctx context.Context, job GenericJob, c client.Client, queues *qcache.Manager, cache *schdcache.Cache) admission.Warnings {
+	queueName := QueueName(job)
+	if queueName == "" || queues == nil || cache == nil {
+		return nil
+	}
+	cqName, ok := queues.ClusterQueueFromLocalQueue(utilqueue.NewLocalQueueReference(job.Object().GetNamespace(), queueName))
+	if !ok {
+		return nil
+	}
+	podSets, err := JobPodSets(ctx, job, c)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).V(3).Info("Skipping the quota check", "error", err)
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, ps := range podSets {
+		exceeding := cache.ResourcesExceedingFlavorQuotas(cqName, resources.NewRequestsFromPodSpec(&ps.Template.Spec))
+		if len(exceeding) > 0 {
+			warnings = append(warnings, fmt.Sprintf("the requests of a pod in podSet %q exceed the quota of every flavor in ClusterQueue %q for resources %v; the job can't be admitted unless the quotas are increased", ps.Name, cqName, exceeding))
+		}
+	}
+	return warnings
+}
+
 // WebhookLogConstructor adds group, kind and replicaRole information to the base log.
 func WebhookLogConstructor(gvk schema.GroupVersionKind, roleTracker *roletracker.RoleTracker) func(base logr.Logger, req *admission.Request) logr.Logger {
 	return func(base logr.Logger, req *admission.Request) logr.Logger {
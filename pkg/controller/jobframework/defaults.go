@@ -92,6 +92,9 @@ func ApplyDefaultWorkloadPriorityClass(ctx context.Context, c client.Client, job
 }
 
 func ApplyDefaultForManagedBy(job GenericJob, queues *qcache.Manager, cache *schdcache.Cache, log logr.Logger) {
+	if IsOptedOutForObject(job.Object()) {
+		return
+	}
 	if managedJob, ok := job.(JobWithManagedBy); ok {
 		if managedJob.CanDefaultManagedBy() {
 			localQueueName, found := job.Object().GetLabels()[constants.QueueLabel]
@@ -29,4 +29,7 @@ const (
 	ReasonErrWorkloadCompose    = "ErrWorkloadCompose"
 	ReasonUpdatedAdmissionCheck = "UpdatedAdmissionCheck"
 	ReasonJobNestingTooDeep     = "JobNestingTooDeep"
+	ReasonMovedToFallbackQueue  = "MovedToFallbackQueue"
+	ReasonMovedToCandidateQueue = "MovedToCandidateQueue"
+	ReasonPodTemplateMutated    = "PodTemplateMutated"
 )
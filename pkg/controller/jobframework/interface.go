@@ -84,6 +84,13 @@ type JobWithReclaimablePods interface {
 	ReclaimablePods(ctx context.Context, c client.Client) ([]kueue.ReclaimablePod, error)
 }
 
+// JobWithFinishedPods is an optional interface that should be implemented by generic jobs
+// which report the number of their finished pods.
+type JobWithFinishedPods interface {
+	// FinishedPods returns the number of succeeded and failed pods of the job.
+	FinishedPods() (succeeded, failed int32)
+}
+
 // JobWithCustomStop is an optional interface that should be implemented by generic jobs
 // when a custom stop procedure is needed.
 type JobWithCustomStop interface {
@@ -62,6 +62,7 @@ import (
 	clientutil "sigs.k8s.io/kueue/pkg/util/client"
 	"sigs.k8s.io/kueue/pkg/util/equality"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
+	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
 	utilqueue "sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
@@ -101,6 +102,9 @@ type JobReconciler struct {
 	managedJobsNamespaceSelector labels.Selector
 	waitForPodsReady             bool
 	labelKeysToCopy              []string
+	podLabelPropagation          *configapi.PodLabelPropagation
+	defaultWorkloadPriorityClass string
+	evictionHooksMaxTimeout      time.Duration
 	clock                        clock.Clock
 	workloadRetentionPolicy      WorkloadRetentionPolicy
 	roleTracker                  *roletracker.RoleTracker
@@ -122,6 +126,9 @@ type Options struct {
 	EnabledExternalFrameworks    sets.Set[string]
 	ManagerName                  string
 	LabelKeysToCopy              []string
+	PodLabelPropagation          *configapi.PodLabelPropagation
+	DefaultWorkloadPriorityClass string
+	EvictionHooksMaxTimeout      time.Duration
 	Queues                       *qcache.Manager
 	Cache                        *schdcache.Cache
 	Clock                        clock.Clock
@@ -206,6 +213,30 @@ func WithLabelKeysToCopy(n []string) Option {
 	}
 }
 
+// WithPodLabelPropagation sets the labels of the Workload and of its
+// LocalQueue propagated to the pods of the admitted job.
+func WithPodLabelPropagation(p *configapi.PodLabelPropagation) Option {
+	return func(o *Options) {
+		o.PodLabelPropagation = p
+	}
+}
+
+// WithDefaultWorkloadPriorityClass sets the WorkloadPriorityClass assigned to
+// the Workloads of jobs which don't specify a priority.
+func WithDefaultWorkloadPriorityClass(name string) Option {
+	return func(o *Options) {
+		o.DefaultWorkloadPriorityClass = name
+	}
+}
+
+// WithEvictionHooksMaxTimeout sets the maximum duration the reconciler waits
+// for the eviction hooks of a Workload before stopping its job.
+func WithEvictionHooksMaxTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.EvictionHooksMaxTimeout = timeout
+	}
+}
+
 // WithQueues adds the queue manager.
 func WithQueues(q *qcache.Manager) Option {
 	return func(o *Options) {
@@ -259,7 +290,8 @@ func WithNoopWebhook(noop bool) Option {
 }
 
 var defaultOptions = Options{
-	Clock: clock.RealClock{},
+	Clock:                   clock.RealClock{},
+	EvictionHooksMaxTimeout: workload.DefaultEvictionHooksMaxTimeout,
 }
 
 func NewReconciler(
@@ -276,6 +308,9 @@ func NewReconciler(
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
 		waitForPodsReady:             options.WaitForPodsReady,
 		labelKeysToCopy:              options.LabelKeysToCopy,
+		podLabelPropagation:          options.PodLabelPropagation,
+		defaultWorkloadPriorityClass: options.DefaultWorkloadPriorityClass,
+		evictionHooksMaxTimeout:      options.EvictionHooksMaxTimeout,
 		clock:                        options.Clock,
 		workloadRetentionPolicy:      options.WorkloadRetentionPolicy,
 		roleTracker:                  options.RoleTracker,
@@ -310,6 +345,11 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	if IsOptedOutForObject(object) {
+		log.V(3).Info("Job opted out of the management by Kueue, ignoring the job")
+		return ctrl.Result{}, r.deleteOptedOutWorkloads(ctx, req.NamespacedName, job)
+	}
+
 	ns := corev1.Namespace{}
 	if err := r.client.Get(ctx, client.ObjectKey{Name: req.Namespace}, &ns); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -552,6 +592,13 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 	// 6. handle eviction
 	if evCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted); evCond != nil && evCond.Status == metav1.ConditionTrue {
 		log.V(3).Info("Handling a job with evicted condition")
+		if hooks := workload.EvictionHooks(wl); len(hooks) > 0 && !job.IsSuspended() {
+			if remaining := workload.EvictionHooksTimeout(wl, r.evictionHooksMaxTimeout) - r.clock.Since(evCond.LastTransitionTime.Time); remaining > 0 {
+				log.V(3).Info("Waiting for the eviction hooks before stopping the job", "hooks", hooks, "timeout", remaining)
+				return ctrl.Result{RequeueAfter: remaining}, nil
+			}
+			log.V(2).Info("Timed out waiting for the eviction hooks, stopping the job", "hooks", hooks)
+		}
 		if err := r.stopJob(ctx, job, wl, StopReasonWorkloadEvicted, evCond.Message); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -560,7 +607,8 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 				log.V(6).Info("The job is no longer active, clear the workloads admission")
 				err := workloadpatching.PatchAdmissionStatus(ctx, r.client, wl, r.clock, func(wl *kueue.Workload) (bool, error) {
 					// The requeued condition status set to true only on EvictedByPreemption
-					setRequeued := (evCond.Reason == kueue.WorkloadEvictedByPreemption) || (evCond.Reason == kueue.WorkloadEvictedDueToNodeFailures)
+					setRequeued := (evCond.Reason == kueue.WorkloadEvictedByPreemption) || (evCond.Reason == kueue.WorkloadEvictedDueToNodeFailures) ||
+						(evCond.Reason == kueue.WorkloadEvictedByPodsCreationTimeout) || (evCond.Reason == kueue.WorkloadEvictedByResourceFlavorStopped)
 					// A pod-owned Workload dies with its pod; requeuing it would
 					// recompute an assignment nothing can consume (placement drift).
 					if features.Enabled(features.SkipReassignmentForPodOwnedWorkloads) && workload.OwnedBySinglePod(wl) {
@@ -614,12 +662,21 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 			r.recordAdmissionCheckUpdate(wl, job)
 		}
 
+		if err := r.handleCandidateQueues(ctx, job, wl); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		requeueAfter, err := r.handleFallbackQueue(ctx, job, wl)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
 		if err := r.handleQueueNameChange(ctx, job, wl); err != nil {
 			return ctrl.Result{}, err
 		}
 
 		log.V(3).Info("Job is suspended and workload not yet admitted by a clusterQueue, nothing to do")
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	// 8. handle job is unsuspended.
@@ -638,6 +695,17 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// 9. handle job whose pod templates were changed after the admission.
+	if err := r.handlePodTemplateMutation(ctx, job, wl); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 10. handle job whose pods were not created in time.
+	requeueAfter, err := r.handlePodsCreationTimeout(ctx, job, wl)
+	if err != nil || requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, err
+	}
+
 	// workload is admitted and job is running, nothing to do.
 	// For elastic jobs, pod ungating is handled by the ElasticJobUngater controller.
 	log.V(3).Info("Job running with admitted workload, nothing to do")
@@ -715,6 +783,246 @@ func (r *JobReconciler) finalizeWorkloads(ctx context.Context, key types.Namespa
 	return nil
 }
 
+// deleteOptedOutWorkloads deletes the workloads of a job which opted out of the
+// management by Kueue while suspended, so that they don't remain orphaned.
+func (r *JobReconciler) deleteOptedOutWorkloads(ctx context.Context, key types.NamespacedName, job GenericJob) error {
+	workloads, err := r.getWorkloads(ctx, key, job)
+	if err != nil {
+		return err
+	}
+	for i := range workloads {
+		wl := &workloads[i]
+		deleteRequested, err := workload.Delete(ctx, r.client, wl)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting workload of opted out job: %w", err)
+		}
+		if deleteRequested {
+			r.record.Eventf(job.Object(), nil, corev1.EventTypeNormal, ReasonDeletedWorkload, "DeletedWorkload",
+				"Deleted Workload of the job opted out of the management by Kueue: %v", workload.Key(wl))
+		}
+	}
+	return nil
+}
+
+// handlePodsCreationTimeout evicts the workload of a job whose pods were never
+// observed once the duration set in its pods-creation-timeout annotation has
+// elapsed since the admission, so that the reserved quota is released. It
+// returns the remaining wait time, if any.
+func (r *JobReconciler) handlePodsCreationTimeout(ctx context.Context, job GenericJob, wl *kueue.Workload) (time.Duration, error) {
+	if !features.Enabled(features.PodsCreationTimeout) || job.IsActive() || podsObserved(job, wl) {
+		return 0, nil
+	}
+	timeout, found := PodsCreationTimeoutForObject(job.Object())
+	if !found {
+		return 0, nil
+	}
+	admittedCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted)
+	if admittedCond == nil {
+		return 0, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	if requeueAfter := timeout - r.clock.Since(admittedCond.LastTransitionTime.Time); requeueAfter > 0 {
+		log.V(3).Info("Requeuing job for checking the pods creation timeout", "requeueAfter", requeueAfter)
+		return requeueAfter, nil
+	}
+
+	log.V(2).Info("Evicting the workload of a job whose pods were not created in time", "timeout", timeout)
+	message := fmt.Sprintf("No pods were created within the timeout of %v", timeout)
+	exposeLqMetrics := r.cache.ShouldExposeLocalQueueMetricsForWorkload(log, wl)
+	err := workloadevict.Evict(ctx, r.client, r.record, wl, kueue.WorkloadEvictedByPodsCreationTimeout, message, "", r.clock, exposeLqMetrics, r.roleTracker, r.customLabels)
+	return 0, client.IgnoreNotFound(err)
+}
+
+// podsObserved returns true if pods of the job were observed since the
+// admission, even if none of them is active now: the workload recorded their
+// readiness, or some of them finished.
+func podsObserved(job GenericJob, wl *kueue.Workload) bool {
+	if cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadPodsReady); cond != nil &&
+		(cond.Status == metav1.ConditionTrue || cond.Reason != kueue.WorkloadWaitForStart) {
+		return true
+	}
+	if len(wl.Status.ReclaimablePods) > 0 {
+		return true
+	}
+	if jobWithFinishedPods, ok := job.(JobWithFinishedPods); ok {
+		succeeded, failed := jobWithFinishedPods.FinishedPods()
+		return succeeded > 0 || failed > 0
+	}
+	return false
+}
+
+// handlePodTemplateMutation compares the pod templates of a running job with the
+// hash recorded on its workload when the job was started. On a mismatch, the
+// workload is evicted when the job uses the Readmit pod-template-mutation-policy,
+// otherwise the PodTemplateMutated condition is set on the workload.
+func (r *JobReconciler) handlePodTemplateMutation(ctx context.Context, job GenericJob, wl *kueue.Workload) error {
+	if !features.Enabled(features.PodTemplateHashing) {
+		return nil
+	}
+	if _, isComposable := job.(ComposableJob); isComposable {
+		return nil
+	}
+	admittedHash, found := wl.Annotations[controllerconsts.AdmittedPodTemplateHashAnnotation]
+	if !found {
+		// The job was started before the feature was enabled.
+		return r.recordAdmittedPodTemplateHash(ctx, job, wl)
+	}
+	podSets, err := JobPodSets(ctx, job, r.client)
+	if err != nil {
+		return err
+	}
+	hash, err := PodTemplatesHash(podSets)
+	if err != nil {
+		return err
+	}
+
+	mutated := apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadPodTemplateMutated)
+	if hash == admittedHash {
+		if !mutated {
+			return nil
+		}
+		return workload.SetConditionAndUpdate(ctx, r.client, wl, kueue.WorkloadPodTemplateMutated, metav1.ConditionFalse,
+			kueue.PodTemplateMatchesAdmissionReason, "The pod templates match the admitted ones", constants.JobControllerName, r.clock)
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	message := "The pod templates were changed after the admission"
+	if PodTemplateMutationPolicyForObject(job.Object()) == controllerconsts.PodTemplateMutationPolicyReadmit {
+		log.V(2).Info("Evicting the workload of a job whose pod templates were changed", "admittedHash", admittedHash, "hash", hash)
+		exposeLqMetrics := r.cache.ShouldExposeLocalQueueMetricsForWorkload(log, wl)
+		err := workloadevict.Evict(ctx, r.client, r.record, wl, kueue.WorkloadEvictedByPodTemplateMutation, message, "", r.clock, exposeLqMetrics, r.roleTracker, r.customLabels)
+		return client.IgnoreNotFound(err)
+	}
+	if mutated {
+		return nil
+	}
+	log.V(2).Info("The pod templates of the job were changed after the admission", "admittedHash", admittedHash, "hash", hash)
+	r.record.Eventf(job.Object(), nil, corev1.EventTypeWarning, ReasonPodTemplateMutated, "PodTemplateMutated", message)
+	return workload.SetConditionAndUpdate(ctx, r.client, wl, kueue.WorkloadPodTemplateMutated, metav1.ConditionTrue,
+		kueue.PodTemplateChangedReason, message, constants.JobControllerName, r.clock)
+}
+
+// recordAdmittedPodTemplateHash records the hash of the pod templates of the
+// started job on its workload.
+func (r *JobReconciler) recordAdmittedPodTemplateHash(ctx context.Context, job GenericJob, wl *kueue.Workload) error {
+	podSets, err := JobPodSets(ctx, job, r.client)
+	if err != nil {
+		return err
+	}
+	hash, err := PodTemplatesHash(podSets)
+	if err != nil {
+		return err
+	}
+	if wl.Annotations[controllerconsts.AdmittedPodTemplateHashAnnotation] == hash {
+		return nil
+	}
+	return clientutil.Patch(ctx, r.client, wl, func() (bool, error) {
+		metav1.SetMetaDataAnnotation(&wl.ObjectMeta, controllerconsts.AdmittedPodTemplateHashAnnotation, hash)
+		return true, nil
+	})
+}
+
+// handleFallbackQueue moves the job to the LocalQueue set in its fallback-queue-name
+// annotation once its workload has been pending for longer than the duration set in
+// the fallback-queue-after annotation. It returns the remaining wait time, if any.
+func (r *JobReconciler) handleFallbackQueue(ctx context.Context, job GenericJob, wl *kueue.Workload) (time.Duration, error) {
+	if !features.Enabled(features.FallbackLocalQueue) || workload.HasQuotaReservation(wl) {
+		return 0, nil
+	}
+	// Composable jobs consist of multiple objects that would all need relabeling.
+	if _, isComposable := job.(ComposableJob); isComposable {
+		return 0, nil
+	}
+	object := job.Object()
+	fallbackQueue, after, found := FallbackQueueForObject(object)
+	if !found || QueueName(job) == fallbackQueue {
+		return 0, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("oldQueueName", QueueName(job), "newQueueName", fallbackQueue)
+	if requeueAfter := after - workload.QueuedWaitTime(wl, r.clock); requeueAfter > 0 {
+		log.V(3).Info("Requeuing job for moving to the fallback queue", "requeueAfter", requeueAfter)
+		return requeueAfter, nil
+	}
+
+	labels := maps.Clone(object.GetLabels())
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[controllerconsts.QueueLabel] = string(fallbackQueue)
+	if features.Enabled(features.CandidateLocalQueues) {
+		// The webhook rejects a queue which isn't one of the candidate queues.
+		relabeled := object.DeepCopyObject().(client.Object)
+		relabeled.SetLabels(labels)
+		if errs := validateCandidateQueues(relabeled); len(errs) > 0 {
+			log.V(2).Info("Not moving job to the fallback queue", "error", errs.ToAggregate())
+			return 0, nil
+		}
+	}
+
+	log.V(2).Info("Moving job to the fallback queue", "after", after)
+	object.SetLabels(labels)
+	if err := r.client.Update(ctx, object); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	r.record.Eventf(object, nil, corev1.EventTypeNormal, ReasonMovedToFallbackQueue, "MovedToFallbackQueue",
+		"Moved to the fallback LocalQueue %q after waiting for %v", fallbackQueue, after)
+	return 0, nil
+}
+
+// handleCandidateQueues moves the job to the next LocalQueue listed in its
+// candidate-queue-names annotation once the scheduler failed to reserve quota
+// for its workload in the current LocalQueue.
+func (r *JobReconciler) handleCandidateQueues(ctx context.Context, job GenericJob, wl *kueue.Workload) error {
+	if !features.Enabled(features.CandidateLocalQueues) || workload.HasQuotaReservation(wl) {
+		return nil
+	}
+	// Composable jobs consist of multiple objects that would all need relabeling.
+	if _, isComposable := job.(ComposableJob); isComposable {
+		return nil
+	}
+	object := job.Object()
+	candidates := CandidateQueuesForObject(object)
+	current := -1
+	for i, candidate := range candidates {
+		if candidate == QueueName(job) {
+			current = i
+			break
+		}
+	}
+	if current == -1 || current == len(candidates)-1 || wl.Spec.QueueName != QueueName(job) || !blockedInQueue(wl) {
+		return nil
+	}
+
+	next := candidates[current+1]
+	log := ctrl.LoggerFrom(ctx).WithValues("oldQueueName", QueueName(job), "newQueueName", next)
+	log.V(2).Info("Moving job to the next candidate queue")
+	labels := object.GetLabels()
+	labels[controllerconsts.QueueLabel] = string(next)
+	object.SetLabels(labels)
+	if err := r.client.Update(ctx, object); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.record.Eventf(object, nil, corev1.EventTypeNormal, ReasonMovedToCandidateQueue, "MovedToCandidateQueue",
+		"Moved to the candidate LocalQueue %q as the workload couldn't be admitted in %q", next, candidates[current])
+	return nil
+}
+
+// blockedInQueue returns true if the scheduler, or the workload controller,
+// evaluated the current spec of the workload and couldn't reserve quota for it.
+func blockedInQueue(wl *kueue.Workload) bool {
+	cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.ObservedGeneration != wl.Generation {
+		return false
+	}
+	switch cond.Reason {
+	case kueue.WorkloadQuotaReservedReasonPendingEvaluation, kueue.WorkloadQuotaReservedReasonWaitingForPreemptedWorkloads:
+		return false
+	}
+	return true
+}
+
 func (r *JobReconciler) handleQueueNameChange(ctx context.Context, job GenericJob, wl *kueue.Workload) error {
 	if jobWithCustomQueueNameChange, ok := job.(JobWithCustomQueueNameChange); ok {
 		return jobWithCustomQueueNameChange.CustomQueueNameChange(ctx, r.client, wl)
@@ -896,7 +1204,7 @@ func FindAncestorJobManagedByKueue(ctx context.Context, c client.Client, jobObj
 		if err := c.Get(ctx, client.ObjectKey{Name: owner.Name, Namespace: jobObj.GetNamespace()}, parentObj); err != nil {
 			return nil, errors.Join(ErrWorkloadOwnerNotFound, err)
 		}
-		if managed && (manageJobsWithoutQueueName || QueueNameForObject(parentObj) != "") {
+		if managed && !IsOptedOutForObject(parentObj) && (manageJobsWithoutQueueName || QueueNameForObject(parentObj) != "") {
 			topLevelJob = parentObj
 		}
 		currentObj = parentObj
@@ -1006,9 +1314,20 @@ func (r *JobReconciler) ensureOneWorkload(ctx context.Context, job GenericJob, o
 	}
 
 	var toUpdate *kueue.Workload
-	if match == nil && len(toDelete) > 0 && job.IsSuspended() && !workload.HasQuotaReservation(toDelete[0]) {
-		toUpdate = toDelete[0]
-		toDelete = toDelete[1:]
+	if match == nil && len(toDelete) > 0 && job.IsSuspended() {
+		switch {
+		case !workload.HasQuotaReservation(toDelete[0]):
+			toUpdate = toDelete[0]
+			toDelete = toDelete[1:]
+		case features.Enabled(features.ReadmitOnPodSetsChange) && len(toDelete) == 1 && !job.IsActive():
+			// The pod sets of a workload with quota reserved are immutable, evict
+			// the workload so that it can be updated and admitted again.
+			if err := r.evictChangedWorkload(ctx, toDelete[0]); err != nil {
+				return nil, err
+			}
+			toUpdate = toDelete[0]
+			toDelete = nil
+		}
 	}
 
 	// If there is no matching workload and the job is running, suspend it.
@@ -1067,9 +1386,16 @@ func (r *JobReconciler) ensureOneWorkload(ctx context.Context, job GenericJob, o
 				return nil, err
 			}
 		}
+		if features.Enabled(features.WorkloadHold) {
+			if err := UpdateHold(ctx, r.client, r.record, job.Object(), match); err != nil {
+				return nil, err
+			}
+		}
 
-		if err := UpdateWorkloadPriority(ctx, r.client, r.record, job.Object(), match, getCustomPriorityClassFuncFromJob(job)); err != nil {
-			return nil, err
+		if !r.hasDefaultWorkloadPriorityClass(job.Object(), match) {
+			if err := UpdateWorkloadPriority(ctx, r.client, r.record, job.Object(), match, getCustomPriorityClassFuncFromJob(job)); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -1135,6 +1461,53 @@ func PropagateAdmissionGatedByAnnotation(obj client.Object, wl *kueue.Workload)
 	return false
 }
 
+// UpdateHold propagates the hold annotation from the job object to its associated
+// workload. Emits an event only if the annotation was actually changed and the update succeeded.
+func UpdateHold(ctx context.Context, c client.Client, r events.EventRecorder, obj client.Object, wl *kueue.Workload) error {
+	if !features.Enabled(features.WorkloadHold) {
+		return nil
+	}
+
+	var propagated bool
+	if err := clientutil.Patch(ctx, c, wl, func() (bool, error) {
+		propagated = PropagateHoldAnnotation(obj, wl)
+		return propagated, nil
+	}); err != nil {
+		return fmt.Errorf("updating the hold of existing workload: %w", err)
+	}
+
+	if propagated {
+		r.Eventf(obj, nil,
+			corev1.EventTypeNormal, ReasonUpdatedWorkload, ReasonUpdatedWorkload,
+			"Updated workload hold to %q", obj.GetAnnotations()[constants.HoldAnnotation],
+		)
+	}
+
+	return nil
+}
+
+// PropagateHoldAnnotation copies the hold annotation from the given object to
+// workload object but only in memory. It does not persist the changes to the API server.
+func PropagateHoldAnnotation(obj client.Object, wl *kueue.Workload) bool {
+	if !features.Enabled(features.WorkloadHold) {
+		return false
+	}
+
+	jobValue := obj.GetAnnotations()[constants.HoldAnnotation]
+	if jobValue == wl.Annotations[constants.HoldAnnotation] {
+		return false
+	}
+	if jobValue == "" {
+		delete(wl.Annotations, constants.HoldAnnotation)
+	} else {
+		if wl.Annotations == nil {
+			wl.Annotations = make(map[string]string)
+		}
+		wl.Annotations[constants.HoldAnnotation] = jobValue
+	}
+	return true
+}
+
 // UpdateWorkloadPriority updates workload priority if object's kueue.x-k8s.io/priority-class label changed.
 func UpdateWorkloadPriority(ctx context.Context, c client.Client, r events.EventRecorder, obj client.Object, wl *kueue.Workload, customPriorityClassFunc func() string) error {
 	jobPriorityClassName := WorkloadPriorityClassName(obj)
@@ -1297,6 +1670,31 @@ func EquivalentToWorkload(ctx context.Context, c client.Client, job GenericJob,
 	return equality.ComparePodSetSlices(jobPodSets, wl.Spec.PodSets, opts...), nil
 }
 
+// evictChangedWorkload evicts the workload, whose pod sets no longer match the
+// suspended job, and releases its quota so that the workload is requeued.
+func (r *JobReconciler) evictChangedWorkload(ctx context.Context, wl *kueue.Workload) error {
+	log := ctrl.LoggerFrom(ctx)
+	message := "The pod sets of the job were changed after the quota reservation"
+	if !workloadevict.IsEvicted(wl) {
+		log.V(2).Info("Evicting the workload of a job whose pod sets were changed", "workload", klog.KObj(wl))
+		exposeLqMetrics := r.cache.ShouldExposeLocalQueueMetricsForWorkload(log, wl)
+		if err := workloadevict.Evict(ctx, r.client, r.record, wl, kueue.WorkloadEvictedByPodTemplateMutation, message, "", r.clock, exposeLqMetrics, r.roleTracker, r.customLabels); err != nil {
+			return err
+		}
+	}
+	return workloadpatching.PatchAdmissionStatus(ctx, r.client, wl, r.clock, func(wl *kueue.Workload) (bool, error) {
+		updated := workload.SetRequeuedCondition(wl, kueue.WorkloadEvictedByPodTemplateMutation, message, true)
+		reason := workload.UnadmittedWorkloadReasonWithFallback(
+			kueue.WorkloadQuotaReservedReasonPendingEvaluation,
+			kueue.WorkloadPending, //nolint:staticcheck // SA1019: fallback
+		)
+		if workload.UnsetQuotaReservationWithCondition(wl, reason, message, r.clock.Now()) {
+			updated = true
+		}
+		return updated, nil
+	})
+}
+
 func (r *JobReconciler) updateWorkloadToMatchJob(ctx context.Context, job GenericJob, object client.Object, wl *kueue.Workload) (*kueue.Workload, error) {
 	newWl, err := r.constructWorkload(ctx, job)
 	if err != nil {
@@ -1322,6 +1720,9 @@ func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object cli
 	if err != nil {
 		return err
 	}
+	if err := r.addPropagatedPodLabels(ctx, wl, info); err != nil {
+		return err
+	}
 	msg := fmt.Sprintf("Admitted by clusterQueue %v", wl.Status.Admission.ClusterQueue)
 
 	log := ctrl.LoggerFrom(ctx)
@@ -1352,6 +1753,11 @@ func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object cli
 			return err
 		}
 		r.record.Eventf(object, nil, corev1.EventTypeNormal, ReasonStarted, "Started", msg)
+		if features.Enabled(features.PodTemplateHashing) {
+			if err := r.recordAdmittedPodTemplateHash(ctx, job, wl); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -1532,17 +1938,23 @@ func PrepareWorkloadPriority(ctx context.Context, c client.Client, obj client.Ob
 }
 
 // prepareWorkload adds the priority information for the constructed workload and
-// the AdmissionGatedBy annotation when the feature is on.
+// the AdmissionGatedBy and hold annotations when the features are on.
 // active is used to set the active field of the workload. If active is nil, the workload will be set to active by default.
 // for the existing workload, the original active status should be retained.
 func (r *JobReconciler) prepareWorkload(ctx context.Context, job GenericJob, wl *kueue.Workload, active *bool) error {
 	if features.Enabled(features.AdmissionGatedBy) {
 		PropagateAdmissionGatedByAnnotation(job.Object(), wl)
 	}
+	if features.Enabled(features.WorkloadHold) {
+		PropagateHoldAnnotation(job.Object(), wl)
+	}
 
 	if err := PrepareWorkloadPriority(ctx, r.client, job.Object(), wl, getCustomPriorityClassFuncFromJob(job)); err != nil {
 		return err
 	}
+	if err := r.applyDefaultWorkloadPriorityClass(ctx, wl); err != nil {
+		return err
+	}
 
 	wl.Spec.PodSets = clearMinCountsIfFeatureDisabled(wl.Spec.PodSets)
 
@@ -1553,6 +1965,35 @@ func (r *JobReconciler) prepareWorkload(ctx context.Context, job GenericJob, wl
 	return nil
 }
 
+// applyDefaultWorkloadPriorityClass assigns the configured default
+// WorkloadPriorityClass to the workload, when no priority class was resolved
+// for the job. The default is skipped if the WorkloadPriorityClass doesn't exist.
+func (r *JobReconciler) applyDefaultWorkloadPriorityClass(ctx context.Context, wl *kueue.Workload) error {
+	if r.defaultWorkloadPriorityClass == "" || !workload.HasNoPriority(wl) {
+		return nil
+	}
+	priorityClassRef, priority, err := utilpriority.GetPriorityFromWorkloadPriorityClass(ctx, r.client, r.defaultWorkloadPriorityClass)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			ctrl.LoggerFrom(ctx).V(2).Info("Default WorkloadPriorityClass not found", "workloadPriorityClass", r.defaultWorkloadPriorityClass)
+			return nil
+		}
+		return err
+	}
+	wl.Spec.PriorityClassRef = priorityClassRef
+	wl.Spec.Priority = &priority
+	return nil
+}
+
+// hasDefaultWorkloadPriorityClass returns whether the workload was assigned
+// the default WorkloadPriorityClass, because the job doesn't specify one.
+func (r *JobReconciler) hasDefaultWorkloadPriorityClass(obj client.Object, wl *kueue.Workload) bool {
+	return r.defaultWorkloadPriorityClass != "" &&
+		WorkloadPriorityClassName(obj) == "" &&
+		workload.IsWorkloadPriorityClass(wl) &&
+		workloadpatching.PriorityClassName(wl) == r.defaultWorkloadPriorityClass
+}
+
 func ExtractPriority(ctx context.Context, c client.Client, obj client.Object, podSets []kueue.PodSet, customPriorityClassFunc func() string) (*kueue.PriorityClassRef, int32, error) {
 	if workloadPriorityClass := WorkloadPriorityClassName(obj); len(workloadPriorityClass) > 0 {
 		return utilpriority.GetPriorityFromWorkloadPriorityClass(ctx, c, workloadPriorityClass)
@@ -1632,6 +2073,38 @@ func assignQueueLabels(ctx context.Context, labels map[string]string, wl *kueue.
 	}
 }
 
+// addPropagatedPodLabels adds the labels of the Workload and of its LocalQueue,
+// which are configured to be propagated to the pods, to the PodSets info.
+// The labels set on the pod templates, or by Kueue, are kept.
+func (r *JobReconciler) addPropagatedPodLabels(ctx context.Context, wl *kueue.Workload, podSetsInfo []podset.PodSetInfo) error {
+	if r.podLabelPropagation == nil {
+		return nil
+	}
+	propagated := make(map[string]string)
+	if len(r.podLabelPropagation.LocalQueueLabelKeys) > 0 {
+		lq := &kueue.LocalQueue{}
+		lqKey := types.NamespacedName{Namespace: wl.Namespace, Name: string(wl.Spec.QueueName)}
+		if err := r.client.Get(ctx, lqKey, lq); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		maps.Copy(propagated, utilmaps.FilterKeys(lq.Labels, r.podLabelPropagation.LocalQueueLabelKeys))
+	}
+	maps.Copy(propagated, utilmaps.FilterKeys(wl.Labels, r.podLabelPropagation.WorkloadLabelKeys))
+	for i := range podSetsInfo {
+		templateLabels := wl.Spec.PodSets[i].Template.Labels
+		for key, value := range propagated {
+			if _, found := podSetsInfo[i].Labels[key]; found {
+				continue
+			}
+			if _, found := templateLabels[key]; found {
+				continue
+			}
+			podSetsInfo[i].AddOrUpdateLabel(key, value)
+		}
+	}
+	return nil
+}
+
 func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job GenericJob, object client.Object) error {
 	log := ctrl.LoggerFrom(ctx)
 
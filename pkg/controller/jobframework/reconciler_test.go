@@ -62,6 +62,7 @@ import (
 	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
 	"sigs.k8s.io/kueue/pkg/util/testingjobs/jobset"
 	testingmpijob "sigs.k8s.io/kueue/pkg/util/testingjobs/mpijob"
+	"sigs.k8s.io/kueue/pkg/workload"
 	"sigs.k8s.io/kueue/pkg/workloadslicing"
 
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
@@ -78,9 +79,27 @@ func TestReconcileGenericJob(t *testing.T) {
 
 	baseReq := types.NamespacedName{Name: testJobName, Namespace: metav1.NamespaceDefault}
 	baseJob := testingjob.MakeJob(testJobName, metav1.NamespaceDefault).UID(testJobName).Queue(testLocalQueueName)
+	now := time.Now().Truncate(time.Second)
+	pastCreation := now.Add(-time.Hour)
+	admittedMain := &kueue.Admission{
+		ClusterQueue:      "default-cq",
+		PodSetAssignments: []kueue.PodSetAssignment{{Name: "main", Count: ptr.To[int32](1)}},
+	}
 	basePodSets := []kueue.PodSet{
 		*utiltestingapi.MakePodSet("main", 1).Obj(),
 	}
+	blockedCond := metav1.Condition{
+		Type:               kueue.WorkloadQuotaReserved,
+		Status:             metav1.ConditionFalse,
+		Reason:             kueue.WorkloadQuotaReservedReasonWaitingForQuota,
+		Message:            "couldn't assign flavors to pod set main: insufficient quota for cpu",
+		ObservedGeneration: 1,
+		LastTransitionTime: metav1.NewTime(now),
+	}
+	admittedHash, err := PodTemplatesHash(basePodSets)
+	if err != nil {
+		t.Fatalf("Failed to compute the pod templates hash: %v", err)
+	}
 	baseWl := utiltestingapi.MakeWorkload("job-test-job", metav1.NamespaceDefault).
 		ResourceVersion("1").
 		Finalizers(kueue.ResourceInUseFinalizerName).
@@ -161,6 +180,206 @@ func TestReconcileGenericJob(t *testing.T) {
 				*baseWl.Clone().Name("job-test-job-1").ResourceVersion("2").Obj(),
 			},
 		},
+		"move job to the fallback queue after waiting": {
+			featureGates: map[featuregate.Feature]bool{features.FallbackLocalQueue: true},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(constants.FallbackQueueAnnotation, "fallback-lq").
+				SetAnnotation(constants.FallbackQueueAfterAnnotation, "30m").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Creation(pastCreation).Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").
+					ResourceVersion("2").
+					Creation(pastCreation).
+					Queue("fallback-lq").
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: testJobName, Namespace: metav1.NamespaceDefault},
+					EventType: corev1.EventTypeNormal,
+					Reason:    ReasonMovedToFallbackQueue,
+					Message:   `Moved to the fallback LocalQueue "fallback-lq" after waiting for 30m0s`,
+				},
+			},
+		},
+		"keep job in its queue before the fallback wait elapses": {
+			featureGates: map[featuregate.Feature]bool{features.FallbackLocalQueue: true},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(constants.FallbackQueueAnnotation, "fallback-lq").
+				SetAnnotation(constants.FallbackQueueAfterAnnotation, "2h").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Creation(pastCreation).Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").Creation(pastCreation).Obj(),
+			},
+		},
+		"keep job in its queue when the fallback queue feature is disabled": {
+			featureGates: map[featuregate.Feature]bool{features.FallbackLocalQueue: false},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(constants.FallbackQueueAnnotation, "fallback-lq").
+				SetAnnotation(constants.FallbackQueueAfterAnnotation, "30m").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Creation(pastCreation).Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").Creation(pastCreation).Obj(),
+			},
+		},
+		"keep job in its queue when the fallback queue isn't a candidate queue": {
+			featureGates: map[featuregate.Feature]bool{
+				features.FallbackLocalQueue:   true,
+				features.CandidateLocalQueues: true,
+			},
+			req: baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(constants.FallbackQueueAnnotation, "fallback-lq").
+				SetAnnotation(constants.FallbackQueueAfterAnnotation, "30m").
+				SetAnnotation(constants.CandidateQueuesAnnotation, "test-lq,second-lq").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Creation(pastCreation).Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").Creation(pastCreation).Obj(),
+			},
+		},
+		"move job to the next candidate queue when the workload is blocked": {
+			featureGates: map[featuregate.Feature]bool{features.CandidateLocalQueues: true},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(constants.CandidateQueuesAnnotation, "test-lq,second-lq").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Generation(1).Condition(blockedCond).Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").
+					ResourceVersion("2").
+					Generation(1).
+					Queue("second-lq").
+					Condition(blockedCond).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: testJobName, Namespace: metav1.NamespaceDefault},
+					EventType: corev1.EventTypeNormal,
+					Reason:    ReasonMovedToCandidateQueue,
+					Message:   `Moved to the candidate LocalQueue "second-lq" as the workload couldn't be admitted in "test-lq"`,
+				},
+			},
+		},
+		"keep job in its queue when the workload was not evaluated since the last queue change": {
+			featureGates: map[featuregate.Feature]bool{features.CandidateLocalQueues: true},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(constants.CandidateQueuesAnnotation, "test-lq,second-lq").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Generation(2).Condition(blockedCond).Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").Generation(2).Condition(blockedCond).Obj(),
+			},
+		},
+		"keep job in the last candidate queue": {
+			featureGates: map[featuregate.Feature]bool{features.CandidateLocalQueues: true},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(constants.CandidateQueuesAnnotation, "first-lq,test-lq").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Generation(1).Condition(blockedCond).Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").Generation(1).Condition(blockedCond).Obj(),
+			},
+		},
+		"keep job in its queue when the candidate queues feature is disabled": {
+			featureGates: map[featuregate.Feature]bool{features.CandidateLocalQueues: false},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(constants.CandidateQueuesAnnotation, "test-lq,second-lq").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Generation(1).Condition(blockedCond).Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").Generation(1).Condition(blockedCond).Obj(),
+			},
+		},
+		"record the pod template hash when starting the job": {
+			featureGates: map[featuregate.Feature]bool{features.PodTemplateHashing: true},
+			req:          baseReq,
+			job:          baseJob.DeepCopy(),
+			podSets:      basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").
+					ReserveQuotaAt(admittedMain, pastCreation).
+					AdmittedAt(true, pastCreation).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").
+					ResourceVersion("2").
+					Annotation(constants.AdmittedPodTemplateHashAnnotation, admittedHash).
+					ReserveQuotaAt(admittedMain, pastCreation).
+					AdmittedAt(true, pastCreation).
+					Obj(),
+			},
+			wantPodSets: []podset.PodSetInfo{
+				{
+					Name:  "main",
+					Count: 1,
+					Annotations: map[string]string{
+						kueue.WorkloadAnnotation: "job-test-job-1",
+					},
+					Labels: map[string]string{
+						kueueconstants.ClusterQueueLabel: "default-cq",
+						kueueconstants.LocalQueueLabel:   "test-lq",
+						kueueconstants.PodSetLabel:       "main",
+					},
+					NodeSelector: map[string]string{},
+				},
+			},
+		},
+		"keep the workload when the pod templates match the admitted ones": {
+			featureGates: map[featuregate.Feature]bool{features.PodTemplateHashing: true},
+			req:          baseReq,
+			job:          baseJob.Clone().Suspend(false).Obj(),
+			podSets:      basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").
+					Annotation(constants.AdmittedPodTemplateHashAnnotation, admittedHash).
+					ReserveQuotaAt(admittedMain, pastCreation).
+					AdmittedAt(true, pastCreation).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").
+					Annotation(constants.AdmittedPodTemplateHashAnnotation, admittedHash).
+					ReserveQuotaAt(admittedMain, pastCreation).
+					AdmittedAt(true, pastCreation).
+					Obj(),
+			},
+		},
 		"update workload to match job preserves active=true": {
 			req:     baseReq,
 			job:     baseJob.DeepCopy(),
@@ -330,6 +549,56 @@ func TestReconcileGenericJob(t *testing.T) {
 			},
 			wantEvents: nil,
 		},
+		"job with hold annotation should create workload with annotation": {
+			featureGates: map[featuregate.Feature]bool{features.WorkloadHold: true},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(kueueconstants.HoldAnnotation, "true").
+				Obj(),
+			podSets: basePodSets,
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().
+					Name("job-test-job-ce737").
+					Annotation(kueueconstants.HoldAnnotation, "true").
+					Obj(),
+			},
+		},
+		"job with hold annotation removed should update workload": {
+			featureGates: map[featuregate.Feature]bool{features.WorkloadHold: true},
+			req:          baseReq,
+			job:          baseJob.DeepCopy(),
+			podSets:      basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").
+					Annotation(kueueconstants.HoldAnnotation, "true").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").ResourceVersion("2").Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: testJobName, Namespace: metav1.NamespaceDefault},
+					EventType: corev1.EventTypeNormal,
+					Reason:    ReasonUpdatedWorkload,
+					Message:   `Updated workload hold to ""`,
+				},
+			},
+		},
+		"job with hold annotation when feature disabled should not update workload": {
+			featureGates: map[featuregate.Feature]bool{features.WorkloadHold: false},
+			req:          baseReq,
+			job: baseJob.Clone().
+				SetAnnotation(kueueconstants.HoldAnnotation, "true").
+				Obj(),
+			podSets: basePodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").Obj(),
+			},
+		},
 		"MultiKueue worker pod label is propagated to PodTemplate if workload has Multikueue origin label": {
 			req: baseReq,
 			job: baseJob.Clone().Label(kueue.MultiKueueOriginLabel, "origin").
@@ -461,6 +730,323 @@ func TestReconcileGenericJob(t *testing.T) {
 	}
 }
 
+func TestReconcileGenericJobPodTemplateMutation(t *testing.T) {
+	var (
+		testJobName        = "test-job"
+		testLocalQueueName = kueue.LocalQueueName("test-lq")
+		testGVK            = batchv1.SchemeGroupVersion.WithKind("Job")
+	)
+
+	baseReq := types.NamespacedName{Name: testJobName, Namespace: metav1.NamespaceDefault}
+	baseJob := testingjob.MakeJob(testJobName, metav1.NamespaceDefault).UID(testJobName).Queue(testLocalQueueName)
+	now := time.Now().Truncate(time.Second)
+	pastCreation := now.Add(-time.Hour)
+	admittedMain := &kueue.Admission{
+		ClusterQueue:      "default-cq",
+		PodSetAssignments: []kueue.PodSetAssignment{{Name: "main", Count: ptr.To[int32](1)}},
+	}
+	basePodSets := []kueue.PodSet{
+		*utiltestingapi.MakePodSet("main", 1).Obj(),
+	}
+	mutatedPodSets := []kueue.PodSet{
+		*utiltestingapi.MakePodSet("main", 1).NodeSelector(map[string]string{"zone": "a"}).Obj(),
+	}
+	admittedHash, err := PodTemplatesHash(basePodSets)
+	if err != nil {
+		t.Fatalf("Failed to compute the pod templates hash: %v", err)
+	}
+	baseWl := utiltestingapi.MakeWorkload("job-test-job", metav1.NamespaceDefault).
+		ResourceVersion("1").
+		Finalizers(kueue.ResourceInUseFinalizerName).
+		Label(constants.JobUIDLabel, testJobName).
+		ControllerReference(testGVK, testJobName, testJobName).
+		Queue(testLocalQueueName).
+		PodSets(basePodSets...).
+		Priority(0)
+
+	testCases := map[string]struct {
+		featureGates  map[featuregate.Feature]bool
+		req           types.NamespacedName
+		job           *batchv1.Job
+		podSets       []kueue.PodSet
+		objs          []client.Object
+		wantWorkloads []kueue.Workload
+		wantEvents    []utiltesting.EventRecord
+	}{
+		"set the PodTemplateMutated condition when the pod templates changed after the admission": {
+			featureGates: map[featuregate.Feature]bool{
+				features.PodTemplateHashing:           true,
+				features.WorkloadRequestUseMergePatch: true,
+			},
+			req:     baseReq,
+			job:     baseJob.Clone().Suspend(false).Obj(),
+			podSets: mutatedPodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").
+					Annotation(constants.AdmittedPodTemplateHashAnnotation, admittedHash).
+					ReserveQuotaAt(admittedMain, pastCreation).
+					AdmittedAt(true, pastCreation).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").
+					ResourceVersion("2").
+					Annotation(constants.AdmittedPodTemplateHashAnnotation, admittedHash).
+					ReserveQuotaAt(admittedMain, pastCreation).
+					AdmittedAt(true, pastCreation).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadPodTemplateMutated,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now),
+						Reason:             kueue.PodTemplateChangedReason,
+						Message:            "The pod templates were changed after the admission",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: testJobName, Namespace: metav1.NamespaceDefault},
+					EventType: corev1.EventTypeWarning,
+					Reason:    ReasonPodTemplateMutated,
+					Message:   "The pod templates were changed after the admission",
+				},
+			},
+		},
+		"evict the workload when the pod templates changed after the admission with the Readmit policy": {
+			featureGates: map[featuregate.Feature]bool{features.PodTemplateHashing: true},
+			req:          baseReq,
+			job: baseJob.Clone().
+				Suspend(false).
+				SetAnnotation(constants.PodTemplateMutationPolicyAnnotation, constants.PodTemplateMutationPolicyReadmit).
+				Obj(),
+			podSets: mutatedPodSets,
+			objs: []client.Object{
+				baseWl.Clone().Name("job-test-job-1").
+					Annotation(constants.AdmittedPodTemplateHashAnnotation, admittedHash).
+					ReserveQuotaAt(admittedMain, pastCreation).
+					AdmittedAt(true, pastCreation).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWl.Clone().Name("job-test-job-1").
+					ResourceVersion("2").
+					Annotation(constants.AdmittedPodTemplateHashAnnotation, admittedHash).
+					ReserveQuotaAt(admittedMain, pastCreation).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now),
+						Reason:             kueue.WorkloadEvictedByPodTemplateMutation,
+						Message:            "The pod templates were changed after the admission",
+					}).
+					AdmittedAt(true, pastCreation).
+					SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{
+						Reason: kueue.WorkloadEvictedByPodTemplateMutation,
+						Count:  1,
+					}).
+					Obj(),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGatesDuringTest(t, tc.featureGates)
+
+			ctx, _ := utiltesting.ContextWithLog(t)
+			mockctrl := gomock.NewController(t)
+
+			mgj := mocks.NewMockGenericJob(mockctrl)
+			mgj.EXPECT().Object().Return(tc.job).AnyTimes()
+			mgj.EXPECT().GVK().Return(testGVK).AnyTimes()
+			mgj.EXPECT().IsSuspended().Return(ptr.Deref(tc.job.Spec.Suspend, false)).AnyTimes()
+			mgj.EXPECT().IsActive().Return(tc.job.Status.Active != 0).AnyTimes()
+			mgj.EXPECT().Finished(gomock.Any()).Return("", false, false).AnyTimes()
+			mgj.EXPECT().PodSets(gomock.Any(), gomock.Any()).Return(tc.podSets, nil).AnyTimes()
+
+			cl := utiltesting.NewClientBuilder(batchv1.AddToScheme, kueue.AddToScheme).
+				WithObjects(utiltesting.MakeNamespace(tc.req.Namespace)).
+				WithObjects(tc.objs...).
+				WithObjects(tc.job).
+				WithIndex(&kueue.Workload{}, indexer.OwnerReferenceIndexKey(testGVK), indexer.WorkloadOwnerIndexFunc(testGVK)).
+				WithStatusSubresource(&kueue.Workload{}).
+				Build()
+
+			recorder := &utiltesting.EventRecorder{}
+			rec := NewReconciler(cl, recorder, WithCache(schdcache.New(cl)), WithClock(testingclock.NewFakeClock(now)))
+			if _, err := rec.ReconcileGenericJob(ctx, controllerruntime.Request{NamespacedName: tc.req}, mgj); err != nil {
+				t.Fatalf("Failed to Reconcile GenericJob: %v", err)
+			}
+
+			wls := kueue.WorkloadList{}
+			if err := cl.List(ctx, &wls); err != nil {
+				t.Fatalf("Failed to List workloads: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, wls.Items, cmpopts.IgnoreFields(corev1.ResourceRequirements{}, "Requests")); diff != "" {
+				t.Errorf("Workloads mismatch (-want +got):\n%s", diff)
+			}
+			if tc.wantEvents != nil {
+				if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+					t.Errorf("Unexpected events (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+// jobWithFinishedPods is a GenericJob reporting the number of its finished pods.
+type jobWithFinishedPods struct {
+	*mocks.MockGenericJob
+	succeeded, failed int32
+}
+
+func (j *jobWithFinishedPods) FinishedPods() (int32, int32) {
+	return j.succeeded, j.failed
+}
+
+func TestReconcileGenericJobPodsCreationTimeout(t *testing.T) {
+	testGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	now := time.Now().Truncate(time.Second)
+	admittedAt := now.Add(-time.Hour)
+	podSets := []kueue.PodSet{*utiltestingapi.MakePodSet("main", 1).Obj()}
+	admission := &kueue.Admission{
+		ClusterQueue:      "default-cq",
+		PodSetAssignments: []kueue.PodSetAssignment{{Name: "main", Count: ptr.To[int32](1)}},
+	}
+	baseWl := utiltestingapi.MakeWorkload("job-test-job-1", metav1.NamespaceDefault).
+		ResourceVersion("1").
+		Finalizers(kueue.ResourceInUseFinalizerName).
+		Label(constants.JobUIDLabel, "test-job").
+		ControllerReference(testGVK, "test-job", "test-job").
+		Queue("test-lq").
+		PodSets(podSets...).
+		Priority(0).
+		ReserveQuotaAt(admission, admittedAt).
+		AdmittedAt(true, admittedAt)
+
+	testCases := map[string]struct {
+		disableFeature bool
+		timeout        string
+		active         int32
+		succeeded      int32
+		failed         int32
+		wl             *kueue.Workload
+		wantEvicted    bool
+	}{
+		"evict the workload when no pods were created within the timeout": {
+			timeout:     "30m",
+			wl:          baseWl.Clone().Obj(),
+			wantEvicted: true,
+		},
+		"evict the workload when the pods are waiting to start": {
+			timeout: "30m",
+			wl: baseWl.Clone().
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadPodsReady,
+					Status: metav1.ConditionFalse,
+					Reason: kueue.WorkloadWaitForStart,
+				}).
+				Obj(),
+			wantEvicted: true,
+		},
+		"keep the workload before the timeout": {
+			timeout: "2h",
+			wl:      baseWl.Clone().Obj(),
+		},
+		"keep the workload of a job with active pods": {
+			timeout: "30m",
+			active:  1,
+			wl:      baseWl.Clone().Obj(),
+		},
+		"keep the workload whose pods were ready": {
+			timeout: "30m",
+			wl: baseWl.Clone().
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadPodsReady,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadStarted,
+				}).
+				Obj(),
+		},
+		"keep the workload whose pods are restarting": {
+			timeout: "30m",
+			wl: baseWl.Clone().
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadPodsReady,
+					Status: metav1.ConditionFalse,
+					Reason: kueue.WorkloadWaitForRecovery,
+				}).
+				Obj(),
+		},
+		"keep the workload with reclaimable pods": {
+			timeout: "30m",
+			wl: baseWl.Clone().
+				ReclaimablePods(kueue.ReclaimablePod{Name: "main", Count: 1}).
+				Obj(),
+		},
+		"keep the workload of a job with succeeded pods": {
+			timeout:   "30m",
+			succeeded: 1,
+			wl:        baseWl.Clone().Obj(),
+		},
+		"keep the workload of a job with failed pods": {
+			timeout: "30m",
+			failed:  1,
+			wl:      baseWl.Clone().Obj(),
+		},
+		"keep the workload when the feature is disabled": {
+			disableFeature: true,
+			timeout:        "30m",
+			wl:             baseWl.Clone().Obj(),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PodsCreationTimeout, !tc.disableFeature)
+
+			ctx, _ := utiltesting.ContextWithLog(t)
+			mockctrl := gomock.NewController(t)
+
+			job := testingjob.MakeJob("test-job", metav1.NamespaceDefault).
+				UID("test-job").
+				Queue("test-lq").
+				Suspend(false).
+				Active(tc.active).
+				SetAnnotation(constants.PodsCreationTimeoutAnnotation, tc.timeout).
+				Obj()
+			mgj := mocks.NewMockGenericJob(mockctrl)
+			mgj.EXPECT().Object().Return(job).AnyTimes()
+			mgj.EXPECT().GVK().Return(testGVK).AnyTimes()
+			mgj.EXPECT().IsSuspended().Return(false).AnyTimes()
+			mgj.EXPECT().IsActive().Return(tc.active != 0).AnyTimes()
+			mgj.EXPECT().Finished(gomock.Any()).Return("", false, false).AnyTimes()
+			mgj.EXPECT().PodSets(gomock.Any(), gomock.Any()).Return(podSets, nil).AnyTimes()
+			genericJob := &jobWithFinishedPods{MockGenericJob: mgj, succeeded: tc.succeeded, failed: tc.failed}
+
+			cl := utiltesting.NewClientBuilder(batchv1.AddToScheme, kueue.AddToScheme).
+				WithObjects(utiltesting.MakeNamespace(metav1.NamespaceDefault), tc.wl, job).
+				WithIndex(&kueue.Workload{}, indexer.OwnerReferenceIndexKey(testGVK), indexer.WorkloadOwnerIndexFunc(testGVK)).
+				WithStatusSubresource(&kueue.Workload{}).
+				Build()
+
+			rec := NewReconciler(cl, &utiltesting.EventRecorder{}, WithCache(schdcache.New(cl)), WithClock(testingclock.NewFakeClock(now)))
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Name: "test-job", Namespace: metav1.NamespaceDefault}}
+			if _, err := rec.ReconcileGenericJob(ctx, req, genericJob); err != nil {
+				t.Fatalf("Failed to Reconcile GenericJob: %v", err)
+			}
+
+			var gotWl kueue.Workload
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.wl), &gotWl); err != nil {
+				t.Fatalf("Failed to get the workload: %v", err)
+			}
+			evictedCond := apimeta.FindStatusCondition(gotWl.Status.Conditions, kueue.WorkloadEvicted)
+			gotEvicted := evictedCond != nil && evictedCond.Status == metav1.ConditionTrue && evictedCond.Reason == kueue.WorkloadEvictedByPodsCreationTimeout
+			if gotEvicted != tc.wantEvicted {
+				t.Errorf("Unexpected eviction by the pods creation timeout, want: %v, got condition: %v", tc.wantEvicted, evictedCond)
+			}
+		})
+	}
+}
+
 func TestReconcileGenericJobWithCustomWorkloadActivation(t *testing.T) {
 	const (
 		testJobName = "test-job"
@@ -938,6 +1524,7 @@ func TestProcessOptions(t *testing.T) {
 				WithWaitForPodsReady(&configapi.WaitForPodsReady{}),
 				WithKubeServerVersion(&kubeversion.ServerVersionFetcher{}),
 				WithLabelKeysToCopy([]string{"toCopyKey"}),
+				WithEvictionHooksMaxTimeout(5 * time.Minute),
 				WithClock(fakeClock),
 			},
 			wantOpts: Options{
@@ -946,6 +1533,7 @@ func TestProcessOptions(t *testing.T) {
 				KubeServerVersion:          &kubeversion.ServerVersionFetcher{},
 				IntegrationOptions:         nil,
 				LabelKeysToCopy:            []string{"toCopyKey"},
+				EvictionHooksMaxTimeout:    5 * time.Minute,
 				Clock:                      fakeClock,
 			},
 		},
@@ -958,6 +1546,7 @@ func TestProcessOptions(t *testing.T) {
 				WaitForPodsReady:           false,
 				KubeServerVersion:          nil,
 				IntegrationOptions:         nil,
+				EvictionHooksMaxTimeout:    workload.DefaultEvictionHooksMaxTimeout,
 				Clock:                      clock.RealClock{},
 			},
 		},
@@ -968,6 +1557,7 @@ func TestProcessOptions(t *testing.T) {
 				KubeServerVersion:          nil,
 				IntegrationOptions:         nil,
 				LabelKeysToCopy:            nil,
+				EvictionHooksMaxTimeout:    workload.DefaultEvictionHooksMaxTimeout,
 				Clock:                      clock.RealClock{},
 			},
 		},
@@ -69,6 +69,9 @@ func (p *podSetTopologyRequestBuilder) Build() (*kueue.PodSetTopologyRequest, er
 
 	podSetGroupName, podSetGroupNameFound := p.meta.Annotations[kueue.PodSetGroupName]
 
+	maxPodsPerDomainValue, maxPodsPerDomainFound := p.meta.Annotations[kueue.PodSetMaxPodsPerDomainAnnotation]
+	maxPodsPerDomainFound = maxPodsPerDomainFound && features.Enabled(features.TASMaxPodsPerDomain)
+
 	switch {
 	case requiredFound:
 		psTopologyReq.Required = &requiredValue
@@ -82,7 +85,7 @@ func (p *podSetTopologyRequestBuilder) Build() (*kueue.PodSetTopologyRequest, er
 		psTopologyReq.Unconstrained = &unconstrained
 	default:
 		hasSliceLayer := (sliceRequiredTopologyFound && sliceSizeFound) || constraintsFound
-		if !hasSliceLayer && (p.podIndexLabel == nil && p.subGroupIndexLabel == nil && p.subGroupCount == nil) {
+		if !hasSliceLayer && !maxPodsPerDomainFound && (p.podIndexLabel == nil && p.subGroupIndexLabel == nil && p.subGroupCount == nil) {
 			return nil, nil
 		}
 	}
@@ -106,6 +109,14 @@ func (p *podSetTopologyRequestBuilder) Build() (*kueue.PodSetTopologyRequest, er
 		psTopologyReq.PodSetSliceSize = new(int32(sliceSizeIntValue))
 	}
 
+	if maxPodsPerDomainFound {
+		maxPodsPerDomain, err := strconv.ParseInt(maxPodsPerDomainValue, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		psTopologyReq.MaxPodsPerDomain = new(int32(maxPodsPerDomain))
+	}
+
 	psTopologyReq.PodIndexLabel = p.podIndexLabel
 	psTopologyReq.SubGroupCount = p.subGroupCount
 	psTopologyReq.SubGroupIndexLabel = p.subGroupIndexLabel
@@ -294,6 +294,45 @@ func TestPodSetTopologyRequestBuilder(t *testing.T) {
 			},
 			wantErr: errParseTopologyConstraints,
 		},
+		"max pods per domain with required annotation": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TASMaxPodsPerDomain: true,
+			},
+			meta: &metav1.ObjectMeta{
+				Annotations: map[string]string{
+					kueue.PodSetRequiredTopologyAnnotation: "cloud.com/block",
+					kueue.PodSetMaxPodsPerDomainAnnotation: "2",
+				},
+			},
+			wantReq: &kueue.PodSetTopologyRequest{
+				Required:         new("cloud.com/block"),
+				MaxPodsPerDomain: ptr.To[int32](2),
+			},
+		},
+		"max pods per domain only": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TASMaxPodsPerDomain: true,
+			},
+			meta: &metav1.ObjectMeta{
+				Annotations: map[string]string{
+					kueue.PodSetMaxPodsPerDomainAnnotation: "2",
+				},
+			},
+			wantReq: &kueue.PodSetTopologyRequest{
+				MaxPodsPerDomain: ptr.To[int32](2),
+			},
+		},
+		"max pods per domain: ignored without feature gate": {
+			meta: &metav1.ObjectMeta{
+				Annotations: map[string]string{
+					kueue.PodSetRequiredTopologyAnnotation: "cloud.com/block",
+					kueue.PodSetMaxPodsPerDomainAnnotation: "2",
+				},
+			},
+			wantReq: &kueue.PodSetTopologyRequest{
+				Required: new("cloud.com/block"),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
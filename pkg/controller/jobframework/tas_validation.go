@@ -20,9 +20,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
@@ -95,9 +98,13 @@ func ValidateTASPodSetRequest(replicaPath *field.Path, replicaMetadata *metav1.O
 	unconstrainedErrs := validateTASUnconstrained(annotationsPath, replicaMetadata)
 	allErrs = append(allErrs, unconstrainedErrs...)
 
+	allErrs = append(allErrs, validateTASUnconstrainedFallback(annotationsPath, replicaMetadata, requiredFound || preferredFound)...)
+
 	sliceSizeAnnotationErr := validateSliceSizeAnnotation(annotationsPath, replicaMetadata)
 	allErrs = append(allErrs, sliceSizeAnnotationErr...)
 
+	allErrs = append(allErrs, validateMaxPodsPerDomainAnnotation(annotationsPath, replicaMetadata)...)
+
 	// validate slice annotations
 	if sliceRequiredFound && !sliceSizeFound {
 		allErrs = append(allErrs, field.Required(annotationsPath.Key(kueue.PodSetSliceSizeAnnotation), fmt.Sprintf("must be set when '%s' is specified", kueue.PodSetSliceRequiredTopologyAnnotation)))
@@ -128,6 +135,23 @@ func validateTASUnconstrained(annotationsPath *field.Path, replicaMetadata *meta
 	return nil
 }
 
+func validateTASUnconstrainedFallback(annotationsPath *field.Path, replicaMetadata *metav1.ObjectMeta, topologyFound bool) field.ErrorList {
+	val, ok := replicaMetadata.Annotations[kueue.PodSetUnconstrainedTopologyFallbackAnnotation]
+	if !ok {
+		return nil
+	}
+	fallbackPath := annotationsPath.Key(kueue.PodSetUnconstrainedTopologyFallbackAnnotation)
+	var allErrs field.ErrorList
+	if !topologyFound {
+		allErrs = append(allErrs, field.Forbidden(fallbackPath,
+			fmt.Sprintf("may not be set when neither '%s' nor '%s' is specified", kueue.PodSetPreferredTopologyAnnotation, kueue.PodSetRequiredTopologyAnnotation)))
+	}
+	if d, err := time.ParseDuration(val); err != nil || d <= 0 {
+		allErrs = append(allErrs, field.Invalid(fallbackPath, val, "must be a positive duration"))
+	}
+	return allErrs
+}
+
 func validateSliceSizeAnnotation(annotationsPath *field.Path, replicaMetadata *metav1.ObjectMeta) field.ErrorList {
 	sliceSizeValue, sliceSizeFound := replicaMetadata.Annotations[kueue.PodSetSliceSizeAnnotation]
 	if !sliceSizeFound {
@@ -155,6 +179,26 @@ func validateSliceSizeAnnotation(annotationsPath *field.Path, replicaMetadata *m
 	return nil
 }
 
+func validateMaxPodsPerDomainAnnotation(annotationsPath *field.Path, replicaMetadata *metav1.ObjectMeta) field.ErrorList {
+	val, ok := replicaMetadata.Annotations[kueue.PodSetMaxPodsPerDomainAnnotation]
+	if !ok {
+		return nil
+	}
+	fldPath := annotationsPath.Key(kueue.PodSetMaxPodsPerDomainAnnotation)
+	if !features.Enabled(features.TASMaxPodsPerDomain) {
+		return field.ErrorList{field.Forbidden(fldPath,
+			fmt.Sprintf("the %s feature gate must be enabled to use this annotation", features.TASMaxPodsPerDomain))}
+	}
+	maxPodsPerDomain, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, val, "must be a numeric value")}
+	}
+	if maxPodsPerDomain < 1 {
+		return field.ErrorList{field.Invalid(fldPath, val, "must be greater than or equal to 1")}
+	}
+	return nil
+}
+
 func validatePodSetGroupNameAnnotation(groupName string, annotationPath *field.Path) field.ErrorList {
 	if _, err := strconv.ParseUint(groupName, 10, 64); err == nil {
 		return field.ErrorList{
@@ -211,6 +255,35 @@ func ValidateSliceSizeAnnotationUpperBound(replicaPath *field.Path, replicaMetad
 	return allErrs
 }
 
+// ValidateTASPodAntiAffinity validates that a PodSet requiring the hostname
+// topology level, that is, all its pods on a single node, doesn't also
+// require pod anti-affinity between its pods on the hostname level, as such
+// pods could never be scheduled.
+func ValidateTASPodAntiAffinity(podSpecPath *field.Path, podSet *kueue.PodSet) field.ErrorList {
+	if podSet == nil || podSet.Count <= 1 || podSet.Template.Annotations[kueue.PodSetRequiredTopologyAnnotation] != corev1.LabelHostname {
+		return nil
+	}
+	affinity := podSet.Template.Spec.Affinity
+	if affinity == nil || affinity.PodAntiAffinity == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	termsPath := podSpecPath.Child("affinity", "podAntiAffinity", "requiredDuringSchedulingIgnoredDuringExecution")
+	for i, term := range affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		if term.TopologyKey != corev1.LabelHostname || len(term.Namespaces) > 0 || term.NamespaceSelector != nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil || !selector.Matches(labels.Set(podSet.Template.Labels)) {
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(termsPath.Index(i),
+			fmt.Sprintf("must not select the pods of the PodSet when '%s' is '%s', as it requires all the pods on a single node",
+				kueue.PodSetRequiredTopologyAnnotation, corev1.LabelHostname)))
+	}
+	return allErrs
+}
+
 func ValidatePodSetGroupingTopology(podSets []kueue.PodSet, podSetAnnotationsByName map[kueue.PodSetReference]*field.Path) field.ErrorList {
 	podSetGroups := orderedgroups.NewOrderedGroups[string, kueue.PodSet]()
 	for _, podSet := range podSets {
@@ -19,6 +19,7 @@ package jobframework
 import (
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/component-base/featuregate"
@@ -218,3 +219,188 @@ func TestValidateSliceSizeAnnotationUpperBound(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTASPodAntiAffinity(t *testing.T) {
+	podSpecPath := field.NewPath("spec", "template", "spec")
+	antiAffinity := func(topologyKey string, matchLabels map[string]string) *corev1.Affinity {
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: matchLabels},
+					TopologyKey:   topologyKey,
+				}},
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		requiredTopology string
+		count            int32
+		affinity         *corev1.Affinity
+		wantErrNum       int
+	}{
+		"valid: hostname required without pod anti-affinity": {
+			requiredTopology: corev1.LabelHostname,
+			count:            2,
+		},
+		"valid: rack required with pod anti-affinity on hostname": {
+			requiredTopology: "cloud.com/rack",
+			count:            2,
+			affinity:         antiAffinity(corev1.LabelHostname, map[string]string{"app": "job"}),
+		},
+		"valid: hostname required with pod anti-affinity on hostname for a single pod": {
+			requiredTopology: corev1.LabelHostname,
+			count:            1,
+			affinity:         antiAffinity(corev1.LabelHostname, map[string]string{"app": "job"}),
+		},
+		"valid: hostname required with pod anti-affinity selecting other pods": {
+			requiredTopology: corev1.LabelHostname,
+			count:            2,
+			affinity:         antiAffinity(corev1.LabelHostname, map[string]string{"app": "other"}),
+		},
+		"invalid: hostname required with pod anti-affinity on hostname selecting its pods": {
+			requiredTopology: corev1.LabelHostname,
+			count:            2,
+			affinity:         antiAffinity(corev1.LabelHostname, map[string]string{"app": "job"}),
+			wantErrNum:       1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			podSet := &kueue.PodSet{
+				Count: tc.count,
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      map[string]string{"app": "job"},
+						Annotations: map[string]string{kueue.PodSetRequiredTopologyAnnotation: tc.requiredTopology},
+					},
+					Spec: corev1.PodSpec{Affinity: tc.affinity},
+				},
+			}
+			errs := ValidateTASPodAntiAffinity(podSpecPath, podSet)
+			if got := len(errs); got != tc.wantErrNum {
+				t.Errorf("ValidateTASPodAntiAffinity() returned %d errors, want %d:\n%v", got, tc.wantErrNum, errs)
+			}
+		})
+	}
+}
+
+func TestValidateUnconstrainedTopologyFallbackAnnotation(t *testing.T) {
+	replicaPath := field.NewPath("spec", "template", "metadata")
+
+	testCases := map[string]struct {
+		annotations map[string]string
+		wantErrNum  int
+	}{
+		"valid: fallback with preferred topology": {
+			annotations: map[string]string{
+				kueue.PodSetPreferredTopologyAnnotation:             "cloud.com/rack",
+				kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "10m",
+			},
+			wantErrNum: 0,
+		},
+		"valid: fallback with required topology": {
+			annotations: map[string]string{
+				kueue.PodSetRequiredTopologyAnnotation:              "cloud.com/rack",
+				kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "30s",
+			},
+			wantErrNum: 0,
+		},
+		"invalid: fallback without topology": {
+			annotations: map[string]string{
+				kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "10m",
+			},
+			wantErrNum: 1,
+		},
+		"invalid: fallback with unconstrained topology": {
+			annotations: map[string]string{
+				kueue.PodSetUnconstrainedTopologyAnnotation:         "true",
+				kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "10m",
+			},
+			wantErrNum: 1,
+		},
+		"invalid: fallback is not a duration": {
+			annotations: map[string]string{
+				kueue.PodSetPreferredTopologyAnnotation:             "cloud.com/rack",
+				kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "ten minutes",
+			},
+			wantErrNum: 1,
+		},
+		"invalid: fallback is not positive": {
+			annotations: map[string]string{
+				kueue.PodSetPreferredTopologyAnnotation:             "cloud.com/rack",
+				kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "0s",
+			},
+			wantErrNum: 1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			meta := &metav1.ObjectMeta{
+				Annotations: tc.annotations,
+			}
+			errs := ValidateTASPodSetRequest(replicaPath, meta)
+			if got := len(errs); got != tc.wantErrNum {
+				t.Errorf("ValidateTASPodSetRequest() returned %d errors, want %d:\n%v", got, tc.wantErrNum, errs)
+			}
+		})
+	}
+}
+
+func TestValidateMaxPodsPerDomainAnnotation(t *testing.T) {
+	replicaPath := field.NewPath("spec", "template", "metadata")
+
+	testCases := map[string]struct {
+		annotations map[string]string
+		disableGate bool
+		wantErrNum  int
+	}{
+		"valid: max pods per domain with required topology": {
+			annotations: map[string]string{
+				kueue.PodSetRequiredTopologyAnnotation: "kubernetes.io/hostname",
+				kueue.PodSetMaxPodsPerDomainAnnotation: "2",
+			},
+			wantErrNum: 0,
+		},
+		"valid: max pods per domain without topology": {
+			annotations: map[string]string{
+				kueue.PodSetMaxPodsPerDomainAnnotation: "1",
+			},
+			wantErrNum: 0,
+		},
+		"invalid: max pods per domain is not a number": {
+			annotations: map[string]string{
+				kueue.PodSetMaxPodsPerDomainAnnotation: "two",
+			},
+			wantErrNum: 1,
+		},
+		"invalid: max pods per domain is not positive": {
+			annotations: map[string]string{
+				kueue.PodSetMaxPodsPerDomainAnnotation: "0",
+			},
+			wantErrNum: 1,
+		},
+		"invalid: feature gate disabled": {
+			annotations: map[string]string{
+				kueue.PodSetMaxPodsPerDomainAnnotation: "2",
+			},
+			disableGate: true,
+			wantErrNum:  1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TASMaxPodsPerDomain, !tc.disableGate)
+			meta := &metav1.ObjectMeta{
+				Annotations: tc.annotations,
+			}
+			errs := ValidateTASPodSetRequest(replicaPath, meta)
+			if got := len(errs); got != tc.wantErrNum {
+				t.Errorf("ValidateTASPodSetRequest() returned %d errors, want %d:\n%v", got, tc.wantErrNum, errs)
+			}
+		})
+	}
+}
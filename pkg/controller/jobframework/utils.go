@@ -18,9 +18,13 @@ package jobframework
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -110,6 +114,11 @@ func RecordWorkloadCreationLatency(ctx context.Context, job client.Object, jobKi
 // WorkloadShouldBeSuspended determines whether jobObj should be default suspended on creation
 func WorkloadShouldBeSuspended(ctx context.Context, jobObj client.Object, k8sClient client.Client,
 	manageJobsWithoutQueueName bool, managedJobsNamespaceSelector labels.Selector) (bool, error) {
+	// Do not default suspend a job which opts out of the management by Kueue
+	if IsOptedOutForObject(jobObj) {
+		return false, nil
+	}
+
 	// Do not default suspend a job whose ancestor is already managed by Kueue
 	ancestorJob, err := FindAncestorJobManagedByKueue(ctx, k8sClient, jobObj, manageJobsWithoutQueueName)
 	if err != nil || ancestorJob != nil {
@@ -173,6 +182,84 @@ func MaximumExecutionTimeSecondsForObject(object client.Object) *int32 {
 	return new(int32(v))
 }
 
+// FallbackQueueForObject extracts the fallback LocalQueue name and the wait
+// duration after which it applies from the given object's annotations.
+// It returns false if either annotation is missing or invalid.
+func FallbackQueueForObject(object client.Object) (kueue.LocalQueueName, time.Duration, bool) {
+	annotations := object.GetAnnotations()
+	queueName, found := annotations[controllerconstants.FallbackQueueAnnotation]
+	if !found || queueName == "" {
+		return "", 0, false
+	}
+	after, err := time.ParseDuration(annotations[controllerconstants.FallbackQueueAfterAnnotation])
+	if err != nil || after <= 0 {
+		return "", 0, false
+	}
+	return kueue.LocalQueueName(queueName), after, true
+}
+
+// CandidateQueuesForObject extracts the prioritized list of candidate LocalQueue
+// names from the given object's annotations. It returns nil if the annotation is missing.
+func CandidateQueuesForObject(object client.Object) []kueue.LocalQueueName {
+	value := object.GetAnnotations()[controllerconstants.CandidateQueuesAnnotation]
+	if value == "" {
+		return nil
+	}
+	names := strings.Split(value, ",")
+	queues := make([]kueue.LocalQueueName, 0, len(names))
+	for _, name := range names {
+		queues = append(queues, kueue.LocalQueueName(strings.TrimSpace(name)))
+	}
+	return queues
+}
+
+// PodsCreationTimeoutForObject extracts the pods creation timeout from the
+// given object's annotations. It returns false if the annotation is missing
+// or invalid.
+func PodsCreationTimeoutForObject(object client.Object) (time.Duration, bool) {
+	timeout, err := time.ParseDuration(object.GetAnnotations()[controllerconstants.PodsCreationTimeoutAnnotation])
+	if err != nil || timeout <= 0 {
+		return 0, false
+	}
+	return timeout, true
+}
+
+// PodTemplateMutationPolicyForObject returns the policy set by the given object's
+// pod-template-mutation-policy annotation, defaulting to PodTemplateMutationPolicyFlag.
+func PodTemplateMutationPolicyForObject(object client.Object) string {
+	if object.GetAnnotations()[controllerconstants.PodTemplateMutationPolicyAnnotation] == controllerconstants.PodTemplateMutationPolicyReadmit {
+		return controllerconstants.PodTemplateMutationPolicyReadmit
+	}
+	return controllerconstants.PodTemplateMutationPolicyFlag
+}
+
+// IsOptedOutForObject returns true if the given object opts out of the management
+// by Kueue with the managed annotation set to "false".
+func IsOptedOutForObject(object client.Object) bool {
+	if !features.Enabled(features.JobManagementOptOut) {
+		return false
+	}
+	return object.GetAnnotations()[controllerconstants.ManagedAnnotation] == "false"
+}
+
+// PodTemplatesHash returns a deterministic hash of the names and the pod specs of
+// the given podSets. The counts are not part of the hash, as they can change for
+// a running job, for example with partial admission.
+func PodTemplatesHash(podSets []kueue.PodSet) (string, error) {
+	templates := make([]map[string]any, 0, len(podSets))
+	for i := range podSets {
+		templates = append(templates, map[string]any{
+			"name": podSets[i].Name,
+			"spec": podSets[i].Template.Spec,
+		})
+	}
+	templatesJSON, err := json.Marshal(templates)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(templatesJSON))[:16], nil
+}
+
 // WorkloadPriorityClassName retrieves the value of the "kueue.x-k8s.io/priority-class" label
 // from the given object. If the label is not present, it returns an empty string.
 func WorkloadPriorityClassName(object client.Object) string {
@@ -224,13 +311,19 @@ func SetMultiKueueMeta(obj client.Object, workloadName, origin string) {
 // NewWorkload creates a new Workload object with the specified name,
 // associated object, pod sets, and label keys to copy.
 func NewWorkload(name string, obj client.Object, podSets []kueue.PodSet, labelKeysToCopy []string) *kueue.Workload {
+	annotations := admissioncheck.FilterProvReqAnnotations(obj.GetAnnotations())
+	if features.Enabled(features.TASColocateWithWorkload) {
+		if colocateWith, found := obj.GetAnnotations()[kueue.ColocateWithWorkloadAnnotation]; found {
+			annotations[kueue.ColocateWithWorkloadAnnotation] = colocateWith
+		}
+	}
 	return &kueue.Workload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   obj.GetNamespace(),
 			Labels:      maps.FilterKeys(obj.GetLabels(), labelKeysToCopy),
 			Finalizers:  []string{kueue.ResourceInUseFinalizerName},
-			Annotations: admissioncheck.FilterProvReqAnnotations(obj.GetAnnotations()),
+			Annotations: annotations,
 		},
 		Spec: kueue.WorkloadSpec{
 			QueueName:                   QueueNameForObject(obj),
@@ -22,6 +22,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	kfmpi "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	kftrainer "github.com/kubeflow/trainer/v2/pkg/apis/trainer/v1alpha1"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
@@ -51,10 +53,16 @@ var (
 	annotationsPath                = metaPath.Child("annotations")
 	queueNameLabelPath             = labelsPath.Key(constants.QueueLabel)
 	maxExecTimeLabelPath           = labelsPath.Key(constants.MaxExecTimeSecondsLabel)
+	fallbackQueueAnnotationPath    = annotationsPath.Key(constants.FallbackQueueAnnotation)
+	fallbackQueueAfterPath         = annotationsPath.Key(constants.FallbackQueueAfterAnnotation)
+	candidateQueuesAnnotationPath  = annotationsPath.Key(constants.CandidateQueuesAnnotation)
+	podsCreationTimeoutPath        = annotationsPath.Key(constants.PodsCreationTimeoutAnnotation)
+	podTemplateMutationPolicyPath  = annotationsPath.Key(constants.PodTemplateMutationPolicyAnnotation)
 	workloadPriorityClassNamePath  = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 	prebuiltWorkloadLabelPath      = labelsPath.Key(constants.PrebuiltWorkloadLabel)
 	prebuiltWorkloadAnnotationPath = annotationsPath.Key(constants.PrebuiltWorkloadAnnotation)
 	elasticJobAnnotationPath       = annotationsPath.Key(workloadslicing.EnabledAnnotationKey)
+	managedAnnotationPath          = annotationsPath.Key(constants.ManagedAnnotation)
 	supportedElasticJobGVKs        = sets.New(
 		batchv1.SchemeGroupVersion.WithKind("Job").String(),
 		rayv1.GroupVersion.WithKind("RayCluster").String(),
@@ -88,6 +96,21 @@ func ValidateJobOnCreate(job GenericJob) field.ErrorList {
 	if features.Enabled(features.AdmissionGatedBy) {
 		allErrs = append(allErrs, webhook.ValidateAdmissionGatedByAnnotationOnCreate(job.Object())...)
 	}
+	if features.Enabled(features.FallbackLocalQueue) {
+		allErrs = append(allErrs, validateFallbackQueue(job.Object())...)
+	}
+	if features.Enabled(features.CandidateLocalQueues) {
+		allErrs = append(allErrs, validateCandidateQueues(job.Object())...)
+	}
+	if features.Enabled(features.PodsCreationTimeout) {
+		allErrs = append(allErrs, validatePodsCreationTimeout(job.Object())...)
+	}
+	if features.Enabled(features.PodTemplateHashing) {
+		allErrs = append(allErrs, validatePodTemplateMutationPolicy(job.Object())...)
+	}
+	if features.Enabled(features.JobManagementOptOut) {
+		allErrs = append(allErrs, validateManagedAnnotation(job.Object())...)
+	}
 
 	return allErrs
 }
@@ -103,6 +126,22 @@ func ValidateJobOnUpdate(oldJob, newJob GenericJob, defaultQueueExist func(strin
 	if features.Enabled(features.AdmissionGatedBy) {
 		allErrs = append(allErrs, webhook.ValidateAdmissionGatedByAnnotationOnUpdate(oldJob.Object(), newJob.Object())...)
 	}
+	if features.Enabled(features.FallbackLocalQueue) {
+		allErrs = append(allErrs, validateFallbackQueue(newJob.Object())...)
+	}
+	if features.Enabled(features.CandidateLocalQueues) {
+		allErrs = append(allErrs, validateCandidateQueues(newJob.Object())...)
+	}
+	if features.Enabled(features.PodsCreationTimeout) {
+		allErrs = append(allErrs, validatePodsCreationTimeout(newJob.Object())...)
+	}
+	if features.Enabled(features.PodTemplateHashing) {
+		allErrs = append(allErrs, validatePodTemplateMutationPolicy(newJob.Object())...)
+	}
+	if features.Enabled(features.JobManagementOptOut) {
+		allErrs = append(allErrs, validateManagedAnnotation(newJob.Object())...)
+		allErrs = append(allErrs, validateUpdateForManagedAnnotation(oldJob, newJob)...)
+	}
 
 	return allErrs
 }
@@ -235,6 +274,106 @@ func validateCreateForMaxExecTime(job GenericJob) field.ErrorList {
 	return nil
 }
 
+func validateFallbackQueue(obj client.Object) field.ErrorList {
+	annotations := obj.GetAnnotations()
+	_, queueFound := annotations[constants.FallbackQueueAnnotation]
+	after, afterFound := annotations[constants.FallbackQueueAfterAnnotation]
+	if !queueFound && !afterFound {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if !queueFound {
+		allErrs = append(allErrs, field.Required(fallbackQueueAnnotationPath, fmt.Sprintf("must be set together with %s", constants.FallbackQueueAfterAnnotation)))
+	} else {
+		allErrs = append(allErrs, ValidateAnnotationAsCRDName(obj, constants.FallbackQueueAnnotation)...)
+	}
+	if !afterFound {
+		allErrs = append(allErrs, field.Required(fallbackQueueAfterPath, fmt.Sprintf("must be set together with %s", constants.FallbackQueueAnnotation)))
+	} else if d, err := time.ParseDuration(after); err != nil {
+		allErrs = append(allErrs, field.Invalid(fallbackQueueAfterPath, after, err.Error()))
+	} else if d <= 0 {
+		allErrs = append(allErrs, field.Invalid(fallbackQueueAfterPath, after, "should be greater than 0"))
+	}
+	return allErrs
+}
+
+func validatePodsCreationTimeout(obj client.Object) field.ErrorList {
+	timeout, found := obj.GetAnnotations()[constants.PodsCreationTimeoutAnnotation]
+	if !found {
+		return nil
+	}
+	if d, err := time.ParseDuration(timeout); err != nil {
+		return field.ErrorList{field.Invalid(podsCreationTimeoutPath, timeout, err.Error())}
+	} else if d <= 0 {
+		return field.ErrorList{field.Invalid(podsCreationTimeoutPath, timeout, "should be greater than 0")}
+	}
+	return nil
+}
+
+func validatePodTemplateMutationPolicy(obj client.Object) field.ErrorList {
+	policy, found := obj.GetAnnotations()[constants.PodTemplateMutationPolicyAnnotation]
+	if !found {
+		return nil
+	}
+	supported := []string{constants.PodTemplateMutationPolicyFlag, constants.PodTemplateMutationPolicyReadmit}
+	if !slices.Contains(supported, policy) {
+		return field.ErrorList{field.NotSupported(podTemplateMutationPolicyPath, policy, supported)}
+	}
+	return nil
+}
+
+func validateManagedAnnotation(obj client.Object) field.ErrorList {
+	value, found := obj.GetAnnotations()[constants.ManagedAnnotation]
+	if !found {
+		return nil
+	}
+	supported := []string{"true", "false"}
+	if !slices.Contains(supported, value) {
+		return field.ErrorList{field.NotSupported(managedAnnotationPath, value, supported)}
+	}
+	return nil
+}
+
+// validateUpdateForManagedAnnotation prevents opting a running job in or out of
+// the management by Kueue, as the job would be admitted or stopped by Kueue.
+func validateUpdateForManagedAnnotation(oldJob, newJob GenericJob) field.ErrorList {
+	if !newJob.IsSuspended() || !oldJob.IsSuspended() {
+		return apivalidation.ValidateImmutableField(
+			newJob.Object().GetAnnotations()[constants.ManagedAnnotation],
+			oldJob.Object().GetAnnotations()[constants.ManagedAnnotation],
+			managedAnnotationPath,
+		)
+	}
+	return nil
+}
+
+func validateCandidateQueues(obj client.Object) field.ErrorList {
+	value, found := obj.GetAnnotations()[constants.CandidateQueuesAnnotation]
+	if !found {
+		return nil
+	}
+	candidates := CandidateQueuesForObject(obj)
+	if len(candidates) == 0 {
+		return field.ErrorList{field.Invalid(candidateQueuesAnnotationPath, value, "must list at least one LocalQueue")}
+	}
+
+	var allErrs field.ErrorList
+	seen := sets.New[kueue.LocalQueueName]()
+	for _, candidate := range candidates {
+		if errs := validation.IsDNS1123Subdomain(string(candidate)); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(candidateQueuesAnnotationPath, value, strings.Join(errs, ",")))
+		} else if seen.Has(candidate) {
+			allErrs = append(allErrs, field.Duplicate(candidateQueuesAnnotationPath, candidate))
+		}
+		seen.Insert(candidate)
+	}
+	if queueName := QueueNameForObject(obj); !seen.Has(queueName) {
+		allErrs = append(allErrs, field.Invalid(queueNameLabelPath, queueName, fmt.Sprintf("must be one of the LocalQueues listed in %s", constants.CandidateQueuesAnnotation)))
+	}
+	return allErrs
+}
+
 func validateUpdateForMaxExecTime(oldJob, newJob GenericJob) field.ErrorList {
 	if !newJob.IsSuspended() || !oldJob.IsSuspended() {
 		return apivalidation.ValidateImmutableField(
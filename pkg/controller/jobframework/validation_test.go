@@ -30,6 +30,7 @@ import (
 	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	mocks "sigs.k8s.io/kueue/internal/mocks/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
@@ -390,6 +391,33 @@ func TestValidateJobOnUpdate(t *testing.T) {
 			newJob:       utiltestingjob.MakeJob("test-job", "ns1").PrebuiltWorkloadAnnotation("workload-name-new").Suspend(true).Obj(),
 			featureGates: map[featuregate.Feature]bool{features.WorkloadIdentifierAnnotations: true},
 		},
+		"managed annotation cannot be added if job is not suspended": {
+			oldJob:       utiltestingjob.MakeJob("test-job", "ns1").Queue("lq1").Suspend(false).Obj(),
+			newJob:       utiltestingjob.MakeJob("test-job", "ns1").Queue("lq1").SetAnnotation(constants.ManagedAnnotation, "false").Suspend(false).Obj(),
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: true},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "metadata.annotations[kueue.x-k8s.io/managed]",
+				},
+			},
+		},
+		"managed annotation cannot be removed if job is not suspended": {
+			oldJob:       utiltestingjob.MakeJob("test-job", "ns1").Queue("lq1").SetAnnotation(constants.ManagedAnnotation, "false").Suspend(false).Obj(),
+			newJob:       utiltestingjob.MakeJob("test-job", "ns1").Queue("lq1").Suspend(false).Obj(),
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: true},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "metadata.annotations[kueue.x-k8s.io/managed]",
+				},
+			},
+		},
+		"managed annotation can be changed if job is suspended": {
+			oldJob:       utiltestingjob.MakeJob("test-job", "ns1").Queue("lq1").Suspend(true).Obj(),
+			newJob:       utiltestingjob.MakeJob("test-job", "ns1").Queue("lq1").SetAnnotation(constants.ManagedAnnotation, "false").Suspend(true).Obj(),
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: true},
+		},
 		"prebuilt workload annotation to label update, WorkloadIdentifierAnnotations enabled": {
 			oldJob:       utiltestingjob.MakeJob("test-job", "ns1").PrebuiltWorkloadAnnotation("workload-name").Suspend(true).Obj(),
 			newJob:       utiltestingjob.MakeJob("test-job", "ns1").PrebuiltWorkloadLabel("workload-name-new").Suspend(true).Obj(),
@@ -425,6 +453,13 @@ func TestValidateJobOnUpdate(t *testing.T) {
 func TestValidateJobOnCreate(t *testing.T) {
 	t.Cleanup(jobframework.EnableIntegrationsForTest(t, "batch/job"))
 	elasticAnnotationPath := field.NewPath("metadata", "annotations").Key(workloadslicing.EnabledAnnotationKey)
+	fallbackQueuePath := field.NewPath("metadata", "annotations").Key(constants.FallbackQueueAnnotation)
+	fallbackQueueAfterPath := field.NewPath("metadata", "annotations").Key(constants.FallbackQueueAfterAnnotation)
+	candidateQueuesPath := field.NewPath("metadata", "annotations").Key(constants.CandidateQueuesAnnotation)
+	queueNameLabelPath := field.NewPath("metadata", "labels").Key(constants.QueueLabel)
+	podsCreationTimeoutPath := field.NewPath("metadata", "annotations").Key(constants.PodsCreationTimeoutAnnotation)
+	podTemplateMutationPolicyPath := field.NewPath("metadata", "annotations").Key(constants.PodTemplateMutationPolicyAnnotation)
+	managedAnnotationPath := field.NewPath("metadata", "annotations").Key(constants.ManagedAnnotation)
 	testCases := map[string]struct {
 		job          *batchv1.Job
 		gvk          schema.GroupVersionKind
@@ -462,6 +497,149 @@ func TestValidateJobOnCreate(t *testing.T) {
 			gvk:          schema.GroupVersionKind{Group: "jobset.x-k8s.io", Version: "v1alpha2", Kind: "JobSet"},
 			featureGates: map[featuregate.Feature]bool{features.ElasticJobsViaWorkloadSlices: false},
 		},
+		"valid fallback queue annotations": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.FallbackQueueAnnotation, "overflow").
+				SetAnnotation(constants.FallbackQueueAfterAnnotation, "10m").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.FallbackLocalQueue: true},
+		},
+		"fallback queue without wait duration is rejected": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.FallbackQueueAnnotation, "overflow").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.FallbackLocalQueue: true},
+			wantErr: field.ErrorList{
+				field.Required(fallbackQueueAfterPath, ""),
+			},
+		},
+		"wait duration without fallback queue is rejected": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.FallbackQueueAfterAnnotation, "10m").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.FallbackLocalQueue: true},
+			wantErr: field.ErrorList{
+				field.Required(fallbackQueuePath, ""),
+			},
+		},
+		"invalid fallback queue annotations are rejected": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.FallbackQueueAnnotation, "Overflow_Queue").
+				SetAnnotation(constants.FallbackQueueAfterAnnotation, "-10m").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.FallbackLocalQueue: true},
+			wantErr: field.ErrorList{
+				field.Invalid(fallbackQueuePath, "Overflow_Queue", ""),
+				field.Invalid(fallbackQueueAfterPath, "-10m", ""),
+			},
+		},
+		"valid candidate queues annotation": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				Queue("team-a").
+				SetAnnotation(constants.CandidateQueuesAnnotation, "team-a, team-b").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.CandidateLocalQueues: true},
+		},
+		"invalid and duplicate candidate queues are rejected": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				Queue("team-a").
+				SetAnnotation(constants.CandidateQueuesAnnotation, "team-a,Team_B,team-a").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.CandidateLocalQueues: true},
+			wantErr: field.ErrorList{
+				field.Invalid(candidateQueuesPath, "team-a,Team_B,team-a", ""),
+				field.Duplicate(candidateQueuesPath, kueue.LocalQueueName("team-a")),
+			},
+		},
+		"queue name not listed in the candidate queues is rejected": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				Queue("team-c").
+				SetAnnotation(constants.CandidateQueuesAnnotation, "team-a,team-b").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.CandidateLocalQueues: true},
+			wantErr: field.ErrorList{
+				field.Invalid(queueNameLabelPath, kueue.LocalQueueName("team-c"), ""),
+			},
+		},
+		"invalid candidate queues without feature gate are ignored": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.CandidateQueuesAnnotation, "Team_B").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.CandidateLocalQueues: false},
+		},
+		"invalid fallback queue annotations without feature gate are ignored": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.FallbackQueueAfterAnnotation, "soon").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.FallbackLocalQueue: false},
+		},
+		"valid pods creation timeout annotation": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.PodsCreationTimeoutAnnotation, "10m").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.PodsCreationTimeout: true},
+		},
+		"invalid pods creation timeout annotation is rejected": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.PodsCreationTimeoutAnnotation, "0s").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.PodsCreationTimeout: true},
+			wantErr: field.ErrorList{
+				field.Invalid(podsCreationTimeoutPath, "0s", ""),
+			},
+		},
+		"valid pod template mutation policy annotation": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.PodTemplateMutationPolicyAnnotation, constants.PodTemplateMutationPolicyReadmit).
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.PodTemplateHashing: true},
+		},
+		"unsupported pod template mutation policy annotation is rejected": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.PodTemplateMutationPolicyAnnotation, "Ignore").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.PodTemplateHashing: true},
+			wantErr: field.ErrorList{
+				field.NotSupported(podTemplateMutationPolicyPath, "Ignore", []string{}),
+			},
+		},
+		"valid managed annotation": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				Queue("lq1").
+				SetAnnotation(constants.ManagedAnnotation, "false").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: true},
+		},
+		"unsupported managed annotation is rejected": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.ManagedAnnotation, "no").
+				Obj(),
+			gvk:          batchv1.SchemeGroupVersion.WithKind("Job"),
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: true},
+			wantErr: field.ErrorList{
+				field.NotSupported(managedAnnotationPath, "no", []string{}),
+			},
+		},
+		"unsupported managed annotation is ignored when the feature is disabled": {
+			job: utiltestingjob.MakeJob("test-job", "ns1").
+				SetAnnotation(constants.ManagedAnnotation, "no").
+				Obj(),
+			gvk: batchv1.SchemeGroupVersion.WithKind("Job"),
+		},
 	}
 
 	for tcName, tc := range testCases {
@@ -0,0 +1,112 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/util/webhook"
+)
+
+var cronJobGVK = batchv1.SchemeGroupVersion.WithKind("CronJob")
+
+// CronJobWebhook propagates the Kueue labels of the CronJobs to their job
+// templates, so that the Jobs created by the CronJobs are managed by Kueue.
+type CronJobWebhook struct{}
+
+// setupCronJobWebhook configures the webhook for batch CronJobs.
+func setupCronJobWebhook(mgr ctrl.Manager, options jobframework.Options) error {
+	obj := &batchv1.CronJob{}
+	if options.NoopWebhook {
+		return webhook.SetupNoopWebhook(mgr, obj)
+	}
+	return ctrl.NewWebhookManagedBy(mgr, obj).
+		WithDefaulter(&CronJobWebhook{}).
+		WithLogConstructor(jobframework.WebhookLogConstructor(cronJobGVK, options.RoleTracker)).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-batch-v1-cronjob,mutating=true,failurePolicy=fail,sideEffects=None,groups=batch,resources=cronjobs,verbs=create;update,versions=v1,name=mcronjob.kb.io,admissionReviewVersions=v1
+
+var _ admission.Defaulter[*batchv1.CronJob] = &CronJobWebhook{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (w *CronJobWebhook) Default(ctx context.Context, cronJob *batchv1.CronJob) error {
+	if !features.Enabled(features.CronJobQueueLabelPropagation) {
+		return nil
+	}
+	log := ctrl.LoggerFrom(ctx).WithName("cronjob-webhook")
+	log.V(5).Info("Propagating queue-name")
+
+	for _, key := range []string{controllerconstants.QueueLabel, controllerconstants.WorkloadPriorityClassLabel} {
+		value, found := cronJob.Labels[key]
+		if !found || value == "" {
+			continue
+		}
+		if cronJob.Spec.JobTemplate.Labels == nil {
+			cronJob.Spec.JobTemplate.Labels = make(map[string]string, 1)
+		}
+		cronJob.Spec.JobTemplate.Labels[key] = value
+	}
+	return nil
+}
+
+// ReservedWorkloadNameForCronJobRun returns the name of the Workload reserved
+// ahead for the run of a CronJob whose Job has the given name.
+func ReservedWorkloadNameForCronJobRun(jobName string) string {
+	return "cronjob-" + jobName
+}
+
+// applyReservedWorkload sets the Workload reserved ahead for a run of the
+// CronJob owning the Job as the prebuilt Workload of the Job, unless the Job
+// already has a prebuilt Workload.
+func (w *JobWebhook) applyReservedWorkload(ctx context.Context, job *Job) error {
+	if !features.Enabled(features.CronJobQuotaReservation) || jobframework.PrebuiltWorkloadNameFor(job.Object()) != "" {
+		return nil
+	}
+	owner := metav1.GetControllerOfNoCopy(job.Object())
+	if owner == nil || owner.Kind != cronJobGVK.Kind || owner.APIVersion != cronJobGVK.GroupVersion().String() {
+		return nil
+	}
+	wl := &kueue.Workload{}
+	key := types.NamespacedName{Namespace: job.Namespace, Name: ReservedWorkloadNameForCronJobRun(job.Name)}
+	if err := w.client.Get(ctx, key, wl); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// Only claim a Workload reserved by the CronJob, which is not claimed by another Job yet.
+	if metav1.GetControllerOfNoCopy(wl) != nil || !slices.ContainsFunc(wl.OwnerReferences, func(ref metav1.OwnerReference) bool {
+		return ref.UID == owner.UID
+	}) {
+		return nil
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Setting the Workload reserved for the CronJob run as the prebuilt Workload", "workload", klog.KObj(wl))
+	jobframework.SetPrebuiltWorkloadName(job.Object(), wl.Name)
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	qcache "sigs.k8s.io/kueue/pkg/cache/queue"
+	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestCronJobDefault(t *testing.T) {
+	makeCronJob := func(cronJobLabels, templateLabels map[string]string) *batchv1.CronJob {
+		return &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cronjob",
+				Namespace: metav1.NamespaceDefault,
+				Labels:    cronJobLabels,
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "* * * * *",
+				JobTemplate: batchv1.JobTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: templateLabels},
+				},
+			},
+		}
+	}
+
+	testcases := map[string]struct {
+		cronJob     *batchv1.CronJob
+		enableGate  bool
+		want        *batchv1.CronJob
+		wantSuspend bool
+	}{
+		"queue label is propagated to the job template": {
+			cronJob:    makeCronJob(map[string]string{controllerconstants.QueueLabel: "user-queue"}, nil),
+			enableGate: true,
+			want: makeCronJob(
+				map[string]string{controllerconstants.QueueLabel: "user-queue"},
+				map[string]string{controllerconstants.QueueLabel: "user-queue"},
+			),
+			wantSuspend: true,
+		},
+		"queue and priority class labels are propagated to the job template": {
+			cronJob: makeCronJob(map[string]string{
+				controllerconstants.QueueLabel:                 "user-queue",
+				controllerconstants.WorkloadPriorityClassLabel: "high",
+			}, map[string]string{"app": "sample"}),
+			enableGate: true,
+			want: makeCronJob(
+				map[string]string{
+					controllerconstants.QueueLabel:                 "user-queue",
+					controllerconstants.WorkloadPriorityClassLabel: "high",
+				},
+				map[string]string{
+					"app":                          "sample",
+					controllerconstants.QueueLabel: "user-queue",
+					controllerconstants.WorkloadPriorityClassLabel: "high",
+				},
+			),
+			wantSuspend: true,
+		},
+		"queue label of the CronJob takes precedence over the job template": {
+			cronJob: makeCronJob(
+				map[string]string{controllerconstants.QueueLabel: "user-queue"},
+				map[string]string{controllerconstants.QueueLabel: "other-queue"},
+			),
+			enableGate: true,
+			want: makeCronJob(
+				map[string]string{controllerconstants.QueueLabel: "user-queue"},
+				map[string]string{controllerconstants.QueueLabel: "user-queue"},
+			),
+			wantSuspend: true,
+		},
+		"CronJob without the queue label": {
+			cronJob:    makeCronJob(map[string]string{"app": "sample"}, nil),
+			enableGate: true,
+			want:       makeCronJob(map[string]string{"app": "sample"}, nil),
+		},
+		"feature gate disabled": {
+			cronJob: makeCronJob(map[string]string{controllerconstants.QueueLabel: "user-queue"}, nil),
+			want:    makeCronJob(map[string]string{controllerconstants.QueueLabel: "user-queue"}, nil),
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.CronJobQueueLabelPropagation, tc.enableGate)
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			w := &CronJobWebhook{}
+			if err := w.Default(ctx, tc.cronJob); err != nil {
+				t.Fatalf("Default() returned error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.cronJob); diff != "" {
+				t.Errorf("Default() mismatch (-want,+got):\n%s", diff)
+			}
+
+			// Create the Job of a run of the CronJob, as the CronJob controller
+			// does, and check if it is managed by Kueue.
+			job := &batchv1.Job{
+				ObjectMeta: *tc.cronJob.Spec.JobTemplate.ObjectMeta.DeepCopy(),
+				Spec:       *tc.cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+			}
+			job.Name = "cronjob-29000000"
+			job.Namespace = tc.cronJob.Namespace
+			job.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(tc.cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			}
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(utiltesting.MakeNamespace(metav1.NamespaceDefault)).
+				Build()
+			cqCache := schdcache.New(cl)
+			jw := &JobWebhook{
+				client:                       cl,
+				managedJobsNamespaceSelector: labels.Everything(),
+				queues:                       qcache.NewManagerForUnitTests(cl, cqCache),
+				cache:                        cqCache,
+			}
+			if err := jw.Default(ctx, job); err != nil {
+				t.Fatalf("Default() of the Job returned error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantSuspend, ptr.Deref(job.Spec.Suspend, false)); diff != "" {
+				t.Errorf("Unexpected suspend of the Job (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
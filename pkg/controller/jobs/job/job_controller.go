@@ -141,6 +141,7 @@ var _ jobframework.GenericJob = (*Job)(nil)
 var _ jobframework.JobWithReclaimablePods = (*Job)(nil)
 var _ jobframework.JobWithCustomStop = (*Job)(nil)
 var _ jobframework.JobWithManagedBy = (*Job)(nil)
+var _ jobframework.JobWithSkip = (*Job)(nil)
 
 func (j *Job) Object() client.Object {
 	return (*batchv1.Job)(j)
@@ -158,6 +159,10 @@ func (j *Job) IsActive() bool {
 	return j.Status.Active != 0
 }
 
+func (j *Job) FinishedPods() (succeeded, failed int32) {
+	return j.Status.Succeeded, j.Status.Failed
+}
+
 func (j *Job) Suspend() {
 	j.Spec.Suspend = new(true)
 }
@@ -278,7 +283,7 @@ func (j *Job) RunWithPodSetsInfo(ctx context.Context, _ client.Client, podSetsIn
 
 	if j.minPodsCount() != nil {
 		j.Spec.Parallelism = new(info.Count)
-		if j.syncCompletionWithParallelism() {
+		if j.syncCompletionWithParallelism() && !j.hasCompletedIndexes() {
 			j.Spec.Completions = j.Spec.Parallelism
 		}
 	}
@@ -309,12 +314,56 @@ func (j *Job) RestorePodSetsInfo(_ context.Context, podSetsInfo []podset.PodSetI
 	return changed
 }
 
+// Skip skips the reconciliation of a suspended Job which is going to fail
+// because of its podFailurePolicy until all its pods are terminated, or it
+// failed, so that it's not resumed in the meantime.
+func (j *Job) Skip(ctx context.Context) bool {
+	if !features.Enabled(features.JobFailureTargetFinishesWorkload) ||
+		!j.DeletionTimestamp.IsZero() ||
+		!j.IsSuspended() ||
+		j.podsTerminated() ||
+		j.podFailurePolicyFailureTarget() == nil {
+		return false
+	}
+	_, _, finished := j.Finished(ctx)
+	return !finished
+}
+
+// podFailurePolicyFailureTarget returns the FailureTarget condition added to
+// the Job when a rule of its podFailurePolicy fails it, if any.
+func (j *Job) podFailurePolicyFailureTarget() *batchv1.JobCondition {
+	for i := range j.Status.Conditions {
+		c := &j.Status.Conditions[i]
+		if c.Type == batchv1.JobFailureTarget && c.Status == corev1.ConditionTrue && c.Reason == batchv1.JobReasonPodFailurePolicy {
+			return c
+		}
+	}
+	return nil
+}
+
+// podsTerminated returns true if the Job has neither active nor terminating
+// pods. The number of terminating pods is only tracked when the
+// JobPodReplacementPolicy feature of Kubernetes is enabled, otherwise the pods
+// are not considered terminated.
+func (j *Job) podsTerminated() bool {
+	return j.Status.Active == 0 && j.Status.Terminating != nil && *j.Status.Terminating == 0
+}
+
 func (j *Job) Finished(ctx context.Context) (message string, success, finished bool) {
 	for _, c := range j.Status.Conditions {
 		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
 			return c.Message, c.Type != batchv1.JobFailed, true
 		}
 	}
+	if features.Enabled(features.JobFailureTargetFinishesWorkload) && j.podsTerminated() {
+		// The Job is going to fail because of its podFailurePolicy, the Failed
+		// condition is only added once the Job controller observes all its pods
+		// terminated. The pods don't use the quota anymore, so there is no need
+		// to wait for it.
+		if c := j.podFailurePolicyFailureTarget(); c != nil {
+			return c.Message, false, true
+		}
+	}
 
 	return "", true, false
 }
@@ -369,6 +418,15 @@ func (j *Job) syncCompletionWithParallelism() bool {
 	return false
 }
 
+// hasCompletedIndexes returns true if the Job controller already tracks completed
+// indexes for the job. Changing the completions of such a job, e.g. on resume
+// after preemption, would drop the completed indexes above the new completions
+// and make them run again, so only the parallelism is adjusted in that case.
+func (j *Job) hasCompletedIndexes() bool {
+	return ptr.Deref(j.Spec.CompletionMode, batchv1.NonIndexedCompletion) == batchv1.IndexedCompletion &&
+		j.Status.CompletedIndexes != ""
+}
+
 func SetupIndexes(ctx context.Context, fieldIndexer client.FieldIndexer) error {
 	if err := fieldIndexer.IndexField(ctx, &batchv1.Job{}, indexer.OwnerReferenceUID, indexer.IndexOwnerUID); err != nil {
 		return err
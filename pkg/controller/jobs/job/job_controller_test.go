@@ -317,6 +317,62 @@ func TestPodSetsInfo(t *testing.T) {
 				},
 			},
 		},
+		"parallelism with completions kept for completed indexes": {
+			job: (*Job)(utiltestingjob.MakeJob("job", "ns").
+				Indexed(true).
+				Parallelism(10).
+				Completions(10).
+				SetAnnotation(JobMinParallelismAnnotation, "2").
+				SetAnnotation(JobCompletionsEqualParallelismAnnotation, "true").
+				Succeeded(5).
+				CompletedIndexes("0-4").
+				Obj()),
+			runInfo: []podset.PodSetInfo{
+				{
+					Count: 5,
+				},
+			},
+			wantUnsuspended: utiltestingjob.MakeJob("job", "ns").
+				Indexed(true).
+				Parallelism(5).
+				Completions(10).
+				SetAnnotation(JobMinParallelismAnnotation, "2").
+				SetAnnotation(JobCompletionsEqualParallelismAnnotation, "true").
+				Suspend(false).
+				Obj(),
+			restoreInfo: []podset.PodSetInfo{
+				{
+					Count: 10,
+				},
+			},
+		},
+		"parallelism with completions synced": {
+			job: (*Job)(utiltestingjob.MakeJob("job", "ns").
+				Indexed(true).
+				Parallelism(10).
+				Completions(10).
+				SetAnnotation(JobMinParallelismAnnotation, "2").
+				SetAnnotation(JobCompletionsEqualParallelismAnnotation, "true").
+				Obj()),
+			runInfo: []podset.PodSetInfo{
+				{
+					Count: 5,
+				},
+			},
+			wantUnsuspended: utiltestingjob.MakeJob("job", "ns").
+				Indexed(true).
+				Parallelism(5).
+				Completions(5).
+				SetAnnotation(JobMinParallelismAnnotation, "2").
+				SetAnnotation(JobCompletionsEqualParallelismAnnotation, "true").
+				Suspend(false).
+				Obj(),
+			restoreInfo: []podset.PodSetInfo{
+				{
+					Count: 10,
+				},
+			},
+		},
 		"noInfoOnRun": {
 			job: (*Job)(utiltestingjob.MakeJob("job", "ns").
 				Parallelism(5).
@@ -630,6 +686,7 @@ func TestReconciler(t *testing.T) {
 		reconcileKey      *types.NamespacedName
 		job               *batchv1.Job
 		workloads         []kueue.Workload
+		localQueues       []kueue.LocalQueue
 		otherJobs         []batchv1.Job
 		priorityClasses   []client.Object
 		wantJob           batchv1.Job
@@ -1205,6 +1262,39 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when workload is created, it has the co-location annotation of its owner; TASColocateWithWorkload": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling: false,
+				features.TASColocateWithWorkload: true,
+			},
+			job: baseJobWrapper.Clone().
+				SetAnnotation(kueue.ColocateWithWorkloadAnnotation, "data-loader").
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(kueue.ColocateWithWorkloadAnnotation, "data-loader").
+				UID("test-uid").
+				Suspend(true).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("job", "ns").
+					Annotations(map[string]string{kueue.ColocateWithWorkloadAnnotation: "data-loader"}).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue(localQueueName).
+					Priority(0).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: "test-uid"}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, "test-uid"),
+				},
+			},
+		},
 		"when workload is created, it has its owner ProvReq annotations": {
 			featureGates: map[featuregate.Feature]bool{
 				features.TopologyAwareScheduling: false,
@@ -1339,6 +1429,69 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when workload is admitted the configured labels of the workload and local queue are propagated to job": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling: false,
+
+				features.AssignQueueLabelsForPods: true,
+			},
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithPodLabelPropagation(&configapi.PodLabelPropagation{
+					WorkloadLabelKeys:   []string{"cost-center", "project"},
+					LocalQueueLabelKeys: []string{"cost-center", "team", "owner"},
+				}),
+			},
+			job: baseJobWrapper.Clone().
+				PodLabel("owner", "job-owner").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				PodLabel("owner", "job-owner").
+				PodLabel("cost-center", "wl-cost-center").
+				PodLabel("team", "lq-team").
+				PodLabel(constants.PodSetLabel, string(kueue.DefaultPodSetName)).
+				PodLabel(constants.LocalQueueLabel, localQueueName).
+				PodLabel(constants.ClusterQueueLabel, clusterQueueName).
+				Obj(),
+			localQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue(localQueueName, "ns").
+					Label("cost-center", "lq-cost-center").
+					Label("team", "lq-team").
+					Label("owner", "lq-owner").
+					Label("other", "lq-other").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).
+						Request(corev1.ResourceCPU, "1").
+						Labels(map[string]string{"owner": "job-owner"}).
+						Obj()).
+					Label("cost-center", "wl-cost-center").
+					Label("other", "wl-other").
+					AdmittedAt(true, now).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).
+						Request(corev1.ResourceCPU, "1").
+						Labels(map[string]string{"owner": "job-owner"}).
+						Obj()).
+					Label("cost-center", "wl-cost-center").
+					Label("other", "wl-other").
+					AdmittedAt(true, now).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Admitted by clusterQueue cq",
+				},
+			},
+		},
 		"when workload is evicted due to spec.active field being false, job gets suspended and quota is unset": {
 			featureGates: map[featuregate.Feature]bool{
 				features.TopologyAwareScheduling: false,
@@ -2308,6 +2461,173 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when workload is evicted due to preemption, job isn't suspended while the eviction hooks are pending": {
+			featureGates: map[featuregate.Feature]bool{
+				features.WorkloadEvictionHooks: true,
+			},
+			job: baseJobWrapper.Clone().
+				Suspend(false).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Annotation(controllerconsts.EvictionHooksAnnotation, "example.com/checkpoint").
+					AdmittedAt(true, now.Add(-time.Minute)).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+						Reason:             kueue.WorkloadEvictedByPreemption,
+						Message:            "Preempted",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Annotation(controllerconsts.EvictionHooksAnnotation, "example.com/checkpoint").
+					AdmittedAt(true, now.Add(-time.Minute)).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByPreemption,
+						Message: "Preempted",
+					}).
+					Obj(),
+			},
+		},
+		"when workload is evicted due to preemption, job gets suspended once the eviction hooks time out": {
+			featureGates: map[featuregate.Feature]bool{
+				features.WorkloadEvictionHooks: true,
+			},
+			job: baseJobWrapper.Clone().
+				Suspend(false).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(true).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Annotation(controllerconsts.EvictionHooksAnnotation, "example.com/checkpoint").
+					Annotation(controllerconsts.EvictionHooksTimeoutAnnotation, "5m").
+					AdmittedAt(true, now.Add(-10*time.Minute)).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-6 * time.Minute)),
+						Reason:             kueue.WorkloadEvictedByPreemption,
+						Message:            "Preempted",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Annotation(controllerconsts.EvictionHooksAnnotation, "example.com/checkpoint").
+					Annotation(controllerconsts.EvictionHooksTimeoutAnnotation, "5m").
+					PastAdmittedTime(600).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadAdmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  "NoReservation",
+						Message: "The workload has no reservation",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "Preempted",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadRequeued,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByPreemption,
+						Message: "Preempted",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByPreemption,
+						Message: "Preempted",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Stopped",
+					Message:   "Preempted",
+				},
+			},
+		},
+		"when workload is evicted due to preemption, job gets suspended once the maximum timeout of the eviction hooks elapses": {
+			featureGates: map[featuregate.Feature]bool{
+				features.WorkloadEvictionHooks: true,
+			},
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithEvictionHooksMaxTimeout(5 * time.Minute),
+			},
+			job: baseJobWrapper.Clone().
+				Suspend(false).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(true).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Annotation(controllerconsts.EvictionHooksAnnotation, "example.com/checkpoint").
+					Annotation(controllerconsts.EvictionHooksTimeoutAnnotation, "30m").
+					AdmittedAt(true, now.Add(-10*time.Minute)).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-6 * time.Minute)),
+						Reason:             kueue.WorkloadEvictedByPreemption,
+						Message:            "Preempted",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Annotation(controllerconsts.EvictionHooksAnnotation, "example.com/checkpoint").
+					Annotation(controllerconsts.EvictionHooksTimeoutAnnotation, "30m").
+					PastAdmittedTime(600).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadAdmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  "NoReservation",
+						Message: "The workload has no reservation",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "Preempted",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadRequeued,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByPreemption,
+						Message: "Preempted",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByPreemption,
+						Message: "Preempted",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Stopped",
+					Message:   "Preempted",
+				},
+			},
+		},
 		"when job is initially suspended, the Workload has active=false and it's not admitted, " +
 			"it should not get an evicted condition, but the job should remain suspended": {
 			featureGates: map[featuregate.Feature]bool{
@@ -2876,24 +3196,90 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
-		"suspended job with partial admission and admitted workload is unsuspended": {
+		"non-matching admitted workload of suspended job is evicted and updated with ReadmitOnPodSetsChange": {
 			featureGates: map[featuregate.Feature]bool{
 				features.TopologyAwareScheduling: false,
-
-				features.AssignQueueLabelsForPods: true,
+				features.ReadmitOnPodSetsChange:  true,
 			},
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
 				jobframework.WithManagedJobsNamespaceSelector(labels.Everything()),
 			},
-			job: baseJobWrapper.Clone().
-				SetAnnotation(JobMinParallelismAnnotation, "5").
-				Obj(),
-			wantJob: *baseJobWrapper.Clone().
-				SetAnnotation(JobMinParallelismAnnotation, "5").
-				Suspend(false).
-				Parallelism(8).
-				PodLabel(constants.PodSetLabel, string(kueue.DefaultPodSetName)).
+			job:     baseJobWrapper.Clone().Request(corev1.ResourceCPU, "2").Obj(),
+			wantJob: *baseJobWrapper.Clone().Request(corev1.ResourceCPU, "2").Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "2").Obj()).
+					Priority(0).
+					PastAdmittedTime(0).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadAdmitted,
+						Status:  metav1.ConditionFalse,
+						Reason:  "NoReservation",
+						Message: "The workload has no reservation",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "The pod sets of the job were changed after the quota reservation",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadRequeued,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByPodTemplateMutation,
+						Message: "The pod sets of the job were changed after the quota reservation",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByPodTemplateMutation,
+						Message: "The pod sets of the job were changed after the quota reservation",
+					}).
+					SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{
+						Reason: kueue.WorkloadEvictedByPodTemplateMutation,
+						Count:  1,
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "wl", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "EvictedDueToPodTemplateMutated",
+					Message:   "The pod sets of the job were changed after the quota reservation",
+				},
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "UpdatedWorkload",
+					Message:   "Updated not matching Workload for suspended job: ns/wl",
+				},
+			},
+		},
+		"suspended job with partial admission and admitted workload is unsuspended": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling: false,
+
+				features.AssignQueueLabelsForPods: true,
+			},
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithManageJobsWithoutQueueName(true),
+				jobframework.WithManagedJobsNamespaceSelector(labels.Everything()),
+			},
+			job: baseJobWrapper.Clone().
+				SetAnnotation(JobMinParallelismAnnotation, "5").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(JobMinParallelismAnnotation, "5").
+				Suspend(false).
+				Parallelism(8).
+				PodLabel(constants.PodSetLabel, string(kueue.DefaultPodSetName)).
 				PodLabel(constants.LocalQueueLabel, localQueueName).
 				PodLabel(constants.ClusterQueueLabel, clusterQueueName).
 				Obj(),
@@ -3156,6 +3542,170 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"when workload is created, it has the default workload priority class": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling: false,
+
+				features.AssignQueueLabelsForPods: true,
+			},
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithDefaultWorkloadPriorityClass(baseWPCWrapper.Name),
+			},
+			job: baseJobWrapper.Clone().
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				UID("test-uid").
+				Suspend(true).
+				Obj(),
+			priorityClasses: []client.Object{
+				baseWPCWrapper.Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue(localQueueName).
+					Priority(baseWPCWrapper.Value).
+					WorkloadPriorityClassRef(baseWPCWrapper.Name).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, "test-uid"),
+				},
+			},
+		},
+		"when workload is created, the workload priority class of the job overrides the default": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling: false,
+
+				features.AssignQueueLabelsForPods: true,
+			},
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithDefaultWorkloadPriorityClass(baseWPCWrapper.Name),
+			},
+			job: baseJobWrapper.Clone().
+				WorkloadPriorityClass(highWPCWrapper.Name).
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				WorkloadPriorityClass(highWPCWrapper.Name).
+				UID("test-uid").
+				Suspend(true).
+				Obj(),
+			priorityClasses: []client.Object{
+				baseWPCWrapper.Obj(), highWPCWrapper.Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue(localQueueName).
+					Priority(highWPCWrapper.Value).
+					WorkloadPriorityClassRef(highWPCWrapper.Name).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, "test-uid"),
+				},
+			},
+		},
+		"when workload is created, the default workload priority class is skipped if it doesn't exist": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling: false,
+
+				features.AssignQueueLabelsForPods: true,
+			},
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithDefaultWorkloadPriorityClass("missing"),
+			},
+			job: baseJobWrapper.Clone().
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				UID("test-uid").
+				Suspend(true).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue(localQueueName).
+					Priority(0).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, "test-uid"),
+				},
+			},
+		},
+		"shouldn't update workload with the default workload priority class": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling: false,
+
+				features.AssignQueueLabelsForPods: true,
+			},
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithDefaultWorkloadPriorityClass(baseWPCWrapper.Name),
+			},
+			job: baseJobWrapper.
+				Clone().
+				Suspend(true).
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.
+				Clone().
+				UID("test-uid").
+				Obj(),
+			priorityClasses: []client.Object{
+				baseWPCWrapper.Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue(localQueueName).
+					Priority(baseWPCWrapper.Value).
+					WorkloadPriorityClassRef(baseWPCWrapper.Name).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue(localQueueName).
+					Priority(baseWPCWrapper.Value).
+					WorkloadPriorityClassRef(baseWPCWrapper.Name).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+		},
 		"the workload without uid label is created when job's uid is longer than 63 characters": {
 			featureGates: map[featuregate.Feature]bool{
 				features.TopologyAwareScheduling: false,
@@ -3210,6 +3760,39 @@ func TestReconciler(t *testing.T) {
 				Suspend(false).
 				Obj(),
 		},
+		"the workload is not created when the job opted out of the management by Kueue": {
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: true},
+			job: utiltestingjob.MakeJob("job", "ns").
+				Queue(localQueueName).
+				SetAnnotation(controllerconsts.ManagedAnnotation, "false").
+				Suspend(false).
+				Obj(),
+			wantJob: *utiltestingjob.MakeJob("job", "ns").
+				Queue(localQueueName).
+				SetAnnotation(controllerconsts.ManagedAnnotation, "false").
+				Suspend(false).
+				Obj(),
+		},
+		"the workload is deleted when the suspended job opted out of the management by Kueue": {
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: true},
+			job: baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.ManagedAnnotation, "false").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.ManagedAnnotation, "false").
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "DeletedWorkload",
+					Message:   "Deleted Workload of the job opted out of the management by Kueue: ns/wl",
+				},
+			},
+		},
 		"non-standalone job is suspended if its parent workload is not found": {
 			featureGates: map[featuregate.Feature]bool{
 				features.TopologyAwareScheduling: false,
@@ -3578,6 +4161,200 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when the suspended job is failing per its podFailurePolicy, the workload is marked as finished instead of resuming the job": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling:          false,
+				features.AssignQueueLabelsForPods:         false,
+				features.JobFailureTargetFinishesWorkload: true,
+			},
+			job: baseJobWrapper.Clone().
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailureTarget,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Terminating(0).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Generation(1).
+					Obj(),
+			},
+			wantJob: *baseJobWrapper.Clone().
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailureTarget,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Terminating(0).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadFinished,
+						Status:             metav1.ConditionTrue,
+						Reason:             kueue.WorkloadFinishedReasonFailed,
+						Message:            "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+						ObservedGeneration: 1,
+					}).
+					Generation(1).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "FinishedWorkload",
+					Message:   "Workload 'ns/wl' is declared finished",
+				},
+			},
+		},
+		"when the suspended job is failing per its podFailurePolicy and has terminating pods, the job is neither resumed nor finished": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling:          false,
+				features.AssignQueueLabelsForPods:         false,
+				features.JobFailureTargetFinishesWorkload: true,
+			},
+			job: baseJobWrapper.Clone().
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailureTarget,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Terminating(1).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Generation(1).
+					Obj(),
+			},
+			wantJob: *baseJobWrapper.Clone().
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailureTarget,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Terminating(1).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Generation(1).
+					Obj(),
+			},
+		},
+		"when the suspended job failed per its podFailurePolicy and the terminating pods are not tracked, the workload is marked as finished": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling:          false,
+				features.AssignQueueLabelsForPods:         false,
+				features.JobFailureTargetFinishesWorkload: true,
+			},
+			job: baseJobWrapper.Clone().
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailureTarget,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Generation(1).
+					Obj(),
+			},
+			wantJob: *baseJobWrapper.Clone().
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailureTarget,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadFinished,
+						Status:             metav1.ConditionTrue,
+						Reason:             kueue.WorkloadFinishedReasonFailed,
+						Message:            "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+						ObservedGeneration: 1,
+					}).
+					Generation(1).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "FinishedWorkload",
+					Message:   "Workload 'ns/wl' is declared finished",
+				},
+			},
+		},
+		"when the suspended job is failing per its podFailurePolicy and JobFailureTargetFinishesWorkload is disabled, the job is resumed": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling:          false,
+				features.AssignQueueLabelsForPods:         false,
+				features.JobFailureTargetFinishesWorkload: false,
+			},
+			job: baseJobWrapper.Clone().
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailureTarget,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Obj(),
+			},
+			wantJob: *baseJobWrapper.Clone().
+				Condition(batchv1.JobCondition{
+					Type:    batchv1.JobFailureTarget,
+					Status:  corev1.ConditionTrue,
+					Reason:  batchv1.JobReasonPodFailurePolicy,
+					Message: "Container main for pod ns/job-abcde failed with exit code 42 matching FailJob rule at index 0",
+				}).
+				Suspend(false).
+				PodLabel(constants.PodSetLabel, string(kueue.DefaultPodSetName)).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					AdmittedAt(true, now).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Admitted by clusterQueue cq",
+				},
+			},
+		},
 		"when the workload is finished, its finalizer is removed": {
 			featureGates: map[featuregate.Feature]bool{
 				features.TopologyAwareScheduling: false,
@@ -4453,6 +5230,9 @@ func TestReconciler(t *testing.T) {
 				if tc.job != nil {
 					objs = append(objs, tc.job)
 				}
+				for i := range tc.localQueues {
+					objs = append(objs, &tc.localQueues[i])
+				}
 
 				kClient := clientBuilder.
 					WithObjects(objs...).
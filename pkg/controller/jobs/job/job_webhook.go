@@ -83,6 +83,9 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		queues:                       options.Queues,
 		cache:                        options.Cache,
 	}
+	if err := setupCronJobWebhook(mgr, options); err != nil {
+		return err
+	}
 	obj := &batchv1.Job{}
 	if options.NoopWebhook {
 		return webhook.SetupNoopWebhook(mgr, obj)
@@ -106,6 +109,9 @@ func (w *JobWebhook) Default(ctx context.Context, obj *batchv1.Job) error {
 
 	jobframework.ApplyDefaultLocalQueue(job.Object(), w.queues.DefaultLocalQueueExist)
 	jobframework.ApplyDefaultWorkloadPriorityClass(ctx, w.client, job.Object())
+	if err := w.applyReservedWorkload(ctx, job); err != nil {
+		return err
+	}
 	if err := jobframework.ApplyDefaultForSuspend(ctx, job, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
 		return err
 	}
@@ -129,7 +135,10 @@ func (w *JobWebhook) ValidateCreate(ctx context.Context, obj *batchv1.Job) (admi
 	if err != nil {
 		return nil, err
 	}
-	return nil, validationErrs.ToAggregate()
+	if len(validationErrs) > 0 {
+		return nil, validationErrs.ToAggregate()
+	}
+	return jobframework.QuotaWarnings(ctx, job, w.client, w.queues, w.cache), nil
 }
 
 func (w *JobWebhook) validateCreate(ctx context.Context, job *Job) (field.ErrorList, error) {
@@ -262,7 +271,9 @@ func (w *JobWebhook) validateTopologyRequest(ctx context.Context, job *Job) (fie
 		return nil, nil
 	}
 
-	return jobframework.ValidateSliceSizeAnnotationUpperBound(replicaMetaPath, &job.Spec.Template.ObjectMeta, &podSets[0]), nil
+	allErrs := jobframework.ValidateSliceSizeAnnotationUpperBound(replicaMetaPath, &job.Spec.Template.ObjectMeta, &podSets[0])
+	allErrs = append(allErrs, jobframework.ValidateTASPodAntiAffinity(field.NewPath("spec", "template", "spec"), &podSets[0])...)
+	return allErrs, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
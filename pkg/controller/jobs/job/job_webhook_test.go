@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	jobsetapi "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
@@ -383,6 +384,27 @@ func TestValidateCreate(t *testing.T) {
 			},
 			featureGates: map[featuregate.Feature]bool{features.TopologyAwareScheduling: true},
 		},
+		{
+			name: "invalid topology request - hostname required with pod anti-affinity on hostname",
+			job: testingutil.MakeJob("job", "default").
+				Parallelism(2).
+				PodLabel("app", "job").
+				PodAnnotation(kueue.PodSetRequiredTopologyAnnotation, corev1.LabelHostname).
+				PodAffinity(&corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "job"}},
+							TopologyKey:   corev1.LabelHostname,
+						}},
+					},
+				}).
+				Obj(),
+			wantValidationErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "template", "spec", "affinity", "podAntiAffinity", "requiredDuringSchedulingIgnoredDuringExecution").Index(0),
+					"must not select the pods of the PodSet when 'kueue.x-k8s.io/podset-required-topology' is 'kubernetes.io/hostname', as it requires all the pods on a single node"),
+			},
+			featureGates: map[featuregate.Feature]bool{features.TopologyAwareScheduling: true},
+		},
 		{
 			name: "invalid topology request - slice size provided without slice topology",
 			job: testingutil.MakeJob("job", "default").
@@ -640,6 +662,114 @@ func TestValidateCreate(t *testing.T) {
 	}
 }
 
+func TestValidateCreateQuotaWarnings(t *testing.T) {
+	testcases := map[string]struct {
+		job           *batchv1.Job
+		clusterQueues []kueue.ClusterQueue
+		wantWarnings  admission.Warnings
+	}{
+		"requests fit in the nominal quota": {
+			job: testingutil.MakeJob("job", "default").Queue("local-queue").Request(corev1.ResourceCPU, "3").Obj(),
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("cluster-queue").
+					ResourceGroup(
+						*utiltestingapi.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "2").Obj(),
+						*utiltestingapi.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+					).
+					Obj(),
+			},
+		},
+		"requests exceed the quota of every flavor": {
+			job: testingutil.MakeJob("job", "default").Queue("local-queue").Request(corev1.ResourceCPU, "5").Obj(),
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("cluster-queue").
+					ResourceGroup(
+						*utiltestingapi.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "2").Obj(),
+						*utiltestingapi.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+					).
+					Obj(),
+			},
+			wantWarnings: admission.Warnings{
+				`the requests of a pod in podSet "main" exceed the quota of every flavor in ClusterQueue "cluster-queue" for resources [cpu]; the job can't be admitted unless the quotas are increased`,
+			},
+		},
+		"requests fit when borrowing from the cohort": {
+			job: testingutil.MakeJob("job", "default").Queue("local-queue").Request(corev1.ResourceCPU, "6").Obj(),
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("cluster-queue").
+					Cohort("cohort").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+				*utiltestingapi.MakeClusterQueue("other-cluster-queue").
+					Cohort("cohort").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+		},
+		"requests exceed the quota allowed by the borrowing limit": {
+			job: testingutil.MakeJob("job", "default").Queue("local-queue").Request(corev1.ResourceCPU, "6").Obj(),
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("cluster-queue").
+					Cohort("cohort").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4", "1").Obj()).
+					Obj(),
+				*utiltestingapi.MakeClusterQueue("other-cluster-queue").
+					Cohort("cohort").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			wantWarnings: admission.Warnings{
+				`the requests of a pod in podSet "main" exceed the quota of every flavor in ClusterQueue "cluster-queue" for resources [cpu]; the job can't be admitted unless the quotas are increased`,
+			},
+		},
+		"resources not covered by the ClusterQueue are ignored": {
+			job: testingutil.MakeJob("job", "default").Queue("local-queue").Request("example.com/gpu", "1").Obj(),
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("cluster-queue").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+		},
+		"ClusterQueue not found": {
+			job: testingutil.MakeJob("job", "default").Queue("local-queue").Request(corev1.ResourceCPU, "5").Obj(),
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(utiltesting.MakeNamespace("default")).
+				Build()
+			cqCache := schdcache.New(cl)
+			queueManager := qcache.NewManagerForUnitTests(cl, cqCache)
+			for _, cq := range tc.clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+				}
+				if err := queueManager.AddClusterQueue(ctx, &cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+				}
+			}
+			lq := utiltestingapi.MakeLocalQueue("local-queue", "default").ClusterQueue("cluster-queue").Obj()
+			if err := queueManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("Inserting queue %s/%s in manager: %v", lq.Namespace, lq.Name, err)
+			}
+			w := &JobWebhook{
+				client: cl,
+				queues: queueManager,
+				cache:  cqCache,
+			}
+			gotWarnings, gotErr := w.ValidateCreate(ctx, tc.job)
+			if gotErr != nil {
+				t.Fatalf("Unexpected error from ValidateCreate(): %v", gotErr)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, gotWarnings); diff != "" {
+				t.Errorf("ValidateCreate() warnings mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateUpdate(t *testing.T) {
 	testcases := []struct {
 		name               string
@@ -1148,6 +1278,31 @@ func TestDefault(t *testing.T) {
 				Obj(),
 			featureGates: map[featuregate.Feature]bool{features.MultiKueue: true},
 		},
+		"job opted out of the management by Kueue isn't suspended": {
+			job: testingutil.MakeJob("job", "default").
+				Queue("queue").
+				SetAnnotation(constants.ManagedAnnotation, "false").
+				Suspend(false).
+				Obj(),
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: true},
+			want: testingutil.MakeJob("job", "default").
+				Queue("queue").
+				SetAnnotation(constants.ManagedAnnotation, "false").
+				Suspend(false).
+				Obj(),
+		},
+		"job opted out of the management by Kueue is suspended when the feature is disabled": {
+			job: testingutil.MakeJob("job", "default").
+				Queue("queue").
+				SetAnnotation(constants.ManagedAnnotation, "false").
+				Suspend(false).
+				Obj(),
+			featureGates: map[featuregate.Feature]bool{features.JobManagementOptOut: false},
+			want: testingutil.MakeJob("job", "default").
+				Queue("queue").
+				SetAnnotation(constants.ManagedAnnotation, "false").
+				Obj(),
+		},
 		"default lq is created, job doesn't have queue label": {
 			defaultLqExist: true,
 			job:            testingutil.MakeJob("test-job", "default").Obj(),
@@ -1192,6 +1347,67 @@ func TestDefault(t *testing.T) {
 				Queue("default").
 				Obj(),
 		},
+		"the Workload reserved for the CronJob run is set as the prebuilt Workload": {
+			job: testingutil.MakeJob("sample-29456940", metav1.NamespaceDefault).
+				OwnerReference("sample", batchv1.SchemeGroupVersion.WithKind("CronJob")).
+				Queue("queue").
+				Obj(),
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("cronjob-sample-29456940", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("CronJob"), "sample", "sample").
+					Obj(),
+			},
+			featureGates: map[featuregate.Feature]bool{features.CronJobQuotaReservation: true},
+			want: testingutil.MakeJob("sample-29456940", metav1.NamespaceDefault).
+				OwnerReference("sample", batchv1.SchemeGroupVersion.WithKind("CronJob")).
+				Queue("queue").
+				PrebuiltWorkloadAnnotation("cronjob-sample-29456940").
+				Obj(),
+		},
+		"the Workload reserved for the CronJob run isn't set when CronJobQuotaReservation is disabled": {
+			job: testingutil.MakeJob("sample-29456940", metav1.NamespaceDefault).
+				OwnerReference("sample", batchv1.SchemeGroupVersion.WithKind("CronJob")).
+				Queue("queue").
+				Obj(),
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("cronjob-sample-29456940", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("CronJob"), "sample", "sample").
+					Obj(),
+			},
+			featureGates: map[featuregate.Feature]bool{features.CronJobQuotaReservation: false},
+			want: testingutil.MakeJob("sample-29456940", metav1.NamespaceDefault).
+				OwnerReference("sample", batchv1.SchemeGroupVersion.WithKind("CronJob")).
+				Queue("queue").
+				Obj(),
+		},
+		"the Workload reserved for the CronJob run isn't set when claimed by another Job": {
+			job: testingutil.MakeJob("sample-29456940", metav1.NamespaceDefault).
+				OwnerReference("sample", batchv1.SchemeGroupVersion.WithKind("CronJob")).
+				Queue("queue").
+				Obj(),
+			objs: []runtime.Object{
+				utiltestingapi.MakeWorkload("cronjob-sample-29456940", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("CronJob"), "sample", "sample").
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "other", "other").
+					Obj(),
+			},
+			featureGates: map[featuregate.Feature]bool{features.CronJobQuotaReservation: true},
+			want: testingutil.MakeJob("sample-29456940", metav1.NamespaceDefault).
+				OwnerReference("sample", batchv1.SchemeGroupVersion.WithKind("CronJob")).
+				Queue("queue").
+				Obj(),
+		},
+		"no reserved Workload for the CronJob run": {
+			job: testingutil.MakeJob("sample-29456940", metav1.NamespaceDefault).
+				OwnerReference("sample", batchv1.SchemeGroupVersion.WithKind("CronJob")).
+				Queue("queue").
+				Obj(),
+			featureGates: map[featuregate.Feature]bool{features.CronJobQuotaReservation: true},
+			want: testingutil.MakeJob("sample-29456940", metav1.NamespaceDefault).
+				OwnerReference("sample", batchv1.SchemeGroupVersion.WithKind("CronJob")).
+				Queue("queue").
+				Obj(),
+		},
 	}
 	for name, tc := range testcases {
 		t.Run(name, func(t *testing.T) {
@@ -97,6 +97,19 @@ func (j *JobSet) IsActive() bool {
 			return true
 		}
 	}
+	if !j.IsSuspended() {
+		return false
+	}
+	// The child Jobs of a suspended JobSet are suspended one by one by the
+	// JobSet controller. Consider the JobSet active while any of the existing
+	// child Jobs still has ready Pods, so that the quota is not released while
+	// some of them may still be running. The child Jobs which don't exist are
+	// not reported in the status and never hold the JobSet active.
+	for i := range j.Status.ReplicatedJobsStatus {
+		if j.Status.ReplicatedJobsStatus[i].Ready > 0 {
+			return true
+		}
+	}
 	return false
 }
 
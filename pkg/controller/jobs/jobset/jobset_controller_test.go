@@ -116,6 +116,87 @@ func TestPodsReady(t *testing.T) {
 	}
 }
 
+func TestIsActive(t *testing.T) {
+	baseJobSet := testingjobset.MakeJobSet("jobset", "ns").ReplicatedJobs(
+		testingjobset.ReplicatedJobRequirements{
+			Name:        "replicated-job-1",
+			Replicas:    2,
+			Parallelism: 1,
+			Completions: 1,
+		},
+		testingjobset.ReplicatedJobRequirements{
+			Name:        "replicated-job-2",
+			Replicas:    3,
+			Parallelism: 1,
+			Completions: 1,
+		},
+	)
+	testcases := map[string]struct {
+		jobSet *jobset.JobSet
+		want   bool
+	}{
+		"no status": {
+			jobSet: baseJobSet.Clone().Suspend(false).Obj(),
+			want:   false,
+		},
+		"running jobs": {
+			jobSet: baseJobSet.Clone().Suspend(false).JobsStatus(
+				jobset.ReplicatedJobStatus{Name: "replicated-job-1", Active: 2},
+				jobset.ReplicatedJobStatus{Name: "replicated-job-2", Active: 3},
+			).Obj(),
+			want: true,
+		},
+		"suspended, some jobs still active": {
+			jobSet: baseJobSet.Clone().JobsStatus(
+				jobset.ReplicatedJobStatus{Name: "replicated-job-1", Suspended: 2},
+				jobset.ReplicatedJobStatus{Name: "replicated-job-2", Active: 1, Suspended: 2},
+			).Obj(),
+			want: true,
+		},
+		"suspended, some jobs still ready": {
+			jobSet: baseJobSet.Clone().JobsStatus(
+				jobset.ReplicatedJobStatus{Name: "replicated-job-1", Suspended: 2},
+				jobset.ReplicatedJobStatus{Name: "replicated-job-2", Ready: 1, Suspended: 2},
+			).Obj(),
+			want: true,
+		},
+		"suspended, some child jobs missing": {
+			jobSet: baseJobSet.Clone().JobsStatus(
+				jobset.ReplicatedJobStatus{Name: "replicated-job-1", Suspended: 2},
+				jobset.ReplicatedJobStatus{Name: "replicated-job-2", Suspended: 1},
+			).Obj(),
+			want: false,
+		},
+		"suspended, all jobs suspended": {
+			jobSet: baseJobSet.Clone().JobsStatus(
+				jobset.ReplicatedJobStatus{Name: "replicated-job-1", Suspended: 2},
+				jobset.ReplicatedJobStatus{Name: "replicated-job-2", Suspended: 3},
+			).Obj(),
+			want: false,
+		},
+		"suspended, remaining jobs suspended": {
+			jobSet: baseJobSet.Clone().JobsStatus(
+				jobset.ReplicatedJobStatus{Name: "replicated-job-1", Succeeded: 1, Suspended: 1},
+				jobset.ReplicatedJobStatus{Name: "replicated-job-2", Failed: 1, Suspended: 2},
+			).Obj(),
+			want: false,
+		},
+		"suspended, no status": {
+			jobSet: baseJobSet.Clone().Obj(),
+			want:   false,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			got := (*JobSet)(tc.jobSet).IsActive()
+			if tc.want != got {
+				t.Errorf("Unexpected response (want: %v, got: %v)", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestReclaimablePods(t *testing.T) {
 	baseWrapper := testingjobset.MakeJobSet("jobset", "ns").ReplicatedJobs(
 		testingjobset.ReplicatedJobRequirements{
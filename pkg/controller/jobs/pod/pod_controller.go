@@ -27,7 +27,9 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -68,6 +70,10 @@ const (
 	ConditionTypeTerminationTarget = "TerminationTarget"
 )
 
+// stopReasonWorkloadPreempted is the reason passed to Stop when the workload
+// is evicted due to preemption.
+const stopReasonWorkloadPreempted = jobframework.StopReason(string(jobframework.StopReasonWorkloadEvicted) + "DueTo" + kueue.WorkloadEvictedByPreemption)
+
 // errMsgIncorrectGroupRoleCount is derived from jobframework.MaxPodSets so the
 // message stays in sync with the limit.
 var errMsgIncorrectGroupRoleCount = fmt.Sprintf("pod group can't include more than %d roles", jobframework.MaxPodSets)
@@ -87,6 +93,7 @@ var (
 	gvk                          = corev1.SchemeGroupVersion.WithKind("Pod")
 	errIncorrectReconcileRequest = errors.New("event handler error: got a single pod reconcile request for a pod group")
 	errPendingOps                = jobframework.UnretryableError("waiting to observe previous operations on pods")
+	errEvictionBlockedByPDB      = errors.New("eviction of pods blocked by PodDisruptionBudget")
 	errPodGroupLabelsMismatch    = errors.New("constructing workload: pods have different label values")
 	realClock                    = clock.RealClock{}
 )
@@ -106,6 +113,7 @@ func init() {
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=get;patch
+// +kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
 // +kubebuilder:rbac:groups="",resources=pods/finalizers,verbs=get;update
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
@@ -506,6 +514,8 @@ func (p *Pod) Stop(ctx context.Context, c client.Client, _ []podset.PodSetInfo,
 		podsInGroup = []corev1.Pod{p.pod}
 	}
 
+	evictionRequired := p.preemptionRespectsPodDisruptionBudgets(stopReason)
+	blockedByPDB := 0
 	stoppedNow := make([]client.Object, 0)
 	for i := range podsInGroup {
 		// If the workload is being deleted, delete even finished Pods.
@@ -514,6 +524,24 @@ func (p *Pod) Stop(ctx context.Context, c client.Client, _ []podset.PodSetInfo,
 		}
 		podInGroup := FromObject(&podsInGroup[i])
 
+		if evictionRequired {
+			// The eviction API refuses to evict the pod when it would violate
+			// a PodDisruptionBudget, so the pod is stopped in a later attempt.
+			err := c.SubResource("eviction").Create(ctx, podInGroup.Object(), &policyv1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      podInGroup.pod.Name,
+					Namespace: podInGroup.pod.Namespace,
+				},
+			})
+			if apierrors.IsTooManyRequests(err) {
+				blockedByPDB++
+				continue
+			}
+			if client.IgnoreNotFound(err) != nil {
+				return stoppedNow, err
+			}
+		}
+
 		// The podset info is not relevant here, since this should mark the pod's end of life
 		podApplyConfig := corev1ac.Pod(podInGroup.pod.Name, podInGroup.pod.Namespace).
 			WithUID(podInGroup.pod.UID).
@@ -532,7 +560,7 @@ func (p *Pod) Stop(ctx context.Context, c client.Client, _ []podset.PodSetInfo,
 		}
 
 		// Delete the pod if it is not already deleted.
-		if err == nil {
+		if err == nil && !evictionRequired {
 			if err := c.Delete(ctx, podInGroup.Object()); client.IgnoreNotFound(err) != nil {
 				return stoppedNow, err
 			}
@@ -551,9 +579,28 @@ func (p *Pod) Stop(ctx context.Context, c client.Client, _ []podset.PodSetInfo,
 		}
 	}
 
+	if blockedByPDB > 0 {
+		return stoppedNow, fmt.Errorf("%w: %d pod(s)", errEvictionBlockedByPDB, blockedByPDB)
+	}
 	return stoppedNow, nil
 }
 
+// preemptionRespectsPodDisruptionBudgets returns whether the pods are stopped
+// with the eviction API. This applies to the preemption of the pods of
+// service-like workloads, that is Deployments and serving pod groups, such as
+// StatefulSets.
+func (p *Pod) preemptionRespectsPodDisruptionBudgets(stopReason jobframework.StopReason) bool {
+	if !features.Enabled(features.PreemptionRespectsPodDisruptionBudgets) ||
+		stopReason != stopReasonWorkloadPreempted {
+		return false
+	}
+	if p.isServing() {
+		return true
+	}
+	owner := metav1.GetControllerOf(&p.pod)
+	return owner != nil && owner.APIVersion == appsv1.SchemeGroupVersion.String() && owner.Kind == "ReplicaSet"
+}
+
 func (p *Pod) ForEach(f func(obj runtime.Object)) {
 	if p.isGroup {
 		for _, pod := range p.list.Items {
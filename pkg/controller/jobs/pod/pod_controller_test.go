@@ -27,6 +27,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -7011,12 +7012,15 @@ func TestStop(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(now)
 
 	testCases := map[string]struct {
+		enablePDB         bool
 		pod               *corev1.Pod
 		stopReason        jobframework.StopReason
 		eventMsg          string
 		deleteBeforePatch bool
+		blockedByPDB      bool
 		wantPatched       bool
 		wantDeleted       bool
+		wantEvicted       bool
 		wantStopped       []client.Object
 		wantErr           error
 	}{
@@ -7052,12 +7056,74 @@ func TestStop(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		"the preempted pod of a Deployment is evicted": {
+			enablePDB: true,
+			pod: testingpod.MakePod("pod", metav1.NamespaceDefault).
+				ResourceVersion("1").
+				OwnerReference("rs", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+				Obj(),
+			stopReason:  stopReasonWorkloadPreempted,
+			eventMsg:    "Test message",
+			wantPatched: true,
+			wantEvicted: true,
+			wantStopped: []client.Object{
+				testingpod.MakePod("pod", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					OwnerReference("rs", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Obj(),
+			},
+		},
+		"the preempted pod of a Deployment isn't stopped when the eviction is blocked by a PodDisruptionBudget": {
+			enablePDB: true,
+			pod: testingpod.MakePod("pod", metav1.NamespaceDefault).
+				ResourceVersion("1").
+				OwnerReference("rs", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+				Obj(),
+			stopReason:   stopReasonWorkloadPreempted,
+			eventMsg:     "Test message",
+			blockedByPDB: true,
+			wantEvicted:  true,
+			wantStopped:  []client.Object{},
+			wantErr:      errEvictionBlockedByPDB,
+		},
+		"the preempted pod of a Deployment is deleted when PreemptionRespectsPodDisruptionBudgets is disabled": {
+			pod: testingpod.MakePod("pod", metav1.NamespaceDefault).
+				ResourceVersion("1").
+				OwnerReference("rs", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+				Obj(),
+			stopReason:  stopReasonWorkloadPreempted,
+			eventMsg:    "Test message",
+			wantPatched: true,
+			wantDeleted: true,
+			wantStopped: []client.Object{
+				testingpod.MakePod("pod", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					OwnerReference("rs", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+					Obj(),
+			},
+		},
+		"the preempted plain pod is deleted": {
+			enablePDB: true,
+			pod: testingpod.MakePod("pod", metav1.NamespaceDefault).
+				ResourceVersion("1").
+				Obj(),
+			stopReason:  stopReasonWorkloadPreempted,
+			eventMsg:    "Test message",
+			wantPatched: true,
+			wantDeleted: true,
+			wantStopped: []client.Object{
+				testingpod.MakePod("pod", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Obj(),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PreemptionRespectsPodDisruptionBudgets, tc.enablePDB)
 			ctx, _ := utiltesting.ContextWithLog(t)
 
-			var patched, deleted bool
+			var patched, deleted, evicted bool
 
 			kcBuilder := utiltesting.NewClientBuilder().
 				WithObjects(tc.pod).
@@ -7079,6 +7145,15 @@ func TestStop(t *testing.T) {
 						deleted = true
 						return c.Delete(ctx, obj, opts...)
 					},
+					SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+						if subResourceName == "eviction" {
+							evicted = true
+							if tc.blockedByPDB {
+								return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+							}
+						}
+						return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+					},
 				})
 			kClient := kcBuilder.Build()
 
@@ -7097,6 +7172,10 @@ func TestStop(t *testing.T) {
 				t.Errorf("deleted mismatch (-want +got):\n%s", diff)
 			}
 
+			if diff := cmp.Diff(tc.wantEvicted, evicted); diff != "" {
+				t.Errorf("evicted mismatch (-want +got):\n%s", diff)
+			}
+
 			if diff := cmp.Diff(tc.wantStopped, stopped); diff != "" {
 				t.Errorf("stopped mismatch (-want +got):\n%s", diff)
 			}
@@ -38,7 +38,9 @@ import (
 	podconstants "sigs.k8s.io/kueue/pkg/controller/jobs/pod/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+	"sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/util/webhook"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 var (
@@ -144,9 +146,13 @@ func (w *PodWebhook) Default(ctx context.Context, obj *corev1.Pod) error {
 
 		// Do not suspend a Pod whose owner is already managed by Kueue
 		ancestorJob, err := jobframework.FindAncestorJobManagedByKueue(ctx, w.client, pod.Object(), w.manageJobsWithoutQueueName)
-		if err != nil || ancestorJob != nil {
+		if err != nil {
 			return err
 		}
+		if ancestorJob != nil {
+			w.applyDefaultContainerRequests(obj, jobframework.QueueNameForObject(ancestorJob))
+			return nil
+		}
 
 		// Local queue defaulting
 		if jobframework.QueueNameForObject(pod.Object()) == "" &&
@@ -159,7 +165,8 @@ func (w *PodWebhook) Default(ctx context.Context, obj *corev1.Pod) error {
 
 		jobframework.ApplyDefaultWorkloadPriorityClass(ctx, w.client, pod.Object())
 
-		suspend = jobframework.QueueNameForObject(pod.Object()) != "" || w.manageJobsWithoutQueueName
+		suspend = !jobframework.IsOptedOutForObject(pod.Object()) &&
+			(jobframework.QueueNameForObject(pod.Object()) != "" || w.manageJobsWithoutQueueName)
 		if suspend {
 			if pod.pod.Labels == nil {
 				pod.pod.Labels = make(map[string]string)
@@ -184,6 +191,7 @@ func (w *PodWebhook) Default(ctx context.Context, obj *corev1.Pod) error {
 			}
 			utilpod.Gate(&pod.pod, kueue.TopologySchedulingGate)
 		}
+		w.applyDefaultContainerRequests(&pod.pod, jobframework.QueueNameForObject(pod.Object()))
 		if err := pod.addRoleHash(); err != nil {
 			return err
 		}
@@ -194,6 +202,19 @@ func (w *PodWebhook) Default(ctx context.Context, obj *corev1.Pod) error {
 	return nil
 }
 
+// applyDefaultContainerRequests sets the default container requests of the
+// ClusterQueue for the containers which don't request the resources, so that
+// the Pod requests on the nodes the resources accounted for its Workload.
+func (w *PodWebhook) applyDefaultContainerRequests(pod *corev1.Pod, queueName kueue.LocalQueueName) {
+	if !features.Enabled(features.ClusterQueueDefaultRequests) || queueName == "" || w.queues == nil {
+		return
+	}
+	defaults := w.queues.DefaultContainerRequests(queue.NewLocalQueueReference(pod.Namespace, queueName))
+	if len(defaults) > 0 {
+		workload.ApplyDefaultContainerRequests(&pod.Spec, defaults)
+	}
+}
+
 // +kubebuilder:webhook:path=/validate--v1-pod,mutating=false,failurePolicy=fail,sideEffects=None,groups="",resources=pods,verbs=create;update,versions=v1,name=vpod.kb.io,admissionReviewVersions=v1
 
 var _ admission.Validator[*corev1.Pod] = &PodWebhook{}
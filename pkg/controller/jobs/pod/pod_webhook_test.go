@@ -27,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -88,6 +89,7 @@ func TestDefault(t *testing.T) {
 		initObjects                  []client.Object
 		pod                          *corev1.Pod
 		defaultLqExist               bool
+		clusterQueue                 *kueue.ClusterQueue
 		manageJobsWithoutQueueName   bool
 		managedJobsNamespaceSelector labels.Selector
 		namespaceSelector            *metav1.LabelSelector
@@ -196,6 +198,75 @@ func TestDefault(t *testing.T) {
 				OwnerReference("parent-job", batchv1.SchemeGroupVersion.WithKind("Job")).
 				Obj(),
 		},
+		"pod gets the default container requests of the ClusterQueue": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling:     false,
+				features.ClusterQueueDefaultRequests: true,
+			},
+			initObjects: []client.Object{defaultNamespace},
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				DefaultContainerRequests(corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				}).
+				Obj(),
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Request(corev1.ResourceMemory, "2Gi").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Request(corev1.ResourceMemory, "2Gi").
+				Request(corev1.ResourceCPU, "500m").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				RoleHash("2ba3e65a").
+				KueueFinalizer().
+				Obj(),
+		},
+		"pod with owner managed by kueue (Job) gets the default container requests of the ClusterQueue": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling:     false,
+				features.ClusterQueueDefaultRequests: true,
+			},
+			initObjects: []client.Object{
+				defaultNamespace,
+				testingjob.MakeJob("parent-job", defaultNamespace.Name).UID("parent-job").Queue("test-queue").Obj(),
+			},
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				DefaultContainerRequests(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}).
+				Obj(),
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				OwnerReference("parent-job", batchv1.SchemeGroupVersion.WithKind("Job")).
+				Obj(),
+			enableIntegrations: []string{"batch/job"},
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				OwnerReference("parent-job", batchv1.SchemeGroupVersion.WithKind("Job")).
+				Request(corev1.ResourceCPU, "500m").
+				Obj(),
+		},
+		"pod gets no default container requests when ClusterQueueDefaultRequests is disabled": {
+			featureGates: map[featuregate.Feature]bool{
+				features.TopologyAwareScheduling:     false,
+				features.ClusterQueueDefaultRequests: false,
+			},
+			initObjects: []client.Object{defaultNamespace},
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				DefaultContainerRequests(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}).
+				Obj(),
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				ManagedByKueueLabel().
+				KueueSchedulingGate().
+				RoleHash("a9f06f3a").
+				KueueFinalizer().
+				Obj(),
+		},
 		"pod with owner managed by kueue (RayCluster)": {
 			featureGates: map[featuregate.Feature]bool{features.TopologyAwareScheduling: false},
 			initObjects: []client.Object{
@@ -655,6 +726,16 @@ func TestDefault(t *testing.T) {
 
 			ctx, _ := utiltesting.ContextWithLog(t)
 
+			if tc.clusterQueue != nil {
+				if err := queueManager.AddClusterQueue(ctx, tc.clusterQueue); err != nil {
+					t.Fatalf("failed to create cluster queue: %s", err)
+				}
+				if err := queueManager.AddLocalQueue(ctx, utiltestingapi.MakeLocalQueue("test-queue", defaultNamespace.Name).
+					ClusterQueue(tc.clusterQueue.Name).Obj()); err != nil {
+					t.Fatalf("failed to create local queue: %s", err)
+				}
+			}
+
 			if tc.defaultLqExist {
 				if err := queueManager.AddLocalQueue(ctx, utiltestingapi.MakeLocalQueue("default", defaultNamespace.Name).
 					ClusterQueue("cluster-queue").Obj()); err != nil {
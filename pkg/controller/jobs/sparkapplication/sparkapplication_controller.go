@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strconv"
 
 	sparkv1beta2 "github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	sparkcommon "github.com/kubeflow/spark-operator/v2/pkg/common"
@@ -47,6 +48,11 @@ const (
 	FrameworkName      = "sparkoperator.k8s.io/sparkapplication"
 	driverPodSetName   = "driver"
 	executorPodSetName = "executor"
+
+	// MinExecutorsAnnotation is the minimum number of executors the
+	// SparkApplication accepts to run with when it is partially admitted.
+	// The driver is never reduced.
+	MinExecutorsAnnotation = "kueue.x-k8s.io/spark-min-executors"
 )
 
 func init() {
@@ -139,6 +145,7 @@ func (j *SparkApplication) PodSets(ctx context.Context, _ client.Client) ([]kueu
 		Name:     executorPodSetName,
 		Template: *executorPodTemplateSpec,
 		Count:    j.numInitialExecutors(),
+		MinCount: j.minExecutors(),
 	}
 
 	if err := setTopologyRequestToPodSetIfEnabled(
@@ -158,6 +165,10 @@ func (j *SparkApplication) RunWithPodSetsInfo(ctx context.Context, _ client.Clie
 
 	j.Spec.Suspend = new(false)
 
+	if j.minExecutors() != nil {
+		j.Spec.Executor.Instances = new(podSetsInfo[1].Count)
+	}
+
 	origGlobalNodeSelector := maps.Clone(j.Spec.NodeSelector)
 
 	mutatePodSetInfoFor := func(role string) error {
@@ -300,6 +311,15 @@ func (j *SparkApplication) RestorePodSetsInfo(ctx context.Context, podSetsInfo [
 	return driverChanged || executorChanged
 }
 
+func (j *SparkApplication) minExecutors() *int32 {
+	if strVal, found := j.GetAnnotations()[MinExecutorsAnnotation]; found {
+		if iVal, err := strconv.Atoi(strVal); err == nil {
+			return new(int32(iVal))
+		}
+	}
+	return nil
+}
+
 func (j *SparkApplication) Finished(ctx context.Context) (message string, success, finished bool) {
 	return j.Status.AppState.ErrorMessage,
 		j.Status.AppState.State == sparkv1beta2.ApplicationStateCompleted,
@@ -121,6 +121,47 @@ func TestPodSets(t *testing.T) {
 				}).Obj(),
 			},
 		},
+		"with min executors": {
+			sparkApp: testSparkApp.Clone().
+				Annotation(MinExecutorsAnnotation, "2").
+				ExecutorInstances(5).Obj(),
+			want: []kueue.PodSet{
+				*utiltestingapi.MakePodSet("driver", 1).PodSpec(corev1.PodSpec{
+					NodeSelector:   map[string]string{},
+					Tolerations:    []corev1.Toleration{},
+					InitContainers: []corev1.Container{},
+					Containers: []corev1.Container{
+						{
+							Name: sparkcommon.SparkDriverContainerName,
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("100m"),
+									corev1.ResourceMemory: resource.MustParse("512Mi"),
+								},
+							},
+						},
+					},
+				}).Obj(),
+				*utiltestingapi.MakePodSet("executor", 5).
+					SetMinimumCount(2).
+					PodSpec(corev1.PodSpec{
+						NodeSelector:   map[string]string{},
+						Tolerations:    []corev1.Toleration{},
+						InitContainers: []corev1.Container{},
+						Containers: []corev1.Container{
+							{
+								Name: sparkcommon.Spark3DefaultExecutorContainerName,
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("100m"),
+										corev1.ResourceMemory: resource.MustParse("512Mi"),
+									},
+								},
+							},
+						},
+					}).Obj(),
+			},
+		},
 		"with TopologyAwareScheduling": {
 			featureGates: map[featuregate.Feature]bool{features.TopologyAwareScheduling: true},
 			sparkApp: testSparkApp.Clone().Queue("local-queue").
@@ -317,6 +358,35 @@ func TestRunWithPodsetsInfo(t *testing.T) {
 				Obj(),
 			wantErr: false,
 		},
+		"should set the admitted number of executors when partial admission is enabled": {
+			sparkApp: testSparkApp.Clone().
+				Annotation(MinExecutorsAnnotation, "2").
+				ExecutorInstances(5).
+				Obj(),
+			podsetsInfo: []podset.PodSetInfo{
+				{Name: "driver", Count: 1},
+				{Name: "executor", Count: 3},
+			},
+			wantSparkApp: testSparkApp.Clone().
+				Annotation(MinExecutorsAnnotation, "2").
+				Suspend(false).
+				DriverTemplate(&corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: sparkcommon.SparkDriverContainerName},
+						},
+					},
+				}).
+				ExecutorInstances(3).
+				ExecutorTemplate(&corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: sparkcommon.Spark3DefaultExecutorContainerName},
+						},
+					},
+				}).
+				Obj(),
+		},
 		"should raise error when PodSet info config conflicts to the SparkApplication": {
 			sparkApp: testSparkApp.Clone().
 				DriverNodeSelector(maps.Clone(nodeSelector2)).
@@ -18,6 +18,8 @@ package sparkapplication
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	sparkv1beta2 "github.com/kubeflow/spark-operator/v2/api/v1beta2"
 	"k8s.io/apimachinery/pkg/labels"
@@ -41,6 +43,7 @@ var (
 	dynamicAllocationEnabledPath = specPath.Child("dynamicAllocation").Child("enabled")
 	driverSpecPath               = specPath.Child("driver")
 	executorSpecPath             = specPath.Child("executor")
+	minExecutorsAnnotationPath   = field.NewPath("metadata", "annotations").Key(MinExecutorsAnnotation)
 )
 
 type SparkApplicationWebhook struct {
@@ -133,6 +136,7 @@ func (w *SparkApplicationWebhook) validateCreate(ctx context.Context, job *spark
 	}
 
 	allErrors = append(allErrors, jobframework.ValidateJobOnCreate(kueueJob)...)
+	allErrors = append(allErrors, validatePartialAdmissionCreate(kueueJob)...)
 	if features.Enabled(features.TopologyAwareScheduling) {
 		validationErrs, err := w.validateTopologyRequest(ctx, kueueJob)
 		if err != nil {
@@ -144,6 +148,32 @@ func (w *SparkApplicationWebhook) validateCreate(ctx context.Context, job *spark
 	return allErrors, nil
 }
 
+func validatePartialAdmissionCreate(sparkApp *SparkApplication) field.ErrorList {
+	var allErrs field.ErrorList
+	if strVal, found := sparkApp.Annotations[MinExecutorsAnnotation]; found {
+		v, err := strconv.Atoi(strVal)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(minExecutorsAnnotationPath, strVal, err.Error()))
+		} else if int32(v) >= sparkApp.numInitialExecutors() || v <= 0 {
+			allErrs = append(allErrs, field.Invalid(minExecutorsAnnotationPath, v, fmt.Sprintf("should be between 0 and %d", sparkApp.numInitialExecutors()-1)))
+		}
+		if isAnElasticJob((*sparkv1beta2.SparkApplication)(sparkApp)) {
+			allErrs = append(allErrs, field.Invalid(minExecutorsAnnotationPath, strVal, "partial admission and elastic job cannot be used together"))
+		}
+	}
+	return allErrs
+}
+
+func validatePartialAdmissionUpdate(oldSparkApp, newSparkApp *SparkApplication) field.ErrorList {
+	var allErrs field.ErrorList
+	if _, found := oldSparkApp.Annotations[MinExecutorsAnnotation]; found {
+		if !oldSparkApp.IsSuspended() && oldSparkApp.numInitialExecutors() != newSparkApp.numInitialExecutors() {
+			allErrs = append(allErrs, field.Forbidden(executorSpecPath.Child("instances"), "cannot change when partial admission is enabled and the job is not suspended"))
+		}
+	}
+	return allErrs
+}
+
 func (w *SparkApplicationWebhook) validateTopologyRequest(ctx context.Context, sparkApp *SparkApplication) (field.ErrorList, error) {
 	var allErrs field.ErrorList
 
@@ -172,6 +202,7 @@ func (w *SparkApplicationWebhook) ValidateUpdate(ctx context.Context, oldSparkAp
 	if w.manageJobsWithoutQueueName || jobframework.QueueName(fromObject(newSparkApp)) != "" {
 		log.Info("Validating update")
 		allErrors := jobframework.ValidateJobOnUpdate(fromObject(oldSparkApp), fromObject(newSparkApp), w.queues.DefaultLocalQueueExist)
+		allErrors = append(allErrors, validatePartialAdmissionUpdate(fromObject(oldSparkApp), fromObject(newSparkApp))...)
 		validationErrs, err := w.validateCreate(ctx, newSparkApp)
 		if err != nil {
 			return nil, err
@@ -76,6 +76,27 @@ func TestValidateCreate(t *testing.T) {
 				`elastic job is not supported for "sparkoperator.k8s.io/v1beta2, Kind=SparkApplication"`,
 			)}.ToAggregate(),
 		},
+		"valid min executors": {
+			sparkApp: testSparkApp.Clone().Queue("local-queue").
+				Annotation(MinExecutorsAnnotation, "2").
+				ExecutorInstances(5).
+				Obj(),
+			wantErr: nil,
+		},
+		"min executors not lower than the executor instances": {
+			sparkApp: testSparkApp.Clone().Queue("local-queue").
+				Annotation(MinExecutorsAnnotation, "5").
+				ExecutorInstances(5).
+				Obj(),
+			wantErr: field.ErrorList{field.Invalid(minExecutorsAnnotationPath, 5, "should be between 0 and 4")}.ToAggregate(),
+		},
+		"invalid min executors": {
+			sparkApp: testSparkApp.Clone().Queue("local-queue").
+				Annotation(MinExecutorsAnnotation, "two").
+				ExecutorInstances(5).
+				Obj(),
+			wantErr: field.ErrorList{field.Invalid(minExecutorsAnnotationPath, "two", `strconv.Atoi: parsing "two": invalid syntax`)}.ToAggregate(),
+		},
 		"base with TAS": {
 			featureGates: map[featuregate.Feature]bool{features.TopologyAwareScheduling: true},
 			sparkApp: testSparkApp.Clone().Queue("local-queue").ExecutorAnnotation(
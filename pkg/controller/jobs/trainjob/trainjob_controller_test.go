@@ -18,6 +18,7 @@ package trainjob
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -395,6 +396,7 @@ func TestReconciler(t *testing.T) {
 	cases := map[string]struct {
 		reconcilerOptions []jobframework.Option
 		trainJob          *kftrainerapi.TrainJob
+		runtime           *kftrainerapi.ClusterTrainingRuntime
 		childJobSet       *jobsetapi.JobSet
 		wantTrainJob      *kftrainerapi.TrainJob
 		wantWorkloads     []kueue.Workload
@@ -447,13 +449,50 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"podset topology request is set from the TAS annotations of the runtime": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithManageJobsWithoutQueueName(true),
+				jobframework.WithManagedJobsNamespaceSelector(labels.Everything()),
+			},
+			trainJob: testTrainJob.DeepCopy(),
+			runtime: testingtrainjob.MakeClusterTrainingRuntime("test", testingjobset.MakeJobSet("", "").ReplicatedJobs(
+				testingjobset.ReplicatedJobRequirements{
+					Name:        "node",
+					Replicas:    1,
+					Parallelism: 1,
+					Completions: 1,
+					Labels: map[string]string{
+						"trainer.kubeflow.org/trainjob-ancestor-step": "trainer",
+					},
+					PodAnnotations: map[string]string{
+						kueue.PodSetRequiredTopologyAnnotation: "cloud.com/block",
+					},
+				}).Obj().Spec),
+			wantTrainJob: testTrainJob.DeepCopy(),
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload(testTrainJob.Name, testTrainJob.Namespace).
+					PodSets(
+						*utiltestingapi.MakePodSet("node", 1).
+							RequiredTopologyRequest("cloud.com/block").
+							PodIndexLabel(new("batch.kubernetes.io/job-completion-index")).
+							SubGroupIndexLabel(ptr.To(jobsetapi.JobIndexKey)).
+							SubGroupCount(ptr.To[int32](1)).
+							Obj(),
+					).
+					Obj(),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			ctx, _ := utiltesting.ContextWithLog(t)
+			runtime := testCtr
+			if tc.runtime != nil {
+				runtime = tc.runtime
+			}
 			clientBuilder := utiltesting.NewClientBuilder(kftrainerapi.AddToScheme, jobsetapi.AddToScheme)
-			kClient := clientBuilder.WithObjects(tc.trainJob, testCtr, testNamespace).Build()
+			kClient := clientBuilder.WithObjects(tc.trainJob, runtime, testNamespace).Build()
 			indexer := utiltesting.AsIndexer(clientBuilder)
 			if err := SetupIndexes(ctx, indexer); err != nil {
 				t.Fatalf("Could not setup indexes: %v", err)
@@ -476,6 +515,9 @@ func TestReconciler(t *testing.T) {
 			if err := kClient.Get(ctx, tJobKey, &gotTrainJob); err != nil {
 				t.Fatalf("Could not get Job after reconcile: %v", err)
 			}
+			if diff := cmp.Diff(tc.wantTrainJob, &gotTrainJob, tjobCmpOpts...); diff != "" {
+				t.Errorf("TrainJob after reconcile (-want,+got):\n%s", diff)
+			}
 			var gotWorkloads kueue.WorkloadList
 			if err := kClient.List(ctx, &gotWorkloads); err != nil {
 				t.Fatalf("Could not get Workloads after reconcile: %v", err)
@@ -486,3 +528,105 @@ func TestReconciler(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilerResumesTrainJobOnAdmission(t *testing.T) {
+	testNamespace := utiltesting.MakeNamespaceWrapper("ns").Label(corev1.LabelMetadataName, "ns").Obj()
+	testJobset := testingjobset.MakeJobSet("", "").ReplicatedJobs(
+		testingjobset.ReplicatedJobRequirements{
+			Name:        "node",
+			Replicas:    1,
+			Parallelism: 1,
+			Completions: 1,
+			Labels: map[string]string{
+				"trainer.kubeflow.org/trainjob-ancestor-step": "trainer",
+			},
+		}).Obj()
+	testCtr := testingtrainjob.MakeClusterTrainingRuntime("test", testJobset.Spec)
+	trainJob := testingtrainjob.MakeTrainJob("trainjob", "ns").
+		RuntimeRef(kftrainerapi.RuntimeRef{
+			APIGroup: new(kftrainerapi.GroupVersion.Group),
+			Name:     "test",
+			Kind:     ptr.To(kftrainerapi.ClusterTrainingRuntimeKind),
+		}).
+		RuntimePatches([]kftrainerapi.RuntimePatch{
+			testingtrainjob.MakeRuntimePatch(runtimePatchManagerName).EmptyMetadata().Obj(),
+		}).
+		Queue("lq").
+		Obj()
+	flavor := utiltestingapi.MakeResourceFlavor("on-demand").NodeLabel("provisioning", "on-demand").Obj()
+
+	ctx, _ := utiltesting.ContextWithLog(t)
+	clientBuilder := utiltesting.NewClientBuilder(kftrainerapi.AddToScheme, jobsetapi.AddToScheme)
+	kClient := clientBuilder.WithObjects(trainJob, testCtr, testNamespace, flavor).
+		WithStatusSubresource(&kueue.Workload{}).
+		Build()
+	indexer := utiltesting.AsIndexer(clientBuilder)
+	if err := SetupIndexes(ctx, indexer); err != nil {
+		t.Fatalf("Could not setup indexes: %v", err)
+	}
+	reconciler, err := NewReconciler(ctx, kClient, indexer, &utiltesting.EventRecorder{})
+	if err != nil {
+		t.Fatalf("Error creating the reconciler: %v", err)
+	}
+	tJobKey := client.ObjectKeyFromObject(trainJob)
+
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: tJobKey}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	var gotTrainJob kftrainerapi.TrainJob
+	if err := kClient.Get(ctx, tJobKey, &gotTrainJob); err != nil {
+		t.Fatalf("Could not get TrainJob after reconcile: %v", err)
+	}
+	if diff := cmp.Diff(trainJob, &gotTrainJob, tjobCmpOpts...); diff != "" {
+		t.Errorf("TrainJob before admission (-want,+got):\n%s", diff)
+	}
+
+	var workloads kueue.WorkloadList
+	if err := kClient.List(ctx, &workloads); err != nil {
+		t.Fatalf("Could not list Workloads: %v", err)
+	}
+	if len(workloads.Items) != 1 {
+		t.Fatalf("Unexpected number of Workloads: got %d, want 1", len(workloads.Items))
+	}
+	now := time.Now()
+	wl := (&utiltestingapi.WorkloadWrapper{Workload: workloads.Items[0]}).
+		ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").
+			PodSets(utiltestingapi.MakePodSetAssignment("node").
+				Assignment(corev1.ResourceCPU, "on-demand", "1").
+				Obj()).
+			Obj(), now).
+		AdmittedAt(true, now).
+		Obj()
+	if err := kClient.Status().Update(ctx, wl); err != nil {
+		t.Fatalf("Could not admit the Workload: %v", err)
+	}
+
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: tJobKey}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if err := kClient.Get(ctx, tJobKey, &gotTrainJob); err != nil {
+		t.Fatalf("Could not get TrainJob after reconcile: %v", err)
+	}
+	wantTrainJob := testingtrainjob.MakeTrainJob("trainjob", "ns").
+		RuntimeRef(trainJob.Spec.RuntimeRef).
+		RuntimePatches([]kftrainerapi.RuntimePatch{
+			testingtrainjob.MakeRuntimePatch(runtimePatchManagerName).
+				EmptyMetadata().
+				ReplicatedJobs(
+					testingtrainjob.MakeReplicatedJobPatch("node").
+						PodAnnotation(kueue.WorkloadAnnotation, wl.Name).
+						PodLabel(constants.PodSetLabel, "node").
+						PodLabel(constants.ClusterQueueLabel, "cq").
+						PodLabel(constants.LocalQueueLabel, "lq").
+						NodeSelector("provisioning", "on-demand").
+						Obj(),
+				).
+				Obj(),
+		}).
+		Queue("lq").
+		Suspend(false).
+		Obj()
+	if diff := cmp.Diff(wantTrainJob, &gotTrainJob, tjobCmpOpts...); diff != "" {
+		t.Errorf("TrainJob after admission (-want,+got):\n%s", diff)
+	}
+}
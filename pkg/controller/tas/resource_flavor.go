@@ -18,13 +18,20 @@ package tas
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -42,10 +49,19 @@ import (
 	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/util/api"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 )
 
+const (
+	nodesMissingTopologyLabelsEventReason = "NodesMissingTopologyLabels"
+	// maxReportedNodes is the maximum number of nodes listed in an event
+	// about the nodes missing topology labels.
+	maxReportedNodes = 10
+)
+
 type rfReconciler struct {
 	logName      string
 	queues       *qcache.Manager
@@ -54,6 +70,13 @@ type rfReconciler struct {
 	recorder     events.EventRecorder
 	roleTracker  *roletracker.RoleTracker
 	nodeUpdateCh chan event.GenericEvent
+	clock        clock.Clock
+
+	// reportedLock protects reported.
+	reportedLock sync.Mutex
+	// reported holds the last reported nodes missing topology labels for
+	// each flavor, so that an event is only emitted when they change.
+	reported map[kueue.ResourceFlavorReference]string
 }
 
 var _ reconcile.Reconciler = (*rfReconciler)(nil)
@@ -72,6 +95,8 @@ func newRfReconciler(c client.Client, queues *qcache.Manager, cache *schdcache.C
 		recorder:     recorder,
 		roleTracker:  roleTracker,
 		nodeUpdateCh: make(chan event.GenericEvent, updateChBuffer),
+		clock:        clock.RealClock{},
+		reported:     make(map[kueue.ResourceFlavorReference]string),
 	}
 }
 
@@ -135,7 +160,12 @@ func (h *nodeHandler) Generic(_ context.Context, e event.GenericEvent, q workque
 	}
 	// trigger reconcile for TAS flavors affected by the node being created or updated
 	for name, cache := range h.cache.CloneTASCache() {
-		if utiltas.NodeMatchesFlavor(node.Labels, cache.NodeLabels(), cache.TopologyLevels()) {
+		levels := cache.TopologyLevels()
+		if features.Enabled(features.TASReportMissingTopologyLabels) {
+			// the nodes missing topology labels are reported by the flavor.
+			levels = nil
+		}
+		if utiltas.NodeMatchesFlavor(utiltas.NodeLevelLabels(node.Labels, cache.DerivedLevels()), cache.NodeLabels(), levels) {
 			q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
 				Name: string(name),
 			}}, constants.UpdatesBatchPeriod)
@@ -153,17 +183,80 @@ func (r *rfReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 			return reconcile.Result{}, err
 		}
 	}
+	if features.Enabled(features.TASReportMissingTopologyLabels) {
+		r.reportNodesMissingTopologyLabels(log, flv, req.Name)
+	}
 	if flv.Spec.TopologyName != nil {
+		// the quotas derived from the capacity of the nodes of the flavor
+		// need to follow the set of nodes.
+		if cqNames := r.cache.UpdateNodeCapacityQuotas(log, kueue.ResourceFlavorReference(flv.Name)); len(cqNames) > 0 {
+			log.V(3).Info("Updated the ClusterQueue quotas derived from the node capacity", "clusterQueues", sets.List(cqNames))
+		}
 		// requeue inadmissible workloads as a change to the resource flavor
 		// or the set of nodes can allow admitting a workload which was
 		// previously inadmissible.
 		if cqNames := r.cache.ActiveClusterQueues(); len(cqNames) > 0 {
 			qcache.NotifyRetryInadmissible(r.queues, cqNames)
 		}
+		// reconcile again once the next node contributes capacity to the flavor,
+		// after being Ready for minNodeReadySeconds.
+		if requeueAfter := r.cache.TASCache().NextNodeReadyAfter(kueue.ResourceFlavorReference(flv.Name), r.clock.Now()); requeueAfter > 0 {
+			log.V(3).Info("Waiting for nodes to be ready for minNodeReadySeconds", "requeueAfter", requeueAfter)
+			return reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
 	}
 	return reconcile.Result{}, nil
 }
 
+// reportNodesMissingTopologyLabels emits a warning event for the flavor when
+// the nodes matching its node labels, but missing some of the topology level
+// labels, change. Such nodes are not used by TAS.
+func (r *rfReconciler) reportNodesMissingTopologyLabels(log logr.Logger, flv *kueue.ResourceFlavor, name string) {
+	flavorName := kueue.ResourceFlavorReference(name)
+	var missing map[string][]string
+	if flv.Spec.TopologyName != nil {
+		missing = r.cache.TASCache().NodesMissingLevels(flavorName)
+	}
+	message := nodesMissingTopologyLabelsMessage(missing)
+
+	r.reportedLock.Lock()
+	defer r.reportedLock.Unlock()
+	if r.reported[flavorName] == message {
+		return
+	}
+	if message == "" {
+		delete(r.reported, flavorName)
+		return
+	}
+	r.reported[flavorName] = message
+	log.V(2).Info("Nodes missing topology labels", "count", len(missing))
+	r.recorder.Eventf(flv, nil, corev1.EventTypeWarning, nodesMissingTopologyLabelsEventReason, "Validate", api.TruncateEventMessage(message))
+}
+
+// forgetReportedNodes drops the nodes missing topology labels reported for
+// the flavor, when it is deleted or no longer uses TAS.
+func (r *rfReconciler) forgetReportedNodes(name kueue.ResourceFlavorReference) {
+	r.reportedLock.Lock()
+	defer r.reportedLock.Unlock()
+	delete(r.reported, name)
+}
+
+func nodesMissingTopologyLabelsMessage(missing map[string][]string) string {
+	if len(missing) == 0 {
+		return ""
+	}
+	nodeNames := slices.Sorted(maps.Keys(missing))
+	nodes := make([]string, 0, min(len(nodeNames), maxReportedNodes))
+	for _, nodeName := range nodeNames[:min(len(nodeNames), maxReportedNodes)] {
+		nodes = append(nodes, fmt.Sprintf("%s (%s)", nodeName, strings.Join(missing[nodeName], ", ")))
+	}
+	message := fmt.Sprintf("%d node(s) matching the node labels of the flavor are missing topology labels and are not used by TAS: %s", len(nodeNames), strings.Join(nodes, "; "))
+	if len(nodeNames) > maxReportedNodes {
+		message += fmt.Sprintf("; and %d more", len(nodeNames)-maxReportedNodes)
+	}
+	return message
+}
+
 func (r *rfReconciler) Create(event event.TypedCreateEvent[*kueue.ResourceFlavor]) bool {
 	if event.Object.Spec.TopologyName != nil {
 		log := r.logger().WithValues("flavor", event.Object.Name)
@@ -176,6 +269,7 @@ func (r *rfReconciler) Create(event event.TypedCreateEvent[*kueue.ResourceFlavor
 }
 
 func (r *rfReconciler) Delete(event event.TypedDeleteEvent[*kueue.ResourceFlavor]) bool {
+	r.forgetReportedNodes(kueue.ResourceFlavorReference(event.Object.Name))
 	return event.Object.Spec.TopologyName != nil
 }
 
@@ -187,7 +281,11 @@ func (r *rfReconciler) Update(event event.TypedUpdateEvent[*kueue.ResourceFlavor
 		return true
 	default:
 		// topologyName was set so is changed or removed
-		return event.ObjectNew.Spec.TopologyName != nil
+		if event.ObjectNew.Spec.TopologyName == nil {
+			r.forgetReportedNodes(kueue.ResourceFlavorReference(event.ObjectNew.Name))
+			return false
+		}
+		return true
 	}
 }
 
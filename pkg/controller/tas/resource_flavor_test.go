@@ -20,13 +20,191 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-base/featuregate"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	qcache "sigs.k8s.io/kueue/pkg/cache/queue"
+	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
 	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
 )
 
+func TestReconcileReportsNodesMissingTopologyLabels(t *testing.T) {
+	const (
+		blockLabel = "cloud.com/block"
+		rackLabel  = "cloud.com/rack"
+	)
+	topology := utiltestingapi.MakeTopology("default").Levels(blockLabel, rackLabel, corev1.LabelHostname).Obj()
+	flavor := utiltestingapi.MakeResourceFlavor("tas").NodeLabel("pool", "tas").TopologyName("default").Obj()
+	labeledNode := testingnode.MakeNode("labeled").
+		Label("pool", "tas").
+		Label(blockLabel, "b1").
+		Label(rackLabel, "r1").
+		Label(corev1.LabelHostname, "labeled").
+		Ready()
+	missingRackNode := testingnode.MakeNode("missing-rack").
+		Label("pool", "tas").
+		Label(blockLabel, "b1").
+		Label(corev1.LabelHostname, "missing-rack").
+		Ready()
+	missingBlockAndRackNode := testingnode.MakeNode("missing-block-and-rack").
+		Label("pool", "tas").
+		Label(corev1.LabelHostname, "missing-block-and-rack").
+		Ready()
+	otherPoolNode := testingnode.MakeNode("other-pool").
+		Label("pool", "other").
+		Label(corev1.LabelHostname, "other-pool").
+		Ready()
+	flavorKey := types.NamespacedName{Name: "tas"}
+
+	testCases := map[string]struct {
+		featureGates map[featuregate.Feature]bool
+		nodes        []*corev1.Node
+		// reconciles is the number of reconciles of the flavor.
+		reconciles int
+		wantEvents []utiltesting.EventRecord
+	}{
+		"nodes missing topology labels are reported once": {
+			featureGates: map[featuregate.Feature]bool{features.TASReportMissingTopologyLabels: true},
+			nodes:        []*corev1.Node{labeledNode.Obj(), missingRackNode.Obj(), missingBlockAndRackNode.Obj(), otherPoolNode.Obj()},
+			reconciles:   2,
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       flavorKey,
+				EventType: corev1.EventTypeWarning,
+				Reason:    nodesMissingTopologyLabelsEventReason,
+				Message:   "2 node(s) matching the node labels of the flavor are missing topology labels and are not used by TAS: missing-block-and-rack (cloud.com/block, cloud.com/rack); missing-rack (cloud.com/rack)",
+			}},
+		},
+		"unschedulable and not Ready nodes missing topology labels are not reported": {
+			featureGates: map[featuregate.Feature]bool{features.TASReportMissingTopologyLabels: true},
+			nodes: []*corev1.Node{
+				labeledNode.Obj(),
+				missingRackNode.Clone().Unschedulable().Obj(),
+				testingnode.MakeNode("not-ready").
+					Label("pool", "tas").
+					Label(corev1.LabelHostname, "not-ready").
+					NotReady().
+					Obj(),
+			},
+			reconciles: 1,
+		},
+		"all nodes are labeled": {
+			featureGates: map[featuregate.Feature]bool{features.TASReportMissingTopologyLabels: true},
+			nodes:        []*corev1.Node{labeledNode.Obj(), otherPoolNode.Obj()},
+			reconciles:   1,
+		},
+		"nodes missing topology labels; TASReportMissingTopologyLabels disabled": {
+			featureGates: map[featuregate.Feature]bool{features.TASReportMissingTopologyLabels: false},
+			nodes:        []*corev1.Node{labeledNode.Obj(), missingRackNode.Obj()},
+			reconciles:   1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGatesDuringTest(t, tc.featureGates)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().WithObjects(flavor.DeepCopy(), topology.DeepCopy()).Build()
+			cache := schdcache.New(cl)
+			cache.AddOrUpdateTopology(log, topology)
+			cache.AddOrUpdateResourceFlavor(log, flavor)
+			for _, node := range tc.nodes {
+				cache.TASCache().SyncNode(node)
+			}
+			recorder := &utiltesting.EventRecorder{}
+			r := newRfReconciler(cl, qcache.NewManagerForUnitTests(cl, cache), cache, recorder, nil)
+
+			for range tc.reconciles {
+				if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: flavorKey}); err != nil {
+					t.Fatalf("Failed to reconcile: %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected events (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteForgetsReportedNodes(t *testing.T) {
+	features.SetFeatureGatesDuringTest(t, map[featuregate.Feature]bool{features.TASReportMissingTopologyLabels: true})
+	ctx, log := utiltesting.ContextWithLog(t)
+	topology := utiltestingapi.MakeTopology("default").Levels("cloud.com/block", corev1.LabelHostname).Obj()
+	flavor := utiltestingapi.MakeResourceFlavor("tas").TopologyName("default").Obj()
+	cl := utiltesting.NewClientBuilder().WithObjects(flavor.DeepCopy(), topology.DeepCopy()).Build()
+	cache := schdcache.New(cl)
+	cache.AddOrUpdateTopology(log, topology)
+	cache.AddOrUpdateResourceFlavor(log, flavor)
+	cache.TASCache().SyncNode(testingnode.MakeNode("missing-block").Label(corev1.LabelHostname, "missing-block").Ready().Obj())
+	r := newRfReconciler(cl, qcache.NewManagerForUnitTests(cl, cache), cache, &utiltesting.EventRecorder{}, nil)
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "tas"}}); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+	if _, found := r.reported["tas"]; !found {
+		t.Fatalf("Nodes missing topology labels not reported for the flavor")
+	}
+	r.Delete(event.TypedDeleteEvent[*kueue.ResourceFlavor]{Object: flavor})
+	if _, found := r.reported["tas"]; found {
+		t.Errorf("Nodes missing topology labels still reported for the deleted flavor")
+	}
+}
+
+func TestReconcileRequeuesForNodeReady(t *testing.T) {
+	features.SetFeatureGatesDuringTest(t, map[featuregate.Feature]bool{features.TASNodeReadinessGating: true})
+	ctx, log := utiltesting.ContextWithLog(t)
+	now := time.Now().Truncate(time.Second)
+	topology := utiltestingapi.MakeTopology("default").Levels(corev1.LabelHostname).Obj()
+	flavor := utiltestingapi.MakeResourceFlavor("tas").TopologyName("default").Obj()
+	flavor.Spec.MinNodeReadySeconds = ptr.To[int32](60)
+	cl := utiltesting.NewClientBuilder().WithObjects(flavor.DeepCopy(), topology.DeepCopy()).Build()
+	cache := schdcache.New(cl)
+	cache.AddOrUpdateTopology(log, topology)
+	cache.AddOrUpdateResourceFlavor(log, flavor)
+	cache.TASCache().SyncNode(testingnode.MakeNode("node").
+		Label(corev1.LabelHostname, "node").
+		StatusConditions(corev1.NodeCondition{
+			Type:               corev1.NodeReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now.Add(-20 * time.Second)),
+		}).
+		Obj())
+	r := newRfReconciler(cl, qcache.NewManagerForUnitTests(cl, cache), cache, &utiltesting.EventRecorder{}, nil)
+	r.clock = testingclock.NewFakeClock(now)
+
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "tas"}})
+	if err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+	if want := 40 * time.Second; result.RequeueAfter != want {
+		t.Errorf("Unexpected RequeueAfter, got %v, want %v", result.RequeueAfter, want)
+	}
+}
+
+func TestNodesMissingTopologyLabelsMessage(t *testing.T) {
+	missing := make(map[string][]string)
+	for _, name := range []string{"n00", "n01", "n02", "n03", "n04", "n05", "n06", "n07", "n08", "n09", "n10", "n11"} {
+		missing[name] = []string{"cloud.com/block"}
+	}
+	want := "12 node(s) matching the node labels of the flavor are missing topology labels and are not used by TAS: " +
+		"n00 (cloud.com/block); n01 (cloud.com/block); n02 (cloud.com/block); n03 (cloud.com/block); n04 (cloud.com/block); " +
+		"n05 (cloud.com/block); n06 (cloud.com/block); n07 (cloud.com/block); n08 (cloud.com/block); n09 (cloud.com/block); and 2 more"
+	if got := nodesMissingTopologyLabelsMessage(missing); got != want {
+		t.Errorf("Unexpected message\nwant: %s\ngot:  %s", want, got)
+	}
+}
+
 func TestNodeHandler_Update(t *testing.T) {
 	now := metav1.Now()
 	later := metav1.NewTime(now.Add(10 * time.Second))
@@ -529,9 +529,17 @@ func readRanksForLabels(
 		if rank < 0 {
 			return nil, fmt.Errorf("rank %v of Pod %q is below 0", rank, klog.KObj(pod))
 		}
-		if _, found := result[rank]; found {
-			// there is a conflict in ranks, they cannot be used
-			return nil, fmt.Errorf("conflicting rank %v found for pod %q", rank, klog.KObj(pod))
+		if other, found := result[rank]; found {
+			switch {
+			case other.DeletionTimestamp != nil && pod.DeletionTimestamp == nil:
+				// the Pod replaces the terminating Pod with the same rank, so
+				// it is assigned to the domain of the terminating Pod.
+			case pod.DeletionTimestamp != nil && other.DeletionTimestamp == nil:
+				continue
+			default:
+				// there is a conflict in ranks, they cannot be used
+				return nil, fmt.Errorf("conflicting rank %v found for pod %q", rank, klog.KObj(pod))
+			}
 		}
 		result[rank] = pod
 	}
@@ -490,6 +490,247 @@ const (
 	// to be reused within a single scheduling cycle by TAS evaluations corresponding to different
 	// sets of preemption candidates.
 	TASCacheNodeMatchResults featuregate.Feature = "TASCacheNodeMatchResults"
+
+	// Enables capping the total quantity of a resource that a ClusterQueue can
+	// borrow across all its flavors, via spec.resourceBorrowingLimits.
+	ResourceBorrowingLimits featuregate.Feature = "ResourceBorrowingLimits"
+
+	// Enables admitting PodSets without a topology assignment when their topology
+	// request cannot be satisfied within the duration set by the
+	// kueue.x-k8s.io/podset-unconstrained-topology-fallback annotation.
+	TASUnconstrainedTopologyFallback featuregate.Feature = "TASUnconstrainedTopologyFallback"
+
+	// Enables moving a pending job to the LocalQueue named by the
+	// kueue.x-k8s.io/fallback-queue-name annotation once its workload has waited
+	// for the duration set by the kueue.x-k8s.io/fallback-queue-after annotation.
+	FallbackLocalQueue featuregate.Feature = "FallbackLocalQueue"
+
+	// Enables a periodic check which recomputes the scheduler cache usage from the
+	// Workloads holding quota reservations, and repairs the detected drifts.
+	CacheConsistencyCheck featuregate.Feature = "CacheConsistencyCheck"
+
+	// Enables scaling the nominal quota of compressible resources of a ClusterQueue
+	// via spec.overcommitRatios.
+	ClusterQueueOvercommit featuregate.Feature = "ClusterQueueOvercommit"
+
+	// Enables releasing the quota reservation of an admitted job whose pods are not
+	// created within the duration set by the kueue.x-k8s.io/pods-creation-timeout
+	// annotation.
+	PodsCreationTimeout featuregate.Feature = "PodsCreationTimeout"
+
+	// Enables writing the admission, preemption and eviction decisions taken for
+	// workloads as structured records to the audit logger.
+	AuditLog featuregate.Feature = "AuditLog"
+
+	// Enables increasing the effective priority of the pending workloads of a
+	// ClusterQueue with their waiting time, as configured by spec.priorityAging.
+	PriorityAging featuregate.Feature = "PriorityAging"
+
+	// Enables configuring how the workloads of a ClusterQueue evicted by the
+	// PodsReady timeout are requeued, as configured by spec.requeueStrategy.
+	RequeueStrategy featuregate.Feature = "RequeueStrategy"
+
+	// Enables delaying the contribution of the Nodes of a TAS ResourceFlavor to
+	// the topology domain capacity until they are Ready for spec.minNodeReadySeconds.
+	TASNodeReadinessGating featuregate.Feature = "TASNodeReadinessGating"
+
+	// Enables the kueue.x-k8s.io/non-preemptible Workload annotation, which excludes
+	// admitted workloads from being selected as preemption victims.
+	NonPreemptibleWorkloads featuregate.Feature = "NonPreemptibleWorkloads"
+
+	// Enables deriving the nominal quota of ClusterQueue flavors from the capacity
+	// of their nodes, via spec.nodeCapacityFlavors.
+	NodeCapacityQuota featuregate.Feature = "NodeCapacityQuota"
+
+	// Enables streaming the admission, eviction and finish events of the Workloads
+	// as Server-Sent Events on the metrics server.
+	WorkloadEventsStream featuregate.Feature = "WorkloadEventsStream"
+
+	// Enables advisory AdmissionChecks, whose state records a result without
+	// blocking the admission of the Workloads.
+	AdvisoryAdmissionChecks featuregate.Feature = "AdvisoryAdmissionChecks"
+
+	// Enables accounting the default container requests of the ClusterQueue for
+	// the containers of the Workloads which don't request a resource.
+	ClusterQueueDefaultRequests featuregate.Feature = "ClusterQueueDefaultRequests"
+
+	// Enables preferring the topology domains of the previous TopologyAssignment
+	// of a Workload when it is admitted again after requeueing.
+	TASPreferPreviousAssignment featuregate.Feature = "TASPreferPreviousAssignment"
+
+	// Enables resetting the priority of the Workloads referencing a deleted
+	// WorkloadPriorityClass to the default priority.
+	ResetPriorityOnWorkloadPriorityClassDeletion featuregate.Feature = "ResetPriorityOnWorkloadPriorityClassDeletion"
+
+	// Enables assigning a different flavor to a Workload evicted because an AdmissionCheck
+	// is in the Retry state for its assigned flavors.
+	RetryAdmissionCheckOnDifferentFlavor featuregate.Feature = "RetryAdmissionCheckOnDifferentFlavor"
+
+	// Enables restricting the namespaces whose Workloads can be assigned a ResourceFlavor.
+	ResourceFlavorNamespaceSelector featuregate.Feature = "ResourceFlavorNamespaceSelector"
+
+	// Enables evicting the lowest priority workloads of a ClusterQueue whose usage
+	// exceeds its quota, as configured by spec.quotaReductionPolicy.
+	QuotaReductionEviction featuregate.Feature = "QuotaReductionEviction"
+
+	// Enables recording a hash of the pod templates of a started job on its workload,
+	// and detecting the changes of the pod templates after the admission.
+	PodTemplateHashing featuregate.Feature = "PodTemplateHashing"
+
+	// Enables ordering the workloads of StrictFIFO ClusterQueues solely by their
+	// queue ordering timestamp, regardless of their priority.
+	StrictFIFOIgnoresPriority featuregate.Feature = "StrictFIFOIgnoresPriority"
+
+	// Enables moving a job whose workload can't be admitted in its LocalQueue to
+	// the next LocalQueue listed in the kueue.x-k8s.io/candidate-queue-names annotation.
+	CandidateLocalQueues featuregate.Feature = "CandidateLocalQueues"
+
+	// Enables treating a batch/Job with the FailureTarget condition, added when a
+	// rule of its podFailurePolicy fails the Job, as finished once its pods are
+	// terminated, so that Kueue doesn't resume it after it was preempted.
+	JobFailureTargetFinishesWorkload featuregate.Feature = "JobFailureTargetFinishesWorkload"
+
+	// Enables preferring the topology domains of the workload referenced in the
+	// kueue.x-k8s.io/colocate-with-workload annotation when placing a workload
+	// with Topology Aware Scheduling.
+	TASColocateWithWorkload featuregate.Feature = "TASColocateWithWorkload"
+
+	// Enables restricting the borrowing of a ClusterQueue to the time windows
+	// configured in its spec.borrowingWindows.
+	BorrowingWindows featuregate.Feature = "BorrowingWindows"
+
+	// Rejects the deletion of a LocalQueue while it has non-finished Workloads.
+	LocalQueueDeletionProtection featuregate.Feature = "LocalQueueDeletionProtection"
+
+	// Enables the scheduler to admit the workloads of independent cohorts
+	// concurrently, using the number of workers configured in the scheduler
+	// configuration.
+	ConcurrentCohortScheduling featuregate.Feature = "ConcurrentCohortScheduling"
+
+	// Assigns a TAS flavor to the PodSets that don't request any resources
+	// covered by the ClusterQueue, so that they get a TopologyAssignment based
+	// on their pod count.
+	TASPlacementWithoutRequests featuregate.Feature = "TASPlacementWithoutRequests"
+
+	// Enables the cooldownSeconds of the ClusterQueue preemption policy, which
+	// protects recently admitted Workloads from preemption and delays requeueing
+	// the preempted Workloads.
+	PreemptionCooldown featuregate.Feature = "PreemptionCooldown"
+
+	// Enables the resourceScaling of the ResourceFlavors, which divides the
+	// usage of the scaled resources in the flavor, for example, to account
+	// for the physical GPUs shared with time-slicing.
+	ResourceFlavorResourceScaling featuregate.Feature = "ResourceFlavorResourceScaling"
+
+	// Enables limiting the number of concurrent ProvisioningRequests created for
+	// the admission checks using a ProvisioningRequestConfig.
+	ProvisioningRequestMaxConcurrentRequests featuregate.Feature = "ProvisioningRequestMaxConcurrentRequests"
+
+	// Finish workloads whose controller owner no longer exists, even if they
+	// still reference it, for example, when the owner was deleted while Kueue
+	// was not running.
+	FinishWorkloadsWithDeletedOwner featuregate.Feature = "FinishWorkloadsWithDeletedOwner"
+
+	// Enables the WeightedRoundRobinAdmissionFairSharing admission mode of ClusterQueues,
+	// which shares the admissions among their LocalQueues in proportion to their weights.
+	AdmissionFairSharingWeightedRoundRobin featuregate.Feature = "AdmissionFairSharingWeightedRoundRobin"
+
+	// Enables the stopPolicy of the ResourceFlavors, which stops new admissions
+	// in a flavor and optionally evicts the workloads using it, without
+	// deactivating the ClusterQueues.
+	ResourceFlavorStopPolicy featuregate.Feature = "ResourceFlavorStopPolicy"
+
+	// Enables surfacing the messages and the retry time of the admission checks
+	// in the Retry state in the Requeued condition and the admissionChecks of
+	// the Workloads.
+	AdmissionCheckRetryStatus featuregate.Feature = "AdmissionCheckRetryStatus"
+
+	// Enable respecting the DoNotSchedule topologySpreadConstraints of the pod template
+	// when assigning TAS domains.
+	TASRespectTopologySpreadConstraints featuregate.Feature = "TASRespectTopologySpreadConstraints"
+
+	// Enables the kueue.x-k8s.io/hold annotation, which keeps a pending Workload in
+	// its queue, accruing its queue position and aging, without admitting it.
+	WorkloadHold featuregate.Feature = "WorkloadHold"
+
+	// Enables the admissionCheckTimeoutSeconds of AdmissionChecks, after which the quota
+	// reservation of Workloads with the check still Pending is released.
+	AdmissionCheckTimeout featuregate.Feature = "AdmissionCheckTimeout"
+
+	// Enables deriving the values of topology levels from a composite node label with
+	// the valueFrom field of the Topology levels.
+	TASDerivedTopologyLevels featuregate.Feature = "TASDerivedTopologyLevels"
+
+	// Enables preempting the pods of Deployments and StatefulSets with the eviction
+	// API, so that the PodDisruptionBudgets of the pods are respected.
+	PreemptionRespectsPodDisruptionBudgets featuregate.Feature = "PreemptionRespectsPodDisruptionBudgets"
+
+	// Enables the estimatedWaitTime of the pending Workloads in the visibility API, estimated
+	// from their position in the queue and the recent admission throughput of the ClusterQueue.
+	WorkloadEstimatedWaitTime featuregate.Feature = "WorkloadEstimatedWaitTime"
+
+	// Enables evicting the Workload of a suspended job when the pod sets of the job change
+	// after the quota reservation, so that the Workload is updated and admitted again.
+	ReadmitOnPodSetsChange featuregate.Feature = "ReadmitOnPodSetsChange"
+
+	// Enables requeuing the reconciliation requests in the non-leading replicas after the
+	// leader election retry period, instead of the lease duration.
+	LeaderElectionFastFailover featuregate.Feature = "LeaderElectionFastFailover"
+
+	// Enables reserving quota for the next run of CronJobs, ahead of the scheduled
+	// time set by the kueue.x-k8s.io/quota-reservation-lead-time annotation.
+	CronJobQuotaReservation featuregate.Feature = "CronJobQuotaReservation"
+
+	// Propagates the queue and priority class labels of CronJobs to their job templates.
+	CronJobQueueLabelPropagation featuregate.Feature = "CronJobQueueLabelPropagation"
+
+	// Enables reserving the guaranteed quota of LocalQueues within their ClusterQueue.
+	LocalQueueGuaranteedQuota featuregate.Feature = "LocalQueueGuaranteedQuota"
+
+	// Enables limiting the number of Workloads admitted in a ClusterQueue with
+	// the maxAdmittedWorkloads field.
+	ClusterQueueMaxAdmittedWorkloads featuregate.Feature = "ClusterQueueMaxAdmittedWorkloads"
+
+	// Enables requesting a share of a node of the assigned flavor with the
+	// kueue.x-k8s.io/node-share annotation.
+	NodeShareRequests featuregate.Feature = "NodeShareRequests"
+
+	// Enables eviction hooks, which delay stopping the job of an evicted Workload
+	// until external controllers complete their cleanup, or until a timeout.
+	WorkloadEvictionHooks featuregate.Feature = "WorkloadEvictionHooks"
+
+	// Enables opting jobs out of the management by Kueue with the kueue.x-k8s.io/managed
+	// annotation set to "false", even when they have the queue-name label.
+	JobManagementOptOut featuregate.Feature = "JobManagementOptOut"
+
+	// Enables configuring the ordered stages of the preemption with the stages
+	// field of the ClusterQueue preemption.
+	PreemptionStages featuregate.Feature = "PreemptionStages"
+
+	// Limits the number of pods of a PodSet assigned by TAS to a single domain
+	// of the lowest topology level, such as a node.
+	TASMaxPodsPerDomain featuregate.Feature = "TASMaxPodsPerDomain"
+
+	// Enables accounting the quota usage of the configured resources of a
+	// ClusterQueue from the limits of the containers.
+	ClusterQueueLimitsAccounting featuregate.Feature = "ClusterQueueLimitsAccounting"
+
+	// Enables reporting the nodes of TAS ResourceFlavors which are missing
+	// topology level labels with events on the ResourceFlavors.
+	TASReportMissingTopologyLabels featuregate.Feature = "TASReportMissingTopologyLabels"
+
+	// Enables the DryRun preemption mode of ClusterQueues, which reports the
+	// preemption targets without evicting them.
+	PreemptionDryRun featuregate.Feature = "PreemptionDryRun"
+
+	// Allows to configure how the flavor is chosen among the flavors which fit
+	// a workload equally well, by the headroom left in the flavors.
+	FlavorTieBreak featuregate.Feature = "FlavorTieBreak"
+
+	// Enables the QuotaExhausted condition of the ClusterQueues, which reports the
+	// resources whose quota, including the quota that can be borrowed from the
+	// cohort, is fully used.
+	ClusterQueueQuotaExhaustedCondition featuregate.Feature = "ClusterQueueQuotaExhaustedCondition"
 )
 
 func init() {
@@ -754,6 +995,238 @@ var defaultVersionedFeatureGates = map[featuregate.Feature]featuregate.Versioned
 	TASCacheNodeMatchResults: {
 		{Version: version.MustParse("0.19"), Default: true, PreRelease: featuregate.Beta},
 	},
+
+	ResourceBorrowingLimits: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASUnconstrainedTopologyFallback: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	FallbackLocalQueue: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	CacheConsistencyCheck: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ClusterQueueOvercommit: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	PodsCreationTimeout: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	AuditLog: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	PriorityAging: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	RequeueStrategy: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASNodeReadinessGating: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	NonPreemptibleWorkloads: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	NodeCapacityQuota: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	WorkloadEventsStream: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	AdvisoryAdmissionChecks: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ClusterQueueDefaultRequests: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASPreferPreviousAssignment: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ResetPriorityOnWorkloadPriorityClassDeletion: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	RetryAdmissionCheckOnDifferentFlavor: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ResourceFlavorNamespaceSelector: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	QuotaReductionEviction: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	PodTemplateHashing: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	StrictFIFOIgnoresPriority: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	CandidateLocalQueues: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	JobFailureTargetFinishesWorkload: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASColocateWithWorkload: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	BorrowingWindows: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	LocalQueueDeletionProtection: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ConcurrentCohortScheduling: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASPlacementWithoutRequests: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	PreemptionCooldown: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ResourceFlavorResourceScaling: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ProvisioningRequestMaxConcurrentRequests: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	FinishWorkloadsWithDeletedOwner: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	AdmissionFairSharingWeightedRoundRobin: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ResourceFlavorStopPolicy: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	AdmissionCheckRetryStatus: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASRespectTopologySpreadConstraints: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	WorkloadHold: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	AdmissionCheckTimeout: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASDerivedTopologyLevels: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	PreemptionRespectsPodDisruptionBudgets: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	WorkloadEstimatedWaitTime: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ReadmitOnPodSetsChange: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	LeaderElectionFastFailover: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	CronJobQuotaReservation: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	CronJobQueueLabelPropagation: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	LocalQueueGuaranteedQuota: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ClusterQueueMaxAdmittedWorkloads: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	NodeShareRequests: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	WorkloadEvictionHooks: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	JobManagementOptOut: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	PreemptionStages: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASMaxPodsPerDomain: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ClusterQueueLimitsAccounting: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	TASReportMissingTopologyLabels: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	PreemptionDryRun: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	FlavorTieBreak: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
+
+	ClusterQueueQuotaExhaustedCondition: {
+		{Version: version.MustParse("0.19"), Default: false, PreRelease: featuregate.Alpha},
+	},
 }
 
 func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) {
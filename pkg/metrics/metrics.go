@@ -34,6 +34,8 @@ import (
 
 type AdmissionResult string
 type ClusterQueueStatus string
+type CacheDriftType string
+type PreemptionOutcome string
 
 type LocalQueueReference struct {
 	Name      kueue.LocalQueueName
@@ -44,6 +46,22 @@ const (
 	AdmissionResultSuccess      AdmissionResult = "success"
 	AdmissionResultInadmissible AdmissionResult = "inadmissible"
 
+	// PreemptionOutcomeSucceeded means that the preemption targets were evicted.
+	PreemptionOutcomeSucceeded PreemptionOutcome = "succeeded"
+	// PreemptionOutcomeNoCandidates means that the workload needed preemption, but no preemption targets were found.
+	PreemptionOutcomeNoCandidates PreemptionOutcome = "no-candidates"
+	// PreemptionOutcomeSkipped means that the preemption was skipped because other workloads needed the same resources in the same cycle.
+	PreemptionOutcomeSkipped PreemptionOutcome = "skipped"
+	// PreemptionOutcomeDryRun means that the preemption targets were only reported, as the ClusterQueue preempts in the DryRun mode.
+	PreemptionOutcomeDryRun PreemptionOutcome = "dry-run"
+
+	// CacheDriftStaleWorkload means the cache tracked a workload which no longer holds a quota reservation.
+	CacheDriftStaleWorkload CacheDriftType = "stale_workload"
+	// CacheDriftMissingWorkload means the cache did not track a workload holding a quota reservation.
+	CacheDriftMissingWorkload CacheDriftType = "missing_workload"
+	// CacheDriftUsage means the ClusterQueue usage, or the TAS usage of its workloads, did not match the usage of its workloads.
+	CacheDriftUsage CacheDriftType = "usage"
+
 	PendingStatusActive       = "active"
 	PendingStatusInadmissible = "inadmissible"
 
@@ -79,6 +97,10 @@ var (
 	// +metricsdoc:labels=cluster_queue="the name of the ClusterQueue",cluster="the name of the worker cluster",replica_role="one of `leader`, `follower`, or `standalone`"
 	MultiKueueWorkloadsDispatchedTotal *prometheus.CounterVec
 
+	// +metricsdoc:group=health
+	// +metricsdoc:labels=cluster_queue="the name of the ClusterQueue",type="possible values are `stale_workload`, `missing_workload` or `usage`",replica_role="one of `leader`, `follower`, or `standalone`"
+	CacheDriftsDetectedTotal *prometheus.CounterVec
+
 	// +metricsdoc:group=clusterqueue
 	// +metricsdoc:labels=cluster_queue="the name of the ClusterQueue",replica_role="one of `leader`, `follower`, or `standalone`"
 	AdmissionCyclePreemptionSkips *prometheus.GaugeVec
@@ -193,6 +215,10 @@ var (
 	// +metricsdoc:labels=preempting_cluster_queue="the ClusterQueue executing preemption",reason="eviction or preemption reason",replica_role="one of `leader`, `follower`, or `standalone`"
 	PreemptedWorkloadsTotal *prometheus.CounterVec
 
+	// +metricsdoc:group=clusterqueue
+	// +metricsdoc:labels=cluster_queue="the name of the ClusterQueue",outcome="one of `succeeded`, `no-candidates`, or `skipped`",replica_role="one of `leader`, `follower`, or `standalone`"
+	PreemptionsTotal *prometheus.CounterVec
+
 	// +metricsdoc:group=clusterqueue
 	// +metricsdoc:labels=cluster_queue="the evicted workload's ClusterQueue from status.admission on the workload before quota was released (only present when the metric records a sample)",reason="eviction or preemption reason (same values as evicted_workloads_total)",replica_role="one of `leader`, `follower`, or `standalone`"
 	WorkloadEvictionLatencySeconds *prometheus.HistogramVec
@@ -279,10 +305,22 @@ var (
 	// +metricsdoc:labels=cohort="the name of the Cohort",cluster_queue="the name of the ClusterQueue",flavor="the resource flavor name",resource="the resource name",replica_role="one of `leader`, `follower`, or `standalone`"
 	ClusterQueueResourceLendingLimit *prometheus.GaugeVec
 
+	// +metricsdoc:group=optional_clusterqueue_resources
+	// +metricsdoc:labels=cohort="the name of the Cohort",cluster_queue="the name of the ClusterQueue",flavor="the resource flavor name",resource="the resource name",replica_role="one of `leader`, `follower`, or `standalone`"
+	ClusterQueueResourceAvailableQuota *prometheus.GaugeVec
+
+	// +metricsdoc:group=optional_clusterqueue_resources
+	// +metricsdoc:labels=cohort="the name of the Cohort",cluster_queue="the name of the ClusterQueue",flavor="the resource flavor name",resource="the resource name",replica_role="one of `leader`, `follower`, or `standalone`"
+	ClusterQueueResourceBorrowableQuota *prometheus.GaugeVec
+
 	// +metricsdoc:group=optional_clusterqueue_resources
 	// +metricsdoc:labels=cluster_queue="the name of the ClusterQueue",cohort="the name of the Cohort",replica_role="one of `leader`, `follower`, or `standalone`"
 	ClusterQueueWeightedShare *prometheus.GaugeVec
 
+	// +metricsdoc:group=clusterqueue
+	// +metricsdoc:labels=cluster_queue="the name of the ClusterQueue",cohort="the name of the Cohort",replica_role="one of `leader`, `follower`, or `standalone`"
+	ClusterQueueCohortUsageShare *prometheus.GaugeVec
+
 	// +metricsdoc:group=cohort
 	// +metricsdoc:labels=cohort="the name of the Cohort",replica_role="one of `leader`, `follower`, or `standalone`"
 	CohortWeightedShare *prometheus.GaugeVec
@@ -370,6 +408,19 @@ The label 'result' can have the following values:
 		}, []string{"cluster_queue", "cluster", "replica_role"},
 	)
 
+	CacheDriftsDetectedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cache_drifts_detected_total",
+			Help: `The total number of drifts between the scheduler cache and the Workloads holding quota reservations,
+detected and repaired by the cache consistency check, per 'cluster_queue'.
+The label 'type' can have the following values:
+- 'stale_workload' means that the cache tracked a workload which no longer holds a quota reservation,
+- 'missing_workload' means that the cache did not track a workload holding a quota reservation,
+- 'usage' means that the ClusterQueue usage, or the TAS usage of its workloads, did not match the usage of its workloads.`,
+		}, []string{"cluster_queue", "type", "replica_role"},
+	)
+
 	AdmissionCyclePreemptionSkips = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -485,13 +536,20 @@ The label 'reason' can have the following values:
 - "AdmissionCheck" means that the workload was evicted because at least one admission check transitioned to False.
 - "ClusterQueueStopped" means that the workload was evicted because the ClusterQueue is stopped.
 - "LocalQueueStopped" means that the workload was evicted because the LocalQueue is stopped.
+- "ResourceFlavorStopped" means that the workload was evicted because a ResourceFlavor assigned to it is stopped.
+- "ClusterQueueQuotaReduction" means that the workload was evicted because the usage of the ClusterQueue exceeded its quota.
+- "PodsCreationTimeout" means that the workload was evicted because its pods were not created within the timeout set on the job.
+- "PodTemplateMutated" means that the workload was evicted because the pod templates of its job were changed after the admission.
 - "NodeFailures" means that the workload was evicted due to node failures when using TopologyAwareScheduling.
 - "Deactivated" means that the workload was evicted because spec.active is set to false.
+- "FlavorMigration" means that the workload was evicted because a variant of the same workload was admitted to a more preferred flavor.
+- "EvictedOnManagerCluster" means that the workload was evicted on the MultiKueue manager cluster.
 The label 'underlying_cause' can have the following values:
 - "" means that the value in 'reason' label is the root cause for eviction.
 - "AdmissionCheck" means that the workload was evicted by Kueue due to a rejected admission check.
 - "MaximumExecutionTimeExceeded" means that the workload was evicted by Kueue due to maximum execution time exceeded.
-- "RequeuingLimitExceeded" means that the workload was evicted by Kueue due to requeuing limit exceeded.`,
+- "RequeuingLimitExceeded" means that the workload was evicted by Kueue due to requeuing limit exceeded.
+- "PodsReadyTimeout" means that the workload was evicted by Kueue due to a PodsReady timeout, because its ClusterQueue uses the Manual requeue strategy.`,
 			Buckets: generateExponentialBuckets(14),
 		}, append([]string{"cluster_queue", "reason", "underlying_cause", "replica_role"}, extraLabels...),
 	)
@@ -621,13 +679,20 @@ The label 'reason' can have the following values:
 - "AdmissionCheck" means that the workload was evicted because at least one admission check transitioned to False.
 - "ClusterQueueStopped" means that the workload was evicted because the ClusterQueue is stopped.
 - "LocalQueueStopped" means that the workload was evicted because the LocalQueue is stopped.
+- "ResourceFlavorStopped" means that the workload was evicted because a ResourceFlavor assigned to it is stopped.
+- "ClusterQueueQuotaReduction" means that the workload was evicted because the usage of the ClusterQueue exceeded its quota.
+- "PodsCreationTimeout" means that the workload was evicted because its pods were not created within the timeout set on the job.
+- "PodTemplateMutated" means that the workload was evicted because the pod templates of its job were changed after the admission.
 - "NodeFailures" means that the workload was evicted due to node failures when using TopologyAwareScheduling.
 - "Deactivated" means that the workload was evicted because spec.active is set to false.
+- "FlavorMigration" means that the workload was evicted because a variant of the same workload was admitted to a more preferred flavor.
+- "EvictedOnManagerCluster" means that the workload was evicted on the MultiKueue manager cluster.
 The label 'underlying_cause' can have the following values:
 - "" means that the value in 'reason' label is the root cause for eviction.
 - "AdmissionCheck" means that the workload was evicted by Kueue due to a rejected admission check.
 - "MaximumExecutionTimeExceeded" means that the workload was evicted by Kueue due to maximum execution time exceeded.
-- "RequeuingLimitExceeded" means that the workload was evicted by Kueue due to requeuing limit exceeded.`,
+- "RequeuingLimitExceeded" means that the workload was evicted by Kueue due to requeuing limit exceeded.
+- "PodsReadyTimeout" means that the workload was evicted by Kueue due to a PodsReady timeout, because its ClusterQueue uses the Manual requeue strategy.`,
 		}, append([]string{"cluster_queue", "reason", "underlying_cause", "priority_class", "replica_role"}, extraLabels...),
 	)
 
@@ -650,13 +715,20 @@ The label 'reason' can have the following values:
 - "AdmissionCheck" means that the workload was evicted because at least one admission check transitioned to False.
 - "ClusterQueueStopped" means that the workload was evicted because the ClusterQueue is stopped.
 - "LocalQueueStopped" means that the workload was evicted because the LocalQueue is stopped.
+- "ResourceFlavorStopped" means that the workload was evicted because a ResourceFlavor assigned to it is stopped.
+- "ClusterQueueQuotaReduction" means that the workload was evicted because the usage of the ClusterQueue exceeded its quota.
+- "PodsCreationTimeout" means that the workload was evicted because its pods were not created within the timeout set on the job.
+- "PodTemplateMutated" means that the workload was evicted because the pod templates of its job were changed after the admission.
 - "NodeFailures" means that the workload was evicted due to node failures when using TopologyAwareScheduling.
 - "Deactivated" means that the workload was evicted because spec.active is set to false.
+- "FlavorMigration" means that the workload was evicted because a variant of the same workload was admitted to a more preferred flavor.
+- "EvictedOnManagerCluster" means that the workload was evicted on the MultiKueue manager cluster.
 The label 'underlying_cause' can have the following values:
 - "" means that the value in 'reason' label is the root cause for eviction.
 - "AdmissionCheck" means that the workload was evicted by Kueue due to a rejected admission check.
 - "MaximumExecutionTimeExceeded" means that the workload was evicted by Kueue due to maximum execution time exceeded.
-- "RequeuingLimitExceeded" means that the workload was evicted by Kueue due to requeuing limit exceeded.`,
+- "RequeuingLimitExceeded" means that the workload was evicted by Kueue due to requeuing limit exceeded.
+- "PodsReadyTimeout" means that the workload was evicted by Kueue due to a PodsReady timeout, because its ClusterQueue uses the Manual requeue strategy.`,
 		}, append([]string{"name", "namespace", "reason", "underlying_cause", "priority_class", "replica_role"}, extraLabels...),
 	)
 
@@ -671,15 +743,22 @@ The label 'reason' can have the following values:
 - "AdmissionCheck" means that the workload was evicted because at least one admission check transitioned to False.
 - "ClusterQueueStopped" means that the workload was evicted because the ClusterQueue is stopped.
 - "LocalQueueStopped" means that the workload was evicted because the LocalQueue is stopped.
+- "ResourceFlavorStopped" means that the workload was evicted because a ResourceFlavor assigned to it is stopped.
+- "ClusterQueueQuotaReduction" means that the workload was evicted because the usage of the ClusterQueue exceeded its quota.
+- "PodsCreationTimeout" means that the workload was evicted because its pods were not created within the timeout set on the job.
+- "PodTemplateMutated" means that the workload was evicted because the pod templates of its job were changed after the admission.
 - "NodeFailures" means that the workload was evicted due to node failures when using TopologyAwareScheduling.
 - "Deactivated" means that the workload was evicted because spec.active is set to false.
+- "FlavorMigration" means that the workload was evicted because a variant of the same workload was admitted to a more preferred flavor.
+- "EvictedOnManagerCluster" means that the workload was evicted on the MultiKueue manager cluster.
 The label 'underlying_cause' can have the following values:
 - "" means that the value in 'reason' label is the root cause for eviction.
 - "WaitForStart" means that the pods have not been ready since admission, or the workload is not admitted.
 - "WaitForRecovery" means that the Pods were ready since the workload admission, but some pod has failed.
 - "AdmissionCheck" means that the workload was evicted by Kueue due to a rejected admission check.
 - "MaximumExecutionTimeExceeded" means that the workload was evicted by Kueue due to maximum execution time exceeded.
-- "RequeuingLimitExceeded" means that the workload was evicted by Kueue due to requeuing limit exceeded.`,
+- "RequeuingLimitExceeded" means that the workload was evicted by Kueue due to requeuing limit exceeded.
+- "PodsReadyTimeout" means that the workload was evicted by Kueue due to a PodsReady timeout, because its ClusterQueue uses the Manual requeue strategy.`,
 		}, append([]string{"cluster_queue", "reason", "underlying_cause", "priority_class", "replica_role"}, extraLabels...),
 	)
 
@@ -696,6 +775,19 @@ The label 'reason' can have the following values:
 		}, append([]string{"preempting_cluster_queue", "reason", "replica_role"}, extraLabels...),
 	)
 
+	PreemptionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "preemptions_total",
+			Help: `The number of preemption attempts of the workloads per 'cluster_queue',
+The label 'outcome' can have the following values:
+- "succeeded" means that the preemption targets were evicted.
+- "no-candidates" means that the workload needed preemption, but no preemption targets were found.
+- "skipped" means that the preemption was skipped because other workloads needed the same resources in the same cycle.
+- "dry-run" means that the preemption targets were only reported, as the ClusterQueue preempts in the DryRun mode.`,
+		}, append([]string{"cluster_queue", "outcome", "replica_role"}, extraLabels...),
+	)
+
 	WorkloadEvictionLatencySeconds = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Subsystem: constants.KueueName,
@@ -710,8 +802,14 @@ The label 'reason' can have the following values:
 - "AdmissionCheck" means that the workload was evicted because at least one admission check transitioned to False.
 - "ClusterQueueStopped" means that the workload was evicted because the ClusterQueue is stopped.
 - "LocalQueueStopped" means that the workload was evicted because the LocalQueue is stopped.
+- "ResourceFlavorStopped" means that the workload was evicted because a ResourceFlavor assigned to it is stopped.
+- "ClusterQueueQuotaReduction" means that the workload was evicted because the usage of the ClusterQueue exceeded its quota.
+- "PodsCreationTimeout" means that the workload was evicted because its pods were not created within the timeout set on the job.
+- "PodTemplateMutated" means that the workload was evicted because the pod templates of its job were changed after the admission.
 - "NodeFailures" means that the workload was evicted due to node failures when using TopologyAwareScheduling.
-- "Deactivated" means that the workload was evicted because spec.active is set to false.`,
+- "Deactivated" means that the workload was evicted because spec.active is set to false.
+- "FlavorMigration" means that the workload was evicted because a variant of the same workload was admitted to a more preferred flavor.
+- "EvictedOnManagerCluster" means that the workload was evicted on the MultiKueue manager cluster.`,
 			Buckets: generateExponentialBuckets(14),
 		}, append([]string{"cluster_queue", "reason", "replica_role"}, extraLabels...),
 	)
@@ -873,6 +971,27 @@ admission penalties, divided by the LocalQueue's fair sharing weight`,
 	)
 	trackGaugeVec(ClusterQueueResourceLendingLimit, gaugeCleanupScopeClusterQueueResource)
 
+	ClusterQueueResourceAvailableQuota = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_available_quota",
+			Help: `Reports the cluster_queue's resource quota that remains available for admitting workloads within all the flavors,
+including the quota that can be borrowed from the cohort given the current usage of the other cluster_queues.
+If the available quota is unlimited, this metric reports +Inf.`,
+		}, append([]string{"cohort", "cluster_queue", "flavor", "resource", "replica_role"}, extraLabels...),
+	)
+	trackGaugeVec(ClusterQueueResourceAvailableQuota, gaugeCleanupScopeClusterQueueResource)
+
+	ClusterQueueResourceBorrowableQuota = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_borrowable_quota",
+			Help: `Reports the part of the cluster_queue's available quota that can be borrowed from the cohort within all the flavors,
+given the current usage of the other cluster_queues. If the borrowable quota is unlimited, this metric reports +Inf.`,
+		}, append([]string{"cohort", "cluster_queue", "flavor", "resource", "replica_role"}, extraLabels...),
+	)
+	trackGaugeVec(ClusterQueueResourceBorrowableQuota, gaugeCleanupScopeClusterQueueResource)
+
 	ClusterQueueWeightedShare = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -886,6 +1005,21 @@ If the ClusterQueue has a weight of zero and is borrowing, this will return NaN.
 	)
 	trackGaugeVec(ClusterQueueWeightedShare, gaugeCleanupScopeClusterQueueLabelChange)
 
+	ClusterQueueCohortUsageShare = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_cohort_usage_share",
+			Help: `Reports the share of the quota of its cohort used by the ClusterQueue, that is
+the maximum of the ratios of the usage of the ClusterQueue to the quota of the root
+of the cohort tree, among all the resources. It ranges from 0 to 1.
+Unlike cluster_queue_weighted_share, which drives the fair sharing decisions, it accounts
+all the usage of the ClusterQueue, not only the usage above its nominal quota, and ignores the weight.
+This metric is only reported when fair sharing is enabled, and is updated on the scheduling cycles
+where the share changed. The series is removed when the ClusterQueue leaves its cohort.`,
+		}, append([]string{"cluster_queue", "cohort", "replica_role"}, extraLabels...),
+	)
+	trackGaugeVec(ClusterQueueCohortUsageShare, gaugeCleanupScopeClusterQueueLabelChange)
+
 	CohortWeightedShare = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -965,6 +1099,10 @@ func AdmissionAttempt(result AdmissionResult, duration time.Duration, tracker *r
 	admissionAttemptDuration.WithLabelValues(string(result), role).Observe(duration.Seconds())
 }
 
+func ReportCacheDrift(cqName kueue.ClusterQueueReference, driftType CacheDriftType, tracker *roletracker.RoleTracker) {
+	CacheDriftsDetectedTotal.WithLabelValues(string(cqName), string(driftType), roletracker.GetRole(tracker)).Inc()
+}
+
 func ReportMultiKueueWorkloadDispatched(cqName kueue.ClusterQueueReference, cluster string, tracker *roletracker.RoleTracker) {
 	MultiKueueWorkloadsDispatchedTotal.WithLabelValues(string(cqName), cluster, roletracker.GetRole(tracker)).Inc()
 }
@@ -1111,6 +1249,11 @@ func ReportPreemption(preemptingCqName kueue.ClusterQueueReference, preemptingRe
 	PreemptedWorkloadsTotal.WithLabelValues(labels...).Inc()
 }
 
+func ReportPreemptionAttempt(cqName kueue.ClusterQueueReference, outcome PreemptionOutcome, customLabelValues []string, tracker *roletracker.RoleTracker) {
+	labels := append([]string{string(cqName), string(outcome), roletracker.GetRole(tracker)}, customLabelValues...)
+	PreemptionsTotal.WithLabelValues(labels...).Inc()
+}
+
 func LQRefFromWorkload(wl *kueue.Workload) LocalQueueReference {
 	return LocalQueueReference{
 		Name:      wl.Spec.QueueName,
@@ -1132,8 +1275,10 @@ func ClearClusterQueueMetrics(cq kueue.ClusterQueueReference) {
 	QueuedUntilReadyWaitTime.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	AdmittedUntilReadyWaitTime.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	EvictedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
+	CacheDriftsDetectedTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	EvictedWorkloadsOnceTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	PreemptedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"preempting_cluster_queue": cqName})
+	PreemptionsTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	// Histogram vec, not cleared by gauge cleanup above.
 	WorkloadEvictionLatencySeconds.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	PodSchedulingGateRemovalSeconds.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
@@ -1211,6 +1356,12 @@ func ReportClusterQueueQuotas(cohort kueue.CohortReference, queue, flavor, resou
 	ClusterQueueResourceLendingLimit.WithLabelValues(labels...).Set(lending)
 }
 
+func ReportClusterQueueAvailableQuota(cohort kueue.CohortReference, queue, flavor, resource string, available, borrowable float64, customLabelValues []string, tracker *roletracker.RoleTracker) {
+	labels := append([]string{string(cohort), queue, flavor, resource, roletracker.GetRole(tracker)}, customLabelValues...)
+	ClusterQueueResourceAvailableQuota.WithLabelValues(labels...).Set(available)
+	ClusterQueueResourceBorrowableQuota.WithLabelValues(labels...).Set(borrowable)
+}
+
 func ReportCohortSubtreeQuota(
 	cohort kueue.CohortReference,
 	flavor kueue.ResourceFlavorReference,
@@ -1322,6 +1473,15 @@ func ReportClusterQueueWeightedShare(cq kueue.ClusterQueueReference, cohort kueu
 	ClusterQueueWeightedShare.WithLabelValues(labels...).Set(weightedShare)
 }
 
+func ReportClusterQueueCohortUsageShare(cq kueue.ClusterQueueReference, cohort kueue.CohortReference, share float64, customLabelValues []string, tracker *roletracker.RoleTracker) {
+	labels := append([]string{string(cq), string(cohort), roletracker.GetRole(tracker)}, customLabelValues...)
+	ClusterQueueCohortUsageShare.WithLabelValues(labels...).Set(share)
+}
+
+func ClearClusterQueueCohortUsageShare(cq kueue.ClusterQueueReference) {
+	ClusterQueueCohortUsageShare.DeletePartialMatch(prometheus.Labels{"cluster_queue": string(cq)})
+}
+
 func ReportCohortWeightedShare(cohort kueue.CohortReference, weightedShare float64, customLabelValues []string, tracker *roletracker.RoleTracker) {
 	labels := append([]string{string(cohort), roletracker.GetRole(tracker)}, customLabelValues...)
 	CohortWeightedShare.WithLabelValues(labels...).Set(weightedShare)
@@ -1399,6 +1559,8 @@ func ClearClusterQueueResourceQuotas(cqName, flavor, resource string) {
 	ClusterQueueResourceNominalQuota.DeletePartialMatch(lbls)
 	ClusterQueueResourceBorrowingLimit.DeletePartialMatch(lbls)
 	ClusterQueueResourceLendingLimit.DeletePartialMatch(lbls)
+	ClusterQueueResourceAvailableQuota.DeletePartialMatch(lbls)
+	ClusterQueueResourceBorrowableQuota.DeletePartialMatch(lbls)
 }
 
 func ClearClusterQueueResourceUsage(cqName, flavor, resource string) {
@@ -1433,6 +1595,7 @@ func Register() {
 		AdmissionAttemptsTotal,
 		admissionAttemptDuration,
 		MultiKueueWorkloadsDispatchedTotal,
+		CacheDriftsDetectedTotal,
 		AdmissionCyclePreemptionSkips,
 		PendingWorkloads,
 		FinishedWorkloads,
@@ -1448,6 +1611,7 @@ func Register() {
 		EvictedWorkloadsTotal,
 		EvictedWorkloadsOnceTotal,
 		PreemptedWorkloadsTotal,
+		PreemptionsTotal,
 		WorkloadEvictionLatencySeconds,
 		ReservingActiveWorkloads,
 		AdmittedActiveWorkloads,
@@ -1458,7 +1622,10 @@ func Register() {
 		ClusterQueueResourceNominalQuota,
 		ClusterQueueResourceBorrowingLimit,
 		ClusterQueueResourceLendingLimit,
+		ClusterQueueResourceAvailableQuota,
+		ClusterQueueResourceBorrowableQuota,
 		ClusterQueueWeightedShare,
+		ClusterQueueCohortUsageShare,
 		ClusterQueueInfo,
 		CohortInfo,
 		CohortWeightedShare,
@@ -88,6 +88,12 @@ func TestReportAndCleanupClusterQueueMetrics(t *testing.T) {
 	expectFilteredMetricsCount(t, ClusterQueueResourceReservations, 2, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 2, "cluster_queue", "queue")
 
+	ReportClusterQueueAvailableQuota("cohort", "queue", "flavor", "res", 8, 3, nil, nil)
+	ReportClusterQueueAvailableQuota("cohort", "queue", "flavor2", "res", 2, 1, nil, nil)
+
+	expectFilteredMetricsCount(t, ClusterQueueResourceAvailableQuota, 2, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueResourceBorrowableQuota, 2, "cluster_queue", "queue")
+
 	ClearClusterQueueResourceMetrics("queue")
 
 	expectFilteredMetricsCount(t, ClusterQueueResourceNominalQuota, 0, "cluster_queue", "queue")
@@ -95,6 +101,8 @@ func TestReportAndCleanupClusterQueueMetrics(t *testing.T) {
 	expectFilteredMetricsCount(t, ClusterQueueResourceLendingLimit, 0, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceReservations, 0, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 0, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueResourceAvailableQuota, 0, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueResourceBorrowableQuota, 0, "cluster_queue", "queue")
 }
 
 func TestReportAndCleanupClusterQueueQuotas(t *testing.T) {
@@ -284,6 +292,20 @@ func TestReportAndCleanupClusterQueuePreemptedNumber(t *testing.T) {
 	expectFilteredMetricsCount(t, PreemptedWorkloadsTotal, 0, "preempting_cluster_queue", "cluster_queue1")
 }
 
+func TestReportAndCleanupClusterQueuePreemptionAttempts(t *testing.T) {
+	ReportPreemptionAttempt("cluster_queue1", PreemptionOutcomeSucceeded, nil, nil)
+	ReportPreemptionAttempt("cluster_queue1", PreemptionOutcomeNoCandidates, nil, nil)
+	ReportPreemptionAttempt("cluster_queue1", PreemptionOutcomeSkipped, nil, nil)
+
+	expectFilteredMetricsCount(t, PreemptionsTotal, 3, "cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, PreemptionsTotal, 1, "cluster_queue", "cluster_queue1", "outcome", "succeeded")
+	expectFilteredMetricsCount(t, PreemptionsTotal, 1, "cluster_queue", "cluster_queue1", "outcome", "no-candidates")
+	expectFilteredMetricsCount(t, PreemptionsTotal, 1, "cluster_queue", "cluster_queue1", "outcome", "skipped")
+
+	ClearClusterQueueMetrics("cluster_queue1")
+	expectFilteredMetricsCount(t, PreemptionsTotal, 0, "cluster_queue", "cluster_queue1")
+}
+
 func TestReportAndCleanupLocalQueueEvictedNumber(t *testing.T) {
 	lq := LocalQueueReference{Name: kueue.LocalQueueName("lq1"), Namespace: "ns1"}
 	ReportLocalQueueEvictedWorkloads(lq, "Preempted", "", "", nil, nil)
@@ -409,16 +431,19 @@ func TestClearClusterQueueMetricsOnLabelChangeOnlyClearsScopedGaugeMetrics(t *te
 
 	ReportPendingWorkloads(cqName, 3, 1, nil, nil)
 	ReportClusterQueueWeightedShare(cqName, "cohort", 7, nil, nil)
+	ReportClusterQueueCohortUsageShare(cqName, "cohort", 0.5, nil, nil)
 	ReportReplacedWorkloadSlices(cqName, nil, nil)
 
 	expectFilteredMetricsCount(t, PendingWorkloads, 2, "cluster_queue", cqName)
 	expectFilteredMetricsCount(t, ClusterQueueWeightedShare, 1, "cluster_queue", cqName)
+	expectFilteredMetricsCount(t, ClusterQueueCohortUsageShare, 1, "cluster_queue", cqName)
 	expectFilteredMetricsCount(t, ReplacedWorkloadSlicesTotal, 1, "cluster_queue", cqName)
 
 	ClearClusterQueueMetricsOnLabelChange(cqName)
 
 	expectFilteredMetricsCount(t, PendingWorkloads, 2, "cluster_queue", cqName)
 	expectFilteredMetricsCount(t, ClusterQueueWeightedShare, 0, "cluster_queue", cqName)
+	expectFilteredMetricsCount(t, ClusterQueueCohortUsageShare, 0, "cluster_queue", cqName)
 	expectFilteredMetricsCount(t, ReplacedWorkloadSlicesTotal, 0, "cluster_queue", cqName)
 
 	ClearClusterQueueMetrics(cqName)
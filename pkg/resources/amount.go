@@ -139,6 +139,17 @@ func (a Amount) SubInt64(v int64) Amount {
 	return Amount{value: utilmath.SaturatingSub(a.value, v)}
 }
 
+// MulMilli returns a * milli / 1000, saturating to Unlimited on overflow.
+// Unlimited stays Unlimited.
+func (a Amount) MulMilli(milli int64) Amount {
+	if a.isUnlimited() {
+		return a
+	}
+	whole := utilmath.SaturatingMul(a.value/1000, milli)
+	frac := utilmath.SaturatingMul(a.value%1000, milli) / 1000
+	return Amount{value: utilmath.SaturatingAdd(whole, frac)}
+}
+
 // Cmp returns -1 / 0 / +1 like bytes.Compare. Two Unlimited values compare
 // equal; Unlimited is greater than any bounded value.
 func (a Amount) Cmp(b Amount) int {
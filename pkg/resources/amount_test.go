@@ -177,6 +177,30 @@ func TestAmountArithmetic(t *testing.T) {
 				}
 			},
 		},
+		"MulMilli scales bounded amounts": {
+			run: func(t *testing.T) {
+				got := NewAmount(2500).MulMilli(1500)
+				if got.Int64() != 3750 {
+					t.Errorf("got %d, want 3750", got.Int64())
+				}
+			},
+		},
+		"MulMilli saturates on overflow": {
+			run: func(t *testing.T) {
+				got := NewAmount(math.MaxInt64 - 1).MulMilli(1500)
+				if !got.isUnlimited() {
+					t.Errorf("overflowing MulMilli should become Unlimited, got %v", got)
+				}
+			},
+		},
+		"MulMilli of Unlimited stays Unlimited": {
+			run: func(t *testing.T) {
+				got := Unlimited.MulMilli(500)
+				if !got.isUnlimited() {
+					t.Errorf("Unlimited.MulMilli should stay Unlimited, got %v", got)
+				}
+			},
+		},
 		"AddInt64 saturates": {
 			run: func(t *testing.T) {
 				got := NewAmount(math.MaxInt64 - 1).AddInt64(100)
@@ -0,0 +1,128 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"cmp"
+	"slices"
+
+	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
+	"sigs.k8s.io/kueue/pkg/features"
+)
+
+// independentEntryGroups reorders the entries so that the entries which can
+// affect the admission of each other are contiguous, and returns them split
+// into such groups. The returned groups share the backing array of entries.
+//
+// Entries interact when their ClusterQueues belong to the same cohort tree,
+// as they share quota, or when they use the same Topology Aware Scheduling
+// flavor, as they share the topology snapshot of the flavor. All the entries
+// interact when ConcurrentAdmission or TASColocateWithWorkload is enabled.
+func independentEntryGroups(entries []entry, snapshot *schdcache.Snapshot) [][]entry {
+	if len(entries) == 0 {
+		return nil
+	}
+	if features.Enabled(features.ConcurrentAdmission) {
+		// The variants of a workload can target ClusterQueues in different
+		// cohorts, so they can't be processed independently.
+		return [][]entry{entries}
+	}
+	if features.Enabled(features.TASColocateWithWorkload) {
+		// The topology assignment of a workload colocated with another workload
+		// looks up the workloads admitted in all the ClusterQueues of the
+		// snapshot, which are updated by the admissions of the other groups.
+		return [][]entry{entries}
+	}
+	crossFlavorTAS := features.Enabled(features.TASHandleOverlappingFlavors)
+	sets := newDisjointSets()
+	keys := make([]string, len(entries))
+	for i := range entries {
+		cq := snapshot.ClusterQueue(entries[i].ClusterQueue)
+		if cq.HasParent() {
+			keys[i] = "cohort/" + string(cq.Parent().Root().GetName())
+		} else {
+			keys[i] = "clusterQueue/" + string(cq.Name)
+		}
+		for tasFlavor := range cq.TASFlavors {
+			if crossFlavorTAS {
+				sets.union(keys[i], "tas")
+			} else {
+				sets.union(keys[i], "tas/"+string(tasFlavor))
+			}
+		}
+	}
+
+	groupIndex := make(map[string]int)
+	groupOf := make([]int, len(entries))
+	for i := range entries {
+		root := sets.find(keys[i])
+		idx, found := groupIndex[root]
+		if !found {
+			idx = len(groupIndex)
+			groupIndex[root] = idx
+		}
+		groupOf[i] = idx
+	}
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(groupOf[a], groupOf[b])
+	})
+	sorted := make([]entry, 0, len(entries))
+	for _, i := range order {
+		sorted = append(sorted, entries[i])
+	}
+	copy(entries, sorted)
+	slices.Sort(groupOf)
+
+	groups := make([][]entry, 0, len(groupIndex))
+	start := 0
+	for i := 1; i <= len(entries); i++ {
+		if i == len(entries) || groupOf[i] != groupOf[start] {
+			groups = append(groups, entries[start:i])
+			start = i
+		}
+	}
+	return groups
+}
+
+// disjointSets is a union-find structure over string keys.
+type disjointSets map[string]string
+
+func newDisjointSets() disjointSets {
+	return make(disjointSets)
+}
+
+func (d disjointSets) find(key string) string {
+	parent, found := d[key]
+	if !found || parent == key {
+		d[key] = key
+		return key
+	}
+	root := d.find(parent)
+	d[key] = root
+	return root
+}
+
+func (d disjointSets) union(a, b string) {
+	rootA, rootB := d.find(a), d.find(b)
+	if rootA != rootB {
+		d[rootB] = rootA
+	}
+}
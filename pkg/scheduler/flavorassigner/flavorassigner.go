@@ -26,7 +26,9 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
 	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
@@ -42,6 +44,7 @@ import (
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 	"sigs.k8s.io/kueue/pkg/util/orderedgroups"
 	"sigs.k8s.io/kueue/pkg/util/podset"
+	"sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/util/tas"
 	"sigs.k8s.io/kueue/pkg/workload"
 	"sigs.k8s.io/kueue/pkg/workload/concurrentadmission"
@@ -77,6 +80,14 @@ type Assignment struct {
 	// quotaCheckStrategy is the strategy to use for quota check.
 	quotaCheckStrategy configapi.QuotaCheckStrategy
 
+	// resourceFlavors are used to scale the usage of the resources by the
+	// resourceScaling of their assigned flavors.
+	resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
+
+	// unconstrainedTopologyFallback identifies the PodSets for which the topology
+	// request is ignored, so that they get admitted without a topology assignment.
+	unconstrainedTopologyFallback sets.Set[kueue.PodSetReference]
+
 	// NoFitReason contains the reason why the overall assignment failed with NoFit.
 	NoFitReason string
 }
@@ -112,6 +123,7 @@ func (a *Assignment) ComputeTASNetUsage(log logr.Logger, cq *schdcache.ClusterQu
 				continue
 			}
 			singlePodRequests := resources.NewRequestsFromPodSpec(&podSet.Template.Spec)
+			workload.ApplyNodeShareRequests(singlePodRequests, podSet, psa.Requests, psa.Count)
 			if _, ok := result[*tasFlavor]; !ok {
 				result[*tasFlavor] = make(workload.TASFlavorUsage, 0)
 			}
@@ -223,7 +235,16 @@ func (a *Assignment) TotalRequestsFor(log logr.Logger, wl *workload.Info) resour
 		}
 		ps = *ps.ScaledTo(newCount)
 
-		for res, q := range ps.Requests {
+		requests := ps.Requests
+		_, nodeShare := workload.NodeShare(&wl.Obj.Spec.PodSets[i])
+		if nodeShare {
+			// The requests were resolved against the nodeShape of the assigned
+			// flavors, and scaled by their resourceScaling.
+			requests = resources.NewRequests(a.PodSets[i].Requests)
+			delete(requests, corev1.ResourcePods)
+		}
+
+		for res, q := range requests {
 			// zero-quantity request may have no flavor (#8079), and is irrelevant for
 			// later calculations
 			if q == 0 {
@@ -234,6 +255,9 @@ func (a *Assignment) TotalRequestsFor(log logr.Logger, wl *workload.Info) resour
 				continue
 			}
 			flv := a.PodSets[i].Flavors[res].Name
+			if _, preassigned := ps.Flavors[res]; !preassigned && !nodeShare {
+				q = scaledUsage(a.resourceFlavors[flv], res, q)
+			}
 			usage[resources.FlavorResource{Flavor: flv, Resource: res}] = usage[resources.FlavorResource{Flavor: flv, Resource: res}].AddInt64(q)
 		}
 	}
@@ -590,6 +614,31 @@ type FlavorAssigner struct {
 	// non-sliced workloads.
 	replaceWorkloadSlice *workload.Info
 	quotaCheckStrategy   configapi.QuotaCheckStrategy
+
+	// unconstrainedTopologyFallback identifies the PodSets which should be
+	// assigned flavors without a topology assignment.
+	unconstrainedTopologyFallback sets.Set[kueue.PodSetReference]
+
+	// namespaceLabels are the labels of the workload's namespace, matched
+	// against the namespaceSelector of the flavors.
+	namespaceLabels labels.Set
+
+	// nodeShares are the requests of the PodSets with the node-share
+	// annotation, by the index of the PodSet.
+	nodeShares map[int]*nodeShareRequest
+}
+
+// nodeShareRequest is the request of a PodSet for a share of a node of the
+// assigned flavor.
+type nodeShareRequest struct {
+	// share is the percentage of a node requested by each pod.
+	share int64
+	count int32
+	// requests are the requests of the PodSet which is being assigned.
+	requests resources.Requests
+	// placeholders are the resources of the nodeShapes of the flavors which
+	// are not requested by the pods.
+	placeholders sets.Set[corev1.ResourceName]
 }
 
 func New(
@@ -612,6 +661,21 @@ func New(
 	}
 }
 
+// WithNamespaceLabels sets the labels of the workload's namespace, which are
+// matched against the namespaceSelector of the flavors.
+func (a *FlavorAssigner) WithNamespaceLabels(nsLabels labels.Set) *FlavorAssigner {
+	a.namespaceLabels = nsLabels
+	return a
+}
+
+// WithUnconstrainedTopologyFallback makes the subsequent assignments ignore the
+// topology requests of the given PodSets, so that they are admitted without a
+// topology assignment.
+func (a *FlavorAssigner) WithUnconstrainedTopologyFallback(podSets sets.Set[kueue.PodSetReference]) *FlavorAssigner {
+	a.unconstrainedTopologyFallback = podSets
+	return a
+}
+
 func lastAssignmentOutdated(wl *workload.Info, cq *schdcache.ClusterQueueSnapshot) bool {
 	return cq.AllocatableResourceGeneration > wl.LastAssignment.ClusterQueueGeneration
 }
@@ -641,6 +705,7 @@ type indexedPodSet struct {
 }
 
 func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignment {
+	a.nodeShares = make(map[int]*nodeShareRequest)
 	requests := make([]workload.PodSetResources, len(a.wl.TotalRequests))
 	if len(counts) == 0 {
 		for i, ps := range a.wl.TotalRequests {
@@ -655,6 +720,7 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 	assignment := Assignment{
 		PodSets:            make([]PodSetAssignment, 0, len(requests)),
 		quotaCheckStrategy: a.quotaCheckStrategy,
+		resourceFlavors:    a.resourceFlavors,
 		Usage: workload.Usage{
 			Quota: make(resources.FlavorResourceQuantities),
 		},
@@ -662,7 +728,8 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 			LastTriedFlavorIdx:     make([]map[corev1.ResourceName]int, 0, len(requests)),
 			ClusterQueueGeneration: a.cq.AllocatableResourceGeneration,
 		},
-		replaceWorkloadSlice: a.replaceWorkloadSlice,
+		replaceWorkloadSlice:          a.replaceWorkloadSlice,
+		unconstrainedTopologyFallback: a.unconstrainedTopologyFallback,
 	}
 
 	groupedRequests := orderedgroups.NewOrderedGroups[string, indexedPodSet]()
@@ -671,6 +738,9 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 		if a.cq.RGByResource(corev1.ResourcePods) != nil {
 			podSet.Requests[corev1.ResourcePods] = int64(podSet.Count)
 		}
+		if share, ok := workload.NodeShare(&a.wl.Obj.Spec.PodSets[i]); ok && len(podSet.Flavors) == 0 {
+			a.addNodeShareRequest(i, share, &podSet)
+		}
 
 		psAssignment := PodSetAssignment{
 			Name:     podSet.Name,
@@ -752,6 +822,16 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 			}
 		}
 
+		if len(groupFlavors) == 0 && groupStatus.IsFit() && a.requestsTASPlacementWithoutQuota(podSets) {
+			if flavors := a.findTASFlavorForPlacement(log, psIDs); flavors != nil {
+				groupFlavors = flavors
+				for _, ips := range podSets {
+					ips.podSet.Requests[corev1.ResourcePods] = 0
+					ips.podSetAssignment.Requests[corev1.ResourcePods] = *resource.NewQuantity(0, resource.DecimalSI)
+				}
+			}
+		}
+
 		finalConsidered := finalizeFlavorAssignmentAttempts(consideredFlavors)
 		atLeastOnePodsAssignmentFailed := false
 		for _, podSet := range podSets {
@@ -760,6 +840,8 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 			podSet.podSetAssignment.Flavors = podSetFlavors
 			podSet.podSetAssignment.Status = groupStatus
 			podSet.podSetAssignment.FlavorAssignmentAttempts = finalConsidered
+			a.resolveNodeShare(podSet)
+			a.scaleRequests(podSet)
 
 			assignment.append(podSet.podSet.Requests, podSet.podSetAssignment)
 			if podSet.podSetAssignment.Status.IsError() || (len(podSet.podSet.Requests) > 0 && len(podSet.podSetAssignment.Flavors) == 0) {
@@ -923,6 +1005,164 @@ func (a *Assignment) findOldPodSetRequest(psName kueue.PodSetReference, resource
 	return 0
 }
 
+// addNodeShareRequest records the node-share request of the PodSet. The
+// resources of the nodeShapes of the flavors, which the pods don't request,
+// are added to the requests of the PodSet as zero-quantity placeholders, so
+// that they get a flavor assigned.
+func (a *FlavorAssigner) addNodeShareRequest(psID int, share int64, podSet *workload.PodSetResources) {
+	ns := &nodeShareRequest{
+		share:        share,
+		count:        podSet.Count,
+		requests:     podSet.Requests,
+		placeholders: sets.New[corev1.ResourceName](),
+	}
+	for _, rg := range a.cq.ResourceGroups {
+		for _, fName := range rg.Flavors {
+			flavor, found := a.resourceFlavors[fName]
+			if !found {
+				continue
+			}
+			for res := range flavor.Spec.NodeShape {
+				if res == corev1.ResourcePods || !rg.CoveredResources.Has(res) {
+					continue
+				}
+				if _, requested := podSet.Requests[res]; !requested {
+					podSet.Requests[res] = 0
+					ns.placeholders.Insert(res)
+				}
+			}
+		}
+	}
+	a.nodeShares[psID] = ns
+}
+
+// nodeShareUsage returns the request of the resource by the PodSets, with the
+// requests of the PodSets with the node-share annotation resolved against the
+// nodeShape of the flavor.
+func (a *FlavorAssigner) nodeShareUsage(fName kueue.ResourceFlavorReference, res corev1.ResourceName, psIDs []int, request int64) int64 {
+	for _, psID := range psIDs {
+		ns, found := a.nodeShares[psID]
+		if !found {
+			continue
+		}
+		if shared, found := nodeShareOf(a.resourceFlavors[fName], res, ns.share); found {
+			request += shared*int64(ns.count) - ns.requests[res]
+		}
+	}
+	return request
+}
+
+// resolveNodeShare sets the requests of the PodSet with the node-share
+// annotation to the share of the nodeShape of their assigned flavors, and
+// drops the placeholders which remain unrequested.
+func (a *FlavorAssigner) resolveNodeShare(ips indexedPodSet) {
+	ns, found := a.nodeShares[ips.originalIndex]
+	if !found {
+		return
+	}
+	for res, flvAssignment := range ips.podSetAssignment.Flavors {
+		if shared, found := nodeShareOf(a.resourceFlavors[flvAssignment.Name], res, ns.share); found {
+			ips.podSet.Requests[res] = shared * int64(ns.count)
+			ips.podSetAssignment.Requests[res] = resources.ResourceQuantity(res, ips.podSet.Requests[res])
+		}
+	}
+	for res := range ns.placeholders {
+		if ips.podSet.Requests[res] == 0 {
+			delete(ips.podSet.Requests, res)
+			delete(ips.podSetAssignment.Requests, res)
+			delete(ips.podSetAssignment.Flavors, res)
+		}
+	}
+}
+
+// nodeShareOf returns the share, in percent, of the resource in the nodeShape
+// of the flavor, and true if the nodeShape defines the resource.
+func nodeShareOf(flavor *kueue.ResourceFlavor, res corev1.ResourceName, share int64) (int64, bool) {
+	if flavor == nil || res == corev1.ResourcePods {
+		return 0, false
+	}
+	q, found := flavor.Spec.NodeShape[res]
+	if !found {
+		return 0, false
+	}
+	return resources.ResourceValue(res, q) * share / 100, true
+}
+
+// scaleRequests scales the requests of the PodSet by the resourceScaling of
+// their assigned flavors, so that the PodSet uses the scaled quota. The
+// requests which were assigned a flavor before come from the admission of the
+// workload, and are already scaled.
+func (a *FlavorAssigner) scaleRequests(ips indexedPodSet) {
+	for res, flvAssignment := range ips.podSetAssignment.Flavors {
+		if _, preassigned := ips.podSet.Flavors[res]; preassigned {
+			continue
+		}
+		request := ips.podSet.Requests[res]
+		if scaled := scaledUsage(a.resourceFlavors[flvAssignment.Name], res, request); scaled != request {
+			ips.podSet.Requests[res] = scaled
+			ips.podSetAssignment.Requests[res] = resources.ResourceQuantity(res, scaled)
+		}
+	}
+}
+
+// scaledUsage returns the quota of the flavor used by the request of the
+// resource, that is, the request divided by the resourceScaling of the flavor
+// for the resource, rounded up.
+func scaledUsage(flavor *kueue.ResourceFlavor, res corev1.ResourceName, request int64) int64 {
+	if flavor == nil || !features.Enabled(features.ResourceFlavorResourceScaling) {
+		return request
+	}
+	factor := int64(flavor.Spec.ResourceScaling[res])
+	if factor <= 1 {
+		return request
+	}
+	return (request + factor - 1) / factor
+}
+
+// requestsTASPlacementWithoutQuota returns whether the PodSets request TAS, but
+// don't request any resources covered by the ClusterQueue.
+func (a *FlavorAssigner) requestsTASPlacementWithoutQuota(podSets []indexedPodSet) bool {
+	if !features.Enabled(features.TopologyAwareScheduling) || !features.Enabled(features.TASPlacementWithoutRequests) {
+		return false
+	}
+	for _, ips := range podSets {
+		if ips.podSet.Count == 0 || !isTASRequested(&a.wl.Obj.Spec.PodSets[ips.originalIndex], a.cq) {
+			return false
+		}
+		for resName := range ips.podSet.Requests {
+			if a.cq.RGByResource(resName) != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// findTASFlavorForPlacement returns the first TAS flavor of the ClusterQueue
+// that matches the PodSets, assigned to the pods resource. The PodSets don't
+// consume quota from the flavor, which is only used to compute their
+// TopologyAssignment.
+func (a *FlavorAssigner) findTASFlavorForPlacement(log logr.Logger, psIDs []int) ResourceAssignment {
+	podSets := make([]*kueue.PodSet, len(psIDs))
+	for idx, psID := range psIDs {
+		podSets[idx] = &a.wl.Obj.Spec.PodSets[psID]
+	}
+	for i := range a.cq.ResourceGroups {
+		resourceGroup := &a.cq.ResourceGroups[i]
+		for _, fName := range resourceGroup.Flavors {
+			if a.cq.TASFlavors[fName] == nil {
+				continue
+			}
+			if flavorStatus := a.checkFlavorForPodSets(log, fName, psIDs, podSets, resourceGroup); !flavorStatus.IsFit() {
+				continue
+			}
+			log.V(3).Info("Assigning TAS flavor to PodSets without resource requests", "flavor", fName)
+			return ResourceAssignment{corev1.ResourcePods: &FlavorAssignment{Name: fName, Mode: Fit}}
+		}
+	}
+	return nil
+}
+
 // findFlavorForPodSets finds the flavor which can satisfy all the PodSet requests
 // for all resources in the same group as resName.
 // Returns the chosen flavor, along with the information about resources that need to be borrowed
@@ -953,6 +1193,15 @@ func (a *FlavorAssigner) findFlavorForPodSets(
 	bestAssignmentMode := worstGranularMode()
 	consideredFlavors := newFlavorAssignmentAttempts(len(resourceGroup.Flavors))
 
+	// When the ClusterQueue configures the flavorTieBreak, the search goes on
+	// after the first flavor which fits, to choose the flavor with the
+	// preferred headroom among the flavors which fit equally well.
+	tieBreak := a.cq.FlavorTieBreak()
+	tieBreaking := false
+	var bestHeadroom float64
+
+	failedFlavors := a.admissionCheckFailedFlavors(resourceGroup)
+
 	// We will only check against the flavors' labels for the resource.
 	attemptedFlavorIdx := -1
 	idx := a.wl.LastAssignment.NextFlavorToTryForPodSetResource(psIDs[0], resName)
@@ -967,6 +1216,10 @@ func (a *FlavorAssigner) findFlavorForPodSets(
 			status.appendf("skipping flavor %s due to WorkloadAllowedResourceFlavorAnnotation annotation", fName)
 			continue
 		}
+		if failedFlavors.Has(fName) {
+			status.appendf("skipping flavor %s as an admission check failed for it", fName)
+			continue
+		}
 
 		if flavorStatus := a.checkFlavorForPodSets(log, fName, psIDs, podSets, resourceGroup); !flavorStatus.IsFit() {
 			flavorStatus.noFitReason = kueue.WorkloadQuotaReservedReasonNoMatchingFlavor
@@ -983,10 +1236,14 @@ func (a *FlavorAssigner) findFlavorForPodSets(
 		// Calculate representativeMode for this assignment as the worst mode among all requests.
 		representativeMode := bestGranularMode()
 		maxBorrow := 0
+		flavorHeadroom := 1.0
 		var flavorQuotaReasons []string
 		var flavorNoFitReason string
 
 		for rName, val := range requests {
+			val = a.nodeShareUsage(fName, rName, psIDs, val)
+			val = scaledUsage(a.resourceFlavors[fName], rName, val)
+
 			// Ensure the same resource flavor is used for the workload slice as in the original admitted slice.
 			if features.Enabled(features.ElasticJobsViaWorkloadSlices) && a.replaceWorkloadSlice != nil {
 				for _, psID := range psIDs {
@@ -1019,8 +1276,11 @@ func (a *FlavorAssigner) findFlavorForPodSets(
 				flavorNoFitReason = mostSevereReason(flavorNoFitReason, s.noFitReason)
 			}
 			maxBorrow = max(maxBorrow, borrow)
+			if preemptionMode == fit {
+				flavorHeadroom = min(flavorHeadroom, a.headroom(fr, assignmentUsage[fr], val))
+			}
 			mode := granularMode{preemptionMode, borrowingLevel(borrow)}
-			if isPreferred(representativeMode, mode, a.cq.FlavorFungibility) {
+			if a.isPreferred(representativeMode, mode) {
 				representativeMode = mode
 			}
 			if representativeMode.preemptionMode == noFit {
@@ -1038,12 +1298,24 @@ func (a *FlavorAssigner) findFlavorForPodSets(
 		consideredFlavors.AddRepresentativeModeFlavorAttempt(fName, representativeMode.preemptionMode, maxBorrow, flavorQuotaReasons, flavorNoFitReason)
 
 		if features.Enabled(features.FlavorFungibility) {
-			if !shouldTryNextFlavor(representativeMode, a.cq.FlavorFungibility) {
+			if tieBreaking {
+				if representativeMode == bestAssignmentMode && prefersHeadroom(tieBreak, flavorHeadroom, bestHeadroom) {
+					bestAssignment = assignments
+					bestHeadroom = flavorHeadroom
+				}
+				continue
+			}
+			if !a.shouldTryNextFlavor(representativeMode) {
 				bestAssignment = assignments
 				bestAssignmentMode = representativeMode
-				break
+				if tieBreak == kueue.FlavorTieBreakNone || representativeMode.preemptionMode != fit {
+					break
+				}
+				tieBreaking = true
+				bestHeadroom = flavorHeadroom
+				continue
 			}
-			if isPreferred(representativeMode, bestAssignmentMode, a.cq.FlavorFungibility) {
+			if a.isPreferred(representativeMode, bestAssignmentMode) {
 				bestAssignment = assignments
 				bestAssignmentMode = representativeMode
 			}
@@ -1073,6 +1345,44 @@ func (a *FlavorAssigner) findFlavorForPodSets(
 	return bestAssignment, status, consideredFlavors
 }
 
+// headroom returns the fraction of the quota available to the ClusterQueue
+// for the flavor and resource which remains unused after assigning the
+// request.
+func (a *FlavorAssigner) headroom(fr resources.FlavorResource, assumedUsage resources.Amount, requestUsage int64) float64 {
+	maxCapacity := a.cq.PotentialAvailable(fr)
+	if maxCapacity == resources.Unlimited || maxCapacity.CmpInt64(0) <= 0 {
+		return 1
+	}
+	remaining := a.cq.Available(fr).Sub(assumedUsage.AddInt64(requestUsage))
+	return float64(remaining.Int64()) / float64(maxCapacity.Int64())
+}
+
+// prefersHeadroom returns true if the headroom x is preferred over y by the
+// flavorTieBreak policy. Equal headrooms keep the order of the flavors.
+func prefersHeadroom(policy kueue.FlavorTieBreakPolicy, x, y float64) bool {
+	switch policy {
+	case kueue.FlavorTieBreakMostHeadroom:
+		return x > y
+	case kueue.FlavorTieBreakLeastHeadroom:
+		return x < y
+	}
+	return false
+}
+
+// admissionCheckFailedFlavors returns the flavors of the resource group for
+// which the admission checks of the workload failed. When the checks failed for
+// all the flavors of the resource group, none of them are skipped.
+func (a *FlavorAssigner) admissionCheckFailedFlavors(rg *schdcache.ResourceGroup) sets.Set[kueue.ResourceFlavorReference] {
+	if !features.Enabled(features.RetryAdmissionCheckOnDifferentFlavor) {
+		return nil
+	}
+	failed := workload.FailedFlavors(a.wl.Obj)
+	if failed.HasAll(rg.Flavors...) {
+		return nil
+	}
+	return failed
+}
+
 func (a *FlavorAssigner) checkFlavorForPodSets(
 	log logr.Logger,
 	flavorName kueue.ResourceFlavorReference,
@@ -1089,12 +1399,33 @@ func (a *FlavorAssigner) checkFlavorForPodSets(
 		return status
 	}
 
+	if features.Enabled(features.ResourceFlavorStopPolicy) && ptr.Deref(flavor.Spec.StopPolicy, kueue.None) != kueue.None {
+		status.appendf("flavor %s is stopped", flavorName)
+		return status
+	}
+
+	if features.Enabled(features.ResourceFlavorNamespaceSelector) && flavor.Spec.NamespaceSelector != nil {
+		nsSelector, err := metav1.LabelSelectorAsSelector(flavor.Spec.NamespaceSelector)
+		if err != nil {
+			status.err = err
+			return status
+		}
+		if !nsSelector.Matches(a.namespaceLabels) {
+			status.appendf("flavor %s doesn't match the namespace %s", flavorName, a.wl.Obj.Namespace)
+			return status
+		}
+	}
+
 	// Use only this flavor's own label keys (not the union across all flavors in
 	// the resource group) so that affinity terms referencing keys from other
 	// flavors are correctly ignored when evaluating this flavor.
 	flavorLabelKeys := sets.KeySet(flavor.Spec.NodeLabels)
 
 	for psIdx, psID := range psIDs {
+		if _, found := a.nodeShares[psID]; found && len(flavor.Spec.NodeShape) == 0 {
+			status.appendf("flavor %s doesn't define a nodeShape", flavorName)
+			return status
+		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			ps := &a.wl.Obj.Spec.PodSets[psID]
 			if message := checkPodSetAndFlavorMatchForTAS(a.cq, ps, flavor, rg); message != nil {
@@ -1124,6 +1455,52 @@ func (a *FlavorAssigner) checkFlavorForPodSets(
 	return status
 }
 
+// isPreferred returns true if mode x is better than y. When the ClusterQueue
+// configures the preemption stages, the modes requiring preemption are ordered
+// by their stages.
+func (a *FlavorAssigner) isPreferred(x, y granularMode) bool {
+	if stages := preemptioncommon.PreemptionStages(a.cq.Preemption); len(stages) > 0 && x.isPreemptMode() && y.isPreemptMode() {
+		if xStage, yStage := preemptionStageIndex(x, stages), preemptionStageIndex(y, stages); xStage != yStage {
+			return xStage < yStage
+		}
+	}
+	return isPreferred(x, y, a.cq.FlavorFungibility)
+}
+
+// shouldTryNextFlavor returns true if the next flavor should be tried for the
+// representativeMode. When the ClusterQueue configures the preemption stages,
+// the next flavor is tried before preempting only if the FlavorDowngrade stage
+// comes before the stage of the preemption. When the ClusterQueue preempts in
+// the DryRun mode, the next flavor is always tried before preempting, as the
+// preemption targets aren't evicted.
+func (a *FlavorAssigner) shouldTryNextFlavor(representativeMode granularMode) bool {
+	if representativeMode.isPreemptMode() && preemptioncommon.IsDryRun(a.cq.Preemption) {
+		return true
+	}
+	stages := preemptioncommon.PreemptionStages(a.cq.Preemption)
+	if len(stages) == 0 || !representativeMode.isPreemptMode() {
+		return shouldTryNextFlavor(representativeMode, a.cq.FlavorFungibility)
+	}
+	downgradeStage := slices.Index(stages, kueue.PreemptionStageFlavorDowngrade)
+	if downgradeStage != -1 && downgradeStage < preemptionStageIndex(representativeMode, stages) {
+		return true
+	}
+	return !representativeMode.borrowingLevel.optimal() && a.cq.FlavorFungibility.WhenCanBorrow == kueue.TryNextFlavor
+}
+
+// preemptionStageIndex returns the index of the preemption stage of the mode
+// in stages, or len(stages) if the stage isn't configured.
+func preemptionStageIndex(mode granularMode, stages []kueue.PreemptionStage) int {
+	stage := kueue.PreemptionStageWithinClusterQueue
+	if mode.preemptionMode == reclaim {
+		stage = kueue.PreemptionStageReclaimWithinCohort
+	}
+	if idx := slices.Index(stages, stage); idx != -1 {
+		return idx
+	}
+	return len(stages)
+}
+
 func shouldTryNextFlavor(representativeMode granularMode, flavorFungibility kueue.FlavorFungibility) bool {
 	policyPreempt := flavorFungibility.WhenCanPreempt
 	policyBorrow := flavorFungibility.WhenCanBorrow
@@ -1226,6 +1603,15 @@ func (a *FlavorAssigner) fitsResourceQuota(
 	}
 
 	borrow, mayReclaimInHierarchy := classical.FindHeightOfLowestSubtreeThatFits(a.cq, fr, val)
+	if features.Enabled(features.LocalQueueGuaranteedQuota) && val.Cmp(available) <= 0 {
+		// The quota guaranteed to the other LocalQueues is not reclaimed by
+		// preemptions, so the workload waits until it is released.
+		if reserved := a.cq.GuaranteedToOtherLocalQueues(queue.KeyFromWorkload(a.wl.Obj), fr); val.Add(reserved).Cmp(available) > 0 {
+			status.appendf("insufficient unused quota for %s in flavor %s, %s is guaranteed to other LocalQueues",
+				fr.Resource, fr.Flavor, resources.AmountQuantityString(fr.Resource, reserved))
+			return noFit, borrow, &status
+		}
+	}
 	// Fit
 	if val.Cmp(available) <= 0 {
 		return fit, borrow, nil
@@ -29,12 +29,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta2"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
+	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	preemptioncommon "sigs.k8s.io/kueue/pkg/scheduler/preemption/common"
@@ -206,6 +209,7 @@ func TestAssignFlavors(t *testing.T) {
 
 	cases := map[string]struct {
 		wlPods                     []kueue.PodSet
+		wlAnnotations              map[string]string
 		wlReclaimablePods          []kueue.ReclaimablePod
 		clusterQueue               kueue.ClusterQueue
 		clusterQueueUsage          resources.FlavorResourceQuantities
@@ -367,6 +371,83 @@ func TestAssignFlavors(t *testing.T) {
 				}},
 			},
 		},
+		"single flavor, ephemeral-storage and hugepages, fits": {
+			wlPods: []kueue.PodSet{
+				*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 2).
+					Request(corev1.ResourceCPU, "1").
+					Request(corev1.ResourceEphemeralStorage, "1Gi").
+					Request("hugepages-2Mi", "4Mi").
+					Obj(),
+			},
+			clusterQueue: *utiltestingapi.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "2").
+						Resource(corev1.ResourceEphemeralStorage, "2Gi").
+						Resource("hugepages-2Mi", "8Mi").
+						Obj(),
+				).Obj(),
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU:              {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+						corev1.ResourceEphemeralStorage: {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+						"hugepages-2Mi":                 {Name: "default", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("2"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+						"hugepages-2Mi":                 resource.MustParse("8Mi"),
+					},
+					Count:                    2,
+					FlavorAssignmentAttempts: []FlavorAssignmentAttempt{{Flavor: "default", Mode: Fit}},
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "default", Resource: corev1.ResourceCPU}:              resources.NewAmount(2_000),
+					{Flavor: "default", Resource: corev1.ResourceEphemeralStorage}: resources.NewAmount(2 * 1024 * utiltesting.Mi),
+					{Flavor: "default", Resource: "hugepages-2Mi"}:                 resources.NewAmount(8 * utiltesting.Mi),
+				}},
+			},
+		},
+		"single flavor, ephemeral-storage exceeds quota, doesn't fit": {
+			wlPods: []kueue.PodSet{
+				*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 3).
+					Request(corev1.ResourceCPU, "1").
+					Request(corev1.ResourceEphemeralStorage, "1Gi").
+					Obj(),
+			},
+			clusterQueue: *utiltestingapi.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "4").
+						Resource(corev1.ResourceEphemeralStorage, "2Gi").
+						Obj(),
+				).Obj(),
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("3"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("3Gi"),
+					},
+					Status: *NewStatus("insufficient quota for ephemeral-storage in flavor default, previously considered podsets requests (0) + current podset request (3Gi) > maximum capacity (2Gi)"),
+					FlavorAssignmentAttempts: []FlavorAssignmentAttempt{
+						{
+							Flavor:      "default",
+							Mode:        NoFit,
+							Reasons:     []string{"insufficient quota for ephemeral-storage in flavor default, previously considered podsets requests (0) + current podset request (3Gi) > maximum capacity (2Gi)"},
+							NoFitReason: "ExceedsMaxQuota",
+						},
+					},
+					Count: 3,
+				}},
+				Usage:       workload.Usage{Quota: resources.FlavorResourceQuantities{}},
+				NoFitReason: "ExceedsMaxQuota",
+			},
+		},
 		"multiple resource groups, fits": {
 			wlPods: []kueue.PodSet{
 				*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).
@@ -949,6 +1030,131 @@ func TestAssignFlavors(t *testing.T) {
 				}},
 			},
 		},
+		"multiple flavors, skips tainted flavor not matching the pod tolerations": {
+			wlPods: []kueue.PodSet{
+				*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Toleration(corev1.Toleration{
+						Key:      "instance",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "reserved",
+						Effect:   corev1.TaintEffectNoSchedule,
+					}).
+					Obj(),
+			},
+			clusterQueue: *utiltestingapi.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					*utiltestingapi.MakeFlavorQuotas("tainted").
+						Resource(corev1.ResourceCPU, "4").
+						Obj(),
+					*utiltestingapi.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						Obj(),
+				).Obj(),
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					FlavorAssignmentAttempts: []FlavorAssignmentAttempt{
+						{
+							Flavor:      "tainted",
+							Mode:        NoFit,
+							Reasons:     []string{"untolerated taint {instance spot NoSchedule <nil>} in flavor tainted"},
+							NoFitReason: "NoMatchingFlavor",
+						},
+						{Flavor: "two", Mode: Fit},
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: resources.NewAmount(3_000),
+				}},
+			},
+		},
+		"multiple flavors, skips flavor which failed an admission check": {
+			wlPods: []kueue.PodSet{
+				*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wlAnnotations: map[string]string{
+				controllerconstants.AdmissionCheckFailedFlavorsAnnotation: "one",
+			},
+			clusterQueue: *utiltestingapi.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					*utiltestingapi.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						Obj(),
+					*utiltestingapi.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						Obj(),
+				).Obj(),
+			featureGates: map[featuregate.Feature]bool{features.RetryAdmissionCheckOnDifferentFlavor: true},
+			wantRepMode:  Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit, TriedFlavorIdx: -1},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					FlavorAssignmentAttempts: []FlavorAssignmentAttempt{
+						{Flavor: "two", Mode: Fit},
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "two", Resource: corev1.ResourceCPU}: resources.NewAmount(3_000),
+				}},
+			},
+		},
+		"multiple flavors, retries all flavors when all of them failed an admission check": {
+			wlPods: []kueue.PodSet{
+				*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wlAnnotations: map[string]string{
+				controllerconstants.AdmissionCheckFailedFlavorsAnnotation: "one,two",
+			},
+			clusterQueue: *utiltestingapi.MakeClusterQueue("test-clusterqueue").
+				ResourceGroup(
+					*utiltestingapi.MakeFlavorQuotas("one").
+						Resource(corev1.ResourceCPU, "4").
+						Obj(),
+					*utiltestingapi.MakeFlavorQuotas("two").
+						Resource(corev1.ResourceCPU, "4").
+						Obj(),
+				).Obj(),
+			featureGates: map[featuregate.Feature]bool{features.RetryAdmissionCheckOnDifferentFlavor: true},
+			wantRepMode:  Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: kueue.DefaultPodSetName,
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "one", Mode: Fit, TriedFlavorIdx: 0},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3"),
+					},
+					FlavorAssignmentAttempts: []FlavorAssignmentAttempt{
+						{Flavor: "one", Mode: Fit},
+					},
+					Count: 1,
+				}},
+				Usage: workload.Usage{Quota: resources.FlavorResourceQuantities{
+					{Flavor: "one", Resource: corev1.ResourceCPU}: resources.NewAmount(3_000),
+				}},
+			},
+		},
 		"multiple flavors, fits a node selector": {
 			wlPods: []kueue.PodSet{
 				*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).
@@ -3583,6 +3789,9 @@ func TestAssignFlavors(t *testing.T) {
 					features.SetFeatureGateDuringTest(t, fg, val)
 				}
 				wlInfo := workload.NewInfo(&kueue.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: tc.wlAnnotations,
+					},
 					Spec: kueue.WorkloadSpec{
 						PodSets: tc.wlPods,
 					},
@@ -3681,6 +3890,8 @@ func TestReclaimBeforePriorityPreemption(t *testing.T) {
 		testClusterQueueUsage  resources.FlavorResourceQuantities
 		otherClusterQueueUsage resources.FlavorResourceQuantities
 		flavorFungibility      *kueue.FlavorFungibility
+		preemptionStages       []kueue.PreemptionStage
+		preemptionMode         kueue.PreemptionMode
 		wantMode               FlavorAssignmentMode
 		wantAssigment          rfMap
 		simulationResult       map[resources.FlavorResource]simulationResultForFlavor
@@ -3727,6 +3938,45 @@ func TestReclaimBeforePriorityPreemption(t *testing.T) {
 			wantMode:      Preempt,
 			wantAssigment: rfMap{"gpu": "uno"},
 		},
+		"Select first flavor which fits when preempting in the DryRun mode, even if flavor fungibility is disabled": {
+			workloadRequests: utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10"),
+			testClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "uno", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			otherClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "due", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			simulationResult: map[resources.FlavorResource]simulationResultForFlavor{
+				{Flavor: "uno", Resource: "gpu"}: {preemptioncommon.Preempt, 0},
+				{Flavor: "due", Resource: "gpu"}: {preemptioncommon.Reclaim, 0},
+			},
+			flavorFungibility: &kueue.FlavorFungibility{
+				WhenCanPreempt: kueue.MayStopSearch,
+			},
+			preemptionMode: kueue.PreemptionModeDryRun,
+			wantMode:       Fit,
+			wantAssigment:  rfMap{"gpu": "tre"},
+		},
+		"Select first flavor where gpu reclamation is possible when preempting in the DryRun mode and no flavor fits": {
+			workloadRequests: utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10"),
+			testClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "uno", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			otherClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "due", Resource: "gpu"}: resources.NewAmount(1),
+				{Flavor: "tre", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			simulationResult: map[resources.FlavorResource]simulationResultForFlavor{
+				{Flavor: "uno", Resource: "gpu"}: {preemptioncommon.Preempt, 0},
+				{Flavor: "due", Resource: "gpu"}: {preemptioncommon.Reclaim, 0},
+			},
+			flavorFungibility: &kueue.FlavorFungibility{
+				WhenCanPreempt: kueue.MayStopSearch,
+			},
+			preemptionMode: kueue.PreemptionModeDryRun,
+			wantMode:       Preempt,
+			wantAssigment:  rfMap{"gpu": "due"},
+		},
 		"Select first flavor when flavor fungibility is disabled; using deprecated WhenCanPreempt=MayStopSearch": {
 			workloadRequests: utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10"),
 			testClusterQueueUsage: resources.FlavorResourceQuantities{
@@ -3771,9 +4021,90 @@ func TestReclaimBeforePriorityPreemption(t *testing.T) {
 			wantMode:      Preempt,
 			wantAssigment: rfMap{"gpu": "tre", "compute": "tre"},
 		},
+		"Select first flavor where preemption within the ClusterQueue is possible, as it is the first stage": {
+			workloadRequests: utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10"),
+			testClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "uno", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			otherClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "due", Resource: "gpu"}: resources.NewAmount(1),
+				{Flavor: "tre", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			simulationResult: map[resources.FlavorResource]simulationResultForFlavor{
+				{Flavor: "uno", Resource: "gpu"}: {preemptioncommon.Preempt, 0},
+				{Flavor: "due", Resource: "gpu"}: {preemptioncommon.Reclaim, 0},
+			},
+			preemptionStages: []kueue.PreemptionStage{
+				kueue.PreemptionStageWithinClusterQueue,
+				kueue.PreemptionStageReclaimWithinCohort,
+				kueue.PreemptionStageFlavorDowngrade,
+			},
+			wantMode:      Preempt,
+			wantAssigment: rfMap{"gpu": "uno"},
+		},
+		"Select second flavor where gpu reclamation is possible, as the flavor downgrade comes before preemption within the ClusterQueue": {
+			workloadRequests: utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10"),
+			testClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "uno", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			otherClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "due", Resource: "gpu"}: resources.NewAmount(1),
+				{Flavor: "tre", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			simulationResult: map[resources.FlavorResource]simulationResultForFlavor{
+				{Flavor: "uno", Resource: "gpu"}: {preemptioncommon.Preempt, 0},
+				{Flavor: "due", Resource: "gpu"}: {preemptioncommon.Reclaim, 0},
+			},
+			preemptionStages: []kueue.PreemptionStage{
+				kueue.PreemptionStageReclaimWithinCohort,
+				kueue.PreemptionStageFlavorDowngrade,
+				kueue.PreemptionStageWithinClusterQueue,
+			},
+			wantMode:      Preempt,
+			wantAssigment: rfMap{"gpu": "due"},
+		},
+		"Select second flavor where gpu reclamation is possible, as preemption within the ClusterQueue isn't a stage": {
+			workloadRequests: utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10"),
+			testClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "uno", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			otherClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "due", Resource: "gpu"}: resources.NewAmount(1),
+				{Flavor: "tre", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			simulationResult: map[resources.FlavorResource]simulationResultForFlavor{
+				{Flavor: "uno", Resource: "gpu"}: {preemptioncommon.Preempt, 0},
+				{Flavor: "due", Resource: "gpu"}: {preemptioncommon.Reclaim, 0},
+			},
+			preemptionStages: []kueue.PreemptionStage{
+				kueue.PreemptionStageReclaimWithinCohort,
+				kueue.PreemptionStageFlavorDowngrade,
+			},
+			wantMode:      Preempt,
+			wantAssigment: rfMap{"gpu": "due"},
+		},
+		"Select first flavor where gpu reclamation is possible, as it comes before the flavor downgrade": {
+			workloadRequests: utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).Request("gpu", "10"),
+			otherClusterQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "uno", Resource: "gpu"}: resources.NewAmount(1),
+				{Flavor: "due", Resource: "gpu"}: resources.NewAmount(1),
+			},
+			simulationResult: map[resources.FlavorResource]simulationResultForFlavor{
+				{Flavor: "uno", Resource: "gpu"}: {preemptioncommon.Reclaim, 0},
+				{Flavor: "due", Resource: "gpu"}: {preemptioncommon.Reclaim, 0},
+			},
+			preemptionStages: []kueue.PreemptionStage{
+				kueue.PreemptionStageReclaimWithinCohort,
+				kueue.PreemptionStageFlavorDowngrade,
+			},
+			wantMode:      Preempt,
+			wantAssigment: rfMap{"gpu": "uno"},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PreemptionStages, true)
+			features.SetFeatureGateDuringTest(t, features.PreemptionDryRun, true)
 			ctx, _ := utiltesting.ContextWithLog(t)
 			resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
 				"uno": utiltestingapi.MakeResourceFlavor("uno").Obj(),
@@ -3785,6 +4116,8 @@ func TestReclaimBeforePriorityPreemption(t *testing.T) {
 				Preemption(kueue.ClusterQueuePreemption{
 					WithinClusterQueue:  kueue.PreemptionPolicyLowerPriority,
 					ReclaimWithinCohort: kueue.PreemptionPolicyLowerPriority,
+					Stages:              tc.preemptionStages,
+					Mode:                tc.preemptionMode,
 				}).
 				FlavorFungibility(kueue.FlavorFungibility{
 					WhenCanPreempt: kueue.TryNextFlavor,
@@ -5158,6 +5491,478 @@ func TestAssignFlavorsWithAllowedFlavors(t *testing.T) {
 	}
 }
 
+func TestAssignFlavorsWithNamespaceSelector(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"team-a": utiltestingapi.MakeResourceFlavor("team-a").
+			NamespaceSelector(&metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "a"},
+			}).Obj(),
+		"shared": utiltestingapi.MakeResourceFlavor("shared").Obj(),
+	}
+
+	cq := *utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("team-a").Resource(corev1.ResourceCPU, "10").Obj(),
+			*utiltestingapi.MakeFlavorQuotas("shared").Resource(corev1.ResourceCPU, "1").Obj(),
+		).Obj()
+
+	tests := map[string]struct {
+		enableFeature   bool
+		namespaceLabels labels.Set
+		wantFlavor      kueue.ResourceFlavorReference
+		wantRepMode     FlavorAssignmentMode
+	}{
+		"namespace matching the selector": {
+			enableFeature:   true,
+			namespaceLabels: labels.Set{"team": "a"},
+			wantFlavor:      "team-a",
+			wantRepMode:     Fit,
+		},
+		"namespace not matching the selector": {
+			enableFeature:   true,
+			namespaceLabels: labels.Set{"team": "b"},
+			wantRepMode:     NoFit,
+		},
+		"namespace without labels": {
+			enableFeature: true,
+			wantRepMode:   NoFit,
+		},
+		"feature disabled": {
+			namespaceLabels: labels.Set{"team": "b"},
+			wantFlavor:      "team-a",
+			wantRepMode:     Fit,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ResourceFlavorNamespaceSelector, tc.enableFeature)
+			wl := utiltestingapi.MakeWorkload("wl", "ns").
+				PodSets(*utiltestingapi.MakePodSet("main", 1).Request(corev1.ResourceCPU, "2").Obj()).
+				Obj()
+			wlInfo := workload.NewInfo(wl)
+
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := schdcache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, &cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(log, rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueue(kueue.ClusterQueueReference(cq.Name))
+
+			assigner := New(wlInfo, cqSnapshot, resourceFlavors, false, &testOracle{}, nil, configapi.QuotaCheckBlockUndeclared).
+				WithNamespaceLabels(tc.namespaceLabels)
+			gotAssignment := assigner.Assign(log, nil)
+
+			if gotAssignment.RepresentativeMode() != tc.wantRepMode {
+				t.Errorf("RepresentativeMode() = %v, want %v", gotAssignment.RepresentativeMode(), tc.wantRepMode)
+			}
+
+			if tc.wantRepMode == Fit {
+				gotFlavor := gotAssignment.PodSets[0].Flavors[corev1.ResourceCPU].Name
+				if gotFlavor != tc.wantFlavor {
+					t.Errorf("Assigned flavor = %v, want %v", gotFlavor, tc.wantFlavor)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignFlavorsWithStoppedFlavor(t *testing.T) {
+	cq := *utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("retiring").Resource(corev1.ResourceCPU, "10").Obj(),
+			*utiltestingapi.MakeFlavorQuotas("new").Resource(corev1.ResourceCPU, "2").Obj(),
+		).Obj()
+
+	tests := map[string]struct {
+		enableFeature bool
+		stopPolicy    kueue.StopPolicy
+		request       string
+		wantFlavor    kueue.ResourceFlavorReference
+		wantRepMode   FlavorAssignmentMode
+	}{
+		"flavor not stopped": {
+			enableFeature: true,
+			stopPolicy:    kueue.None,
+			request:       "2",
+			wantFlavor:    "retiring",
+			wantRepMode:   Fit,
+		},
+		"stopped flavor is skipped": {
+			enableFeature: true,
+			stopPolicy:    kueue.Hold,
+			request:       "2",
+			wantFlavor:    "new",
+			wantRepMode:   Fit,
+		},
+		"drained flavor is skipped": {
+			enableFeature: true,
+			stopPolicy:    kueue.HoldAndDrain,
+			request:       "2",
+			wantFlavor:    "new",
+			wantRepMode:   Fit,
+		},
+		"no capacity in the other flavors": {
+			enableFeature: true,
+			stopPolicy:    kueue.HoldAndDrain,
+			request:       "3",
+			wantRepMode:   NoFit,
+		},
+		"feature disabled": {
+			stopPolicy:  kueue.HoldAndDrain,
+			request:     "2",
+			wantFlavor:  "retiring",
+			wantRepMode: Fit,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ResourceFlavorStopPolicy, tc.enableFeature)
+			resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+				"retiring": utiltestingapi.MakeResourceFlavor("retiring").StopPolicy(tc.stopPolicy).Obj(),
+				"new":      utiltestingapi.MakeResourceFlavor("new").Obj(),
+			}
+			wl := utiltestingapi.MakeWorkload("wl", "ns").
+				PodSets(*utiltestingapi.MakePodSet("main", 1).Request(corev1.ResourceCPU, tc.request).Obj()).
+				Obj()
+			wlInfo := workload.NewInfo(wl)
+
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := schdcache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, &cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(log, rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueue(kueue.ClusterQueueReference(cq.Name))
+
+			assigner := New(wlInfo, cqSnapshot, resourceFlavors, false, &testOracle{}, nil, configapi.QuotaCheckBlockUndeclared)
+			gotAssignment := assigner.Assign(log, nil)
+
+			if gotAssignment.RepresentativeMode() != tc.wantRepMode {
+				t.Errorf("RepresentativeMode() = %v, want %v", gotAssignment.RepresentativeMode(), tc.wantRepMode)
+			}
+
+			if tc.wantRepMode == Fit {
+				gotFlavor := gotAssignment.PodSets[0].Flavors[corev1.ResourceCPU].Name
+				if gotFlavor != tc.wantFlavor {
+					t.Errorf("Assigned flavor = %v, want %v", gotFlavor, tc.wantFlavor)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignFlavorsWithFlavorTieBreak(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"busy": utiltestingapi.MakeResourceFlavor("busy").Obj(),
+		"idle": utiltestingapi.MakeResourceFlavor("idle").Obj(),
+	}
+	usage := resources.FlavorResourceQuantities{
+		{Flavor: "busy", Resource: corev1.ResourceCPU}: resources.NewAmount(6_000),
+		{Flavor: "idle", Resource: corev1.ResourceCPU}: resources.NewAmount(1_000),
+	}
+
+	tests := map[string]struct {
+		enableFeature bool
+		tieBreak      *kueue.FlavorTieBreakPolicy
+		request       string
+		wantFlavor    kueue.ResourceFlavorReference
+	}{
+		"no policy": {
+			enableFeature: true,
+			request:       "2",
+			wantFlavor:    "busy",
+		},
+		"None": {
+			enableFeature: true,
+			tieBreak:      ptr.To(kueue.FlavorTieBreakNone),
+			request:       "2",
+			wantFlavor:    "busy",
+		},
+		"MostHeadroom": {
+			enableFeature: true,
+			tieBreak:      ptr.To(kueue.FlavorTieBreakMostHeadroom),
+			request:       "2",
+			wantFlavor:    "idle",
+		},
+		"LeastHeadroom": {
+			enableFeature: true,
+			tieBreak:      ptr.To(kueue.FlavorTieBreakLeastHeadroom),
+			request:       "2",
+			wantFlavor:    "busy",
+		},
+		"MostHeadroom; only the idle flavor fits": {
+			enableFeature: true,
+			tieBreak:      ptr.To(kueue.FlavorTieBreakMostHeadroom),
+			request:       "5",
+			wantFlavor:    "idle",
+		},
+		"LeastHeadroom; only the idle flavor fits": {
+			enableFeature: true,
+			tieBreak:      ptr.To(kueue.FlavorTieBreakLeastHeadroom),
+			request:       "5",
+			wantFlavor:    "idle",
+		},
+		"feature disabled": {
+			tieBreak:   ptr.To(kueue.FlavorTieBreakMostHeadroom),
+			request:    "2",
+			wantFlavor: "busy",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.FlavorTieBreak, tc.enableFeature)
+			cqWrapper := utiltestingapi.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltestingapi.MakeFlavorQuotas("busy").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltestingapi.MakeFlavorQuotas("idle").Resource(corev1.ResourceCPU, "10").Obj(),
+				)
+			if tc.tieBreak != nil {
+				cqWrapper.FlavorTieBreak(*tc.tieBreak)
+			}
+			cq := cqWrapper.Obj()
+			wl := utiltestingapi.MakeWorkload("wl", "ns").
+				PodSets(*utiltestingapi.MakePodSet("main", 1).Request(corev1.ResourceCPU, tc.request).Obj()).
+				Obj()
+			wlInfo := workload.NewInfo(wl)
+
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := schdcache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(log, rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueue(kueue.ClusterQueueReference(cq.Name))
+			cqSnapshot.AddUsage(workload.Usage{Quota: usage})
+
+			assigner := New(wlInfo, cqSnapshot, resourceFlavors, false, &testOracle{}, nil, configapi.QuotaCheckBlockUndeclared)
+			gotAssignment := assigner.Assign(log, nil)
+
+			if gotAssignment.RepresentativeMode() != Fit {
+				t.Fatalf("RepresentativeMode() = %v, want %v", gotAssignment.RepresentativeMode(), Fit)
+			}
+			gotFlavor := gotAssignment.PodSets[0].Flavors[corev1.ResourceCPU].Name
+			if gotFlavor != tc.wantFlavor {
+				t.Errorf("Assigned flavor = %v, want %v", gotFlavor, tc.wantFlavor)
+			}
+		})
+	}
+}
+
+func TestAssignFlavorsWithResourceScaling(t *testing.T) {
+	const gpu = corev1.ResourceName("nvidia.com/gpu")
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"time-sliced": utiltestingapi.MakeResourceFlavor("time-sliced").
+			ResourceScaling(gpu, 4).Obj(),
+	}
+
+	cq := *utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("time-sliced").Resource(gpu, "2").Obj(),
+		).Obj()
+
+	tests := map[string]struct {
+		enableFeature bool
+		podSet        *kueue.PodSet
+		wantRepMode   FlavorAssignmentMode
+		wantUsage     resources.FlavorResourceQuantities
+	}{
+		"4 advertised GPUs count as 1 unit of quota": {
+			enableFeature: true,
+			podSet:        utiltestingapi.MakePodSet("main", 4).Request(gpu, "1").Obj(),
+			wantRepMode:   Fit,
+			wantUsage:     resources.FlavorResourceQuantities{{Flavor: "time-sliced", Resource: gpu}: resources.NewAmount(1)},
+		},
+		"usage is rounded up": {
+			enableFeature: true,
+			podSet:        utiltestingapi.MakePodSet("main", 5).Request(gpu, "1").Obj(),
+			wantRepMode:   Fit,
+			wantUsage:     resources.FlavorResourceQuantities{{Flavor: "time-sliced", Resource: gpu}: resources.NewAmount(2)},
+		},
+		"scaled request exceeding the quota": {
+			enableFeature: true,
+			podSet:        utiltestingapi.MakePodSet("main", 3).Request(gpu, "4").Obj(),
+			wantRepMode:   NoFit,
+		},
+		"feature disabled": {
+			podSet:      utiltestingapi.MakePodSet("main", 4).Request(gpu, "1").Obj(),
+			wantRepMode: NoFit,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ResourceFlavorResourceScaling, tc.enableFeature)
+			wl := utiltestingapi.MakeWorkload("wl", "ns").PodSets(*tc.podSet).Obj()
+			wlInfo := workload.NewInfo(wl)
+
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := schdcache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, &cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(log, rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueue(kueue.ClusterQueueReference(cq.Name))
+
+			assigner := New(wlInfo, cqSnapshot, resourceFlavors, false, &testOracle{}, nil, configapi.QuotaCheckBlockUndeclared)
+			gotAssignment := assigner.Assign(log, nil)
+
+			if gotAssignment.RepresentativeMode() != tc.wantRepMode {
+				t.Errorf("RepresentativeMode() = %v, want %v", gotAssignment.RepresentativeMode(), tc.wantRepMode)
+			}
+			if tc.wantRepMode == Fit {
+				if diff := cmp.Diff(tc.wantUsage, gotAssignment.Usage.Quota); diff != "" {
+					t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.wantUsage, gotAssignment.TotalRequestsFor(log, wlInfo)); diff != "" {
+					t.Errorf("Unexpected total requests (-want,+got):\n%s", diff)
+				}
+				wantResourceUsage := resources.ResourceQuantity(gpu, tc.wantUsage[resources.FlavorResource{Flavor: "time-sliced", Resource: gpu}].Int64())
+				if got := gotAssignment.ToAPI()[0].ResourceUsage[gpu]; got.Cmp(wantResourceUsage) != 0 {
+					t.Errorf("Unexpected resourceUsage in the admission: got %s, want %s", got.String(), wantResourceUsage.String())
+				}
+			}
+		})
+	}
+}
+
+func TestAssignFlavorsWithNodeShare(t *testing.T) {
+	const gpu = corev1.ResourceName("nvidia.com/gpu")
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"default": utiltestingapi.MakeResourceFlavor("default").Obj(),
+		"whole-node": utiltestingapi.MakeResourceFlavor("whole-node").
+			NodeShape(corev1.ResourceCPU, "8").
+			NodeShape(gpu, "4").Obj(),
+	}
+
+	cq := *utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "100").
+				Resource(gpu, "100").Obj(),
+			*utiltestingapi.MakeFlavorQuotas("whole-node").
+				Resource(corev1.ResourceCPU, "16").
+				Resource(gpu, "8").Obj(),
+		).Obj()
+
+	tests := map[string]struct {
+		enableFeature bool
+		podSet        *kueue.PodSet
+		wantRepMode   FlavorAssignmentMode
+		wantFlavor    kueue.ResourceFlavorReference
+		wantUsage     resources.FlavorResourceQuantities
+	}{
+		"a whole node per pod": {
+			enableFeature: true,
+			podSet: utiltestingapi.MakePodSet("main", 2).
+				Annotations(map[string]string{constants.NodeShareAnnotation: "100%"}).
+				Request(corev1.ResourceCPU, "1").Obj(),
+			wantRepMode: Fit,
+			wantFlavor:  "whole-node",
+			wantUsage: resources.FlavorResourceQuantities{
+				{Flavor: "whole-node", Resource: corev1.ResourceCPU}: resources.NewAmount(16_000),
+				{Flavor: "whole-node", Resource: gpu}:                resources.NewAmount(8),
+			},
+		},
+		"half a node per pod": {
+			enableFeature: true,
+			podSet: utiltestingapi.MakePodSet("main", 4).
+				Annotations(map[string]string{constants.NodeShareAnnotation: "50%"}).
+				Request(corev1.ResourceCPU, "1").Obj(),
+			wantRepMode: Fit,
+			wantFlavor:  "whole-node",
+			wantUsage: resources.FlavorResourceQuantities{
+				{Flavor: "whole-node", Resource: corev1.ResourceCPU}: resources.NewAmount(16_000),
+				{Flavor: "whole-node", Resource: gpu}:                resources.NewAmount(8),
+			},
+		},
+		"whole nodes exceeding the quota": {
+			enableFeature: true,
+			podSet: utiltestingapi.MakePodSet("main", 3).
+				Annotations(map[string]string{constants.NodeShareAnnotation: "100%"}).
+				Request(corev1.ResourceCPU, "1").Obj(),
+			wantRepMode: NoFit,
+		},
+		"feature disabled": {
+			podSet: utiltestingapi.MakePodSet("main", 2).
+				Annotations(map[string]string{constants.NodeShareAnnotation: "100%"}).
+				Request(corev1.ResourceCPU, "1").Obj(),
+			wantRepMode: Fit,
+			wantFlavor:  "default",
+			wantUsage: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: resources.NewAmount(2_000),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.NodeShareRequests, tc.enableFeature)
+			wl := utiltestingapi.MakeWorkload("wl", "ns").PodSets(*tc.podSet).Obj()
+			wlInfo := workload.NewInfo(wl)
+
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := schdcache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, &cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(log, rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueue(kueue.ClusterQueueReference(cq.Name))
+
+			assigner := New(wlInfo, cqSnapshot, resourceFlavors, false, &testOracle{}, nil, configapi.QuotaCheckBlockUndeclared)
+			gotAssignment := assigner.Assign(log, nil)
+
+			if gotAssignment.RepresentativeMode() != tc.wantRepMode {
+				t.Errorf("RepresentativeMode() = %v, want %v", gotAssignment.RepresentativeMode(), tc.wantRepMode)
+			}
+			if tc.wantRepMode == Fit {
+				if gotFlavor := gotAssignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; gotFlavor != tc.wantFlavor {
+					t.Errorf("Assigned flavor = %v, want %v", gotFlavor, tc.wantFlavor)
+				}
+				if diff := cmp.Diff(tc.wantUsage, gotAssignment.Usage.Quota); diff != "" {
+					t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.wantUsage, gotAssignment.TotalRequestsFor(log, wlInfo)); diff != "" {
+					t.Errorf("Unexpected total requests (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
 func TestIsNoFitDueToCapacityAndLimits(t *testing.T) {
 	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
 		"flavor-a": utiltestingapi.MakeResourceFlavor("flavor-a").NodeLabel("type", "a").Obj(),
@@ -48,6 +48,10 @@ func (a *Assignment) WorkloadsTopologyRequests(log logr.Logger, wl *workload.Inf
 				log.V(3).Info("Skipping TAS for count=0 podSet", "podSet", podSet.Name)
 				continue
 			}
+			if a.unconstrainedTopologyFallback.Has(podSet.Name) {
+				log.V(3).Info("Skipping TAS for podSet falling back to unconstrained topology", "podSet", podSet.Name)
+				continue
+			}
 			if psAssignment.TopologyAssignment != nil && !psAssignment.HasUnhealthyNode(wl) {
 				// skip if already computed and doesn't need recomputing
 				// if it already has an assignment but needs recomputing due to a failed node
@@ -76,6 +80,9 @@ func (a *Assignment) WorkloadsTopologyRequests(log logr.Logger, wl *workload.Inf
 				a.psError(psAssignment, err)
 				continue
 			} else if psTASRequest != nil {
+				// Place the pods by the node-share requests recorded in the
+				// admission, so that the TAS usage matches the placement.
+				workload.ApplyNodeShareRequests(psTASRequest.SinglePodRequests, &podSet, psAssignment.Requests, psAssignment.Count)
 				tasRequests[psTASRequest.Flavor] = append(tasRequests[psTASRequest.Flavor], *psTASRequest)
 			}
 		}
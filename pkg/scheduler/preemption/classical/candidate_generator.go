@@ -17,12 +17,14 @@ limitations under the License.
 package classical
 
 import (
+	"fmt"
 	"slices"
 	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
@@ -105,6 +107,14 @@ func NewCandidateIterator(
 	allCandidates = append(allCandidates, nonEvictedHierarchicalReclaimCandidates...)
 	allCandidates = append(allCandidates, nonEvictedSTCandidates...)
 	allCandidates = append(allCandidates, nonEvictedSameQueueCandidates...)
+	if logV := hierarchicalReclaimCtx.Log.V(5); logV.Enabled() {
+		logV.Info("Ordered preemption candidates",
+			"preemptingWorkload", klog.KObj(hierarchicalReclaimCtx.Wl),
+			"candidates", candidateReferences(allCandidates),
+			"hierarchyCandidates", len(hierarchyCandidates),
+			"priorityCandidates", len(priorityCandidates),
+			"sameQueueCandidates", len(sameQueueCandidates))
+	}
 	return &candidateIterator{
 		runIndex:                          0,
 		frsNeedPreemption:                 frsNeedPreemption,
@@ -116,6 +126,16 @@ func NewCandidateIterator(
 	}
 }
 
+// candidateReferences returns the references of the candidates, in order,
+// together with their ClusterQueue and preemption reason.
+func candidateReferences(candidates []*candidateElem) []string {
+	refs := make([]string, len(candidates))
+	for i, c := range candidates {
+		refs[i] = fmt.Sprintf("%s (clusterQueue: %s, reason: %s)", klog.KObj(c.wl.Obj), c.wl.ClusterQueue, c.preemptionVariant.PreemptionReason())
+	}
+	return refs
+}
+
 // Next allows to iterate over the ordered sequence of candidates, with the reason
 // for eviction returned together with a candidate.
 func (c *candidateIterator) Next(borrow bool) (*workload.Info, string) {
@@ -124,25 +144,26 @@ func (c *candidateIterator) Next(borrow bool) (*workload.Info, string) {
 	}
 	candidate := c.candidates[c.runIndex]
 	c.runIndex++
-	if !c.candidateIsValid(candidate, borrow) {
+	if reason := c.candidateRejectionReason(candidate, borrow); reason != "" {
+		logRejectedCandidate(c.hierarchicalReclaimCtx.Log.WithValues("allowBorrowing", borrow), candidate.wl, reason)
 		return c.Next(borrow)
 	}
 	return candidate.wl, candidate.preemptionVariant.PreemptionReason()
 }
 
-// candidateIsValid checks if candidate is valid,
-// as eg. some candidates can only be considered without borrowing
+// candidateRejectionReason returns why the candidate is not valid, or an empty
+// string if it is, as eg. some candidates can only be considered without borrowing
 // Also, preemption of candidates might invalidate other candidates
-func (c *candidateIterator) candidateIsValid(candidate *candidateElem, borrow bool) bool {
+func (c *candidateIterator) candidateRejectionReason(candidate *candidateElem, borrow bool) string {
 	if c.hierarchicalReclaimCtx.Cq.Name == candidate.wl.ClusterQueue {
-		return true
+		return ""
 	}
 	if borrow && candidate.preemptionVariant == ReclaimWithoutBorrowing {
-		return false
+		return "can only be preempted if the preempting ClusterQueue doesn't borrow"
 	}
 	cq := c.snapshot.ClusterQueue(candidate.wl.ClusterQueue)
 	if schdcache.IsWithinNominalInResources(cq, c.frsNeedPreemption) {
-		return false
+		return "its ClusterQueue is within nominal quota"
 	}
 	// we don't go all the way to the root but only to the lca node
 	for node := range cq.PathParentToRoot() {
@@ -150,10 +171,10 @@ func (c *candidateIterator) candidateIsValid(candidate *candidateElem, borrow bo
 			break
 		}
 		if schdcache.IsWithinNominalInResources(node, c.frsNeedPreemption) {
-			return false
+			return fmt.Sprintf("its cohort %q is within nominal quota", node.GetName())
 		}
 	}
-	return true
+	return ""
 }
 
 // Reset moves the candidate iterator back to the starting position.
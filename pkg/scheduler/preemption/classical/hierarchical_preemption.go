@@ -17,8 +17,12 @@ limitations under the License.
 package classical
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
@@ -66,6 +70,7 @@ type HierarchicalPreemptionCtx struct {
 	FrsNeedPreemption sets.Set[resources.FlavorResource]
 	Requests          resources.FlavorResourceQuantities
 	WorkloadOrdering  workload.Ordering
+	Now               time.Time
 }
 
 func IsBorrowingWithinCohortForbidden(cq *schdcache.ClusterQueueSnapshot) (bool, *int32) {
@@ -80,6 +85,7 @@ func IsBorrowingWithinCohortForbidden(cq *schdcache.ClusterQueueSnapshot) (bool,
 // preemption type for a given candidate
 func classifyPreemptionVariant(ctx *HierarchicalPreemptionCtx, wl *workload.Info, haveHierarchicalAdvantage bool) preemptionVariant {
 	if !WorkloadUsesResources(wl, ctx.FrsNeedPreemption) {
+		logRejectedCandidate(ctx.Log, wl, "doesn't use the resources needing preemption")
 		return Never
 	}
 
@@ -91,6 +97,7 @@ func classifyPreemptionVariant(ctx *HierarchicalPreemptionCtx, wl *workload.Info
 	}
 
 	if !preemptioncommon.SatisfiesPreemptionPolicy(ctx.Log, ctx.Wl, wl.Obj, ctx.WorkloadOrdering, preemptionPolicy) {
+		logRejectedCandidate(ctx.Log, wl, fmt.Sprintf("doesn't satisfy the %s preemption policy", preemptionPolicy))
 		return Never
 	}
 
@@ -112,6 +119,15 @@ func classifyPreemptionVariant(ctx *HierarchicalPreemptionCtx, wl *workload.Info
 	return ReclaimWhileBorrowing
 }
 
+func logRejectedCandidate(log logr.Logger, wl *workload.Info, reason string) {
+	if logV := log.V(5); logV.Enabled() {
+		logV.Info("Rejected preemption candidate",
+			"candidate", klog.KObj(wl.Obj),
+			"candidateClusterQueue", klog.KRef("", string(wl.ClusterQueue)),
+			"reason", reason)
+	}
+}
+
 func isAboveBorrowingThreshold(candidatePriority, incomingPriority int64, borrowWithinCohortThreshold *int32) bool {
 	if candidatePriority >= incomingPriority {
 		return true
@@ -124,6 +140,7 @@ func isAboveBorrowingThreshold(candidatePriority, incomingPriority int64, borrow
 
 func collectSameQueueCandidates(ctx *HierarchicalPreemptionCtx) []*candidateElem {
 	if ctx.Cq.Preemption.WithinClusterQueue == kueue.PreemptionPolicyNever {
+		ctx.Log.V(5).Info("Skipping preemption candidates in the ClusterQueue", "reason", "withinClusterQueue preemption policy is Never")
 		return []*candidateElem{}
 	}
 	return getCandidatesFromCQ(ctx.Cq, nil, ctx, false)
@@ -132,6 +149,10 @@ func collectSameQueueCandidates(ctx *HierarchicalPreemptionCtx) []*candidateElem
 func getCandidatesFromCQ(cq *schdcache.ClusterQueueSnapshot, lca *schdcache.CohortSnapshot, ctx *HierarchicalPreemptionCtx, hasHiearchicalAdvantage bool) []*candidateElem {
 	candidates := []*candidateElem{}
 	for _, candidateWl := range cq.Workloads {
+		if preemptioncommon.InPreemptionCooldown(candidateWl.Obj, cq.Preemption.CooldownSeconds, ctx.Now) {
+			logRejectedCandidate(ctx.Log, candidateWl, "its ClusterQueue preemption cooldown didn't pass yet")
+			continue
+		}
 		preemptionVariant := classifyPreemptionVariant(ctx, candidateWl, hasHiearchicalAdvantage)
 		if preemptionVariant == Never {
 			continue
@@ -149,7 +170,12 @@ func getCandidatesFromCQ(cq *schdcache.ClusterQueueSnapshot, lca *schdcache.Coho
 func collectCandidatesForHierarchicalReclaim(ctx *HierarchicalPreemptionCtx) ([]*candidateElem, []*candidateElem) {
 	hierarchyCandidates := []*candidateElem{}
 	priorityCandidates := []*candidateElem{}
-	if !ctx.Cq.HasParent() || ctx.Cq.Preemption.ReclaimWithinCohort == kueue.PreemptionPolicyNever {
+	if !ctx.Cq.HasParent() {
+		ctx.Log.V(5).Info("Skipping preemption candidates in the cohort", "reason", "ClusterQueue doesn't belong to a cohort")
+		return hierarchyCandidates, priorityCandidates
+	}
+	if ctx.Cq.Preemption.ReclaimWithinCohort == kueue.PreemptionPolicyNever {
+		ctx.Log.V(5).Info("Skipping preemption candidates in the cohort", "reason", "reclaimWithinCohort preemption policy is Never")
 		return hierarchyCandidates, priorityCandidates
 	}
 	var previousSubtreeRoot *schdcache.CohortSnapshot
@@ -162,6 +188,9 @@ func collectCandidatesForHierarchicalReclaim(ctx *HierarchicalPreemptionCtx) ([]
 		} else {
 			candidateList = &priorityCandidates
 		}
+		ctx.Log.V(5).Info("Collecting preemption candidates in cohort subtree",
+			"cohort", klog.KRef("", string(currentSubtreeRoot.GetName())),
+			"hierarchicalAdvantage", hasHierarchicalAdvantage)
 		collectCandidatesInSubtree(ctx, currentSubtreeRoot, currentSubtreeRoot, previousSubtreeRoot, hasHierarchicalAdvantage, candidateList)
 		fits, remainingRequests = schdcache.QuantitiesFitInQuota(currentSubtreeRoot, remainingRequests)
 		// Once we find a subtree sT that fits the requests, we will look for workloads that use quota
@@ -191,6 +220,7 @@ func collectCandidatesInSubtree(
 		}
 		// don't look for candidates in subtrees that are not exceeding their quotas
 		if schdcache.IsWithinNominalInResources(childCohort, ctx.FrsNeedPreemption) {
+			ctx.Log.V(5).Info("Skipping preemption candidates in cohort", "cohort", klog.KRef("", string(childCohort.GetName())), "reason", "cohort is within nominal quota")
 			continue
 		}
 		collectCandidatesInSubtree(ctx, childCohort, subtreeRoot, skipSubtree, hasHierarchicalAdvantage, result)
@@ -199,9 +229,11 @@ func collectCandidatesInSubtree(
 		if childCq == ctx.Cq {
 			continue
 		}
-		if !schdcache.IsWithinNominalInResources(childCq, ctx.FrsNeedPreemption) {
-			*result = append(*result, getCandidatesFromCQ(childCq, subtreeRoot, ctx, hasHierarchicalAdvantage)...)
+		if schdcache.IsWithinNominalInResources(childCq, ctx.FrsNeedPreemption) {
+			ctx.Log.V(5).Info("Skipping preemption candidates in ClusterQueue", "clusterQueue", klog.KRef("", string(childCq.Name)), "reason", "ClusterQueue is within nominal quota")
+			continue
 		}
+		*result = append(*result, getCandidatesFromCQ(childCq, subtreeRoot, ctx, hasHierarchicalAdvantage)...)
 	}
 }
 
@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/features"
@@ -30,6 +32,9 @@ import (
 const timestampPreemptionBuffer = 5 * time.Minute
 
 func SatisfiesPreemptionPolicy(log logr.Logger, preemptor, candidate *kueue.Workload, workloadOrdering workload.Ordering, policy kueue.PreemptionPolicy) bool {
+	if workload.IsNonPreemptible(candidate) {
+		return false
+	}
 	preemptorPriority := priority.EffectivePriority(log, preemptor)
 	candidatePriority := priority.EffectivePriority(log, candidate)
 
@@ -48,3 +53,31 @@ func SatisfiesPreemptionPolicy(log logr.Logger, preemptor, candidate *kueue.Work
 	}
 	return policy == kueue.PreemptionPolicyAny
 }
+
+// InPreemptionCooldown returns whether the quota of the candidate was reserved
+// less than cooldownSeconds ago, in which case the candidate can't be preempted.
+func InPreemptionCooldown(candidate *kueue.Workload, cooldownSeconds *int32, now time.Time) bool {
+	if !features.Enabled(features.PreemptionCooldown) || cooldownSeconds == nil {
+		return false
+	}
+	cond := apimeta.FindStatusCondition(candidate.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return false
+	}
+	return now.Before(cond.LastTransitionTime.Add(time.Duration(*cooldownSeconds) * time.Second))
+}
+
+// PreemptionStages returns the ordered preemption stages configured for the
+// ClusterQueue, or nil if the preemption doesn't follow configured stages.
+func PreemptionStages(preemption kueue.ClusterQueuePreemption) []kueue.PreemptionStage {
+	if !features.Enabled(features.PreemptionStages) {
+		return nil
+	}
+	return preemption.Stages
+}
+
+// IsDryRun returns true if the preemption targets of the ClusterQueue are
+// only reported, and not evicted.
+func IsDryRun(preemption kueue.ClusterQueuePreemption) bool {
+	return features.Enabled(features.PreemptionDryRun) && preemption.Mode == kueue.PreemptionModeDryRun
+}
@@ -150,6 +150,33 @@ func TestSatisfiesPreemptionPolicy(t *testing.T) {
 			policy: kueue.PreemptionPolicyLowerOrNewerEqualPriority,
 			want:   false,
 		},
+		"LowerPriority: non-preemptible candidate with lower priority": {
+			featureGates: map[featuregate.Feature]bool{
+				features.NonPreemptibleWorkloads: true,
+			},
+			preemptor: preemptor.Clone().Priority(10).Obj(),
+			candidate: candidate.Clone().Priority(5).
+				Annotation(controllerconstants.NonPreemptibleAnnotationKey, "true").Obj(),
+			policy: kueue.PreemptionPolicyLowerPriority,
+			want:   false,
+		},
+		"Any: non-preemptible candidate": {
+			featureGates: map[featuregate.Feature]bool{
+				features.NonPreemptibleWorkloads: true,
+			},
+			preemptor: preemptor.Clone().Priority(10).Obj(),
+			candidate: candidate.Clone().Priority(5).
+				Annotation(controllerconstants.NonPreemptibleAnnotationKey, "true").Obj(),
+			policy: kueue.PreemptionPolicyAny,
+			want:   false,
+		},
+		"Any: non-preemptible annotation is ignored when the feature is disabled": {
+			preemptor: preemptor.Clone().Priority(10).Obj(),
+			candidate: candidate.Clone().Priority(5).
+				Annotation(controllerconstants.NonPreemptibleAnnotationKey, "true").Obj(),
+			policy: kueue.PreemptionPolicyAny,
+			want:   true,
+		},
 		"Any: always satisfies regardless of priority or boost": {
 			featureGates: map[featuregate.Feature]bool{
 				features.PriorityBoost: true,
@@ -174,3 +201,51 @@ func TestSatisfiesPreemptionPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestInPreemptionCooldown(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	admission := utiltestingapi.MakeAdmission("cq").Obj()
+	candidate := utiltestingapi.MakeWorkload("candidate", metav1.NamespaceDefault)
+
+	testCases := map[string]struct {
+		disableFeature  bool
+		candidate       *kueue.Workload
+		cooldownSeconds *int32
+		want            bool
+	}{
+		"no cooldown": {
+			candidate: candidate.Clone().ReserveQuotaAt(admission, now.Add(-time.Second)).Obj(),
+			want:      false,
+		},
+		"quota reserved within the cooldown": {
+			candidate:       candidate.Clone().ReserveQuotaAt(admission, now.Add(-30*time.Second)).Obj(),
+			cooldownSeconds: new(int32(60)),
+			want:            true,
+		},
+		"quota reserved before the cooldown": {
+			candidate:       candidate.Clone().ReserveQuotaAt(admission, now.Add(-time.Minute)).Obj(),
+			cooldownSeconds: new(int32(60)),
+			want:            false,
+		},
+		"quota not reserved": {
+			candidate:       candidate.Clone().Obj(),
+			cooldownSeconds: new(int32(60)),
+			want:            false,
+		},
+		"feature disabled": {
+			disableFeature:  true,
+			candidate:       candidate.Clone().ReserveQuotaAt(admission, now.Add(-30*time.Second)).Obj(),
+			cooldownSeconds: new(int32(60)),
+			want:            false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PreemptionCooldown, !tc.disableFeature)
+			if got := InPreemptionCooldown(tc.candidate, tc.cooldownSeconds, now); got != tc.want {
+				t.Errorf("InPreemptionCooldown() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -47,6 +47,18 @@ func LessThanInitialShare(preemptorNewShare PreemptorNewShare, targetOldShare Ta
 	return schdcache.CompareDRS(schdcache.DRS(preemptorNewShare), schdcache.DRS(targetOldShare)) < 0
 }
 
+// WithThreshold returns a strategy which, in addition to the given strategy,
+// requires the share of the Preemptee before the preemption to exceed the
+// share of the Preemptor by more than the threshold.
+func WithThreshold(strategy Strategy, threshold float64) Strategy {
+	return func(preemptorNewShare PreemptorNewShare, targetOldShare TargetOldShare, targetNewShare TargetNewShare) bool {
+		if !strategy(preemptorNewShare, targetOldShare, targetNewShare) {
+			return false
+		}
+		return schdcache.DRS(targetOldShare).PreciseWeightedShare()-schdcache.DRS(preemptorNewShare).PreciseWeightedShare() > threshold
+	}
+}
+
 func Enabled(pfs *config.FairSharing) bool {
 	return pfs != nil
 }
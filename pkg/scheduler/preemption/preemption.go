@@ -23,15 +23,18 @@ import (
 	"slices"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -48,10 +51,12 @@ import (
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption/fairsharing"
 	"sigs.k8s.io/kueue/pkg/util/expectations"
 	"sigs.k8s.io/kueue/pkg/util/logging"
+	utilpreemption "sigs.k8s.io/kueue/pkg/util/preemption"
 	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workload/audit"
 	workloadevict "sigs.k8s.io/kueue/pkg/workload/evict"
 )
 
@@ -233,6 +238,11 @@ func (p *Preemptor) IssuePreemptions(
 			ctx, p.client, p.recorder, wlCopy, kueue.WorkloadEvictedByPreemption, message, "", p.clock, exposeLqMetrics, p.roleTracker, p.customLabels,
 			workloadevict.WithCustomPrepare(func(wl *kueue.Workload) {
 				workload.SetPreemptedCondition(wl, p.clock.Now(), target.Reason, message)
+				if cooldown := target.WorkloadCq.Preemption.CooldownSeconds; cooldown != nil && features.Enabled(features.PreemptionCooldown) {
+					// Hold the preempted workload back for the cooldown, so that it isn't
+					// admitted again just to be preempted again.
+					workload.SetRequeueState(wl, metav1.NewTime(p.clock.Now().Add(time.Duration(*cooldown)*time.Second)), false)
+				}
 			}),
 			workloadevict.WithLooseOnApply(), workloadevict.WithRetryOnConflict(),
 		)
@@ -257,8 +267,18 @@ func (p *Preemptor) IssuePreemptions(
 			klog.KObj(target.WorkloadInfo.Obj), target.WorkloadInfo.Obj.UID, target.WorkloadInfo.ClusterQueue,
 			preemptorEffPri, preemptorBase, preemptorBoost, targetEffPri, targetBase, targetBoost)
 		workloadevict.ReportPreemption(preemptor.ClusterQueue, target.Reason, target.WorkloadInfo.ClusterQueue, p.roleTracker, p.customLabels)
+		audit.Emit(audit.Record{
+			Decision: audit.DecisionPreemption,
+			Workload: wlCopy,
+			Reason:   target.Reason,
+			Message:  message,
+			Actor:    preemptor.Obj,
+		}, p.clock.Now(), p.roleTracker)
 		successfullyPreempted.Add(1)
 	})
+	if len(targets) > 0 && preemptionErrors.Load() == 0 {
+		metrics.ReportPreemptionAttempt(preemptor.ClusterQueue, metrics.PreemptionOutcomeSucceeded, p.customLabels.CQGet(preemptor.ClusterQueue), p.roleTracker)
+	}
 	return int(successfullyPreempted.Load()), int(preemptionErrors.Load()), errCh.ReceiveError()
 }
 
@@ -266,6 +286,12 @@ type preemptionAttemptOpts struct {
 	borrowing bool
 }
 
+// candidateIterator yields the ordered candidates of the classical preemption.
+type candidateIterator interface {
+	Next(borrow bool) (*workload.Info, string)
+	Reset()
+}
+
 // classicalPreemptions implements a heuristic to find a minimal set of Workloads
 // to preempt.
 // The heuristic first removes candidates, in the input order, while their
@@ -282,8 +308,9 @@ func (p *Preemptor) classicalPreemptions(preemptionCtx *preemptionCtx) []*Target
 		FrsNeedPreemption: preemptionCtx.frsNeedPreemption,
 		Requests:          preemptionCtx.workloadUsage.Quota,
 		WorkloadOrdering:  p.workloadOrdering,
+		Now:               p.clock.Now(),
 	}
-	candidatesGenerator := classical.NewCandidateIterator(hierarchicalReclaimCtx, p.enabledAfs, preemptionCtx.frsNeedPreemption, preemptionCtx.snapshot, p.clock, preemptioncommon.CandidatesOrdering)
+	candidatesGenerator := classical.NewCandidateIterator(hierarchicalReclaimCtx, p.enabledAfs, preemptionCtx.frsNeedPreemption, preemptionCtx.snapshot, p.clock, utilpreemption.CandidatesOrdering)
 	var attemptPossibleOpts []preemptionAttemptOpts
 	borrowWithinCohortForbidden, _ := classical.IsBorrowingWithinCohortForbidden(preemptionCtx.preemptorCQ)
 	// We have three types of candidates:
@@ -309,24 +336,89 @@ func (p *Preemptor) classicalPreemptions(preemptionCtx *preemptionCtx) []*Target
 	default:
 		attemptPossibleOpts = []preemptionAttemptOpts{{true}, {false}}
 	}
+	stages := preemptioncommon.PreemptionStages(preemptionCtx.preemptorCQ.Preemption)
+	if logV := preemptionCtx.log.V(5); logV.Enabled() {
+		allowBorrowing := make([]bool, len(attemptPossibleOpts))
+		for i, attemptOpts := range attemptPossibleOpts {
+			allowBorrowing[i] = attemptOpts.borrowing
+		}
+		logV.Info("Simulating classical preemption",
+			"preemptingWorkload", klog.KObj(preemptionCtx.preemptor.Obj),
+			"resourcesRequiringPreemption", preemptionCtx.frsNeedPreemption.UnsortedList(),
+			"borrowWithinCohortForbidden", borrowWithinCohortForbidden,
+			"allowBorrowingAttempts", allowBorrowing,
+			"stages", stages)
+	}
+
+	if len(stages) == 0 {
+		return classicalPreemptionAttempts(preemptionCtx, candidatesGenerator, attemptPossibleOpts, nil)
+	}
+	cqName := preemptionCtx.preemptorCQ.Name
+	for _, stage := range stages {
+		var inStage func(*workload.Info) bool
+		switch stage {
+		case kueue.PreemptionStageWithinClusterQueue:
+			inStage = func(candidate *workload.Info) bool { return candidate.ClusterQueue == cqName }
+		case kueue.PreemptionStageReclaimWithinCohort:
+			inStage = func(candidate *workload.Info) bool { return candidate.ClusterQueue != cqName }
+		default:
+			// The next flavors are tried by the flavor assigner.
+			continue
+		}
+		preemptionCtx.log.V(5).Info("Attempting preemption stage",
+			"preemptingWorkload", klog.KObj(preemptionCtx.preemptor.Obj),
+			"stage", stage)
+		if targets := classicalPreemptionAttempts(preemptionCtx, candidatesGenerator, attemptPossibleOpts, inStage); len(targets) > 0 {
+			return targets
+		}
+	}
+	return nil
+}
 
+// classicalPreemptionAttempts runs the attempts of the classical preemption,
+// only considering the candidates for which inStage returns true, if set.
+func classicalPreemptionAttempts(preemptionCtx *preemptionCtx, candidatesGenerator candidateIterator, attemptPossibleOpts []preemptionAttemptOpts, inStage func(*workload.Info) bool) []*Target {
 	for _, attemptOpts := range attemptPossibleOpts {
 		var targets []*Target
 		candidatesGenerator.Reset()
 		for candidate, reason := candidatesGenerator.Next(attemptOpts.borrowing); candidate != nil; candidate, reason = candidatesGenerator.Next(attemptOpts.borrowing) {
+			if inStage != nil && !inStage(candidate) {
+				continue
+			}
 			preemptionCtx.snapshot.RemoveWorkload(candidate)
 			targets = append(targets, &Target{
 				WorkloadInfo: candidate,
 				Reason:       reason,
 				WorkloadCq:   preemptionCtx.snapshot.ClusterQueue(candidate.ClusterQueue),
 			})
-			if workloadFits(preemptionCtx, attemptOpts.borrowing) {
+			fits := workloadFits(preemptionCtx, attemptOpts.borrowing)
+			if logV := preemptionCtx.log.V(5); logV.Enabled() {
+				logV.Info("Selected preemption candidate",
+					"candidate", klog.KObj(candidate.Obj),
+					"candidateClusterQueue", klog.KRef("", string(candidate.ClusterQueue)),
+					"reason", reason,
+					"allowBorrowing", attemptOpts.borrowing,
+					"workloadFits", fits)
+			}
+			if fits {
 				targets = fillBackWorkloads(preemptionCtx, targets, attemptOpts.borrowing)
 				restoreSnapshot(preemptionCtx.snapshot, targets)
+				if logV := preemptionCtx.log.V(5); logV.Enabled() {
+					logV.Info("Classical preemption succeeded",
+						"preemptingWorkload", klog.KObj(preemptionCtx.preemptor.Obj),
+						"allowBorrowing", attemptOpts.borrowing,
+						"targets", logging.GetObjectReferences(targets))
+				}
 				return targets
 			}
 		}
 		restoreSnapshot(preemptionCtx.snapshot, targets)
+		if logV := preemptionCtx.log.V(5); logV.Enabled() {
+			logV.Info("Classical preemption attempt failed, the workload doesn't fit after preempting all the valid candidates",
+				"preemptingWorkload", klog.KObj(preemptionCtx.preemptor.Obj),
+				"allowBorrowing", attemptOpts.borrowing,
+				"targets", logging.GetObjectReferences(targets))
+		}
 	}
 	return nil
 }
@@ -336,6 +428,9 @@ func fillBackWorkloads(preemptionCtx *preemptionCtx, targets []*Target, allowBor
 	for i := len(targets) - 2; i >= 0; i-- {
 		preemptionCtx.snapshot.AddWorkload(targets[i].WorkloadInfo)
 		if workloadFits(preemptionCtx, allowBorrowing) {
+			preemptionCtx.log.V(5).Info("Preemption candidate is not needed for the workload to fit",
+				"candidate", klog.KObj(targets[i].WorkloadInfo.Obj),
+				"candidateClusterQueue", klog.KRef("", string(targets[i].WorkloadInfo.ClusterQueue)))
 			// O(1) deletion: copy the last element into index i and reduce size.
 			targets[i] = targets[len(targets)-1]
 			targets = targets[:len(targets)-1]
@@ -356,16 +451,23 @@ func restoreSnapshot(snapshot *schdcache.Snapshot, targets []*Target) {
 // This function takes advantage of the properties of the preemption algorithm and the strategies.
 // The number of functions returned might not match the input slice.
 func parseStrategies(fs *config.FairSharing) []fairsharing.Strategy {
+	var strategies []fairsharing.Strategy
 	if fs == nil || len(fs.PreemptionStrategies) == 0 {
-		return []fairsharing.Strategy{fairsharing.LessThanOrEqualToFinalShare, fairsharing.LessThanInitialShare}
+		strategies = []fairsharing.Strategy{fairsharing.LessThanOrEqualToFinalShare, fairsharing.LessThanInitialShare}
+	} else {
+		strategies = make([]fairsharing.Strategy, len(fs.PreemptionStrategies))
+		for i, strategy := range fs.PreemptionStrategies {
+			switch strategy {
+			case config.LessThanOrEqualToFinalShare:
+				strategies[i] = fairsharing.LessThanOrEqualToFinalShare
+			case config.LessThanInitialShare:
+				strategies[i] = fairsharing.LessThanInitialShare
+			}
+		}
 	}
-	strategies := make([]fairsharing.Strategy, len(fs.PreemptionStrategies))
-	for i, strategy := range fs.PreemptionStrategies {
-		switch strategy {
-		case config.LessThanOrEqualToFinalShare:
-			strategies[i] = fairsharing.LessThanOrEqualToFinalShare
-		case config.LessThanInitialShare:
-			strategies[i] = fairsharing.LessThanInitialShare
+	if fs != nil && ptr.Deref(fs.PreemptionThreshold, 0) > 0 {
+		for i := range strategies {
+			strategies[i] = fairsharing.WithThreshold(strategies[i], float64(*fs.PreemptionThreshold))
 		}
 	}
 	return strategies
@@ -453,11 +555,11 @@ func runFirstFsStrategy(preemptionCtx *preemptionCtx, candidates []*workload.Inf
 
 // runSecondFsStrategy implements Fair Sharing Rule S2-b. It returns
 // (fits, targets).
-func runSecondFsStrategy(retryCandidates []*workload.Info, preemptionCtx *preemptionCtx, targets []*Target) (bool, []*Target) {
+func runSecondFsStrategy(retryCandidates []*workload.Info, preemptionCtx *preemptionCtx, targets []*Target, strategy fairsharing.Strategy) (bool, []*Target) {
 	ordering := fairsharing.MakeClusterQueueOrdering(preemptionCtx.preemptorCQ, retryCandidates, preemptionCtx.log, preemptionCtx.clock)
 	for candCQ := range ordering.Iter() {
 		preemptorNewShare, targetOldShare := candCQ.ComputeShares()
-		passed := fairsharing.LessThanInitialShare(preemptorNewShare, targetOldShare, fairsharing.TargetNewShare{})
+		passed := strategy(preemptorNewShare, targetOldShare, fairsharing.TargetNewShare{})
 		// The criteria doesn't depend on the preempted workload, so just preempt the first candidate.
 		candWl := candCQ.PopWorkload()
 		if logV := preemptionCtx.log.V(4); logV.Enabled() {
@@ -494,7 +596,7 @@ func (p *Preemptor) fairPreemptions(preemptionCtx *preemptionCtx, strategies []f
 		return nil
 	}
 	slices.SortFunc(candidates, func(a, b *workload.Info) int {
-		return preemptioncommon.CandidatesOrdering(preemptionCtx.log, p.enabledAfs, a, b, preemptionCtx.preemptorCQ.Name, p.clock.Now())
+		return utilpreemption.CandidatesOrdering(preemptionCtx.log, p.enabledAfs, a, b, preemptionCtx.preemptorCQ.Name, p.clock.Now())
 	})
 	if logV := preemptionCtx.log.V(5); logV.Enabled() {
 		logV.Info(
@@ -519,7 +621,7 @@ func (p *Preemptor) fairPreemptions(preemptionCtx *preemptionCtx, strategies []f
 				"targets", logging.GetObjectReferences(targets),
 				"retryCandidates", workload.References(retryCandidates))
 		}
-		fits, targets = runSecondFsStrategy(retryCandidates, preemptionCtx, targets)
+		fits, targets = runSecondFsStrategy(retryCandidates, preemptionCtx, targets, strategies[1])
 	}
 
 	revertSimulation()
@@ -560,11 +662,16 @@ func findCandidatesForPolicy(
 	wl *kueue.Workload,
 	workloadsToFilter map[workload.Reference]*workload.Info,
 	policy kueue.PreemptionPolicy,
+	cooldownSeconds *int32,
 	frsNeedPreemption sets.Set[resources.FlavorResource],
 	workloadOrdering workload.Ordering,
+	now time.Time,
 ) []*workload.Info {
 	var candidates []*workload.Info
 	for _, candidateWl := range workloadsToFilter {
+		if preemptioncommon.InPreemptionCooldown(candidateWl.Obj, cooldownSeconds, now) {
+			continue
+		}
 		if !preemptioncommon.SatisfiesPreemptionPolicy(
 			log,
 			wl,
@@ -589,7 +696,7 @@ func (p *Preemptor) findCandidates(log logr.Logger, wl *kueue.Workload, cq *schd
 	var candidates []*workload.Info
 
 	if cq.Preemption.WithinClusterQueue != kueue.PreemptionPolicyNever {
-		newCandidates := findCandidatesForPolicy(log, wl, cq.Workloads, cq.Preemption.WithinClusterQueue, frsNeedPreemption, p.workloadOrdering)
+		newCandidates := findCandidatesForPolicy(log, wl, cq.Workloads, cq.Preemption.WithinClusterQueue, cq.Preemption.CooldownSeconds, frsNeedPreemption, p.workloadOrdering, p.clock.Now())
 		candidates = append(candidates, newCandidates...)
 	}
 
@@ -599,7 +706,7 @@ func (p *Preemptor) findCandidates(log logr.Logger, wl *kueue.Workload, cq *schd
 				// Can't reclaim quota from itself or ClusterQueues that are not borrowing.
 				continue
 			}
-			newCandidates := findCandidatesForPolicy(log, wl, cohortCQ.Workloads, cq.Preemption.ReclaimWithinCohort, frsNeedPreemption, p.workloadOrdering)
+			newCandidates := findCandidatesForPolicy(log, wl, cohortCQ.Workloads, cq.Preemption.ReclaimWithinCohort, cohortCQ.Preemption.CooldownSeconds, frsNeedPreemption, p.workloadOrdering, p.clock.Now())
 			candidates = append(candidates, newCandidates...)
 		}
 	}
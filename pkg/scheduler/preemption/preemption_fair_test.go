@@ -93,15 +93,16 @@ func TestFairPreemptions(t *testing.T) {
 	}
 	unitWl := *utiltestingapi.MakeWorkload("unit", "").Request(corev1.ResourceCPU, "1")
 	cases := map[string]struct {
-		clusterQueues    []*kueue.ClusterQueue
-		cohorts          []*kueue.Cohort
-		flavors          []*kueue.ResourceFlavor
-		assignmentFlavor kueue.ResourceFlavorReference
-		strategies       []config.PreemptionStrategy
-		admitted         []kueue.Workload
-		incoming         *kueue.Workload
-		targetCQ         kueue.ClusterQueueReference
-		wantPreempted    sets.Set[string]
+		clusterQueues       []*kueue.ClusterQueue
+		cohorts             []*kueue.Cohort
+		flavors             []*kueue.ResourceFlavor
+		assignmentFlavor    kueue.ResourceFlavorReference
+		strategies          []config.PreemptionStrategy
+		preemptionThreshold *int32
+		admitted            []kueue.Workload
+		incoming            *kueue.Workload
+		targetCQ            kueue.ClusterQueueReference
+		wantPreempted       sets.Set[string]
 	}{
 		"reclaim nominal from user using the most": {
 			clusterQueues: baseCQs,
@@ -120,6 +121,41 @@ func TestFairPreemptions(t *testing.T) {
 			targetCQ:      "c",
 			wantPreempted: sets.New(targetKeyReason("/b1", kueue.InCohortReclamationReason)),
 		},
+		"no preemption when the imbalance is within the preemption threshold": {
+			clusterQueues:       baseCQs,
+			preemptionThreshold: ptr.To[int32](250),
+			admitted: []kueue.Workload{
+				*unitWl.Clone().Name("a1").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a2").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a3").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a4").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a5").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a6").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("b1").SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b2").SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b3").SimpleReserveQuota("b", "default", now).Obj(),
+			},
+			incoming: unitWl.Clone().Name("b_incoming").Obj(),
+			targetCQ: "b",
+		},
+		"preemption when the imbalance exceeds the preemption threshold": {
+			clusterQueues:       baseCQs,
+			preemptionThreshold: ptr.To[int32](200),
+			admitted: []kueue.Workload{
+				*unitWl.Clone().Name("a1").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a2").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a3").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a4").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a5").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a6").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("b1").SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b2").SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b3").SimpleReserveQuota("b", "default", now).Obj(),
+			},
+			incoming:      unitWl.Clone().Name("b_incoming").Obj(),
+			targetCQ:      "b",
+			wantPreempted: sets.New(targetKeyReason("/a1", kueue.InCohortFairSharingReason)),
+		},
 		"can reclaim from queue using less, if taking the latest workload from user using the most isn't enough": {
 			clusterQueues: baseCQs,
 			admitted: []kueue.Workload{
@@ -149,6 +185,50 @@ func TestFairPreemptions(t *testing.T) {
 			targetCQ:      "a",
 			wantPreempted: sets.New(targetKeyReason("/b1", kueue.InCohortFairSharingReason)),
 		},
+		"under-share CQ reclaims borrowed quota from over-share CQ at equal priority": {
+			clusterQueues: baseCQs,
+			admitted: []kueue.Workload{
+				*unitWl.Clone().Name("a1").Priority(10).SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a2").Priority(10).SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a3").Priority(10).SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("b1").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b2").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b3").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b4").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b5").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("c1").Priority(10).SimpleReserveQuota("c", "default", now).Obj(),
+			},
+			incoming:      unitWl.Clone().Name("a_incoming").Priority(10).Obj(),
+			targetCQ:      "a",
+			wantPreempted: sets.New(targetKeyReason("/b1", kueue.InCohortFairSharingReason)),
+		},
+		"under-share CQ can't preempt equal priority workloads when reclaimWithinCohort is LowerPriority": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltestingapi.MakeClusterQueue("a").
+					Cohort("all").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "3").Obj()).
+					Preemption(kueue.ClusterQueuePreemption{
+						ReclaimWithinCohort: kueue.PreemptionPolicyLowerPriority,
+					}).
+					Obj(),
+				utiltestingapi.MakeClusterQueue("b").
+					Cohort("all").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "3").Obj()).
+					Obj(),
+			},
+			admitted: []kueue.Workload{
+				*unitWl.Clone().Name("a1").Priority(10).SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("b1").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b2").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b3").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b4").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+				*unitWl.Clone().Name("b5").Priority(10).SimpleReserveQuota("b", "default", now).Obj(),
+			},
+			incoming: utiltestingapi.MakeWorkload("a_incoming", "").Request(corev1.ResourceCPU, "2").Priority(10).Obj(),
+			targetCQ: "a",
+		},
 		"preempt one from each CQ borrowing": {
 			clusterQueues: baseCQs,
 			admitted: []kueue.Workload{
@@ -974,6 +1054,7 @@ func TestFairPreemptions(t *testing.T) {
 			recorder := &utiltesting.EventRecorder{}
 			preemptor := New(cl, workload.Ordering{}, recorder, &config.FairSharing{
 				PreemptionStrategies: tc.strategies,
+				PreemptionThreshold:  tc.preemptionThreshold,
 			}, false, clocktesting.NewFakeClock(now), nil, preemptexpectations.New(), nil)
 
 			beforeSnapshot, err := cqCache.Snapshot(ctx)
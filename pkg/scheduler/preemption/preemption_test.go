@@ -22,9 +22,12 @@ import (
 	"fmt"
 	"math"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
@@ -42,8 +45,8 @@ import (
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
-	preemptioncommon "sigs.k8s.io/kueue/pkg/scheduler/preemption/common"
 	preemptexpectations "sigs.k8s.io/kueue/pkg/scheduler/preemption/expectations"
+	utilpreemption "sigs.k8s.io/kueue/pkg/util/preemption"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
@@ -281,6 +284,7 @@ func TestPreemption(t *testing.T) {
 		Label(controllerconstants.JobUIDLabel, "job-in")
 
 	cases := map[string]struct {
+		featureGates  map[featuregate.Feature]bool
 		clusterQueues []*kueue.ClusterQueue
 		cohorts       []*kueue.Cohort
 		admitted      []kueue.Workload
@@ -394,6 +398,146 @@ func TestPreemption(t *testing.T) {
 					Obj(),
 			},
 		},
+		"non-preemptible workload is not preempted by a higher priority workload": {
+			featureGates:  map[featuregate.Feature]bool{features.NonPreemptibleWorkloads: true},
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("low", "").
+					Priority(-1).
+					Annotation(controllerconstants.NonPreemptibleAnnotationKey, "true").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("standalone").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								Assignment(corev1.ResourceCPU, "default", "6000m").
+								Obj()).
+							Obj(),
+						now,
+					).
+					Obj(),
+			},
+			incoming: baseIncomingWl.Clone().
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: 0,
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("low", "").
+					Priority(-1).
+					Annotation(controllerconstants.NonPreemptibleAnnotationKey, "true").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("standalone").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								Assignment(corev1.ResourceCPU, "default", "6000m").
+								Obj()).
+							Obj(),
+						now,
+					).
+					Obj(),
+			},
+		},
+		"workload within the preemption cooldown of its ClusterQueue is not preempted": {
+			featureGates: map[featuregate.Feature]bool{features.PreemptionCooldown: true},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltestingapi.MakeClusterQueue("standalone").
+					ResourceGroup(
+						*utiltestingapi.MakeFlavorQuotas("default").
+							Resource(corev1.ResourceCPU, "4").
+							Obj(),
+					).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+						CooldownSeconds:    new(int32(60)),
+					}).
+					Obj(),
+			},
+			admitted: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("recent", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("standalone").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								Assignment(corev1.ResourceCPU, "default", "2000m").
+								Obj()).
+							Obj(),
+						now.Add(-10*time.Second),
+					).
+					Obj(),
+				*utiltestingapi.MakeWorkload("old", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("standalone").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								Assignment(corev1.ResourceCPU, "default", "2000m").
+								Obj()).
+							Obj(),
+						now.Add(-2*time.Minute),
+					).
+					Obj(),
+			},
+			incoming: baseIncomingWl.Clone().
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: 1,
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("old", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("standalone").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								Assignment(corev1.ResourceCPU, "default", "2000m").
+								Obj()).
+							Obj(),
+						now.Add(-2*time.Minute),
+					).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Preempted",
+						Message:            "Preempted to accommodate a workload (UID: wl-in, JobUID: job-in) due to prioritization in the ClusterQueue; preemptor path: /standalone; preemptee path: /standalone",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadPreempted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "InClusterQueue",
+						Message:            "Preempted to accommodate a workload (UID: wl-in, JobUID: job-in) due to prioritization in the ClusterQueue; preemptor path: /standalone; preemptee path: /standalone",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{Reason: "Preempted", Count: 1}).
+					RequeueState(nil, new(metav1.NewTime(now.Add(time.Minute)))).
+					Obj(),
+				*utiltestingapi.MakeWorkload("recent", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltestingapi.MakeAdmission("standalone").
+							PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+								Assignment(corev1.ResourceCPU, "default", "2000m").
+								Obj()).
+							Obj(),
+						now.Add(-10*time.Second),
+					).
+					Obj(),
+			},
+		},
 		"preempt multiple": {
 			clusterQueues: defaultClusterQueues,
 			admitted: []kueue.Workload{
@@ -4103,6 +4247,7 @@ func TestPreemption(t *testing.T) {
 	for name, tc := range cases {
 		for _, useMergePatch := range []bool{false, true} {
 			t.Run(fmt.Sprintf("%s when the WorkloadRequestUseMergePatch feature is %t", name, useMergePatch), func(t *testing.T) {
+				features.SetFeatureGatesDuringTest(t, tc.featureGates)
 				features.SetFeatureGateDuringTest(t, features.WorkloadRequestUseMergePatch, useMergePatch)
 
 				ctx, log := utiltesting.ContextWithLog(t)
@@ -4389,6 +4534,260 @@ func TestPreemptionWhenWorkloadModifiedConcurrently(t *testing.T) {
 	}
 }
 
+func TestClassicalPreemptionDecisionLogs(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	rf := utiltestingapi.MakeResourceFlavor("default").Obj()
+	cq := utiltestingapi.MakeClusterQueue("standalone").
+		ResourceGroup(
+			*utiltestingapi.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Obj(),
+		).
+		Preemption(kueue.ClusterQueuePreemption{
+			WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+		}).
+		Obj()
+	admission := utiltestingapi.MakeAdmission("standalone").
+		PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+			Assignment(corev1.ResourceCPU, "default", "2").
+			Obj()).
+		Obj()
+	workloads := []kueue.Workload{
+		*utiltestingapi.MakeWorkload("low", "default").
+			Priority(-1).
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(admission, now).
+			Obj(),
+		*utiltestingapi.MakeWorkload("high", "default").
+			Priority(2).
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(admission, now).
+			Obj(),
+	}
+	incoming := utiltestingapi.MakeWorkload("in", "default").
+		Priority(1).
+		Request(corev1.ResourceCPU, "2").
+		Obj()
+
+	var logs []string
+	log := funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{Verbosity: 5})
+	ctx := logr.NewContext(t.Context(), log)
+
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: workloads}).
+		WithStatusSubresource(&kueue.Workload{}).
+		Build()
+	cqCache := schdcache.New(cl)
+	cqCache.AddOrUpdateResourceFlavor(log, rf)
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	for i := range workloads {
+		cqCache.AddOrUpdateWorkload(log, &workloads[i])
+	}
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+
+	preemptor := New(cl, workload.Ordering{}, &utiltesting.EventRecorder{}, nil, false, clocktesting.NewFakeClock(now), nil, preemptexpectations.New(), nil)
+	wlInfo := workload.NewInfo(incoming)
+	wlInfo.ClusterQueue = kueue.ClusterQueueReference(cq.Name)
+	targets := preemptor.GetTargets(log, *wlInfo, singlePodSetAssignment(flavorassigner.ResourceAssignment{
+		corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+			Name: kueue.ResourceFlavorReference(rf.Name),
+			Mode: flavorassigner.Preempt,
+		},
+	}), snapshot)
+	if diff := cmp.Diff([]workload.Reference{"default/low"}, utilslices.Map(targets, func(t **Target) workload.Reference { return workload.Key((*t).WorkloadInfo.Obj) })); diff != "" {
+		t.Errorf("Unexpected targets (-want,+got):\n%s", diff)
+	}
+
+	wantLogs := []string{
+		`"msg"="Rejected preemption candidate" "candidate"={"name"="high" "namespace"="default"} "candidateClusterQueue"={"name"="standalone"} "reason"="doesn't satisfy the LowerPriority preemption policy"`,
+		`"msg"="Ordered preemption candidates" "preemptingWorkload"={"name"="in" "namespace"="default"} "candidates"=["default/low (clusterQueue: standalone, reason: InClusterQueue)"]`,
+		`"msg"="Simulating classical preemption" "preemptingWorkload"={"name"="in" "namespace"="default"}`,
+		`"msg"="Selected preemption candidate" "candidate"={"name"="low" "namespace"="default"} "candidateClusterQueue"={"name"="standalone"} "reason"="InClusterQueue" "allowBorrowing"=true "workloadFits"=true`,
+		`"msg"="Classical preemption succeeded"`,
+	}
+	for _, want := range wantLogs {
+		if !slices.ContainsFunc(logs, func(l string) bool { return strings.Contains(l, want) }) {
+			t.Errorf("Missing log containing %s, got logs:\n%s", want, strings.Join(logs, "\n"))
+		}
+	}
+}
+
+func TestPreemptionStages(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	rf := utiltestingapi.MakeResourceFlavor("default").Obj()
+	cqs := []*kueue.ClusterQueue{
+		utiltestingapi.MakeClusterQueue("a").
+			Cohort("all").
+			ResourceGroup(
+				*utiltestingapi.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "4").
+					Obj(),
+			).
+			Obj(),
+		utiltestingapi.MakeClusterQueue("b").
+			Cohort("all").
+			ResourceGroup(
+				*utiltestingapi.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "2").
+					Obj(),
+			).
+			Obj(),
+	}
+	makeAdmission := func(cq kueue.ClusterQueueReference, cpu string) *kueue.Admission {
+		return utiltestingapi.MakeAdmission(cq).
+			PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+				Assignment(corev1.ResourceCPU, "default", cpu).
+				Obj()).
+			Obj()
+	}
+	cases := map[string]struct {
+		stages           []kueue.PreemptionStage
+		disableGate      bool
+		inQueuePriority  int32
+		wantTargets      []workload.Reference
+		wantStagesLogged []kueue.PreemptionStage
+	}{
+		"no stages; reclaim from the cohort first": {
+			inQueuePriority: -1,
+			wantTargets:     []workload.Reference{"default/b-borrowing"},
+		},
+		"preempt within the ClusterQueue before reclaiming within the cohort": {
+			stages: []kueue.PreemptionStage{
+				kueue.PreemptionStageWithinClusterQueue,
+				kueue.PreemptionStageReclaimWithinCohort,
+			},
+			inQueuePriority:  -1,
+			wantTargets:      []workload.Reference{"default/a-in-queue"},
+			wantStagesLogged: []kueue.PreemptionStage{kueue.PreemptionStageWithinClusterQueue},
+		},
+		"reclaim within the cohort before preempting within the ClusterQueue": {
+			stages: []kueue.PreemptionStage{
+				kueue.PreemptionStageReclaimWithinCohort,
+				kueue.PreemptionStageWithinClusterQueue,
+			},
+			inQueuePriority:  -1,
+			wantTargets:      []workload.Reference{"default/b-borrowing"},
+			wantStagesLogged: []kueue.PreemptionStage{kueue.PreemptionStageReclaimWithinCohort},
+		},
+		"reclaim within the cohort when preempting within the ClusterQueue isn't possible": {
+			stages: []kueue.PreemptionStage{
+				kueue.PreemptionStageWithinClusterQueue,
+				kueue.PreemptionStageFlavorDowngrade,
+				kueue.PreemptionStageReclaimWithinCohort,
+			},
+			inQueuePriority: 2,
+			wantTargets:     []workload.Reference{"default/b-borrowing"},
+			wantStagesLogged: []kueue.PreemptionStage{
+				kueue.PreemptionStageWithinClusterQueue,
+				kueue.PreemptionStageReclaimWithinCohort,
+			},
+		},
+		"no targets when the stages don't free enough quota": {
+			stages: []kueue.PreemptionStage{
+				kueue.PreemptionStageWithinClusterQueue,
+			},
+			inQueuePriority:  2,
+			wantStagesLogged: []kueue.PreemptionStage{kueue.PreemptionStageWithinClusterQueue},
+		},
+		"stages are ignored when the feature gate is disabled": {
+			stages: []kueue.PreemptionStage{
+				kueue.PreemptionStageWithinClusterQueue,
+				kueue.PreemptionStageReclaimWithinCohort,
+			},
+			disableGate:     true,
+			inQueuePriority: -1,
+			wantTargets:     []workload.Reference{"default/b-borrowing"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PreemptionStages, !tc.disableGate)
+			workloads := []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a-in-queue", "default").
+					Priority(tc.inQueuePriority).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(makeAdmission("a", "2"), now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("b-borrowing", "default").
+					Priority(0).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuotaAt(makeAdmission("b", "4"), now).
+					Obj(),
+			}
+			incoming := utiltestingapi.MakeWorkload("in", "default").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj()
+
+			var logs []string
+			log := funcr.New(func(prefix, args string) {
+				logs = append(logs, args)
+			}, funcr.Options{Verbosity: 5})
+			ctx := logr.NewContext(t.Context(), log)
+
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: workloads}).
+				WithStatusSubresource(&kueue.Workload{}).
+				Build()
+			cqCache := schdcache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(log, rf)
+			for _, cq := range cqs {
+				cq = cq.DeepCopy()
+				cq.Spec.Preemption = &kueue.ClusterQueuePreemption{
+					ReclaimWithinCohort: kueue.PreemptionPolicyAny,
+					WithinClusterQueue:  kueue.PreemptionPolicyLowerPriority,
+					Stages:              tc.stages,
+				}
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+				}
+			}
+			for i := range workloads {
+				cqCache.AddOrUpdateWorkload(log, &workloads[i])
+			}
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+
+			preemptor := New(cl, workload.Ordering{}, &utiltesting.EventRecorder{}, nil, false, clocktesting.NewFakeClock(now), nil, preemptexpectations.New(), nil)
+			wlInfo := workload.NewInfo(incoming)
+			wlInfo.ClusterQueue = "a"
+			targets := preemptor.GetTargets(log, *wlInfo, singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: kueue.ResourceFlavorReference(rf.Name),
+					Mode: flavorassigner.Preempt,
+				},
+			}), snapshot)
+			if diff := cmp.Diff(tc.wantTargets, utilslices.Map(targets, func(t **Target) workload.Reference { return workload.Key((*t).WorkloadInfo.Obj) })); diff != "" {
+				t.Errorf("Unexpected targets (-want,+got):\n%s", diff)
+			}
+
+			var gotStagesLogged []kueue.PreemptionStage
+			for _, l := range logs {
+				if !strings.Contains(l, `"msg"="Attempting preemption stage"`) {
+					continue
+				}
+				for _, stage := range tc.stages {
+					if strings.Contains(l, fmt.Sprintf(`"stage"=%q`, stage)) {
+						gotStagesLogged = append(gotStagesLogged, stage)
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.wantStagesLogged, gotStagesLogged); diff != "" {
+				t.Errorf("Unexpected attempted stages (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestIssuePreemptionsCountsFailures(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
 	ctx, log := utiltesting.ContextWithLog(t)
@@ -4765,7 +5164,7 @@ func TestCandidatesOrdering(t *testing.T) {
 	for _, tc := range cases {
 		features.SetFeatureGatesDuringTest(t, tc.featureGates)
 		slices.SortFunc(tc.candidates, func(a, b workload.Info) int {
-			return preemptioncommon.CandidatesOrdering(log, tc.featureGates != nil && tc.featureGates[features.AdmissionFairSharing], &a, &b, kueue.ClusterQueueReference(preemptorCq), now)
+			return utilpreemption.CandidatesOrdering(log, tc.featureGates != nil && tc.featureGates[features.AdmissionFairSharing], &a, &b, kueue.ClusterQueueReference(preemptorCq), now)
 		})
 		got := utilslices.Map(tc.candidates, func(c *workload.Info) workload.Reference {
 			return workload.Reference(c.Obj.Name)
@@ -24,15 +24,18 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -48,16 +51,19 @@ import (
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	preemptioncommon "sigs.k8s.io/kueue/pkg/scheduler/preemption/common"
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption/fairsharing"
 	afs "sigs.k8s.io/kueue/pkg/util/admissionfairsharing"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	"sigs.k8s.io/kueue/pkg/util/expectations"
+	"sigs.k8s.io/kueue/pkg/util/logging"
 	"sigs.k8s.io/kueue/pkg/util/priority"
 	utilqueue "sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	"sigs.k8s.io/kueue/pkg/util/wait"
 	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workload/audit"
 	"sigs.k8s.io/kueue/pkg/workload/concurrentadmission"
 	workloadevict "sigs.k8s.io/kueue/pkg/workload/evict"
 	workloadfinish "sigs.k8s.io/kueue/pkg/workload/finish"
@@ -87,6 +93,23 @@ type Scheduler struct {
 	clock                   clock.Clock
 	roleTracker             *roletracker.RoleTracker
 	customLabels            *metrics.CustomLabels
+	workers                 int
+
+	// cohortUsageShares holds the cohort usage shares of the ClusterQueues last reported
+	// to the metrics, so that only the changed ones are reported.
+	cohortUsageShares map[kueue.ClusterQueueReference]reportedCohortUsageShare
+
+	// dryRunPreemptionsMu protects dryRunPreemptions.
+	dryRunPreemptionsMu sync.Mutex
+	// dryRunPreemptions holds the preemption targets last reported for the
+	// pending workloads of the ClusterQueues which preempt in the DryRun mode,
+	// so that an event is only emitted when they change.
+	dryRunPreemptions map[workload.Reference]dryRunPreemption
+
+	// admissionMu serializes the admission of workloads processed by
+	// different workers, so that admission blocked by WaitForPodsReady
+	// observes the workloads admitted by the other workers.
+	admissionMu sync.Mutex
 
 	// schedulingCycle identifies the number of scheduling
 	// attempts since the last restart.
@@ -102,6 +125,7 @@ type options struct {
 	roleTracker                 *roletracker.RoleTracker
 	preemptionExpectations      *expectations.Store
 	customLabels                *metrics.CustomLabels
+	workers                     int
 }
 
 // Option configures the reconciler.
@@ -110,6 +134,7 @@ type Option func(*options)
 var defaultOptions = options{
 	podsReadyRequeuingTimestamp: config.EvictionTimestamp,
 	clock:                       realClock,
+	workers:                     1,
 }
 
 // WithPodsReadyRequeuingTimestamp sets the timestamp that is used for ordering
@@ -167,6 +192,14 @@ func WithQuotaCheckStrategy(qcs config.QuotaCheckStrategy) Option {
 	}
 }
 
+// WithWorkers sets the number of workers that process the entries of
+// independent cohorts concurrently in a scheduling cycle.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
 func New(queues *qcache.Manager, cache *schdcache.Cache, cl client.Client, recorder events.EventRecorder, opts ...Option) *Scheduler {
 	options := defaultOptions
 	for _, opt := range opts {
@@ -199,6 +232,9 @@ func New(queues *qcache.Manager, cache *schdcache.Cache, cl client.Client, recor
 		quotaCheckStrategy:      options.quotaCheckStrategy,
 		roleTracker:             options.roleTracker,
 		customLabels:            options.customLabels,
+		workers:                 options.workers,
+		cohortUsageShares:       make(map[kueue.ClusterQueueReference]reportedCohortUsageShare),
+		dryRunPreemptions:       make(map[workload.Reference]dryRunPreemption),
 	}
 	return s
 }
@@ -283,6 +319,53 @@ func (s *Scheduler) reportSkippedPreemptions(p map[kueue.ClusterQueueReference]i
 	}
 }
 
+// reportedCohortUsageShare is the cohort usage share of a ClusterQueue reported to the metrics,
+// with the labels of its series.
+type reportedCohortUsageShare struct {
+	cohort            kueue.CohortReference
+	role              string
+	customLabelValues []string
+	share             float64
+}
+
+func (r *reportedCohortUsageShare) sameLabels(other *reportedCohortUsageShare) bool {
+	return r.cohort == other.cohort && r.role == other.role && slices.Equal(r.customLabelValues, other.customLabelValues)
+}
+
+// reportCohortUsageShares reports the cohort usage share of the ClusterQueues of the snapshot
+// within their cohorts, when it changed since the last report. It removes the
+// series of the ClusterQueues which left their cohort.
+func (s *Scheduler) reportCohortUsageShares(snapshot *schdcache.Snapshot) {
+	clusterQueues := snapshot.ClusterQueues()
+	for cqName := range s.cohortUsageShares {
+		if cq, found := clusterQueues[cqName]; !found || !cq.HasParent() {
+			metrics.ClearClusterQueueCohortUsageShare(cqName)
+			delete(s.cohortUsageShares, cqName)
+		}
+	}
+	for cqName, cq := range clusterQueues {
+		if !cq.HasParent() {
+			continue
+		}
+		current := reportedCohortUsageShare{
+			cohort:            cq.Parent().Name,
+			role:              roletracker.GetRole(s.roleTracker),
+			customLabelValues: s.customLabels.CQGet(cqName),
+			share:             cq.CohortUsageShare(),
+		}
+		previous, found := s.cohortUsageShares[cqName]
+		switch {
+		case found && previous.sameLabels(&current) && previous.share == current.share:
+			continue
+		case found && !previous.sameLabels(&current):
+			// Remove the series with the previous labels.
+			metrics.ClearClusterQueueCohortUsageShare(cqName)
+		}
+		metrics.ReportClusterQueueCohortUsageShare(cqName, current.cohort, current.share, current.customLabelValues, s.roleTracker)
+		s.cohortUsageShares[cqName] = current
+	}
+}
+
 func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	s.schedulingCycle++
 	log := roletracker.WithReplicaRole(ctrl.LoggerFrom(ctx), s.roleTracker).WithValues("schedulingCycle", s.schedulingCycle)
@@ -317,28 +400,25 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	}
 	logSnapshotIfVerbose(log, snapshot)
 	log.V(2).Info("Snapshot taken", "duration", s.clock.Since(phaseStartTime))
+	if fairsharing.Enabled(s.fairSharing) {
+		s.reportCohortUsageShares(snapshot)
+	}
+	s.pruneDryRunPreemptions(ctx, snapshot)
 
 	// 3. Calculate requirements (resource flavors, borrowing) for admitting workloads.
 	phaseStartTime = s.clock.Now()
 	entries, inadmissibleEntries := s.nominate(ctx, headWorkloads, snapshot)
 	log.V(2).Info("Nomination done", "entries", len(entries), "inadmissibleEntries", len(inadmissibleEntries), "duration", s.clock.Since(phaseStartTime))
 
-	// 4. Create iterator which returns ordered entries.
-	iterator := makeIterator(ctx, entries, s.workloadOrdering, fairsharing.Enabled(s.fairSharing))
-
-	// 5. Admit entries, ensuring that no more than one workload gets
+	// 4. Admit entries, ensuring that no more than one workload gets
 	// admitted by a cohort (if borrowing).
 	// This is because there can be other workloads deeper in a clusterQueue whose
 	// head got admitted that should be scheduled in the cohort before the heads
 	// of other clusterQueues.
 	phaseStartTime = s.clock.Now()
-	preemptedWorkloads := make(preemption.PreemptedWorkloads)
-	skippedPreemptions := make(map[kueue.ClusterQueueReference]int)
-	for iterator.hasNext() {
-		s.processEntry(ctx, iterator.pop(), snapshot, preemptedWorkloads, skippedPreemptions)
-	}
+	skippedPreemptions := s.processEntries(ctx, entries, snapshot)
 
-	// 6. Requeue the heads that were not scheduled.
+	// 5. Requeue the heads that were not scheduled.
 	result := metrics.AdmissionResultInadmissible
 	for _, e := range entries {
 		logAdmissionAttemptIfVerbose(log, &e)
@@ -364,6 +444,41 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	return wait.KeepGoing
 }
 
+// processEntries admits the entries, in the order of an iterator. When
+// configured with more than one worker, the entries are split into groups
+// that don't interact, which are processed concurrently; the entries of each
+// group are still processed in order.
+// It returns the number of skipped preemptions per ClusterQueue.
+func (s *Scheduler) processEntries(ctx context.Context, entries []entry, snapshot *schdcache.Snapshot) map[kueue.ClusterQueueReference]int {
+	if s.workers <= 1 {
+		return s.processEntryGroup(ctx, entries, snapshot)
+	}
+	groups := independentEntryGroups(entries, snapshot)
+	ctrl.LoggerFrom(ctx).V(3).Info("Processing independent groups of entries concurrently", "groups", len(groups), "workers", s.workers)
+	groupSkippedPreemptions := make([]map[kueue.ClusterQueueReference]int, len(groups))
+	workqueue.ParallelizeUntil(ctx, s.workers, len(groups), func(i int) {
+		groupSkippedPreemptions[i] = s.processEntryGroup(ctx, groups[i], snapshot)
+	})
+	skippedPreemptions := make(map[kueue.ClusterQueueReference]int)
+	for _, groupSkipped := range groupSkippedPreemptions {
+		for cqName, count := range groupSkipped {
+			skippedPreemptions[cqName] += count
+		}
+	}
+	return skippedPreemptions
+}
+
+// processEntryGroup admits the entries in the order of an iterator.
+func (s *Scheduler) processEntryGroup(ctx context.Context, entries []entry, snapshot *schdcache.Snapshot) map[kueue.ClusterQueueReference]int {
+	iterator := makeIterator(ctx, entries, s.workloadOrdering, fairsharing.Enabled(s.fairSharing))
+	preemptedWorkloads := make(preemption.PreemptedWorkloads)
+	skippedPreemptions := make(map[kueue.ClusterQueueReference]int)
+	for iterator.hasNext() {
+		s.processEntry(ctx, iterator.pop(), snapshot, preemptedWorkloads, skippedPreemptions)
+	}
+	return skippedPreemptions
+}
+
 // processEntry runs the admission pipeline for a single entry: TAS replacement,
 // preempt-mode pre-checks, fits/overlap checks, preemption issuance, pods-ready
 // gating, workload-slice replacement, and admission. State (entry status,
@@ -415,6 +530,7 @@ func (s *Scheduler) processEntry(
 	if mode == flavorassigner.Preempt {
 		if len(e.preemptionTargets) == 0 {
 			e.requeueReason = qcache.RequeueReasonPreemptionNoCandidates
+			metrics.ReportPreemptionAttempt(cq.Name, metrics.PreemptionOutcomeNoCandidates, s.customLabels.CQGet(cq.Name), s.roleTracker)
 			e.quotaReservedReason = kueue.WorkloadQuotaReservedReasonWaitingForQuota
 			s.reserveCapacityForUnreclaimablePreempt(log, e, cq)
 			return
@@ -426,6 +542,12 @@ func (s *Scheduler) processEntry(
 			e.markPreemptionGated(gatedMsg)
 			return
 		}
+		if preemptioncommon.IsDryRun(cq.Preemption) {
+			if targets, _ := workloadslicing.FindReplacedSliceTarget(e.Obj, e.preemptionTargets); len(targets) > 0 {
+				s.reportDryRunPreemptions(log, e, cq, targets)
+				return
+			}
+		}
 	}
 
 	// We skip multiple-preemptions per cohort if any of the targets are overlapping
@@ -433,6 +555,7 @@ func (s *Scheduler) processEntry(
 		e.markSkipped("Workload has overlapping preemption targets with another workload")
 		e.quotaReservedReason = kueue.WorkloadQuotaReservedReasonWaitingForQuota
 		skippedPreemptions[cq.Name]++
+		metrics.ReportPreemptionAttempt(cq.Name, metrics.PreemptionOutcomeSkipped, s.customLabels.CQGet(cq.Name), s.roleTracker)
 		return
 	}
 
@@ -441,11 +564,13 @@ func (s *Scheduler) processEntry(
 		e.quotaReservedReason = kueue.WorkloadQuotaReservedReasonWaitingForQuota
 		if mode == flavorassigner.Preempt {
 			skippedPreemptions[cq.Name]++
+			metrics.ReportPreemptionAttempt(cq.Name, metrics.PreemptionOutcomeSkipped, s.customLabels.CQGet(cq.Name), s.roleTracker)
 		}
 		return
 	}
 	preemptedWorkloads.Insert(e.preemptionTargets)
 	cq.AddUsage(usage)
+	cq.AddLocalQueueUsage(utilqueue.KeyFromWorkload(e.Obj), usage.Quota)
 
 	// Filter out the old workload slice from the preemption targets.
 	// The old workload slice is initially included in the preemption targets because it is treated
@@ -459,6 +584,8 @@ func (s *Scheduler) processEntry(
 		return
 	}
 
+	s.admissionMu.Lock()
+	defer s.admissionMu.Unlock()
 	s.waitForPodsReadyIfBlocked(ctx, log, e)
 
 	// Copy ClusterName from old slice before admission (needed for MultiKueue).
@@ -521,6 +648,61 @@ func (s *Scheduler) issueMigration(ctx context.Context, log logr.Logger, e *entr
 	e.inadmissibleMsg += ". Pending the migration of 1 workload(s)"
 }
 
+// dryRunPreemption is the preemption targets reported for a pending workload
+// of a ClusterQueue which preempts in the DryRun mode.
+type dryRunPreemption struct {
+	workload     client.ObjectKey
+	clusterQueue kueue.ClusterQueueReference
+	message      string
+}
+
+// reportDryRunPreemptions reports the preemption targets of the entry, without
+// evicting them, for the ClusterQueues which preempt in the DryRun mode.
+// The entry stays pending, as if it couldn't preempt. The event is only
+// emitted when the targets changed since the last report for the workload.
+func (s *Scheduler) reportDryRunPreemptions(log logr.Logger, e *entry, cq *schdcache.ClusterQueueSnapshot, targets []*preemption.Target) {
+	log.V(2).Info("Workload requires preemption, but the ClusterQueue preempts in the DryRun mode", "targets", logging.GetObjectReferences(targets))
+	victims := make([]string, len(targets))
+	for i, target := range targets {
+		victims[i] = fmt.Sprintf("%s (%s)", klog.KObj(target.WorkloadInfo.Obj), target.Reason)
+	}
+	slices.Sort(victims)
+	message := fmt.Sprintf("Would preempt %d workload(s): %s", len(targets), strings.Join(victims, ", "))
+	current := dryRunPreemption{workload: client.ObjectKeyFromObject(e.Obj), clusterQueue: cq.Name, message: message}
+
+	s.dryRunPreemptionsMu.Lock()
+	wlKey := workload.Key(e.Obj)
+	changed := s.dryRunPreemptions[wlKey] != current
+	s.dryRunPreemptions[wlKey] = current
+	s.dryRunPreemptionsMu.Unlock()
+	if changed {
+		s.recorder.Eventf(e.Obj, nil, corev1.EventTypeNormal, "PreemptionDryRun", "Preempt", api.TruncateEventMessage(message))
+	}
+	metrics.ReportPreemptionAttempt(cq.Name, metrics.PreemptionOutcomeDryRun, s.customLabels.CQGet(cq.Name), s.roleTracker)
+	e.requeueReason = qcache.RequeueReasonPreemptionNoCandidates
+	e.quotaReservedReason = kueue.WorkloadQuotaReservedReasonWaitingForQuota
+	e.inadmissibleMsg += fmt.Sprintf(". Preemption is in the DryRun mode, would preempt %d workload(s)", len(targets))
+	e.LastAssignment = nil
+}
+
+// pruneDryRunPreemptions forgets the preemption targets reported for the
+// workloads which were deleted or got quota reserved, or whose ClusterQueue
+// no longer preempts in the DryRun mode.
+func (s *Scheduler) pruneDryRunPreemptions(ctx context.Context, snapshot *schdcache.Snapshot) {
+	s.dryRunPreemptionsMu.Lock()
+	defer s.dryRunPreemptionsMu.Unlock()
+	for wlKey, reported := range s.dryRunPreemptions {
+		if cq := snapshot.ClusterQueue(reported.clusterQueue); cq == nil || !preemptioncommon.IsDryRun(cq.Preemption) {
+			delete(s.dryRunPreemptions, wlKey)
+			continue
+		}
+		var wl kueue.Workload
+		if err := s.client.Get(ctx, reported.workload, &wl); err != nil || workload.HasQuotaReservation(&wl) {
+			delete(s.dryRunPreemptions, wlKey)
+		}
+	}
+}
+
 func (s *Scheduler) issuePreemptions(ctx context.Context, log logr.Logger, e *entry, preemptionTargets []*preemption.Target) {
 	preempted, errors, err := s.preemptor.IssuePreemptions(ctx, s.cache, &e.Info, preemptionTargets, e.clusterQueueSnapshot)
 	if err != nil {
@@ -591,6 +773,9 @@ type entry struct {
 	clusterQueueSnapshot *schdcache.ClusterQueueSnapshot
 	quotaReservedReason  string
 	skipStatusUpdate     bool
+	// namespaceLabels are the labels of the workload's namespace, matched
+	// against the namespaceSelector of the ResourceFlavors.
+	namespaceLabels labels.Set
 }
 
 func (e *entry) assignmentUsage(log logr.Logger) workload.Usage {
@@ -625,13 +810,22 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 		} else if workload.HasRetryChecks(w.Obj) || workload.HasRejectedChecks(w.Obj) {
 			e.inadmissibleMsg = "The workload has failed admission checks"
 			e.quotaReservedReason = kueue.WorkloadQuotaReservedReasonPendingEvaluation
+		} else if workload.IsHeld(w.Obj) {
+			e.inadmissibleMsg = workload.HeldMessage
+			e.quotaReservedReason = kueue.WorkloadHeld
+			e.requeueReason = qcache.RequeueReasonHeld
 		} else if snap.InactiveClusterQueueSets.Has(w.ClusterQueue) {
 			e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s is inactive", w.ClusterQueue)
 			e.quotaReservedReason = kueue.WorkloadQuotaReservedReasonSuspended
 		} else if e.clusterQueueSnapshot == nil {
 			e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s not found", w.ClusterQueue)
 			e.quotaReservedReason = kueue.WorkloadQuotaReservedReasonMisconfigured
-		} else if err := workload.ValidateAdmissibility(ctx, s.client, &w, e.clusterQueueSnapshot.NamespaceSelector); err != nil {
+		} else if !workload.HasQuotaReservation(w.Obj) && e.clusterQueueSnapshot.MaxAdmittedWorkloadsReached() {
+			// The limit is checked before the preemption, so the pending
+			// workloads don't preempt to get under it.
+			e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s reached the maximum number of admitted workloads", w.ClusterQueue)
+			e.quotaReservedReason = kueue.WorkloadQuotaReservedReasonWaitingForQuota
+		} else if nsLabels, err := workload.ValidateAdmissibility(ctx, s.client, &w, e.clusterQueueSnapshot.NamespaceSelector); err != nil {
 			e.inadmissibleMsg = err.Error()
 			if errors.Is(err, workload.ErrInternal) {
 				log.Error(err, "Failed to validate workload admissibility")
@@ -643,7 +837,10 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 				}
 			}
 		} else {
-			assignment, targets := s.getAssignments(log, &e.Info, snap)
+			if features.Enabled(features.ResourceFlavorNamespaceSelector) {
+				e.namespaceLabels = nsLabels
+			}
+			assignment, targets := s.getAssignments(log, &e.Info, snap, e.namespaceLabels)
 			e.recordAssignment(assignment, targets)
 			entries = append(entries, e)
 			continue
@@ -659,17 +856,17 @@ func (s *Scheduler) updateAssignmentIfNeeded(log logr.Logger,
 	cq *schdcache.ClusterQueueSnapshot,
 	preemptedWorkloads preemption.PreemptedWorkloads) (workload.Usage, bool) {
 	usage := e.assignmentUsage(log)
-	fitsCheck := fits(snapshot, cq, &usage, preemptedWorkloads, e.preemptionTargets)
+	fitsCheck := fits(snapshot, cq, utilqueue.KeyFromWorkload(e.Obj), &usage, preemptedWorkloads, e.preemptionTargets)
 	if fitsCheck == schdcache.FitsCheckNoTAS && features.Enabled(features.TASRecomputeAssignmentWithinSchedulingCycle) {
 		log.V(2).Info("Re-computing the assignment as it doesn't fit for TAS")
 		// Clear the last assignment so that we can start from the first flavor again and
 		// reach all flavors from the nomination.
 		e.LastAssignment = nil
 		e.NominationMapping = e.readResourceToFlavorMapping()
-		newAssignment, newTargets := s.getAssignments(log, &e.Info, snapshot)
+		newAssignment, newTargets := s.getAssignments(log, &e.Info, snapshot, e.namespaceLabels)
 		e.recordAssignment(newAssignment, newTargets)
 		usage = e.assignmentUsage(log)
-		fitsCheck = fits(snapshot, cq, &usage, preemptedWorkloads, newTargets)
+		fitsCheck = fits(snapshot, cq, utilqueue.KeyFromWorkload(e.Obj), &usage, preemptedWorkloads, newTargets)
 		log.V(2).Info("Re-computed assignment", "newMode", newAssignment.RepresentativeMode())
 		// clear the assignment flavors as they are only used within a single scheduling cycle
 		e.NominationMapping = nil
@@ -677,7 +874,7 @@ func (s *Scheduler) updateAssignmentIfNeeded(log logr.Logger,
 	return usage, schdcache.FitsCheckOk == fitsCheck
 }
 
-func fits(snapshot *schdcache.Snapshot, cq *schdcache.ClusterQueueSnapshot, usage *workload.Usage, preemptedWorkloads preemption.PreemptedWorkloads,
+func fits(snapshot *schdcache.Snapshot, cq *schdcache.ClusterQueueSnapshot, lq utilqueue.LocalQueueReference, usage *workload.Usage, preemptedWorkloads preemption.PreemptedWorkloads,
 	newTargets []*preemption.Target) schdcache.FitsCheck {
 	workloads := slices.Collect(maps.Values(preemptedWorkloads))
 	for _, target := range newTargets {
@@ -685,7 +882,11 @@ func fits(snapshot *schdcache.Snapshot, cq *schdcache.ClusterQueueSnapshot, usag
 	}
 	revertUsage := snapshot.SimulateWorkloadRemoval(workloads)
 	defer revertUsage()
-	return cq.Fits(*usage)
+	fitsCheck := cq.Fits(*usage)
+	if fitsCheck == schdcache.FitsCheckOk && features.Enabled(features.LocalQueueGuaranteedQuota) && !cq.FitsGuaranteedQuota(lq, usage.Quota) {
+		return schdcache.FitsCheckNoQuota
+	}
+	return fitsCheck
 }
 
 // resourcesToReserve calculates how much of the available resources in cq/cohort assignment should be reserved.
@@ -730,8 +931,8 @@ type partialAssignment struct {
 	preemptionTargets []*preemption.Target
 }
 
-func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *schdcache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
-	assignment, targets := s.getInitialAssignments(log, wl, snap)
+func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *schdcache.Snapshot, nsLabels labels.Set) (flavorassigner.Assignment, []*preemption.Target) {
+	assignment, targets := s.getInitialAssignments(log, wl, snap, nsLabels)
 	cq := snap.ClusterQueue(wl.ClusterQueue)
 	updateAssignmentForTAS(log, snap, cq, wl, &assignment, targets)
 	return assignment, targets
@@ -759,11 +960,12 @@ func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *sch
 //     identified during scheduling.
 //
 // If no valid assignment can be made, returns the original full assignment with no preemption targets.
-func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, snap *schdcache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
+func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, snap *schdcache.Snapshot, nsLabels labels.Set) (flavorassigner.Assignment, []*preemption.Target) {
 	cq := snap.ClusterQueue(wl.ClusterQueue)
 
 	preemptionTargets, replaceableWorkloadSlice := workloadslicing.ReplacedWorkloadSlice(wl, snap)
-	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, fairsharing.Enabled(s.fairSharing), preemption.NewOracle(s.preemptor, snap), replaceableWorkloadSlice, s.quotaCheckStrategy)
+	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, fairsharing.Enabled(s.fairSharing), preemption.NewOracle(s.preemptor, snap), replaceableWorkloadSlice, s.quotaCheckStrategy).
+		WithNamespaceLabels(nsLabels)
 	fullAssignment := flvAssigner.Assign(log, nil)
 
 	arm := fullAssignment.RepresentativeMode()
@@ -798,6 +1000,22 @@ func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, sn
 			return pa.assignment, append(preemptionTargets, pa.preemptionTargets...)
 		}
 	}
+
+	if fallbackPodSets := workload.UnconstrainedTopologyFallbackPodSets(wl.Obj, s.clock); fallbackPodSets.Len() > 0 {
+		// The topology requests of some PodSets could not be satisfied for
+		// long enough, try admitting these PodSets without topology assignments.
+		assignment := flvAssigner.WithUnconstrainedTopologyFallback(fallbackPodSets).Assign(log, nil)
+		switch assignment.RepresentativeMode() {
+		case flavorassigner.Fit:
+			log.V(3).Info("Falling back to unconstrained topology", "podSets", sets.List(fallbackPodSets))
+			return assignment, preemptionTargets
+		case flavorassigner.Preempt:
+			if faPreemptionTargets := s.preemptor.GetTargets(log, *wl, assignment, snap); len(faPreemptionTargets) > 0 {
+				log.V(3).Info("Falling back to unconstrained topology", "podSets", sets.List(fallbackPodSets))
+				return assignment, append(preemptionTargets, faPreemptionTargets...)
+			}
+		}
+	}
 	return fullAssignment, nil
 }
 
@@ -927,6 +1145,13 @@ func (s *Scheduler) assumeWorkload(log logr.Logger, e *entry, cq *schdcache.Clus
 	e.markAssumed()
 	log.V(2).Info("Workload assumed in the cache")
 
+	if features.Enabled(features.AdmissionFairSharingWeightedRoundRobin) {
+		s.queues.RecordAdmission(e.Obj)
+	}
+	if features.Enabled(features.WorkloadEstimatedWaitTime) {
+		s.queues.RecordAdmissionThroughput(cq.Name)
+	}
+
 	if afs.Enabled(s.admissionFairSharing) {
 		s.updateEntryPenalty(log, e, add)
 		// Trigger LocalQueue reconciler to apply any pending penalties
@@ -1073,6 +1298,12 @@ func (s *Scheduler) recordQuotaReservationMetrics(log logr.Logger, newWorkload,
 	}
 
 	s.recorder.Eventf(newWorkload, nil, corev1.EventTypeNormal, "QuotaReserved", "QuotaReserved", api.TruncateEventMessage(quotaReservedEventMessage))
+	audit.Emit(audit.Record{
+		Decision: audit.DecisionAdmission,
+		Workload: newWorkload,
+		Reason:   kueue.WorkloadQuotaReserved,
+		Message:  quotaReservedEventMessage,
+	}, s.clock.Now(), s.roleTracker)
 
 	priorityClassName := workloadpatching.PriorityClassName(newWorkload)
 	metrics.QuotaReservedWorkload(admission.ClusterQueue, priorityClassName, waitTime, s.customLabels.CQGet(admission.ClusterQueue), s.roleTracker)
@@ -45,15 +45,18 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	qcache "sigs.k8s.io/kueue/pkg/cache/queue"
 	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
+	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
 	preemptexpectations "sigs.k8s.io/kueue/pkg/scheduler/preemption/expectations"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingmetrics "sigs.k8s.io/kueue/pkg/util/testing/metrics"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
 	"sigs.k8s.io/kueue/pkg/workload"
 	"sigs.k8s.io/kueue/pkg/workloadslicing"
@@ -86,6 +89,8 @@ type scheduleTestCase struct {
 
 	cohorts []kueue.Cohort
 
+	resourceTransformations []config.ResourceTransformation
+
 	// wantAssignments is a summary of all the admissions in the cache after this cycle.
 	wantAssignments map[workload.Reference]kueue.Admission
 	// wantWorkloads is the subset of workloads that got admitted in this cycle.
@@ -150,6 +155,7 @@ func runScheduleTestCases(t *testing.T, cfg scheduleTestConfig, cases map[string
 				func(t *testing.T) {
 					features.SetFeatureGatesDuringTest(t, scenario)
 					metrics.AdmissionCyclePreemptionSkips.Reset()
+					metrics.PreemptionsTotal.Reset()
 					fg := map[featuregate.Feature]bool{}
 					maps.Copy(fg, tc.featureGates)
 					features.SetFeatureGatesDuringTest(t, fg)
@@ -205,8 +211,8 @@ func runScheduleTestCases(t *testing.T, cfg scheduleTestConfig, cases map[string
 
 					cl := clientBuilder.Build()
 					recorder := &utiltesting.EventRecorder{}
-					cqCache := schdcache.New(cl)
-					qManager := qcache.NewManagerForUnitTests(cl, cqCache)
+					cqCache := schdcache.New(cl, schdcache.WithResourceTransformations(tc.resourceTransformations))
+					qManager := qcache.NewManagerForUnitTests(cl, cqCache, qcache.WithResourceTransformations(tc.resourceTransformations))
 					// Workloads are loaded into queues or clusterQueues as we add them.
 					for _, q := range allQueues {
 						if err := qManager.AddLocalQueue(ctx, &q); err != nil {
@@ -339,6 +345,14 @@ func runScheduleTestCases(t *testing.T, cfg scheduleTestConfig, cases map[string
 						if want != got {
 							t.Errorf("Counted %d skips for %q, want %d", got, cqName, want)
 						}
+						lvs = []string{cqName, string(metrics.PreemptionOutcomeSkipped), roletracker.RoleStandalone}
+						val, err = testutil.GetCounterMetricValue(metrics.PreemptionsTotal.WithLabelValues(lvs...))
+						if err != nil {
+							t.Fatalf("Couldn't get value for metric preemptions_total for %q: %v", cqName, err)
+						}
+						if got := int(val); want != got {
+							t.Errorf("Counted %d skipped preemptions for %q, want %d", got, cqName, want)
+						}
 					}
 				},
 			)
@@ -464,6 +478,19 @@ func TestSchedule(t *testing.T) {
 		*utiltestingapi.MakeLocalQueue("lend-a-queue", "lend").ClusterQueue("lend-a").Obj(),
 		*utiltestingapi.MakeLocalQueue("lend-b-queue", "lend").ClusterQueue("lend-b").Obj(),
 	}
+	acceleratorTransformations := []config.ResourceTransformation{
+		{
+			Input:    "nvidia.com/gpu",
+			Strategy: ptr.To(config.Replace),
+			Outputs:  corev1.ResourceList{"accelerator": resource.MustParse("1")},
+		},
+		{
+			Input:    "amd.com/gpu",
+			Strategy: ptr.To(config.Replace),
+			Outputs:  corev1.ResourceList{"accelerator": resource.MustParse("1")},
+		},
+	}
+
 	cases := map[string]scheduleTestCase{
 		"use second flavor when the first has no preemption candidates; WhenCanPreempt: MayStopSearch": {
 			featureGates: map[featuregate.Feature]bool{features.PartialAdmission: true},
@@ -994,6 +1021,201 @@ func TestSchedule(t *testing.T) {
 				"sales": {"sales/new"},
 			},
 		},
+		"workload stays pending when the ClusterQueue reached its maxAdmittedWorkloads": {
+			featureGates: map[featuregate.Feature]bool{features.ClusterQueueMaxAdmittedWorkloads: true},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("capped-cq").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					MaxAdmittedWorkloads(1).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("capped", "sales").ClusterQueue("capped-cq").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("running", "sales").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("capped-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "2").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("pending", "sales").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("pending", "sales").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForQuota,
+						Message:            "ClusterQueue capped-cq reached the maximum number of admitted workloads",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadAdmittedReasonNoReservation,
+						Message:            "The workload has no reservation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					ResourceRequests(kueue.PodSetRequest{
+						Name: kueue.DefaultPodSetName,
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("2"),
+						},
+					}).
+					Obj(),
+				*utiltestingapi.MakeWorkload("running", "sales").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("capped-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "2").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/running": *utiltestingapi.MakeAdmission("capped-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "2").
+						Obj()).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				"capped-cq": {"sales/pending"},
+			},
+		},
+		"workload is admitted below the maxAdmittedWorkloads of the ClusterQueue": {
+			featureGates: map[featuregate.Feature]bool{features.ClusterQueueMaxAdmittedWorkloads: true},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("capped-cq").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					MaxAdmittedWorkloads(2).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("capped", "sales").ClusterQueue("capped-cq").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("running", "sales").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("capped-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "2").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("pending", "sales").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("pending", "sales").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionTrue,
+						Reason:             "QuotaReserved",
+						Message:            "Quota reserved in ClusterQueue capped-cq",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Admitted",
+						Message:            "The workload is admitted",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Admission(utiltestingapi.MakeAdmission("capped-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "2").
+							Obj()).
+						Obj()).
+					Obj(),
+				*utiltestingapi.MakeWorkload("running", "sales").
+					Queue("capped").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("capped-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "2").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/running": *utiltestingapi.MakeAdmission("capped-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "2").
+						Obj()).
+					Obj(),
+				"sales/pending": *utiltestingapi.MakeAdmission("capped-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "2").
+						Obj()).
+					Obj(),
+			},
+		},
+		"held workload is not admitted": {
+			featureGates: map[featuregate.Feature]bool{features.WorkloadHold: true},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("held", "sales").
+					Queue("main").
+					Annotation(constants.HoldAnnotation, "true").
+					PodSets(*utiltestingapi.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("held", "sales").
+					Queue("main").
+					Annotation(constants.HoldAnnotation, "true").
+					PodSets(*utiltestingapi.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadHeld,
+						Message:            "The workload is held by the kueue.x-k8s.io/hold annotation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadAdmittedReasonNoReservation,
+						Message:            "The workload has no reservation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					ResourceRequests(kueue.PodSetRequest{
+						Name: "one",
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1"),
+						},
+					}).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				"sales": {"sales/held"},
+			},
+		},
 		"failed to match clusterQueue selector": {
 			featureGates: map[featuregate.Feature]bool{features.PartialAdmission: true},
 			workloads: []kueue.Workload{
@@ -1599,38 +1821,117 @@ func TestSchedule(t *testing.T) {
 					Obj(),
 			},
 		},
-		"workload exceeds lending limit when borrow in cohort": {
+		"vendor accelerator counts against the quota of the normalized resource": {
+			resourceTransformations: acceleratorTransformations,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("accelerator-cq").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("model-a").
+						Resource("accelerator", "4").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("accelerator", "sales").ClusterQueue("accelerator-cq").Obj(),
+			},
 			workloads: []kueue.Workload{
-				*utiltestingapi.MakeWorkload("a", "lend").
-					Request(corev1.ResourceCPU, "2").
-					ReserveQuotaAt(utiltestingapi.MakeAdmission("lend-b").
+				*utiltestingapi.MakeWorkload("nvidia", "sales").
+					Queue("accelerator").
+					Request("nvidia.com/gpu", "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("accelerator-cq").
 						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-							Assignment(corev1.ResourceCPU, "default", "2000m").
+							Assignment("accelerator", "model-a", "2").
 							Obj()).
 						Obj(), now).
+					AdmittedAt(true, now).
 					Obj(),
-				*utiltestingapi.MakeWorkload("b", "lend").
-					Queue("lend-b-queue").
-					Request(corev1.ResourceCPU, "3").
+				*utiltestingapi.MakeWorkload("amd", "sales").
+					Queue("accelerator").
+					Request("amd.com/gpu", "2").
 					Obj(),
 			},
 			wantWorkloads: []kueue.Workload{
-				*utiltestingapi.MakeWorkload("a", "lend").
-					Request(corev1.ResourceCPU, "2").
-					ReserveQuotaAt(utiltestingapi.MakeAdmission("lend-b").
+				*utiltestingapi.MakeWorkload("amd", "sales").
+					Queue("accelerator").
+					Request("amd.com/gpu", "2").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionTrue,
+						Reason:             "QuotaReserved",
+						Message:            "Quota reserved in ClusterQueue accelerator-cq",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Admitted",
+						Message:            "The workload is admitted",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Admission(utiltestingapi.MakeAdmission("accelerator-cq").
 						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-							Assignment(corev1.ResourceCPU, "default", "2000m").
+							Assignment("accelerator", "model-a", "2").
+							Obj()).
+						Obj()).
+					Obj(),
+				*utiltestingapi.MakeWorkload("nvidia", "sales").
+					Queue("accelerator").
+					Request("nvidia.com/gpu", "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("accelerator-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment("accelerator", "model-a", "2").
 							Obj()).
 						Obj(), now).
+					AdmittedAt(true, now).
 					Obj(),
-				*utiltestingapi.MakeWorkload("b", "lend").
-					Queue("lend-b-queue").
-					Request(corev1.ResourceCPU, "3").
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/nvidia": *utiltestingapi.MakeAdmission("accelerator-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment("accelerator", "model-a", "2").
+						Obj()).
+					Obj(),
+				"sales/amd": *utiltestingapi.MakeAdmission("accelerator-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment("accelerator", "model-a", "2").
+						Obj()).
+					Obj(),
+			},
+		},
+		"vendor accelerator exceeding the remaining quota of the normalized resource": {
+			resourceTransformations: acceleratorTransformations,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("accelerator-cq").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("model-a").
+						Resource("accelerator", "4").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("accelerator", "sales").ClusterQueue("accelerator-cq").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("nvidia", "sales").
+					Queue("accelerator").
+					Request("nvidia.com/gpu", "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("accelerator-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment("accelerator", "model-a", "2").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("amd", "sales").
+					Queue("accelerator").
+					Request("amd.com/gpu", "3").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("amd", "sales").
+					Queue("accelerator").
+					Request("amd.com/gpu", "3").
 					Condition(metav1.Condition{
 						Type:               kueue.WorkloadQuotaReserved,
 						Status:             metav1.ConditionFalse,
 						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForQuota,
-						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for accelerator in flavor model-a, 1 more needed",
 						LastTransitionTime: metav1.NewTime(now),
 					}).
 					Condition(metav1.Condition{
@@ -1641,44 +1942,577 @@ func TestSchedule(t *testing.T) {
 						LastTransitionTime: metav1.NewTime(now),
 					}).
 					ResourceRequests(kueue.PodSetRequest{
-						Name: "main",
+						Name: kueue.DefaultPodSetName,
 						Resources: corev1.ResourceList{
-							corev1.ResourceCPU: resource.MustParse("3"),
+							"accelerator": resource.MustParse("3"),
 						},
 					}).
 					Obj(),
+				*utiltestingapi.MakeWorkload("nvidia", "sales").
+					Queue("accelerator").
+					Request("nvidia.com/gpu", "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("accelerator-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment("accelerator", "model-a", "2").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
 			},
 			wantAssignments: map[workload.Reference]kueue.Admission{
-				"lend/a": *utiltestingapi.MakeAdmission("lend-b").
+				"sales/nvidia": *utiltestingapi.MakeAdmission("accelerator-cq").
 					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-						Assignment(corev1.ResourceCPU, "default", "2000m").
+						Assignment("accelerator", "model-a", "2").
 						Obj()).
 					Obj(),
 			},
 			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]workload.Reference{
-				"lend-b": {"lend/b"},
+				"accelerator-cq": {"sales/amd"},
 			},
 		},
-		// Cohorts in UPPERCASE, ClusterQueues in lowercase.
-		//
-		//        ROOT
-		//         |
-		//       CHILD
-		//      /     \
-		// lender    borrower
-		//
-		// In (), we display nominal quota.
-		// lender(10):   lendingLimit=3
-		// borrower(5):  borrowingLimit=10, usage=5
-		//
-		// lender reserves 10-3=7 CPU and lends at most 3
-		// to the cohort. borrower has used all 5 of its
-		// nominal quota, so it must borrow to admit more.
-		//
-		// wl-pending requests 4 CPU, but only 3 are
-		// lendable. We expect it to be inadmissible.
-		"hierarchical cohort respects lending limit when borrowing": {
-			cohorts: []kueue.Cohort{
+		"fractional cpu quota admits the workload using the remaining millicpu": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("fractional-cq").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "1500m").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("fractional", "sales").ClusterQueue("fractional-cq").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("b", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("c", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("b", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("c", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionTrue,
+						Reason:             "QuotaReserved",
+						Message:            "Quota reserved in ClusterQueue fractional-cq",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Admitted",
+						Message:            "The workload is admitted",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Admission(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/a": *utiltestingapi.MakeAdmission("fractional-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "500m").
+						Obj()).
+					Obj(),
+				"sales/b": *utiltestingapi.MakeAdmission("fractional-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "500m").
+						Obj()).
+					Obj(),
+				"sales/c": *utiltestingapi.MakeAdmission("fractional-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "500m").
+						Obj()).
+					Obj(),
+			},
+		},
+		"fractional cpu quota rejects the workload exceeding the remaining millicpu": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("fractional-cq").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "1500m").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("fractional", "sales").ClusterQueue("fractional-cq").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("b", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("c", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("d", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("b", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("c", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("fractional-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "500m").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("d", "sales").
+					Queue("fractional").
+					Request(corev1.ResourceCPU, "500m").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForQuota,
+						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 500m more needed",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadAdmittedReasonNoReservation,
+						Message:            "The workload has no reservation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					ResourceRequests(kueue.PodSetRequest{
+						Name: kueue.DefaultPodSetName,
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("500m"),
+						},
+					}).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/a": *utiltestingapi.MakeAdmission("fractional-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "500m").
+						Obj()).
+					Obj(),
+				"sales/b": *utiltestingapi.MakeAdmission("fractional-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "500m").
+						Obj()).
+					Obj(),
+				"sales/c": *utiltestingapi.MakeAdmission("fractional-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "500m").
+						Obj()).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				"fractional-cq": {"sales/d"},
+			},
+		},
+		"quota guaranteed to a LocalQueue is not used by the other LocalQueues": {
+			featureGates: map[featuregate.Feature]bool{features.LocalQueueGuaranteedQuota: true},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("guaranteed-cq").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("guaranteed", "sales").
+					ClusterQueue("guaranteed-cq").
+					GuaranteedQuota("default", corev1.ResourceCPU, "4").
+					Obj(),
+				*utiltestingapi.MakeLocalQueue("shared", "sales").ClusterQueue("guaranteed-cq").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("shared-a", "sales").
+					Queue("shared").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("guaranteed-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "6").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("shared-b", "sales").
+					Queue("shared").
+					Creation(now.Add(-time.Minute)).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltestingapi.MakeWorkload("guaranteed-a", "sales").
+					Queue("guaranteed").
+					Creation(now).
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("guaranteed-a", "sales").
+					Queue("guaranteed").
+					Creation(now).
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+				*utiltestingapi.MakeWorkload("shared-a", "sales").
+					Queue("shared").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("guaranteed-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "6").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("shared-b", "sales").
+					Queue("shared").
+					Creation(now.Add(-time.Minute)).
+					Request(corev1.ResourceCPU, "2").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForQuota,
+						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 4 is guaranteed to other LocalQueues",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadAdmittedReasonNoReservation,
+						Message:            "The workload has no reservation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					ResourceRequests(kueue.PodSetRequest{
+						Name: kueue.DefaultPodSetName,
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("2"),
+						},
+					}).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/shared-a": *utiltestingapi.MakeAdmission("guaranteed-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "6").
+						Obj()).
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				"guaranteed-cq": {"sales/guaranteed-a"},
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				"guaranteed-cq": {"sales/shared-b"},
+			},
+		},
+		"LocalQueue admits up to its guaranteed quota while the other LocalQueues use the rest of the ClusterQueue": {
+			featureGates: map[featuregate.Feature]bool{features.LocalQueueGuaranteedQuota: true},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("guaranteed-cq").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("guaranteed", "sales").
+					ClusterQueue("guaranteed-cq").
+					GuaranteedQuota("default", corev1.ResourceCPU, "4").
+					Obj(),
+				*utiltestingapi.MakeLocalQueue("shared", "sales").ClusterQueue("guaranteed-cq").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("shared-a", "sales").
+					Queue("shared").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("guaranteed-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "6").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("guaranteed-a", "sales").
+					Queue("guaranteed").
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("guaranteed-a", "sales").
+					Queue("guaranteed").
+					Request(corev1.ResourceCPU, "4").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionTrue,
+						Reason:             "QuotaReserved",
+						Message:            "Quota reserved in ClusterQueue guaranteed-cq",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Admitted",
+						Message:            "The workload is admitted",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Admission(utiltestingapi.MakeAdmission("guaranteed-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "4").
+							Obj()).
+						Obj()).
+					Obj(),
+				*utiltestingapi.MakeWorkload("shared-a", "sales").
+					Queue("shared").
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("guaranteed-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "6").
+							Obj()).
+						Obj(), now).
+					AdmittedAt(true, now).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/guaranteed-a": *utiltestingapi.MakeAdmission("guaranteed-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "4").
+						Obj()).
+					Obj(),
+				"sales/shared-a": *utiltestingapi.MakeAdmission("guaranteed-cq").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "6").
+						Obj()).
+					Obj(),
+			},
+		},
+		"workload exceeds lending limit when borrow in cohort": {
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a", "lend").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("lend-b").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "2000m").
+							Obj()).
+						Obj(), now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("b", "lend").
+					Queue("lend-b-queue").
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a", "lend").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("lend-b").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "default", "2000m").
+							Obj()).
+						Obj(), now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("b", "lend").
+					Queue("lend-b-queue").
+					Request(corev1.ResourceCPU, "3").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForQuota,
+						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadAdmittedReasonNoReservation,
+						Message:            "The workload has no reservation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					ResourceRequests(kueue.PodSetRequest{
+						Name: "main",
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("3"),
+						},
+					}).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"lend/a": *utiltestingapi.MakeAdmission("lend-b").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "default", "2000m").
+						Obj()).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				"lend-b": {"lend/b"},
+			},
+		},
+		// Cohorts in UPPERCASE, ClusterQueues in lowercase.
+		//
+		//        ROOT
+		//         |
+		//       CHILD
+		//      /     \
+		// lender    borrower
+		//
+		// In (), we display nominal quota.
+		// lender(10):   lendingLimit=3
+		// borrower(5):  borrowingLimit=10, usage=5
+		//
+		// lender reserves 10-3=7 CPU and lends at most 3
+		// to the cohort. borrower has used all 5 of its
+		// nominal quota, so it must borrow to admit more.
+		//
+		// wl-pending requests 4 CPU, but only 3 are
+		// lendable. We expect it to be inadmissible.
+		"workload larger than the nominal quota of each ClusterQueue admits by borrowing from the cohort": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("gpu-a").
+					Cohort("gpu").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(
+						*utiltestingapi.MakeFlavorQuotas("on-demand").
+							Resource("example.com/gpu", "8").
+							Obj(),
+					).Obj(),
+				*utiltestingapi.MakeClusterQueue("gpu-b").
+					Cohort("gpu").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(
+						*utiltestingapi.MakeFlavorQuotas("on-demand").
+							Resource("example.com/gpu", "8").
+							Obj(),
+					).Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("gpu-a", "eng-alpha").ClusterQueue("gpu-a").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("large", "eng-alpha").
+					Queue("gpu-a").
+					PodSets(*utiltestingapi.MakePodSet("main", 16).
+						Request("example.com/gpu", "1").
+						Obj()).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("large", "eng-alpha").
+					Queue("gpu-a").
+					PodSets(*utiltestingapi.MakePodSet("main", 16).
+						Request("example.com/gpu", "1").
+						Obj()).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionTrue,
+						Reason:             "QuotaReserved",
+						Message:            "Quota reserved in ClusterQueue gpu-a",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Admitted",
+						Message:            "The workload is admitted",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Admission(
+						utiltestingapi.MakeAdmission("gpu-a", "main").
+							PodSets(utiltestingapi.MakePodSetAssignment("main").
+								Assignment("example.com/gpu", "on-demand", "16").
+								Count(16).
+								Obj()).
+							Obj(),
+					).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"eng-alpha/large": *utiltestingapi.MakeAdmission("gpu-a", "main").
+					PodSets(utiltestingapi.MakePodSetAssignment("main").
+						Assignment("example.com/gpu", "on-demand", "16").
+						Count(16).
+						Obj()).
+					Obj(),
+			},
+		},
+		"hierarchical cohort respects lending limit when borrowing": {
+			cohorts: []kueue.Cohort{
 				*utiltestingapi.MakeCohort("root").Obj(),
 				*utiltestingapi.MakeCohort("child").Parent("root").Obj(),
 			},
@@ -1979,59 +2813,257 @@ func TestSchedule(t *testing.T) {
 						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: job-preemptor) due to reclamation within the cohort; preemptor path: /eng/eng-beta; preemptee path: /eng/eng-alpha",
 						LastTransitionTime: metav1.NewTime(now),
 					}).
-					SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{Reason: "Preempted", Count: 1}).
+					SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{Reason: "Preempted", Count: 1}).
+					Obj(),
+				*utiltestingapi.MakeWorkload("use-all-spot", "eng-alpha").
+					Request(corev1.ResourceCPU, "100").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("eng-alpha").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "spot", "100000m").
+							Obj()).
+						Obj(), now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("low-1", "eng-beta").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "30").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("eng-beta").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "on-demand", "30000m").
+							Obj()).
+						Obj(), now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("low-2", "eng-beta").
+					Priority(-2).
+					Request(corev1.ResourceCPU, "10").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("eng-beta").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "on-demand", "10000m").
+							Obj()).
+						Obj(), now).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Preempted",
+						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: job-preemptor) due to prioritization in the ClusterQueue; preemptor path: /eng/eng-beta; preemptee path: /eng/eng-beta",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadPreempted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "InClusterQueue",
+						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: job-preemptor) due to prioritization in the ClusterQueue; preemptor path: /eng/eng-beta; preemptee path: /eng/eng-beta",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{Reason: "Preempted", Count: 1}).
+					Obj(),
+				*utiltestingapi.MakeWorkload("preemptor", "eng-beta").
+					UID("wl-preemptor").
+					JobUID("job-preemptor").
+					Queue("main").
+					Request(corev1.ResourceCPU, "20").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForPreemptedWorkloads,
+						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor on-demand, 20 more needed, insufficient unused quota for cpu in flavor spot, 20 more needed. Pending the preemption of 2 workload(s)",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadAdmittedReasonNoReservation,
+						Message:            "The workload has no reservation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					ResourceRequests(kueue.PodSetRequest{
+						Name: "main",
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("20"),
+						},
+					}).
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				// Preemptor is not admitted in this cycle.
+				"eng-beta": {"eng-beta/preemptor"},
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"eng-alpha/use-all-spot": *utiltestingapi.MakeAdmission("eng-alpha").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "spot", "100").
+						Obj()).
+					Obj(),
+				"eng-beta/low-1": *utiltestingapi.MakeAdmission("eng-beta").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "on-demand", "30").
+						Obj()).
+					Obj(),
+				// Removal from cache for the preempted workloads is deferred until we receive Workload updates
+				"eng-beta/low-2": *utiltestingapi.MakeAdmission("eng-beta").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "on-demand", "10").
+						Obj()).
+					Obj(),
+				"eng-alpha/borrower": *utiltestingapi.MakeAdmission("eng-alpha").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "on-demand", "60").
+						Obj()).
+					Obj(),
+			},
+		},
+		"preemption in the DryRun mode reports the targets without evicting them": {
+			featureGates: map[featuregate.Feature]bool{features.PreemptionDryRun: true},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("dry-run").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").
+						Obj()).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+						Mode:               kueue.PreemptionModeDryRun,
+					}).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("dry-run", "sales").ClusterQueue("dry-run").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("preemptor", "sales").
+					UID("wl-preemptor").
+					Queue("dry-run").
+					Request(corev1.ResourceCPU, "5").
+					Obj(),
+				*utiltestingapi.MakeWorkload("low", "sales").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "8").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("dry-run").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "on-demand", "8").
+							Obj()).
+						Obj(), now).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("low", "sales").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "8").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("dry-run").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "on-demand", "8").
+							Obj()).
+						Obj(), now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("preemptor", "sales").
+					UID("wl-preemptor").
+					Queue("dry-run").
+					Request(corev1.ResourceCPU, "5").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForQuota,
+						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor on-demand, 3 more needed. Preemption is in the DryRun mode, would preempt 1 workload(s)",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadAdmittedReasonNoReservation,
+						Message:            "The workload has no reservation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					ResourceRequests(kueue.PodSetRequest{
+						Name: "main",
+						Resources: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("5"),
+						},
+					}).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				"dry-run": {"sales/preemptor"},
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/low": *utiltestingapi.MakeAdmission("dry-run").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment(corev1.ResourceCPU, "on-demand", "8").
+						Obj()).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				utiltesting.MakeEventRecord("sales", "preemptor", "PreemptionDryRun", corev1.EventTypeNormal).
+					Message("Would preempt 1 workload(s): sales/low (InClusterQueue)").
+					Obj(),
+				utiltesting.MakeEventRecord("sales", "preemptor", kueue.WorkloadQuotaReservedReasonWaitingForQuota, corev1.EventTypeWarning).
+					Message("couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor on-demand, 3 more needed. Preemption is in the DryRun mode, would preempt 1 workload(s)").
+					Obj(),
+			},
+		},
+		"preemption in the DryRun mode; PreemptionDryRun disabled": {
+			featureGates: map[featuregate.Feature]bool{features.PreemptionDryRun: false},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("dry-run").
+					ResourceGroup(*utiltestingapi.MakeFlavorQuotas("on-demand").
+						Resource(corev1.ResourceCPU, "10").
+						Obj()).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+						Mode:               kueue.PreemptionModeDryRun,
+					}).
 					Obj(),
-				*utiltestingapi.MakeWorkload("use-all-spot", "eng-alpha").
-					Request(corev1.ResourceCPU, "100").
-					ReserveQuotaAt(utiltestingapi.MakeAdmission("eng-alpha").
-						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-							Assignment(corev1.ResourceCPU, "spot", "100000m").
-							Obj()).
-						Obj(), now).
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("dry-run", "sales").ClusterQueue("dry-run").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("preemptor", "sales").
+					UID("wl-preemptor").
+					Queue("dry-run").
+					Request(corev1.ResourceCPU, "5").
 					Obj(),
-				*utiltestingapi.MakeWorkload("low-1", "eng-beta").
+				*utiltestingapi.MakeWorkload("low", "sales").
 					Priority(-1).
-					Request(corev1.ResourceCPU, "30").
-					ReserveQuotaAt(utiltestingapi.MakeAdmission("eng-beta").
+					Request(corev1.ResourceCPU, "8").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("dry-run").
 						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-							Assignment(corev1.ResourceCPU, "on-demand", "30000m").
+							Assignment(corev1.ResourceCPU, "on-demand", "8").
 							Obj()).
 						Obj(), now).
 					Obj(),
-				*utiltestingapi.MakeWorkload("low-2", "eng-beta").
-					Priority(-2).
-					Request(corev1.ResourceCPU, "10").
-					ReserveQuotaAt(utiltestingapi.MakeAdmission("eng-beta").
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("low", "sales").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "8").
+					ReserveQuotaAt(utiltestingapi.MakeAdmission("dry-run").
 						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-							Assignment(corev1.ResourceCPU, "on-demand", "10000m").
+							Assignment(corev1.ResourceCPU, "on-demand", "8").
 							Obj()).
 						Obj(), now).
 					Condition(metav1.Condition{
 						Type:               kueue.WorkloadEvicted,
 						Status:             metav1.ConditionTrue,
 						Reason:             "Preempted",
-						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: job-preemptor) due to prioritization in the ClusterQueue; preemptor path: /eng/eng-beta; preemptee path: /eng/eng-beta",
+						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: UNKNOWN) due to prioritization in the ClusterQueue; preemptor path: /dry-run; preemptee path: /dry-run",
 						LastTransitionTime: metav1.NewTime(now),
 					}).
 					Condition(metav1.Condition{
 						Type:               kueue.WorkloadPreempted,
 						Status:             metav1.ConditionTrue,
 						Reason:             "InClusterQueue",
-						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: job-preemptor) due to prioritization in the ClusterQueue; preemptor path: /eng/eng-beta; preemptee path: /eng/eng-beta",
+						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: UNKNOWN) due to prioritization in the ClusterQueue; preemptor path: /dry-run; preemptee path: /dry-run",
 						LastTransitionTime: metav1.NewTime(now),
 					}).
 					SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{Reason: "Preempted", Count: 1}).
 					Obj(),
-				*utiltestingapi.MakeWorkload("preemptor", "eng-beta").
+				*utiltestingapi.MakeWorkload("preemptor", "sales").
 					UID("wl-preemptor").
-					JobUID("job-preemptor").
-					Queue("main").
-					Request(corev1.ResourceCPU, "20").
+					Queue("dry-run").
+					Request(corev1.ResourceCPU, "5").
 					Condition(metav1.Condition{
 						Type:               kueue.WorkloadQuotaReserved,
 						Status:             metav1.ConditionFalse,
 						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForPreemptedWorkloads,
-						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor on-demand, 20 more needed, insufficient unused quota for cpu in flavor spot, 20 more needed. Pending the preemption of 2 workload(s)",
+						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor on-demand, 3 more needed. Pending the preemption of 1 workload(s)",
 						LastTransitionTime: metav1.NewTime(now),
 					}).
 					Condition(metav1.Condition{
@@ -2044,35 +3076,18 @@ func TestSchedule(t *testing.T) {
 					ResourceRequests(kueue.PodSetRequest{
 						Name: "main",
 						Resources: corev1.ResourceList{
-							corev1.ResourceCPU: resource.MustParse("20"),
+							corev1.ResourceCPU: resource.MustParse("5"),
 						},
 					}).
 					Obj(),
 			},
 			wantLeft: map[kueue.ClusterQueueReference][]workload.Reference{
-				// Preemptor is not admitted in this cycle.
-				"eng-beta": {"eng-beta/preemptor"},
+				"dry-run": {"sales/preemptor"},
 			},
 			wantAssignments: map[workload.Reference]kueue.Admission{
-				"eng-alpha/use-all-spot": *utiltestingapi.MakeAdmission("eng-alpha").
-					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-						Assignment(corev1.ResourceCPU, "spot", "100").
-						Obj()).
-					Obj(),
-				"eng-beta/low-1": *utiltestingapi.MakeAdmission("eng-beta").
-					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-						Assignment(corev1.ResourceCPU, "on-demand", "30").
-						Obj()).
-					Obj(),
-				// Removal from cache for the preempted workloads is deferred until we receive Workload updates
-				"eng-beta/low-2": *utiltestingapi.MakeAdmission("eng-beta").
-					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-						Assignment(corev1.ResourceCPU, "on-demand", "10").
-						Obj()).
-					Obj(),
-				"eng-alpha/borrower": *utiltestingapi.MakeAdmission("eng-alpha").
+				"sales/low": *utiltestingapi.MakeAdmission("dry-run").
 					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
-						Assignment(corev1.ResourceCPU, "on-demand", "60").
+						Assignment(corev1.ResourceCPU, "on-demand", "8").
 						Obj()).
 					Obj(),
 			},
@@ -2878,6 +3893,79 @@ func TestSchedule(t *testing.T) {
 				},
 			},
 		},
+		"partial admission reduces the workers but not the driver": {
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("new", "sales").
+					Queue("main").
+					PodSets(
+						*utiltestingapi.MakePodSet("driver", 1).
+							Request(corev1.ResourceCPU, "2").
+							Obj(),
+						*utiltestingapi.MakePodSet("workers", 60).
+							SetMinimumCount(10).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+					).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("new", "sales").
+					Queue("main").
+					PodSets(
+						*utiltestingapi.MakePodSet("driver", 1).
+							Request(corev1.ResourceCPU, "2").
+							Obj(),
+						*utiltestingapi.MakePodSet("workers", 60).
+							SetMinimumCount(10).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+					).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionTrue,
+						Reason:             "QuotaReserved",
+						Message:            "Quota reserved in ClusterQueue sales",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Admitted",
+						Message:            "The workload is admitted",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Admission(
+						utiltestingapi.MakeAdmission("sales").
+							PodSets(
+								utiltestingapi.MakePodSetAssignment("driver").
+									Assignment(corev1.ResourceCPU, "default", "2").
+									Count(1).
+									Obj(),
+								utiltestingapi.MakePodSetAssignment("workers").
+									Assignment(corev1.ResourceCPU, "default", "48").
+									Count(48).
+									Obj(),
+							).
+							Obj(),
+					).
+					Obj(),
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"sales/new": {
+					ClusterQueue: "sales",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						utiltestingapi.MakePodSetAssignment("driver").
+							Assignment(corev1.ResourceCPU, "default", "2000m").
+							Count(1).
+							Obj(),
+						utiltestingapi.MakePodSetAssignment("workers").
+							Assignment(corev1.ResourceCPU, "default", "48000m").
+							Count(48).
+							Obj(),
+					},
+				},
+			},
+		},
 		"partial admission disabled, multiple variable pod sets": {
 			workloads: []kueue.Workload{
 				*utiltestingapi.MakeWorkload("new", "sales").
@@ -4875,6 +5963,118 @@ func TestSchedule(t *testing.T) {
 					Obj(),
 			},
 		},
+		"preempt in second flavor when the first flavor is full with higher priority workloads": {
+			// Flavor 1, on-demand, is used by a workload with a higher priority
+			// than the preemptor, so there are no candidates in it.
+			// Flavor 2, spot, is used by a lower priority workload, which is
+			// preempted.
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltestingapi.MakeClusterQueue("other-alpha").
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(
+						*utiltestingapi.MakeFlavorQuotas("on-demand").Resource("gpu", "2").Obj(),
+						*utiltestingapi.MakeFlavorQuotas("spot").Resource("gpu", "2").Obj(),
+					).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltestingapi.MakeLocalQueue("other", "eng-alpha").ClusterQueue("other-alpha").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("high", "eng-alpha").
+					Priority(200).
+					Queue("other").
+					Request("gpu", "2").
+					SimpleReserveQuota("other-alpha", "on-demand", now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("low", "eng-alpha").
+					Priority(0).
+					Queue("other").
+					Request("gpu", "2").
+					SimpleReserveQuota("other-alpha", "spot", now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("preemptor", "eng-alpha").
+					UID("wl-preemptor").
+					JobUID("job-preemptor").
+					Priority(100).
+					Queue("other").
+					Request("gpu", "2").
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("high", "eng-alpha").
+					Priority(200).
+					Queue("other").
+					Request("gpu", "2").
+					SimpleReserveQuota("other-alpha", "on-demand", now).
+					Obj(),
+				*utiltestingapi.MakeWorkload("low", "eng-alpha").
+					Priority(0).
+					Queue("other").
+					Request("gpu", "2").
+					SimpleReserveQuota("other-alpha", "spot", now).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "Preempted",
+						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: job-preemptor) due to prioritization in the ClusterQueue; preemptor path: /other-alpha; preemptee path: /other-alpha",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadPreempted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "InClusterQueue",
+						Message:            "Preempted to accommodate a workload (UID: wl-preemptor, JobUID: job-preemptor) due to prioritization in the ClusterQueue; preemptor path: /other-alpha; preemptee path: /other-alpha",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					SchedulingStatsEviction(kueue.WorkloadSchedulingStatsEviction{Reason: "Preempted", Count: 1}).
+					Obj(),
+				*utiltestingapi.MakeWorkload("preemptor", "eng-alpha").
+					UID("wl-preemptor").
+					JobUID("job-preemptor").
+					Priority(100).
+					Queue("other").
+					Request("gpu", "2").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadQuotaReserved,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadQuotaReservedReasonWaitingForPreemptedWorkloads,
+						Message:            "couldn't assign flavors to pod set main: insufficient unused quota for gpu in flavor on-demand, 2 more needed, insufficient unused quota for gpu in flavor spot, 2 more needed. Pending the preemption of 1 workload(s)",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadAdmitted,
+						Status:             metav1.ConditionFalse,
+						Reason:             kueue.WorkloadAdmittedReasonNoReservation,
+						Message:            "The workload has no reservation",
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					ResourceRequests(kueue.PodSetRequest{
+						Name: "main",
+						Resources: corev1.ResourceList{
+							"gpu": resource.MustParse("2"),
+						},
+					}).
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]workload.Reference{
+				"other-alpha": {"eng-alpha/preemptor"},
+			},
+			wantAssignments: map[workload.Reference]kueue.Admission{
+				"eng-alpha/high": *utiltestingapi.MakeAdmission("other-alpha").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment("gpu", "on-demand", "2").
+						Obj()).
+					Obj(),
+				"eng-alpha/low": *utiltestingapi.MakeAdmission("other-alpha").
+					PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+						Assignment("gpu", "spot", "2").
+						Obj()).
+					Obj(),
+			},
+		},
 		"workload requiring reclaimation prioritized over wl in another full cq": {
 			// Also see #3405.
 			//
@@ -8761,3 +9961,92 @@ func (r *workloadUpdateWatcherRecorder) NotifyWorkloadUpdate(oldWl, newWl *kueue
 		r.newWl = newWl.DeepCopy()
 	}
 }
+
+func TestReportFairShares(t *testing.T) {
+	ctx, log := utiltesting.ContextWithLog(t)
+	cq := utiltestingapi.MakeClusterQueue("fair-share-cq").
+		Cohort("fair-share-cohort").
+		ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+		Obj()
+	cl := utiltesting.NewClientBuilder().Build()
+	cqCache := schdcache.New(cl)
+	cqCache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Adding ClusterQueue to the cache: %v", err)
+	}
+	s := New(qcache.NewManagerForUnitTests(cl, cqCache), cqCache, cl, nil)
+
+	report := func() {
+		t.Helper()
+		snapshot, err := cqCache.Snapshot(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error while building snapshot: %v", err)
+		}
+		s.reportCohortUsageShares(snapshot)
+	}
+	series := func() []testingmetrics.MetricDataPoint {
+		return testingmetrics.CollectFilteredGaugeVec(metrics.ClusterQueueCohortUsageShare, map[string]string{"cluster_queue": "fair-share-cq"})
+	}
+
+	report()
+	if got := series(); len(got) != 1 || got[0].Labels["cohort"] != "fair-share-cohort" {
+		t.Errorf("Unexpected cohort usage share series after the first report: %v", got)
+	}
+	if _, found := s.cohortUsageShares["fair-share-cq"]; !found {
+		t.Errorf("Fair share of the ClusterQueue not recorded after the first report")
+	}
+
+	updated := cq.DeepCopy()
+	updated.Spec.CohortName = ""
+	if err := cqCache.UpdateClusterQueue(log, updated); err != nil {
+		t.Fatalf("Updating ClusterQueue in the cache: %v", err)
+	}
+	report()
+	if got := series(); len(got) != 0 {
+		t.Errorf("Unexpected cohort usage share series after the ClusterQueue left its cohort: %v", got)
+	}
+	if _, found := s.cohortUsageShares["fair-share-cq"]; found {
+		t.Errorf("Fair share of the ClusterQueue still recorded after it left its cohort")
+	}
+}
+
+func TestReportDryRunPreemptions(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.PreemptionDryRun, true)
+	_, log := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().Build()
+	cqCache := schdcache.New(cl)
+	recorder := &utiltesting.EventRecorder{}
+	s := New(qcache.NewManagerForUnitTests(cl, cqCache), cqCache, cl, recorder)
+	cq := &schdcache.ClusterQueueSnapshot{Name: "dry-run"}
+	target := func(name string) *preemption.Target {
+		return &preemption.Target{
+			WorkloadInfo: workload.NewInfo(utiltestingapi.MakeWorkload(name, "sales").Obj()),
+			Reason:       kueue.InClusterQueueReason,
+		}
+	}
+	preemptor := utiltestingapi.MakeWorkload("preemptor", "sales").Obj()
+
+	for _, targets := range [][]*preemption.Target{
+		{target("low"), target("lower")},
+		{target("lower"), target("low")},
+		{target("low")},
+	} {
+		e := &entry{Info: *workload.NewInfo(preemptor)}
+		s.reportDryRunPreemptions(log, e, cq, targets)
+		if e.requeueReason != qcache.RequeueReasonPreemptionNoCandidates {
+			t.Errorf("Unexpected requeue reason %q, want %q", e.requeueReason, qcache.RequeueReasonPreemptionNoCandidates)
+		}
+	}
+
+	wantEvents := []utiltesting.EventRecord{
+		utiltesting.MakeEventRecord("sales", "preemptor", "PreemptionDryRun", corev1.EventTypeNormal).
+			Message("Would preempt 2 workload(s): sales/low (InClusterQueue), sales/lower (InClusterQueue)").
+			Obj(),
+		utiltesting.MakeEventRecord("sales", "preemptor", "PreemptionDryRun", corev1.EventTypeNormal).
+			Message("Would preempt 1 workload(s): sales/low (InClusterQueue)").
+			Obj(),
+	}
+	if diff := cmp.Diff(wantEvents, recorder.RecordedEvents); diff != "" {
+		t.Errorf("Unexpected events (-want/+got):\n%s", diff)
+	}
+}
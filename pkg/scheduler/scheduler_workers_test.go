@@ -0,0 +1,413 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/component-base/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	qcache "sigs.k8s.io/kueue/pkg/cache/queue"
+	schdcache "sigs.k8s.io/kueue/pkg/cache/scheduler"
+	"sigs.k8s.io/kueue/pkg/features"
+	preemptexpectations "sigs.k8s.io/kueue/pkg/scheduler/preemption/expectations"
+	"sigs.k8s.io/kueue/pkg/util/routine"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// workersTestSetup holds the objects of a scheduler configured to process
+// independent cohorts concurrently.
+type workersTestSetup struct {
+	scheduler *Scheduler
+	cache     *schdcache.Cache
+	queues    *qcache.Manager
+	wg        *sync.WaitGroup
+}
+
+func newWorkersTestSetup(tb testing.TB, workers int, clusterQueues []kueue.ClusterQueue, localQueues []kueue.LocalQueue, workloads []kueue.Workload) *workersTestSetup {
+	tb.Helper()
+	ctx, log := utiltesting.ContextWithLog(tb)
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: workloads}, &kueue.LocalQueueList{Items: localQueues}).
+		WithObjects(utiltesting.MakeNamespaceWrapper("default").Obj()).
+		WithStatusSubresource(&kueue.Workload{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(context.Context, client.Client, string, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+				return nil // discard status updates, the admissions are verified in the cache
+			},
+		}).
+		Build()
+	cqCache := schdcache.New(cl)
+	expStore := preemptexpectations.New()
+	qManager := qcache.NewManagerForUnitTests(cl, cqCache, qcache.WithPreemptionExpectations(expStore))
+	cqCache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("default").Obj())
+	for i := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, &clusterQueues[i]); err != nil {
+			tb.Fatalf("Inserting clusterQueue %s in cache: %v", clusterQueues[i].Name, err)
+		}
+		if err := qManager.AddClusterQueue(ctx, &clusterQueues[i]); err != nil {
+			tb.Fatalf("Inserting clusterQueue %s in manager: %v", clusterQueues[i].Name, err)
+		}
+	}
+	for i := range localQueues {
+		if err := qManager.AddLocalQueue(ctx, &localQueues[i]); err != nil {
+			tb.Fatalf("Inserting queue %s/%s in manager: %v", localQueues[i].Namespace, localQueues[i].Name, err)
+		}
+	}
+	scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{},
+		WithWorkers(workers), WithPreemptionExpectations(expStore))
+	wg := &sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+	return &workersTestSetup{scheduler: scheduler, cache: cqCache, queues: qManager, wg: wg}
+}
+
+func TestScheduleWithWorkers(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	clusterQueue := func(name string, cohort kueue.CohortReference) kueue.ClusterQueue {
+		return *utiltestingapi.MakeClusterQueue(name).
+			Cohort(cohort).
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+			Obj()
+	}
+	clusterQueues := []kueue.ClusterQueue{
+		clusterQueue("cq-a1", "cohort-a"),
+		clusterQueue("cq-a2", "cohort-a"),
+		clusterQueue("cq-b1", "cohort-b"),
+		clusterQueue("cq-b2", "cohort-b"),
+		clusterQueue("cq-c", ""),
+	}
+	localQueues := []kueue.LocalQueue{
+		*utiltestingapi.MakeLocalQueue("lq-a1", "default").ClusterQueue("cq-a1").Obj(),
+		*utiltestingapi.MakeLocalQueue("lq-a2", "default").ClusterQueue("cq-a2").Obj(),
+		*utiltestingapi.MakeLocalQueue("lq-b1", "default").ClusterQueue("cq-b1").Obj(),
+		*utiltestingapi.MakeLocalQueue("lq-b2", "default").ClusterQueue("cq-b2").Obj(),
+		*utiltestingapi.MakeLocalQueue("lq-c", "default").ClusterQueue("cq-c").Obj(),
+	}
+
+	cases := map[string]struct {
+		workloads []kueue.Workload
+		// wantAdmitted maps the admitted workloads to their ClusterQueue.
+		wantAdmitted map[workload.Reference]kueue.ClusterQueueReference
+	}{
+		"workloads of independent cohorts are admitted": {
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a1", "default").Queue("lq-a1").Request(corev1.ResourceCPU, "4").Obj(),
+				*utiltestingapi.MakeWorkload("b1", "default").Queue("lq-b1").Request(corev1.ResourceCPU, "4").Obj(),
+				*utiltestingapi.MakeWorkload("c", "default").Queue("lq-c").Request(corev1.ResourceCPU, "4").Obj(),
+			},
+			wantAdmitted: map[workload.Reference]kueue.ClusterQueueReference{
+				"default/a1": "cq-a1",
+				"default/b1": "cq-b1",
+				"default/c":  "cq-c",
+			},
+		},
+		"ordering by priority is preserved within each cohort": {
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a1-low", "default").Queue("lq-a1").Priority(1).Request(corev1.ResourceCPU, "6").Obj(),
+				*utiltestingapi.MakeWorkload("a2-high", "default").Queue("lq-a2").Priority(10).Request(corev1.ResourceCPU, "6").Obj(),
+				*utiltestingapi.MakeWorkload("b1-high", "default").Queue("lq-b1").Priority(10).Request(corev1.ResourceCPU, "6").Obj(),
+				*utiltestingapi.MakeWorkload("b2-low", "default").Queue("lq-b2").Priority(1).Request(corev1.ResourceCPU, "6").Obj(),
+				*utiltestingapi.MakeWorkload("c", "default").Queue("lq-c").Request(corev1.ResourceCPU, "5").Obj(),
+			},
+			wantAdmitted: map[workload.Reference]kueue.ClusterQueueReference{
+				"default/a2-high": "cq-a2",
+				"default/b1-high": "cq-b1",
+				"default/c":       "cq-c",
+			},
+		},
+		"ordering by creation time is preserved within each cohort": {
+			workloads: []kueue.Workload{
+				*utiltestingapi.MakeWorkload("a1-new", "default").Queue("lq-a1").Creation(now).Request(corev1.ResourceCPU, "6").Obj(),
+				*utiltestingapi.MakeWorkload("a2-old", "default").Queue("lq-a2").Creation(now.Add(-time.Minute)).Request(corev1.ResourceCPU, "6").Obj(),
+				*utiltestingapi.MakeWorkload("b1-old", "default").Queue("lq-b1").Creation(now.Add(-time.Minute)).Request(corev1.ResourceCPU, "6").Obj(),
+				*utiltestingapi.MakeWorkload("b2-new", "default").Queue("lq-b2").Creation(now).Request(corev1.ResourceCPU, "6").Obj(),
+			},
+			wantAdmitted: map[workload.Reference]kueue.ClusterQueueReference{
+				"default/a2-old": "cq-a2",
+				"default/b1-old": "cq-b1",
+			},
+		},
+	}
+	for name, tc := range cases {
+		for _, workers := range []int{1, 4} {
+			for _, colocate := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s workers=%d colocate=%t", name, workers, colocate), func(t *testing.T) {
+					features.SetFeatureGateDuringTest(t, features.ConcurrentCohortScheduling, true)
+					features.SetFeatureGateDuringTest(t, features.TASColocateWithWorkload, colocate)
+					ctx, _ := utiltesting.ContextWithLog(t)
+					setup := newWorkersTestSetup(t, workers, clusterQueues, localQueues, tc.workloads)
+					ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+					go setup.queues.CleanUpOnContext(ctx)
+					defer cancel()
+
+					setup.scheduler.schedule(ctx)
+					setup.wg.Wait()
+
+					snapshot, err := setup.cache.Snapshot(ctx)
+					if err != nil {
+						t.Fatalf("Unexpected error while building snapshot: %v", err)
+					}
+					gotAdmitted := make(map[workload.Reference]kueue.ClusterQueueReference)
+					for cqName, cq := range snapshot.ClusterQueues() {
+						for wlKey := range cq.Workloads {
+							gotAdmitted[wlKey] = cqName
+						}
+					}
+					if diff := cmp.Diff(tc.wantAdmitted, gotAdmitted); diff != "" {
+						t.Errorf("Unexpected admitted workloads (-want,+got):\n%s", diff)
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestScheduleWithWorkersColocateWithWorkload runs the admission of a workload
+// colocated with another workload concurrently with the admissions in other
+// cohorts. It's meant to be run with the race detector.
+func TestScheduleWithWorkersColocateWithWorkload(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ConcurrentCohortScheduling, true)
+	features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, true)
+	features.SetFeatureGateDuringTest(t, features.TASColocateWithWorkload, true)
+	now := time.Now().Truncate(time.Second)
+	ctx, log := utiltesting.ContextWithLog(t)
+
+	topology := utiltestingapi.MakeDefaultOneLevelTopology("tas-single-level")
+	clusterQueues := []kueue.ClusterQueue{
+		*utiltestingapi.MakeClusterQueue("cq-tas").
+			Cohort("cohort-tas").
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("tas").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	localQueues := []kueue.LocalQueue{
+		*utiltestingapi.MakeLocalQueue("lq-tas", "default").ClusterQueue("cq-tas").Obj(),
+	}
+	workloads := []kueue.Workload{
+		*utiltestingapi.MakeWorkload("follower", "default").
+			Queue("lq-tas").
+			Annotation(kueue.ColocateWithWorkloadAnnotation, "leader").
+			PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).
+				RequiredTopologyRequest(corev1.LabelHostname).
+				Request(corev1.ResourceCPU, "1").
+				Obj()).
+			Obj(),
+	}
+	wantAdmitted := map[workload.Reference]kueue.ClusterQueueReference{
+		"default/leader":   "cq-tas",
+		"default/follower": "cq-tas",
+	}
+	// The workloads of the other cohorts preempt within their ClusterQueue,
+	// which modifies the workloads of the snapshot during the simulation.
+	var admitted []*kueue.Workload
+	for i := range 16 {
+		name := fmt.Sprintf("cq-%d", i)
+		clusterQueues = append(clusterQueues, *utiltestingapi.MakeClusterQueue(name).
+			Cohort(kueue.CohortReference(fmt.Sprintf("cohort-%d", i))).
+			ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+			Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+			Obj())
+		localQueues = append(localQueues, *utiltestingapi.MakeLocalQueue(name, "default").ClusterQueue(name).Obj())
+		workloads = append(workloads, *utiltestingapi.MakeWorkload(name, "default").
+			Queue(kueue.LocalQueueName(name)).
+			Priority(100).
+			Request(corev1.ResourceCPU, "5").
+			Obj())
+		low := utiltestingapi.MakeWorkload("low-"+name, "default").
+			Queue(kueue.LocalQueueName(name)).
+			Request(corev1.ResourceCPU, "5").
+			ReserveQuotaAt(utiltestingapi.MakeAdmission(kueue.ClusterQueueReference(name)).
+				PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+					Assignment(corev1.ResourceCPU, "default", "5").
+					Obj()).
+				Obj(), now).
+			Obj()
+		admitted = append(admitted, low)
+		wantAdmitted[workload.Key(low)] = kueue.ClusterQueueReference(name)
+	}
+
+	setup := newWorkersTestSetup(t, 4, clusterQueues, localQueues, workloads)
+	setup.cache.TASCache().SyncNode(testingnode.MakeNode("x1").
+		Label("tas-node", "true").
+		Label(corev1.LabelHostname, "x1").
+		StatusAllocatable(corev1.ResourceList{
+			corev1.ResourceCPU:  resource.MustParse("10"),
+			corev1.ResourcePods: resource.MustParse("10"),
+		}).
+		Ready().
+		Obj())
+	setup.cache.AddOrUpdateResourceFlavor(log, utiltestingapi.MakeResourceFlavor("tas").
+		NodeLabel("tas-node", "true").
+		TopologyName(topology.Name).
+		Obj())
+	setup.cache.AddOrUpdateTopology(log, topology)
+	leader := utiltestingapi.MakeWorkload("leader", "default").
+		Queue("lq-tas").
+		PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 1).
+			RequiredTopologyRequest(corev1.LabelHostname).
+			Request(corev1.ResourceCPU, "1").
+			Obj()).
+		ReserveQuotaAt(utiltestingapi.MakeAdmission("cq-tas").
+			PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+				Assignment(corev1.ResourceCPU, "tas", "1").
+				TopologyAssignment(utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname}).
+					Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"x1"}, 1).Obj()).
+					Obj()).
+				Obj()).
+			Obj(), now).
+		Obj()
+	for _, wl := range append(admitted, leader) {
+		if !setup.cache.AddOrUpdateWorkload(log, wl) {
+			t.Fatalf("Failed to add workload %s to the cache", wl.Name)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+	go setup.queues.CleanUpOnContext(ctx)
+	defer cancel()
+
+	setup.scheduler.schedule(ctx)
+	setup.wg.Wait()
+
+	snapshot, err := setup.cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error while building snapshot: %v", err)
+	}
+	gotAdmitted := make(map[workload.Reference]kueue.ClusterQueueReference)
+	for cqName, cq := range snapshot.ClusterQueues() {
+		for wlKey := range cq.Workloads {
+			gotAdmitted[wlKey] = cqName
+		}
+	}
+	if diff := cmp.Diff(wantAdmitted, gotAdmitted); diff != "" {
+		t.Errorf("Unexpected admitted workloads (-want,+got):\n%s", diff)
+	}
+}
+
+func TestIndependentEntryGroups(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	clusterQueues := []kueue.ClusterQueue{
+		*utiltestingapi.MakeClusterQueue("cq-a1").Cohort("cohort-a").Obj(),
+		*utiltestingapi.MakeClusterQueue("cq-a2").Cohort("cohort-a").Obj(),
+		*utiltestingapi.MakeClusterQueue("cq-b").Cohort("cohort-b").Obj(),
+		*utiltestingapi.MakeClusterQueue("cq-c").Obj(),
+	}
+	cqCache := schdcache.New(utiltesting.NewFakeClient())
+	for i := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, &clusterQueues[i]); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in cache: %v", clusterQueues[i].Name, err)
+		}
+	}
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error while building snapshot: %v", err)
+	}
+	entries := []entry{
+		{Info: workload.Info{ClusterQueue: "cq-a1", Obj: utiltestingapi.MakeWorkload("a1", "").Obj()}},
+		{Info: workload.Info{ClusterQueue: "cq-b", Obj: utiltestingapi.MakeWorkload("b", "").Obj()}},
+		{Info: workload.Info{ClusterQueue: "cq-a2", Obj: utiltestingapi.MakeWorkload("a2", "").Obj()}},
+		{Info: workload.Info{ClusterQueue: "cq-c", Obj: utiltestingapi.MakeWorkload("c", "").Obj()}},
+	}
+	cases := map[string]struct {
+		featureGates map[featuregate.Feature]bool
+		want         [][]string
+	}{
+		"entries are grouped by cohort tree": {
+			want: [][]string{{"a1", "a2"}, {"b"}, {"c"}},
+		},
+		"entries are not split when TASColocateWithWorkload is enabled": {
+			featureGates: map[featuregate.Feature]bool{features.TASColocateWithWorkload: true},
+			want:         [][]string{{"a1", "b", "a2", "c"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGatesDuringTest(t, tc.featureGates)
+			var got [][]string
+			for _, group := range independentEntryGroups(slices.Clone(entries), snapshot) {
+				var names []string
+				for _, e := range group {
+					names = append(names, e.Obj.Name)
+				}
+				got = append(got, names)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected groups (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func BenchmarkScheduleWithWorkers(b *testing.B) {
+	const (
+		cohorts                = 64
+		clusterQueuesPerCohort = 4
+	)
+	var clusterQueues []kueue.ClusterQueue
+	var localQueues []kueue.LocalQueue
+	var workloads []kueue.Workload
+	for c := range cohorts {
+		for q := range clusterQueuesPerCohort {
+			name := fmt.Sprintf("cq-%d-%d", c, q)
+			clusterQueues = append(clusterQueues, *utiltestingapi.MakeClusterQueue(name).
+				Cohort(kueue.CohortReference(fmt.Sprintf("cohort-%d", c))).
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj())
+			localQueues = append(localQueues, *utiltestingapi.MakeLocalQueue(name, "default").ClusterQueue(name).Obj())
+			workloads = append(workloads, *utiltestingapi.MakeWorkload(name, "default").
+				Queue(kueue.LocalQueueName(name)).
+				Request(corev1.ResourceCPU, "1").
+				Obj())
+		}
+	}
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			features.SetFeatureGateDuringTest(b, features.ConcurrentCohortScheduling, true)
+			ctx, log := utiltesting.ContextWithLog(b)
+			for b.Loop() {
+				b.StopTimer()
+				setup := newWorkersTestSetup(b, workers, clusterQueues, localQueues, nil)
+				for i := range workloads {
+					if err := setup.queues.AddOrUpdateWorkload(log, workloads[i].DeepCopy()); err != nil {
+						b.Fatalf("Failed to add workload to qManager: %v", err)
+					}
+				}
+				b.StartTimer()
+				setup.scheduler.schedule(ctx)
+				b.StopTimer()
+				setup.wg.Wait()
+				b.StartTimer()
+			}
+		})
+	}
+}
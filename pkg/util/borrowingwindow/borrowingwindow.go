@@ -0,0 +1,101 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package borrowingwindow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+)
+
+// Windows are the parsed borrowing windows of a ClusterQueue. Empty Windows
+// allow borrowing at any time.
+type Windows []window
+
+type window struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// ParseSchedule parses the cron schedule of a borrowing window.
+func ParseSchedule(schedule string) (cron.Schedule, error) {
+	return cron.ParseStandard(schedule)
+}
+
+// Parse parses the borrowing windows of a ClusterQueue.
+func Parse(in []kueue.BorrowingWindow) (Windows, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	windows := make(Windows, 0, len(in))
+	for _, w := range in {
+		schedule, err := ParseSchedule(w.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", w.Schedule, err)
+		}
+		windows = append(windows, window{
+			schedule: schedule,
+			duration: time.Duration(w.DurationSeconds) * time.Second,
+		})
+	}
+	return windows, nil
+}
+
+// Open returns whether borrowing is allowed at the given time, that is, if
+// there are no windows or one of them is open.
+func (ws Windows) Open(now time.Time) bool {
+	if len(ws) == 0 {
+		return true
+	}
+	for _, w := range ws {
+		if _, open := w.openedAt(now); open {
+			return true
+		}
+	}
+	return false
+}
+
+// NextTransition returns the next time after now at which one of the windows
+// opens or closes, or the zero time if there is none.
+func (ws Windows) NextTransition(now time.Time) time.Time {
+	var next time.Time
+	for _, w := range ws {
+		candidates := []time.Time{w.schedule.Next(now)}
+		if openedAt, open := w.openedAt(now); open {
+			candidates = append(candidates, openedAt.Add(w.duration))
+		}
+		for _, t := range candidates {
+			if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+				next = t
+			}
+		}
+	}
+	return next
+}
+
+// openedAt returns the earliest time at which the window opened and is still
+// open at the given time, and whether there is such a time.
+func (w window) openedAt(now time.Time) (time.Time, bool) {
+	t := w.schedule.Next(now.Add(-w.duration))
+	if t.IsZero() || t.After(now) {
+		return time.Time{}, false
+	}
+	return t, true
+}
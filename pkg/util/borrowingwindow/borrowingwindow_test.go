@@ -0,0 +1,92 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package borrowingwindow
+
+import (
+	"testing"
+	"time"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+)
+
+func TestWindows(t *testing.T) {
+	// Monday
+	day := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+	nightly := kueue.BorrowingWindow{Schedule: "0 18 * * 1-5", DurationSeconds: 14 * 3600}
+	weekend := kueue.BorrowingWindow{Schedule: "0 0 * * 6", DurationSeconds: 48 * 3600}
+
+	cases := map[string]struct {
+		windows            []kueue.BorrowingWindow
+		now                time.Time
+		wantOpen           bool
+		wantNextTransition time.Time
+	}{
+		"no windows": {
+			now:      day.Add(12 * time.Hour),
+			wantOpen: true,
+		},
+		"before the window opens": {
+			windows:            []kueue.BorrowingWindow{nightly},
+			now:                day.Add(12 * time.Hour),
+			wantNextTransition: day.Add(18 * time.Hour),
+		},
+		"when the window opens": {
+			windows:            []kueue.BorrowingWindow{nightly},
+			now:                day.Add(18 * time.Hour),
+			wantOpen:           true,
+			wantNextTransition: day.Add(32 * time.Hour),
+		},
+		"after midnight, within the window opened the previous day": {
+			windows:            []kueue.BorrowingWindow{nightly},
+			now:                day.Add(26 * time.Hour),
+			wantOpen:           true,
+			wantNextTransition: day.Add(32 * time.Hour),
+		},
+		"when the window closes": {
+			windows:            []kueue.BorrowingWindow{nightly},
+			now:                day.Add(32 * time.Hour),
+			wantNextTransition: day.Add(42 * time.Hour),
+		},
+		"within the second window": {
+			windows:            []kueue.BorrowingWindow{nightly, weekend},
+			now:                day.Add(6*24*time.Hour + 12*time.Hour),
+			wantOpen:           true,
+			wantNextTransition: day.Add(7 * 24 * time.Hour),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			windows, err := Parse(tc.windows)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := windows.Open(tc.now); got != tc.wantOpen {
+				t.Errorf("Unexpected Open: got %v, want %v", got, tc.wantOpen)
+			}
+			if got := windows.NextTransition(tc.now); !got.Equal(tc.wantNextTransition) {
+				t.Errorf("Unexpected NextTransition: got %v, want %v", got, tc.wantNextTransition)
+			}
+		})
+	}
+}
+
+func TestParseInvalidSchedule(t *testing.T) {
+	_, err := Parse([]kueue.BorrowingWindow{{Schedule: "every night", DurationSeconds: 60}})
+	if err == nil {
+		t.Error("Expected an error for an invalid schedule")
+	}
+}
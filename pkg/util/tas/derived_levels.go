@@ -0,0 +1,97 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+)
+
+// DerivedLevel is a topology level whose value is derived from a composite
+// node label.
+type DerivedLevel struct {
+	// Level is the node label of the topology level.
+	Level     string
+	nodeLabel string
+	regex     *regexp.Regexp
+}
+
+// DerivedLevels returns the levels of the topology whose values are derived
+// from composite node labels. It returns an error if any regex is invalid.
+func DerivedLevels(topology *kueue.Topology) ([]DerivedLevel, error) {
+	var result []DerivedLevel
+	for _, level := range topology.Spec.Levels {
+		if level.ValueFrom == nil {
+			continue
+		}
+		regex, err := regexp.Compile(level.ValueFrom.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex for level %q: %w", level.NodeLabel, err)
+		}
+		result = append(result, DerivedLevel{
+			Level:     level.NodeLabel,
+			nodeLabel: level.ValueFrom.NodeLabel,
+			regex:     regex,
+		})
+	}
+	return result, nil
+}
+
+// value returns the value of the level for the node labels. The value is the
+// first capture group of the regex, or the whole match if the regex has no
+// capture groups.
+func (d *DerivedLevel) value(nodeLabels map[string]string) (string, bool) {
+	labelValue, ok := nodeLabels[d.nodeLabel]
+	if !ok {
+		return "", false
+	}
+	match := d.regex.FindStringSubmatch(labelValue)
+	if match == nil {
+		return "", false
+	}
+	if len(match) > 1 {
+		match = match[1:]
+	}
+	if match[0] == "" {
+		return "", false
+	}
+	return match[0], true
+}
+
+// NodeLevelLabels returns the node labels extended with the values of the
+// derived levels. The node labels are returned as is when there are no
+// derived levels. The derived levels for which the node label doesn't match
+// are left unset, so that the node doesn't match the topology.
+func NodeLevelLabels(nodeLabels map[string]string, derived []DerivedLevel) map[string]string {
+	if len(derived) == 0 {
+		return nodeLabels
+	}
+	result := maps.Clone(nodeLabels)
+	if result == nil {
+		result = make(map[string]string, len(derived))
+	}
+	for i := range derived {
+		delete(result, derived[i].Level)
+		if value, ok := derived[i].value(nodeLabels); ok {
+			result[derived[i].Level] = value
+		}
+	}
+	return result
+}
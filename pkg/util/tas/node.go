@@ -31,3 +31,14 @@ func NodeMatchesFlavor(nodeLabels map[string]string, requiredLabels map[string]s
 	}
 	return true
 }
+
+// MissingLevels returns the topology levels which are missing in the node labels.
+func MissingLevels(nodeLabels map[string]string, requiredLevels []string) []string {
+	var missing []string
+	for _, level := range requiredLevels {
+		if _, ok := nodeLabels[level]; !ok {
+			missing = append(missing, level)
+		}
+	}
+	return missing
+}
@@ -18,6 +18,7 @@ package tas
 
 import (
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -89,6 +90,17 @@ func IsNodeStatusConditionTrue(conditions []corev1.NodeCondition, conditionType
 	return false
 }
 
+// NodeReadyRemaining returns the time remaining until the node has been Ready
+// for minReadySeconds. It returns minReadySeconds if the node is not Ready.
+func NodeReadyRemaining(node *corev1.Node, minReadySeconds int32, now time.Time) time.Duration {
+	minReady := time.Duration(minReadySeconds) * time.Second
+	cond := GetNodeCondition(node, corev1.NodeReady)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return minReady
+	}
+	return max(cond.LastTransitionTime.Add(minReady).Sub(now), 0)
+}
+
 func GetNodeCondition(node *corev1.Node, conditionType corev1.NodeConditionType) *corev1.NodeCondition {
 	for i := range node.Status.Conditions {
 		if node.Status.Conditions[i].Type == conditionType {
@@ -17,6 +17,8 @@ limitations under the License.
 package tas
 
 import (
+	"encoding/json"
+	"errors"
 	"iter"
 	"slices"
 
@@ -337,3 +339,70 @@ func HasNodeInPodSetAssignment(psa *kueue.PodSetAssignment, nodeName string) boo
 	}
 	return false
 }
+
+// MaxPreviousAssignmentAnnotationSize is the maximum size of the value of the
+// PreviousTopologyAssignmentAnnotation, which keeps the annotations of the
+// Workload well within the 256KiB limit of the API server.
+const MaxPreviousAssignmentAnnotationSize = 32 * 1024
+
+// ErrPreviousAssignmentTooLarge is returned when the value of the
+// PreviousTopologyAssignmentAnnotation exceeds MaxPreviousAssignmentAnnotationSize.
+var ErrPreviousAssignmentTooLarge = errors.New("the previous topology assignment is too large to be recorded")
+
+// PreviousAssignmentAnnotationValue returns the value of the
+// PreviousTopologyAssignmentAnnotation for the admission. It maps the PodSets
+// assigned with TAS to the IDs of the lowest level domains of their
+// TopologyAssignments. When the lowest level is the hostname, the IDs are the
+// hostnames. It returns an empty string if no PodSet is assigned with TAS, and
+// ErrPreviousAssignmentTooLarge if the value exceeds
+// MaxPreviousAssignmentAnnotationSize.
+func PreviousAssignmentAnnotationValue(admission *kueue.Admission) (string, error) {
+	if admission == nil {
+		return "", nil
+	}
+	domains := make(map[kueue.PodSetReference][]TopologyDomainID)
+	for i := range admission.PodSetAssignments {
+		psa := &admission.PodSetAssignments[i]
+		if psa.TopologyAssignment == nil || len(psa.TopologyAssignment.Levels) == 0 {
+			continue
+		}
+		domains[psa.Name] = LowestLevelDomainIDs(psa.TopologyAssignment)
+	}
+	if len(domains) == 0 {
+		return "", nil
+	}
+	value, err := json.Marshal(domains)
+	if err != nil {
+		return "", err
+	}
+	if len(value) > MaxPreviousAssignmentAnnotationSize {
+		return "", ErrPreviousAssignmentTooLarge
+	}
+	return string(value), nil
+}
+
+// LowestLevelDomainIDs returns the IDs of the lowest level domains of the
+// TopologyAssignment. When the lowest level is the hostname, the IDs are the
+// hostnames, as for the leaves of the TAS snapshot.
+func LowestLevelDomainIDs(ta *kueue.TopologyAssignment) []TopologyDomainID {
+	var ids []TopologyDomainID
+	lowestLevelHostname := IsLowestLevelHostname(ta.Levels)
+	for domain := range InternalSeqFrom(ta) {
+		if lowestLevelHostname {
+			ids = append(ids, TopologyDomainID(domain.Values[len(domain.Values)-1]))
+		} else {
+			ids = append(ids, DomainID(domain.Values))
+		}
+	}
+	return ids
+}
+
+// PreviousAssignmentDomains parses the value of the
+// PreviousTopologyAssignmentAnnotation.
+func PreviousAssignmentDomains(value string) (map[kueue.PodSetReference][]TopologyDomainID, error) {
+	var domains map[kueue.PodSetReference][]TopologyDomainID
+	if err := json.Unmarshal([]byte(value), &domains); err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package tas
 
 import (
+	"errors"
+	"fmt"
 	"slices"
 	"testing"
 
@@ -1067,3 +1069,102 @@ func TestHasTASAssignmentOnNode(t *testing.T) {
 		})
 	}
 }
+
+func TestPreviousAssignmentAnnotationValue(t *testing.T) {
+	largeAssignment := &TopologyAssignment{Levels: []string{corev1.LabelHostname}}
+	for i := range 4000 {
+		largeAssignment.Domains = append(largeAssignment.Domains, TopologyDomainAssignment{Values: []string{fmt.Sprintf("node-%d", i)}, Count: 1})
+	}
+	testCases := []struct {
+		name        string
+		admission   *kueue.Admission
+		want        string
+		wantDomains map[kueue.PodSetReference][]TopologyDomainID
+		wantErr     error
+	}{
+		{
+			name: "nil admission",
+		},
+		{
+			name: "no topology assignment",
+			admission: &kueue.Admission{
+				PodSetAssignments: []kueue.PodSetAssignment{{Name: "main"}},
+			},
+		},
+		{
+			name: "lowest level is hostname",
+			admission: &kueue.Admission{
+				PodSetAssignments: []kueue.PodSetAssignment{
+					{
+						Name: "main",
+						TopologyAssignment: V1Beta2From(&TopologyAssignment{
+							Levels: []string{"example.com/rack", corev1.LabelHostname},
+							Domains: []TopologyDomainAssignment{
+								{Values: []string{"rack1", "node1"}, Count: 2},
+								{Values: []string{"rack1", "node2"}, Count: 1},
+							},
+						}),
+					},
+					{Name: "launcher"},
+				},
+			},
+			want: `{"main":["node1","node2"]}`,
+			wantDomains: map[kueue.PodSetReference][]TopologyDomainID{
+				"main": {"node1", "node2"},
+			},
+		},
+		{
+			name: "lowest level is not hostname",
+			admission: &kueue.Admission{
+				PodSetAssignments: []kueue.PodSetAssignment{
+					{
+						Name: "main",
+						TopologyAssignment: V1Beta2From(&TopologyAssignment{
+							Levels: []string{"example.com/block", "example.com/rack"},
+							Domains: []TopologyDomainAssignment{
+								{Values: []string{"block1", "rack1"}, Count: 1},
+							},
+						}),
+					},
+				},
+			},
+			want: `{"main":["block1,rack1"]}`,
+			wantDomains: map[kueue.PodSetReference][]TopologyDomainID{
+				"main": {"block1,rack1"},
+			},
+		},
+		{
+			name: "too large",
+			admission: &kueue.Admission{
+				PodSetAssignments: []kueue.PodSetAssignment{
+					{
+						Name:               "main",
+						TopologyAssignment: V1Beta2From(largeAssignment),
+					},
+				},
+			},
+			wantErr: ErrPreviousAssignmentTooLarge,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := PreviousAssignmentAnnotationValue(tc.admission)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Unexpected error, got %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Unexpected annotation value, got %q, want %q", got, tc.want)
+			}
+			if got == "" {
+				return
+			}
+			gotDomains, err := PreviousAssignmentDomains(got)
+			if err != nil {
+				t.Fatalf("Unexpected error parsing the annotation value: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantDomains, gotDomains); diff != "" {
+				t.Errorf("Unexpected domains (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
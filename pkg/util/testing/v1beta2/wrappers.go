@@ -843,6 +843,22 @@ func (q *LocalQueueWrapper) FairSharing(fs *kueue.FairSharing) *LocalQueueWrappe
 	return q
 }
 
+// GuaranteedQuota sets the guaranteed quota of the resource in the flavor.
+func (q *LocalQueueWrapper) GuaranteedQuota(flavor kueue.ResourceFlavorReference, name corev1.ResourceName, quota string) *LocalQueueWrapper {
+	rq := kueue.LocalQueueResourceQuota{Name: name, Quota: resource.MustParse(quota)}
+	for i := range q.Spec.GuaranteedQuota {
+		if q.Spec.GuaranteedQuota[i].Name == flavor {
+			q.Spec.GuaranteedQuota[i].Resources = append(q.Spec.GuaranteedQuota[i].Resources, rq)
+			return q
+		}
+	}
+	q.Spec.GuaranteedQuota = append(q.Spec.GuaranteedQuota, kueue.LocalQueueFlavorQuota{
+		Name:      flavor,
+		Resources: []kueue.LocalQueueResourceQuota{rq},
+	})
+	return q
+}
+
 // PendingWorkloads updates the pendingWorkloads in status.
 func (q *LocalQueueWrapper) PendingWorkloads(n int32) *LocalQueueWrapper {
 	q.Status.PendingWorkloads = n
@@ -1081,6 +1097,70 @@ func (c *ClusterQueueWrapper) ResourceGroup(flavors ...kueue.FlavorQuotas) *Clus
 	return c
 }
 
+// ResourceBorrowingLimit adds a borrowing limit for the resource across all flavors.
+func (c *ClusterQueueWrapper) ResourceBorrowingLimit(name corev1.ResourceName, limit string) *ClusterQueueWrapper {
+	c.Spec.ResourceBorrowingLimits = append(c.Spec.ResourceBorrowingLimits, kueue.ResourceBorrowingLimit{
+		Name:           name,
+		BorrowingLimit: resource.MustParse(limit),
+	})
+	return c
+}
+
+// OvercommitRatio adds an overcommit ratio for the resource.
+func (c *ClusterQueueWrapper) OvercommitRatio(name corev1.ResourceName, ratio string) *ClusterQueueWrapper {
+	c.Spec.OvercommitRatios = append(c.Spec.OvercommitRatios, kueue.ResourceOvercommitRatio{
+		Name:  name,
+		Ratio: resource.MustParse(ratio),
+	})
+	return c
+}
+
+// NodeCapacityFlavors sets the flavors whose nominal quota is derived from the
+// node capacity.
+func (c *ClusterQueueWrapper) NodeCapacityFlavors(flavors ...kueue.ResourceFlavorReference) *ClusterQueueWrapper {
+	c.Spec.NodeCapacityFlavors = flavors
+	return c
+}
+
+// DefaultContainerRequests sets the default container requests of the ClusterQueue.
+func (c *ClusterQueueWrapper) DefaultContainerRequests(requests corev1.ResourceList) *ClusterQueueWrapper {
+	c.Spec.DefaultContainerRequests = requests
+	return c
+}
+
+// QuotaReductionPolicy sets the quota reduction policy.
+func (c *ClusterQueueWrapper) QuotaReductionPolicy(policy kueue.QuotaReductionPolicy) *ClusterQueueWrapper {
+	c.Spec.QuotaReductionPolicy = &policy
+	return c
+}
+
+// BorrowingWindow adds a time window during which the ClusterQueue can borrow.
+func (c *ClusterQueueWrapper) BorrowingWindow(schedule string, durationSeconds int32) *ClusterQueueWrapper {
+	c.Spec.BorrowingWindows = append(c.Spec.BorrowingWindows, kueue.BorrowingWindow{
+		Schedule:        schedule,
+		DurationSeconds: durationSeconds,
+	})
+	return c
+}
+
+// LimitsAccountedResources sets the resources whose quota usage is accounted from the limits.
+func (c *ClusterQueueWrapper) LimitsAccountedResources(names ...corev1.ResourceName) *ClusterQueueWrapper {
+	c.Spec.LimitsAccountedResources = names
+	return c
+}
+
+// PriorityAging sets the priority aging policy.
+func (c *ClusterQueueWrapper) PriorityAging(policy kueue.PriorityAging) *ClusterQueueWrapper {
+	c.Spec.PriorityAging = &policy
+	return c
+}
+
+// RequeueStrategy sets the requeue strategy.
+func (c *ClusterQueueWrapper) RequeueStrategy(strategy kueue.RequeueStrategy) *ClusterQueueWrapper {
+	c.Spec.RequeueStrategy = &strategy
+	return c
+}
+
 // AdmissionChecks replaces the queue additional checks.
 // This is a convenience wrapper that converts to the AdmissionChecksStrategy format.
 func (c *ClusterQueueWrapper) AdmissionChecks(checks ...kueue.AdmissionCheckReference) *ClusterQueueWrapper {
@@ -1127,6 +1207,18 @@ func (c *ClusterQueueWrapper) StopPolicy(p kueue.StopPolicy) *ClusterQueueWrappe
 	return c
 }
 
+// FlavorTieBreak sets the flavorTieBreak policy of the ClusterQueue.
+func (c *ClusterQueueWrapper) FlavorTieBreak(p kueue.FlavorTieBreakPolicy) *ClusterQueueWrapper {
+	c.Spec.FlavorTieBreak = &p
+	return c
+}
+
+// MaxAdmittedWorkloads sets the maxAdmittedWorkloads of the ClusterQueue.
+func (c *ClusterQueueWrapper) MaxAdmittedWorkloads(n int32) *ClusterQueueWrapper {
+	c.Spec.MaxAdmittedWorkloads = &n
+	return c
+}
+
 // DeletionTimestamp sets a deletion timestamp for the cluster queue.
 func (c *ClusterQueueWrapper) DeletionTimestamp(t time.Time) *ClusterQueueWrapper {
 	c.ClusterQueue.DeletionTimestamp = new(metav1.NewTime(t).Rfc3339Copy())
@@ -1319,6 +1411,36 @@ func (rf *ResourceFlavorWrapper) Toleration(t corev1.Toleration) *ResourceFlavor
 	return rf
 }
 
+// NamespaceSelector sets the namespaceSelector of the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) NamespaceSelector(s *metav1.LabelSelector) *ResourceFlavorWrapper {
+	rf.Spec.NamespaceSelector = s
+	return rf
+}
+
+// ResourceScaling sets the scaling factor of the resource in the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) ResourceScaling(r corev1.ResourceName, factor int32) *ResourceFlavorWrapper {
+	if rf.Spec.ResourceScaling == nil {
+		rf.Spec.ResourceScaling = make(map[corev1.ResourceName]int32)
+	}
+	rf.Spec.ResourceScaling[r] = factor
+	return rf
+}
+
+// NodeShape sets the quantity of the resource in the node shape of the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) NodeShape(r corev1.ResourceName, q string) *ResourceFlavorWrapper {
+	if rf.Spec.NodeShape == nil {
+		rf.Spec.NodeShape = make(corev1.ResourceList)
+	}
+	rf.Spec.NodeShape[r] = resource.MustParse(q)
+	return rf
+}
+
+// StopPolicy sets the stop policy of the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) StopPolicy(p kueue.StopPolicy) *ResourceFlavorWrapper {
+	rf.Spec.StopPolicy = &p
+	return rf
+}
+
 // Creation sets the creation timestamp of the LocalQueue.
 func (rf *ResourceFlavorWrapper) Creation(t time.Time) *ResourceFlavorWrapper {
 	rf.CreationTimestamp = metav1.NewTime(t)
@@ -1360,6 +1482,20 @@ func (t *TopologyWrapper) Levels(levels ...string) *TopologyWrapper {
 	return t
 }
 
+// LevelValueFrom sets the source of the value of the level with the given
+// node label.
+func (t *TopologyWrapper) LevelValueFrom(level, nodeLabel, regex string) *TopologyWrapper {
+	for i := range t.Spec.Levels {
+		if t.Spec.Levels[i].NodeLabel == level {
+			t.Spec.Levels[i].ValueFrom = &kueue.TopologyLevelValueSource{
+				NodeLabel: nodeLabel,
+				Regex:     regex,
+			}
+		}
+	}
+	return t
+}
+
 // Label adds a label to a Topology.
 func (t *TopologyWrapper) Label(k, v string) *TopologyWrapper {
 	if t.Labels == nil {
@@ -1553,6 +1689,18 @@ func (ac *AdmissionCheckWrapper) Parameters(apigroup, kind, name string) *Admiss
 	return ac
 }
 
+// AdmissionCheckTimeoutSeconds sets the admissionCheckTimeoutSeconds of the AdmissionCheck.
+func (ac *AdmissionCheckWrapper) AdmissionCheckTimeoutSeconds(seconds int32) *AdmissionCheckWrapper {
+	ac.Spec.AdmissionCheckTimeoutSeconds = &seconds
+	return ac
+}
+
+// TimeoutAction sets the timeoutAction of the AdmissionCheck.
+func (ac *AdmissionCheckWrapper) TimeoutAction(action kueue.AdmissionCheckTimeoutAction) *AdmissionCheckWrapper {
+	ac.Spec.TimeoutAction = &action
+	return ac
+}
+
 func (ac *AdmissionCheckWrapper) Obj() *kueue.AdmissionCheck {
 	return &ac.AdmissionCheck
 }
@@ -1769,6 +1917,11 @@ func (prc *ProvisioningRequestConfigWrapper) PodSetMergePolicy(mode kueue.Provis
 	return prc
 }
 
+func (prc *ProvisioningRequestConfigWrapper) MaxConcurrentRequests(n int32) *ProvisioningRequestConfigWrapper {
+	prc.Spec.MaxConcurrentRequests = &n
+	return prc
+}
+
 func (prc *ProvisioningRequestConfigWrapper) Clone() *ProvisioningRequestConfigWrapper {
 	return &ProvisioningRequestConfigWrapper{ProvisioningRequestConfig: *prc.DeepCopy()}
 }
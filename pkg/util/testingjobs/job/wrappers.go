@@ -137,6 +137,12 @@ func (j *JobWrapper) Completions(p int32) *JobWrapper {
 	return j
 }
 
+// PodFailurePolicy sets the job's pod failure policy.
+func (j *JobWrapper) PodFailurePolicy(policy *batchv1.PodFailurePolicy) *JobWrapper {
+	j.Spec.PodFailurePolicy = policy
+	return j
+}
+
 // Indexed sets the job's completion to Indexed of NonIndexed
 func (j *JobWrapper) Indexed(indexed bool) *JobWrapper {
 	mode := batchv1.NonIndexedCompletion
@@ -302,12 +308,30 @@ func (j *JobWrapper) Active(c int32) *JobWrapper {
 	return j
 }
 
+// Terminating sets the .status.terminating
+func (j *JobWrapper) Terminating(c int32) *JobWrapper {
+	j.Status.Terminating = &c
+	return j
+}
+
 // Failed sets the .status.failed
 func (j *JobWrapper) Failed(c int32) *JobWrapper {
 	j.Status.Failed = c
 	return j
 }
 
+// Succeeded sets the .status.succeeded
+func (j *JobWrapper) Succeeded(c int32) *JobWrapper {
+	j.Status.Succeeded = c
+	return j
+}
+
+// CompletedIndexes sets the .status.completedIndexes
+func (j *JobWrapper) CompletedIndexes(indexes string) *JobWrapper {
+	j.Status.CompletedIndexes = indexes
+	return j
+}
+
 // Ready sets the .status.ready
 func (j *JobWrapper) Ready(c int32) *JobWrapper {
 	j.Status.Ready = &c
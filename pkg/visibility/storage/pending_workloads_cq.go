@@ -74,6 +74,7 @@ func (m *pendingWorkloadsInCqREST) Get(_ context.Context, name string, opts runt
 	wls := make([]visibility.PendingWorkload, 0, min(limit, int64(len(pendingWorkloadsInfo))))
 
 	localQueuePositions := make(map[kueue.LocalQueueName]int32, 0)
+	throughput := admissionThroughput(m.queueMgr, kueue.ClusterQueueReference(name))
 
 	for index := 0; index < int(offset+limit) && index < len(pendingWorkloadsInfo); index++ {
 		// Update positions in LocalQueue
@@ -84,7 +85,7 @@ func (m *pendingWorkloadsInCqREST) Get(_ context.Context, name string, opts runt
 
 		if index >= int(offset) {
 			// Add a workload to results
-			wls = append(wls, *newPendingWorkload(wlInfo, positionInLocalQueue, index))
+			wls = append(wls, *newPendingWorkload(wlInfo, positionInLocalQueue, index, throughput))
 		}
 	}
 	return &visibility.PendingWorkloadsSummary{Items: wls}, nil
@@ -79,6 +79,7 @@ func (m *pendingWorkloadsInLqREST) Get(ctx context.Context, name string, opts ru
 	pendingWorkloadsInfo := m.queueMgr.PendingWorkloadsInfo(cqName)
 	wls := make([]visibility.PendingWorkload, 0, min(limit, int64(len(pendingWorkloadsInfo))))
 	skippedWls := 0
+	throughput := admissionThroughput(m.queueMgr, cqName)
 	for index, wlInfo := range pendingWorkloadsInfo {
 		if len(wls) >= int(limit) {
 			break
@@ -88,7 +89,7 @@ func (m *pendingWorkloadsInLqREST) Get(ctx context.Context, name string, opts ru
 				skippedWls++
 			} else {
 				// Add a workload to results
-				wls = append(wls, *newPendingWorkload(wlInfo, int32(len(wls)+int(offset)), index))
+				wls = append(wls, *newPendingWorkload(wlInfo, int32(len(wls)+int(offset)), index, throughput))
 			}
 		}
 	}
@@ -19,8 +19,11 @@ package storage
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta2"
+	qcache "sigs.k8s.io/kueue/pkg/cache/queue"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -31,7 +34,17 @@ func boundedLimitOffset(limit, offset int64) (int64, int64) {
 	return limit, offset
 }
 
-func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq int) *visibility.PendingWorkload {
+// admissionThroughput returns the admission throughput of the ClusterQueue
+// used to estimate the wait times of its pending workloads, or 0 when the
+// estimation is disabled.
+func admissionThroughput(queueMgr *qcache.Manager, cqName kueue.ClusterQueueReference) float64 {
+	if !features.Enabled(features.WorkloadEstimatedWaitTime) {
+		return 0
+	}
+	return queueMgr.AdmissionThroughput(cqName)
+}
+
+func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq int, throughput float64) *visibility.PendingWorkload {
 	ownerReferences := make([]metav1.OwnerReference, 0, len(wlInfo.Obj.OwnerReferences))
 	for _, ref := range wlInfo.Obj.OwnerReferences {
 		ownerReferences = append(ownerReferences, metav1.OwnerReference{
@@ -41,6 +54,10 @@ func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq
 			UID:        ref.UID,
 		})
 	}
+	var estimatedWaitTime *metav1.Duration
+	if waitTime := qcache.EstimatedWaitTime(throughput, positionInCq); waitTime != nil {
+		estimatedWaitTime = &metav1.Duration{Duration: *waitTime}
+	}
 	return &visibility.PendingWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              wlInfo.Obj.Name,
@@ -52,5 +69,6 @@ func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq
 		Priority:               *wlInfo.Obj.Spec.Priority,
 		LocalQueueName:         wlInfo.Obj.Spec.QueueName,
 		PositionInLocalQueue:   positionInLq,
+		EstimatedWaitTime:      estimatedWaitTime,
 	}
 }
@@ -19,19 +19,24 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/util/borrowingwindow"
 	utilqueue "sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
@@ -44,12 +49,16 @@ const (
 
 var admissionChecksPath = field.NewPath("spec", "admissionChecksStrategy", "admissionChecks")
 
-type ClusterQueueWebhook struct{}
+type ClusterQueueWebhook struct {
+	client      client.Client
+	fairSharing bool
+}
 
-func setupWebhookForClusterQueue(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) error {
+func setupWebhookForClusterQueue(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker, options Options) error {
+	wh := &ClusterQueueWebhook{client: mgr.GetClient(), fairSharing: options.FairSharing}
 	return ctrl.NewWebhookManagedBy(mgr, &kueue.ClusterQueue{}).
-		WithDefaulter(&ClusterQueueWebhook{}).
-		WithValidator(&ClusterQueueWebhook{}).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		WithLogConstructor(roletracker.WebhookLogConstructor(roleTracker)).
 		Complete()
 }
@@ -77,7 +86,8 @@ func (w *ClusterQueueWebhook) ValidateCreate(ctx context.Context, cq *kueue.Clus
 	log := ctrl.LoggerFrom(ctx).WithName("clusterqueue-webhook")
 	log.V(5).Info("Validating create")
 	allErrs := ValidateClusterQueue(cq)
-	return nil, allErrs.ToAggregate()
+	allErrs = append(allErrs, w.validatePreemptionStages(cq)...)
+	return w.missingReferencesWarnings(ctx, cq), allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -85,7 +95,8 @@ func (w *ClusterQueueWebhook) ValidateUpdate(ctx context.Context, oldCQ, newCQ *
 	log := ctrl.LoggerFrom(ctx).WithName("clusterqueue-webhook")
 	log.V(5).Info("Validating update")
 	allErrs := ValidateClusterQueueUpdate(oldCQ, newCQ)
-	return nil, allErrs.ToAggregate()
+	allErrs = append(allErrs, w.validatePreemptionStages(newCQ)...)
+	return w.missingReferencesWarnings(ctx, newCQ), allErrs.ToAggregate()
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
@@ -93,6 +104,56 @@ func (w *ClusterQueueWebhook) ValidateDelete(_ context.Context, _ *kueue.Cluster
 	return nil, nil
 }
 
+// missingReferencesWarnings warns about the ResourceFlavors, and the Topologies
+// of the TAS flavors, referenced by the ClusterQueue which don't exist.
+// The ClusterQueue is admitted, but stays inactive until they are created.
+func (w *ClusterQueueWebhook) missingReferencesWarnings(ctx context.Context, cq *kueue.ClusterQueue) admission.Warnings {
+	if w.client == nil {
+		return nil
+	}
+	log := ctrl.LoggerFrom(ctx).WithName("clusterqueue-webhook")
+	var warnings admission.Warnings
+	seen := sets.New[kueue.ResourceFlavorReference]()
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			if seen.Has(fq.Name) {
+				continue
+			}
+			seen.Insert(fq.Name)
+			var rf kueue.ResourceFlavor
+			if err := w.client.Get(ctx, types.NamespacedName{Name: string(fq.Name)}, &rf); err != nil {
+				if apierrors.IsNotFound(err) {
+					warnings = append(warnings, fmt.Sprintf("ResourceFlavor %q not found, the ClusterQueue will be inactive until it is created", fq.Name))
+				} else {
+					log.Error(err, "Failed to get ResourceFlavor", "resourceFlavor", fq.Name)
+				}
+				continue
+			}
+			if !features.Enabled(features.TopologyAwareScheduling) || rf.Spec.TopologyName == nil {
+				continue
+			}
+			var topology kueue.Topology
+			if err := w.client.Get(ctx, types.NamespacedName{Name: string(*rf.Spec.TopologyName)}, &topology); err != nil {
+				if apierrors.IsNotFound(err) {
+					warnings = append(warnings, fmt.Sprintf("Topology %q of ResourceFlavor %q not found, the ClusterQueue will be inactive until it is created", *rf.Spec.TopologyName, fq.Name))
+				} else {
+					log.Error(err, "Failed to get Topology", "topology", *rf.Spec.TopologyName)
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+// validatePreemptionStages rejects the preemption stages when Fair Sharing is
+// enabled, as they only apply to the Classic Preemption.
+func (w *ClusterQueueWebhook) validatePreemptionStages(cq *kueue.ClusterQueue) field.ErrorList {
+	if !w.fairSharing || cq.Spec.Preemption == nil || len(cq.Spec.Preemption.Stages) == 0 {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("spec", "preemption", "stages"), "must not be set when Fair Sharing is enabled")}
+}
+
 func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 	allErrs := validateClusterQueueSpec(cq)
 	allErrs = append(allErrs, validateAdmissionCheckOnFlavors(cq)...)
@@ -132,6 +193,111 @@ func validateClusterQueueSpec(cq *kueue.ClusterQueue) field.ErrorList {
 	allErrs = append(allErrs, validateTotalCoveredResources(cq.Spec.ResourceGroups, path.Child("resourceGroups"))...)
 	allErrs = append(allErrs, validateFlavorResourceCombinations(cq.Spec.ResourceGroups, path.Child("resourceGroups"))...)
 	allErrs = append(allErrs, validateConcurrentAdmissionPolicy(cq, path)...)
+	allErrs = append(allErrs, validateResourceBorrowingLimits(cq, config, path.Child("resourceBorrowingLimits"))...)
+	allErrs = append(allErrs, validateBorrowingWindows(cq.Spec.BorrowingWindows, path.Child("borrowingWindows"))...)
+	allErrs = append(allErrs, validateOvercommitRatios(cq.Spec.OvercommitRatios, path.Child("overcommitRatios"))...)
+	allErrs = append(allErrs, validateNodeCapacityFlavors(cq, path.Child("nodeCapacityFlavors"))...)
+	allErrs = append(allErrs, validateDefaultContainerRequests(cq.Spec.DefaultContainerRequests, path.Child("defaultContainerRequests"))...)
+	return allErrs
+}
+
+func validateDefaultContainerRequests(requests corev1.ResourceList, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for name, quantity := range requests {
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Key(string(name)), quantity.String(), "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
+// validateNodeCapacityFlavors enforces that the quota is only derived from the
+// node capacity for the flavors of a ClusterQueue outside of any cohort, as
+// the ClusterQueues of a cohort would otherwise share the same nodes several
+// times.
+func validateNodeCapacityFlavors(cq *kueue.ClusterQueue, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(cq.Spec.NodeCapacityFlavors) == 0 {
+		return allErrs
+	}
+	if cq.Spec.CohortName != "" {
+		allErrs = append(allErrs, field.Forbidden(path, "must not be set for a ClusterQueue in a cohort"))
+	}
+	flavors := sets.New[kueue.ResourceFlavorReference]()
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, flavor := range rg.Flavors {
+			flavors.Insert(flavor.Name)
+		}
+	}
+	for i, flavor := range cq.Spec.NodeCapacityFlavors {
+		if !flavors.Has(flavor) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), flavor, "must be a flavor of the resourceGroups"))
+		}
+	}
+	return allErrs
+}
+
+// validateBorrowingWindows enforces that the schedule of every borrowing window
+// is a valid cron expression.
+func validateBorrowingWindows(windows []kueue.BorrowingWindow, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, window := range windows {
+		if _, err := borrowingwindow.ParseSchedule(window.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("schedule"), window.Schedule, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// overcommittableResources are the resources whose nominal quota can be
+// scaled by an overcommit ratio.
+var overcommittableResources = []corev1.ResourceName{corev1.ResourceCPU}
+
+// maxOvercommitRatio bounds the overcommit ratios, so that the overcommitted
+// quota stays within reasonable bounds of the nominal quota.
+var maxOvercommitRatio = resource.MustParse("10")
+
+// validateOvercommitRatios enforces that only compressible resources are
+// overcommitted and that the ratios do not shrink the nominal quota nor
+// exceed maxOvercommitRatio.
+func validateOvercommitRatios(ratios []kueue.ResourceOvercommitRatio, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	one := resource.MustParse("1")
+	for i, ratio := range ratios {
+		path := path.Index(i)
+		if !slices.Contains(overcommittableResources, ratio.Name) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("name"), ratio.Name, overcommittableResources))
+		}
+		if ratio.Ratio.Cmp(one) < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("ratio"), ratio.Ratio.String(), "must be greater than or equal to 1"))
+		}
+		if ratio.Ratio.Cmp(maxOvercommitRatio) > 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("ratio"), ratio.Ratio.String(), "must be less than or equal to 10"))
+		}
+	}
+	return allErrs
+}
+
+// validateResourceBorrowingLimits enforces that every resource borrowing limit
+// refers to a covered resource and satisfies the same rules as a borrowingLimit.
+func validateResourceBorrowingLimits(cq *kueue.ClusterQueue, config validationConfig, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(cq.Spec.ResourceBorrowingLimits) == 0 {
+		return allErrs
+	}
+	coveredResources := sets.New[corev1.ResourceName]()
+	for _, rg := range cq.Spec.ResourceGroups {
+		coveredResources.Insert(rg.CoveredResources...)
+	}
+	for i, limit := range cq.Spec.ResourceBorrowingLimits {
+		path := path.Index(i)
+		if !coveredResources.Has(limit.Name) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("name"), limit.Name, sets.List(coveredResources)))
+		}
+		borrowingLimitPath := path.Child("borrowingLimit")
+		allErrs = append(allErrs, validateLimit(limit.BorrowingLimit, config, borrowingLimitPath, false)...)
+		allErrs = append(allErrs, validateResourceQuantity(limit.BorrowingLimit, borrowingLimitPath)...)
+	}
 	return allErrs
 }
 
@@ -28,9 +28,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
 )
 
@@ -485,6 +488,144 @@ func TestValidateClusterQueue(t *testing.T) {
 			wantDetail:   "must be one of the flavors defined in the ClusterQueue: [flavor1]",
 			wantBadValue: "non-existent-flavor",
 		},
+		{
+			name: "resourceBorrowingLimits for a covered resource in cohort",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				Cohort("prod").
+				ResourceGroup(
+					*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj(),
+					*utiltestingapi.MakeFlavorQuotas("arm").Resource("cpu", "1").Obj()).
+				ResourceBorrowingLimit("cpu", "2").
+				Obj(),
+		},
+		{
+			name: "resourceBorrowingLimits without cohort",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj()).
+				ResourceBorrowingLimit("cpu", "2").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("resourceBorrowingLimits").Index(0).Child("borrowingLimit"), "2", limitIsEmptyErrorMsgTemplate),
+			},
+		},
+		{
+			name: "resourceBorrowingLimits with negative value",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				Cohort("prod").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj()).
+				ResourceBorrowingLimit("cpu", "-1").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("resourceBorrowingLimits").Index(0).Child("borrowingLimit"), "-1", ""),
+			},
+		},
+		{
+			name: "resourceBorrowingLimits for a resource that is not covered",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				Cohort("prod").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj()).
+				ResourceBorrowingLimit("memory", "1Gi").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.NotSupported(specPath.Child("resourceBorrowingLimits").Index(0).Child("name"), corev1.ResourceMemory, []corev1.ResourceName{corev1.ResourceCPU}),
+			},
+		},
+		{
+			name: "borrowingWindows with a valid schedule",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				Cohort("prod").
+				BorrowingWindow("0 18 * * 1-5", 14*3600).
+				Obj(),
+		},
+		{
+			name: "borrowingWindows with an invalid schedule",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				Cohort("prod").
+				BorrowingWindow("0 18 * * 1-5", 14*3600).
+				BorrowingWindow("every night", 3600).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("borrowingWindows").Index(1).Child("schedule"), "every night", ""),
+			},
+		},
+		{
+			name: "overcommitRatios for cpu",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj()).
+				OvercommitRatio("cpu", "1.5").
+				Obj(),
+		},
+		{
+			name: "overcommitRatios for memory",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("memory", "1Gi").Obj()).
+				OvercommitRatio("memory", "1.5").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.NotSupported(specPath.Child("overcommitRatios").Index(0).Child("name"), corev1.ResourceMemory, []corev1.ResourceName{corev1.ResourceCPU}),
+			},
+		},
+		{
+			name: "overcommitRatios with ratio lower than 1",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj()).
+				OvercommitRatio("cpu", "0.5").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("overcommitRatios").Index(0).Child("ratio"), "500m", ""),
+			},
+		},
+		{
+			name: "overcommitRatios with ratio greater than 10",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj()).
+				OvercommitRatio("cpu", "11").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("overcommitRatios").Index(0).Child("ratio"), "11", ""),
+			},
+		},
+		{
+			name: "nodeCapacityFlavors for a flavor of the resourceGroups",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "0").Obj()).
+				NodeCapacityFlavors("x86").
+				Obj(),
+		},
+		{
+			name: "nodeCapacityFlavors for a ClusterQueue in a cohort",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				Cohort("cohort").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "0").Obj()).
+				NodeCapacityFlavors("x86").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(specPath.Child("nodeCapacityFlavors"), ""),
+			},
+		},
+		{
+			name: "nodeCapacityFlavors for a flavor not in the resourceGroups",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "0").Obj()).
+				NodeCapacityFlavors("arm").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("nodeCapacityFlavors").Index(0), "arm", ""),
+			},
+		},
+		{
+			name: "defaultContainerRequests with a negative quantity",
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj()).
+				DefaultContainerRequests(corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("-1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("defaultContainerRequests").Key("cpu"), "-1", ""),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -820,3 +961,123 @@ func TestValidateFlavorResourceCombinations(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterQueueWebhookWarnings(t *testing.T) {
+	testcases := map[string]struct {
+		clusterQueue *kueue.ClusterQueue
+		objs         []client.Object
+		enableTAS    bool
+		wantWarnings admission.Warnings
+	}{
+		"all references exist": {
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+				Obj(),
+			objs: []client.Object{utiltestingapi.MakeResourceFlavor("default").Obj()},
+		},
+		"missing resource flavor": {
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj(),
+					*utiltestingapi.MakeFlavorQuotas("missing").Resource(corev1.ResourceCPU, "1").Obj(),
+				).
+				Obj(),
+			objs: []client.Object{utiltestingapi.MakeResourceFlavor("default").Obj()},
+			wantWarnings: admission.Warnings{
+				`ResourceFlavor "missing" not found, the ClusterQueue will be inactive until it is created`,
+			},
+		},
+		"missing topology": {
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("tas").Resource(corev1.ResourceCPU, "1").Obj()).
+				Obj(),
+			objs:      []client.Object{utiltestingapi.MakeResourceFlavor("tas").TopologyName("default").Obj()},
+			enableTAS: true,
+			wantWarnings: admission.Warnings{
+				`Topology "default" of ResourceFlavor "tas" not found, the ClusterQueue will be inactive until it is created`,
+			},
+		},
+		"missing topology is ignored when TAS is disabled": {
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("tas").Resource(corev1.ResourceCPU, "1").Obj()).
+				Obj(),
+			objs: []client.Object{utiltestingapi.MakeResourceFlavor("tas").TopologyName("default").Obj()},
+		},
+		"existing topology": {
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*utiltestingapi.MakeFlavorQuotas("tas").Resource(corev1.ResourceCPU, "1").Obj()).
+				Obj(),
+			objs: []client.Object{
+				utiltestingapi.MakeResourceFlavor("tas").TopologyName("default").Obj(),
+				utiltestingapi.MakeTopology("default").Levels(corev1.LabelHostname).Obj(),
+			},
+			enableTAS: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGatesDuringTest(t, map[featuregate.Feature]bool{features.TopologyAwareScheduling: tc.enableTAS})
+			ctx, _ := utiltesting.ContextWithLog(t)
+			wh := &ClusterQueueWebhook{client: utiltesting.NewClientBuilder().WithObjects(tc.objs...).Build()}
+			gotWarnings, err := wh.ValidateCreate(ctx, tc.clusterQueue)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, gotWarnings, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected warnings (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePreemptionStages(t *testing.T) {
+	stagesPath := field.NewPath("spec", "preemption", "stages")
+	withStages := utiltestingapi.MakeClusterQueue("cluster-queue").
+		Preemption(kueue.ClusterQueuePreemption{
+			WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+			Stages:             []kueue.PreemptionStage{kueue.PreemptionStageWithinClusterQueue, kueue.PreemptionStageFlavorDowngrade},
+		}).
+		Obj()
+	testcases := map[string]struct {
+		clusterQueue *kueue.ClusterQueue
+		fairSharing  bool
+		wantErr      field.ErrorList
+	}{
+		"stages with Classic Preemption": {
+			clusterQueue: withStages,
+		},
+		"stages with Fair Sharing": {
+			clusterQueue: withStages,
+			fairSharing:  true,
+			wantErr: field.ErrorList{
+				field.Forbidden(stagesPath, ""),
+			},
+		},
+		"no stages with Fair Sharing": {
+			clusterQueue: utiltestingapi.MakeClusterQueue("cluster-queue").
+				Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+				Obj(),
+			fairSharing: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			wh := &ClusterQueueWebhook{client: utiltesting.NewClientBuilder().Build(), fairSharing: tc.fairSharing}
+			_, gotErr := wh.ValidateCreate(ctx, tc.clusterQueue)
+			var wantErr error
+			if len(tc.wantErr) > 0 {
+				wantErr = tc.wantErr.ToAggregate()
+			}
+			if diff := cmp.Diff(wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+			_, gotErr = wh.ValidateUpdate(ctx, tc.clusterQueue, tc.clusterQueue)
+			if diff := cmp.Diff(wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("Unexpected update error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/roletracker"
+	"sigs.k8s.io/kueue/pkg/workload/finish"
+)
+
+type LocalQueueWebhook struct {
+	client client.Client
+}
+
+func setupWebhookForLocalQueue(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) error {
+	return ctrl.NewWebhookManagedBy(mgr, &kueue.LocalQueue{}).
+		WithValidator(&LocalQueueWebhook{client: mgr.GetClient()}).
+		WithLogConstructor(roletracker.WebhookLogConstructor(roleTracker)).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1beta2-localqueue,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=localqueues,verbs=create;update,versions=v1beta2,name=vlocalqueue.kb.io,admissionReviewVersions=v1
+
+// The deletions are validated by a separate webhook which ignores the
+// failures, so that the LocalQueues can still be deleted when the webhook
+// is unavailable, or the LocalQueueDeletionProtection feature is disabled.
+// +kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1beta2-localqueue,mutating=false,failurePolicy=ignore,sideEffects=None,groups=kueue.x-k8s.io,resources=localqueues,verbs=delete,versions=v1beta2,name=vlocalqueuedeletion.kb.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*kueue.LocalQueue] = &LocalQueueWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateCreate(ctx context.Context, lq *kueue.LocalQueue) (admission.Warnings, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("localqueue-webhook")
+	log.V(5).Info("Validating create")
+	allErrs, err := w.validateGuaranteedQuota(ctx, lq)
+	if err != nil {
+		return nil, err
+	}
+	return nil, allErrs.ToAggregate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateUpdate(ctx context.Context, _, newLQ *kueue.LocalQueue) (admission.Warnings, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("localqueue-webhook")
+	log.V(5).Info("Validating update")
+	allErrs, err := w.validateGuaranteedQuota(ctx, newLQ)
+	if err != nil {
+		return nil, err
+	}
+	return nil, allErrs.ToAggregate()
+}
+
+// validateGuaranteedQuota validates that the flavors and resources of the
+// guaranteed quota of the LocalQueue are defined in its ClusterQueue, and that
+// the guaranteed quota of all the LocalQueues of the ClusterQueue stays within
+// the nominal quota of the ClusterQueue.
+func (w *LocalQueueWebhook) validateGuaranteedQuota(ctx context.Context, lq *kueue.LocalQueue) (field.ErrorList, error) {
+	if !features.Enabled(features.LocalQueueGuaranteedQuota) || len(lq.Spec.GuaranteedQuota) == 0 {
+		return nil, nil
+	}
+	cq := &kueue.ClusterQueue{}
+	if err := w.client.Get(ctx, client.ObjectKey{Name: string(lq.Spec.ClusterQueue)}, cq); err != nil {
+		// The ClusterQueue might be created after the LocalQueue.
+		return nil, client.IgnoreNotFound(err)
+	}
+	nominal := make(map[resources.FlavorResource]resource.Quantity)
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			for _, rq := range fq.Resources {
+				nominal[resources.FlavorResource{Flavor: fq.Name, Resource: rq.Name}] = rq.NominalQuota
+			}
+		}
+	}
+
+	var localQueues kueue.LocalQueueList
+	if err := w.client.List(ctx, &localQueues, client.MatchingFields{indexer.QueueClusterQueueKey: string(lq.Spec.ClusterQueue)}); err != nil {
+		return nil, err
+	}
+	guaranteed := make(map[resources.FlavorResource]resource.Quantity)
+	for i := range localQueues.Items {
+		other := &localQueues.Items[i]
+		if other.Namespace == lq.Namespace && other.Name == lq.Name {
+			continue
+		}
+		for _, fq := range other.Spec.GuaranteedQuota {
+			for _, rq := range fq.Resources {
+				fr := resources.FlavorResource{Flavor: fq.Name, Resource: rq.Name}
+				sum := guaranteed[fr]
+				sum.Add(rq.Quota)
+				guaranteed[fr] = sum
+			}
+		}
+	}
+
+	var allErrs field.ErrorList
+	basePath := field.NewPath("spec", "guaranteedQuota")
+	for i, fq := range lq.Spec.GuaranteedQuota {
+		for j, rq := range fq.Resources {
+			path := basePath.Index(i).Child("resources").Index(j)
+			fr := resources.FlavorResource{Flavor: fq.Name, Resource: rq.Name}
+			nominalQuota, found := nominal[fr]
+			if !found {
+				allErrs = append(allErrs, field.NotFound(path.Child("name"), fmt.Sprintf("%s in flavor %s of ClusterQueue %s", rq.Name, fq.Name, cq.Name)))
+				continue
+			}
+			sum := guaranteed[fr]
+			sum.Add(rq.Quota)
+			if sum.Cmp(nominalQuota) > 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("quota"), rq.Quota.String(),
+					fmt.Sprintf("the guaranteed quota of the LocalQueues of ClusterQueue %s (%s) exceeds its nominal quota (%s)", cq.Name, sum.String(), nominalQuota.String())))
+			}
+		}
+	}
+	return allErrs, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateDelete(ctx context.Context, lq *kueue.LocalQueue) (admission.Warnings, error) {
+	if !features.Enabled(features.LocalQueueDeletionProtection) {
+		return nil, nil
+	}
+	log := ctrl.LoggerFrom(ctx).WithName("localqueue-webhook")
+	log.V(5).Info("Validating delete")
+	active, err := w.countNonFinishedWorkloads(ctx, lq)
+	if err != nil {
+		return nil, err
+	}
+	if active > 0 {
+		log.V(3).Info("Rejecting the deletion of the LocalQueue with non-finished workloads", "localQueue", klog.KObj(lq), "workloads", active)
+		return nil, fmt.Errorf("LocalQueue %q has %d non-finished workloads; wait for them to finish or delete them before deleting the LocalQueue", lq.Name, active)
+	}
+	return nil, nil
+}
+
+// countNonFinishedWorkloads returns the number of Workloads submitted to the
+// LocalQueue which are not finished yet.
+func (w *LocalQueueWebhook) countNonFinishedWorkloads(ctx context.Context, lq *kueue.LocalQueue) (int, error) {
+	var workloads kueue.WorkloadList
+	if err := w.client.List(ctx, &workloads, client.InNamespace(lq.Namespace), client.MatchingFields{indexer.WorkloadQueueKey: lq.Name}); err != nil {
+		return 0, err
+	}
+	count := 0
+	for i := range workloads.Items {
+		if !finish.IsFinished(&workloads.Items[i]) {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func TestValidateLocalQueueDelete(t *testing.T) {
+	now := time.Now()
+	admitted := utiltestingapi.MakeWorkload("admitted", "ns").
+		Queue("lq").
+		ReserveQuotaAt(utiltestingapi.MakeAdmission("cq").Obj(), now).
+		AdmittedAt(true, now).
+		Obj()
+	pending := utiltestingapi.MakeWorkload("pending", "ns").Queue("lq").Obj()
+	finished := utiltestingapi.MakeWorkload("finished", "ns").Queue("lq").FinishedAt(now).Obj()
+	otherQueue := utiltestingapi.MakeWorkload("other-queue", "ns").Queue("other-lq").Obj()
+	otherNamespace := utiltestingapi.MakeWorkload("other-namespace", "other-ns").Queue("lq").Obj()
+
+	testCases := map[string]struct {
+		enableFeature bool
+		workloads     []client.Object
+		wantErr       string
+	}{
+		"no workloads": {
+			enableFeature: true,
+		},
+		"admitted workload": {
+			enableFeature: true,
+			workloads:     []client.Object{admitted},
+			wantErr:       `LocalQueue "lq" has 1 non-finished workloads; wait for them to finish or delete them before deleting the LocalQueue`,
+		},
+		"admitted and pending workloads": {
+			enableFeature: true,
+			workloads:     []client.Object{admitted, pending, finished},
+			wantErr:       `LocalQueue "lq" has 2 non-finished workloads; wait for them to finish or delete them before deleting the LocalQueue`,
+		},
+		"only finished workloads and workloads of other queues": {
+			enableFeature: true,
+			workloads:     []client.Object{finished, otherQueue, otherNamespace},
+		},
+		"admitted workload, feature disabled": {
+			workloads: []client.Object{admitted},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.LocalQueueDeletionProtection, tc.enableFeature)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.workloads...).Build()
+			wh := &LocalQueueWebhook{client: cl}
+
+			_, err := wh.ValidateDelete(ctx, utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj())
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("Unexpected error: got %q, want %q", gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLocalQueueGuaranteedQuota(t *testing.T) {
+	cq := utiltestingapi.MakeClusterQueue("cq").
+		ResourceGroup(*utiltestingapi.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+
+	testCases := map[string]struct {
+		enableFeature bool
+		objs          []client.Object
+		lq            *kueue.LocalQueue
+		wantErr       string
+	}{
+		"within the nominal quota": {
+			enableFeature: true,
+			objs: []client.Object{
+				cq,
+				utiltestingapi.MakeLocalQueue("other-lq", "other-ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceCPU, "4").Obj(),
+			},
+			lq: utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceCPU, "6").Obj(),
+		},
+		"the updated LocalQueue isn't accounted twice": {
+			enableFeature: true,
+			objs: []client.Object{
+				cq,
+				utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceCPU, "6").Obj(),
+			},
+			lq: utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceCPU, "8").Obj(),
+		},
+		"exceeds the nominal quota": {
+			enableFeature: true,
+			objs: []client.Object{
+				cq,
+				utiltestingapi.MakeLocalQueue("other-lq", "other-ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceCPU, "4").Obj(),
+				utiltestingapi.MakeLocalQueue("other-cq-lq", "ns").ClusterQueue("other-cq").GuaranteedQuota("default", corev1.ResourceCPU, "10").Obj(),
+			},
+			lq:      utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceCPU, "7").Obj(),
+			wantErr: `spec.guaranteedQuota[0].resources[0].quota: Invalid value: "7": the guaranteed quota of the LocalQueues of ClusterQueue cq (11) exceeds its nominal quota (10)`,
+		},
+		"flavor not in the ClusterQueue": {
+			enableFeature: true,
+			objs:          []client.Object{cq},
+			lq:            utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").GuaranteedQuota("other", corev1.ResourceCPU, "1").Obj(),
+			wantErr:       `spec.guaranteedQuota[0].resources[0].name: Not found: "cpu in flavor other of ClusterQueue cq"`,
+		},
+		"resource not in the ClusterQueue": {
+			enableFeature: true,
+			objs:          []client.Object{cq},
+			lq:            utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceMemory, "1Gi").Obj(),
+			wantErr:       `spec.guaranteedQuota[0].resources[0].name: Not found: "memory in flavor default of ClusterQueue cq"`,
+		},
+		"ClusterQueue not found": {
+			enableFeature: true,
+			lq:            utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceCPU, "20").Obj(),
+		},
+		"exceeds the nominal quota, feature disabled": {
+			objs: []client.Object{cq},
+			lq:   utiltestingapi.MakeLocalQueue("lq", "ns").ClusterQueue("cq").GuaranteedQuota("default", corev1.ResourceCPU, "20").Obj(),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.LocalQueueGuaranteedQuota, tc.enableFeature)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.objs...).Build()
+			wh := &LocalQueueWebhook{client: cl}
+
+			_, err := wh.ValidateUpdate(ctx, nil, tc.lq)
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("Unexpected error: got %q, want %q", gotErr, tc.wantErr)
+			}
+		})
+	}
+}
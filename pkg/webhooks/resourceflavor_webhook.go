@@ -89,6 +89,32 @@ func ValidateResourceFlavor(rf *kueue.ResourceFlavor) field.ErrorList {
 
 	allErrs = append(allErrs, validateNodeTaints(rf.Spec.NodeTaints, specPath.Child("nodeTaints"))...)
 	allErrs = append(allErrs, validateTolerations(rf.Spec.Tolerations, specPath.Child("tolerations"))...)
+	allErrs = append(allErrs, metavalidation.ValidateLabelSelector(rf.Spec.NamespaceSelector, metavalidation.LabelSelectorValidationOptions{}, specPath.Child("namespaceSelector"))...)
+	allErrs = append(allErrs, validateResourceScaling(rf, specPath.Child("resourceScaling"))...)
+	allErrs = append(allErrs, validateNodeShape(rf.Spec.NodeShape, specPath.Child("nodeShape"))...)
+	return allErrs
+}
+
+func validateNodeShape(nodeShape corev1.ResourceList, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for res, q := range nodeShape {
+		if q.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(res)), q.String(), "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
+
+func validateResourceScaling(rf *kueue.ResourceFlavor, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(rf.Spec.ResourceScaling) > 0 && rf.Spec.TopologyName != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "must not be set when topologyName is set"))
+	}
+	for res, factor := range rf.Spec.ResourceScaling {
+		if factor < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(res)), factor, "must be greater than or equal to 1"))
+		}
+	}
 	return allErrs
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
@@ -65,6 +66,63 @@ func TestValidateResourceFlavor(t *testing.T) {
 					WithOrigin("format=k8s-label-value"),
 			},
 		},
+		{
+			name: "valid namespace selector",
+			rf: utiltestingapi.MakeResourceFlavor("resource-flavor").
+				NamespaceSelector(&metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "a"},
+				}).Obj(),
+		},
+		{
+			name: "invalid namespace selector",
+			rf: utiltestingapi.MakeResourceFlavor("resource-flavor").
+				NamespaceSelector(&metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "team",
+						Operator: "NoSuchOperator",
+					}},
+				}).Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "namespaceSelector", "matchExpressions").Index(0).Child("operator"), metav1.LabelSelectorOperator("NoSuchOperator"), ""),
+			},
+		},
+		{
+			name: "valid resource scaling",
+			rf: utiltestingapi.MakeResourceFlavor("resource-flavor").
+				ResourceScaling("nvidia.com/gpu", 4).Obj(),
+		},
+		{
+			name: "invalid resource scaling",
+			rf: utiltestingapi.MakeResourceFlavor("resource-flavor").
+				ResourceScaling("nvidia.com/gpu", 0).Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "resourceScaling").Key("nvidia.com/gpu"), int32(0), ""),
+			},
+		},
+		{
+			name: "resource scaling with topology",
+			rf: utiltestingapi.MakeResourceFlavor("resource-flavor").
+				NodeLabel("pool", "gpu").
+				TopologyName("default").
+				ResourceScaling("nvidia.com/gpu", 4).Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "resourceScaling"), ""),
+			},
+		},
+		{
+			name: "valid node shape",
+			rf: utiltestingapi.MakeResourceFlavor("resource-flavor").
+				NodeShape(corev1.ResourceCPU, "96").
+				NodeShape("nvidia.com/gpu", "8").Obj(),
+		},
+		{
+			name: "invalid node shape",
+			rf: utiltestingapi.MakeResourceFlavor("resource-flavor").
+				NodeShape(corev1.ResourceCPU, "0").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "nodeShape").Key("cpu"), "0", ""),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -0,0 +1,136 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/util/roletracker"
+)
+
+type TopologyWebhook struct{}
+
+func setupWebhookForTopology(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) error {
+	return ctrl.NewWebhookManagedBy(mgr, &kueue.Topology{}).
+		WithValidator(&TopologyWebhook{}).
+		WithLogConstructor(roletracker.WebhookLogConstructor(roleTracker)).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1beta2-topology,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=topologies,verbs=create,versions=v1beta2,name=vtopology.kb.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*kueue.Topology] = &TopologyWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *TopologyWebhook) ValidateCreate(ctx context.Context, topology *kueue.Topology) (admission.Warnings, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("topology-webhook")
+	log.V(5).Info("Validating Topology create")
+	return levelsOrderWarnings(topology.Spec.Levels), validateTopology(topology).ToAggregate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *TopologyWebhook) ValidateUpdate(context.Context, *kueue.Topology, *kueue.Topology) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *TopologyWebhook) ValidateDelete(context.Context, *kueue.Topology) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateTopology(topology *kueue.Topology) field.ErrorList {
+	var allErrs field.ErrorList
+	levelsPath := field.NewPath("spec", "levels")
+	levels := topology.Spec.Levels
+	for i, level := range levels {
+		if level.ValueFrom == nil {
+			continue
+		}
+		valueFromPath := levelsPath.Index(i).Child("valueFrom")
+		// The pods are assigned to the nodes by hostname, so that they don't
+		// require the derived levels, which aren't node labels.
+		if levels[len(levels)-1].NodeLabel != corev1.LabelHostname {
+			allErrs = append(allErrs, field.Forbidden(valueFromPath, fmt.Sprintf("requires %q to be the lowest level", corev1.LabelHostname)))
+		}
+		if level.NodeLabel == corev1.LabelHostname {
+			allErrs = append(allErrs, field.Forbidden(valueFromPath, fmt.Sprintf("must not be set for the %q level", corev1.LabelHostname)))
+		}
+		if _, err := regexp.Compile(level.ValueFrom.Regex); err != nil {
+			allErrs = append(allErrs, field.Invalid(valueFromPath.Child("regex"), level.ValueFrom.Regex, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// levelBreadth ranks the well-known kinds of topology levels, from the
+// broadest to the narrowest. The kind of a level is the last dash or dot
+// separated word of the name of its node label, like "zone" in
+// "topology.kubernetes.io/zone", "rack" in "cloud.provider.com/topology-rack"
+// or "clique" in "nvidia.com/gpu.clique".
+var levelBreadth = map[string]int{
+	"region":   0,
+	"zone":     1,
+	"block":    2,
+	"subblock": 3,
+	"rack":     4,
+	// An NVLink domain spans the hosts sharing the high-bandwidth GPU
+	// interconnect, like the hosts of a GB200 NVL72 rack. NVIDIA GPU Feature
+	// Discovery labels the nodes with their domain as the GPU clique.
+	"nvlink":   5,
+	"clique":   5,
+	"host":     6,
+	"hostname": 6,
+	"node":     6,
+}
+
+// levelsOrderWarnings warns about the levels which look broader than a
+// preceding level, as the levels are expected to be ordered from the broadest
+// to the narrowest. The levels which aren't of a well-known kind are not
+// checked.
+func levelsOrderWarnings(levels []kueue.TopologyLevel) admission.Warnings {
+	var warnings admission.Warnings
+	narrowest := -1
+	var narrowestLabel string
+	for _, level := range levels {
+		breadth, known := nodeLabelBreadth(level.NodeLabel)
+		if !known {
+			continue
+		}
+		if breadth < narrowest {
+			warnings = append(warnings, fmt.Sprintf("level %q looks broader than the preceding level %q; levels should be ordered from the broadest to the narrowest", level.NodeLabel, narrowestLabel))
+			continue
+		}
+		narrowest = breadth
+		narrowestLabel = level.NodeLabel
+	}
+	return warnings
+}
+
+func nodeLabelBreadth(nodeLabel string) (int, bool) {
+	name := nodeLabel[strings.LastIndex(nodeLabel, "/")+1:]
+	breadth, known := levelBreadth[strings.ToLower(name[strings.LastIndexAny(name, "-.")+1:])]
+	return breadth, known
+}
@@ -0,0 +1,177 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func TestTopologyWebhookWarnings(t *testing.T) {
+	testcases := map[string]struct {
+		levels       []string
+		wantWarnings admission.Warnings
+	}{
+		"ordered from the broadest to the narrowest": {
+			levels: []string{
+				corev1.LabelTopologyZone,
+				"cloud.provider.com/topology-block",
+				"cloud.provider.com/topology-rack",
+				corev1.LabelHostname,
+			},
+		},
+		"NVLink domain between the rack and the host": {
+			levels: []string{
+				"cloud.provider.com/topology-block",
+				"cloud.provider.com/topology-rack",
+				"cloud.provider.com/topology-nvlink",
+				corev1.LabelHostname,
+			},
+		},
+		"NVLink domain below the host": {
+			levels: []string{
+				"cloud.provider.com/topology-rack",
+				"cloud.provider.com/topology-host",
+				"cloud.provider.com/topology-nvlink",
+			},
+			wantWarnings: admission.Warnings{
+				`level "cloud.provider.com/topology-nvlink" looks broader than the preceding level "cloud.provider.com/topology-host"; levels should be ordered from the broadest to the narrowest`,
+			},
+		},
+		"GPU clique between the rack and the host": {
+			levels: []string{
+				"cloud.provider.com/topology-block",
+				"cloud.provider.com/topology-rack",
+				"nvidia.com/gpu.clique",
+				corev1.LabelHostname,
+			},
+		},
+		"GPU clique below the host": {
+			levels: []string{
+				"cloud.provider.com/topology-rack",
+				"cloud.provider.com/topology-host",
+				"nvidia.com/gpu.clique",
+			},
+			wantWarnings: admission.Warnings{
+				`level "nvidia.com/gpu.clique" looks broader than the preceding level "cloud.provider.com/topology-host"; levels should be ordered from the broadest to the narrowest`,
+			},
+		},
+		"levels of an unknown kind are skipped": {
+			levels: []string{
+				"cloud.provider.com/topology-rack",
+				"cloud.provider.com/topology-superpod",
+				"cloud.provider.com/topology-block",
+			},
+			wantWarnings: admission.Warnings{
+				`level "cloud.provider.com/topology-block" looks broader than the preceding level "cloud.provider.com/topology-rack"; levels should be ordered from the broadest to the narrowest`,
+			},
+		},
+		"inverted": {
+			levels: []string{
+				"cloud.provider.com/topology-rack",
+				"cloud.provider.com/topology-block",
+				corev1.LabelTopologyZone,
+			},
+			wantWarnings: admission.Warnings{
+				`level "cloud.provider.com/topology-block" looks broader than the preceding level "cloud.provider.com/topology-rack"; levels should be ordered from the broadest to the narrowest`,
+				`level "topology.kubernetes.io/zone" looks broader than the preceding level "cloud.provider.com/topology-rack"; levels should be ordered from the broadest to the narrowest`,
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			topology := utiltestingapi.MakeTopology("default").Levels(tc.levels...).Obj()
+			gotWarnings, err := (&TopologyWebhook{}).ValidateCreate(ctx, topology)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, gotWarnings); diff != "" {
+				t.Errorf("Unexpected warnings (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTopology(t *testing.T) {
+	const (
+		blockLabel     = "cloud.provider.com/topology-block"
+		rackLabel      = "cloud.provider.com/topology-rack"
+		compositeLabel = "cloud.provider.com/topology"
+	)
+	testcases := map[string]struct {
+		topology *kueue.Topology
+		wantErr  field.ErrorList
+	}{
+		"no derived levels": {
+			topology: utiltestingapi.MakeTopology("default").Levels(blockLabel, rackLabel).Obj(),
+		},
+		"derived levels": {
+			topology: utiltestingapi.MakeTopology("default").
+				Levels(blockLabel, rackLabel, corev1.LabelHostname).
+				LevelValueFrom(blockLabel, compositeLabel, "^(block[0-9]+)-rack[0-9]+$").
+				LevelValueFrom(rackLabel, compositeLabel, "^(block[0-9]+-rack[0-9]+)$").
+				Obj(),
+		},
+		"invalid regex": {
+			topology: utiltestingapi.MakeTopology("default").
+				Levels(blockLabel, corev1.LabelHostname).
+				LevelValueFrom(blockLabel, compositeLabel, "^(block[0-9]+").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "levels").Index(0).Child("valueFrom", "regex"), nil, ""),
+			},
+		},
+		"lowest level is not hostname": {
+			topology: utiltestingapi.MakeTopology("default").
+				Levels(blockLabel, rackLabel).
+				LevelValueFrom(blockLabel, compositeLabel, "^(block[0-9]+)").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "levels").Index(0).Child("valueFrom"), ""),
+			},
+		},
+		"hostname level derived": {
+			topology: utiltestingapi.MakeTopology("default").
+				Levels(blockLabel, corev1.LabelHostname).
+				LevelValueFrom(corev1.LabelHostname, compositeLabel, "^(node[0-9]+)").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "levels").Index(1).Child("valueFrom"), ""),
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateTopology(tc.topology)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
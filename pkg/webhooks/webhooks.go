@@ -17,16 +17,49 @@ limitations under the License.
 package webhooks
 
 import (
+	"time"
+
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
+type Options struct {
+	EvictionHooksMaxTimeout time.Duration
+	FairSharing             bool
+}
+
+// Option configures the webhooks.
+type Option func(*Options)
+
+// WithEvictionHooksMaxTimeout sets the maximum timeout of the eviction hooks
+// of the Workloads.
+func WithEvictionHooksMaxTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.EvictionHooksMaxTimeout = timeout
+	}
+}
+
+// WithFairSharing indicates whether Fair Sharing is enabled in the configuration.
+func WithFairSharing(enabled bool) Option {
+	return func(o *Options) {
+		o.FairSharing = enabled
+	}
+}
+
+var defaultOptions = Options{
+	EvictionHooksMaxTimeout: workload.DefaultEvictionHooksMaxTimeout,
+}
+
 // Setup sets up the webhooks for core controllers. It returns the name of the
 // webhook that failed to create and an error, if any.
-func Setup(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) (string, error) {
-	if err := setupWebhookForWorkload(mgr, roleTracker); err != nil {
+func Setup(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker, opts ...Option) (string, error) {
+	options := defaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := setupWebhookForWorkload(mgr, roleTracker, options); err != nil {
 		return "Workload", err
 	}
 
@@ -34,7 +67,7 @@ func Setup(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) (string, erro
 		return "ResourceFlavor", err
 	}
 
-	if err := setupWebhookForClusterQueue(mgr, roleTracker); err != nil {
+	if err := setupWebhookForClusterQueue(mgr, roleTracker, options); err != nil {
 		return "ClusterQueue", err
 	}
 
@@ -46,11 +79,9 @@ func Setup(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) (string, erro
 		return "LocalQueue", err
 	}
 
-	return "", nil
-}
+	if err := setupWebhookForTopology(mgr, roleTracker); err != nil {
+		return "Topology", err
+	}
 
-func setupWebhookForLocalQueue(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) error {
-	return ctrl.NewWebhookManagedBy(mgr, &kueue.LocalQueue{}).
-		WithLogConstructor(roletracker.WebhookLogConstructor(roleTracker)).
-		Complete()
+	return "", nil
 }
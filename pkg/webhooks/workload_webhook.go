@@ -19,8 +19,10 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"slices"
 	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
@@ -32,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/constants"
 	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -46,10 +49,15 @@ import (
 // priorityBoostAnnotationPath is the field path for the priority-boost annotation, used in validation errors.
 var priorityBoostAnnotationPath = field.NewPath("metadata", "annotations").Key(controllerconstants.PriorityBoostAnnotationKey)
 
-type WorkloadWebhook struct{}
+// evictionHooksTimeoutAnnotationPath is the field path for the eviction-hooks-timeout annotation, used in validation errors.
+var evictionHooksTimeoutAnnotationPath = field.NewPath("metadata", "annotations").Key(controllerconstants.EvictionHooksTimeoutAnnotation)
 
-func setupWebhookForWorkload(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker) error {
-	wh := &WorkloadWebhook{}
+type WorkloadWebhook struct {
+	evictionHooksMaxTimeout time.Duration
+}
+
+func setupWebhookForWorkload(mgr ctrl.Manager, roleTracker *roletracker.RoleTracker, options Options) error {
+	wh := &WorkloadWebhook{evictionHooksMaxTimeout: options.EvictionHooksMaxTimeout}
 	return ctrl.NewWebhookManagedBy(mgr, &kueue.Workload{}).
 		WithDefaulter(wh).
 		WithValidator(wh).
@@ -84,14 +92,34 @@ var _ admission.Validator[*kueue.Workload] = &WorkloadWebhook{}
 func (w *WorkloadWebhook) ValidateCreate(ctx context.Context, wl *kueue.Workload) (admission.Warnings, error) {
 	log := ctrl.LoggerFrom(ctx).WithName("workload-webhook")
 	log.V(5).Info("Validating create")
-	return warningsForWorkload(wl), ValidateWorkload(wl, nil).ToAggregate()
+	allErrs := ValidateWorkload(wl, nil)
+	allErrs = append(allErrs, w.validateEvictionHooksTimeout(wl)...)
+	return warningsForWorkload(wl), allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
 func (w *WorkloadWebhook) ValidateUpdate(ctx context.Context, oldWL, newWL *kueue.Workload) (admission.Warnings, error) {
 	log := ctrl.LoggerFrom(ctx).WithName("workload-webhook")
 	log.V(5).Info("Validating update")
-	return warningsForWorkload(newWL), ValidateWorkloadUpdate(newWL, oldWL).ToAggregate()
+	allErrs := ValidateWorkloadUpdate(newWL, oldWL)
+	allErrs = append(allErrs, w.validateEvictionHooksTimeout(newWL)...)
+	return warningsForWorkload(newWL), allErrs.ToAggregate()
+}
+
+// validateEvictionHooksTimeout validates that the eviction-hooks-timeout
+// annotation doesn't exceed the maximum timeout of the eviction hooks.
+func (w *WorkloadWebhook) validateEvictionHooksTimeout(wl *kueue.Workload) field.ErrorList {
+	if !features.Enabled(features.WorkloadEvictionHooks) {
+		return nil
+	}
+	value, ok := wl.Annotations[controllerconstants.EvictionHooksTimeoutAnnotation]
+	if !ok {
+		return nil
+	}
+	if timeout, err := time.ParseDuration(value); err == nil && timeout > w.evictionHooksMaxTimeout {
+		return field.ErrorList{field.Invalid(evictionHooksTimeoutAnnotationPath, value, fmt.Sprintf("must not exceed %s", w.evictionHooksMaxTimeout))}
+	}
+	return nil
 }
 
 // slated to become a hard validation error in a future release (see https://github.com/kubernetes-sigs/kueue/pull/13061#issuecomment-4979676077 for more context).
@@ -150,6 +178,14 @@ func ValidateWorkload(obj, oldObj *kueue.Workload) field.ErrorList {
 		allErrs = append(allErrs, webhook.ValidateAdmissionGatedByAnnotationOnCreate(obj)...)
 	}
 
+	if features.Enabled(features.WorkloadEvictionHooks) {
+		if value, ok := obj.Annotations[controllerconstants.EvictionHooksTimeoutAnnotation]; ok {
+			if timeout, err := time.ParseDuration(value); err != nil || timeout <= 0 {
+				allErrs = append(allErrs, field.Invalid(evictionHooksTimeoutAnnotationPath, value, "must be a positive duration"))
+			}
+		}
+	}
+
 	// KEP-7990: when priority-boost annotation is set, it must be a valid signed integer; invalid values cause rejection.
 	// Missing key is valid (treated as 0). If the key is present, the value must not be empty; use "0" explicitly.
 	if features.Enabled(features.PriorityBoost) {
@@ -179,6 +215,13 @@ func validatePodSet(ps *kueue.PodSet, path *field.Path) field.ErrorList {
 	for ci := range ps.Template.Spec.Containers {
 		allErrs = append(allErrs, validateContainer(&ps.Template.Spec.Containers[ci], cPath.Index(ci))...)
 	}
+	if features.Enabled(features.NodeShareRequests) {
+		if value, ok := ps.Template.Annotations[constants.NodeShareAnnotation]; ok {
+			if _, err := workload.ParseNodeShare(value); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("template", "metadata", "annotations").Key(constants.NodeShareAnnotation), value, err.Error()))
+			}
+		}
+	}
 
 	return allErrs
 }
@@ -200,6 +243,26 @@ func validateAdmissionChecks(obj *kueue.Workload, basePath *field.Path) field.Er
 		// no need to check the number of podSetUpdates,
 		// because it cannot exceed the one of the podSets without having an unknown podSet name
 		allErrs = append(allErrs, validatePodSetUpdates(&obj.Status.AdmissionChecks[i], obj, basePath.Index(i).Child("podSetUpdates"))...)
+		allErrs = append(allErrs, validateArtifacts(obj.Status.AdmissionChecks[i].Artifacts, basePath.Index(i).Child("artifacts"))...)
+	}
+	return allErrs
+}
+
+func validateArtifacts(artifacts []kueue.AdmissionCheckArtifact, basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i := range artifacts {
+		urlPath := basePath.Index(i).Child("url")
+		u, err := url.Parse(artifacts[i].URL)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(urlPath, artifacts[i].URL, err.Error()))
+			continue
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			allErrs = append(allErrs, field.NotSupported(urlPath.Child("scheme"), u.Scheme, []string{"http", "https"}))
+		}
+		if u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(urlPath, artifacts[i].URL, "must have a host"))
+		}
 	}
 	return allErrs
 }
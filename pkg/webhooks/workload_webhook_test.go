@@ -107,6 +107,28 @@ func TestValidateWorkload(t *testing.T) {
 				field.Invalid(firstPodSetSpecPath.Child("containers").Index(0).Child("resources", "requests").Key(string(corev1.ResourcePods)), nil, ""),
 			}.ToAggregate(),
 		},
+		"valid artifacts": {
+			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).AdmissionChecks(
+				kueue.AdmissionCheckState{Artifacts: []kueue.AdmissionCheckArtifact{
+					{Name: "cost-estimate", URL: "https://reports.example.com/cost?workload=wl"},
+					{Name: "scan", URL: "http://scanner.example.com:8080/results/1"},
+				}},
+			).Obj(),
+		},
+		"invalid artifacts": {
+			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).AdmissionChecks(
+				kueue.AdmissionCheckState{Artifacts: []kueue.AdmissionCheckArtifact{
+					{Name: "unsupported-scheme", URL: "ftp://reports.example.com/cost"},
+					{Name: "no-host", URL: "https:///cost"},
+					{Name: "malformed", URL: "https://reports.example.com/%zz"},
+				}},
+			).Obj(),
+			wantErr: field.ErrorList{
+				field.NotSupported(firstAdmissionChecksPath.Child("artifacts").Index(0).Child("url", "scheme"), nil, []string{}),
+				field.Invalid(firstAdmissionChecksPath.Child("artifacts").Index(1).Child("url"), nil, ""),
+				field.Invalid(firstAdmissionChecksPath.Child("artifacts").Index(2).Child("url"), nil, ""),
+			}.ToAggregate(),
+		},
 		"empty podSetUpdates": {
 			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).AdmissionChecks(kueue.AdmissionCheckState{}).Obj(),
 			wantErr:  nil,
@@ -290,6 +312,64 @@ func TestValidateWorkload(t *testing.T) {
 				Obj(),
 			wantErr: nil,
 		},
+		"valid eviction-hooks-timeout annotation": {
+			featureGates: map[featuregate.Feature]bool{features.WorkloadEvictionHooks: true},
+			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*utiltestingapi.MakePodSet("main", 1).Obj()).
+				Annotation(controllerconstants.EvictionHooksAnnotation, "example.com/checkpoint").
+				Annotation(controllerconstants.EvictionHooksTimeoutAnnotation, "5m").
+				Obj(),
+			wantErr: nil,
+		},
+		"invalid eviction-hooks-timeout annotation": {
+			featureGates: map[featuregate.Feature]bool{features.WorkloadEvictionHooks: true},
+			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*utiltestingapi.MakePodSet("main", 1).Obj()).
+				Annotation(controllerconstants.EvictionHooksTimeoutAnnotation, "-5m").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(evictionHooksTimeoutAnnotationPath, "-5m", ""),
+			}.ToAggregate(),
+		},
+		"eviction-hooks-timeout annotation exceeding the maximum timeout": {
+			featureGates: map[featuregate.Feature]bool{features.WorkloadEvictionHooks: true},
+			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*utiltestingapi.MakePodSet("main", 1).Obj()).
+				Annotation(controllerconstants.EvictionHooksTimeoutAnnotation, "2h").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(evictionHooksTimeoutAnnotationPath, "2h", ""),
+			}.ToAggregate(),
+		},
+		"valid node-share annotation": {
+			featureGates: map[featuregate.Feature]bool{features.NodeShareRequests: true},
+			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*utiltestingapi.MakePodSet("main", 1).
+					Annotations(map[string]string{constants.NodeShareAnnotation: "100%"}).
+					Obj()).
+				Obj(),
+			wantErr: nil,
+		},
+		"invalid node-share annotation": {
+			featureGates: map[featuregate.Feature]bool{features.NodeShareRequests: true},
+			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*utiltestingapi.MakePodSet("main", 1).
+					Annotations(map[string]string{constants.NodeShareAnnotation: "150%"}).
+					Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(podSetsPath.Index(0).Child("template", "metadata", "annotations").Key(constants.NodeShareAnnotation), "150%", ""),
+			}.ToAggregate(),
+		},
+		"invalid node-share annotation when feature off": {
+			featureGates: map[featuregate.Feature]bool{features.NodeShareRequests: false},
+			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*utiltestingapi.MakePodSet("main", 1).
+					Annotations(map[string]string{constants.NodeShareAnnotation: "one node"}).
+					Obj()).
+				Obj(),
+			wantErr: nil,
+		},
 		"valid AdmissionGatedBy annotation with single gate": {
 			featureGates: map[featuregate.Feature]bool{features.AdmissionGatedBy: true},
 			workload: utiltestingapi.MakeWorkload(testWorkloadName, testWorkloadNamespace).
@@ -437,7 +517,7 @@ func TestValidateWorkload(t *testing.T) {
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			features.SetFeatureGatesDuringTest(t, tc.featureGates)
-			gotWarnings, gotErr := (&WorkloadWebhook{}).ValidateCreate(t.Context(), tc.workload)
+			gotWarnings, gotErr := (&WorkloadWebhook{evictionHooksMaxTimeout: workload.DefaultEvictionHooksMaxTimeout}).ValidateCreate(t.Context(), tc.workload)
 			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
 				t.Errorf("ValidateCreate() error mismatch (-want +got):\n%s", diff)
 			}
@@ -1102,7 +1182,7 @@ func TestValidateWorkloadUpdate(t *testing.T) {
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			features.SetFeatureGatesDuringTest(t, tc.featureGates)
-			gotWarnings, gotErr := (&WorkloadWebhook{}).ValidateUpdate(t.Context(), tc.before, tc.after)
+			gotWarnings, gotErr := (&WorkloadWebhook{evictionHooksMaxTimeout: workload.DefaultEvictionHooksMaxTimeout}).ValidateUpdate(t.Context(), tc.before, tc.after)
 			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
 				t.Errorf("ValidateUpdate() error mismatch (-want +got):\n%s", diff)
 			}
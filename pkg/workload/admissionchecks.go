@@ -27,7 +27,10 @@ import (
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
+	"sigs.k8s.io/kueue/pkg/util/csv"
 	"sigs.k8s.io/kueue/pkg/util/wait"
 )
 
@@ -104,12 +107,18 @@ func SyncAdmittedCondition(w *kueue.Workload, now time.Time) bool {
 	return apimeta.SetStatusCondition(&w.Status.Conditions, newCondition)
 }
 
-// matchingChecks returns the list of admission checks in the given state.
+// IsAdvisoryCheck returns true if the admission check state belongs to an
+// advisory check, which doesn't block the admission of the workload.
+func IsAdvisoryCheck(acs *kueue.AdmissionCheckState) bool {
+	return features.Enabled(features.AdvisoryAdmissionChecks) && ptr.Deref(acs.Advisory, false)
+}
+
+// matchingChecks returns the list of blocking admission checks in the given state.
 func matchingChecks(wl *kueue.Workload, s kueue.CheckState) []kueue.AdmissionCheckState {
 	matching := make([]kueue.AdmissionCheckState, 0, len(wl.Status.AdmissionChecks))
 	for i := range wl.Status.AdmissionChecks {
 		ac := wl.Status.AdmissionChecks[i]
-		if ac.State == s {
+		if ac.State == s && !IsAdvisoryCheck(&ac) {
 			matching = append(matching, ac)
 		}
 	}
@@ -126,10 +135,74 @@ func RetryChecks(wl *kueue.Workload) []kueue.AdmissionCheckState {
 	return matchingChecks(wl, kueue.CheckStateRetry)
 }
 
-// HasAllChecksReady returns true if all the checks of the workload are ready.
+// FailedFlavors returns the flavors recorded in the AdmissionCheckFailedFlavorsAnnotation
+// of the workload.
+func FailedFlavors(wl *kueue.Workload) sets.Set[kueue.ResourceFlavorReference] {
+	val, ok := wl.GetAnnotations()[controllerconstants.AdmissionCheckFailedFlavorsAnnotation]
+	if !ok {
+		return nil
+	}
+	flavors := sets.New[kueue.ResourceFlavorReference]()
+	for _, f := range csv.Parse(val) {
+		flavors.Insert(kueue.ResourceFlavorReference(f))
+	}
+	return flavors
+}
+
+// RecordFailedFlavors adds the assigned flavors of the workload, for which its
+// blocking Retry checks are configured in the ClusterQueue, to the
+// AdmissionCheckFailedFlavorsAnnotation. Returns true if the annotation was updated.
+func RecordFailedFlavors(wl *kueue.Workload, cq *kueue.ClusterQueue) bool {
+	if wl.Status.Admission == nil || cq == nil {
+		return false
+	}
+	checkFlavors := admissioncheck.NewAdmissionChecks(cq)
+	assignedFlavors := findAdmissionFlavors(*wl.Status.Admission)
+	failed := FailedFlavors(wl)
+	if failed == nil {
+		failed = sets.New[kueue.ResourceFlavorReference]()
+	}
+	updated := false
+	for i := range wl.Status.AdmissionChecks {
+		check := &wl.Status.AdmissionChecks[i]
+		if check.State != kueue.CheckStateRetry || IsAdvisoryCheck(check) {
+			continue
+		}
+		for f := range assignedFlavors.Intersection(checkFlavors[check.Name]) {
+			if !failed.Has(f) {
+				failed.Insert(f)
+				updated = true
+			}
+		}
+	}
+	if !updated {
+		return false
+	}
+	if wl.Annotations == nil {
+		wl.Annotations = make(map[string]string, 1)
+	}
+	names := make([]string, 0, failed.Len())
+	for _, f := range sets.List(failed) {
+		names = append(names, string(f))
+	}
+	wl.Annotations[controllerconstants.AdmissionCheckFailedFlavorsAnnotation] = csv.Serialize(names)
+	return true
+}
+
+// ClearFailedFlavors removes the AdmissionCheckFailedFlavorsAnnotation from the
+// workload. Returns true if the annotation was removed.
+func ClearFailedFlavors(wl *kueue.Workload) bool {
+	if _, ok := wl.Annotations[controllerconstants.AdmissionCheckFailedFlavorsAnnotation]; !ok {
+		return false
+	}
+	delete(wl.Annotations, controllerconstants.AdmissionCheckFailedFlavorsAnnotation)
+	return true
+}
+
+// HasAllChecksReady returns true if all the blocking checks of the workload are ready.
 func HasAllChecksReady(wl *kueue.Workload) bool {
 	for i := range wl.Status.AdmissionChecks {
-		if wl.Status.AdmissionChecks[i].State != kueue.CheckStateReady {
+		if wl.Status.AdmissionChecks[i].State != kueue.CheckStateReady && !IsAdvisoryCheck(&wl.Status.AdmissionChecks[i]) {
 			return false
 		}
 	}
@@ -156,22 +229,22 @@ func HasAllRequiredChecks(log logr.Logger, wl *kueue.Workload, allChecks Admissi
 	return mustHaveChecks.Len() == 0
 }
 
-// HasRetryChecks returns true if any of the workloads checks is Retry
+// HasRetryChecks returns true if any of the workloads blocking checks is Retry
 func HasRetryChecks(wl *kueue.Workload) bool {
 	for i := range wl.Status.AdmissionChecks {
 		state := wl.Status.AdmissionChecks[i].State
-		if state == kueue.CheckStateRetry {
+		if state == kueue.CheckStateRetry && !IsAdvisoryCheck(&wl.Status.AdmissionChecks[i]) {
 			return true
 		}
 	}
 	return false
 }
 
-// HasRejectedChecks returns true if any of the workloads checks is Rejected
+// HasRejectedChecks returns true if any of the workloads blocking checks is Rejected
 func HasRejectedChecks(wl *kueue.Workload) bool {
 	for i := range wl.Status.AdmissionChecks {
 		state := wl.Status.AdmissionChecks[i].State
-		if state == kueue.CheckStateRejected {
+		if state == kueue.CheckStateRejected && !IsAdvisoryCheck(&wl.Status.AdmissionChecks[i]) {
 			return true
 		}
 	}
@@ -190,7 +263,7 @@ func GetMaxRetryTime(wl *kueue.Workload) metav1.Time {
 		if wl.Status.AdmissionChecks[i].LastTransitionTime.IsZero() {
 			continue
 		}
-		if wl.Status.AdmissionChecks[i].State != kueue.CheckStateRetry {
+		if wl.Status.AdmissionChecks[i].State != kueue.CheckStateRetry || IsAdvisoryCheck(&wl.Status.AdmissionChecks[i]) {
 			continue
 		}
 
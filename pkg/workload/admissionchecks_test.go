@@ -122,6 +122,90 @@ func TestSyncAdmittedCondition(t *testing.T) {
 			},
 			wantChange: true,
 		},
+		"reservation, advisory check pending": {
+			featureGates: map[featuregate.Feature]bool{features.AdvisoryAdmissionChecks: true},
+			checkStates: []kueue.AdmissionCheckState{
+				{
+					Name:     "check1",
+					State:    kueue.CheckStatePending,
+					Advisory: new(true),
+				},
+				{
+					Name:  "check2",
+					State: kueue.CheckStateReady,
+				},
+			},
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:               kueue.WorkloadAdmitted,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Admitted",
+					ObservedGeneration: 1,
+				},
+			},
+			wantChange: true,
+		},
+		"reservation, advisory check rejected": {
+			featureGates: map[featuregate.Feature]bool{features.AdvisoryAdmissionChecks: true},
+			checkStates: []kueue.AdmissionCheckState{
+				{
+					Name:     "check1",
+					State:    kueue.CheckStateRejected,
+					Advisory: new(true),
+				},
+			},
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:               kueue.WorkloadAdmitted,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Admitted",
+					ObservedGeneration: 1,
+				},
+			},
+			wantChange: true,
+		},
+		"reservation, advisory check pending; AdvisoryAdmissionChecks disabled": {
+			featureGates: map[featuregate.Feature]bool{features.AdvisoryAdmissionChecks: false},
+			checkStates: []kueue.AdmissionCheckState{
+				{
+					Name:     "check1",
+					State:    kueue.CheckStatePending,
+					Advisory: new(true),
+				},
+			},
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
 		"reservation lost": {
 			checkStates: []kueue.AdmissionCheckState{
 				{
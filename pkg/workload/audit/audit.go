@@ -0,0 +1,109 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit writes machine-parseable records of the admission, preemption
+// and eviction decisions taken for workloads to a dedicated "audit" logger.
+package audit
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/roletracker"
+)
+
+// LoggerName is the name of the logger the audit records are written to.
+const LoggerName = "audit"
+
+// Decision is the type of decision an audit record is written for.
+type Decision string
+
+const (
+	// DecisionAdmission is recorded when the scheduler reserves quota for a workload.
+	DecisionAdmission Decision = "Admission"
+	// DecisionPreemption is recorded when the scheduler preempts a workload to
+	// make room for another workload.
+	DecisionPreemption Decision = "Preemption"
+	// DecisionEviction is recorded when a workload is evicted, releasing its
+	// quota, for any other reason than a preemption.
+	DecisionEviction Decision = "Eviction"
+)
+
+// Record describes a single decision taken for a workload.
+type Record struct {
+	Decision Decision
+	// Workload is the workload the decision was taken for. Its admission
+	// determines the ClusterQueue and the quota impact of the record.
+	Workload *kueue.Workload
+	Reason   string
+	Message  string
+	// Actor is the workload which caused the decision, such as the preemptor.
+	Actor *kueue.Workload
+}
+
+// Emit writes the record to the audit logger, when the AuditLog feature
+// gate is enabled.
+func Emit(r Record, now time.Time, tracker *roletracker.RoleTracker) {
+	if !features.Enabled(features.AuditLog) {
+		return
+	}
+	emit(roletracker.WithReplicaRole(ctrl.Log, tracker), r, now)
+}
+
+func emit(log logr.Logger, r Record, now time.Time) {
+	log.WithName(LoggerName).Info("Workload decision", keysAndValues(r, now)...)
+}
+
+func keysAndValues(r Record, now time.Time) []any {
+	kv := []any{
+		"decision", r.Decision,
+		"timestamp", now.UTC().Format(time.RFC3339),
+		"workload", klog.KObj(r.Workload),
+		"workloadUID", r.Workload.UID,
+		"reason", r.Reason,
+		"message", r.Message,
+	}
+	if admission := r.Workload.Status.Admission; admission != nil {
+		kv = append(kv, "clusterQueue", admission.ClusterQueue, "quota", quotaImpact(admission))
+	}
+	if r.Actor != nil {
+		kv = append(kv, "actor", klog.KObj(r.Actor), "actorUID", r.Actor.UID)
+	}
+	return kv
+}
+
+// quotaImpact returns the quota reserved by the admission, keyed by
+// "<flavor>/<resource>".
+func quotaImpact(admission *kueue.Admission) map[string]string {
+	usage := make(resources.FlavorResourceQuantities)
+	for _, psa := range admission.PodSetAssignments {
+		for resourceName, quantity := range psa.ResourceUsage {
+			fr := resources.FlavorResource{Flavor: psa.Flavors[resourceName], Resource: resourceName}
+			usage[fr] = usage[fr].Add(resources.AmountFromQuantity(resourceName, quantity))
+		}
+	}
+	impact := make(map[string]string, len(usage))
+	for fr, amount := range usage {
+		impact[string(fr.Flavor)+"/"+string(fr.Resource)] = resources.AmountQuantityString(fr.Resource, amount)
+	}
+	return impact
+}
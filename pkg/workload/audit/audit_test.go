@@ -0,0 +1,148 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func TestEmit(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+	admission := utiltestingapi.MakeAdmission("cq").
+		PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+			Assignment(corev1.ResourceCPU, "default", "2").
+			Assignment(corev1.ResourceMemory, "default", "1Gi").
+			Obj()).
+		Obj()
+	admitted := utiltestingapi.MakeWorkload("wl", "ns").UID("wl-uid").ReserveQuotaAt(admission, now).Obj()
+	preemptor := utiltestingapi.MakeWorkload("preemptor", "ns").UID("preemptor-uid").Obj()
+
+	testCases := map[string]struct {
+		record Record
+		want   map[string]any
+	}{
+		"admission": {
+			record: Record{
+				Decision: DecisionAdmission,
+				Workload: admitted,
+				Reason:   kueue.WorkloadQuotaReserved,
+				Message:  "Quota reserved in ClusterQueue cq",
+			},
+			want: map[string]any{
+				"logger":       "audit",
+				"msg":          "Workload decision",
+				"decision":     "Admission",
+				"timestamp":    "2026-01-02T03:04:05Z",
+				"workload":     map[string]any{"name": "wl", "namespace": "ns"},
+				"workloadUID":  "wl-uid",
+				"reason":       "QuotaReserved",
+				"message":      "Quota reserved in ClusterQueue cq",
+				"clusterQueue": "cq",
+				"quota":        map[string]any{"default/cpu": "2", "default/memory": "1Gi"},
+			},
+		},
+		"preemption": {
+			record: Record{
+				Decision: DecisionPreemption,
+				Workload: admitted,
+				Reason:   kueue.InClusterQueueReason,
+				Message:  "Preempted to accommodate a workload",
+				Actor:    preemptor,
+			},
+			want: map[string]any{
+				"logger":       "audit",
+				"msg":          "Workload decision",
+				"decision":     "Preemption",
+				"timestamp":    "2026-01-02T03:04:05Z",
+				"workload":     map[string]any{"name": "wl", "namespace": "ns"},
+				"workloadUID":  "wl-uid",
+				"reason":       "InClusterQueue",
+				"message":      "Preempted to accommodate a workload",
+				"clusterQueue": "cq",
+				"quota":        map[string]any{"default/cpu": "2", "default/memory": "1Gi"},
+				"actor":        map[string]any{"name": "preemptor", "namespace": "ns"},
+				"actorUID":     "preemptor-uid",
+			},
+		},
+		"eviction": {
+			record: Record{
+				Decision: DecisionEviction,
+				Workload: admitted,
+				Reason:   kueue.WorkloadEvictedByPodsReadyTimeout,
+				Message:  "Exceeded the PodsReady timeout",
+			},
+			want: map[string]any{
+				"logger":       "audit",
+				"msg":          "Workload decision",
+				"decision":     "Eviction",
+				"timestamp":    "2026-01-02T03:04:05Z",
+				"workload":     map[string]any{"name": "wl", "namespace": "ns"},
+				"workloadUID":  "wl-uid",
+				"reason":       "PodsReadyTimeout",
+				"message":      "Exceeded the PodsReady timeout",
+				"clusterQueue": "cq",
+				"quota":        map[string]any{"default/cpu": "2", "default/memory": "1Gi"},
+			},
+		},
+		"eviction of a workload without admission": {
+			record: Record{
+				Decision: DecisionEviction,
+				Workload: preemptor,
+				Reason:   kueue.WorkloadDeactivated,
+			},
+			want: map[string]any{
+				"logger":      "audit",
+				"msg":         "Workload decision",
+				"decision":    "Eviction",
+				"timestamp":   "2026-01-02T03:04:05Z",
+				"workload":    map[string]any{"name": "preemptor", "namespace": "ns"},
+				"workloadUID": "preemptor-uid",
+				"reason":      "Deactivated",
+				"message":     "",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var lines []string
+			log := funcr.NewJSON(func(obj string) { lines = append(lines, obj) }, funcr.Options{})
+
+			emit(log, tc.record, now)
+
+			if len(lines) != 1 {
+				t.Fatalf("Expected exactly one audit record, got %d", len(lines))
+			}
+			var got map[string]any
+			if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+				t.Fatalf("Audit record is not valid JSON: %v", err)
+			}
+			delete(got, "level")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected audit record (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package evict
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -32,10 +33,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	utilqueue "sigs.k8s.io/kueue/pkg/util/queue"
 	"sigs.k8s.io/kueue/pkg/util/roletracker"
+	"sigs.k8s.io/kueue/pkg/workload/audit"
 	"sigs.k8s.io/kueue/pkg/workload/patching"
 )
 
@@ -196,6 +199,15 @@ func Evict(
 		return nil
 	}
 	reportEvictedWorkload(recorder, wl, wl.Status.Admission.ClusterQueue, reason, msg, underlyingCause, exposeLqMetrics, tracker, cl)
+	// The preemptions are recorded by the preemptor, together with the actor.
+	if reason != kueue.WorkloadEvictedByPreemption {
+		audit.Emit(audit.Record{
+			Decision: audit.DecisionEviction,
+			Workload: wl,
+			Reason:   reason,
+			Message:  msg,
+		}, clock.Now(), tracker)
+	}
 	if reportWorkloadEvictedOnce {
 		metrics.ReportEvictedWorkloadsOnce(wl.Status.Admission.ClusterQueue, reason, string(underlyingCause), patching.PriorityClassName(wl), cl.CQGet(wl.Status.Admission.ClusterQueue), tracker)
 	}
@@ -224,17 +236,22 @@ func resetChecksOnEviction(w *kueue.Workload, now time.Time) {
 			continue
 		}
 		var retryCount *int32
+		message := "Reset to Pending after eviction. Previously: " + string(checks[i].State)
 		if checks[i].State == kueue.CheckStateRetry {
 			tmpRetryCount := ptr.Deref(checks[i].RetryCount, 0) + 1
 			retryCount = new(tmpRetryCount)
+			if features.Enabled(features.AdmissionCheckRetryStatus) && checks[i].Message != "" {
+				message += fmt.Sprintf(" (%s)", checks[i].Message)
+			}
 		}
 		checks[i] = kueue.AdmissionCheckState{
 			Name:                checks[i].Name,
 			State:               kueue.CheckStatePending,
-			Message:             "Reset to Pending after eviction. Previously: " + string(checks[i].State),
+			Message:             api.TruncateConditionMessage(message),
 			LastTransitionTime:  metav1.NewTime(now),
 			RequeueAfterSeconds: checks[i].RequeueAfterSeconds,
 			RetryCount:          retryCount,
+			Advisory:            checks[i].Advisory,
 		}
 	}
 }
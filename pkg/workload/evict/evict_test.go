@@ -18,13 +18,22 @@ package evict
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/util/roletracker"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+	"sigs.k8s.io/kueue/pkg/workload/patching"
 )
 
 func TestIsEvictedByDeactivation(t *testing.T) {
@@ -131,3 +140,69 @@ func TestIsEvictedByPodsReadyTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestEvictReportsReason(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		reason          string
+		underlyingCause kueue.EvictionUnderlyingCause
+	}{
+		"preempted": {
+			reason: kueue.WorkloadEvictedByPreemption,
+		},
+		"pods ready timeout": {
+			reason: kueue.WorkloadEvictedByPodsReadyTimeout,
+		},
+		"admission check": {
+			reason: kueue.WorkloadEvictedByAdmissionCheck,
+		},
+		"cluster queue stopped": {
+			reason: kueue.WorkloadEvictedByClusterQueueStopped,
+		},
+		"deactivated due to the maximum execution time": {
+			reason:          kueue.WorkloadDeactivated,
+			underlyingCause: kueue.WorkloadMaximumExecutionTimeExceeded,
+		},
+		"flavor migration": {
+			reason: kueue.WorkloadEvictedByFlavorMigration,
+		},
+		"evicted on the manager cluster": {
+			reason: kueue.WorkloadEvictedOnManagerCluster,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cqName := kueue.ClusterQueueReference("cq-" + tc.reason)
+			wl := utiltestingapi.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltestingapi.MakeAdmission(cqName).Obj(), now).
+				Obj()
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(wl).
+				WithStatusSubresource(wl).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				Build()
+			t.Cleanup(func() { metrics.ClearClusterQueueMetrics(cqName) })
+
+			if err := Evict(ctx, cl, &utiltesting.EventRecorder{}, wl, tc.reason, "evicted", tc.underlyingCause, clocktesting.NewFakeClock(now), false, nil, nil); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted)
+			if cond == nil || cond.Status != metav1.ConditionTrue {
+				t.Fatalf("Expected the workload to have the %s condition, got %v", kueue.WorkloadEvicted, cond)
+			}
+			wantConditionReason := tc.reason
+			if tc.underlyingCause != "" {
+				wantConditionReason = patching.ReasonWithCause(tc.reason, string(tc.underlyingCause))
+			}
+			if cond.Reason != wantConditionReason {
+				t.Errorf("Unexpected condition reason: got %q, want %q", cond.Reason, wantConditionReason)
+			}
+			got := testutil.ToFloat64(metrics.EvictedWorkloadsTotal.WithLabelValues(string(cqName), tc.reason, string(tc.underlyingCause), "", roletracker.RoleStandalone))
+			if got != 1 {
+				t.Errorf("Unexpected evicted_workloads_total: got %v, want 1", got)
+			}
+		})
+	}
+}
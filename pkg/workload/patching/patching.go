@@ -99,6 +99,7 @@ func SetAdmissionCheckState(checks *[]kueue.AdmissionCheckState, newCheck kueue.
 	existingCondition.Message = newCheck.Message
 	existingCondition.PodSetUpdates = newCheck.PodSetUpdates
 	existingCondition.RequeueAfterSeconds = newCheck.RequeueAfterSeconds
+	existingCondition.Artifacts = newCheck.Artifacts
 	return true
 }
 
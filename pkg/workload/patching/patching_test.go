@@ -146,6 +146,41 @@ func TestSetCheckState(t *testing.T) {
 				},
 			},
 		},
+		"update check with artifacts": {
+			origStates: []kueue.AdmissionCheckState{
+				{
+					Name:               "check1",
+					State:              kueue.CheckStatePending,
+					LastTransitionTime: *t0.DeepCopy(),
+					Message:            "msg1",
+					Artifacts: []kueue.AdmissionCheckArtifact{
+						{Name: "cost-estimate", URL: "https://reports.example.com/cost/1"},
+					},
+				},
+			},
+			state: kueue.AdmissionCheckState{
+				Name:               "check1",
+				State:              kueue.CheckStateRejected,
+				LastTransitionTime: *t1.DeepCopy(),
+				Message:            "msg2",
+				Artifacts: []kueue.AdmissionCheckArtifact{
+					{Name: "cost-estimate", URL: "https://reports.example.com/cost/2"},
+					{Name: "scan", URL: "https://reports.example.com/scan/2"},
+				},
+			},
+			wantStates: []kueue.AdmissionCheckState{
+				{
+					Name:               "check1",
+					State:              kueue.CheckStateRejected,
+					LastTransitionTime: *t1.DeepCopy(),
+					Message:            "msg2",
+					Artifacts: []kueue.AdmissionCheckArtifact{
+						{Name: "cost-estimate", URL: "https://reports.example.com/cost/2"},
+						{Name: "scan", URL: "https://reports.example.com/scan/2"},
+					},
+				},
+			},
+		},
 		"add new check, no transition time": {
 			origStates: []kueue.AdmissionCheckState{},
 			state: kueue.AdmissionCheckState{
@@ -32,6 +32,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	"sigs.k8s.io/kueue/pkg/util/resource"
@@ -137,12 +138,115 @@ func UseLimitsAsMissingRequestsInPod(pod *corev1.PodSpec) {
 	}
 }
 
+func handleClusterQueueDefaultRequests(defaults corev1.ResourceList, wl *kueue.Workload) {
+	if len(defaults) == 0 {
+		return
+	}
+	for pi := range wl.Spec.PodSets {
+		ApplyDefaultContainerRequests(&wl.Spec.PodSets[pi].Template.Spec, defaults)
+	}
+}
+
+// ApplyDefaultContainerRequests sets the default requests for the containers
+// of the pod which neither request nor limit the resources. The resources
+// requested at the pod level are left out.
+func ApplyDefaultContainerRequests(pod *corev1.PodSpec, defaults corev1.ResourceList) {
+	// Pod-level resources (KEP-2837) take precedence over the requests of
+	// the containers.
+	if pod.Resources != nil && len(pod.Resources.Requests) > 0 {
+		podDefaults := make(corev1.ResourceList, len(defaults))
+		for name, quantity := range defaults {
+			if _, found := pod.Resources.Requests[name]; !found {
+				podDefaults[name] = quantity
+			}
+		}
+		defaults = podDefaults
+	}
+	for ci := range pod.InitContainers {
+		res := &pod.InitContainers[ci].Resources
+		res.Requests = resource.MergeResourceListKeepFirst(res.Requests, defaults)
+	}
+	for ci := range pod.Containers {
+		res := &pod.Containers[ci].Resources
+		res.Requests = resource.MergeResourceListKeepFirst(res.Requests, defaults)
+	}
+}
+
+func handleClusterQueueLimitsAccounting(names []corev1.ResourceName, wl *kueue.Workload) {
+	if len(names) == 0 {
+		return
+	}
+	for pi := range wl.Spec.PodSets {
+		pod := &wl.Spec.PodSets[pi].Template.Spec
+		for ci := range pod.InitContainers {
+			useLimitsAsRequests(&pod.InitContainers[ci].Resources, names)
+		}
+		for ci := range pod.Containers {
+			useLimitsAsRequests(&pod.Containers[ci].Resources, names)
+		}
+		// Pod-level resources (KEP-2837) are an optional pointer, only set when the
+		// PodLevelResources feature is enabled and used.
+		if pod.Resources != nil {
+			useLimitsAsRequests(pod.Resources, names)
+		}
+	}
+}
+
+// useLimitsAsRequests raises the requests of the given resources to their
+// limits, when the limits are higher.
+func useLimitsAsRequests(res *corev1.ResourceRequirements, names []corev1.ResourceName) {
+	for _, name := range names {
+		limit, found := res.Limits[name]
+		if !found {
+			continue
+		}
+		if request, found := res.Requests[name]; found && request.Cmp(limit) >= 0 {
+			continue
+		}
+		if res.Requests == nil {
+			res.Requests = make(corev1.ResourceList, len(names))
+		}
+		res.Requests[name] = limit.DeepCopy()
+	}
+}
+
+// AdjustResourcesOption configures the adjustment of the resource requests
+// of a workload.
+type AdjustResourcesOption func(*adjustResourcesOptions)
+
+type adjustResourcesOptions struct {
+	defaultContainerRequests corev1.ResourceList
+	limitsAccountedResources []corev1.ResourceName
+}
+
+// WithDefaultContainerRequests sets the default container requests of the
+// ClusterQueue of the workload.
+func WithDefaultContainerRequests(requests corev1.ResourceList) AdjustResourcesOption {
+	return func(o *adjustResourcesOptions) {
+		o.defaultContainerRequests = requests
+	}
+}
+
+// WithLimitsAccountedResources sets the resources whose usage is accounted
+// from the limits in the ClusterQueue of the workload.
+func WithLimitsAccountedResources(names []corev1.ResourceName) AdjustResourcesOption {
+	return func(o *adjustResourcesOptions) {
+		o.limitsAccountedResources = names
+	}
+}
+
 // AdjustResources adjusts the resource requests of a workload based on:
 // - PodOverhead
 // - LimitRanges
 // - Limits
-func AdjustResources(ctx context.Context, cl client.Client, wl *kueue.Workload) {
+// - ClusterQueue default container requests
+// - ClusterQueue limits accounted resources
+func AdjustResources(ctx context.Context, cl client.Client, wl *kueue.Workload, opts ...AdjustResourcesOption) {
 	log := ctrl.LoggerFrom(ctx)
+	options := adjustResourcesOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
 	for _, err := range handlePodOverhead(ctx, cl, wl) {
 		log.Error(err, "Failures adjusting requests for pod overhead")
 	}
@@ -150,6 +254,12 @@ func AdjustResources(ctx context.Context, cl client.Client, wl *kueue.Workload)
 		log.Error(err, "Failed adjusting requests for LimitRanges")
 	}
 	handleLimitsToRequests(wl)
+	if features.Enabled(features.ClusterQueueDefaultRequests) {
+		handleClusterQueueDefaultRequests(options.defaultContainerRequests, wl)
+	}
+	if features.Enabled(features.ClusterQueueLimitsAccounting) {
+		handleClusterQueueLimitsAccounting(options.limitsAccountedResources, wl)
+	}
 }
 
 // ValidateResources validates that requested resources are less or equal
@@ -227,34 +337,34 @@ func hasInternalError(errs field.ErrorList) bool {
 
 // ValidateAdmissibility checks if the workload's namespace matches the ClusterQueue's
 // namespace selector, and if its resource requests are valid and satisfy LimitRanges.
-// Returns the admissibility error if any.
+// Returns the labels of the workload's namespace, and the admissibility error if any.
 func ValidateAdmissibility(
 	ctx context.Context,
 	c client.Client,
 	wi *Info,
 	cqNamespaceSelector labels.Selector,
-) error {
+) (labels.Set, error) {
 	var ns corev1.Namespace
 	if err := c.Get(ctx, types.NamespacedName{Name: wi.Obj.Namespace}, &ns); err != nil {
 		if apierrors.IsNotFound(err) {
-			return fmt.Errorf("workload namespace %q does not exist: %w", wi.Obj.Namespace, err)
+			return nil, fmt.Errorf("workload namespace %q does not exist: %w", wi.Obj.Namespace, err)
 		}
-		return fmt.Errorf("%w: %w", ErrInternal, err)
+		return nil, fmt.Errorf("%w: %w", ErrInternal, err)
 	}
 	if cqNamespaceSelector != nil && !cqNamespaceSelector.Matches(labels.Set(ns.Labels)) {
-		return ErrNamespaceMismatch
+		return nil, ErrNamespaceMismatch
 	}
 
 	if errs := ValidateResources(wi); len(errs) > 0 {
-		return fmt.Errorf("%s: %w", ErrInvalidWLResources, errs.ToAggregate())
+		return nil, fmt.Errorf("%s: %w", ErrInvalidWLResources, errs.ToAggregate())
 	}
 
 	if errs := ValidateLimitRange(ctx, c, wi); len(errs) > 0 {
 		if hasInternalError(errs) {
-			return fmt.Errorf("%w: %w", ErrInternal, errs.ToAggregate())
+			return nil, fmt.Errorf("%w: %w", ErrInternal, errs.ToAggregate())
 		}
-		return fmt.Errorf("%s: %w", ErrLimitRangeConstraintsUnsatisfiedResources, errs.ToAggregate())
+		return nil, fmt.Errorf("%s: %w", ErrLimitRangeConstraintsUnsatisfiedResources, errs.ToAggregate())
 	}
 
-	return nil
+	return ns.Labels, nil
 }
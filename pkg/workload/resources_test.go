@@ -25,9 +25,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/component-base/featuregate"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -35,9 +37,16 @@ import (
 )
 
 func TestAdjustResources(t *testing.T) {
+	defaultRequests := WithDefaultContainerRequests(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("500m"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	})
+	limitsAccounted := WithLimitsAccountedResources([]corev1.ResourceName{corev1.ResourceCPU})
 	cases := map[string]struct {
+		featureGates   map[featuregate.Feature]bool
 		runtimeClasses []nodev1.RuntimeClass
 		limitranges    []corev1.LimitRange
+		opts           []AdjustResourcesOption
 		wl             *kueue.Workload
 		wantWl         *kueue.Workload
 	}{
@@ -549,16 +558,143 @@ func TestAdjustResources(t *testing.T) {
 				).
 				Obj(),
 		},
+		"ClusterQueue default container requests": {
+			featureGates: map[featuregate.Feature]bool{features.ClusterQueueDefaultRequests: true},
+			opts:         []AdjustResourcesOption{defaultRequests},
+			wl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(
+					*utiltestingapi.MakePodSet("a", 1).
+						Obj(),
+					*utiltestingapi.MakePodSet("b", 1).
+						Request(corev1.ResourceMemory, "2Gi").
+						Limit(corev1.ResourceCPU, "2").
+						Obj(),
+				).
+				Obj(),
+			wantWl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(
+					*utiltestingapi.MakePodSet("a", 1).
+						Request(corev1.ResourceCPU, "500m").
+						Request(corev1.ResourceMemory, "1Gi").
+						Obj(),
+					*utiltestingapi.MakePodSet("b", 1).
+						Request(corev1.ResourceMemory, "2Gi").
+						Limit(corev1.ResourceCPU, "2").
+						Request(corev1.ResourceCPU, "2").
+						Obj(),
+				).
+				Obj(),
+		},
+		"ClusterQueue default container requests with pod-level requests": {
+			featureGates: map[featuregate.Feature]bool{features.ClusterQueueDefaultRequests: true},
+			opts:         []AdjustResourcesOption{defaultRequests},
+			wl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(
+					*utiltestingapi.MakePodSet("a", 1).
+						PodLevelRequest(corev1.ResourceMemory, "4Gi").
+						Obj(),
+				).
+				Obj(),
+			wantWl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(
+					*utiltestingapi.MakePodSet("a", 1).
+						PodLevelRequest(corev1.ResourceMemory, "4Gi").
+						Request(corev1.ResourceCPU, "500m").
+						Obj(),
+				).
+				Obj(),
+		},
+		"ClusterQueue default container requests; ClusterQueueDefaultRequests disabled": {
+			featureGates: map[featuregate.Feature]bool{features.ClusterQueueDefaultRequests: false},
+			opts:         []AdjustResourcesOption{defaultRequests},
+			wl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(*utiltestingapi.MakePodSet("a", 1).Obj()).
+				Obj(),
+			wantWl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(*utiltestingapi.MakePodSet("a", 1).Obj()).
+				Obj(),
+		},
+		"ClusterQueue limits accounted resources": {
+			featureGates: map[featuregate.Feature]bool{features.ClusterQueueLimitsAccounting: true},
+			opts:         []AdjustResourcesOption{limitsAccounted},
+			wl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(
+					*utiltestingapi.MakePodSet("a", 1).
+						Request(corev1.ResourceCPU, "1").
+						Limit(corev1.ResourceCPU, "3").
+						Request(corev1.ResourceMemory, "1Gi").
+						Limit(corev1.ResourceMemory, "2Gi").
+						Obj(),
+					*utiltestingapi.MakePodSet("b", 1).
+						Request(corev1.ResourceCPU, "2").
+						Limit(corev1.ResourceCPU, "2").
+						Obj(),
+					*utiltestingapi.MakePodSet("c", 1).
+						PodLevelRequest(corev1.ResourceCPU, "1").
+						PodLevelLimit(corev1.ResourceCPU, "4").
+						Obj(),
+				).
+				Obj(),
+			wantWl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(
+					*utiltestingapi.MakePodSet("a", 1).
+						Request(corev1.ResourceCPU, "3").
+						Limit(corev1.ResourceCPU, "3").
+						Request(corev1.ResourceMemory, "1Gi").
+						Limit(corev1.ResourceMemory, "2Gi").
+						Obj(),
+					*utiltestingapi.MakePodSet("b", 1).
+						Request(corev1.ResourceCPU, "2").
+						Limit(corev1.ResourceCPU, "2").
+						Obj(),
+					*utiltestingapi.MakePodSet("c", 1).
+						PodLevelRequest(corev1.ResourceCPU, "4").
+						PodLevelLimit(corev1.ResourceCPU, "4").
+						Obj(),
+				).
+				Obj(),
+		},
+		"ClusterQueue limits accounted resources; ClusterQueueLimitsAccounting disabled": {
+			featureGates: map[featuregate.Feature]bool{features.ClusterQueueLimitsAccounting: false},
+			opts:         []AdjustResourcesOption{limitsAccounted},
+			wl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(
+					*utiltestingapi.MakePodSet("a", 1).
+						Request(corev1.ResourceCPU, "1").
+						Limit(corev1.ResourceCPU, "3").
+						Obj(),
+				).
+				Obj(),
+			wantWl: utiltestingapi.MakeWorkload("foo", "ns").
+				Queue("lq").
+				PodSets(
+					*utiltestingapi.MakePodSet("a", 1).
+						Request(corev1.ResourceCPU, "1").
+						Limit(corev1.ResourceCPU, "3").
+						Obj(),
+				).
+				Obj(),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGatesDuringTest(t, tc.featureGates)
 			cl := utiltesting.NewClientBuilder().WithLists(
 				&nodev1.RuntimeClassList{Items: tc.runtimeClasses},
 				&corev1.LimitRangeList{Items: tc.limitranges},
 			).WithIndex(&corev1.LimitRange{}, indexer.LimitRangeHasContainerOrPodType, indexer.IndexLimitRangeHasContainerOrPodType).
 				Build()
 			ctx, _ := utiltesting.ContextWithLog(t)
-			AdjustResources(ctx, cl, tc.wl)
+			AdjustResources(ctx, cl, tc.wl, tc.opts...)
 			if diff := cmp.Diff(tc.wl, tc.wantWl); diff != "" {
 				t.Errorf("Unexpected resources after adjusting (-want,+got): %s", diff)
 			}
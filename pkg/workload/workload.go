@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -54,6 +55,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	afs "sigs.k8s.io/kueue/pkg/util/admissionfairsharing"
 	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/util/csv"
 	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 	"sigs.k8s.io/kueue/pkg/util/podset"
 	"sigs.k8s.io/kueue/pkg/util/priority"
@@ -485,6 +487,101 @@ func IsExplicitlyRequestingTAS(podSets ...kueue.PodSet) bool {
 		})
 }
 
+// UnconstrainedTopologyFallbackAfter returns how long the PodSet waits for its
+// topology request to be satisfied before it can be admitted without a
+// topology assignment, and whether such a fallback is configured for the PodSet.
+func UnconstrainedTopologyFallbackAfter(ps *kueue.PodSet) (time.Duration, bool) {
+	if !features.Enabled(features.TopologyAwareScheduling) || !features.Enabled(features.TASUnconstrainedTopologyFallback) {
+		return 0, false
+	}
+	if ps.TopologyRequest == nil || (ps.TopologyRequest.Required == nil && ps.TopologyRequest.Preferred == nil) {
+		return 0, false
+	}
+	val, found := ps.Template.Annotations[kueue.PodSetUnconstrainedTopologyFallbackAnnotation]
+	if !found {
+		return 0, false
+	}
+	fallbackAfter, err := time.ParseDuration(val)
+	if err != nil || fallbackAfter <= 0 {
+		return 0, false
+	}
+	return fallbackAfter, true
+}
+
+// UnconstrainedTopologyFallbackPodSets returns the PodSets of the workload which
+// have been waiting for their topology request to be satisfied for longer than
+// their fallback duration.
+func UnconstrainedTopologyFallbackPodSets(wl *kueue.Workload, clock clock.Clock) sets.Set[kueue.PodSetReference] {
+	podSets := sets.New[kueue.PodSetReference]()
+	waitTime := QueuedWaitTime(wl, clock)
+	for i := range wl.Spec.PodSets {
+		if fallbackAfter, found := UnconstrainedTopologyFallbackAfter(&wl.Spec.PodSets[i]); found && waitTime >= fallbackAfter {
+			podSets.Insert(wl.Spec.PodSets[i].Name)
+		}
+	}
+	return podSets
+}
+
+// NextUnconstrainedTopologyFallback returns the time left until the next
+// PodSet of the workload can fall back to being admitted without a topology
+// assignment, and false if no PodSet is waiting for the fallback.
+func NextUnconstrainedTopologyFallback(wl *kueue.Workload, clock clock.Clock) (time.Duration, bool) {
+	var next time.Duration
+	found := false
+	waitTime := QueuedWaitTime(wl, clock)
+	for i := range wl.Spec.PodSets {
+		fallbackAfter, ok := UnconstrainedTopologyFallbackAfter(&wl.Spec.PodSets[i])
+		if !ok || waitTime >= fallbackAfter {
+			continue
+		}
+		if remaining := fallbackAfter - waitTime; !found || remaining < next {
+			next = remaining
+			found = true
+		}
+	}
+	return next, found
+}
+
+// LastUnconstrainedTopologyFallback returns the latest time at which a PodSet
+// of the workload could fall back to being admitted without a topology
+// assignment, and false if no PodSet reached its fallback yet.
+func LastUnconstrainedTopologyFallback(wl *kueue.Workload, clock clock.Clock) (time.Time, bool) {
+	var last time.Time
+	found := false
+	queued := queuedTime(wl)
+	for i := range wl.Spec.PodSets {
+		fallbackAfter, ok := UnconstrainedTopologyFallbackAfter(&wl.Spec.PodSets[i])
+		if !ok {
+			continue
+		}
+		if fallbackAt := queued.Add(fallbackAfter); !fallbackAt.After(clock.Now()) && (!found || fallbackAt.After(last)) {
+			last = fallbackAt
+			found = true
+		}
+	}
+	return last, found
+}
+
+// podSetsAdmittedWithUnconstrainedTopologyFallback returns the names of the
+// PodSets which have the unconstrained topology fallback configured, but
+// received no topology assignment in the admission.
+func podSetsAdmittedWithUnconstrainedTopologyFallback(w *kueue.Workload, admission *kueue.Admission) []string {
+	var names []string
+	for _, psa := range admission.PodSetAssignments {
+		if psa.TopologyAssignment != nil || psa.DelayedTopologyRequest != nil || ptr.Deref(psa.Count, 1) == 0 {
+			continue
+		}
+		ps := podset.FindPodSetByName(w.Spec.PodSets, psa.Name)
+		if ps == nil {
+			continue
+		}
+		if _, found := UnconstrainedTopologyFallbackAfter(ps); found {
+			names = append(names, string(psa.Name))
+		}
+	}
+	return names
+}
+
 // TASUsage returns topology usage requested by the Workload
 func (i *Info) TASUsage() TASUsage {
 	if !features.Enabled(features.TopologyAwareScheduling) || !i.IsUsingTAS() {
@@ -669,6 +766,7 @@ func totalRequestsFromAdmission(wl *kueue.Workload) []PodSetResources {
 			singlePodRequests := setRes.SinglePodRequests()
 			if ps := podset.FindPodSetByName(wl.Spec.PodSets, psa.Name); ps != nil {
 				singlePodRequests = resources.NewRequestsFromPodSpec(&ps.Template.Spec)
+				ApplyNodeShareRequests(singlePodRequests, ps, psa.ResourceUsage, setRes.Count)
 			}
 			for req := range tas.InternalSeqFrom(psa.TopologyAssignment) {
 				setRes.TopologyRequest.DomainRequests = append(setRes.TopologyRequest.DomainRequests, TopologyDomainRequests{
@@ -817,11 +915,15 @@ func SetRequeuedCondition(wl *kueue.Workload, reason, message string, status boo
 }
 
 func QueuedWaitTime(wl *kueue.Workload, clock clock.Clock) time.Duration {
-	queuedTime := wl.CreationTimestamp.Time
+	return clock.Since(queuedTime(wl))
+}
+
+// queuedTime returns the time at which the workload was last queued.
+func queuedTime(wl *kueue.Workload) time.Time {
 	if c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadRequeued); c != nil {
-		queuedTime = c.LastTransitionTime.Time
+		return c.LastTransitionTime.Time
 	}
-	return clock.Since(queuedTime)
+	return wl.CreationTimestamp.Time
 }
 
 // SetQuotaReservation records that quota has been reserved for the given Workload
@@ -831,8 +933,9 @@ func QueuedWaitTime(wl *kueue.Workload, clock clock.Clock) time.Duration {
 //   - Sets w.Status.Admission to the provided admission.
 //   - Adds or updates a Condition of type kueue.WorkloadQuotaReserved with
 //     Status=True, Reason="QuotaReserved", Message="Quota reserved in ClusterQueue <name>",
-//     and ObservedGeneration set to w.Generation. The message is truncated via
-//     api.TruncateConditionMessage.
+//     and ObservedGeneration set to w.Generation. The message also names the
+//     PodSets admitted without a topology assignment due to the unconstrained
+//     topology fallback, and it is truncated via api.TruncateConditionMessage.
 //   - Resets any active "evicted" and "preempted" conditions by invoking
 //     resetActiveCondition for kueue.WorkloadEvicted and kueue.WorkloadPreempted.
 func SetQuotaReservation(w *kueue.Workload, admission *kueue.Admission, clock clock.Clock) bool {
@@ -844,7 +947,7 @@ func SetQuotaReservation(w *kueue.Workload, admission *kueue.Admission, clock cl
 		Type:               kueue.WorkloadQuotaReserved,
 		Status:             metav1.ConditionTrue,
 		Reason:             reason,
-		Message:            api.TruncateConditionMessage(fmt.Sprintf("Quota reserved in ClusterQueue %s", admission.ClusterQueue)),
+		Message:            api.TruncateConditionMessage(quotaReservedMessage(w, admission)),
 		ObservedGeneration: w.Generation,
 		LastTransitionTime: metav1.NewTime(clock.Now()),
 	})
@@ -864,6 +967,14 @@ func SetQuotaReservation(w *kueue.Workload, admission *kueue.Admission, clock cl
 	return changed
 }
 
+func quotaReservedMessage(w *kueue.Workload, admission *kueue.Admission) string {
+	msg := fmt.Sprintf("Quota reserved in ClusterQueue %s", admission.ClusterQueue)
+	if podSets := podSetsAdmittedWithUnconstrainedTopologyFallback(w, admission); len(podSets) > 0 {
+		msg += fmt.Sprintf(", without topology assignment for podSets %s as their topology request could not be satisfied", strings.Join(podSets, ", "))
+	}
+	return msg
+}
+
 func resetActiveCondition(conds *[]metav1.Condition, gen int64, condType, reason string, clock clock.Clock) bool {
 	prev := apimeta.FindStatusCondition(*conds, condType)
 	// Ignore not found or inactive condition.
@@ -1242,6 +1353,102 @@ func HasAdmissionGate(w *kueue.Workload) bool {
 	return false
 }
 
+// HeldMessage is the message of the QuotaReserved condition of held workloads.
+const HeldMessage = "The workload is held by the kueue.x-k8s.io/hold annotation"
+
+// IsHeld returns true if the workload has the hold annotation set to "true" and the WorkloadHold feature is on.
+func IsHeld(w *kueue.Workload) bool {
+	return features.Enabled(features.WorkloadHold) && w.Annotations[constants.HoldAnnotation] == "true"
+}
+
+// IsNonPreemptible returns true if the workload is annotated as non-preemptible
+// and the NonPreemptibleWorkloads feature is on.
+func IsNonPreemptible(w *kueue.Workload) bool {
+	if !features.Enabled(features.NonPreemptibleWorkloads) {
+		return false
+	}
+	return w.Annotations[controllerconstants.NonPreemptibleAnnotationKey] == "true"
+}
+
+// DefaultEvictionHooksTimeout is the maximum duration Kueue waits for the
+// eviction hooks of a workload, when it doesn't set a timeout.
+const DefaultEvictionHooksTimeout = 10 * time.Minute
+
+// DefaultEvictionHooksMaxTimeout is the maximum timeout of the eviction hooks
+// of the workloads, when the Configuration doesn't set one.
+const DefaultEvictionHooksMaxTimeout = time.Hour
+
+// EvictionHooks returns the names of the eviction hooks of the workload which
+// haven't completed yet, if the WorkloadEvictionHooks feature is on.
+func EvictionHooks(w *kueue.Workload) []string {
+	if !features.Enabled(features.WorkloadEvictionHooks) {
+		return nil
+	}
+	return csv.Parse(w.Annotations[controllerconstants.EvictionHooksAnnotation])
+}
+
+// EvictionHooksTimeout returns the maximum duration Kueue waits for the
+// eviction hooks of the workload, counted from its eviction, clamped to
+// maxTimeout.
+func EvictionHooksTimeout(w *kueue.Workload, maxTimeout time.Duration) time.Duration {
+	timeout := DefaultEvictionHooksTimeout
+	if value, err := time.ParseDuration(w.Annotations[controllerconstants.EvictionHooksTimeoutAnnotation]); err == nil && value > 0 {
+		timeout = value
+	}
+	return min(timeout, maxTimeout)
+}
+
+// ParseNodeShare parses the value of the node-share annotation, which is a
+// percentage of a node between "1%" and "100%".
+func ParseNodeShare(value string) (int64, error) {
+	percent, found := strings.CutSuffix(value, "%")
+	if !found {
+		return 0, fmt.Errorf("%q is not a percentage", value)
+	}
+	share, err := strconv.ParseInt(percent, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a percentage: %w", value, err)
+	}
+	if share < 1 || share > 100 {
+		return 0, fmt.Errorf("%q must be between 1%% and 100%%", value)
+	}
+	return share, nil
+}
+
+// NodeShare returns the percentage of a node of the assigned flavor requested
+// by each pod of the PodSet with the node-share annotation, and true if the
+// annotation is set to a valid value and the NodeShareRequests feature is on.
+func NodeShare(ps *kueue.PodSet) (int64, bool) {
+	if !features.Enabled(features.NodeShareRequests) {
+		return 0, false
+	}
+	value, ok := ps.Template.Annotations[constants.NodeShareAnnotation]
+	if !ok {
+		return 0, false
+	}
+	share, err := ParseNodeShare(value)
+	if err != nil {
+		return 0, false
+	}
+	return share, true
+}
+
+// ApplyNodeShareRequests overrides the requests of a single pod of the PodSet
+// with the node-share requests resolved against the nodeShape of the assigned
+// flavor, which are recorded in the admitted requests of the count pods of the
+// PodSet. It is a no-op for the PodSets without a node-share request.
+func ApplyNodeShareRequests(singlePodRequests resources.Requests, ps *kueue.PodSet, admitted corev1.ResourceList, count int32) {
+	if _, ok := NodeShare(ps); !ok || count <= 0 {
+		return
+	}
+	for res, q := range admitted {
+		if res == corev1.ResourcePods {
+			continue
+		}
+		singlePodRequests[res] = resources.ResourceValue(res, q) / int64(count)
+	}
+}
+
 // HasActiveQuotaReservation returns true if the workload has an active quota
 // reservation that should be tracked for ClusterQueue usage. This requires the
 // workload to be active, not finished, and holding a quota reservation.
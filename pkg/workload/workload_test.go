@@ -38,6 +38,7 @@ import (
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta2"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/constants"
 	controllerconstants "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -358,6 +359,60 @@ func TestNewInfo(t *testing.T) {
 				},
 			},
 		},
+		"admitted with TAS and node share": {
+			workload: *utiltestingapi.MakeWorkload("tas", "").
+				PodSets(
+					*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 2).
+						Annotations(map[string]string{constants.NodeShareAnnotation: "50%"}).
+						Request(corev1.ResourceCPU, "1").
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Obj(),
+				).
+				ReserveQuotaAt(
+					utiltestingapi.MakeAdmission("tas-cq").
+						PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+							Assignment(corev1.ResourceCPU, "tas", "8").
+							Assignment("example.com/gpu", "tas", "4").
+							Count(2).
+							TopologyAssignment(utiltestingapi.MakeTopologyAssignment(utiltas.Levels(utiltestingapi.MakeDefaultOneLevelTopology("default"))).
+								Domains(utiltestingapi.MakeTopologyDomainAssignment([]string{"node-a"}, 2).Obj()).
+								Obj()).
+							Obj()).
+						Obj(), now,
+				).
+				Obj(),
+			featureGates: map[featuregate.Feature]bool{features.NodeShareRequests: true},
+			wantInfo: Info{
+				ClusterQueue: "tas-cq",
+				TotalRequests: []PodSetResources{
+					{
+						Name: kueue.DefaultPodSetName,
+						Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+							corev1.ResourceCPU: "tas",
+							"example.com/gpu":  "tas",
+						},
+						Requests: resources.Requests{
+							corev1.ResourceCPU: 8000,
+							"example.com/gpu":  4,
+						},
+						Count: 2,
+						TopologyRequest: &TopologyRequest{
+							Levels: []string{corev1.LabelHostname},
+							DomainRequests: []TopologyDomainRequests{{
+								Values: []string{"node-a"},
+								// The node-share requests recorded in the admission
+								// replace the requests of the pod spec.
+								SinglePodRequests: resources.Requests{
+									corev1.ResourceCPU: 4000,
+									"example.com/gpu":  2,
+								},
+								Count: 2,
+							}},
+						},
+					},
+				},
+			},
+		},
 		"admitted with reclaim; reclaimablePods on": {
 			workload: *utiltestingapi.MakeWorkload("", "").
 				PodSets(
@@ -2171,6 +2226,7 @@ func TestWithPreprocessedDRAResourcesReplacesExtendedResources(t *testing.T) {
 }
 
 func TestSetQuotaReservation(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.TASUnconstrainedTopologyFallback, true)
 	// test clock and time "constants" uses in conditions.
 	testClock := testingclock.NewFakeClock(time.Now())
 	now := testClock.Now()
@@ -2255,6 +2311,58 @@ func TestSetQuotaReservation(t *testing.T) {
 				).
 				Obj(),
 		},
+		"WorkloadAdmittedWithUnconstrainedTopologyFallback": {
+			args: args{
+				workload: newWorkload().
+					PodSets(
+						*utiltestingapi.MakePodSet("launcher", 1).
+							RequiredTopologyRequest(corev1.LabelHostname).
+							Obj(),
+						*utiltestingapi.MakePodSet("worker", 2).
+							PreferredTopologyRequest(corev1.LabelHostname).
+							Annotations(map[string]string{kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "10m"}).
+							Obj(),
+					).
+					Obj(),
+				admission: utiltestingapi.MakeAdmission("test-queue").
+					PodSets(
+						utiltestingapi.MakePodSetAssignment("launcher").
+							TopologyAssignment(utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname}).
+								Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"x1"}, 1).Obj()).
+								Obj()).
+							Obj(),
+						utiltestingapi.MakePodSetAssignment("worker").
+							Count(2).
+							Obj(),
+					).
+					Obj(),
+			},
+			want: newWorkload().
+				PodSets(
+					*utiltestingapi.MakePodSet("launcher", 1).
+						RequiredTopologyRequest(corev1.LabelHostname).
+						Obj(),
+					*utiltestingapi.MakePodSet("worker", 2).
+						PreferredTopologyRequest(corev1.LabelHostname).
+						Annotations(map[string]string{kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "10m"}).
+						Obj(),
+				).
+				Admission(utiltestingapi.MakeAdmission("test-queue").
+					PodSets(
+						utiltestingapi.MakePodSetAssignment("launcher").
+							TopologyAssignment(utiltestingapi.MakeTopologyAssignment([]string{corev1.LabelHostname}).
+								Domain(utiltestingapi.MakeTopologyDomainAssignment([]string{"x1"}, 1).Obj()).
+								Obj()).
+							Obj(),
+						utiltestingapi.MakePodSetAssignment("worker").
+							Count(2).
+							Obj(),
+					).
+					Obj()).
+				Condition(newCondition(kueue.WorkloadQuotaReserved, metav1.ConditionTrue, quotaReservedReason,
+					quotaReservedMessage+", without topology assignment for podSets worker as their topology request could not be satisfied", now)).
+				Obj(),
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
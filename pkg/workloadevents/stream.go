@@ -0,0 +1,255 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+)
+
+const (
+	// Path is the path under which the stream is served by the metrics server.
+	Path = "/workload-events"
+
+	// subscriberBufferSize is the number of events buffered for a subscriber.
+	// Events are dropped for subscribers which fall further behind.
+	subscriberBufferSize = 100
+)
+
+// EventType is the type of a workload transition pushed to the subscribers.
+type EventType string
+
+const (
+	EventAdmitted EventType = "Admitted"
+	EventEvicted  EventType = "Evicted"
+	EventFinished EventType = "Finished"
+)
+
+// Event describes a transition of a Workload.
+type Event struct {
+	Type         EventType                   `json:"type"`
+	Namespace    string                      `json:"namespace"`
+	Name         string                      `json:"name"`
+	ClusterQueue kueue.ClusterQueueReference `json:"clusterQueue,omitempty"`
+	Reason       string                      `json:"reason,omitempty"`
+	Message      string                      `json:"message,omitempty"`
+	Time         metav1.Time                 `json:"time"`
+
+	labels labels.Set
+}
+
+// Filter selects the events delivered to a subscriber.
+type Filter struct {
+	// Namespace restricts the events to the workloads of the namespace.
+	// All namespaces are watched when empty.
+	Namespace string
+	// Selector restricts the events to the workloads matching the labels.
+	Selector labels.Selector
+}
+
+func (f *Filter) matches(e *Event) bool {
+	if f.Namespace != "" && f.Namespace != e.Namespace {
+		return false
+	}
+	return f.Selector == nil || f.Selector.Matches(e.labels)
+}
+
+type subscriber struct {
+	filter Filter
+	ch     chan Event
+}
+
+// Broadcaster pushes the admission, eviction and finish events of the
+// Workloads, observed through the manager's informer cache, to its
+// subscribers.
+type Broadcaster struct {
+	cache cache.Informers
+
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// SetupWithManager adds the Broadcaster to the manager, watching the
+// Workloads through the manager's cache.
+func (b *Broadcaster) SetupWithManager(mgr ctrl.Manager) error {
+	b.cache = mgr.GetCache()
+	return mgr.Add(b)
+}
+
+// Start registers the event handler in the Workload informer.
+func (b *Broadcaster) Start(ctx context.Context) error {
+	informer, err := b.cache.GetInformer(ctx, &kueue.Workload{})
+	if err != nil {
+		return fmt.Errorf("getting workload informer: %w", err)
+	}
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: b.OnUpdate,
+	}); err != nil {
+		return fmt.Errorf("adding workload event handler: %w", err)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable, every replica serves
+// the stream.
+func (b *Broadcaster) NeedLeaderElection() bool {
+	return false
+}
+
+// OnUpdate publishes the transitions between the old and the new version of
+// a Workload.
+func (b *Broadcaster) OnUpdate(oldObj, newObj any) {
+	oldWl, ok := oldObj.(*kueue.Workload)
+	if !ok {
+		return
+	}
+	newWl, ok := newObj.(*kueue.Workload)
+	if !ok {
+		return
+	}
+	for _, e := range transitions(oldWl, newWl) {
+		b.publish(e)
+	}
+}
+
+// Subscribe registers a subscriber receiving the events matching the filter.
+// The returned function must be called to unsubscribe.
+func (b *Broadcaster) Subscribe(filter Filter) (<-chan Event, func()) {
+	s := &subscriber{filter: filter, ch: make(chan Event, subscriberBufferSize)}
+	b.mu.Lock()
+	b.subscribers[s] = struct{}{}
+	b.mu.Unlock()
+	return s.ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, s)
+		b.mu.Unlock()
+	}
+}
+
+func (b *Broadcaster) publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subscribers {
+		if !s.filter.matches(&e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+		}
+	}
+}
+
+// ServeHTTP streams the events as Server-Sent Events. The stream can be
+// restricted with the "namespace" and "labelSelector" query parameters.
+func (b *Broadcaster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	filter := Filter{Namespace: r.URL.Query().Get("namespace")}
+	if selector := r.URL.Query().Get("labelSelector"); selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid labelSelector: %v", err), http.StatusBadRequest)
+			return
+		}
+		filter.Selector = parsed
+	}
+
+	events, unsubscribe := b.Subscribe(filter)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	log := ctrl.LoggerFrom(r.Context()).WithName("workload-events")
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-events:
+			data, err := json.Marshal(e)
+			if err != nil {
+				log.Error(err, "Failed to encode the workload event")
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// transitions returns the events for the conditions which turned true
+// between the old and the new version of the Workload.
+func transitions(oldWl, newWl *kueue.Workload) []Event {
+	var events []Event
+	for _, t := range []struct {
+		condition string
+		event     EventType
+	}{
+		{condition: kueue.WorkloadAdmitted, event: EventAdmitted},
+		{condition: kueue.WorkloadEvicted, event: EventEvicted},
+		{condition: kueue.WorkloadFinished, event: EventFinished},
+	} {
+		if apimeta.IsStatusConditionTrue(oldWl.Status.Conditions, t.condition) {
+			continue
+		}
+		cond := apimeta.FindStatusCondition(newWl.Status.Conditions, t.condition)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			continue
+		}
+		e := Event{
+			Type:      t.event,
+			Namespace: newWl.Namespace,
+			Name:      newWl.Name,
+			Reason:    cond.Reason,
+			Message:   cond.Message,
+			Time:      cond.LastTransitionTime,
+			labels:    newWl.Labels,
+		}
+		if newWl.Status.Admission != nil {
+			e.ClusterQueue = newWl.Status.Admission.ClusterQueue
+		}
+		events = append(events, e)
+	}
+	return events
+}
@@ -0,0 +1,215 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadevents
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
+)
+
+func admittedWorkload(pending *utiltestingapi.WorkloadWrapper, now time.Time) *utiltestingapi.WorkloadWrapper {
+	admission := utiltestingapi.MakeAdmission("cq").
+		PodSets(utiltestingapi.MakePodSetAssignment(kueue.DefaultPodSetName).
+			Assignment(corev1.ResourceCPU, "default", "1").
+			Obj()).
+		Obj()
+	return pending.Clone().ReserveQuotaAt(admission, now).AdmittedAt(true, now)
+}
+
+func TestTransitions(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	pending := utiltestingapi.MakeWorkload("wl", "ns").Label("team", "a")
+	admitted := admittedWorkload(pending, now)
+
+	testCases := map[string]struct {
+		oldWl      *kueue.Workload
+		newWl      *kueue.Workload
+		wantEvents []Event
+	}{
+		"admission": {
+			oldWl: pending.Clone().Obj(),
+			newWl: admitted.Clone().Obj(),
+			wantEvents: []Event{{
+				Type:         EventAdmitted,
+				Namespace:    "ns",
+				Name:         "wl",
+				ClusterQueue: "cq",
+				Reason:       "ByTest",
+				Message:      "Admitted by ClusterQueue cq",
+				Time:         metav1.NewTime(now),
+			}},
+		},
+		"no transition": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Obj(),
+		},
+		"eviction": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Condition(metav1.Condition{
+				Type:               kueue.WorkloadEvicted,
+				Status:             metav1.ConditionTrue,
+				Reason:             kueue.WorkloadEvictedByPreemption,
+				Message:            "Preempted",
+				LastTransitionTime: metav1.NewTime(now),
+			}).Obj(),
+			wantEvents: []Event{{
+				Type:         EventEvicted,
+				Namespace:    "ns",
+				Name:         "wl",
+				ClusterQueue: "cq",
+				Reason:       kueue.WorkloadEvictedByPreemption,
+				Message:      "Preempted",
+				Time:         metav1.NewTime(now),
+			}},
+		},
+		"finish": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Condition(metav1.Condition{
+				Type:               kueue.WorkloadFinished,
+				Status:             metav1.ConditionTrue,
+				Reason:             kueue.WorkloadFinishedReasonSucceeded,
+				LastTransitionTime: metav1.NewTime(now),
+			}).Obj(),
+			wantEvents: []Event{{
+				Type:         EventFinished,
+				Namespace:    "ns",
+				Name:         "wl",
+				ClusterQueue: "cq",
+				Reason:       kueue.WorkloadFinishedReasonSucceeded,
+				Time:         metav1.NewTime(now),
+			}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := transitions(tc.oldWl, tc.newWl)
+			if diff := cmp.Diff(tc.wantEvents, got, cmpopts.IgnoreUnexported(Event{}), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubscribeFilter(t *testing.T) {
+	b := NewBroadcaster()
+	pending := utiltestingapi.MakeWorkload("wl", "ns").Label("team", "a")
+	admitted := admittedWorkload(pending, time.Now())
+
+	matching, unsubscribeMatching := b.Subscribe(Filter{Namespace: "ns", Selector: labels.SelectorFromSet(labels.Set{"team": "a"})})
+	defer unsubscribeMatching()
+	otherNamespace, unsubscribeNamespace := b.Subscribe(Filter{Namespace: "other"})
+	defer unsubscribeNamespace()
+	otherTeam, unsubscribeTeam := b.Subscribe(Filter{Selector: labels.SelectorFromSet(labels.Set{"team": "b"})})
+	defer unsubscribeTeam()
+
+	b.OnUpdate(pending.Obj(), admitted.Obj())
+
+	if got := len(matching); got != 1 {
+		t.Errorf("Unexpected number of events for the matching subscriber, got %d, want 1", got)
+	}
+	if got := len(otherNamespace); got != 0 {
+		t.Errorf("Unexpected number of events for the other namespace subscriber, got %d, want 0", got)
+	}
+	if got := len(otherTeam); got != 0 {
+		t.Errorf("Unexpected number of events for the other team subscriber, got %d, want 0", got)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	b := NewBroadcaster()
+	server := httptest.NewServer(b)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + Path + "?namespace=ns&labelSelector=team%3Da")
+	if err != nil {
+		t.Fatalf("Failed to connect to the stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected status code, got %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	pending := utiltestingapi.MakeWorkload("wl", "ns").Label("team", "a")
+	admitted := admittedWorkload(pending, time.Now())
+	b.OnUpdate(pending.Obj(), admitted.Obj())
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	var gotType string
+	var gotEvent Event
+	timeout := time.After(time.Second)
+	for gotEvent.Name == "" {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("The stream was closed before receiving the event")
+			}
+			if value, found := strings.CutPrefix(line, "event: "); found {
+				gotType = value
+			}
+			if value, found := strings.CutPrefix(line, "data: "); found {
+				if err := json.Unmarshal([]byte(value), &gotEvent); err != nil {
+					t.Fatalf("Failed to decode the event: %v", err)
+				}
+			}
+		case <-timeout:
+			t.Fatal("The event was not received within a second")
+		}
+	}
+	if gotType != string(EventAdmitted) {
+		t.Errorf("Unexpected event type, got %q, want %q", gotType, EventAdmitted)
+	}
+	if gotEvent.Namespace != "ns" || gotEvent.Name != "wl" {
+		t.Errorf("Unexpected workload, got %s/%s, want ns/wl", gotEvent.Namespace, gotEvent.Name)
+	}
+}
+
+func TestServeHTTPInvalidSelector(t *testing.T) {
+	b := NewBroadcaster()
+	server := httptest.NewServer(b)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + Path + "?labelSelector=team%3D%3D%3D")
+	if err != nil {
+		t.Fatalf("Failed to connect to the stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Unexpected status code, got %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
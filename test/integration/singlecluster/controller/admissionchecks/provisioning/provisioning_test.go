@@ -373,7 +373,7 @@ var _ = ginkgo.Describe("Provisioning", ginkgo.Label("controller:provisioning",
 				util.ExpectEventAppeared(ctx, k8sClient, eventsv1.Event{
 					Reason: "AdmissionCheckRejected",
 					Type:   corev1.EventTypeWarning,
-					Note:   fmt.Sprintf(`Deactivated due to AdmissionCheck in Rejected state: %q`, ac.Name),
+					Note:   fmt.Sprintf(`Deactivated due to AdmissionCheck in Rejected state: %q (Failed on attempt 1 of 1, no retries left)`, ac.Name),
 				})
 
 				gomega.Eventually(func(g gomega.Gomega) {
@@ -1114,7 +1114,7 @@ var _ = ginkgo.Describe("Provisioning", ginkgo.Label("controller:provisioning",
 				util.ExpectEventAppeared(ctx, k8sClient, eventsv1.Event{
 					Reason: "AdmissionCheckRejected",
 					Type:   corev1.EventTypeWarning,
-					Note:   fmt.Sprintf(`Deactivated due to AdmissionCheck in Rejected state: %q`, ac.Name),
+					Note:   fmt.Sprintf(`Deactivated due to AdmissionCheck in Rejected state: %q (Failed on attempt 2 of 2, no retries left)`, ac.Name),
 				})
 
 				gomega.Eventually(func(g gomega.Gomega) {
@@ -2242,6 +2242,80 @@ var _ = ginkgo.Describe("Interacting with scheduler", ginkgo.Ordered, ginkgo.Con
 		gomega.Expect(runningSelector).To(gomega.Equal(map[string]string{instanceKey: "on-demand"}))
 	})
 
+	ginkgo.It("Should move a pending job to its fallback queue after the configured wait", func() {
+		features.SetFeatureGateDuringTest(ginkgo.GinkgoTB(), features.FallbackLocalQueue, true)
+
+		ginkgo.By("creating a job which consumes the whole on-demand quota of the prod ClusterQueue")
+		blockingJob := testingjob.MakeJob("blocking-job", ns.Name).Queue(kueue.LocalQueueName(prodLocalQ.Name)).Request(corev1.ResourceCPU, "5").Obj()
+		util.MustCreate(ctx, k8sClient, blockingJob)
+		createdBlockingJob := &batchv1.Job{}
+		gomega.Eventually(func(g gomega.Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(blockingJob), createdBlockingJob)).Should(gomega.Succeed())
+			g.Expect(createdBlockingJob.Spec.Suspend).Should(gomega.Equal(new(false)))
+		}, util.Timeout, util.Interval).Should(gomega.Succeed())
+
+		ginkgo.By("creating a job with a fallback queue which does not fit in the prod ClusterQueue")
+		job := testingjob.MakeJob("fallback-job", ns.Name).
+			Queue(kueue.LocalQueueName(prodLocalQ.Name)).
+			SetAnnotation(constants.FallbackQueueAnnotation, devLocalQ.Name).
+			SetAnnotation(constants.FallbackQueueAfterAnnotation, "2s").
+			Request(corev1.ResourceCPU, "5").
+			Obj()
+		util.MustCreate(ctx, k8sClient, job)
+		wlLookupKey := types.NamespacedName{Name: workloadjob.GetWorkloadNameForJob(job.Name, job.UID), Namespace: ns.Name}
+		createdWorkload := &kueue.Workload{}
+		gomega.Eventually(func(g gomega.Gomega) {
+			g.Expect(k8sClient.Get(ctx, wlLookupKey, createdWorkload)).Should(gomega.Succeed())
+			g.Expect(createdWorkload.Spec.QueueName).Should(gomega.Equal(kueue.LocalQueueName(prodLocalQ.Name)))
+		}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		util.ExpectWorkloadsToBePending(ctx, k8sClient, createdWorkload)
+
+		ginkgo.By("checking the job is moved to the fallback queue and admitted there")
+		createdJob := &batchv1.Job{}
+		gomega.Eventually(func(g gomega.Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(job), createdJob)).Should(gomega.Succeed())
+			g.Expect(createdJob.Labels).Should(gomega.HaveKeyWithValue(constants.QueueLabel, devLocalQ.Name))
+			g.Expect(k8sClient.Get(ctx, wlLookupKey, createdWorkload)).Should(gomega.Succeed())
+			g.Expect(createdWorkload.Spec.QueueName).Should(gomega.Equal(kueue.LocalQueueName(devLocalQ.Name)))
+		}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		util.ExpectWorkloadsToBeAdmittedCount(ctx, k8sClient, 1, createdWorkload)
+		gomega.Expect(createdWorkload.Status.Admission.ClusterQueue).Should(gomega.Equal(kueue.ClusterQueueReference(devClusterQ.Name)))
+	})
+
+	ginkgo.It("Should admit a job in its second candidate queue when the first one is full", func() {
+		features.SetFeatureGateDuringTest(ginkgo.GinkgoTB(), features.CandidateLocalQueues, true)
+
+		ginkgo.By("creating a job which consumes the whole on-demand quota of the prod ClusterQueue")
+		blockingJob := testingjob.MakeJob("blocking-job", ns.Name).Queue(kueue.LocalQueueName(prodLocalQ.Name)).Request(corev1.ResourceCPU, "5").Obj()
+		util.MustCreate(ctx, k8sClient, blockingJob)
+		createdBlockingJob := &batchv1.Job{}
+		gomega.Eventually(func(g gomega.Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(blockingJob), createdBlockingJob)).Should(gomega.Succeed())
+			g.Expect(createdBlockingJob.Spec.Suspend).Should(gomega.Equal(new(false)))
+		}, util.Timeout, util.Interval).Should(gomega.Succeed())
+
+		ginkgo.By("creating a job with candidate queues which does not fit in the prod ClusterQueue")
+		job := testingjob.MakeJob("candidate-job", ns.Name).
+			Queue(kueue.LocalQueueName(prodLocalQ.Name)).
+			SetAnnotation(constants.CandidateQueuesAnnotation, prodLocalQ.Name+","+devLocalQ.Name).
+			Request(corev1.ResourceCPU, "5").
+			Obj()
+		util.MustCreate(ctx, k8sClient, job)
+
+		ginkgo.By("checking the job is moved to the second candidate queue and admitted there")
+		wlLookupKey := types.NamespacedName{Name: workloadjob.GetWorkloadNameForJob(job.Name, job.UID), Namespace: ns.Name}
+		createdWorkload := &kueue.Workload{}
+		createdJob := &batchv1.Job{}
+		gomega.Eventually(func(g gomega.Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(job), createdJob)).Should(gomega.Succeed())
+			g.Expect(createdJob.Labels).Should(gomega.HaveKeyWithValue(constants.QueueLabel, devLocalQ.Name))
+			g.Expect(k8sClient.Get(ctx, wlLookupKey, createdWorkload)).Should(gomega.Succeed())
+			g.Expect(createdWorkload.Spec.QueueName).Should(gomega.Equal(kueue.LocalQueueName(devLocalQ.Name)))
+		}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		util.ExpectWorkloadsToBeAdmittedCount(ctx, k8sClient, 1, createdWorkload)
+		gomega.Expect(createdWorkload.Status.Admission.ClusterQueue).Should(gomega.Equal(kueue.ClusterQueueReference(devClusterQ.Name)))
+	})
+
 	ginkgo.When("The workload's admission is removed", func() {
 		ginkgo.It("Should restore the original node selectors", func() {
 			localQueue := utiltestingapi.MakeLocalQueue("local-queue", ns.Name).ClusterQueue(prodClusterQ.Name).Obj()
@@ -2564,6 +2638,103 @@ var _ = ginkgo.Describe("Interacting with scheduler", ginkgo.Ordered, ginkgo.Con
 		})
 	})
 
+	ginkgo.It("Should not readmit a preempted Job which is failing per its podFailurePolicy", func() {
+		features.SetFeatureGateDuringTest(ginkgo.GinkgoTB(), features.JobFailureTargetFinishesWorkload, true)
+
+		highPriorityClass := utiltesting.MakePriorityClass("high").PriorityValue(100).Obj()
+		util.MustCreate(ctx, k8sClient, highPriorityClass)
+		ginkgo.DeferCleanup(func() {
+			gomega.Expect(k8sClient.Delete(ctx, highPriorityClass)).To(gomega.Succeed())
+		})
+
+		ginkgo.By("creating a job which consumes the whole on-demand quota", func() {
+			job := testingjob.MakeJob("on-demand", ns.Name).
+				Queue(kueue.LocalQueueName(devLocalQ.Name)).
+				PriorityClass("high").
+				Parallelism(5).
+				Request(corev1.ResourceCPU, "1").
+				NodeSelector(instanceKey, "on-demand").
+				Obj()
+			util.MustCreate(ctx, k8sClient, job)
+			util.ExpectJobUnsuspendedWithNodeSelectors(ctx, k8sClient, client.ObjectKeyFromObject(job), map[string]string{
+				instanceKey: "on-demand",
+			})
+		})
+
+		lowJob := testingjob.MakeJob("low", ns.Name).
+			Queue(kueue.LocalQueueName(devLocalQ.Name)).
+			Parallelism(5).
+			Request(corev1.ResourceCPU, "1").
+			PodFailurePolicy(&batchv1.PodFailurePolicy{
+				Rules: []batchv1.PodFailurePolicyRule{{
+					Action: batchv1.PodFailurePolicyActionFailJob,
+					OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+						Operator: batchv1.PodFailurePolicyOnExitCodesOpIn,
+						Values:   []int32{42},
+					},
+				}},
+			}).
+			Obj()
+		lowJobKey := client.ObjectKeyFromObject(lowJob)
+		ginkgo.By("creating a low priority job", func() {
+			util.MustCreate(ctx, k8sClient, lowJob)
+			util.ExpectJobUnsuspendedWithNodeSelectors(ctx, k8sClient, lowJobKey, map[string]string{
+				instanceKey: "spot-untainted",
+			})
+		})
+
+		highJob := testingjob.MakeJob("high", ns.Name).
+			Queue(kueue.LocalQueueName(devLocalQ.Name)).
+			PriorityClass("high").
+			Parallelism(5).
+			Request(corev1.ResourceCPU, "1").
+			NodeSelector(instanceKey, "spot-untainted").
+			Obj()
+		ginkgo.By("creating a high priority job which preempts the low priority job", func() {
+			util.MustCreate(ctx, k8sClient, highJob)
+			util.ExpectJobUnsuspendedWithNodeSelectors(ctx, k8sClient, client.ObjectKeyFromObject(highJob), map[string]string{
+				instanceKey: "spot-untainted",
+			})
+		})
+
+		createdLowJob := &batchv1.Job{}
+		ginkgo.By("marking the low priority job as failing per its podFailurePolicy", func() {
+			gomega.Eventually(func(g gomega.Gomega) {
+				g.Expect(k8sClient.Get(ctx, lowJobKey, createdLowJob)).Should(gomega.Succeed())
+				g.Expect(createdLowJob.Spec.Suspend).Should(gomega.Equal(new(true)))
+				createdLowJob.Status.Conditions = append(createdLowJob.Status.Conditions, batchv1.JobCondition{
+					Type:               batchv1.JobFailureTarget,
+					Status:             corev1.ConditionTrue,
+					Reason:             batchv1.JobReasonPodFailurePolicy,
+					Message:            "Container c for pod failed with exit code 42 matching FailJob rule at index 0",
+					LastProbeTime:      metav1.Now(),
+					LastTransitionTime: metav1.Now(),
+				})
+				createdLowJob.Status.Terminating = new(int32(0))
+				g.Expect(k8sClient.Status().Update(ctx, createdLowJob)).Should(gomega.Succeed())
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		})
+
+		lowWlKey := types.NamespacedName{Name: workloadjob.GetWorkloadNameForJob(lowJob.Name, lowJob.UID), Namespace: ns.Name}
+		ginkgo.By("checking the workload of the low priority job is finished", func() {
+			util.ExpectWorkloadToFinish(ctx, k8sClient, lowWlKey)
+			createdWorkload := &kueue.Workload{}
+			gomega.Expect(k8sClient.Get(ctx, lowWlKey, createdWorkload)).Should(gomega.Succeed())
+			gomega.Expect(createdWorkload.Status.Conditions).Should(utiltesting.HaveConditionStatusTrueAndReason(kueue.WorkloadFinished, kueue.WorkloadFinishedReasonFailed))
+		})
+
+		ginkgo.By("deleting the high priority job to release the quota", func() {
+			util.ExpectObjectToBeDeleted(ctx, k8sClient, highJob, true)
+		})
+
+		ginkgo.By("checking the low priority job isn't resumed", func() {
+			gomega.Consistently(func(g gomega.Gomega) {
+				g.Expect(k8sClient.Get(ctx, lowJobKey, createdLowJob)).Should(gomega.Succeed())
+				g.Expect(createdLowJob.Spec.Suspend).Should(gomega.Equal(new(true)))
+			}, util.ConsistentDuration, util.ShortInterval).Should(gomega.Succeed())
+		})
+	})
+
 	ginkgo.It("Should schedule jobs with partial admission", framework.SlowSpec, func() {
 		job1 := testingjob.MakeJob("job1", ns.Name).
 			Queue(kueue.LocalQueueName(prodLocalQ.Name)).
@@ -307,7 +307,7 @@ var _ = ginkgo.Describe("JobSet controller", ginkgo.Label("job:jobset", "area:jo
 			jobSet := testingjobset.MakeJobSet(jobSetName, ns.Name).ReplicatedJobs(
 				testingjobset.ReplicatedJobRequirements{
 					Name:        "replicated-job-1",
-					Replicas:    1,
+					Replicas:    2,
 					Parallelism: 1,
 					Completions: 1,
 				},
@@ -346,7 +346,7 @@ var _ = ginkgo.Describe("JobSet controller", ginkgo.Label("job:jobset", "area:jo
 				gomega.Eventually(func(g gomega.Gomega) {
 					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(jobSet), jobSet)).To(gomega.Succeed())
 					jobSet.Status.ReplicatedJobsStatus = make([]jobsetapi.ReplicatedJobStatus, 1)
-					jobSet.Status.ReplicatedJobsStatus[0].Active = 1
+					jobSet.Status.ReplicatedJobsStatus[0].Active = 2
 					jobSet.Status.ReplicatedJobsStatus[0].Name = jobSet.Spec.ReplicatedJobs[0].Name
 					g.Expect(k8sClient.Status().Update(ctx, jobSet)).To(gomega.Succeed())
 				}, util.Timeout, util.Interval).Should(gomega.Succeed())
@@ -374,10 +374,27 @@ var _ = ginkgo.Describe("JobSet controller", ginkgo.Label("job:jobset", "area:jo
 				}, util.ConsistentDuration, util.ShortInterval).Should(gomega.Succeed())
 			})
 
-			ginkgo.By("mark the jobset as inactive", func() {
+			ginkgo.By("mark one of the child jobs as suspended", func() {
+				gomega.Eventually(func(g gomega.Gomega) {
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(jobSet), jobSet)).To(gomega.Succeed())
+					jobSet.Status.ReplicatedJobsStatus[0].Active = 1
+					jobSet.Status.ReplicatedJobsStatus[0].Suspended = 1
+					g.Expect(k8sClient.Status().Update(ctx, jobSet)).To(gomega.Succeed())
+				}, util.Timeout, util.Interval).Should(gomega.Succeed())
+			})
+
+			ginkgo.By("the workload should stay admitted while a child job is running", func() {
+				gomega.Consistently(func(g gomega.Gomega) {
+					g.Expect(k8sClient.Get(ctx, wlLookupKey, createdWorkload)).To(gomega.Succeed())
+					g.Expect(createdWorkload.Status.Conditions).To(utiltesting.HaveConditionStatusTrue(kueue.WorkloadQuotaReserved))
+				}, util.ConsistentDuration, util.ShortInterval).Should(gomega.Succeed())
+			})
+
+			ginkgo.By("mark all the child jobs as suspended", func() {
 				gomega.Eventually(func(g gomega.Gomega) {
 					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(jobSet), jobSet)).To(gomega.Succeed())
 					jobSet.Status.ReplicatedJobsStatus[0].Active = 0
+					jobSet.Status.ReplicatedJobsStatus[0].Suspended = 2
 					g.Expect(k8sClient.Status().Update(ctx, jobSet)).To(gomega.Succeed())
 				}, util.Timeout, util.Interval).Should(gomega.Succeed())
 			})
@@ -1301,6 +1318,87 @@ var _ = ginkgo.Describe("JobSet controller with TopologyAwareScheduling", ginkgo
 		})
 	})
 
+	ginkgo.It("should place each replicated job in its own required topology domain", framework.SlowSpec, func() {
+		ginkgo.By("creating a second rack", func() {
+			node := *testingnode.MakeNode("b1r2").
+				Label(nodeGroupLabel, "tas").
+				Label(utiltesting.DefaultBlockTopologyLevel, "b1").
+				Label(utiltesting.DefaultRackTopologyLevel, "r2").
+				StatusAllocatable(corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					corev1.ResourcePods:   resource.MustParse("10"),
+				}).
+				Ready().
+				Obj()
+			util.CreateNodesWithStatus(ctx, k8sClient, []corev1.Node{node})
+			nodes = append(nodes, node)
+		})
+
+		jobSet := testingjobset.MakeJobSet(jobSetName, ns.Name).
+			Queue(localQueue.Name).
+			ReplicatedJobs(
+				testingjobset.ReplicatedJobRequirements{
+					Name:        "rj1",
+					Replicas:    1,
+					Parallelism: 1,
+					Completions: 1,
+					PodAnnotations: map[string]string{
+						kueue.PodSetRequiredTopologyAnnotation: utiltesting.DefaultRackTopologyLevel,
+					},
+					Image: util.GetAgnHostImage(),
+					Args:  util.BehaviorWaitForDeletion,
+				},
+				testingjobset.ReplicatedJobRequirements{
+					Name:        "rj2",
+					Replicas:    1,
+					Parallelism: 1,
+					Completions: 1,
+					PodAnnotations: map[string]string{
+						kueue.PodSetRequiredTopologyAnnotation: utiltesting.DefaultRackTopologyLevel,
+					},
+					Image: util.GetAgnHostImage(),
+					Args:  util.BehaviorWaitForDeletion,
+				},
+			).
+			Request("rj1", corev1.ResourceCPU, "1").
+			Request("rj2", corev1.ResourceCPU, "1").
+			Obj()
+		ginkgo.By("creating a JobSet", func() {
+			util.MustCreate(ctx, k8sClient, jobSet)
+		})
+
+		wl := &kueue.Workload{}
+		wlLookupKey := types.NamespacedName{
+			Name:      workloadjobset.GetWorkloadNameForJobSet(jobSet.Name, jobSet.UID),
+			Namespace: ns.Name,
+		}
+
+		ginkgo.By("verify the workload is admitted", func() {
+			gomega.Eventually(func(g gomega.Gomega) {
+				g.Expect(k8sClient.Get(ctx, wlLookupKey, wl)).Should(gomega.Succeed())
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+			util.ExpectWorkloadsToBeAdmitted(ctx, k8sClient, wl)
+		})
+
+		ginkgo.By("verify each replicated job is assigned to a distinct rack", func() {
+			gomega.Eventually(func(g gomega.Gomega) {
+				g.Expect(k8sClient.Get(ctx, wlLookupKey, wl)).Should(gomega.Succeed())
+				g.Expect(wl.Status.Admission).ShouldNot(gomega.BeNil())
+				g.Expect(wl.Status.Admission.PodSetAssignments).Should(gomega.HaveLen(2))
+				racks := make([]string, 0, 2)
+				for _, psa := range wl.Status.Admission.PodSetAssignments {
+					g.Expect(psa.TopologyAssignment).ShouldNot(gomega.BeNil())
+					ta := tas.InternalFrom(psa.TopologyAssignment)
+					g.Expect(ta.Domains).Should(gomega.HaveLen(1))
+					g.Expect(ta.Domains[0].Values).Should(gomega.HaveLen(2))
+					racks = append(racks, ta.Domains[0].Values[1])
+				}
+				g.Expect(racks).Should(gomega.ConsistOf("r1", "r2"))
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		})
+	})
+
 	ginkgo.It("should re-admit jobset with completed podSets after preemption without infinite loop", framework.SlowSpec, func() {
 		jobSet := testingjobset.MakeJobSet("tas-preempt-jobset", ns.Name).
 			Queue(localQueue.Name).
@@ -1402,8 +1500,8 @@ var _ = ginkgo.Describe("JobSet controller with TopologyAwareScheduling", ginkgo
 						Succeeded: 1,
 					},
 					jobsetapi.ReplicatedJobStatus{
-						Name:   "job-b",
-						Active: 0,
+						Name:      "job-b",
+						Suspended: 1,
 					},
 				).Obj()
 				g.Expect(k8sClient.Status().Update(ctx, jobSet)).To(gomega.Succeed())
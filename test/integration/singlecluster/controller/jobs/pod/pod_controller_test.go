@@ -2316,6 +2316,68 @@ var _ = ginkgo.Describe("Pod controller interacting with scheduler", ginkgo.Labe
 		})
 	})
 
+	ginkgo.It("Should keep a pod group gated until its workload is admitted and ungate the same pods", func() {
+		ginkgo.By("creating localQueue")
+		localQueue = utiltestingapi.MakeLocalQueue("local-queue", ns.Name).ClusterQueue(clusterQueue.Name).Obj()
+		util.MustCreate(ctx, k8sClient, localQueue)
+
+		blockerPod := testingpod.MakePod("blocker-pod", ns.Name).Queue(localQueue.Name).
+			Request(corev1.ResourceCPU, "4").
+			Obj()
+		ginkgo.By("creating a pod which uses most of the quota", func() {
+			util.MustCreate(ctx, k8sClient, blockerPod)
+			gomega.Eventually(func(g gomega.Gomega) {
+				createdPod := &corev1.Pod{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(blockerPod), createdPod)).To(gomega.Succeed())
+				g.Expect(createdPod.Spec.SchedulingGates).Should(gomega.BeEmpty())
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		})
+
+		basePod := testingpod.MakePod("pod", ns.Name).
+			GroupNameLabel("gated-pods").
+			GroupTotalCount("2").
+			Queue(localQueue.Name).
+			Request(corev1.ResourceCPU, "1")
+		pod1 := basePod.Clone().Name("pod1").Obj()
+		pod2 := basePod.Clone().Name("pod2").Obj()
+		ginkgo.By("creating the pod group which does not fit", func() {
+			util.MustCreate(ctx, k8sClient, pod1)
+			util.MustCreate(ctx, k8sClient, pod2)
+		})
+		wlKey := types.NamespacedName{Namespace: ns.Name, Name: "gated-pods"}
+		util.ExpectWorkloadsToBePendingByKeys(ctx, k8sClient, wlKey)
+
+		createdUIDs := make(map[string]types.UID, 2)
+		ginkgo.By("checking the pods stay gated while the workload is pending", func() {
+			gomega.Consistently(func(g gomega.Gomega) {
+				for _, pod := range []*corev1.Pod{pod1, pod2} {
+					createdPod := &corev1.Pod{}
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), createdPod)).To(gomega.Succeed())
+					g.Expect(createdPod.Spec.SchedulingGates).Should(gomega.ContainElement(corev1.PodSchedulingGate{Name: podconstants.SchedulingGateName}))
+					g.Expect(createdPod.Status.Phase).Should(gomega.Equal(corev1.PodPending))
+					createdUIDs[pod.Name] = createdPod.UID
+				}
+			}, util.ConsistentDuration, util.ShortInterval).Should(gomega.Succeed())
+		})
+
+		ginkgo.By("finishing the pod which uses most of the quota", func() {
+			util.SetPodsPhase(ctx, k8sClient, corev1.PodSucceeded, blockerPod)
+		})
+		util.ExpectWorkloadsToBeAdmittedByKeys(ctx, k8sClient, wlKey)
+
+		ginkgo.By("checking the same pods are ungated", func() {
+			gomega.Eventually(func(g gomega.Gomega) {
+				for _, pod := range []*corev1.Pod{pod1, pod2} {
+					createdPod := &corev1.Pod{}
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), createdPod)).To(gomega.Succeed())
+					g.Expect(createdPod.Spec.SchedulingGates).Should(gomega.BeEmpty())
+					g.Expect(createdPod.UID).Should(gomega.Equal(createdUIDs[pod.Name]))
+					g.Expect(createdPod.Spec.NodeSelector).Should(gomega.HaveKeyWithValue(instanceKey, spotUntaintedFlavor.Name))
+				}
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		})
+	})
+
 	ginkgo.When("The workload's admission is removed", func() {
 		ginkgo.It("Should not restore the original node selectors", func() {
 			localQueue := utiltestingapi.MakeLocalQueue("local-queue", ns.Name).ClusterQueue(clusterQueue.Name).Obj()
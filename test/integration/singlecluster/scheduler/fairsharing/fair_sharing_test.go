@@ -165,6 +165,9 @@ var _ = ginkgo.Describe("Scheduler", ginkgo.Label("feature:fairsharing"), func()
 			util.ExpectClusterQueueWeightedShareMetric(cqA, 625.0)
 			util.ExpectClusterQueueWeightedShareMetric(cqB, 0.0)
 			util.ExpectClusterQueueWeightedShareMetric(cqShared, 0.0)
+			util.ExpectClusterQueueCohortUsageShareMetric(cqA, 1.0)
+			util.ExpectClusterQueueCohortUsageShareMetric(cqB, 0.0)
+			util.ExpectClusterQueueCohortUsageShareMetric(cqShared, 0.0)
 
 			ginkgo.By("Creating newer workloads in cq-b")
 			util.WaitForNextSecondAfterCreation(wls[len(wls)-1])
@@ -204,6 +207,9 @@ var _ = ginkgo.Describe("Scheduler", ginkgo.Label("feature:fairsharing"), func()
 			util.ExpectClusterQueueWeightedShareMetric(cqA, 250.0)
 			util.ExpectClusterQueueWeightedShareMetric(cqB, 250.0)
 			util.ExpectClusterQueueWeightedShareMetric(cqShared, 0.0)
+			util.ExpectClusterQueueCohortUsageShareMetric(cqA, 0.625)
+			util.ExpectClusterQueueCohortUsageShareMetric(cqB, 0.375)
+			util.ExpectClusterQueueCohortUsageShareMetric(cqShared, 0.0)
 
 			ginkgo.By("Terminating 2 more running workloads in cqA: cqB starts to take over shared quota")
 			util.FinishRunningWorkloadsInCQ(ctx, k8sClient, cqA, 2)
@@ -1429,6 +1429,34 @@ var _ = ginkgo.Describe("Topology Aware Scheduling", ginkgo.Ordered, func() {
 				})
 			})
 
+			ginkgo.It("should admit workload without topology assignment after the unconstrained topology fallback timeout", func() {
+				features.SetFeatureGateDuringTest(ginkgo.GinkgoTB(), features.TASUnconstrainedTopologyFallback, true)
+
+				var wl *kueue.Workload
+				ginkgo.By("creating a workload which requires rack, but does not fit in any", func() {
+					wl = utiltestingapi.MakeWorkload("wl", ns.Name).
+						PodSets(*utiltestingapi.MakePodSet("worker", 4).
+							RequiredTopologyRequest(utiltesting.DefaultRackTopologyLevel).
+							Annotations(map[string]string{kueue.PodSetUnconstrainedTopologyFallbackAnnotation: "3s"}).
+							Obj()).
+						Queue(kueue.LocalQueueName(localQueue.Name)).Request(corev1.ResourceCPU, "1").Obj()
+					util.MustCreate(ctx, k8sClient, wl)
+				})
+
+				ginkgo.By("verify the workload is admitted without topology assignment after the fallback timeout", func() {
+					util.ExpectWorkloadsToBeAdmitted(ctx, k8sClient, wl)
+					gomega.Eventually(func(g gomega.Gomega) {
+						g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(wl), wl)).To(gomega.Succeed())
+						g.Expect(wl.Status.Admission).ShouldNot(gomega.BeNil())
+						g.Expect(wl.Status.Admission.PodSetAssignments).Should(gomega.HaveLen(1))
+						g.Expect(wl.Status.Admission.PodSetAssignments[0].TopologyAssignment).Should(gomega.BeNil())
+						quotaReserved := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+						g.Expect(quotaReserved).ShouldNot(gomega.BeNil())
+						g.Expect(quotaReserved.Message).Should(gomega.ContainSubstring("without topology assignment for podSets worker"))
+					}, util.Timeout, util.Interval).Should(gomega.Succeed())
+				})
+			})
+
 			ginkgo.It("should admit workload which fits", func() {
 				var wl1, wl2 *kueue.Workload
 				ginkgo.By("creating a workload which can fit", func() {
@@ -3783,6 +3811,70 @@ var _ = ginkgo.Describe("Topology Aware Scheduling", ginkgo.Ordered, func() {
 					util.ExpectPendingWorkloadsMetric(clusterQueue, 0, 0)
 				})
 			})
+
+			ginkgo.It("should admit workload when nodes join a rack which was too small", func() {
+				var (
+					wl1 *kueue.Workload
+				)
+				makeNode := func(name, rack string) corev1.Node {
+					return *testingnode.MakeNode(name).
+						Label("node-group", "tas").
+						Label(utiltesting.DefaultBlockTopologyLevel, "b1").
+						Label(utiltesting.DefaultRackTopologyLevel, rack).
+						StatusAllocatable(corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+							corev1.ResourcePods:   resource.MustParse("10"),
+						}).
+						Ready().
+						Obj()
+				}
+
+				ginkgo.By("creating racks which are too small for the workload", func() {
+					nodes = []corev1.Node{
+						makeNode("b1-r1-x1", "r1"),
+						makeNode("b1-r2-x1", "r2"),
+					}
+					util.CreateNodesWithStatus(ctx, k8sClient, nodes)
+				})
+
+				ginkgo.By("creating a workload which requires rack, but does not fit in any", func() {
+					wl1 = utiltestingapi.MakeWorkload("wl1", ns.Name).
+						Queue(kueue.LocalQueueName(localQueue.Name)).
+						PodSets(*utiltestingapi.MakePodSet(kueue.DefaultPodSetName, 2).
+							RequiredTopologyRequest(utiltesting.DefaultRackTopologyLevel).
+							Request(corev1.ResourceCPU, "1").
+							Obj()).
+						Obj()
+					util.MustCreate(ctx, k8sClient, wl1)
+				})
+
+				ginkgo.By("verify the workload is inadmissible", func() {
+					util.ExpectWorkloadsToBePending(ctx, k8sClient, wl1)
+					util.ExpectPendingWorkloadsMetric(clusterQueue, 0, 1)
+				})
+
+				ginkgo.By("adding a node to the first rack", func() {
+					newNodes := []corev1.Node{makeNode("b1-r1-x2", "r1")}
+					util.CreateNodesWithStatus(ctx, k8sClient, newNodes)
+					nodes = append(nodes, newNodes...)
+				})
+
+				ginkgo.By("verify the workload is admitted in the first rack", func() {
+					util.ExpectWorkloadsToBeAdmitted(ctx, k8sClient, wl1)
+					util.ExpectAdmittedWorkloadsTotalMetric(clusterQueue, "", 1)
+					util.ExpectPendingWorkloadsMetric(clusterQueue, 0, 0)
+					gomega.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(wl1), wl1)).To(gomega.Succeed())
+					gomega.Expect(wl1.Status.Admission.PodSetAssignments[0].TopologyAssignment).Should(gomega.BeComparableTo(
+						utiltas.V1Beta2From(&utiltas.TopologyAssignment{
+							Levels: []string{utiltesting.DefaultBlockTopologyLevel, utiltesting.DefaultRackTopologyLevel},
+							Domains: []utiltas.TopologyDomainAssignment{
+								{Count: 2, Values: []string{"b1", "r1"}},
+							},
+						}),
+					))
+				})
+			})
 		})
 
 		ginkgo.When("Workloads use preferred node affinity and TASRespectNodeAffinityPreferred is enabled", func() {
@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta2"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingapi "sigs.k8s.io/kueue/pkg/util/testing/v1beta2"
 	"sigs.k8s.io/kueue/test/util"
@@ -61,6 +62,33 @@ var _ = ginkgo.Describe("Queue validating webhook", func() {
 			}, util.Timeout, util.Interval).Should(gomega.Succeed())
 		})
 	})
+	ginkgo.When("Deleting a Queue", func() {
+		ginkgo.BeforeEach(func() {
+			features.SetFeatureGateDuringTest(ginkgo.GinkgoTB(), features.LocalQueueDeletionProtection, true)
+		})
+		ginkgo.It("Should reject the deletion while a workload is admitted", func() {
+			ginkgo.By("Creating a new Queue")
+			lq := utiltestingapi.MakeLocalQueue(queueName, ns.Name).ClusterQueue("foo").Obj()
+			util.MustCreate(ctx, k8sClient, lq)
+
+			ginkgo.By("Creating an admitted workload in the Queue")
+			wl := utiltestingapi.MakeWorkload("wl", ns.Name).Queue(kueue.LocalQueueName(lq.Name)).Obj()
+			util.MustCreate(ctx, k8sClient, wl)
+			util.SetQuotaReservation(ctx, k8sClient, client.ObjectKeyFromObject(wl), utiltestingapi.MakeAdmission("foo").Obj())
+			util.SyncAdmittedConditionForWorkloads(ctx, k8sClient, wl)
+
+			ginkgo.By("Deleting the Queue")
+			gomega.Expect(k8sClient.Delete(ctx, lq)).Should(utiltesting.BeForbiddenError())
+
+			ginkgo.By("Finishing the workload")
+			util.FinishWorkloads(ctx, k8sClient, wl)
+
+			ginkgo.By("Deleting the Queue")
+			gomega.Eventually(func(g gomega.Gomega) {
+				g.Expect(k8sClient.Delete(ctx, lq)).Should(gomega.Succeed())
+			}, util.Timeout, util.Interval).Should(gomega.Succeed())
+		})
+	})
 	ginkgo.When("Updating the status of a Queue", func() {
 		ginkgo.It("Should allow flavors quantity up to the limit in flavorsReservation and flavorsUsage", func() {
 			ginkgo.By("Creating a new Queue")
@@ -196,6 +196,16 @@ func ExpectPreemptedWorkloadsTotalMetric(preemptorCqName, reason string, v int)
 		preemptorCqName, reason, roletracker.RoleStandalone)
 }
 
+func ExpectPreemptionsTotalMetricAtLeast(cqName string, outcome metrics.PreemptionOutcome, minCount int) {
+	ginkgo.GinkgoHelper()
+	metric := metrics.PreemptionsTotal.WithLabelValues(cqName, string(outcome), roletracker.RoleStandalone)
+	gomega.Eventually(func(g gomega.Gomega) {
+		v, err := testutil.GetCounterMetricValue(metric)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(int(v)).Should(gomega.BeNumerically(">=", minCount))
+	}, Timeout, Interval).Should(gomega.Succeed())
+}
+
 // ExpectWorkloadEvictionLatencyHistogramMetricAtLeast asserts bucket sample count for workload_eviction_latency_seconds.
 func ExpectWorkloadEvictionLatencyHistogramMetricAtLeast(cqName kueue.ClusterQueueReference, reason string, minCount int) {
 	ginkgo.GinkgoHelper()
@@ -277,6 +287,12 @@ func ExpectClusterQueueWeightedShareMetric(cq *kueue.ClusterQueue, value float64
 	expectGaugeMetric(metrics.ClusterQueueWeightedShare, lvs, gomega.Equal(value))
 }
 
+func ExpectClusterQueueCohortUsageShareMetric(cq *kueue.ClusterQueue, value float64) {
+	ginkgo.GinkgoHelper()
+	lvs := []string{cq.Name, string(cq.Spec.CohortName), roletracker.RoleStandalone}
+	expectGaugeMetric(metrics.ClusterQueueCohortUsageShare, lvs, gomega.BeNumerically("~", value, 1e-9))
+}
+
 func ExpectLocalQueueResourceMetric(queue *kueue.LocalQueue, flavorName, resourceName string, value float64) {
 	ginkgo.GinkgoHelper()
 	lvs := []string{queue.Name, queue.Namespace, flavorName, resourceName, roletracker.RoleStandalone}
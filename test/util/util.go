@@ -1102,11 +1102,49 @@ func ExpectClusterQueuesToBeActive(ctx context.Context, c client.Client, cqs ...
 	gomega.EventuallyWithOffset(1, func(g gomega.Gomega) {
 		for _, cq := range cqs {
 			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cq), readCq)).To(gomega.Succeed())
+			if cond := apimeta.FindStatusCondition(readCq.Status.Conditions, kueue.ClusterQueueActive); cond != nil && cond.Status == metav1.ConditionFalse &&
+				(cond.Reason == kueue.ClusterQueueActiveReasonFlavorNotFound || cond.Reason == kueue.ClusterQueueActiveReasonTopologyNotFound) {
+				missing, err := missingClusterQueueReferences(ctx, c, readCq)
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				if len(missing) > 0 {
+					gomega.StopTrying(fmt.Sprintf("ClusterQueue %q is inactive with reason %s, missing: %s: %s",
+						readCq.Name, cond.Reason, strings.Join(missing, ", "), cond.Message)).Now()
+				}
+			}
 			g.Expect(readCq.Status.Conditions).To(utiltesting.HaveConditionStatusTrue(kueue.ClusterQueueActive))
 		}
 	}, MediumTimeout, Interval).Should(gomega.Succeed(), AssertMsg("ClusterQueues did not become active", readCq))
 }
 
+// missingClusterQueueReferences returns the ResourceFlavors, and the Topologies
+// of the ResourceFlavors, referenced by the ClusterQueue which don't exist.
+func missingClusterQueueReferences(ctx context.Context, c client.Client, cq *kueue.ClusterQueue) ([]string, error) {
+	var missing []string
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			var rf kueue.ResourceFlavor
+			if err := c.Get(ctx, client.ObjectKey{Name: string(fq.Name)}, &rf); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				missing = append(missing, fmt.Sprintf("ResourceFlavor %q", fq.Name))
+				continue
+			}
+			if rf.Spec.TopologyName == nil {
+				continue
+			}
+			var topology kueue.Topology
+			if err := c.Get(ctx, client.ObjectKey{Name: string(*rf.Spec.TopologyName)}, &topology); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				missing = append(missing, fmt.Sprintf("Topology %q", *rf.Spec.TopologyName))
+			}
+		}
+	}
+	return missing, nil
+}
+
 func ExpectLocalQueuesToBeActive(ctx context.Context, c client.Client, lqs ...*kueue.LocalQueue) {
 	readLq := &kueue.LocalQueue{}
 	gomega.EventuallyWithOffset(1, func(g gomega.Gomega) {